@@ -44,9 +44,12 @@ type Routes struct {
 	ChannelsForTeam          *mux.Router // 'api/v4/teams/{team_id:[A-Za-z0-9]+}/channels'
 	ChannelMembers           *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/members'
 	ChannelMember            *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/members/{user_id:[A-Za-z0-9]+}'
+	ChannelMembersDrift      *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/members/drift'
 	ChannelMembersForUser    *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/teams/{team_id:[A-Za-z0-9]+}/channels/members'
 	ChannelModerations       *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/moderations'
 	ChannelCategories        *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/teams/{team_id:[A-Za-z0-9]+}/channels/categories'
+	ChannelBookmarks         *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks'
+	ChannelBookmark          *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks/{bookmark_id:[A-Za-z0-9]+}'
 
 	Posts           *mux.Router // 'api/v4/posts'
 	Post            *mux.Router // 'api/v4/posts/{post_id:[A-Za-z0-9]+}'
@@ -54,6 +57,12 @@ type Routes struct {
 	PostsForUser    *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/posts'
 	PostForUser     *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/posts/{post_id:[A-Za-z0-9]+}'
 
+	SavedSearches *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_searches'
+	SavedSearch   *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_searches/{saved_search_id:[A-Za-z0-9]+}'
+
+	SavedPosts *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_posts'
+	SavedPost  *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/saved_posts/{post_id:[A-Za-z0-9]+}'
+
 	Files *mux.Router // 'api/v4/files'
 	File  *mux.Router // 'api/v4/files/{file_id:[A-Za-z0-9]+}'
 
@@ -105,8 +114,9 @@ type Routes struct {
 
 	Reactions *mux.Router // 'api/v4/reactions'
 
-	Roles   *mux.Router // 'api/v4/roles'
-	Schemes *mux.Router // 'api/v4/schemes'
+	Roles       *mux.Router // 'api/v4/roles'
+	Schemes     *mux.Router // 'api/v4/schemes'
+	Permissions *mux.Router // 'api/v4/permissions'
 
 	Emojis      *mux.Router // 'api/v4/emoji'
 	Emoji       *mux.Router // 'api/v4/emoji/{emoji_id:[A-Za-z0-9]+}'
@@ -158,10 +168,14 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.BaseRoutes.ChannelByNameForTeamName = api.BaseRoutes.TeamByName.PathPrefix("/channels/name/{channel_name:[A-Za-z0-9_-]+}").Subrouter()
 	api.BaseRoutes.ChannelsForTeam = api.BaseRoutes.Team.PathPrefix("/channels").Subrouter()
 	api.BaseRoutes.ChannelMembers = api.BaseRoutes.Channel.PathPrefix("/members").Subrouter()
+	// Registered before ChannelMember, whose user id would otherwise match "drift"
+	api.BaseRoutes.ChannelMembersDrift = api.BaseRoutes.ChannelMembers.PathPrefix("/drift").Subrouter()
 	api.BaseRoutes.ChannelMember = api.BaseRoutes.ChannelMembers.PathPrefix("/{user_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.ChannelMembersForUser = api.BaseRoutes.User.PathPrefix("/teams/{team_id:[A-Za-z0-9]+}/channels/members").Subrouter()
 	api.BaseRoutes.ChannelModerations = api.BaseRoutes.Channel.PathPrefix("/moderations").Subrouter()
 	api.BaseRoutes.ChannelCategories = api.BaseRoutes.User.PathPrefix("/teams/{team_id:[A-Za-z0-9]+}/channels/categories").Subrouter()
+	api.BaseRoutes.ChannelBookmarks = api.BaseRoutes.Channel.PathPrefix("/bookmarks").Subrouter()
+	api.BaseRoutes.ChannelBookmark = api.BaseRoutes.ChannelBookmarks.PathPrefix("/{bookmark_id:[A-Za-z0-9]+}").Subrouter()
 
 	api.BaseRoutes.Posts = api.BaseRoutes.ApiRoot.PathPrefix("/posts").Subrouter()
 	api.BaseRoutes.Post = api.BaseRoutes.Posts.PathPrefix("/{post_id:[A-Za-z0-9]+}").Subrouter()
@@ -169,6 +183,12 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.BaseRoutes.PostsForUser = api.BaseRoutes.User.PathPrefix("/posts").Subrouter()
 	api.BaseRoutes.PostForUser = api.BaseRoutes.PostsForUser.PathPrefix("/{post_id:[A-Za-z0-9]+}").Subrouter()
 
+	api.BaseRoutes.SavedSearches = api.BaseRoutes.User.PathPrefix("/saved_searches").Subrouter()
+	api.BaseRoutes.SavedSearch = api.BaseRoutes.SavedSearches.PathPrefix("/{saved_search_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.SavedPosts = api.BaseRoutes.User.PathPrefix("/saved_posts").Subrouter()
+	api.BaseRoutes.SavedPost = api.BaseRoutes.SavedPosts.PathPrefix("/{post_id:[A-Za-z0-9]+}").Subrouter()
+
 	api.BaseRoutes.Files = api.BaseRoutes.ApiRoot.PathPrefix("/files").Subrouter()
 	api.BaseRoutes.File = api.BaseRoutes.Files.PathPrefix("/{file_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.PublicFile = api.BaseRoutes.Root.PathPrefix("/files/{file_id:[A-Za-z0-9]+}/public").Subrouter()
@@ -215,6 +235,7 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 
 	api.BaseRoutes.Roles = api.BaseRoutes.ApiRoot.PathPrefix("/roles").Subrouter()
 	api.BaseRoutes.Schemes = api.BaseRoutes.ApiRoot.PathPrefix("/schemes").Subrouter()
+	api.BaseRoutes.Permissions = api.BaseRoutes.ApiRoot.PathPrefix("/permissions").Subrouter()
 
 	api.BaseRoutes.Image = api.BaseRoutes.ApiRoot.PathPrefix("/image").Subrouter()
 
@@ -225,7 +246,11 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.InitBot()
 	api.InitTeam()
 	api.InitChannel()
+	api.InitChannelBookmark()
 	api.InitPost()
+	api.InitSavedSearch()
+	api.InitSavedPost()
+	api.InitPostShareToken()
 	api.InitFile()
 	api.InitSystem()
 	api.InitLicense()
@@ -251,6 +276,7 @@ func Init(configservice configservice.ConfigService, globalOptionsFunc app.AppOp
 	api.InitPlugin()
 	api.InitRole()
 	api.InitScheme()
+	api.InitPermission()
 	api.InitImage()
 	api.InitTermsOfService()
 	api.InitGroup()
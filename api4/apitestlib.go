@@ -78,7 +78,11 @@ func setupTestHelper(dbStore store.Store, searchEngine *searchengine.Broker, ent
 		panic("failed to initialize memory store: " + err.Error())
 	}
 
-	config := memoryStore.Get()
+	return setupTestHelperWithConfigStore(memoryStore, tempWorkspace, dbStore, searchEngine, enterprise, includeCache, updateConfig)
+}
+
+func setupTestHelperWithConfigStore(configStore config.Store, tempWorkspace string, dbStore store.Store, searchEngine *searchengine.Broker, enterprise bool, includeCache bool, updateConfig func(*model.Config)) *TestHelper {
+	config := configStore.Get()
 	*config.PluginSettings.Directory = filepath.Join(tempWorkspace, "plugins")
 	*config.PluginSettings.ClientDirectory = filepath.Join(tempWorkspace, "webapp")
 	config.ServiceSettings.EnableLocalMode = model.NewBool(true)
@@ -86,10 +90,10 @@ func setupTestHelper(dbStore store.Store, searchEngine *searchengine.Broker, ent
 	if updateConfig != nil {
 		updateConfig(config)
 	}
-	memoryStore.Set(config)
+	configStore.Set(config)
 
 	var options []app.Option
-	options = append(options, app.ConfigStore(memoryStore))
+	options = append(options, app.ConfigStore(configStore))
 	options = append(options, app.StoreOverride(dbStore))
 
 	s, err := app.NewServer(options...)
@@ -104,7 +108,7 @@ func setupTestHelper(dbStore store.Store, searchEngine *searchengine.Broker, ent
 	th := &TestHelper{
 		App:               app.New(app.ServerConnector(s)),
 		Server:            s,
-		ConfigStore:       memoryStore,
+		ConfigStore:       configStore,
 		IncludeCacheLayer: includeCache,
 	}
 
@@ -211,6 +215,48 @@ func Setup(tb testing.TB) *TestHelper {
 	return th
 }
 
+// SetupWithDatabaseConfigStore is like Setup, but keeps the configuration in the test database
+// for the tests needing its history.
+func SetupWithDatabaseConfigStore(tb testing.TB) *TestHelper {
+	if testing.Short() {
+		tb.SkipNow()
+	}
+
+	if mainHelper == nil {
+		tb.SkipNow()
+	}
+
+	tempWorkspace, err := ioutil.TempDir("", "apptest")
+	if err != nil {
+		panic(err)
+	}
+
+	sqlSettings := mainHelper.GetSQLSettings()
+	dsn := *sqlSettings.DataSource
+	if *sqlSettings.DriverName == model.DATABASE_DRIVER_MYSQL {
+		dsn = model.DATABASE_DRIVER_MYSQL + "://" + dsn
+	}
+	databaseStore, err := config.NewDatabaseStore(dsn)
+	if err != nil {
+		panic("failed to initialize database store: " + err.Error())
+	}
+
+	// Start from the defaults rather than the configuration left by a previous test.
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	if _, err = databaseStore.Set(cfg); err != nil {
+		panic("failed to reset database store: " + err.Error())
+	}
+
+	dbStore := mainHelper.GetStore()
+	dbStore.DropAllTables()
+	dbStore.MarkSystemRanUnitTests()
+	searchEngine := mainHelper.GetSearchEngine()
+	th := setupTestHelperWithConfigStore(databaseStore, tempWorkspace, dbStore, searchEngine, false, true, nil)
+	th.InitLogin()
+	return th
+}
+
 func SetupConfig(tb testing.TB, updateConfig func(cfg *model.Config)) *TestHelper {
 	if testing.Short() {
 		tb.SkipNow()
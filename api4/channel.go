@@ -27,6 +27,8 @@ func (api *API) InitChannel() {
 
 	api.BaseRoutes.ChannelsForTeam.Handle("", api.ApiSessionRequired(getPublicChannelsForTeam)).Methods("GET")
 	api.BaseRoutes.ChannelsForTeam.Handle("/deleted", api.ApiSessionRequired(getDeletedChannelsForTeam)).Methods("GET")
+	api.BaseRoutes.ChannelsForTeam.Handle("/recently_deleted", api.ApiSessionRequired(getRecentlyDeletedChannelsForTeam)).Methods("GET")
+	api.BaseRoutes.ChannelsForTeam.Handle("/activity", api.ApiSessionRequired(getChannelsWithActivityForTeam)).Methods("GET")
 	api.BaseRoutes.ChannelsForTeam.Handle("/private", api.ApiSessionRequired(getPrivateChannelsForTeam)).Methods("GET")
 	api.BaseRoutes.ChannelsForTeam.Handle("/ids", api.ApiSessionRequired(getPublicChannelsByIdsForTeam)).Methods("POST")
 	api.BaseRoutes.ChannelsForTeam.Handle("/search", api.ApiSessionRequiredDisableWhenBusy(searchChannelsForTeam)).Methods("POST")
@@ -49,6 +51,7 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("/patch", api.ApiSessionRequired(patchChannel)).Methods("PUT")
 	api.BaseRoutes.Channel.Handle("/convert", api.ApiSessionRequired(convertChannelToPrivate)).Methods("POST")
 	api.BaseRoutes.Channel.Handle("/privacy", api.ApiSessionRequired(updateChannelPrivacy)).Methods("PUT")
+	api.BaseRoutes.Channel.Handle("/readonly", api.ApiSessionRequired(updateChannelReadOnly)).Methods("PUT")
 	api.BaseRoutes.Channel.Handle("/restore", api.ApiSessionRequired(restoreChannel)).Methods("POST")
 	api.BaseRoutes.Channel.Handle("", api.ApiSessionRequired(deleteChannel)).Methods("DELETE")
 	api.BaseRoutes.Channel.Handle("/stats", api.ApiSessionRequired(getChannelStats)).Methods("GET")
@@ -57,14 +60,17 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("/members_minus_group_members", api.ApiSessionRequired(channelMembersMinusGroupMembers)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/move", api.ApiSessionRequired(moveChannel)).Methods("POST")
 	api.BaseRoutes.Channel.Handle("/member_counts_by_group", api.ApiSessionRequired(channelMemberCountsByGroup)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/member_history", api.ApiSessionRequired(getChannelMemberHistory)).Methods("GET")
 
 	api.BaseRoutes.ChannelForUser.Handle("/unread", api.ApiSessionRequired(getChannelUnread)).Methods("GET")
+	api.BaseRoutes.ChannelForUser.Handle("/last_viewed", api.ApiSessionRequired(getChannelLastViewed)).Methods("GET")
 
 	api.BaseRoutes.ChannelByName.Handle("", api.ApiSessionRequired(getChannelByName)).Methods("GET")
 	api.BaseRoutes.ChannelByNameForTeamName.Handle("", api.ApiSessionRequired(getChannelByNameForTeamName)).Methods("GET")
 
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(getChannelMembers)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("/ids", api.ApiSessionRequired(getChannelMembersByIds)).Methods("POST")
+	api.BaseRoutes.ChannelMembersDrift.Handle("", api.ApiSessionRequired(getChannelMembersDrift)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(addChannelMember)).Methods("POST")
 	api.BaseRoutes.ChannelMembersForUser.Handle("", api.ApiSessionRequired(getChannelMembersForUser)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.ApiSessionRequired(getChannelMember)).Methods("GET")
@@ -72,6 +78,7 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.ChannelMember.Handle("/roles", api.ApiSessionRequired(updateChannelMemberRoles)).Methods("PUT")
 	api.BaseRoutes.ChannelMember.Handle("/schemeRoles", api.ApiSessionRequired(updateChannelMemberSchemeRoles)).Methods("PUT")
 	api.BaseRoutes.ChannelMember.Handle("/notify_props", api.ApiSessionRequired(updateChannelMemberNotifyProps)).Methods("PUT")
+	api.BaseRoutes.ChannelMember.Handle("/manually_managed", api.ApiSessionRequired(updateChannelMemberManuallyManaged)).Methods("PUT")
 
 	api.BaseRoutes.ChannelModerations.Handle("", api.ApiSessionRequired(getChannelModerations)).Methods("GET")
 	api.BaseRoutes.ChannelModerations.Handle("/patch", api.ApiSessionRequired(patchChannelModerations)).Methods("PUT")
@@ -98,6 +105,11 @@ func createChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if (channel.SuppressJoinLeaveMessages != nil || channel.ExperimentalHideChannelFromPublicSearch != nil || channel.DisableFileAttachments != nil) && !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
 	sc, err := c.App.CreateChannelWithUser(channel, c.App.Session().UserId)
 	if err != nil {
 		c.Err = err
@@ -203,6 +215,38 @@ func updateChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		oldChannel.GroupConstrained = channel.GroupConstrained
 	}
 
+	if channel.SuppressJoinLeaveMessages != nil && channel.IsJoinLeaveMessagesSuppressed() != oldChannel.IsJoinLeaveMessagesSuppressed() {
+		if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+			return
+		}
+		oldChannel.SuppressJoinLeaveMessages = channel.SuppressJoinLeaveMessages
+	}
+
+	if channel.ExperimentalHideChannelFromPublicSearch != nil && channel.IsHiddenFromPublicSearch() != oldChannel.IsHiddenFromPublicSearch() {
+		if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+			return
+		}
+		oldChannel.ExperimentalHideChannelFromPublicSearch = channel.ExperimentalHideChannelFromPublicSearch
+	}
+
+	if channel.DisableFileAttachments != nil && channel.IsFileAttachmentsDisabled() != oldChannel.IsFileAttachmentsDisabled() {
+		if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+			return
+		}
+		oldChannel.DisableFileAttachments = channel.DisableFileAttachments
+	}
+
+	if channel.ExcludeFromAutoArchive != nil && channel.IsExcludedFromAutoArchive() != oldChannel.IsExcludedFromAutoArchive() {
+		if !c.App.SessionHasPermissionToChannel(*c.App.Session(), oldChannel.Id, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+			return
+		}
+		oldChannel.ExcludeFromAutoArchive = channel.ExcludeFromAutoArchive
+	}
+
 	updatedChannel, err := c.App.UpdateChannel(oldChannel)
 	if err != nil {
 		c.Err = err
@@ -376,6 +420,16 @@ func patchChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if (patch.SuppressJoinLeaveMessages != nil || patch.ExperimentalHideChannelFromPublicSearch != nil || patch.DisableFileAttachments != nil) && !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if patch.ExcludeFromAutoArchive != nil && !c.App.SessionHasPermissionToChannel(*c.App.Session(), oldChannel.Id, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
 	rchannel, err := c.App.PatchChannel(oldChannel, patch, c.App.Session().UserId)
 	if err != nil {
 		c.Err = err
@@ -601,6 +655,8 @@ func getChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	c.App.FillInChannelReadOnly(channel)
+
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -629,36 +685,48 @@ func getChannelUnread(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(channelUnread.ToJson()))
 }
 
-func getChannelStats(c *Context, w http.ResponseWriter, r *http.Request) {
-	c.RequireChannelId()
+func getChannelLastViewed(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
 	if c.Err != nil {
 		return
 	}
 
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
 	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
 		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
 		return
 	}
 
-	memberCount, err := c.App.GetChannelMemberCount(c.Params.ChannelId)
+	lastViewed, err := c.App.GetChannelLastViewed(c.Params.ChannelId, c.Params.UserId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	guestCount, err := c.App.GetChannelGuestCount(c.Params.ChannelId)
-	if err != nil {
-		c.Err = err
+	w.Write([]byte(lastViewed.ToJson()))
+}
+
+func getChannelStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
 		return
 	}
 
-	pinnedPostCount, err := c.App.GetChannelPinnedPostCount(c.Params.ChannelId)
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	stats, err := c.App.GetChannelStats(c.Params.ChannelId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	stats := model.ChannelStats{ChannelId: c.Params.ChannelId, MemberCount: memberCount, GuestCount: guestCount, PinnedPostCount: pinnedPostCount}
 	w.Write([]byte(stats.ToJson()))
 }
 
@@ -737,7 +805,13 @@ func getPublicChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	channels, err := c.App.GetPublicChannelsForTeam(c.Params.TeamId, c.Params.Page*c.Params.PerPage, c.Params.PerPage)
+	includeHidden, _ := strconv.ParseBool(r.URL.Query().Get("include_hidden"))
+	if includeHidden && !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	channels, err := c.App.GetPublicChannelsForTeam(c.Params.TeamId, c.Params.Page*c.Params.PerPage, c.Params.PerPage, includeHidden)
 	if err != nil {
 		c.Err = err
 		return
@@ -758,7 +832,32 @@ func getDeletedChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	channels, err := c.App.GetDeletedChannels(c.Params.TeamId, c.Params.Page*c.Params.PerPage, c.Params.PerPage, c.App.Session().UserId)
+	var channels *model.ChannelList
+	var err *model.AppError
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		since, parseErr := strconv.ParseInt(sinceString, 10, 64)
+		if parseErr != nil || since < 0 {
+			c.SetInvalidParam("since")
+			return
+		}
+
+		userId := r.URL.Query().Get("user_id")
+		if userId == "" {
+			userId = c.App.Session().UserId
+		} else if !model.IsValidId(userId) {
+			c.SetInvalidParam("user_id")
+			return
+		}
+
+		if !c.App.SessionHasPermissionToUser(*c.App.Session(), userId) {
+			c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+			return
+		}
+
+		channels, err = c.App.GetDeletedChannelsForUserSince(c.Params.TeamId, userId, since)
+	} else {
+		channels, err = c.App.GetDeletedChannels(c.Params.TeamId, c.Params.Page*c.Params.PerPage, c.Params.PerPage, c.App.Session().UserId)
+	}
 	if err != nil {
 		c.Err = err
 		return
@@ -773,6 +872,65 @@ func getDeletedChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Reques
 	w.Write([]byte(channels.ToJson()))
 }
 
+func getRecentlyDeletedChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	channels, err := c.App.GetRecentlyDeletedChannels(c.Params.TeamId, c.Params.Page*c.Params.PerPage, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	channelList := model.ChannelList(channels)
+	err = c.App.FillInChannelsProps(&channelList)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(channelList.ToJson()))
+}
+
+func getChannelsWithActivityForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	opts := model.ChannelActivityOpts{
+		IncludeDeleted: c.Params.IncludeDeleted,
+	}
+	switch r.URL.Query().Get("sort") {
+	case "", "display_name":
+	case "activity":
+		opts.SortByActivity = true
+	default:
+		c.SetInvalidUrlParam("sort")
+		return
+	}
+
+	channels, err := c.App.GetTeamChannelsWithActivity(c.Params.TeamId, c.Params.Page, c.Params.PerPage, opts)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelsWithActivityToJson(channels)))
+}
+
 func getPrivateChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId()
 	if c.Err != nil {
@@ -1101,6 +1259,8 @@ func getChannelByName(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	c.App.FillInChannelReadOnly(channel)
+
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -1128,6 +1288,8 @@ func getChannelByNameForTeamName(c *Context, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	c.App.FillInChannelReadOnly(channel)
+
 	w.Write([]byte(channel.ToJson()))
 }
 
@@ -1148,9 +1310,36 @@ func getChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	members.SetIsMuted()
 	w.Write([]byte(members.ToJson()))
 }
 
+func getChannelMembersDrift(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	drift, err := c.App.GetChannelMembersDrift(channel)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(drift.ToJson()))
+}
+
 func getChannelMembersTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -1194,6 +1383,7 @@ func getChannelMembersByIds(c *Context, w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	members.SetIsMuted()
 	w.Write([]byte(members.ToJson()))
 }
 
@@ -1214,6 +1404,7 @@ func getChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	member.SetIsMuted()
 	w.Write([]byte(member.ToJson()))
 }
 
@@ -1239,6 +1430,7 @@ func getChannelMembersForUser(c *Context, w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	members.SetIsMuted()
 	w.Write([]byte(members.ToJson()))
 }
 
@@ -1354,6 +1546,42 @@ func updateChannelMemberSchemeRoles(c *Context, w http.ResponseWriter, r *http.R
 	ReturnStatusOK(w)
 }
 
+func updateChannelMemberManuallyManaged(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.StringInterfaceFromJson(r.Body)
+
+	manuallyManaged, ok := props["manually_managed"].(bool)
+	if !ok {
+		c.SetInvalidParam("manually_managed")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelMemberManuallyManaged", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("manually_managed", manuallyManaged)
+
+	// Like the drift it affects, this is left to system admins.
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if _, err := c.App.UpdateChannelMemberManuallyManaged(c.Params.ChannelId, c.Params.UserId, manuallyManaged); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
 func updateChannelMemberNotifyProps(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId().RequireUserId()
 	if c.Err != nil {
@@ -1791,6 +2019,52 @@ func patchChannelModerations(c *Context, w http.ResponseWriter, r *http.Request)
 	w.Write(b)
 }
 
+func updateChannelReadOnly(c *Context, w http.ResponseWriter, r *http.Request) {
+	if c.App.Srv().License() == nil {
+		c.Err = model.NewAppError("Api4.updateChannelReadOnly", "api.channel.patch_channel_moderations.license.error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.StringInterfaceFromJson(r.Body)
+	enabled, ok := props["enabled"].(bool)
+	if !ok {
+		c.SetInvalidParam("enabled")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelReadOnly", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("enabled", enabled)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_MANAGE_CHANNEL_READ_ONLY) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_READ_ONLY)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddMeta("channel", channel)
+
+	channel, err = c.App.SetChannelReadOnly(channel, c.App.Session().UserId, enabled)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("name=" + channel.Name)
+
+	w.Write([]byte(channel.ToJson()))
+}
+
 func moveChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -2122,3 +2396,43 @@ func deleteCategoryForTeamForUser(c *Context, w http.ResponseWriter, r *http.Req
 	auditRec.Success()
 	ReturnStatusOK(w)
 }
+
+func getChannelMemberHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var parseErr error
+		since, parseErr = strconv.ParseInt(sinceString, 10, 64)
+		if parseErr != nil || since < 0 {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	until := model.GetMillis()
+	if untilString := r.URL.Query().Get("until"); untilString != "" {
+		var parseErr error
+		until, parseErr = strconv.ParseInt(untilString, 10, 64)
+		if parseErr != nil || until < since {
+			c.SetInvalidParam("until")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	histories, err := c.App.GetChannelMemberHistory(c.Params.ChannelId, since, until, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelMemberHistoryResultListToJson(histories)))
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func (api *API) InitChannelBookmark() {
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.ApiSessionRequired(getChannelBookmarks)).Methods("GET")
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.ApiSessionRequired(createChannelBookmark)).Methods("POST")
+	api.BaseRoutes.ChannelBookmarks.Handle("/order", api.ApiSessionRequired(updateChannelBookmarksOrder)).Methods("PUT")
+	api.BaseRoutes.ChannelBookmark.Handle("", api.ApiSessionRequired(updateChannelBookmark)).Methods("PUT")
+	api.BaseRoutes.ChannelBookmark.Handle("/patch", api.ApiSessionRequired(patchChannelBookmark)).Methods("PUT")
+	api.BaseRoutes.ChannelBookmark.Handle("", api.ApiSessionRequired(deleteChannelBookmark)).Methods("DELETE")
+}
+
+func getChannelBookmarks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	bookmarks, err := c.App.GetChannelBookmarks(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelBookmarksToJson(bookmarks)))
+}
+
+func createChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	bookmark := model.ChannelBookmarkFromJson(r.Body)
+	if bookmark == nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_ADD_BOOKMARK) {
+		c.SetPermissionError(model.PERMISSION_ADD_BOOKMARK)
+		return
+	}
+
+	bookmark.ChannelId = c.Params.ChannelId
+	bookmark.OwnerId = c.App.Session().UserId
+
+	rbookmark, err := c.App.CreateChannelBookmark(bookmark)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("bookmark", rbookmark)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rbookmark.ToJson()))
+}
+
+func updateChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	update := model.ChannelBookmarkFromJson(r.Body)
+	if update == nil || (update.Id != "" && update.Id != c.Params.BookmarkId) {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_ADD_BOOKMARK) {
+		c.SetPermissionError(model.PERMISSION_ADD_BOOKMARK)
+		return
+	}
+
+	bookmark, err := c.App.GetChannelBookmark(c.Params.BookmarkId, false)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if bookmark.ChannelId != c.Params.ChannelId {
+		c.SetInvalidUrlParam("bookmark_id")
+		return
+	}
+
+	if !canManageChannelBookmark(c, bookmark) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	rbookmark, err := c.App.UpdateChannelBookmark(bookmark, update, c.App.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("bookmark", rbookmark)
+
+	w.Write([]byte(rbookmark.ToJson()))
+}
+
+func patchChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := model.ChannelBookmarkPatchFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_ADD_BOOKMARK) {
+		c.SetPermissionError(model.PERMISSION_ADD_BOOKMARK)
+		return
+	}
+
+	bookmark, err := c.App.GetChannelBookmark(c.Params.BookmarkId, false)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if bookmark.ChannelId != c.Params.ChannelId {
+		c.SetInvalidUrlParam("bookmark_id")
+		return
+	}
+
+	if !canManageChannelBookmark(c, bookmark) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	rbookmark, err := c.App.PatchChannelBookmark(bookmark, patch, c.App.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("bookmark", rbookmark)
+
+	w.Write([]byte(rbookmark.ToJson()))
+}
+
+func deleteChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_ADD_BOOKMARK) {
+		c.SetPermissionError(model.PERMISSION_ADD_BOOKMARK)
+		return
+	}
+
+	bookmark, err := c.App.GetChannelBookmark(c.Params.BookmarkId, false)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if bookmark.ChannelId != c.Params.ChannelId {
+		c.SetInvalidUrlParam("bookmark_id")
+		return
+	}
+
+	if !canManageChannelBookmark(c, bookmark) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	if err := c.App.DeleteChannelBookmark(bookmark); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+// canManageChannelBookmark checks that the session owns the bookmark or administers its channel.
+func canManageChannelBookmark(c *Context, bookmark *model.ChannelBookmark) bool {
+	if bookmark.OwnerId == c.App.Session().UserId {
+		return true
+	}
+
+	return c.App.SessionHasPermissionToChannel(*c.App.Session(), bookmark.ChannelId, model.PERMISSION_MANAGE_CHANNEL_ROLES)
+}
+
+func updateChannelBookmarksOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	bookmarkIds := model.ArrayFromJson(r.Body)
+	for _, bookmarkId := range bookmarkIds {
+		if !model.IsValidId(bookmarkId) {
+			c.SetInvalidParam("bookmark_ids")
+			return
+		}
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelBookmarksOrder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), c.Params.ChannelId, model.PERMISSION_ADD_BOOKMARK) {
+		c.SetPermissionError(model.PERMISSION_ADD_BOOKMARK)
+		return
+	}
+
+	bookmarks, err := c.App.UpdateChannelBookmarkSortOrder(c.Params.ChannelId, bookmarkIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(model.ChannelBookmarksToJson(bookmarks)))
+}
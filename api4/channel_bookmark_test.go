@@ -0,0 +1,300 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestChannelBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channelId := th.BasicChannel.Id
+
+	link := &model.ChannelBookmark{
+		ChannelId:   channelId,
+		DisplayName: "Runbook",
+		LinkUrl:     "https://example.com/runbook",
+		Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+	}
+
+	t.Run("create, list and delete", func(t *testing.T) {
+		WebSocketClient, err := th.CreateWebSocketClient()
+		require.Nil(t, err)
+		WebSocketClient.Listen()
+		defer WebSocketClient.Close()
+
+		created, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, created.OwnerId)
+
+		timeout := time.After(5 * time.Second)
+		received := false
+		for !received {
+			select {
+			case event := <-WebSocketClient.EventChannel:
+				if event.EventType() == model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_CREATED {
+					assert.Equal(t, channelId, event.GetBroadcast().ChannelId)
+					received = true
+				}
+			case <-timeout:
+				require.Fail(t, "timed out waiting for bookmark created event")
+			}
+		}
+
+		bookmarks, resp := Client.GetChannelBookmarks(channelId)
+		CheckNoError(t, resp)
+		require.Len(t, bookmarks, 1)
+		assert.Equal(t, created.Id, bookmarks[0].Id)
+
+		ok, resp := Client.DeleteChannelBookmark(channelId, created.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		bookmarks, resp = Client.GetChannelBookmarks(channelId)
+		CheckNoError(t, resp)
+		require.Empty(t, bookmarks)
+
+		_, resp = Client.DeleteChannelBookmark(channelId, created.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		created, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, created.Id)
+
+		patched, resp := Client.PatchChannelBookmark(channelId, created.Id, &model.ChannelBookmarkPatch{
+			DisplayName: model.NewString("Playbook"),
+			Emoji:       model.NewString("book"),
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, "Playbook", patched.DisplayName)
+		assert.Equal(t, "book", patched.Emoji)
+		assert.Equal(t, link.LinkUrl, patched.LinkUrl)
+
+		_, resp = Client.PatchChannelBookmark(channelId, created.Id, &model.ChannelBookmarkPatch{
+			LinkUrl: model.NewString("not a url"),
+		})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.PatchChannelBookmark(th.BasicChannel2.Id, created.Id, &model.ChannelBookmarkPatch{
+			DisplayName: model.NewString("Elsewhere"),
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		created, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, created.Id)
+
+		updated, resp := Client.UpdateChannelBookmark(&model.ChannelBookmark{
+			Id:          created.Id,
+			ChannelId:   channelId,
+			DisplayName: "Playbook",
+			LinkUrl:     "https://example.com/playbook",
+			Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, created.Id, updated.Id)
+		assert.Equal(t, "Playbook", updated.DisplayName)
+		assert.Equal(t, "https://example.com/playbook", updated.LinkUrl)
+		assert.Empty(t, updated.Emoji)
+		assert.Equal(t, created.SortOrder, updated.SortOrder)
+		assert.Equal(t, created.CreateAt, updated.CreateAt)
+
+		_, resp = Client.UpdateChannelBookmark(&model.ChannelBookmark{
+			Id:          created.Id,
+			ChannelId:   channelId,
+			DisplayName: "Playbook",
+			LinkUrl:     "not a url",
+			Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+		})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.UpdateChannelBookmark(&model.ChannelBookmark{
+			Id:          created.Id,
+			ChannelId:   th.BasicChannel2.Id,
+			DisplayName: "Elsewhere",
+			LinkUrl:     link.LinkUrl,
+			Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+		})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.UpdateChannelBookmark(&model.ChannelBookmark{
+			Id:          model.NewId(),
+			ChannelId:   channelId,
+			DisplayName: "Missing",
+			LinkUrl:     link.LinkUrl,
+			Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+		})
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("file bookmark", func(t *testing.T) {
+		fileResp, resp := Client.UploadFile([]byte("data"), channelId, "runbook.txt")
+		CheckNoError(t, resp)
+		fileId := fileResp.FileInfos[0].Id
+
+		created, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   channelId,
+			DisplayName: "Runbook file",
+			FileId:      fileId,
+			Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, fileId, created.FileId)
+
+		// Other members of the channel can read the bookmarked file.
+		Client2 := th.CreateClient()
+		th.LoginBasic2WithClient(Client2)
+		_, resp = Client2.GetFile(fileId)
+		CheckNoError(t, resp)
+
+		_, resp = Client2.GetFileInfo(fileId)
+		CheckNoError(t, resp)
+
+		ok, resp := Client.DeleteChannelBookmark(channelId, created.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		_, resp = Client2.GetFile(fileId)
+		CheckForbiddenStatus(t, resp)
+
+		// A file uploaded by someone else cannot be bookmarked.
+		otherFileResp, resp := th.SystemAdminClient.UploadFile([]byte("data"), channelId, "other.txt")
+		CheckNoError(t, resp)
+
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   channelId,
+			DisplayName: "Other file",
+			FileId:      otherFileResp.FileInfos[0].Id,
+			Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		first, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, first.Id)
+		second, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, second.Id)
+
+		bookmarks, resp := Client.UpdateChannelBookmarksOrder(channelId, []string{second.Id, first.Id})
+		CheckNoError(t, resp)
+		require.Len(t, bookmarks, 2)
+		assert.Equal(t, second.Id, bookmarks[0].Id)
+		assert.Equal(t, first.Id, bookmarks[1].Id)
+
+		_, resp = Client.UpdateChannelBookmarksOrder(channelId, []string{second.Id})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("without the add_bookmark permission", func(t *testing.T) {
+		created, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, created.Id)
+
+		defer th.RestoreDefaultRolePermissions(th.SaveDefaultRolePermissions())
+		th.RemovePermissionFromRole(model.PERMISSION_ADD_BOOKMARK.Id, model.CHANNEL_USER_ROLE_ID)
+
+		_, resp = Client.CreateChannelBookmark(link)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.PatchChannelBookmark(channelId, created.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("Playbook")})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.UpdateChannelBookmark(created)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.UpdateChannelBookmarksOrder(channelId, []string{created.Id})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.DeleteChannelBookmark(channelId, created.Id)
+		CheckForbiddenStatus(t, resp)
+
+		// Reading bookmarks only requires access to the channel.
+		bookmarks, resp := Client.GetChannelBookmarks(channelId)
+		CheckNoError(t, resp)
+		require.Len(t, bookmarks, 1)
+	})
+
+	t.Run("someone else's bookmark", func(t *testing.T) {
+		created, resp := Client.CreateChannelBookmark(link)
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, created.Id)
+
+		Client2 := th.CreateClient()
+		th.LoginBasic2WithClient(Client2)
+
+		_, resp = Client2.PatchChannelBookmark(channelId, created.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("Playbook")})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client2.UpdateChannelBookmark(created)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client2.DeleteChannelBookmark(channelId, created.Id)
+		CheckForbiddenStatus(t, resp)
+
+		// Channel admins can manage every bookmark of the channel.
+		th.MakeUserChannelAdmin(th.BasicUser2, th.BasicChannel)
+
+		patched, resp := Client2.PatchChannelBookmark(channelId, created.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("Playbook")})
+		CheckNoError(t, resp)
+		assert.Equal(t, th.BasicUser.Id, patched.OwnerId)
+
+		// The bookmark keeps its owner when an editor points it at their own file.
+		fileResp, resp := Client.UploadFile([]byte("data"), channelId, "runbook.txt")
+		CheckNoError(t, resp)
+		fileBookmark, resp := Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   channelId,
+			DisplayName: "Runbook file",
+			FileId:      fileResp.FileInfos[0].Id,
+			Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+		})
+		CheckNoError(t, resp)
+		defer Client.DeleteChannelBookmark(channelId, fileBookmark.Id)
+
+		otherFileResp, resp := Client2.UploadFile([]byte("data"), channelId, "playbook.txt")
+		CheckNoError(t, resp)
+
+		patched, resp = Client2.PatchChannelBookmark(channelId, fileBookmark.Id, &model.ChannelBookmarkPatch{
+			FileId: model.NewString(otherFileResp.FileInfos[0].Id),
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, th.BasicUser.Id, patched.OwnerId)
+		assert.Equal(t, otherFileResp.FileInfos[0].Id, patched.FileId)
+
+		ok, resp := Client2.DeleteChannelBookmark(channelId, created.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+	})
+
+	t.Run("non member", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+
+		_, resp := Client.GetChannelBookmarks(privateChannel.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.CreateChannelBookmark(&model.ChannelBookmark{
+			ChannelId:   privateChannel.Id,
+			DisplayName: "Runbook",
+			LinkUrl:     "https://example.com/runbook",
+			Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+		})
+		CheckForbiddenStatus(t, resp)
+	})
+}
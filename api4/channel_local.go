@@ -42,7 +42,7 @@ func localCreateChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	defer c.LogAuditRec(auditRec)
 	auditRec.AddMeta("channel", channel)
 
-	sc, err := c.App.CreateChannel(channel, false)
+	sc, err := c.App.CreateChannelBypassingThrottle(channel, false)
 	if err != nil {
 		c.Err = err
 		return
@@ -271,6 +271,42 @@ func TestPatchChannel(t *testing.T) {
 	require.Equal(t, *rchannel.GroupConstrained, *patch.GroupConstrained, "GroupConstrained flags do not match")
 	patch.GroupConstrained = nil
 
+	// Only system admins can suppress join/leave messages
+	patch.SuppressJoinLeaveMessages = model.NewBool(true)
+	_, resp = Client.PatchChannel(th.BasicChannel.Id, patch)
+	CheckForbiddenStatus(t, resp)
+
+	rchannel, resp = th.SystemAdminClient.PatchChannel(th.BasicChannel.Id, patch)
+	CheckNoError(t, resp)
+	require.True(t, rchannel.IsJoinLeaveMessagesSuppressed())
+	patch.SuppressJoinLeaveMessages = nil
+
+	// Only system admins can disable file attachments
+	patch.DisableFileAttachments = model.NewBool(true)
+	_, resp = Client.PatchChannel(th.BasicChannel.Id, patch)
+	CheckForbiddenStatus(t, resp)
+
+	rchannel, resp = th.SystemAdminClient.PatchChannel(th.BasicChannel.Id, patch)
+	CheckNoError(t, resp)
+	require.True(t, rchannel.IsFileAttachmentsDisabled())
+	patch.DisableFileAttachments = model.NewBool(false)
+	_, resp = th.SystemAdminClient.PatchChannel(th.BasicChannel.Id, patch)
+	CheckNoError(t, resp)
+	patch.DisableFileAttachments = nil
+
+	// Only channel admins can exclude a channel from automatic archiving
+	_, appErr := th.App.UpdateChannelMemberSchemeRoles(th.BasicChannel.Id, th.BasicUser.Id, false, true, false)
+	require.Nil(t, appErr)
+	patch.ExcludeFromAutoArchive = model.NewBool(true)
+	_, resp = Client.PatchChannel(th.BasicChannel.Id, patch)
+	CheckForbiddenStatus(t, resp)
+
+	th.MakeUserChannelAdmin(th.BasicUser, th.BasicChannel)
+	rchannel, resp = Client.PatchChannel(th.BasicChannel.Id, patch)
+	CheckNoError(t, resp)
+	require.True(t, rchannel.IsExcludedFromAutoArchive())
+	patch.ExcludeFromAutoArchive = nil
+
 	_, resp = Client.PatchChannel("junk", patch)
 	CheckBadRequestStatus(t, resp)
 
@@ -737,6 +773,138 @@ func TestGetDeletedChannelsForTeam(t *testing.T) {
 	require.Len(t, channels, 1, "should be one channel per page")
 }
 
+func TestGetDeletedChannelsForTeamForUserSince(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	team := th.BasicTeam
+
+	publicChannel := th.CreatePublicChannel()
+	privateChannel := th.CreatePrivateChannel()
+	otherChannel := th.CreatePublicChannel()
+	_, resp := th.Client.RemoveUserFromChannel(otherChannel.Id, th.BasicUser.Id)
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.DeleteChannel(publicChannel.Id)
+	CheckNoError(t, resp)
+	_, resp = th.SystemAdminClient.DeleteChannel(otherChannel.Id)
+	CheckNoError(t, resp)
+
+	time.Sleep(10 * time.Millisecond)
+	since := model.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	_, resp = th.Client.DeleteChannel(privateChannel.Id)
+	CheckNoError(t, resp)
+
+	channels, resp := th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, th.BasicUser.Id, 0)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 2)
+	require.Equal(t, publicChannel.Id, channels[0].Id)
+	require.Equal(t, privateChannel.Id, channels[1].Id)
+
+	channels, resp = th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, th.BasicUser.Id, since)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 1)
+	require.Equal(t, privateChannel.Id, channels[0].Id)
+
+	channels, resp = th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, "", since)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 1, "should default to the session user")
+
+	_, resp = th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, th.BasicUser2.Id, 0)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, "junk", 0)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.Client.GetDeletedChannelsForTeamForUserSince(team.Id, th.BasicUser.Id, -1)
+	CheckBadRequestStatus(t, resp)
+
+	channels, resp = th.SystemAdminClient.GetDeletedChannelsForTeamForUserSince(team.Id, th.BasicUser.Id, since)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 1)
+}
+
+func TestGetRecentlyDeletedChannelsForTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	team := th.BasicTeam
+
+	_, resp := th.Client.GetRecentlyDeletedChannelsForTeam(team.Id, 0, 100)
+	CheckForbiddenStatus(t, resp)
+
+	publicChannel := th.CreatePublicChannel()
+	_, resp = th.Client.DeleteChannel(publicChannel.Id)
+	CheckNoError(t, resp)
+
+	time.Sleep(10 * time.Millisecond)
+
+	privateChannel := th.CreatePrivateChannel()
+	_, resp = th.Client.DeleteChannel(privateChannel.Id)
+	CheckNoError(t, resp)
+
+	channels, resp := th.SystemAdminClient.GetRecentlyDeletedChannelsForTeam(team.Id, 0, 100)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 2)
+	require.Equal(t, privateChannel.Id, channels[0].Id, "should list the most recently deleted channel first")
+	require.Equal(t, publicChannel.Id, channels[1].Id)
+
+	channels, resp = th.SystemAdminClient.GetRecentlyDeletedChannelsForTeam(team.Id, 1, 1)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 1)
+	require.Equal(t, publicChannel.Id, channels[0].Id)
+}
+
+func TestGetChannelsWithActivityForTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	team := th.BasicTeam
+
+	_, resp := th.Client.GetChannelsWithActivityForTeam(team.Id, 0, 100, false, "")
+	CheckForbiddenStatus(t, resp)
+
+	th.CreatePostWithClient(th.Client, th.BasicChannel2)
+
+	findChannel := func(channels []*model.ChannelWithActivity, channelId string) *model.ChannelWithActivity {
+		for _, channel := range channels {
+			if channel.Id == channelId {
+				return channel
+			}
+		}
+		return nil
+	}
+
+	t.Run("without archived channels", func(t *testing.T) {
+		channels, resp := th.SystemAdminClient.GetChannelsWithActivityForTeam(team.Id, 0, 100, false, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, findChannel(channels, th.BasicPrivateChannel.Id))
+		require.Nil(t, findChannel(channels, th.BasicDeletedChannel.Id))
+
+		channel := findChannel(channels, th.BasicChannel.Id)
+		require.NotNil(t, channel)
+		require.EqualValues(t, 2, channel.MemberCount)
+		require.NotZero(t, channel.LastPostAt)
+	})
+
+	t.Run("with archived channels", func(t *testing.T) {
+		channels, resp := th.SystemAdminClient.GetChannelsWithActivityForTeam(team.Id, 0, 100, true, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, findChannel(channels, th.BasicDeletedChannel.Id))
+	})
+
+	t.Run("most recently active first", func(t *testing.T) {
+		channels, resp := th.SystemAdminClient.GetChannelsWithActivityForTeam(team.Id, 0, 1, false, "activity")
+		CheckNoError(t, resp)
+		require.Len(t, channels, 1)
+		require.Equal(t, th.BasicChannel2.Id, channels[0].Id)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetChannelsWithActivityForTeam(team.Id, 0, 100, false, "members")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestGetPrivateChannelsForTeam(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -834,6 +1002,48 @@ func TestGetPublicChannelsForTeam(t *testing.T) {
 		_, resp = client.GetPublicChannelsForTeam(team.Id, 0, 100, "")
 		CheckNoError(t, resp)
 	})
+
+	t.Run("hidden channels", func(t *testing.T) {
+		Client.Logout()
+		th.LoginBasic()
+
+		hiddenChannel, resp := th.SystemAdminClient.CreateChannel(&model.Channel{
+			TeamId:                                  team.Id,
+			DisplayName:                             "Hidden",
+			Name:                                    GenerateTestChannelName(),
+			Type:                                    model.CHANNEL_OPEN,
+			ExperimentalHideChannelFromPublicSearch: model.NewBool(true),
+		})
+		CheckNoError(t, resp)
+		require.True(t, hiddenChannel.IsHiddenFromPublicSearch())
+
+		containsHidden := func(channels []*model.Channel) bool {
+			for _, c := range channels {
+				if c.Id == hiddenChannel.Id {
+					return true
+				}
+			}
+			return false
+		}
+
+		channels, resp := Client.GetPublicChannelsForTeam(team.Id, 0, 100, "")
+		CheckNoError(t, resp)
+		require.False(t, containsHidden(channels), "regular user should not see hidden channel")
+
+		_, resp = Client.GetPublicChannelsForTeamIncludingHidden(team.Id, 0, 100, "")
+		CheckForbiddenStatus(t, resp)
+
+		channels, resp = th.SystemAdminClient.GetPublicChannelsForTeam(team.Id, 0, 100, "")
+		CheckNoError(t, resp)
+		require.False(t, containsHidden(channels), "hidden channel should not be listed by default")
+
+		channels, resp = th.SystemAdminClient.GetPublicChannelsForTeamIncludingHidden(team.Id, 0, 100, "")
+		CheckNoError(t, resp)
+		require.True(t, containsHidden(channels), "admin should see hidden channel when requested")
+
+		_, resp = Client.PatchChannel(th.BasicChannel.Id, &model.ChannelPatch{ExperimentalHideChannelFromPublicSearch: model.NewBool(true)})
+		CheckForbiddenStatus(t, resp)
+	})
 }
 
 func TestGetPublicChannelsByIdsForTeam(t *testing.T) {
@@ -1892,6 +2102,44 @@ func TestGetChannelMembersByIds(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelMembersDrift(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePrivateChannel()
+
+	_, resp := th.SystemAdminClient.GetChannelMembersDrift(channel.Id)
+	CheckBadRequestStatus(t, resp)
+
+	channel.GroupConstrained = model.NewBool(true)
+	_, appErr := th.App.UpdateChannel(channel)
+	require.Nil(t, appErr)
+
+	_, resp = th.Client.GetChannelMembersDrift(channel.Id)
+	CheckForbiddenStatus(t, resp)
+
+	drift, resp := th.SystemAdminClient.GetChannelMembersDrift(channel.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, channel.Id, drift.ChannelId)
+	require.Equal(t, []string{th.BasicUser.Id}, drift.UserIds)
+	require.Empty(t, drift.ExemptUserIds)
+
+	_, resp = th.Client.UpdateChannelMemberManuallyManaged(channel.Id, th.BasicUser.Id, true)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.UpdateChannelMemberManuallyManaged(channel.Id, th.BasicUser.Id, true)
+	CheckNoError(t, resp)
+	require.True(t, ok)
+
+	drift, resp = th.SystemAdminClient.GetChannelMembersDrift(channel.Id)
+	CheckNoError(t, resp)
+	require.Empty(t, drift.UserIds)
+	require.Equal(t, []string{th.BasicUser.Id}, drift.ExemptUserIds)
+
+	_, resp = th.SystemAdminClient.GetChannelMembersDrift(model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
+
 func TestGetChannelMember(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1931,6 +2179,25 @@ func TestGetChannelMember(t *testing.T) {
 	c.Login(user.Email, user.Password)
 	_, resp = c.GetChannelMember(th.BasicChannel.Id, th.BasicUser.Id, "")
 	CheckForbiddenStatus(t, resp)
+
+	t.Run("should return whether a direct channel is muted", func(t *testing.T) {
+		th.LoginBasic()
+		dm, resp := th.Client.CreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+
+		member, resp := th.Client.GetChannelMember(dm.Id, th.BasicUser.Id, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, member.IsMuted)
+		require.False(t, *member.IsMuted)
+
+		_, resp = th.Client.UpdateChannelNotifyProps(dm.Id, th.BasicUser.Id, map[string]string{model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_MENTION})
+		CheckNoError(t, resp)
+
+		member, resp = th.Client.GetChannelMember(dm.Id, th.BasicUser.Id, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, member.IsMuted)
+		require.True(t, *member.IsMuted)
+	})
 }
 
 func TestGetChannelMembersForUser(t *testing.T) {
@@ -2084,6 +2351,40 @@ func TestGetChannelUnread(t *testing.T) {
 	CheckNotFoundStatus(t, resp)
 }
 
+func TestGetChannelLastViewed(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	user := th.BasicUser
+	channel := th.CreatePublicChannel()
+
+	lastViewed, resp := Client.GetChannelLastViewed(channel.Id, user.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, channel.Id, lastViewed.ChannelId)
+	require.Equal(t, user.Id, lastViewed.UserId)
+
+	post := th.CreatePostWithClient(Client, channel)
+	_, resp = Client.ViewChannel(user.Id, &model.ChannelView{ChannelId: channel.Id})
+	CheckNoError(t, resp)
+
+	lastViewed, resp = Client.GetChannelLastViewed(channel.Id, user.Id)
+	CheckNoError(t, resp)
+	require.Equal(t, post.Id, lastViewed.PostId)
+	require.NotZero(t, lastViewed.LastViewedAt)
+
+	_, resp = Client.GetChannelLastViewed("junk", user.Id)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelLastViewed(channel.Id, model.NewId())
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.GetChannelLastViewed(model.NewId(), user.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetChannelLastViewed(channel.Id, model.NewId())
+	CheckNotFoundStatus(t, resp)
+}
+
 func TestGetChannelStats(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2101,6 +2402,7 @@ func TestGetChannelStats(t *testing.T) {
 	stats, resp = Client.GetChannelStats(channel.Id, "")
 	CheckNoError(t, resp)
 	require.Equal(t, int64(1), stats.PinnedPostCount, "should have returned 1 pinned post count")
+	require.Equal(t, int64(1), stats.RecentRootPostCount, "should have returned 1 recent root post count")
 
 	_, resp = Client.GetChannelStats("junk", "")
 	CheckBadRequestStatus(t, resp)
@@ -2121,6 +2423,71 @@ func TestGetChannelStats(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelMemberHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	start := model.GetMillis()
+	channel := th.CreatePublicChannel()
+	th.AddUserToChannel(th.BasicUser2, channel)
+	_, resp := Client.RemoveUserFromChannel(channel.Id, th.BasicUser2.Id)
+	CheckNoError(t, resp)
+
+	histories, resp := th.SystemAdminClient.GetChannelMemberHistory(channel.Id, start, model.GetMillis(), 0, 60)
+	CheckNoError(t, resp)
+	require.Len(t, histories, 2)
+	assert.Equal(t, th.BasicUser.Id, histories[0].UserId)
+	assert.Nil(t, histories[0].LeaveTime)
+	assert.Equal(t, th.BasicUser2.Id, histories[1].UserId)
+	assert.Equal(t, th.BasicUser2.Username, histories[1].Username)
+	assert.NotNil(t, histories[1].LeaveTime)
+
+	histories, resp = th.SystemAdminClient.GetChannelMemberHistory(channel.Id, start, model.GetMillis(), 1, 1)
+	CheckNoError(t, resp)
+	require.Len(t, histories, 1)
+	assert.Equal(t, th.BasicUser2.Id, histories[0].UserId)
+
+	histories, resp = th.SystemAdminClient.GetChannelMemberHistory(channel.Id, 0, start-1, 0, 60)
+	CheckNoError(t, resp)
+	assert.Empty(t, histories)
+
+	_, resp = th.SystemAdminClient.GetChannelMemberHistory(channel.Id, start, start-1, 0, 60)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelMemberHistory(channel.Id, start, model.GetMillis(), 0, 60)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestGetChannelStatsRecentRootPostCount(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	channel := th.CreatePublicChannel()
+
+	createPost := func(daysAgo int64, rootId string) *model.Post {
+		post, err := th.App.Srv().Store.Post().Save(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   "message",
+			RootId:    rootId,
+			ParentId:  rootId,
+			CreateAt:  model.GetMillis() - daysAgo*24*60*60*1000,
+		})
+		require.Nil(t, err)
+		return post
+	}
+
+	createPost(10, "")
+	createPost(10, "")
+	recent := createPost(5, "")
+	createPost(5, recent.Id)
+	createPost(1, "")
+
+	stats, resp := th.Client.GetChannelStats(channel.Id, "")
+	CheckNoError(t, resp)
+	require.Equal(t, int64(2), stats.RecentRootPostCount)
+}
+
 func TestGetPinnedPosts(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -3029,6 +3396,13 @@ func TestAutocompleteChannelsForSearch(t *testing.T) {
 			[]string{dc1.Name, gc1.Name},
 			[]string{dc2.Name, gc2.Name},
 		},
+		{
+			"Direct messages with @",
+			th.BasicTeam.Id,
+			"@" + u1.Username,
+			[]string{dc1.Name},
+			[]string{dc2.Name},
+		},
 	} {
 		t.Run(tc.description, func(t *testing.T) {
 			channels, resp := th.Client.AutocompleteChannelsForTeamForSearch(tc.teamID, tc.fragment)
@@ -3737,6 +4111,86 @@ func TestPatchChannelModerations(t *testing.T) {
 
 }
 
+func TestUpdateChannelReadOnly(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	channel := th.CreatePublicChannel()
+	th.App.AddUserToChannel(th.BasicUser2, channel)
+
+	th.App.SetPhase2PermissionsMigrationStatus(true)
+
+	t.Run("errors without a license", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.UpdateChannelReadOnly(channel.Id, true)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.Srv().SetLicense(model.NewTestLicense())
+
+	t.Run("errors as a channel member", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.UpdateChannelReadOnly(channel.Id, true)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("errors for a direct channel", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+		_, resp := th.SystemAdminClient.UpdateChannelReadOnly(dm.Id, true)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel admin can toggle read-only", func(t *testing.T) {
+		rchannel, resp := Client.GetChannel(channel.Id, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, rchannel.IsReadOnly)
+		require.False(t, *rchannel.IsReadOnly)
+
+		rchannel, resp = Client.UpdateChannelReadOnly(channel.Id, true)
+		CheckNoError(t, resp)
+		require.True(t, *rchannel.IsReadOnly)
+
+		rchannel, resp = Client.GetChannel(channel.Id, "")
+		CheckNoError(t, resp)
+		require.True(t, *rchannel.IsReadOnly)
+
+		// It is the same create_post channel moderation an admin would set
+		moderations, resp := th.SystemAdminClient.GetChannelModerations(channel.Id, "")
+		CheckNoError(t, resp)
+		for _, moderation := range moderations {
+			if moderation.Name == model.PERMISSION_CREATE_POST.Id {
+				require.False(t, moderation.Roles.Members.Value)
+				require.False(t, moderation.Roles.Guests.Value)
+			}
+		}
+
+		posts, resp := Client.GetPostsForChannel(channel.Id, 0, 1, "")
+		CheckNoError(t, resp)
+		require.Equal(t, model.POST_CHANGE_READ_ONLY, posts.Posts[posts.Order[0]].Type)
+
+		// Only channel admins can post
+		_, resp = Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "announcement"})
+		CheckNoError(t, resp)
+
+		th.LoginBasic2()
+		_, resp = Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "reply"})
+		CheckForbiddenStatus(t, resp)
+		th.LoginBasic()
+
+		rchannel, resp = Client.UpdateChannelReadOnly(channel.Id, false)
+		CheckNoError(t, resp)
+		require.False(t, *rchannel.IsReadOnly)
+		require.Nil(t, rchannel.SchemeId, "the channel scheme should be removed once it matches the team scheme again")
+
+		th.LoginBasic2()
+		_, resp = Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "reply"})
+		CheckNoError(t, resp)
+		th.LoginBasic()
+	})
+}
+
 func TestGetChannelMemberCountsByGroup(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
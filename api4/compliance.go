@@ -6,6 +6,7 @@ package api4
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/avct/uasurfer"
 	"github.com/mattermost/mattermost-server/v5/audit"
@@ -17,6 +18,7 @@ func (api *API) InitCompliance() {
 	api.BaseRoutes.Compliance.Handle("/reports", api.ApiSessionRequired(getComplianceReports)).Methods("GET")
 	api.BaseRoutes.Compliance.Handle("/reports/{report_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getComplianceReport)).Methods("GET")
 	api.BaseRoutes.Compliance.Handle("/reports/{report_id:[A-Za-z0-9]+}/download", api.ApiSessionRequiredTrustRequester(downloadComplianceReport)).Methods("GET")
+	api.BaseRoutes.Compliance.Handle("/posts/files", api.ApiSessionRequired(getPostIdsWithFileExtensions)).Methods("GET")
 }
 
 func createComplianceReport(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -147,3 +149,36 @@ func downloadComplianceReport(c *Context, w http.ResponseWriter, r *http.Request
 
 	w.Write(reportBytes)
 }
+
+func getPostIdsWithFileExtensions(c *Context, w http.ResponseWriter, r *http.Request) {
+	extensions := strings.Split(r.URL.Query().Get("extensions"), ",")
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); len(sinceString) > 0 {
+		var parseError error
+		since, parseError = strconv.ParseInt(sinceString, 10, 64)
+		if parseError != nil {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	auditRec := c.MakeAuditRecord("getPostIdsWithFileExtensions", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("extensions", extensions)
+	auditRec.AddMeta("since", since)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	postIds, err := c.App.GetPostIdsWithFileExtensions(extensions, since, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	w.Write([]byte(model.ArrayToJson(postIds)))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestGetPostIdsWithFileExtensions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	extension := "exe" + model.NewId()
+	info, err := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+		PostId:    th.BasicPost.Id,
+		CreatorId: th.BasicUser.Id,
+		Path:      "file." + extension,
+		Extension: extension,
+	})
+	require.Nil(t, err)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+
+	_, resp := th.Client.GetPostIdsWithFileExtensions([]string{extension}, 0, 0, 60)
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("should return the posts with the extension", func(t *testing.T) {
+		postIds, resp := th.SystemAdminClient.GetPostIdsWithFileExtensions([]string{"." + extension, "bat" + model.NewId()}, 0, 0, 60)
+		CheckNoError(t, resp)
+		assert.Equal(t, []string{th.BasicPost.Id}, postIds)
+	})
+
+	t.Run("should not return older posts", func(t *testing.T) {
+		postIds, resp := th.SystemAdminClient.GetPostIdsWithFileExtensions([]string{extension}, info.CreateAt+1, 0, 60)
+		CheckNoError(t, resp)
+		assert.Empty(t, postIds)
+	})
+
+	t.Run("should require an extension", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetPostIdsWithFileExtensions([]string{" ", ""}, 0, 0, 60)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/config"
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/mergeutils"
 )
 
 func (api *API) InitConfig() {
@@ -20,6 +20,11 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.ApiRoot.Handle("/config/reload", api.ApiSessionRequired(configReload)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/config/client", api.ApiHandler(getClientConfig)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/config/environment", api.ApiSessionRequired(getEnvironmentConfig)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/history", api.ApiSessionRequired(getConfigHistory)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/rollback/{config_id:[A-Za-z0-9]+}", api.ApiSessionRequired(rollbackConfig)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/config/feature_flags", api.ApiSessionRequired(getFeatureFlags)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/feature_flags/{feature_flag_name:[A-Za-z0-9_]+}/override", api.ApiSessionRequired(setFeatureFlagOverride)).Methods("PUT")
+	api.BaseRoutes.ApiRoot.Handle("/config/feature_flags/{feature_flag_name:[A-Za-z0-9_]+}/override", api.ApiSessionRequired(deleteFeatureFlagOverride)).Methods("DELETE")
 }
 
 func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -71,23 +76,46 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec := c.MakeAuditRecord("updateConfig", audit.Fail)
 	defer c.LogAuditRec(auditRec)
 
-	cfg.SetDefaults()
-
 	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
 		return
 	}
 
+	cfg = restrictConfigUpdate(c, "updateConfig", cfg)
+	if c.Err != nil {
+		return
+	}
+
+	warnings, err := c.App.SaveConfigWithWarnings(cfg, true)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	cfg = c.App.GetSanitizedConfig()
+
+	auditRec.Success()
+	c.LogAudit("updateConfig")
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte((&model.ConfigWithWarnings{Config: cfg, Warnings: warnings}).ToJson()))
+}
+
+// restrictConfigUpdate applies the restrictions on updating the configuration through the API to
+// the given configuration, and validates it. It returns the configuration to save, or sets c.Err.
+func restrictConfigUpdate(c *Context, where string, cfg *model.Config) *model.Config {
+	cfg.SetDefaults()
+
 	appCfg := c.App.Config()
 	if *appCfg.ServiceSettings.SiteURL != "" && *cfg.ServiceSettings.SiteURL == "" {
-		c.Err = model.NewAppError("updateConfig", "api.config.update_config.clear_siteurl.app_error", nil, "", http.StatusBadRequest)
-		return
+		c.Err = model.NewAppError(where, "api.config.update_config.clear_siteurl.app_error", nil, "", http.StatusBadRequest)
+		return nil
 	}
-	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+	if *appCfg.ExperimentalSettings.RestrictSystemAdmin {
 		// Start with the current configuration, and only merge values not marked as being
 		// restricted.
 		var err error
-		cfg, err = config.Merge(appCfg, cfg, &utils.MergeConfig{
+		cfg, err = config.Merge(appCfg, cfg, &mergeutils.MergeConfig{
 			StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
 				restricted := structField.Tag.Get("restricted") == "true"
 
@@ -95,7 +123,8 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 			},
 		})
 		if err != nil {
-			c.Err = model.NewAppError("updateConfig", "api.config.update_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+			c.Err = model.NewAppError(where, "api.config.update_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+			return nil
 		}
 	}
 
@@ -107,25 +136,12 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	c.App.HandleMessageExportConfig(cfg, appCfg)
 
-	err := cfg.IsValid()
-	if err != nil {
+	if err := cfg.IsValid(); err != nil {
 		c.Err = err
-		return
+		return nil
 	}
 
-	err = c.App.SaveConfig(cfg, true)
-	if err != nil {
-		c.Err = err
-		return
-	}
-
-	cfg = c.App.GetSanitizedConfig()
-
-	auditRec.Success()
-	c.LogAudit("updateConfig")
-
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Write([]byte(cfg.ToJson()))
+	return cfg
 }
 
 func getClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -141,14 +157,31 @@ func getClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var config map[string]string
+	var clientConfig map[string]string
+	var audience config.ClientConfigAudience
 	if len(c.App.Session().UserId) == 0 {
-		config = c.App.LimitedClientConfigWithComputed()
+		audience = config.ClientConfigAudienceAnonymous
+		clientConfig = c.App.LimitedClientConfigWithComputed()
 	} else {
-		config = c.App.ClientConfigWithComputed()
+		if c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+			audience = config.ClientConfigAudienceAdmin
+			clientConfig = c.App.AdminClientConfigWithComputed()
+		} else {
+			audience = config.ClientConfigAudienceUser
+			clientConfig = c.App.ClientConfigWithComputed()
+		}
+
+		clientConfig["FeatureFlags"] = model.MapBoolToJson(c.App.GetFeatureFlagsForUser(c.App.Session().UserId))
 	}
 
-	w.Write([]byte(model.MapToJson(config)))
+	// Clients that no longer rely on the deprecated fields can ask to leave them out.
+	if r.URL.Query().Get("include_deprecated") != "false" {
+		for name, value := range c.App.DeprecatedClientConfig(audience, r.UserAgent()) {
+			clientConfig[name] = value
+		}
+	}
+
+	w.Write([]byte(model.MapToJson(clientConfig)))
 }
 
 func getEnvironmentConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -183,7 +216,7 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = model.NewAppError("patchConfig", "api.config.update_config.clear_siteurl.app_error", nil, "", http.StatusBadRequest)
 		return
 	}
-	var filterFn utils.StructFieldFilter
+	var filterFn mergeutils.StructFieldFilter
 	if *appCfg.ExperimentalSettings.RestrictSystemAdmin {
 		filterFn = func(structField reflect.StructField, base, patch reflect.Value) bool {
 			return !(structField.Tag.Get("restricted") == "true")
@@ -201,7 +234,7 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.App.HandleMessageExportConfig(cfg, appCfg)
 	}
 
-	updatedCfg, mergeErr := config.Merge(appCfg, cfg, &utils.MergeConfig{
+	updatedCfg, mergeErr := config.Merge(appCfg, cfg, &mergeutils.MergeConfig{
 		StructFieldFilter: filterFn,
 	})
 
@@ -216,7 +249,7 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.App.SaveConfig(updatedCfg, true)
+	warnings, err := c.App.SaveConfigWithWarnings(updatedCfg, true)
 	if err != nil {
 		c.Err = err
 		return
@@ -225,5 +258,138 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Write([]byte(c.App.GetSanitizedConfig().ToJson()))
+	w.Write([]byte((&model.ConfigWithWarnings{Config: c.App.GetSanitizedConfig(), Warnings: warnings}).ToJson()))
+}
+
+func getConfigHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	entries, err := c.App.GetConfigHistory(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(model.ConfigHistoryEntriesToJson(entries)))
+}
+
+func rollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("rollbackConfig", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("config_id", c.Params.ConfigId)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("rollbackConfig", "api.restricted_system_admin", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := c.App.GetHistoricalConfig(c.Params.ConfigId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	// Apply the same restrictions as updating the configuration through the API.
+	cfg = restrictConfigUpdate(c, "rollbackConfig", cfg)
+	if c.Err != nil {
+		return
+	}
+
+	warnings, err := c.App.SaveConfigWithWarnings(cfg, true)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("config_id=" + c.Params.ConfigId)
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte((&model.ConfigWithWarnings{Config: c.App.GetSanitizedConfig(), Warnings: warnings}).ToJson()))
+}
+
+func getFeatureFlags(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	w.Write([]byte(model.FeatureFlagListToJson(c.App.GetFeatureFlags())))
+}
+
+func setFeatureFlagOverride(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireFeatureFlagName()
+	if c.Err != nil {
+		return
+	}
+
+	override := model.FeatureFlagOverrideFromJson(r.Body)
+	if override == nil {
+		c.SetInvalidParam("override")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("setFeatureFlagOverride", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("feature_flag_name", c.Params.FeatureFlagName)
+	auditRec.AddMeta("rollout_percentage", override.RolloutPercentage)
+	auditRec.AddMeta("user_ids", override.UserIds)
+	auditRec.AddMeta("team_ids", override.TeamIds)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	flag, err := c.App.SetFeatureFlagOverride(c.Params.FeatureFlagName, override)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("feature_flag_name=" + c.Params.FeatureFlagName)
+
+	w.Write([]byte(flag.ToJson()))
+}
+
+func deleteFeatureFlagOverride(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireFeatureFlagName()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteFeatureFlagOverride", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("feature_flag_name", c.Params.FeatureFlagName)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	flag, err := c.App.DeleteFeatureFlagOverride(c.Params.FeatureFlagName)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("feature_flag_name=" + c.Params.FeatureFlagName)
+
+	w.Write([]byte(flag.ToJson()))
 }
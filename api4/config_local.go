@@ -10,7 +10,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/config"
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/mergeutils"
 )
 
 func (api *API) InitConfigLocal() {
@@ -47,7 +47,7 @@ func localUpdateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.App.SaveConfig(cfg, true)
+	warnings, err := c.App.SaveConfigWithWarnings(cfg, true)
 	if err != nil {
 		c.Err = err
 		return
@@ -59,7 +59,7 @@ func localUpdateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.LogAudit("updateConfig")
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Write([]byte(cfg.ToJson()))
+	w.Write([]byte((&model.ConfigWithWarnings{Config: cfg, Warnings: warnings}).ToJson()))
 }
 
 func localPatchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -84,7 +84,7 @@ func localPatchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.App.HandleMessageExportConfig(cfg, appCfg)
 	}
 
-	updatedCfg, mergeErr := config.Merge(appCfg, cfg, &utils.MergeConfig{
+	updatedCfg, mergeErr := config.Merge(appCfg, cfg, &mergeutils.MergeConfig{
 		StructFieldFilter: filterFn,
 	})
 
@@ -99,7 +99,7 @@ func localPatchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.App.SaveConfig(updatedCfg, true)
+	warnings, err := c.App.SaveConfigWithWarnings(updatedCfg, true)
 	if err != nil {
 		c.Err = err
 		return
@@ -108,5 +108,5 @@ func localPatchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Write([]byte(c.App.GetSanitizedConfig().ToJson()))
+	w.Write([]byte((&model.ConfigWithWarnings{Config: c.App.GetSanitizedConfig(), Warnings: warnings}).ToJson()))
 }
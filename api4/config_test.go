@@ -5,8 +5,10 @@ package api4
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -287,6 +289,90 @@ func TestUpdateConfigRestrictSystemAdmin(t *testing.T) {
 	})
 }
 
+func TestUpdateConfigTeamDefaultClientLocales(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("supported locale", func(t *testing.T) {
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+		cfg.LocalizationSettings.TeamDefaultClientLocales = map[string]string{th.BasicTeam.Id: "de"}
+
+		cfg, resp = th.SystemAdminClient.UpdateConfig(cfg)
+		CheckNoError(t, resp)
+		assert.Equal(t, "de", cfg.LocalizationSettings.TeamDefaultClientLocales[th.BasicTeam.Id])
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+		cfg.LocalizationSettings.TeamDefaultClientLocales = map[string]string{th.BasicTeam.Id: "tlh"}
+
+		_, resp = th.SystemAdminClient.UpdateConfig(cfg)
+		CheckBadRequestStatus(t, resp)
+		assert.Equal(t, "de", th.App.Config().LocalizationSettings.TeamDefaultClientLocales[th.BasicTeam.Id])
+	})
+}
+
+func TestUpdateConfigSiteURLReachability(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	var pings int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.URL.Path == "/api/v4/system/ping" {
+			atomic.AddInt32(&pings, 1)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	updateSiteURL := func(t *testing.T, siteURL string) *model.ConfigWithWarnings {
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+		*cfg.ServiceSettings.SiteURL = siteURL
+
+		r, appErr := th.SystemAdminClient.DoApiPut("/config", cfg.ToJson())
+		require.Nil(t, appErr)
+		defer r.Body.Close()
+
+		result := model.ConfigWithWarningsFromJson(r.Body)
+		require.NotNil(t, result)
+		require.Equal(t, siteURL, *result.ServiceSettings.SiteURL)
+		return result
+	}
+
+	t.Run("reachable site URL", func(t *testing.T) {
+		result := updateSiteURL(t, server.URL)
+		assert.Empty(t, result.Warnings)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&pings))
+	})
+
+	t.Run("unchanged site URL is not checked again", func(t *testing.T) {
+		result := updateSiteURL(t, server.URL)
+		assert.Empty(t, result.Warnings)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&pings))
+	})
+
+	t.Run("site URL not serving the API", func(t *testing.T) {
+		result := updateSiteURL(t, server.URL+"/subpath")
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], server.URL+"/subpath")
+	})
+
+	t.Run("unreachable site URL", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachableURL := unreachable.URL
+		unreachable.Close()
+
+		result := updateSiteURL(t, unreachableURL)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], unreachableURL)
+	})
+}
+
 func TestGetEnvironmentConfig(t *testing.T) {
 	os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://example.mattermost.com")
 	os.Setenv("MM_SERVICESETTINGS_ENABLECUSTOMEMOJI", "true")
@@ -384,6 +470,32 @@ func TestGetOldClientConfig(t *testing.T) {
 		require.Empty(t, config["GoogleDeveloperKey"], "config should be missing developer key")
 	})
 
+	t.Run("deployment details only for system admins", func(t *testing.T) {
+		config, resp := th.Client.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		require.NotContains(t, config, "SQLDriverName")
+		require.NotContains(t, config, "RunJobs")
+
+		config, resp = th.SystemAdminClient.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		require.Equal(t, *th.App.Config().SqlSettings.DriverName, config["SQLDriverName"])
+		require.Contains(t, config, "RunJobs")
+	})
+
+	t.Run("deprecated fields unless left out", func(t *testing.T) {
+		config, resp := th.Client.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		require.Equal(t, "true", config["ExperimentalEnablePostMetadata"])
+		require.Equal(t, config["EnableDiagnostics"], config["DiagnosticsEnabled"])
+
+		r, err := th.Client.DoApiGet("/config/client?format=old&include_deprecated=false", "")
+		require.Nil(t, err)
+		defer r.Body.Close()
+		config = model.MapFromJson(r.Body)
+		require.NotContains(t, config, "ExperimentalEnablePostMetadata")
+		require.NotContains(t, config, "DiagnosticsEnabled")
+	})
+
 	t.Run("missing format", func(t *testing.T) {
 		Client := th.Client
 
@@ -551,3 +663,156 @@ func TestPatchConfig(t *testing.T) {
 		require.Equal(t, nonEmptyURL, *cfg.ServiceSettings.SiteURL)
 	})
 }
+
+func TestConfigHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("as regular user", func(t *testing.T) {
+		_, resp := th.Client.GetConfigHistory(0, 10)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.RollbackConfig(model.NewId())
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid config id", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.RollbackConfig("junk")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("restricted system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		_, resp := th.SystemAdminClient.RollbackConfig(model.NewId())
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("store without history", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetConfigHistory(0, 10)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.RollbackConfig(model.NewId())
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("database store", func(t *testing.T) {
+		th := SetupWithDatabaseConfigStore(t).InitBasic()
+		defer th.TearDown()
+
+		require.Empty(t, *th.App.Config().ServiceSettings.SiteURL)
+		entries, resp := th.SystemAdminClient.GetConfigHistory(0, 1)
+		CheckNoError(t, resp)
+		require.Len(t, entries, 1)
+		withoutSiteURLId := entries[0].Id
+
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+		*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+		*cfg.TeamSettings.SiteName = "First"
+		_, resp = th.SystemAdminClient.UpdateConfig(cfg)
+		CheckNoError(t, resp)
+
+		entries, resp = th.SystemAdminClient.GetConfigHistory(0, 1)
+		CheckNoError(t, resp)
+		require.Len(t, entries, 1)
+		firstId := entries[0].Id
+
+		*cfg.TeamSettings.SiteName = "Second"
+		_, resp = th.SystemAdminClient.UpdateConfig(cfg)
+		CheckNoError(t, resp)
+
+		var listenerCalls int32
+		listenerId := th.App.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+			if *oldCfg.TeamSettings.SiteName == "Second" && *newCfg.TeamSettings.SiteName == "First" {
+				atomic.AddInt32(&listenerCalls, 1)
+			}
+		})
+		defer th.App.RemoveConfigListener(listenerId)
+
+		_, resp = th.SystemAdminClient.RollbackConfig(withoutSiteURLId)
+		CheckBadRequestStatus(t, resp)
+		assert.Equal(t, "http://localhost:8065", *th.App.Config().ServiceSettings.SiteURL)
+		assert.Equal(t, "Second", *th.App.Config().TeamSettings.SiteName)
+
+		rolledBack, resp := th.SystemAdminClient.RollbackConfig(firstId)
+		CheckNoError(t, resp)
+		assert.Equal(t, "First", *rolledBack.TeamSettings.SiteName)
+		assert.Equal(t, "http://localhost:8065", *rolledBack.ServiceSettings.SiteURL)
+		assert.Equal(t, "First", *th.App.Config().TeamSettings.SiteName)
+		assert.Equal(t, "http://localhost:8065", *th.App.Config().ServiceSettings.SiteURL)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&listenerCalls))
+	})
+}
+
+func clientFeatureFlags(t *testing.T, config map[string]string) map[string]bool {
+	t.Helper()
+
+	require.Contains(t, config, "FeatureFlags")
+	return model.MapBoolFromJson(strings.NewReader(config["FeatureFlags"]))
+}
+
+func TestFeatureFlags(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("as regular user", func(t *testing.T) {
+		_, resp := th.Client.GetFeatureFlags()
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.DeleteFeatureFlagOverride("TestFeature")
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("override for a user", func(t *testing.T) {
+		flag, resp := th.SystemAdminClient.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{UserIds: []string{th.BasicUser.Id}})
+		CheckNoError(t, resp)
+		require.NotNil(t, flag.Override)
+		assert.Equal(t, []string{th.BasicUser.Id}, flag.Override.UserIds)
+
+		flags, resp := th.SystemAdminClient.GetFeatureFlags()
+		CheckNoError(t, resp)
+		require.Len(t, flags, 1)
+		assert.Equal(t, "TestFeature", flags[0].Name)
+		assert.Equal(t, flag.Override, flags[0].Override)
+
+		config, resp := th.Client.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		assert.Equal(t, map[string]bool{"TestFeature": true}, clientFeatureFlags(t, config))
+
+		config, resp = th.SystemAdminClient.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		assert.Equal(t, map[string]bool{"TestFeature": false}, clientFeatureFlags(t, config))
+
+		config, resp = th.CreateClient().GetOldClientConfig("")
+		CheckNoError(t, resp)
+		assert.NotContains(t, config, "FeatureFlags")
+	})
+
+	t.Run("delete override", func(t *testing.T) {
+		flag, resp := th.SystemAdminClient.DeleteFeatureFlagOverride("TestFeature")
+		CheckNoError(t, resp)
+		assert.Nil(t, flag.Override)
+
+		config, resp := th.Client.GetOldClientConfig("")
+		CheckNoError(t, resp)
+		assert.Equal(t, map[string]bool{"TestFeature": false}, clientFeatureFlags(t, config))
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{UserIds: []string{"junk"}})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.SetFeatureFlagOverride("Unknown", &model.FeatureFlagOverride{})
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.DeleteFeatureFlagOverride("Unknown")
+		CheckNotFoundStatus(t, resp)
+	})
+}
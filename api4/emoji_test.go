@@ -166,6 +166,65 @@ func TestCreateEmoji(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestCreateEmojiMaxPerUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+		*cfg.ServiceSettings.MaxCustomEmojiPerUser = 2
+	})
+
+	createEmoji := func(client *model.Client4, creatorId string) (*model.Emoji, *model.Response) {
+		emoji := &model.Emoji{
+			CreatorId: creatorId,
+			Name:      model.NewId(),
+		}
+		return client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	}
+
+	emoji, resp := createEmoji(th.Client, th.BasicUser.Id)
+	CheckNoError(t, resp)
+	_, resp = createEmoji(th.Client, th.BasicUser.Id)
+	CheckNoError(t, resp)
+
+	t.Run("should not allow more emoji than the limit", func(t *testing.T) {
+		_, resp := createEmoji(th.Client, th.BasicUser.Id)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.emoji.create.too_many.app_error")
+	})
+
+	t.Run("should not limit other users", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := createEmoji(th.Client, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("should not limit system admins", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, resp := createEmoji(th.SystemAdminClient, th.SystemAdminUser.Id)
+			CheckNoError(t, resp)
+		}
+	})
+
+	t.Run("should allow new emoji after deleting one", func(t *testing.T) {
+		_, resp := th.Client.DeleteEmoji(emoji.Id)
+		CheckNoError(t, resp)
+
+		_, resp = createEmoji(th.Client, th.BasicUser.Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("should not limit when set to zero", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxCustomEmojiPerUser = 0 })
+
+		_, resp := createEmoji(th.Client, th.BasicUser.Id)
+		CheckNoError(t, resp)
+	})
+}
+
 func TestGetEmojiList(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
@@ -637,6 +638,44 @@ func TestUploadFiles(t *testing.T) {
 	}
 }
 
+func TestUploadFileToChannelWithAttachmentsDisabled(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+
+	channel, resp := th.SystemAdminClient.PatchChannel(th.BasicChannel.Id, &model.ChannelPatch{DisableFileAttachments: model.NewBool(true)})
+	CheckNoError(t, resp)
+	require.True(t, channel.IsFileAttachmentsDisabled())
+
+	_, resp = th.Client.UploadFile(sent, channel.Id, "test.png")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.UploadFile(sent, channel.Id, "test.png")
+	CheckForbiddenStatus(t, resp)
+
+	fileResp, resp := th.Client.UploadFile(sent, th.BasicChannel2.Id, "test.png")
+	CheckNoError(t, resp)
+	_, resp = th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "attached", FileIds: []string{fileResp.FileInfos[0].Id}})
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("system admins can bypass the restriction if allowed", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.AdminsBypassDisabledAttachments = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.AdminsBypassDisabledAttachments = false })
+
+		_, resp = th.SystemAdminClient.UploadFile(sent, channel.Id, "test.png")
+		CheckNoError(t, resp)
+
+		_, resp = th.Client.UploadFile(sent, channel.Id, "test.png")
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGetFile(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -732,6 +771,81 @@ func TestGetFileHeaders(t *testing.T) {
 	t.Run("no extension 2", testHeaders([]byte("<html></html>"), "test", "application/octet-stream", false))
 }
 
+func TestGetFileRange(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+
+	fileResp, resp := Client.UploadFile(sent, th.BasicChannel.Id, "test.png")
+	CheckNoError(t, resp)
+
+	getRange := func(rangeHeader string) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", Client.ApiUrl+Client.GetFileRoute(fileResp.FileInfos[0].Id), nil)
+		require.NoError(t, err)
+		req.Header.Set(model.HEADER_AUTH, Client.AuthType+" "+Client.AuthToken)
+		req.Header.Set("Range", rangeHeader)
+
+		httpResp, err := Client.HttpClient.Do(req)
+		require.NoError(t, err)
+		defer closeBody(httpResp)
+
+		data, err := ioutil.ReadAll(httpResp.Body)
+		require.NoError(t, err)
+		return httpResp, data
+	}
+
+	t.Run("partial range", func(t *testing.T) {
+		httpResp, data := getRange("bytes=10-19")
+		require.Equal(t, http.StatusPartialContent, httpResp.StatusCode)
+		assert.Equal(t, "bytes", httpResp.Header.Get("Accept-Ranges"))
+		assert.Equal(t, fmt.Sprintf("bytes 10-19/%d", len(sent)), httpResp.Header.Get("Content-Range"))
+		assert.Equal(t, "10", httpResp.Header.Get("Content-Length"))
+		assert.Equal(t, sent[10:20], data)
+	})
+
+	t.Run("full range", func(t *testing.T) {
+		httpResp, data := getRange("bytes=0-")
+		require.Equal(t, http.StatusPartialContent, httpResp.StatusCode)
+		assert.Equal(t, fmt.Sprintf("bytes 0-%d/%d", len(sent)-1, len(sent)), httpResp.Header.Get("Content-Range"))
+		assert.Equal(t, sent, data)
+	})
+
+	t.Run("multiple ranges", func(t *testing.T) {
+		httpResp, data := getRange("bytes=0-4,20-29")
+		require.Equal(t, http.StatusPartialContent, httpResp.StatusCode)
+
+		mediaType, params, err := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/byteranges", mediaType)
+
+		mr := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+		var parts [][]byte
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			partData, err := ioutil.ReadAll(part)
+			require.NoError(t, err)
+			parts = append(parts, partData)
+		}
+		assert.Equal(t, [][]byte{sent[0:5], sent[20:30]}, parts)
+	})
+
+	t.Run("unsatisfiable range", func(t *testing.T) {
+		httpResp, _ := getRange(fmt.Sprintf("bytes=%d-", len(sent)+10))
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, httpResp.StatusCode)
+	})
+}
+
 func TestGetFileThumbnail(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
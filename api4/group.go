@@ -19,10 +19,17 @@ func (api *API) InitGroup() {
 	// GET /api/v4/groups
 	api.BaseRoutes.Groups.Handle("", api.ApiSessionRequired(getGroups)).Methods("GET")
 
+	// POST /api/v4/groups
+	api.BaseRoutes.Groups.Handle("", api.ApiSessionRequired(createGroup)).Methods("POST")
+
 	// GET /api/v4/groups/:group_id
 	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}",
 		api.ApiSessionRequired(getGroup)).Methods("GET")
 
+	// DELETE /api/v4/groups/:group_id
+	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}",
+		api.ApiSessionRequired(deleteGroup)).Methods("DELETE")
+
 	// PUT /api/v4/groups/:group_id/patch
 	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/patch",
 		api.ApiSessionRequired(patchGroup)).Methods("PUT")
@@ -60,6 +67,14 @@ func (api *API) InitGroup() {
 	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/members",
 		api.ApiSessionRequired(getGroupMembers)).Methods("GET")
 
+	// POST /api/v4/groups/:group_id/members
+	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/members",
+		api.ApiSessionRequired(addGroupMembers)).Methods("POST")
+
+	// DELETE /api/v4/groups/:group_id/members
+	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/members",
+		api.ApiSessionRequired(deleteGroupMembers)).Methods("DELETE")
+
 	// GET /api/v4/users/:user_id/groups?page=0&per_page=100
 	api.BaseRoutes.Users.Handle("/{user_id:[A-Za-z0-9]+}/groups",
 		api.ApiSessionRequired(getGroupsByUserId)).Methods("GET")
@@ -88,17 +103,17 @@ func getGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
-		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
-		return
-	}
-
 	group, err := c.App.GetGroup(c.Params.GroupId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
+	if appErr := verifyGroupManagePermission(c, group); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
 	b, marshalErr := json.Marshal(group)
 	if marshalErr != nil {
 		c.Err = model.NewAppError("Api4.getGroup", "api.marshal_error", nil, marshalErr.Error(), http.StatusInternalServerError)
@@ -128,11 +143,6 @@ func patchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
-		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
-		return
-	}
-
 	group, err := c.App.GetGroup(c.Params.GroupId)
 	if err != nil {
 		c.Err = err
@@ -140,6 +150,11 @@ func patchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	auditRec.AddMeta("group", group)
 
+	if appErr := verifyGroupManagePermission(c, group); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
 	if groupPatch.AllowReference != nil && *groupPatch.AllowReference {
 		if groupPatch.Name == nil {
 			tmp := strings.ReplaceAll(strings.ToLower(group.DisplayName), " ", "-")
@@ -497,6 +512,183 @@ func verifyLinkUnlinkPermission(c *Context, syncableType model.GroupSyncableType
 	return nil
 }
 
+// verifyGroupManagePermission checks that the session may manage the group: custom groups need the
+// manage_custom_groups permission, while the groups synced from LDAP are left to system admins.
+func verifyGroupManagePermission(c *Context, group *model.Group) *model.AppError {
+	permission := model.PERMISSION_MANAGE_SYSTEM
+	if group.IsCustom() {
+		permission = model.PERMISSION_MANAGE_CUSTOM_GROUPS
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), permission) {
+		return c.App.MakePermissionError(permission)
+	}
+
+	return nil
+}
+
+// getCustomGroup returns the group of the request, failing unless it is a custom group. The members
+// of the other groups come from their source, so they can't be changed through the API.
+func getCustomGroup(c *Context, where string) *model.Group {
+	group, err := c.App.GetGroup(c.Params.GroupId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if !group.IsCustom() {
+		c.Err = model.NewAppError(where, "api.custom_groups.must_be_custom.app_error", nil, "source="+string(group.Source), http.StatusBadRequest)
+		return nil
+	}
+
+	return group
+}
+
+func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	group := model.GroupFromJson(r.Body)
+	if group == nil {
+		c.SetInvalidParam("group")
+		return
+	}
+
+	if group.Source != "" && !group.IsCustom() {
+		c.Err = model.NewAppError("Api4.createGroup", "api.custom_groups.must_be_custom.app_error", nil, "source="+string(group.Source), http.StatusBadRequest)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if c.App.Srv().License() == nil || !*c.App.Srv().License().Features.LDAPGroups {
+		c.Err = model.NewAppError("Api4.createGroup", "api.ldap_groups.license_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_CUSTOM_GROUPS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CUSTOM_GROUPS)
+		return
+	}
+
+	if group.Name != nil && (*group.Name == model.USER_NOTIFY_ALL || *group.Name == model.CHANNEL_MENTIONS_NOTIFY_PROP || *group.Name == model.USER_NOTIFY_HERE) {
+		c.Err = model.NewAppError("Api4.createGroup", "api.ldap_groups.existing_reserved_name_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	group, err := c.App.CreateCustomGroup(group)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddMeta("group", group)
+
+	b, marshalErr := json.Marshal(group)
+	if marshalErr != nil {
+		c.Err = model.NewAppError("Api4.createGroup", "api.marshal_error", nil, marshalErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusCreated)
+	w.Write(b)
+}
+
+func deleteGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if c.App.Srv().License() == nil || !*c.App.Srv().License().Features.LDAPGroups {
+		c.Err = model.NewAppError("Api4.deleteGroup", "api.ldap_groups.license_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_CUSTOM_GROUPS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CUSTOM_GROUPS)
+		return
+	}
+
+	group := getCustomGroup(c, "Api4.deleteGroup")
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("group", group)
+
+	if _, err := c.App.DeleteGroup(group.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func addGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	modifyGroupMembers(c, w, r, "addGroupMembers", c.App.UpsertGroupMembers)
+}
+
+func deleteGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	modifyGroupMembers(c, w, r, "deleteGroupMembers", c.App.DeleteGroupMembers)
+}
+
+func modifyGroupMembers(c *Context, w http.ResponseWriter, r *http.Request, event string, modify func(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	groupMembers := model.GroupModifyMembersFromJson(r.Body)
+	if groupMembers == nil || len(groupMembers.UserIds) == 0 {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	for _, userID := range groupMembers.UserIds {
+		if !model.IsValidId(userID) {
+			c.SetInvalidParam("user_ids")
+			return
+		}
+	}
+
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_ids", groupMembers.UserIds)
+
+	if c.App.Srv().License() == nil || !*c.App.Srv().License().Features.LDAPGroups {
+		c.Err = model.NewAppError("Api4."+event, "api.ldap_groups.license_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_CUSTOM_GROUPS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CUSTOM_GROUPS)
+		return
+	}
+
+	group := getCustomGroup(c, "Api4."+event)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("group", group)
+
+	members, err := modify(group.Id, groupMembers.UserIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	b, marshalErr := json.Marshal(members)
+	if marshalErr != nil {
+		c.Err = model.NewAppError("Api4."+event, "api.marshal_error", nil, marshalErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	auditRec.Success()
+	w.Write(b)
+}
+
 func getGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireGroupId()
 	if c.Err != nil {
@@ -508,8 +700,14 @@ func getGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
-		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+	group, err := c.App.GetGroup(c.Params.GroupId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if appErr := verifyGroupManagePermission(c, group); appErr != nil {
+		c.Err = appErr
 		return
 	}
 
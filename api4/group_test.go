@@ -125,6 +125,159 @@ func TestPatchGroup(t *testing.T) {
 	CheckUnauthorizedStatus(t, response)
 }
 
+func TestCreateGroup(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	id := model.NewId()
+	g := &model.Group{
+		DisplayName:    "dn_" + id,
+		Name:           model.NewString("name" + id),
+		Description:    "description_" + id,
+		AllowReference: true,
+	}
+
+	_, response := th.Client.CreateGroup(g)
+	CheckNotImplementedStatus(t, response)
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	// Only system admins have the permission by default
+	_, response = th.Client.CreateGroup(g)
+	CheckForbiddenStatus(t, response)
+
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+	defer th.RemovePermissionFromRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+
+	group, response := th.Client.CreateGroup(g)
+	CheckCreatedStatus(t, response)
+	assert.Equal(t, model.GroupSourceCustom, group.Source)
+	assert.Equal(t, *g.Name, *group.Name)
+	assert.True(t, group.AllowReference)
+
+	t.Run("name must be unique", func(t *testing.T) {
+		_, response = th.Client.CreateGroup(g)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "store.sql_group.unique_constraint", response.Error.Id)
+
+		g.Name = model.NewString(th.BasicUser2.Username)
+		_, response = th.Client.CreateGroup(g)
+		CheckBadRequestStatus(t, response)
+		assert.Equal(t, "store.sql_group.name_conflicts_with_username", response.Error.Id)
+
+		g.Name = model.NewString(model.USER_NOTIFY_ALL)
+		_, response = th.Client.CreateGroup(g)
+		CheckBadRequestStatus(t, response)
+	})
+
+	t.Run("only custom groups", func(t *testing.T) {
+		_, response = th.Client.CreateGroup(&model.Group{
+			DisplayName: "dn_" + model.NewId(),
+			Source:      model.GroupSourceLdap,
+			RemoteId:    model.NewId(),
+		})
+		CheckBadRequestStatus(t, response)
+	})
+}
+
+func TestPatchCustomGroup(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	group, err := th.App.CreateCustomGroup(&model.Group{DisplayName: "dn_" + model.NewId()})
+	require.Nil(t, err)
+
+	_, response := th.Client.PatchGroup(group.Id, &model.GroupPatch{DisplayName: model.NewString("new display name")})
+	CheckForbiddenStatus(t, response)
+
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+	defer th.RemovePermissionFromRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+
+	patched, response := th.Client.PatchGroup(group.Id, &model.GroupPatch{DisplayName: model.NewString("new display name")})
+	CheckOKStatus(t, response)
+	assert.Equal(t, "new display name", patched.DisplayName)
+
+	// LDAP groups are still left to system admins
+	_, response = th.Client.PatchGroup(th.Group.Id, &model.GroupPatch{DisplayName: model.NewString("new display name")})
+	CheckForbiddenStatus(t, response)
+}
+
+func TestDeleteGroup(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group, err := th.App.CreateCustomGroup(&model.Group{DisplayName: "dn_" + model.NewId()})
+	require.Nil(t, err)
+
+	_, response := th.Client.DeleteGroup(group.Id)
+	CheckNotImplementedStatus(t, response)
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	_, err = th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, th.BasicTeam.Id, false))
+	require.Nil(t, err)
+
+	_, response = th.Client.DeleteGroup(group.Id)
+	CheckForbiddenStatus(t, response)
+
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+	defer th.RemovePermissionFromRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+
+	_, response = th.Client.DeleteGroup(th.Group.Id)
+	CheckBadRequestStatus(t, response)
+
+	ok, response := th.Client.DeleteGroup(group.Id)
+	CheckOKStatus(t, response)
+	assert.True(t, ok)
+
+	groupTeams, err := th.App.GetGroupSyncables(group.Id, model.GroupSyncableTypeTeam)
+	require.Nil(t, err)
+	assert.Empty(t, groupTeams)
+
+	_, response = th.Client.DeleteGroup(group.Id)
+	CheckNotFoundStatus(t, response)
+}
+
+func TestModifyGroupMembers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	group, err := th.App.CreateCustomGroup(&model.Group{DisplayName: "dn_" + model.NewId()})
+	require.Nil(t, err)
+
+	_, response := th.Client.UpsertGroupMembers(group.Id, []string{th.BasicUser.Id})
+	CheckForbiddenStatus(t, response)
+
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+	defer th.RemovePermissionFromRole(model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id, model.SYSTEM_USER_ROLE_ID)
+
+	members, response := th.Client.UpsertGroupMembers(group.Id, []string{th.BasicUser.Id, th.BasicUser2.Id})
+	CheckOKStatus(t, response)
+	assert.Len(t, members, 2)
+
+	members, response = th.Client.DeleteGroupMembers(group.Id, []string{th.BasicUser2.Id})
+	CheckOKStatus(t, response)
+	require.Len(t, members, 1)
+	assert.Equal(t, th.BasicUser2.Id, members[0].UserId)
+
+	count, err := th.App.GetGroupMemberCount(group.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, response = th.Client.UpsertGroupMembers(group.Id, []string{"junk"})
+	CheckBadRequestStatus(t, response)
+
+	// The members of LDAP groups come from the group sync
+	_, response = th.Client.UpsertGroupMembers(th.Group.Id, []string{th.BasicUser.Id})
+	CheckBadRequestStatus(t, response)
+	_, response = th.SystemAdminClient.DeleteGroupMembers(th.Group.Id, []string{th.BasicUser.Id})
+	CheckBadRequestStatus(t, response)
+}
+
 func TestLinkGroupTeam(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -6,7 +6,6 @@ package api4
 import (
 	"net/http"
 
-	"github.com/NYTimes/gziphandler"
 	"github.com/mattermost/mattermost-server/v5/web"
 )
 
@@ -26,7 +25,7 @@ func (api *API) ApiHandler(h func(*Context, http.ResponseWriter, *http.Request))
 		IsLocal:             false,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 }
@@ -45,7 +44,7 @@ func (api *API) ApiSessionRequired(h func(*Context, http.ResponseWriter, *http.R
 		IsLocal:             false,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 
@@ -66,7 +65,7 @@ func (api *API) ApiSessionRequiredMfa(h func(*Context, http.ResponseWriter, *htt
 		IsLocal:             false,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 
@@ -87,7 +86,7 @@ func (api *API) ApiHandlerTrustRequester(h func(*Context, http.ResponseWriter, *
 		IsLocal:             false,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 
@@ -107,7 +106,7 @@ func (api *API) ApiSessionRequiredTrustRequester(h func(*Context, http.ResponseW
 		IsLocal:             false,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 
@@ -128,7 +127,7 @@ func (api *API) ApiSessionRequiredDisableWhenBusy(h func(*Context, http.Response
 		DisableWhenBusy:     true,
 	}
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 
@@ -151,7 +150,7 @@ func (api *API) ApiLocal(h func(*Context, http.ResponseWriter, *http.Request)) h
 	}
 
 	if *api.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return web.GzipHandler(api.ConfigService.Config(), handler)
 	}
 	return handler
 }
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestGetChannelPolicies(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("custom_permissions_schemes"))
+
+	th.App.SetPhase2PermissionsMigrationStatus(true)
+
+	scheme, resp := th.SystemAdminClient.CreateScheme(&model.Scheme{
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Scope:       model.SCHEME_SCOPE_CHANNEL,
+	})
+	CheckNoError(t, resp)
+
+	channel := th.CreatePublicChannel()
+	channel.SchemeId = &scheme.Id
+	_, err := th.App.Srv().Store.Channel().Update(channel)
+	require.Nil(t, err)
+
+	t.Run("returns the channels with a scheme", func(t *testing.T) {
+		policies, resp := th.SystemAdminClient.GetChannelPolicies(0, 200)
+		CheckNoError(t, resp)
+
+		var policy *model.ChannelPolicyWithChannelInfo
+		for _, p := range policies {
+			if p.ChannelId == channel.Id {
+				policy = p
+			}
+		}
+
+		require.NotNil(t, policy)
+		assert.Equal(t, channel.DisplayName, policy.ChannelDisplayName)
+		assert.Equal(t, th.BasicTeam.Id, policy.TeamId)
+		assert.Equal(t, th.BasicTeam.DisplayName, policy.TeamDisplayName)
+		assert.Equal(t, scheme.Id, policy.SchemeId)
+		assert.Equal(t, scheme.DefaultChannelUserRole, policy.DefaultChannelUserRole)
+	})
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp := th.Client.GetChannelPolicies(0, 200)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires the permissions migration", func(t *testing.T) {
+		th.App.SetPhase2PermissionsMigrationStatus(false)
+		defer th.App.SetPhase2PermissionsMigrationStatus(true)
+
+		_, resp := th.SystemAdminClient.GetChannelPolicies(0, 200)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
@@ -10,4 +10,5 @@ func (api *API) InitPluginLocal() {
 	api.BaseRoutes.Plugin.Handle("", api.ApiLocal(removePlugin)).Methods("DELETE")
 	api.BaseRoutes.Plugin.Handle("/enable", api.ApiLocal(enablePlugin)).Methods("POST")
 	api.BaseRoutes.Plugin.Handle("/disable", api.ApiLocal(disablePlugin)).Methods("POST")
+	api.BaseRoutes.Plugin.Handle("/config", api.ApiLocal(getPluginConfig)).Methods("GET")
 }
@@ -21,7 +21,6 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
 	"github.com/mattermost/mattermost-server/v5/testlib"
-	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 
 	svg "github.com/h2non/go-is-svg"
@@ -1487,9 +1486,9 @@ func TestInstallMarketplacePlugin(t *testing.T) {
 		prepackagedPluginsDir, found := fileutils.FindDir(prepackagedPluginsDir)
 		require.True(t, found, "failed to find prepackaged plugins directory")
 
-		err = utils.CopyFile(filepath.Join(path, "testplugin.tar.gz"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
+		err = fileutils.CopyFile(filepath.Join(path, "testplugin.tar.gz"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
 		require.NoError(t, err)
-		err = utils.CopyFile(filepath.Join(path, "testplugin.tar.gz.asc"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
+		err = fileutils.CopyFile(filepath.Join(path, "testplugin.tar.gz.asc"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
 		require.NoError(t, err)
 
 		th := SetupConfig(t, func(cfg *model.Config) {
@@ -1620,7 +1619,7 @@ func TestInstallMarketplacePlugin(t *testing.T) {
 		prepackagedPluginsDir, found := fileutils.FindDir(prepackagedPluginsDir)
 		require.True(t, found, "failed to find prepackaged plugins directory")
 
-		err = utils.CopyFile(filepath.Join(path, "testplugin.tar.gz"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
+		err = fileutils.CopyFile(filepath.Join(path, "testplugin.tar.gz"), filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
 		require.NoError(t, err)
 
 		th := SetupConfig(t, func(cfg *model.Config) {
@@ -1701,3 +1700,26 @@ func findClusterMessages(event string, msgs []*model.ClusterMessage) []*model.Cl
 	}
 	return result
 }
+
+func TestGetPluginConfig(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+	})
+
+	_, resp := th.Client.GetPluginConfig("testplugin")
+	CheckForbiddenStatus(t, resp)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp := client.GetPluginConfig("notinstalled")
+		CheckNotFoundStatus(t, resp)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.Enable = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.Enable = true })
+
+		_, resp = client.GetPluginConfig("testplugin")
+		CheckNotImplementedStatus(t, resp)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func (api *API) InitPostShareToken() {
+	api.BaseRoutes.Post.Handle("/share", api.ApiSessionRequired(createPostShareToken)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/post_share_tokens", api.ApiSessionRequired(revokePostShareTokensForChannel)).Methods("DELETE")
+}
+
+func createPostShareToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createPostShareToken", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	post, err := c.App.GetSinglePost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddMeta("channel_id", post.ChannelId)
+
+	// Only members of the channel can share its posts, even if they could otherwise read them.
+	if _, err = c.App.GetChannelMember(post.ChannelId, c.App.Session().UserId); err != nil {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	token, err := c.App.CreatePostShareToken(post, c.App.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("expires_at", token.ExpiresAt)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(token.ToJson()))
+}
+
+func revokePostShareTokensForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("revokePostShareTokensForChannel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	count, err := c.App.RevokePostShareTokensForChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("revoked", count)
+
+	ReturnStatusOK(w)
+}
+
+// sessionHasPostShareToken is the permission check branch for users who can't read the channel of
+// a post, but were given a post share token granting them access to it. Every use of a token is
+// audited, whether it grants access or not.
+func sessionHasPostShareToken(c *Context, r *http.Request, event string, grantsAccess func(token *model.PostShareToken) bool) bool {
+	tokenString := r.URL.Query().Get("share_token")
+	if tokenString == "" {
+		return false
+	}
+
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	token, err := c.App.GetPostShareTokenForSession(*c.App.Session(), tokenString)
+	if err != nil {
+		auditRec.AddMeta("error", err.Id)
+		return false
+	}
+	auditRec.AddMeta("shared_post_id", token.PostId)
+	auditRec.AddMeta("channel_id", token.ChannelId)
+	auditRec.AddMeta("creator_id", token.CreatorId)
+
+	if !grantsAccess(token) {
+		return false
+	}
+
+	auditRec.Success()
+
+	return true
+}
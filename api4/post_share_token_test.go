@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestPostShareTokens(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	enablePostShareTokens := *th.App.Config().ServiceSettings.EnablePostShareTokens
+	includeThread := *th.App.Config().ServiceSettings.PostShareTokenIncludeThread
+	defer func() {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnablePostShareTokens = enablePostShareTokens
+			*cfg.ServiceSettings.PostShareTokenIncludeThread = includeThread
+		})
+	}()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnablePostShareTokens = true
+		*cfg.ServiceSettings.PostShareTokenIncludeThread = true
+	})
+
+	privateChannel := th.CreatePrivateChannel()
+	root := th.CreatePostWithClient(Client, privateChannel)
+	reply, resp := Client.CreatePost(&model.Post{ChannelId: privateChannel.Id, RootId: root.Id, Message: "reply"})
+	CheckNoError(t, resp)
+	otherPost := th.CreatePostWithClient(Client, privateChannel)
+
+	t.Run("share a post with a team member outside the channel", func(t *testing.T) {
+		token, resp := Client.CreatePostShareToken(reply.Id)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, reply.Id, token.PostId)
+		assert.Equal(t, th.BasicTeam.Id, token.TeamId)
+		assert.True(t, token.IncludeThread)
+		assert.True(t, token.ExpiresAt > token.CreateAt)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetPost(reply.Id, "")
+		CheckForbiddenStatus(t, resp)
+
+		post, resp := Client.GetPostWithShareToken(reply.Id, token.Token)
+		CheckNoError(t, resp)
+		assert.Equal(t, reply.Id, post.Id)
+
+		thread, resp := Client.GetPostThreadWithShareToken(root.Id, token.Token)
+		CheckNoError(t, resp)
+		assert.Contains(t, thread.Posts, root.Id)
+		assert.Contains(t, thread.Posts, reply.Id)
+
+		_, resp = Client.GetPostWithShareToken(otherPost.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetPostThreadWithShareToken(otherPost.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetPostWithShareToken(reply.Id, model.NewId())
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("share a post without its thread", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.PostShareTokenIncludeThread = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.PostShareTokenIncludeThread = true })
+
+		token, resp := Client.CreatePostShareToken(reply.Id)
+		CheckNoError(t, resp)
+		assert.False(t, token.IncludeThread)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetPostWithShareToken(reply.Id, token.Token)
+		CheckNoError(t, resp)
+
+		_, resp = Client.GetPostThreadWithShareToken(root.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("users outside the team can't use a token", func(t *testing.T) {
+		token, resp := Client.CreatePostShareToken(root.Id)
+		CheckNoError(t, resp)
+
+		user := th.CreateUser()
+		client := th.CreateClient()
+		_, resp = client.Login(user.Email, user.Password)
+		CheckNoError(t, resp)
+
+		_, resp = client.GetPostWithShareToken(root.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("expired tokens don't grant access", func(t *testing.T) {
+		now := model.GetMillis()
+		token, err := th.App.Srv().Store.PostShareToken().Save(&model.PostShareToken{
+			PostId:    root.Id,
+			ChannelId: privateChannel.Id,
+			TeamId:    th.BasicTeam.Id,
+			CreatorId: th.BasicUser.Id,
+			CreateAt:  now - 60000,
+			ExpiresAt: now - 1000,
+		})
+		require.Nil(t, err)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.GetPostWithShareToken(root.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("only channel members can share a post", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.CreatePostShareToken(root.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CreatePostShareToken(root.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("posts of direct channels can't be shared", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+		post := th.CreatePostWithClient(Client, dm)
+
+		_, resp := Client.CreatePostShareToken(post.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("revoke the tokens of a channel", func(t *testing.T) {
+		token, resp := Client.CreatePostShareToken(root.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.RevokePostShareTokensForChannel(privateChannel.Id)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := th.SystemAdminClient.RevokePostShareTokensForChannel(privateChannel.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetPostWithShareToken(root.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("disabling the feature disables existing tokens", func(t *testing.T) {
+		token, resp := Client.CreatePostShareToken(root.Id)
+		CheckNoError(t, resp)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePostShareTokens = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePostShareTokens = true })
+
+		_, resp = Client.CreatePostShareToken(root.Id)
+		CheckNotImplementedStatus(t, resp)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.GetPostWithShareToken(root.Id, token.Token)
+		CheckForbiddenStatus(t, resp)
+	})
+}
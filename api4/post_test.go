@@ -4,8 +4,10 @@
 package api4
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -210,6 +212,39 @@ func TestCreatePost(t *testing.T) {
 	require.Equal(t, post.CreateAt, rpost.CreateAt, "create at should match")
 }
 
+func TestCreatePostDeduplicate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := &model.Post{
+		ChannelId:     th.BasicChannel.Id,
+		Message:       "message",
+		PendingPostId: model.NewId() + ":" + fmt.Sprint(model.GetMillis()),
+	}
+
+	createPost := func() []byte {
+		t.Helper()
+
+		r, err := th.Client.DoApiPost("/posts", post.ToUnsanitizedJson())
+		require.Nil(t, err)
+		defer r.Body.Close()
+		require.Equal(t, http.StatusCreated, r.StatusCode)
+
+		body, readErr := ioutil.ReadAll(r.Body)
+		require.NoError(t, readErr)
+		return body
+	}
+
+	response := createPost()
+	duplicateResponse := createPost()
+	assert.Equal(t, string(response), string(duplicateResponse), "should have answered the retry as the original request")
+
+	rpost := model.PostFromJson(bytes.NewReader(response))
+	require.NotNil(t, rpost)
+	_, resp := th.Client.GetPost(rpost.Id, "")
+	CheckNoError(t, resp)
+}
+
 func TestCreatePostEphemeral(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2338,6 +2373,65 @@ func TestSearchPostsWithDateFlags(t *testing.T) {
 	require.Len(t, posts.Order, 1, "wrong number of posts")
 }
 
+func TestGetPostEditHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := th.CreatePost()
+	originalMessage := post.Message
+
+	postEdits, resp := Client.GetPostEditHistory(post.Id)
+	CheckNoError(t, resp)
+	require.Empty(t, postEdits)
+
+	_, resp = Client.PatchPost(post.Id, &model.PostPatch{Message: model.NewString("edited once")})
+	CheckNoError(t, resp)
+	time.Sleep(2 * time.Millisecond)
+	_, resp = Client.PatchPost(post.Id, &model.PostPatch{IsPinned: model.NewBool(true)})
+	CheckNoError(t, resp)
+	_, resp = Client.PatchPost(post.Id, &model.PostPatch{Message: model.NewString("edited twice")})
+	CheckNoError(t, resp)
+
+	t.Run("edits that change the message are kept", func(t *testing.T) {
+		postEdits, resp := Client.GetPostEditHistory(post.Id)
+		CheckNoError(t, resp)
+		require.Len(t, postEdits, 2)
+		assert.Equal(t, originalMessage, postEdits[0].Content)
+		assert.Equal(t, "edited once", postEdits[1].Content)
+	})
+
+	t.Run("users who can't read the channel can't get the history", func(t *testing.T) {
+		privatePost := th.CreatePostWithClient(Client, th.BasicPrivateChannel2)
+
+		_, resp := th.SystemAdminClient.GetPostEditHistory(privatePost.Id)
+		CheckNoError(t, resp)
+
+		th.LoginBasic2()
+		_, resp = Client.GetPostEditHistory(privatePost.Id)
+		CheckForbiddenStatus(t, resp)
+		th.LoginBasic()
+	})
+
+	t.Run("the history of a deleted post isn't found", func(t *testing.T) {
+		deletedPost := th.CreatePost()
+		_, resp := Client.PatchPost(deletedPost.Id, &model.PostPatch{Message: model.NewString("edited")})
+		CheckNoError(t, resp)
+		_, resp = Client.DeletePost(deletedPost.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.GetPostEditHistory(deletedPost.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("requires a session", func(t *testing.T) {
+		Client.Logout()
+		_, resp := Client.GetPostEditHistory(post.Id)
+		CheckUnauthorizedStatus(t, resp)
+		th.LoginBasic()
+	})
+}
+
 func TestGetFileInfosForPost(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -101,6 +101,11 @@ func updatePreferences(c *Context, w http.ResponseWriter, r *http.Request) {
 	var sanitizedPreferences model.Preferences
 
 	for _, pref := range preferences {
+		if pref.Category == model.PREFERENCE_CATEGORY_PASSWORD {
+			c.SetInvalidParam("preference.category")
+			return
+		}
+
 		if pref.Category == model.PREFERENCE_CATEGORY_FLAGGED_POST {
 			post, err := c.App.GetSinglePost(pref.Name)
 			if err != nil {
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func (api *API) InitSavedPost() {
+	api.BaseRoutes.SavedPosts.Handle("", api.ApiSessionRequired(getSavedPostsForUser)).Methods("GET")
+	api.BaseRoutes.SavedPosts.Handle("", api.ApiSessionRequired(createSavedPost)).Methods("POST")
+	api.BaseRoutes.SavedPosts.Handle("/labels", api.ApiSessionRequired(getSavedPostLabelsForUser)).Methods("GET")
+	api.BaseRoutes.SavedPost.Handle("/patch", api.ApiSessionRequired(patchSavedPost)).Methods("PUT")
+	api.BaseRoutes.SavedPost.Handle("", api.ApiSessionRequired(deleteSavedPost)).Methods("DELETE")
+}
+
+func getSavedPostsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	// An empty label lists the posts saved without one, while no label at all lists every post.
+	var label *string
+	if labels, ok := r.URL.Query()["label"]; ok && len(labels) > 0 {
+		label = &labels[0]
+	}
+
+	savedPosts, err := c.App.GetSavedPostsForUser(c.Params.UserId, label, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SavedPostsToJson(savedPosts)))
+}
+
+func getSavedPostLabelsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	labels, err := c.App.GetSavedPostLabelsForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ArrayToJson(labels)))
+}
+
+func createSavedPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	savedPost := model.SavedPostFromJson(r.Body)
+	if savedPost == nil {
+		c.SetInvalidParam("saved_post")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createSavedPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("post_id", savedPost.PostId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	post, err := c.App.GetSinglePost(savedPost.PostId)
+	if err != nil {
+		c.SetInvalidParam("saved_post.post_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.App.Session(), post.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	savedPost.UserId = c.Params.UserId
+	savedPost.SavedAt = 0
+
+	rsavedPost, err := c.App.CreateSavedPost(savedPost)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rsavedPost.ToJson()))
+}
+
+func patchSavedPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := model.SavedPostPatchFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("saved_post")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchSavedPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedPost, err := c.App.GetSavedPost(c.Params.UserId, c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	rsavedPost, err := c.App.PatchSavedPost(savedPost, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(rsavedPost.ToJson()))
+}
+
+func deleteSavedPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteSavedPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedPost, err := c.App.GetSavedPost(c.Params.UserId, c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := c.App.DeleteSavedPost(savedPost); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestSavedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("save, list, patch and unsave", func(t *testing.T) {
+		followUp := th.CreatePost()
+		unlabeled := th.CreatePost()
+
+		created, resp := Client.CreateSavedPost(&model.SavedPost{
+			UserId: th.BasicUser.Id,
+			PostId: followUp.Id,
+			Label:  "Follow up",
+		})
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.NotZero(t, created.SavedAt)
+
+		_, resp = Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: unlabeled.Id})
+		CheckNoError(t, resp)
+
+		_, resp = Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: unlabeled.Id})
+		CheckBadRequestStatus(t, resp)
+
+		savedPosts, resp := Client.GetSavedPosts(th.BasicUser.Id, 0, 60)
+		CheckNoError(t, resp)
+		require.Len(t, savedPosts, 2)
+
+		savedPosts, resp = Client.GetSavedPostsWithLabel(th.BasicUser.Id, "Follow up", 0, 60)
+		CheckNoError(t, resp)
+		require.Len(t, savedPosts, 1)
+		assert.Equal(t, followUp.Id, savedPosts[0].PostId)
+
+		savedPosts, resp = Client.GetSavedPostsWithLabel(th.BasicUser.Id, "", 0, 60)
+		CheckNoError(t, resp)
+		require.Len(t, savedPosts, 1)
+		assert.Equal(t, unlabeled.Id, savedPosts[0].PostId)
+
+		labels, resp := Client.GetSavedPostLabels(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, []string{"Follow up"}, labels)
+
+		patched, resp := Client.PatchSavedPost(th.BasicUser.Id, followUp.Id, &model.SavedPostPatch{Note: model.NewString("Ask about the release date")})
+		CheckNoError(t, resp)
+		assert.Equal(t, "Follow up", patched.Label)
+		assert.Equal(t, "Ask about the release date", patched.Note)
+
+		post, resp := Client.GetPost(followUp.Id, "")
+		CheckNoError(t, resp)
+		require.NotNil(t, post.Metadata.SavedPost)
+		assert.Equal(t, "Ask about the release date", post.Metadata.SavedPost.Note)
+
+		flagged, resp := Client.GetFlaggedPostsForUser(th.BasicUser.Id, 0, 60)
+		CheckNoError(t, resp)
+		assert.Contains(t, flagged.Posts, followUp.Id)
+
+		ok, resp := Client.DeleteSavedPost(th.BasicUser.Id, followUp.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		_, resp = Client.DeleteSavedPost(th.BasicUser.Id, followUp.Id)
+		CheckNotFoundStatus(t, resp)
+
+		post, resp = Client.GetPost(followUp.Id, "")
+		CheckNoError(t, resp)
+		assert.Nil(t, post.Metadata.SavedPost)
+
+		flagged, resp = Client.GetFlaggedPostsForUser(th.BasicUser.Id, 0, 60)
+		CheckNoError(t, resp)
+		assert.NotContains(t, flagged.Posts, followUp.Id)
+	})
+
+	t.Run("post in a channel the user can't read", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		post := th.CreatePostWithClient(Client, privateChannel)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp := Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser2.Id, PostId: post.Id})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("missing post", func(t *testing.T) {
+		_, resp := Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: model.NewId()})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other users' saved posts", func(t *testing.T) {
+		post := th.CreatePost()
+		_, resp := Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: post.Id})
+		CheckNoError(t, resp)
+
+		_, resp = Client.GetSavedPosts(th.BasicUser2.Id, 0, 60)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetSavedPostLabels(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser2.Id, PostId: post.Id})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.PatchSavedPost(th.BasicUser2.Id, post.Id, &model.SavedPostPatch{Label: model.NewString("Theirs")})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.DeleteSavedPost(th.BasicUser2.Id, post.Id)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		post2, resp := Client.GetPost(post.Id, "")
+		CheckNoError(t, resp)
+		assert.Nil(t, post2.Metadata.SavedPost)
+	})
+
+	t.Run("deleting a post unsaves it", func(t *testing.T) {
+		post := th.CreatePost()
+		_, resp := Client.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: post.Id})
+		CheckNoError(t, resp)
+
+		th.App.DeleteFlaggedPosts(post.Id)
+
+		_, resp = Client.PatchSavedPost(th.BasicUser.Id, post.Id, &model.SavedPostPatch{Label: model.NewString("Gone")})
+		CheckNotFoundStatus(t, resp)
+	})
+}
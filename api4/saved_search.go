@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func (api *API) InitSavedSearch() {
+	api.BaseRoutes.SavedSearches.Handle("", api.ApiSessionRequired(getSavedSearchesForUser)).Methods("GET")
+	api.BaseRoutes.SavedSearches.Handle("", api.ApiSessionRequired(createSavedSearch)).Methods("POST")
+	api.BaseRoutes.SavedSearch.Handle("", api.ApiSessionRequired(getSavedSearch)).Methods("GET")
+	api.BaseRoutes.SavedSearch.Handle("/patch", api.ApiSessionRequired(patchSavedSearch)).Methods("PUT")
+	api.BaseRoutes.SavedSearch.Handle("", api.ApiSessionRequired(deleteSavedSearch)).Methods("DELETE")
+}
+
+func getSavedSearchesForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedSearches, err := c.App.GetSavedSearchesForUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SavedSearchesToJson(savedSearches)))
+}
+
+func createSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	savedSearch := model.SavedSearchFromJson(r.Body)
+	if savedSearch == nil {
+		c.SetInvalidParam("saved_search")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedSearch.UserId = c.Params.UserId
+
+	rsavedSearch, err := c.App.CreateSavedSearch(savedSearch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("saved_search", rsavedSearch)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(rsavedSearch.ToJson()))
+}
+
+func getSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedSearch := getSavedSearchForUser(c)
+	if c.Err != nil {
+		return
+	}
+
+	w.Write([]byte(savedSearch.ToJson()))
+}
+
+func patchSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := model.SavedSearchPatchFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("saved_search")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("saved_search_id", c.Params.SavedSearchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedSearch := getSavedSearchForUser(c)
+	if c.Err != nil {
+		return
+	}
+
+	rsavedSearch, err := c.App.PatchSavedSearch(savedSearch, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("saved_search", rsavedSearch)
+
+	w.Write([]byte(rsavedSearch.ToJson()))
+}
+
+func deleteSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteSavedSearch", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("saved_search_id", c.Params.SavedSearchId)
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedSearch := getSavedSearchForUser(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.DeleteSavedSearch(savedSearch); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+// getSavedSearchForUser returns the saved search from the URL, making sure it belongs to the user
+// from the URL.
+func getSavedSearchForUser(c *Context) *model.SavedSearch {
+	savedSearch, err := c.App.GetSavedSearch(c.Params.SavedSearchId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if savedSearch.UserId != c.Params.UserId {
+		c.SetInvalidUrlParam("saved_search_id")
+		return nil
+	}
+
+	return savedSearch
+}
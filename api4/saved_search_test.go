@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestSavedSearches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	newSavedSearch := func(name string) *model.SavedSearch {
+		return &model.SavedSearch{
+			UserId: th.BasicUser.Id,
+			TeamId: th.BasicTeam.Id,
+			Name:   name,
+			Terms:  "outage in:" + th.BasicChannel.Name,
+		}
+	}
+
+	t.Run("create, get, patch and delete", func(t *testing.T) {
+		created, resp := Client.CreateSavedSearch(newSavedSearch("Outages"))
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, created.UserId)
+
+		fetched, resp := Client.GetSavedSearch(th.BasicUser.Id, created.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, created, fetched)
+
+		savedSearches, resp := Client.GetSavedSearches(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		require.Len(t, savedSearches, 1)
+		assert.Equal(t, created.Id, savedSearches[0].Id)
+
+		patched, resp := Client.PatchSavedSearch(th.BasicUser.Id, created.Id, &model.SavedSearchPatch{
+			Terms:            model.NewString("outage incident"),
+			NotifyOnNewMatch: model.NewBool(true),
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, "Outages", patched.Name)
+		assert.Equal(t, "outage incident", patched.Terms)
+		assert.True(t, patched.NotifyOnNewMatch)
+		assert.True(t, patched.LastEvaluatedAt >= created.LastEvaluatedAt)
+
+		ok, resp := Client.DeleteSavedSearch(th.BasicUser.Id, created.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		_, resp = Client.GetSavedSearch(th.BasicUser.Id, created.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid terms", func(t *testing.T) {
+		savedSearch := newSavedSearch("Everything")
+		savedSearch.Terms = "*"
+		_, resp := Client.CreateSavedSearch(savedSearch)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("team the user isn't a member of", func(t *testing.T) {
+		savedSearch := newSavedSearch("Elsewhere")
+		savedSearch.TeamId = th.CreateTeamWithClient(th.SystemAdminClient).Id
+		_, resp := Client.CreateSavedSearch(savedSearch)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other users' saved searches", func(t *testing.T) {
+		created, resp := Client.CreateSavedSearch(newSavedSearch("Mine"))
+		CheckNoError(t, resp)
+		defer Client.DeleteSavedSearch(th.BasicUser.Id, created.Id)
+
+		_, resp = Client.GetSavedSearches(th.BasicUser2.Id)
+		CheckForbiddenStatus(t, resp)
+
+		savedSearch := newSavedSearch("Theirs")
+		savedSearch.UserId = th.BasicUser2.Id
+		_, resp = Client.CreateSavedSearch(savedSearch)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetSavedSearch(th.BasicUser2.Id, created.Id)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = Client.DeleteSavedSearch(th.BasicUser.Id, created.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetSavedSearch(th.BasicUser2.Id, created.Id)
+		CheckBadRequestStatus(t, resp)
+
+		savedSearches, resp := th.SystemAdminClient.GetSavedSearches(th.BasicUser.Id)
+		CheckNoError(t, resp)
+		require.Len(t, savedSearches, 1)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		var created []*model.SavedSearch
+		defer func() {
+			for _, savedSearch := range created {
+				Client.DeleteSavedSearch(th.BasicUser.Id, savedSearch.Id)
+			}
+		}()
+
+		for i := 0; i < model.SAVED_SEARCH_MAX_PER_USER; i++ {
+			savedSearch := newSavedSearch(fmt.Sprintf("Search %d", i))
+			savedSearch.NotifyOnNewMatch = i < model.SAVED_SEARCH_MAX_NOTIFY_PER_USER
+			rsavedSearch, resp := Client.CreateSavedSearch(savedSearch)
+			CheckNoError(t, resp)
+			created = append(created, rsavedSearch)
+		}
+
+		_, resp := Client.CreateSavedSearch(newSavedSearch("One too many"))
+		CheckBadRequestStatus(t, resp)
+		require.Equal(t, "app.saved_search.too_many.app_error", resp.Error.Id)
+
+		_, resp = Client.PatchSavedSearch(th.BasicUser.Id, created[len(created)-1].Id, &model.SavedSearchPatch{
+			NotifyOnNewMatch: model.NewBool(true),
+		})
+		CheckBadRequestStatus(t, resp)
+		require.Equal(t, "app.saved_search.too_many_notify.app_error", resp.Error.Id)
+	})
+}
@@ -13,6 +13,7 @@ func (api *API) InitStatus() {
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(getUserStatus)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/status/ids", api.ApiSessionRequired(getUserStatusesByIds)).Methods("POST")
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(updateUserStatus)).Methods("PUT")
+	api.BaseRoutes.Team.Handle("/statuses", api.ApiSessionRequired(getTeamStatuses)).Methods("GET")
 }
 
 func getUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -63,6 +64,31 @@ func getUserStatusesByIds(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.StatusListToJson(statusMap)))
 }
 
+func getTeamStatuses(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	statusMap, err := c.App.GetStatusesByTeam(c.Params.TeamId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	statuses := make([]*model.Status, 0, len(statusMap))
+	for _, status := range statusMap {
+		statuses = append(statuses, status)
+	}
+
+	w.Write([]byte(model.StatusListToJson(statuses)))
+}
+
 func updateUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
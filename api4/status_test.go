@@ -138,6 +138,45 @@ func TestGetUsersStatusesByIds(t *testing.T) {
 	})
 }
 
+func TestGetTeamStatuses(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.SetStatusOnline(th.BasicUser.Id, true)
+	th.App.SetStatusAwayIfNeeded(th.BasicUser2.Id, true)
+
+	outsider := th.CreateUser()
+	th.App.SetStatusOnline(outsider.Id, true)
+
+	t.Run("statuses of the team members", func(t *testing.T) {
+		statuses, resp := Client.GetTeamStatuses(th.BasicTeam.Id)
+		CheckNoError(t, resp)
+
+		statusMap := map[string]string{}
+		for _, status := range statuses {
+			statusMap[status.UserId] = status.Status
+		}
+		assert.Equal(t, model.STATUS_ONLINE, statusMap[th.BasicUser.Id])
+		assert.Equal(t, model.STATUS_AWAY, statusMap[th.BasicUser2.Id])
+		assert.NotContains(t, statusMap, outsider.Id)
+	})
+
+	t.Run("not a member of the team", func(t *testing.T) {
+		team := &model.Team{DisplayName: "Private", Name: GenerateTestTeamName(), Email: th.GenerateTestEmail(), Type: model.TEAM_INVITE}
+		team, resp := th.SystemAdminClient.CreateTeam(team)
+		CheckNoError(t, resp)
+
+		_, resp = Client.GetTeamStatuses(team.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid team id", func(t *testing.T) {
+		_, resp := Client.GetTeamStatuses("junk")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestUpdateUserStatus(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
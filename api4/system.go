@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"archive/zip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,9 +32,14 @@ var redirectLocationDataCache = cache.NewLRU(&cache.LRUOptions{
 })
 
 func (api *API) InitSystem() {
-	api.BaseRoutes.System.Handle("/ping", api.ApiHandler(getSystemPing)).Methods("GET")
+	api.BaseRoutes.System.Handle("/ping", api.ApiHandler(getSystemPing)).Methods("GET", "HEAD")
 
 	api.BaseRoutes.System.Handle("/timezones", api.ApiSessionRequired(getSupportedTimezones)).Methods("GET")
+	api.BaseRoutes.System.Handle("/support_packet", api.ApiSessionRequired(generateSupportPacket)).Methods("POST")
+	api.BaseRoutes.System.Handle("/notifications", api.ApiSessionRequired(getAdminNotifications)).Methods("GET")
+	api.BaseRoutes.System.Handle("/reload_certs", api.ApiSessionRequired(reloadCertificates)).Methods("POST")
+	api.BaseRoutes.System.Handle("/rate_limits", api.ApiSessionRequired(getRateLimitStatus)).Methods("GET")
+	api.BaseRoutes.System.Handle("/rate_limits", api.ApiSessionRequired(resetRateLimit)).Methods("DELETE")
 
 	api.BaseRoutes.ApiRoot.Handle("/audits", api.ApiSessionRequired(getAudits)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/email/test", api.ApiSessionRequired(testEmail)).Methods("POST")
@@ -353,6 +359,129 @@ func getSupportedTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(b)
 }
 
+func getAdminNotifications(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	notifications, err := c.App.GetAdminNotifications(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.AdminNotificationsToJson(notifications)))
+}
+
+func reloadCertificates(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("reloadCertificates", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.ReloadCertificates(); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func getRateLimitStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		c.SetInvalidParam("key")
+		return
+	}
+
+	statuses, err := c.App.GetRateLimitStatus(key, r.URL.Query().Get("header"))
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.RateLimitStatusListToJson(statuses)))
+}
+
+func resetRateLimit(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("resetRateLimit", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		c.SetInvalidParam("key")
+		return
+	}
+	auditRec.AddMeta("key", key)
+
+	if err := c.App.ResetRateLimit(key, r.URL.Query().Get("header")); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func generateSupportPacket(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("generateSupportPacket", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	files, err := c.App.GenerateSupportPacket()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	filename := fmt.Sprintf("mattermost_support_packet_%s.zip", time.Now().Format("2006-01-02-03-04"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment;filename=\""+filename+"\"")
+
+	// The headers have been sent once the archive starts streaming, so failures can only be logged.
+	zipWriter := zip.NewWriter(w)
+	for _, file := range files {
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			mlog.Error("Failed to add a file to the support packet", mlog.String("file", file.Name), mlog.Err(err))
+			return
+		}
+
+		if _, err := fileWriter.Write(file.Data); err != nil {
+			mlog.Error("Failed to write a file to the support packet", mlog.String("file", file.Name), mlog.Err(err))
+			return
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		mlog.Error("Failed to write the support packet", mlog.Err(err))
+		return
+	}
+
+	auditRec.Success()
+}
+
 func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJson(r.Body)
 	if cfg == nil {
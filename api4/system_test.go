@@ -4,6 +4,8 @@
 package api4
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -271,6 +273,48 @@ func TestGetLogs(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGenerateSupportPacket(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GenerateSupportPacket()
+	CheckForbiddenStatus(t, resp)
+
+	data, resp := th.SystemAdminClient.GenerateSupportPacket()
+	CheckNoError(t, resp)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t, names, model.SUPPORT_PACKET_FILE_NAME)
+	assert.Contains(t, names, model.SUPPORT_PACKET_CONFIG_FILE_NAME)
+
+	_, resp = th.SystemAdminClient.GenerateSupportPacket()
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestGetAdminNotifications(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	notification, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, "job failed "+model.NewId())
+	require.Nil(t, err)
+
+	_, resp := th.Client.GetAdminNotifications(0, 10)
+	CheckForbiddenStatus(t, resp)
+
+	notifications, resp := th.SystemAdminClient.GetAdminNotifications(0, 10)
+	CheckNoError(t, resp)
+	require.NotEmpty(t, notifications)
+	assert.Equal(t, notification.Id, notifications[0].Id)
+	assert.Equal(t, notification.Message, notifications[0].Message)
+}
+
 func TestPostLog(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -666,3 +710,23 @@ func TestPushNotificationAck(t *testing.T) {
 		assert.NotNil(t, resp.Body)
 	})
 }
+
+func TestRateLimits(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	_, resp := th.Client.GetRateLimitStatus(th.BasicUser.Id, "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.ResetRateLimit(th.BasicUser.Id, "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetRateLimitStatus("", "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetRateLimitStatus(th.BasicUser.Id, "")
+	CheckNotImplementedStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.ResetRateLimit(th.BasicUser.Id, "")
+	CheckNotImplementedStatus(t, resp)
+}
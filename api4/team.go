@@ -38,6 +38,7 @@ func (api *API) InitTeam() {
 	api.BaseRoutes.Teams.Handle("/search", api.ApiSessionRequiredDisableWhenBusy(searchTeams)).Methods("POST")
 	api.BaseRoutes.TeamsForUser.Handle("", api.ApiSessionRequired(getTeamsForUser)).Methods("GET")
 	api.BaseRoutes.TeamsForUser.Handle("/unread", api.ApiSessionRequired(getTeamsUnreadForUser)).Methods("GET")
+	api.BaseRoutes.TeamsForUser.Handle("/order", api.ApiSessionRequired(updateTeamsOrderForUser)).Methods("PUT")
 
 	api.BaseRoutes.Team.Handle("", api.ApiSessionRequired(getTeam)).Methods("GET")
 	api.BaseRoutes.Team.Handle("", api.ApiSessionRequired(updateTeam)).Methods("PUT")
@@ -430,6 +431,33 @@ func getTeamsUnreadForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.TeamsUnreadToJson(unreadTeamsList)))
 }
 
+func updateTeamsOrderForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.App.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateTeamsOrderForUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	teamIds := model.ArrayFromJson(r.Body)
+
+	order, err := c.App.UpdateTeamsOrderForUser(c.Params.UserId, teamIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	w.Write([]byte(model.ArrayToJson(order)))
+}
+
 func getTeamMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId().RequireUserId()
 	if c.Err != nil {
@@ -519,13 +547,13 @@ func getTeamMembersForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	members, err := c.App.GetTeamMembersForUser(c.Params.UserId)
+	members, err := c.App.GetTeamMembersWithUnreadForUser(c.Params.UserId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	w.Write([]byte(model.TeamMembersToJson(members)))
+	w.Write([]byte(model.TeamMembersWithUnreadToJson(members)))
 }
 
 func getTeamMembersByIds(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -629,7 +657,13 @@ func addTeamMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	member, err = c.App.AddTeamMember(member.TeamId, member.UserId)
+	// A user joining by themself gets the join message rather than one saying they added themself.
+	requestorId := c.App.Session().UserId
+	if requestorId == member.UserId {
+		requestorId = ""
+	}
+
+	member, err = c.App.AddTeamMember(member.TeamId, member.UserId, requestorId)
 
 	if err != nil {
 		c.Err = err
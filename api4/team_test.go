@@ -3126,9 +3126,9 @@ func TestTeamMembersMinusGroupMembers(t *testing.T) {
 	team, err := th.App.UpdateTeam(team)
 	require.Nil(t, err)
 
-	_, err = th.App.AddTeamMember(team.Id, user1.Id)
+	_, err = th.App.AddTeamMember(team.Id, user1.Id, "")
 	require.Nil(t, err)
-	_, err = th.App.AddTeamMember(team.Id, user2.Id)
+	_, err = th.App.AddTeamMember(team.Id, user2.Id, "")
 	require.Nil(t, err)
 
 	group1 := th.CreateGroup()
@@ -3206,3 +3206,31 @@ func TestTeamMembersMinusGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTeamsOrderForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	team2 := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, team2)
+
+	order, resp := Client.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{team2.Id})
+	CheckNoError(t, resp)
+	require.Equal(t, []string{team2.Id, th.BasicTeam.Id}, order)
+
+	members, resp := Client.GetTeamMembersWithUnreadForUser(th.BasicUser.Id, "")
+	CheckNoError(t, resp)
+	require.Len(t, members, 2)
+	require.Equal(t, team2.Id, members[0].TeamId)
+	require.Equal(t, th.BasicTeam.Id, members[1].TeamId)
+
+	_, resp = Client.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{model.NewId()})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.UpdateTeamsOrderForUser(th.BasicUser2.Id, []string{th.BasicTeam.Id})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{th.BasicTeam.Id, team2.Id})
+	CheckNoError(t, resp)
+}
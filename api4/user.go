@@ -58,6 +58,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/auth", api.ApiSessionRequiredTrustRequester(updateUserAuth)).Methods("PUT")
 
 	api.BaseRoutes.Users.Handle("/mfa", api.ApiHandler(checkUserMfa)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/availability", api.ApiHandler(checkUserAvailability)).Methods("POST")
 	api.BaseRoutes.User.Handle("/mfa", api.ApiSessionRequiredMfa(updateUserMfa)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/mfa/generate", api.ApiSessionRequiredMfa(generateMfaSecret)).Methods("POST")
 
@@ -1345,6 +1346,37 @@ func updateUserAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(user.ToJson()))
 }
 
+func checkUserAvailability(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJson(r.Body)
+
+	username := props["username"]
+	email := props["email"]
+	if username == "" && email == "" {
+		c.SetInvalidParam("username")
+		return
+	}
+
+	if username != "" && !model.IsValidUsername(model.NormalizeUsername(username)) {
+		c.SetInvalidParam("username")
+		return
+	}
+
+	if email != "" && !model.IsValidEmail(model.NormalizeEmail(email)) {
+		c.SetInvalidParam("email")
+		return
+	}
+
+	available, err := c.App.IsUsernameOrEmailAvailable(username, email, c.App.IpAddress())
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	// A single result is returned for both, so that a combined check doesn't tell which one is taken.
+	resp := map[string]interface{}{"available": available}
+	w.Write([]byte(model.StringInterfaceToJson(resp)))
+}
+
 // Deprecated: checkUserMfa is deprecated and should not be used anymore, starting with version 6.0 it will be disabled.
 //			   Clients should attempt a login without MFA and will receive a MFA error when it's required.
 func checkUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -1581,7 +1613,7 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 			"api.user.login.inactive.app_error",
 			"api.user.login.not_verified.app_error",
 			"api.user.check_user_login_attempts.too_many.app_error",
-			"app.team.join_user_to_team.max_accounts.app_error",
+			"app.team.max_users.app_error",
 			"store.sql_user.save.max_accounts.app_error",
 		}
 
@@ -2575,6 +2575,99 @@ func TestUpdateUserMfa(t *testing.T) {
 }
 
 // CheckUserMfa is deprecated and should not be used anymore, it will be disabled by default in version 6.0
+func TestCheckUserAvailability(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.EnableUserCreation = true
+		*cfg.PrivacySettings.ShowEmailAddress = true
+	})
+	th.Client.Logout()
+
+	freeUsername := GenerateTestUsername()
+	freeEmail := th.GenerateTestEmail()
+
+	t.Run("username", func(t *testing.T) {
+		available, resp := th.Client.CheckUserAvailability(freeUsername, "")
+		CheckNoError(t, resp)
+		require.True(t, available)
+
+		available, resp = th.Client.CheckUserAvailability(strings.ToUpper(th.BasicUser.Username), "")
+		CheckNoError(t, resp)
+		require.False(t, available)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		available, resp := th.Client.CheckUserAvailability("", freeEmail)
+		CheckNoError(t, resp)
+		require.True(t, available)
+
+		available, resp = th.Client.CheckUserAvailability("", th.BasicUser.Email)
+		CheckNoError(t, resp)
+		require.False(t, available)
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		available, resp := th.Client.CheckUserAvailability(freeUsername, freeEmail)
+		CheckNoError(t, resp)
+		require.True(t, available)
+
+		available, resp = th.Client.CheckUserAvailability(freeUsername, th.BasicUser.Email)
+		CheckNoError(t, resp)
+		require.False(t, available)
+
+		available, resp = th.Client.CheckUserAvailability(th.BasicUser.Username, freeEmail)
+		CheckNoError(t, resp)
+		require.False(t, available)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, resp := th.Client.CheckUserAvailability("", "")
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.Client.CheckUserAvailability("not a username", "")
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.Client.CheckUserAvailability("", "not an email")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("emails hidden", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PrivacySettings.ShowEmailAddress = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PrivacySettings.ShowEmailAddress = true })
+
+		_, resp := th.Client.CheckUserAvailability("", freeEmail)
+		CheckForbiddenStatus(t, resp)
+
+		available, resp := th.Client.CheckUserAvailability(freeUsername, "")
+		CheckNoError(t, resp)
+		require.True(t, available)
+	})
+
+	t.Run("user creation disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableUserCreation = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableUserCreation = true })
+
+		_, resp := th.Client.CheckUserAvailability(freeUsername, "")
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("rate limited without rate limiting enabled", func(t *testing.T) {
+		require.False(t, *th.App.Config().RateLimitSettings.Enable)
+
+		var resp *model.Response
+		for i := 0; i < 100; i++ {
+			if _, resp = th.Client.CheckUserAvailability(freeUsername, ""); resp.Error != nil {
+				break
+			}
+		}
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "app.user.check_availability.too_many_requests.app_error", resp.Error.Id)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+}
+
 func TestCheckUserMfa(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2786,6 +2879,37 @@ func TestUpdateUserPassword(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestUpdateUserPasswordMinimumChangeInterval(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MinimumChangeIntervalInHours = 24 })
+
+	// The password of a new user was set when the account was created.
+	_, resp := th.Client.UpdateUserPassword(th.BasicUser.Id, th.BasicUser.Password, "newpassword1")
+	CheckErrorMessage(t, resp, "api.user.update_password.too_soon.app_error")
+	CheckBadRequestStatus(t, resp)
+
+	// System admins can still reset the password, and users can replace a password set by an admin.
+	_, resp = th.SystemAdminClient.UpdateUserPassword(th.BasicUser.Id, "", "pwdsetbyadmin")
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.UpdateUserPassword(th.BasicUser.Id, "pwdsetbyadmin", "newpassword1")
+	CheckNoError(t, resp)
+
+	_, resp = th.Client.UpdateUserPassword(th.BasicUser.Id, "newpassword1", "newpassword2")
+	CheckErrorMessage(t, resp, "api.user.update_password.too_soon.app_error")
+
+	// The admin's choice of password can't be faked through the preferences.
+	_, resp = th.Client.UpdatePreferences(th.BasicUser.Id, &model.Preferences{{
+		UserId:   th.BasicUser.Id,
+		Category: model.PREFERENCE_CATEGORY_PASSWORD,
+		Name:     model.PREFERENCE_NAME_PASSWORD_SET_BY_ADMIN,
+		Value:    "true",
+	}})
+	CheckBadRequestStatus(t, resp)
+}
+
 func TestResetPassword(t *testing.T) {
 	t.Skip("test disabled during old build server changes, should be investigated")
 
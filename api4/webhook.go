@@ -16,6 +16,10 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(getIncomingHook)).Methods("GET")
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(updateIncomingHook)).Methods("PUT")
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(deleteIncomingHook)).Methods("DELETE")
+	api.BaseRoutes.IncomingHook.Handle("/validate", api.ApiSessionRequired(validateIncomingHookPayload)).Methods("POST")
+	api.BaseRoutes.IncomingHook.Handle("/debug", api.ApiSessionRequired(enableIncomingHookDebugging)).Methods("POST")
+	api.BaseRoutes.IncomingHook.Handle("/debug", api.ApiSessionRequired(disableIncomingHookDebugging)).Methods("DELETE")
+	api.BaseRoutes.IncomingHook.Handle("/recent_requests", api.ApiSessionRequired(getIncomingHookRecentRequests)).Methods("GET")
 
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(createOutgoingHook)).Methods("POST")
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(getOutgoingHooks)).Methods("GET")
@@ -242,6 +246,140 @@ func getIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(hook.ToJson()))
 }
 
+func validateIncomingHookPayload(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	payload, err := model.IncomingWebhookRequestFromJson(r.Body)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	hook, err := c.App.GetIncomingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	channel, err := c.App.GetChannel(hook.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), hook.TeamId, model.PERMISSION_MANAGE_INCOMING_WEBHOOKS) ||
+		(channel.Type != model.CHANNEL_OPEN && !c.App.SessionHasPermissionToChannel(*c.App.Session(), hook.ChannelId, model.PERMISSION_READ_CHANNEL)) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_INCOMING_WEBHOOKS)
+		return
+	}
+
+	if c.App.Session().UserId != hook.UserId && !c.App.SessionHasPermissionToTeam(*c.App.Session(), hook.TeamId, model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS)
+		return
+	}
+
+	preview, err := c.App.PreviewIncomingWebhook(hook, payload)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	for _, previewErr := range preview.Errors {
+		previewErr.Translate(c.App.T)
+	}
+
+	w.Write([]byte(preview.ToJson()))
+}
+
+// getManageableIncomingHook returns the incoming webhook of the request if the session is allowed
+// to manage it, setting c.Err otherwise.
+func getManageableIncomingHook(c *Context) *model.IncomingWebhook {
+	c.RequireHookId()
+	if c.Err != nil {
+		return nil
+	}
+
+	hook, err := c.App.GetIncomingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	channel, err := c.App.GetChannel(hook.ChannelId)
+	if err != nil {
+		c.Err = err
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), hook.TeamId, model.PERMISSION_MANAGE_INCOMING_WEBHOOKS) ||
+		(channel.Type != model.CHANNEL_OPEN && !c.App.SessionHasPermissionToChannel(*c.App.Session(), hook.ChannelId, model.PERMISSION_READ_CHANNEL)) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_INCOMING_WEBHOOKS)
+		return nil
+	}
+
+	if c.App.Session().UserId != hook.UserId && !c.App.SessionHasPermissionToTeam(*c.App.Session(), hook.TeamId, model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS)
+		return nil
+	}
+
+	return hook
+}
+
+func enableIncomingHookDebugging(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("enableIncomingHookDebugging", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("hook_id", c.Params.HookId)
+
+	hook := getManageableIncomingHook(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("hook", hook)
+
+	session, err := c.App.EnableIncomingWebhookDebugging(hook.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Write([]byte(session.ToJson()))
+}
+
+func disableIncomingHookDebugging(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("disableIncomingHookDebugging", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("hook_id", c.Params.HookId)
+
+	hook := getManageableIncomingHook(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("hook", hook)
+
+	if err := c.App.DisableIncomingWebhookDebugging(hook.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func getIncomingHookRecentRequests(c *Context, w http.ResponseWriter, r *http.Request) {
+	hook := getManageableIncomingHook(c)
+	if c.Err != nil {
+		return
+	}
+
+	w.Write([]byte(c.App.GetIncomingWebhookDebugSession(hook.Id).ToJson()))
+}
+
 func deleteIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
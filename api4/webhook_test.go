@@ -4,6 +4,8 @@
 package api4
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -292,6 +294,181 @@ func TestGetIncomingWebhook(t *testing.T) {
 	})
 }
 
+func TestValidateIncomingWebhookPayload(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.SystemAdminClient
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, resp := Client.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+
+	t.Run("should return the preview without posting", func(t *testing.T) {
+		preview, resp := Client.ValidateIncomingWebhookPayload(hook.Id, &model.IncomingWebhookRequest{Text: "preview text"})
+		CheckNoError(t, resp)
+
+		require.Len(t, preview.Posts, 1)
+		assert.Equal(t, "preview text", preview.Posts[0].Message)
+		assert.Equal(t, th.BasicChannel.Id, preview.Posts[0].ChannelId)
+		assert.Empty(t, preview.Errors)
+
+		posts, resp := Client.GetPostsForChannel(th.BasicChannel.Id, 0, 10, "")
+		CheckNoError(t, resp)
+		for _, post := range posts.Posts {
+			assert.NotEqual(t, "preview text", post.Message)
+		}
+	})
+
+	t.Run("should return validation errors", func(t *testing.T) {
+		payload := &model.IncomingWebhookRequest{
+			Attachments: []*model.SlackAttachment{{Fields: []*model.SlackAttachmentField{{Title: "title"}, {}}}},
+		}
+		preview, resp := Client.ValidateIncomingWebhookPayload(hook.Id, payload)
+		CheckNoError(t, resp)
+
+		require.Len(t, preview.Errors, 1)
+		assert.Equal(t, "web.incoming_webhook.preview.attachment_field.app_error", preview.Errors[0].Id)
+		assert.NotEqual(t, preview.Errors[0].Id, preview.Errors[0].Message)
+	})
+
+	t.Run("should fail for a missing hook", func(t *testing.T) {
+		_, resp := Client.ValidateIncomingWebhookPayload(model.NewId(), &model.IncomingWebhookRequest{Text: "text"})
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("should fail without permissions", func(t *testing.T) {
+		_, resp := th.Client.ValidateIncomingWebhookPayload(hook.Id, &model.IncomingWebhookRequest{Text: "text"})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestIncomingWebhookDebugging(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	Client := th.SystemAdminClient
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, resp := Client.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+	otherHook, resp := Client.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+
+	hookUrl := Client.Url + "/hooks/" + hook.Id
+	postToHook := func(url, contentType, body string) {
+		httpResp, err := http.Post(url, contentType, strings.NewReader(body))
+		require.Nil(t, err)
+		httpResp.Body.Close()
+	}
+
+	t.Run("should not capture requests before debugging is enabled", func(t *testing.T) {
+		postToHook(hookUrl, "application/json", `{"text": "not captured"}`)
+
+		session, resp := Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckNoError(t, resp)
+		assert.Zero(t, session.ExpiresAt)
+		assert.Empty(t, session.Requests)
+	})
+
+	t.Run("should capture accepted and rejected requests", func(t *testing.T) {
+		session, resp := Client.EnableIncomingWebhookDebugging(hook.Id)
+		CheckNoError(t, resp)
+		assert.Greater(t, session.ExpiresAt, model.GetMillis())
+
+		req, err := http.NewRequest("POST", hookUrl, strings.NewReader(`{"text": "captured"}`))
+		require.Nil(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer secret")
+		httpResp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		httpResp.Body.Close()
+		require.Equal(t, http.StatusOK, httpResp.StatusCode)
+
+		postToHook(hookUrl, "application/json", `{"text": `)
+		postToHook(hookUrl, "application/json", `{"text": "captured", "channel": "junk"}`)
+		postToHook(Client.Url+"/hooks/"+otherHook.Id, "application/json", `{"text": "other hook"}`)
+
+		session, resp = Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckNoError(t, resp)
+		require.Len(t, session.Requests, 3)
+
+		accepted := session.Requests[0]
+		assert.Equal(t, http.StatusOK, accepted.StatusCode)
+		assert.Equal(t, `{"text": "captured"}`, accepted.Body)
+		assert.Contains(t, string(accepted.Payload), `"text":"captured"`)
+		assert.Equal(t, model.FAKE_SETTING, accepted.Headers["Authorization"])
+		assert.Empty(t, accepted.ErrorId)
+
+		badJson := session.Requests[1]
+		assert.Equal(t, http.StatusBadRequest, badJson.StatusCode)
+		assert.Equal(t, "model.incoming_hook.parse_data.app_error", badJson.ErrorId)
+		assert.Empty(t, badJson.Payload)
+
+		badChannel := session.Requests[2]
+		assert.NotEqual(t, http.StatusOK, badChannel.StatusCode)
+		assert.NotEmpty(t, badChannel.ErrorId)
+		assert.Contains(t, string(badChannel.Payload), `"channel":"junk"`)
+
+		session, resp = Client.GetIncomingWebhookRecentRequests(otherHook.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, session.Requests)
+	})
+
+	t.Run("should cap the stored body", func(t *testing.T) {
+		postToHook(hookUrl, "application/json", `{"text": "`+strings.Repeat("a", model.INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE)+`"}`)
+
+		session, resp := Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckNoError(t, resp)
+		capture := session.Requests[len(session.Requests)-1]
+		assert.True(t, capture.BodyTruncated)
+		assert.Len(t, capture.Body, model.INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE)
+		assert.Equal(t, http.StatusOK, capture.StatusCode)
+	})
+
+	t.Run("should keep only the most recent requests", func(t *testing.T) {
+		for i := 0; i < model.INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS; i++ {
+			postToHook(hookUrl, "application/json", `{"text": "recent"}`)
+		}
+
+		session, resp := Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckNoError(t, resp)
+		require.Len(t, session.Requests, model.INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS)
+		for _, capture := range session.Requests {
+			assert.Equal(t, `{"text": "recent"}`, capture.Body)
+		}
+	})
+
+	t.Run("should fail without permissions", func(t *testing.T) {
+		_, resp := th.Client.EnableIncomingWebhookDebugging(hook.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.DisableIncomingWebhookDebugging(hook.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should fail for a missing hook", func(t *testing.T) {
+		_, resp := Client.GetIncomingWebhookRecentRequests(model.NewId())
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("should discard the captured requests when disabled", func(t *testing.T) {
+		ok, resp := Client.DisableIncomingWebhookDebugging(hook.Id)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+
+		postToHook(hookUrl, "application/json", `{"text": "not captured"}`)
+
+		session, resp := Client.GetIncomingWebhookRecentRequests(hook.Id)
+		CheckNoError(t, resp)
+		assert.Zero(t, session.ExpiresAt)
+		assert.Empty(t, session.Requests)
+	})
+}
+
 func TestDeleteIncomingWebhook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5,6 +5,8 @@ package api4
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/gorilla/websocket"
 	"github.com/mattermost/mattermost-server/v5/mlog"
@@ -20,7 +22,7 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  model.SOCKET_MAX_MESSAGE_SIZE_KB,
 		WriteBufferSize: model.SOCKET_MAX_MESSAGE_SIZE_KB,
-		CheckOrigin:     c.App.OriginChecker(),
+		CheckOrigin:     countOriginCheckFailures(c, c.App.OriginChecker()),
 	}
 
 	ws, err := upgrader.Upgrade(w, r, nil)
@@ -31,10 +33,55 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	wc := c.App.NewWebConn(ws, *c.App.Session(), c.App.T, "")
+	wc.SetCapabilities(strings.Split(r.URL.Query().Get("capabilities"), ","))
+	if rateLimiter := c.App.Srv().RateLimiter; rateLimiter != nil {
+		wc.SetRateLimitKey(rateLimiter.GenerateKey(r))
+	}
 
 	if len(c.App.Session().UserId) > 0 {
 		c.App.HubRegister(wc)
 	}
 
+	if metrics := c.App.Metrics(); metrics != nil {
+		metrics.IncrementWebSocketConnect()
+		defer metrics.IncrementWebSocketDisconnect()
+	}
+
 	wc.Pump()
 }
+
+// countOriginCheckFailures wraps the origin check of the websocket upgrader to count the requests it
+// rejects. Without a check, the upgrader only accepts requests from the same origin as the host.
+func countOriginCheckFailures(c *Context, checkOrigin func(*http.Request) bool) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		var allowed bool
+		if checkOrigin != nil {
+			allowed = checkOrigin(r)
+		} else {
+			allowed = isSameOrigin(r)
+		}
+
+		if !allowed {
+			if metrics := c.App.Metrics(); metrics != nil {
+				metrics.IncrementWebSocketOriginCheckFailure()
+			}
+		}
+
+		return allowed
+	}
+}
+
+// isSameOrigin is the origin check the websocket upgrader uses when none is given.
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
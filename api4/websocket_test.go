@@ -215,34 +215,42 @@ func TestWebsocketOriginSecurity(t *testing.T) {
 	require.Nil(t, err, err)
 
 	// Should succeed now because open CORS
-	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "*" })
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{{Origin: model.NewString("*")}}
+	})
 	_, _, err = websocket.DefaultDialer.Dial(url+model.API_URL_SUFFIX+"/websocket", http.Header{
 		"Origin": []string{"http://www.evil.com"},
 	})
 	require.Nil(t, err, err)
 
 	// Should succeed now because matching CORS
-	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "http://www.evil.com" })
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{{Origin: model.NewString("http://www.evil.com")}}
+	})
 	_, _, err = websocket.DefaultDialer.Dial(url+model.API_URL_SUFFIX+"/websocket", http.Header{
 		"Origin": []string{"http://www.evil.com"},
 	})
 	require.Nil(t, err, err)
 
 	// Should fail because non-matching CORS
-	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "http://www.good.com" })
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{{Origin: model.NewString("http://www.good.com")}}
+	})
 	_, _, err = websocket.DefaultDialer.Dial(url+model.API_URL_SUFFIX+"/websocket", http.Header{
 		"Origin": []string{"http://www.evil.com"},
 	})
-	require.NotNil(t, err, "Should have errored because Origin contain AllowCorsFrom")
+	require.NotNil(t, err, "Should have errored because Origin is not in CorsOrigins")
 
 	// Should fail because non-matching CORS
-	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "http://www.good.com" })
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{{Origin: model.NewString("http://www.good.com")}}
+	})
 	_, _, err = websocket.DefaultDialer.Dial(url+model.API_URL_SUFFIX+"/websocket", http.Header{
 		"Origin": []string{"http://www.good.co"},
 	})
 	require.NotNil(t, err, "Should have errored because Origin does not match host! SECURITY ISSUE!")
 
-	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowCorsFrom = "" })
+	th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{} })
 }
 
 func TestWebSocketStatuses(t *testing.T) {
@@ -385,3 +393,98 @@ func TestWebSocketStatuses(t *testing.T) {
 
 	WebSocketClient.Close()
 }
+
+func TestWebSocketCreatePost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	connect := func(t *testing.T, capabilities []string) (*model.WebSocketClient, []interface{}) {
+		client, err := model.NewWebSocketClientWithCapabilities(fmt.Sprintf("ws://localhost:%v", th.App.Srv().ListenAddr.Port), th.Client.AuthToken, capabilities)
+		require.Nil(t, err)
+		client.Listen()
+
+		resp := <-client.ResponseChannel
+		require.Equal(t, model.STATUS_OK, resp.Status)
+
+		for event := range client.EventChannel {
+			if event.EventType() == model.WEBSOCKET_EVENT_HELLO {
+				granted, _ := event.GetData()["capabilities"].([]interface{})
+				return client, granted
+			}
+		}
+		return client, nil
+	}
+
+	post := &model.Post{ChannelId: th.BasicChannel.Id, Message: "websocket post"}
+
+	t.Run("not enabled", func(t *testing.T) {
+		client, granted := connect(t, []string{model.WEBSOCKET_CAPABILITY_CREATE_POST})
+		defer client.Close()
+		require.Empty(t, granted)
+
+		client.CreatePost(post)
+		resp := <-client.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "api.websocket_handler.create_post.disabled.app_error", resp.Error.Id)
+		require.Equal(t, client.Sequence-1, resp.SeqReply)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebSocketPostCreation = true })
+
+	t.Run("capability not asked for", func(t *testing.T) {
+		client, granted := connect(t, nil)
+		defer client.Close()
+		require.Empty(t, granted)
+
+		client.CreatePost(post)
+		resp := <-client.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "api.websocket_handler.create_post.disabled.app_error", resp.Error.Id)
+	})
+
+	client, granted := connect(t, []string{"unknown", model.WEBSOCKET_CAPABILITY_CREATE_POST})
+	defer client.Close()
+	require.Equal(t, []interface{}{model.WEBSOCKET_CAPABILITY_CREATE_POST}, granted)
+
+	t.Run("creates the post", func(t *testing.T) {
+		client.CreatePost(post)
+		resp := <-client.ResponseChannel
+		require.Nil(t, resp.Error)
+		require.Equal(t, client.Sequence-1, resp.SeqReply)
+
+		rpost, ok := resp.Data["post"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, post.Message, rpost["message"])
+		require.Equal(t, th.BasicUser.Id, rpost["user_id"])
+
+		created, appErr := th.App.GetSinglePost(rpost["id"].(string))
+		require.Nil(t, appErr)
+		require.Equal(t, post.Message, created.Message)
+	})
+
+	t.Run("without permission to the channel", func(t *testing.T) {
+		client.CreatePost(&model.Post{ChannelId: th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE).Id, Message: "message"})
+		resp := <-client.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.Error.StatusCode)
+	})
+
+	t.Run("message too long", func(t *testing.T) {
+		client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: strings.Repeat("a", model.POST_MESSAGE_MAX_RUNES_V2+1)})
+		resp := <-client.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.Error.StatusCode)
+	})
+
+	t.Run("message over the read limit", func(t *testing.T) {
+		client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: strings.Repeat("a", model.SOCKET_MAX_MESSAGE_SIZE_KB+th.App.MaxPostSize()*6)})
+		resp := <-client.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "api.web_socket.read.message_too_large.app_error", resp.Error.Id)
+		require.Equal(t, client.Sequence-1, resp.SeqReply)
+
+		client.CreatePost(post)
+		resp = <-client.ResponseChannel
+		require.Nil(t, resp.Error, "the connection should still be usable")
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+const (
+	// Admins are notified daily once the license expires within this period.
+	LICENSE_EXPIRING_NOTIFICATION_PERIOD = 30 * dayInMilliseconds
+)
+
+// NotifyAdmins records an event that system admins should know about and posts it to the admin alerts
+// channel, if one is configured. An event identical to one raised within the deduplication window
+// increments the count of the existing notification and updates its post instead of creating a new one.
+func (a *App) NotifyAdmins(notificationType, message string) (*model.AdminNotification, *model.AppError) {
+	if runes := []rune(message); len(runes) > model.ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES {
+		message = string(runes[:model.ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES])
+	}
+
+	a.Srv().adminNotificationLock.Lock()
+	defer a.Srv().adminNotificationLock.Unlock()
+
+	since := model.GetMillis() - model.ADMIN_NOTIFICATION_DEDUPLICATION_WINDOW
+	notification, err := a.Srv().Store.AdminNotification().GetLatest(notificationType, message, since)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return nil, model.NewAppError("NotifyAdmins", "app.admin_notification.get_latest.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if notification == nil {
+		notification = &model.AdminNotification{
+			Type:    notificationType,
+			Message: message,
+		}
+		a.postAdminNotification(notification)
+
+		notification, err = a.Srv().Store.AdminNotification().Save(notification)
+		if err != nil {
+			return nil, adminNotificationAppError("NotifyAdmins", "app.admin_notification.save.app_error", err)
+		}
+
+		return notification, nil
+	}
+
+	notification.Count++
+	a.postAdminNotification(notification)
+
+	notification, err = a.Srv().Store.AdminNotification().Update(notification)
+	if err != nil {
+		return nil, adminNotificationAppError("NotifyAdmins", "app.admin_notification.update.app_error", err)
+	}
+
+	return notification, nil
+}
+
+func adminNotificationAppError(where, id string, err error) *model.AppError {
+	var appErr *model.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return model.NewAppError(where, id, nil, err.Error(), http.StatusInternalServerError)
+}
+
+// GetAdminNotifications returns a page of the admin notifications, most recently raised first.
+func (a *App) GetAdminNotifications(page, perPage int) ([]*model.AdminNotification, *model.AppError) {
+	notifications, err := a.Srv().Store.AdminNotification().GetAll(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetAdminNotifications", "app.admin_notification.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return notifications, nil
+}
+
+// postAdminNotification posts the notification to the admin alerts channel, or updates its existing
+// post with the new count. Failing to post is only logged, so that the event is still recorded.
+func (a *App) postAdminNotification(notification *model.AdminNotification) {
+	channelId := *a.Config().ServiceSettings.AdminAlertsChannelId
+	if channelId == "" {
+		return
+	}
+
+	message := notification.Message
+	if notification.Count > 1 {
+		message = fmt.Sprintf("%s\n%s", message, utils.T("app.admin_notification.repeated", map[string]interface{}{"Count": notification.Count}))
+	}
+
+	if notification.PostId != "" {
+		post, err := a.GetSinglePost(notification.PostId)
+		if err == nil && post.ChannelId == channelId {
+			post = post.Clone()
+			post.Message = message
+			if _, err = a.UpdatePost(post, false); err == nil {
+				return
+			}
+		}
+		if err != nil {
+			mlog.Warn("Failed to update admin notification post, creating a new one", mlog.String("post_id", notification.PostId), mlog.Err(err))
+		}
+	}
+
+	post, err := a.SendSystemBotChannelPost(channelId, message, nil)
+	if err != nil {
+		mlog.Error("Failed to post admin notification", mlog.String("channel_id", channelId), mlog.Err(err))
+		return
+	}
+
+	notification.PostId = post.Id
+}
+
+func (a *App) notifyAdminsOfPluginHealthCheckFailure(pluginId string, deactivated bool, err error) {
+	id := "app.admin_notification.plugin_restarted"
+	if deactivated {
+		id = "app.admin_notification.plugin_deactivated"
+	}
+
+	message := utils.T(id, map[string]interface{}{"PluginId": pluginId, "Error": err.Error()})
+	if _, appErr := a.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message); appErr != nil {
+		mlog.Error("Failed to notify admins of a plugin health check failure", mlog.String("plugin_id", pluginId), mlog.Err(appErr))
+	}
+}
+
+func (a *App) notifyAdminsOfJobFailure(job *model.Job, jobError *model.AppError) {
+	// The detailed error usually differs between runs, so it is left out to let repeated failures collapse.
+	translated := *jobError
+	translated.Translate(utils.T)
+
+	message := utils.T("app.admin_notification.job_failed", map[string]interface{}{"JobType": job.Type, "Error": translated.Message})
+	if _, appErr := a.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, message); appErr != nil {
+		mlog.Error("Failed to notify admins of a job failure", mlog.String("job_id", job.Id), mlog.Err(appErr))
+	}
+}
+
+func (a *App) notifyAdminsOfLicenseExpiration(license *model.License) {
+	remaining := license.ExpiresAt - model.GetMillis()
+	if remaining >= LICENSE_EXPIRING_NOTIFICATION_PERIOD {
+		return
+	}
+
+	message := utils.T("app.admin_notification.license_expired")
+	if remaining > 0 {
+		message = utils.T("app.admin_notification.license_expiring", map[string]interface{}{"Days": remaining/dayInMilliseconds + 1})
+	}
+
+	if _, appErr := a.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_LICENSE_EXPIRING, message); appErr != nil {
+		mlog.Error("Failed to notify admins of the license expiration", mlog.Err(appErr))
+	}
+}
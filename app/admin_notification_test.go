@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestNotifyAdmins(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("should only record the notification without an admin alerts channel", func(t *testing.T) {
+		notification, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, "job failed "+model.NewId())
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), notification.Count)
+		assert.Empty(t, notification.PostId)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AdminAlertsChannelId = th.BasicChannel.Id
+	})
+
+	t.Run("should post the notification and collapse repeated ones", func(t *testing.T) {
+		message := "plugin failed " + model.NewId()
+
+		notification, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message)
+		require.Nil(t, err)
+		require.NotEmpty(t, notification.PostId)
+
+		post, err := th.App.GetSinglePost(notification.PostId)
+		require.Nil(t, err)
+		assert.Equal(t, th.BasicChannel.Id, post.ChannelId)
+		assert.Equal(t, message, post.Message)
+
+		bot, err := th.App.GetUserByUsername(model.SYSTEM_BOT_USERNAME)
+		require.Nil(t, err)
+		assert.Equal(t, bot.Id, post.UserId)
+
+		repeated, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message)
+		require.Nil(t, err)
+		assert.Equal(t, notification.Id, repeated.Id)
+		assert.Equal(t, int64(2), repeated.Count)
+		assert.Equal(t, notification.PostId, repeated.PostId)
+
+		post, err = th.App.GetSinglePost(notification.PostId)
+		require.Nil(t, err)
+		assert.Contains(t, post.Message, message)
+		assert.NotEqual(t, message, post.Message)
+	})
+
+	t.Run("should not collapse notifications outside of the deduplication window", func(t *testing.T) {
+		message := "job failed " + model.NewId()
+
+		notification, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, message)
+		require.Nil(t, err)
+
+		// Updating the notification through the store would reset its update time.
+		_, nErr := th.GetSqlSupplier().GetMaster().Exec("UPDATE AdminNotifications SET UpdateAt = :UpdateAt WHERE Id = :Id", map[string]interface{}{"UpdateAt": model.GetMillis() - model.ADMIN_NOTIFICATION_DEDUPLICATION_WINDOW - 1, "Id": notification.Id})
+		require.Nil(t, nErr)
+
+		next, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, message)
+		require.Nil(t, err)
+		assert.NotEqual(t, notification.Id, next.Id)
+		assert.Equal(t, int64(1), next.Count)
+		assert.NotEqual(t, notification.PostId, next.PostId)
+	})
+
+	t.Run("should be listed most recent first", func(t *testing.T) {
+		notification, err := th.App.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_CONFIG_SAVE_FAILED, "config save failed "+model.NewId())
+		require.Nil(t, err)
+
+		notifications, err := th.App.GetAdminNotifications(0, 1)
+		require.Nil(t, err)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, notification.Id, notifications[0].Id)
+	})
+}
@@ -43,18 +43,32 @@ func (a *App) GetAnalytics(name string, teamId string) (model.AnalyticsRows, *mo
 		rows[9] = &model.AnalyticsRow{Name: "monthly_active_users", Value: 0}
 		rows[10] = &model.AnalyticsRow{Name: "inactive_user_count", Value: 0}
 
-		openChan := make(chan store.StoreResult, 1)
-		privateChan := make(chan store.StoreResult, 1)
-		go func() {
-			count, err2 := a.Srv().Store.Channel().AnalyticsTypeCount(teamId, model.CHANNEL_OPEN)
-			openChan <- store.StoreResult{Data: count, Err: err2}
-			close(openChan)
-		}()
-		go func() {
-			count, err2 := a.Srv().Store.Channel().AnalyticsTypeCount(teamId, model.CHANNEL_PRIVATE)
-			privateChan <- store.StoreResult{Data: count, Err: err2}
-			close(privateChan)
-		}()
+		// The channel and post counts of a team come from a single query, unless the post count
+		// must be skipped.
+		var teamStatsChan chan store.StoreResult
+		var openChan chan store.StoreResult
+		var privateChan chan store.StoreResult
+		if teamId != "" && !skipIntensiveQueries {
+			teamStatsChan = make(chan store.StoreResult, 1)
+			go func() {
+				stats, err2 := a.Srv().Store.Team().GetTeamStats(teamId)
+				teamStatsChan <- store.StoreResult{Data: stats, NErr: err2}
+				close(teamStatsChan)
+			}()
+		} else {
+			openChan = make(chan store.StoreResult, 1)
+			privateChan = make(chan store.StoreResult, 1)
+			go func() {
+				count, err2 := a.Srv().Store.Channel().AnalyticsTypeCount(teamId, model.CHANNEL_OPEN)
+				openChan <- store.StoreResult{Data: count, Err: err2}
+				close(openChan)
+			}()
+			go func() {
+				count, err2 := a.Srv().Store.Channel().AnalyticsTypeCount(teamId, model.CHANNEL_PRIVATE)
+				privateChan <- store.StoreResult{Data: count, Err: err2}
+				close(privateChan)
+			}()
+		}
 
 		var userChan chan store.StoreResult
 		var userInactiveChan chan store.StoreResult
@@ -75,7 +89,7 @@ func (a *App) GetAnalytics(name string, teamId string) (model.AnalyticsRows, *mo
 		}
 
 		var postChan chan store.StoreResult
-		if !skipIntensiveQueries {
+		if teamStatsChan == nil && !skipIntensiveQueries {
 			postChan = make(chan store.StoreResult, 1)
 			go func() {
 				count, err2 := a.Srv().Store.Post().AnalyticsPostCount(teamId, false, false)
@@ -105,26 +119,38 @@ func (a *App) GetAnalytics(name string, teamId string) (model.AnalyticsRows, *mo
 			close(monthlyActiveChan)
 		}()
 
-		r := <-openChan
-		if r.Err != nil {
-			return nil, r.Err
-		}
-		rows[0].Value = float64(r.Data.(int64))
-
-		r = <-privateChan
-		if r.Err != nil {
-			return nil, r.Err
-		}
-		rows[1].Value = float64(r.Data.(int64))
-
-		if postChan == nil {
-			rows[2].Value = -1
+		var r store.StoreResult
+		if teamStatsChan != nil {
+			r = <-teamStatsChan
+			if r.NErr != nil {
+				return nil, model.NewAppError("GetAnalytics", "app.team.get_stats.app_error", nil, r.NErr.Error(), http.StatusInternalServerError)
+			}
+			stats := r.Data.(*model.TeamStats)
+			rows[0].Value = float64(stats.OpenChannelCount)
+			rows[1].Value = float64(stats.PrivateChannelCount)
+			rows[2].Value = float64(stats.PostCount)
 		} else {
-			r = <-postChan
+			r = <-openChan
 			if r.Err != nil {
 				return nil, r.Err
 			}
-			rows[2].Value = float64(r.Data.(int64))
+			rows[0].Value = float64(r.Data.(int64))
+
+			r = <-privateChan
+			if r.Err != nil {
+				return nil, r.Err
+			}
+			rows[1].Value = float64(r.Data.(int64))
+
+			if postChan == nil {
+				rows[2].Value = -1
+			} else {
+				r = <-postChan
+				if r.Err != nil {
+					return nil, r.Err
+				}
+				rows[2].Value = float64(r.Data.(int64))
+			}
 		}
 
 		if userChan == nil {
@@ -108,6 +108,9 @@ func (a *App) InitServer() {
 			a.Srv().Go(func() {
 				runLicenseExpirationCheckJob(a)
 			})
+			a.Srv().Go(func() {
+				runSavedSearchEvaluationJob(a)
+			})
 		}
 		a.srv.RunJobs()
 	})
@@ -127,6 +130,15 @@ func (a *App) initJobs() {
 	if jobsExpiryNotifyInterface != nil {
 		a.srv.Jobs.ExpiryNotify = jobsExpiryNotifyInterface(a)
 	}
+	if jobsInactiveChannelArchiveInterface != nil {
+		a.srv.Jobs.InactiveChannelArchive = jobsInactiveChannelArchiveInterface(a)
+	}
+	if jobsEditRetentionInterface != nil {
+		a.srv.Jobs.EditRetention = jobsEditRetentionInterface(a)
+	}
+	if jobsChannelMembersDriftInterface != nil {
+		a.srv.Jobs.ChannelMembersDrift = jobsChannelMembersDriftInterface(a)
+	}
 
 	a.srv.Jobs.Workers = a.srv.Jobs.InitWorkers()
 	a.srv.Jobs.Schedulers = a.srv.Jobs.InitSchedulers()
@@ -22,6 +22,7 @@ import (
 	"github.com/mattermost/go-i18n/i18n"
 	goi18n "github.com/mattermost/go-i18n/i18n"
 	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/config"
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -49,10 +50,25 @@ type AppIface interface {
 	AddCursorIdsForPostList(originalList *model.PostList, afterPost, beforePost string, since int64, page, perPage int)
 	// AddPublicKey will add plugin public key to the config. Overwrites the previous file
 	AddPublicKey(name string, key io.Reader) *model.AppError
+	// AdminClientConfigWithComputed gets the configuration in a format suitable for sending to a
+	// system admin's client, which includes details about the deployment other users don't receive.
+	AdminClientConfigWithComputed() map[string]string
+	// ArchiveChannels archives each of the given channels on behalf of the session user, who needs the
+	// permission to delete it. A channel that is skipped or fails doesn't stop the others, and the
+	// outcome for each channel is returned in the given order. Default channels are never archived.
+	ArchiveChannels(channelIds []string) ([]*model.ChannelArchiveResult, *model.AppError)
+	// ArchiveInactiveChannelsForTeam goes through the public and private channels of the team that have
+	// had no posts for the configured number of days. Channels are first warned with a system message and
+	// archived once the warning period has elapsed without new posts. The display names of the archived
+	// channels are sent to the team admins and returned.
+	ArchiveInactiveChannelsForTeam(team *model.Team) ([]string, *model.AppError)
 	// Basic test team and user so you always know one
 	CreateBasicUser(client *model.Client4) *model.AppError
 	// Caller must close the first return value
 	FileReader(path string) (filesstore.ReadCloseSeeker, *model.AppError)
+	// CaptureIncomingWebhookRequest records a request received by an incoming webhook, if debugging is
+	// enabled for it.
+	CaptureIncomingWebhookRequest(hookId string, capture *model.IncomingWebhookRequestCapture)
 	// ChannelMembersMinusGroupMembers returns the set of users in the given channel minus the set of users in the given
 	// groups.
 	//
@@ -67,8 +83,20 @@ type AppIface interface {
 	ConvertUserToBot(user *model.User) (*model.Bot, *model.AppError)
 	// CreateBot creates the given bot and corresponding user.
 	CreateBot(bot *model.Bot) (*model.Bot, *model.AppError)
+	// CreateChannel creates the channel, counting it against the daily channel creation limit of its
+	// creator, if any.
+	CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError)
+	// CreateChannelBookmark saves a new bookmark at the end of the channel's bookmarks.
+	CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError)
+	// CreateChannelBypassingThrottle creates the channel without counting it against the daily channel
+	// creation limit of its creator. It's meant for admins and imports, which legitimately create many
+	// channels at once. The maximum number of channels per team still applies.
+	CreateChannelBypassingThrottle(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError)
 	// CreateChannelScheme creates a new Scheme of scope channel and assigns it to the channel.
 	CreateChannelScheme(channel *model.Channel) (*model.Scheme, *model.AppError)
+	// CreateCustomGroup creates a group whose members are managed through the API instead of being
+	// synced from LDAP.
+	CreateCustomGroup(group *model.Group) (*model.Group, *model.AppError)
 	// CreateDefaultChannels creates channels in the given team for each channel returned by (*App).DefaultChannelNames.
 	//
 	CreateDefaultChannels(teamID string) ([]*model.Channel, *model.AppError)
@@ -78,9 +106,27 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(user *model.User) (*model.User, *model.AppError)
+	// CreatePostForSession creates a post on behalf of the user of the session, as requested through
+	// the REST API or a websocket. Only system admins may choose the creation time of the post. The
+	// post created, or the one requested on failure, is added to the given audit record.
+	CreatePostForSession(session model.Session, post *model.Post, setOnline bool, auditRec *audit.Record) (*model.Post, *model.AppError)
+	// CreatePostShareToken creates a token granting read access to the given post to the members of the
+	// team of its channel, for as long as the config allows. Posts of direct and group channels can't
+	// be shared, since those channels don't belong to a team.
+	CreatePostShareToken(post *model.Post, creatorId string) (*model.PostShareToken, *model.AppError)
+	// CreateSavedPost saves a post for a user and flags it, so that it also shows up wherever flagged
+	// posts are listed.
+	CreateSavedPost(savedPost *model.SavedPost) (*model.SavedPost, *model.AppError)
+	// CreateSavedSearch saves a new search for a member of the saved search's team, as long as the
+	// user hasn't reached the number of saved searches they may have.
+	CreateSavedSearch(savedSearch *model.SavedSearch) (*model.SavedSearch, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(user *model.User) (*model.User, *model.AppError)
+	// CreatedPostResponse returns the JSON response to a request creating the given post. A post
+	// created with a pending post id gets the response recorded for it, so that retries deduplicated
+	// by CreatePost are answered byte for byte as the original request was.
+	CreatedPostResponse(post *model.Post) string
 	// Creates and stores FileInfos for a post created before the FileInfos table existed.
 	MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo
 	// DefaultChannelNames returns the list of system-wide default channel names.
@@ -94,25 +140,62 @@ type AppIface interface {
 	DefaultChannelNames() []string
 	// DeleteBotIconImage deletes LHS icon for a bot.
 	DeleteBotIconImage(botUserId string) *model.AppError
+	// DeleteChannelBookmark soft deletes the given bookmark.
+	DeleteChannelBookmark(bookmark *model.ChannelBookmark) *model.AppError
 	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
 	DeleteChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
+	// DeleteExpiredEditOriginals permanently deletes the originals kept when posts are edited, once
+	// the edit is older than DataRetentionSettings.EditedPostOriginalRetentionDays, and returns how many
+	// were deleted. With the setting at 0 the originals are kept as long as the post they belong to,
+	// and nothing is deleted here.
+	DeleteExpiredEditOriginals() (int64, *model.AppError)
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
 	// groups of all group-constrained teams and channels.
 	DeleteGroupConstrainedMemberships() error
+	// DeleteGroupMembers removes the users from the group.
+	DeleteGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)
+	// DeleteFeatureFlagOverride removes the override of the feature flag, restoring its configured
+	// value.
+	DeleteFeatureFlagOverride(name string) (*model.FeatureFlag, *model.AppError)
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
+	// DeleteSavedPost unsaves a post for a user, which also unflags it.
+	DeleteSavedPost(savedPost *model.SavedPost) *model.AppError
+	// DeleteSavedSearch deletes the given saved search.
+	DeleteSavedSearch(savedSearch *model.SavedSearch) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his mermbership's roles from
 	// regular user roles to guest roles.
 	DemoteUserToGuest(user *model.User) *model.AppError
+	// DeprecatedClientConfig gets the deprecated fields of the configuration, logging that they were
+	// sent so the clients still relying on them can be tracked down.
+	DeprecatedClientConfig(audience config.ClientConfigAudience, userAgent string) map[string]string
+	// DisableIncomingWebhookDebugging stops capturing the requests received by an incoming webhook and
+	// discards the ones captured so far.
+	DisableIncomingWebhookDebugging(hookId string) *model.AppError
 	// DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
 	// Notifies cluster peers through config change.
 	DisablePlugin(id string) *model.AppError
 	// DoPermissionsMigrations execute all the permissions migrations need by the current version.
 	DoPermissionsMigrations() error
+	// DoSavedPostsMigration saves the posts flagged before saved posts existed, so that they show up
+	// in the saved posts of their users.
+	DoSavedPostsMigration()
+	// EnableIncomingWebhookDebugging starts capturing the requests received by an incoming webhook
+	// for the next hour. Enabling it again extends the capture, keeping the requests captured so far.
+	EnableIncomingWebhookDebugging(hookId string) (*model.IncomingWebhookDebugSession, *model.AppError)
 	// EnablePlugin will set the config for an installed plugin to enabled, triggering asynchronous
 	// activation if inactive anywhere in the cluster.
 	// Notifies cluster peers through config change.
 	EnablePlugin(id string) *model.AppError
+	// EnsureSystemBot returns the id of the bot that messages sent by the server itself are posted as,
+	// creating the bot if it doesn't exist yet. It is called at startup, so that the bot exists before
+	// any message is sent.
+	EnsureSystemBot() (string, *model.AppError)
+	// EvaluateSavedSearches runs the saved searches notifying of new matches that weren't run during
+	// the last evaluation interval, and sends their users a direct message listing the posts created
+	// since the previous run. Searches are run on behalf of their users, so only posts in channels the
+	// users can read at that time are reported.
+	EvaluateSavedSearches()
 	// Expand announcements in incoming webhooks from Slack. Those announcements
 	// can be found in the text attribute, or in the pretext, text, title and value
 	// attributes of the attachment structure. The Slack attachment structure is
@@ -122,6 +205,13 @@ type AppIface interface {
 	// A new ExpiresAt is only written if enough time has elapsed since last update.
 	// Returns true only if the session was extended.
 	ExtendSessionExpiryIfNeeded(session *model.Session) bool
+	// FeatureEnabled returns whether the feature flag is enabled for the user. Flags that aren't
+	// overridden take their configured value, while overridden flags are only enabled for the users
+	// the override includes. Unknown flags are disabled.
+	FeatureEnabled(name, userId string) bool
+	// FillInChannelReadOnly sets the computed IsReadOnly field of the channel. It is left unset
+	// when the channel moderations can't be read, rather than failing the request.
+	FillInChannelReadOnly(channel *model.Channel)
 	// FillInPostProps should be invoked before saving posts to fill in properties such as
 	// channel_mentions.
 	//
@@ -133,6 +223,15 @@ type AppIface interface {
 	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
 	// associated to the team excluding bots.
 	FilterNonGroupTeamMembers(userIds []string, team *model.Team) ([]string, error)
+	// GenerateSupportPacket collects the files of a support packet: the server information, the sanitized
+	// configuration and the tail of the server log. Generating a packet is expensive, so it is only allowed
+	// once every SUPPORT_PACKET_RATE_LIMIT.
+	GenerateSupportPacket() ([]*model.SupportPacketFile, *model.AppError)
+	// GetAdminNotifications returns a page of the admin notifications, most recently raised first.
+	GetAdminNotifications(page, perPage int) ([]*model.AdminNotification, *model.AppError)
+	// GetAllChannelPolicies returns a page of the channels that override the permissions of their team
+	// with a scheme of their own, along with that scheme and the names of the channel and its team.
+	GetAllChannelPolicies(page, perPage int) ([]*model.ChannelPolicyWithChannelInfo, *model.AppError)
 	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
 	// filter.
 	GetAllLdapGroupsPage(page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
@@ -142,23 +241,65 @@ type AppIface interface {
 	GetBotIconImage(botUserId string) ([]byte, *model.AppError)
 	// GetBots returns the requested page of bots.
 	GetBots(options *model.BotGetOptions) (model.BotList, *model.AppError)
+	// GetChannelBookmark returns the given bookmark.
+	GetChannelBookmark(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, *model.AppError)
+	// GetChannelBookmarks returns the active bookmarks of the given channel in their sort order.
+	GetChannelBookmarks(channelId string) ([]*model.ChannelBookmark, *model.AppError)
 	// GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
 	GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError)
+	// GetChannelLastViewed returns the last post the user saw in the channel and when they last viewed
+	// it, so clients can place the new messages divider where the server does. Both are empty when the
+	// user never viewed the channel.
+	GetChannelLastViewed(channelId, userId string) (*model.ChannelLastViewed, *model.AppError)
+	// GetChannelMemberHistory returns a page of the memberships of the channel that were joined or left
+	// between since and until.
+	GetChannelMemberHistory(channelId string, since, until int64, page, perPage int) ([]*model.ChannelMemberHistoryResult, *model.AppError)
+	// GetChannelMembersDrift returns the members of the group-constrained channel that aren't members of
+	// any of its groups, which is the case when the group sync failed to remove them.
+	GetChannelMembersDrift(channel *model.Channel) (*model.ChannelMembersDrift, *model.AppError)
+	// GetChannelMembersDrifts returns the drift of every group-constrained channel that has members
+	// who aren't members of any of its groups.
+	GetChannelMembersDrifts() ([]*model.ChannelMembersDrift, *model.AppError)
 	// GetChannelModerationsForChannel Gets a channels ChannelModerations from either the higherScoped roles or from the channel scheme roles.
 	GetChannelModerationsForChannel(channel *model.Channel) ([]*model.ChannelModeration, *model.AppError)
+	// GetChannelRecentRootPostCount returns the number of root posts created in the channel over the
+	// last CHANNEL_STATS_RECENT_DAYS days.
+	GetChannelRecentRootPostCount(channelId string) (int64, *model.AppError)
+	// GetChannelStats returns the member, guest, pinned post and recent root post counts of the channel.
+	GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError)
 	// GetClusterPluginStatuses returns the status for plugins installed anywhere in the cluster.
 	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
 	// GetConfigFile proxies access to the given configuration file to the underlying config store.
 	GetConfigFile(name string) ([]byte, error)
+	// GetConfigHistory returns a page of the configurations saved, most recent first. Only the database
+	// configuration store keeps history.
+	GetConfigHistory(page, perPage int) ([]*model.ConfigHistoryEntry, *model.AppError)
+	// GetDeletedChannelsForUserSince returns the channels of a team deleted after the given time that the
+	// user is a member of, oldest deletion first, so clients can prune them from their local state.
+	GetDeletedChannelsForUserSince(teamId, userId string, since int64) (*model.ChannelList, *model.AppError)
 	// GetEmojiStaticUrl returns a relative static URL for system default emojis,
 	// and the API route for custom ones. Errors if not found or if custom and deleted.
 	GetEmojiStaticUrl(emojiName string) (string, *model.AppError)
 	// GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
 	GetEnvironmentConfig() map[string]interface{}
+	// GetFeatureFlags returns every feature flag along with its configured value and override.
+	GetFeatureFlags() []*model.FeatureFlag
+	// GetFeatureFlagsForUser returns whether each feature flag is enabled for the user, by name.
+	GetFeatureFlagsForUser(userId string) map[string]bool
 	// GetFilteredUsersStats is used to get a count of users based on the set of filters supported by UserCountOptions.
 	GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError)
+	// GetFlaggedPostsWithContext returns a page of the posts flagged by the user, most recently flagged
+	// first, prepared for the client with the names of their channel and team. Posts in channels the
+	// user can no longer read are left out, so a page may hold fewer posts than the limit.
+	GetFlaggedPostsWithContext(userId string, offset, limit int) (*model.PostList, *model.AppError)
 	// GetGroupsByTeam returns the paged list and the total count of group associated to the given team.
 	GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
+	// GetHistoricalConfig returns a previously saved configuration, with the secrets of the active
+	// configuration.
+	GetHistoricalConfig(id string) (*model.Config, *model.AppError)
+	// GetIncomingWebhookDebugSession returns the requests captured for an incoming webhook. The
+	// session has no requests and a zero ExpiresAt when debugging isn't enabled for the webhook.
+	GetIncomingWebhookDebugSession(hookId string) *model.IncomingWebhookDebugSession
 	// GetKnownUsers returns the list of user ids of users with any direct
 	// relationship with a user. That means any user sharing any channel, including
 	// direct and group channels.
@@ -168,6 +309,9 @@ type AppIface interface {
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 	// and plugins that are installed locally.
 	GetMarketplacePlugins(filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	// GetPluginEffectiveConfig returns the configuration an installed plugin is running with: the
+	// defaults from its settings schema merged with any admin overrides, with secrets redacted.
+	GetPluginEffectiveConfig(id string) (map[string]interface{}, *model.AppError)
 	// GetPluginPublicKeyFiles returns all public keys listed in the config.
 	GetPluginPublicKeyFiles() ([]string, *model.AppError)
 	// GetPluginStatus returns the status for a plugin installed on this server.
@@ -180,21 +324,69 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPostEditHistory returns the previous contents of the given post, oldest first.
+	GetPostEditHistory(postId string) ([]*model.PostEdit, *model.AppError)
+	// GetPostIdsWithFileExtensions returns the ids of the posts with attachments having one of the given
+	// extensions, uploaded since the given time. Extensions are matched case insensitively, with or without
+	// the leading period.
+	GetPostIdsWithFileExtensions(extensions []string, since int64, page, perPage int) ([]string, *model.AppError)
+	// GetPostShareTokenForSession returns the given post share token if it grants access to the user of
+	// the session, which requires the feature to be enabled, the token not to have expired and the user
+	// to be a member of the team of the token.
+	GetPostShareTokenForSession(session model.Session, tokenString string) (*model.PostShareToken, *model.AppError)
 	// GetPublicKey will return the actual public key saved in the `name` file.
 	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetRateLimitStatus returns the current budget of the key, a user id or an IP address, for each
+	// class of requests. The header value is the one sent in the header the requests are varied by, if
+	// any.
+	GetRateLimitStatus(key string, headerValue string) ([]*model.RateLimitStatus, *model.AppError)
+	// GetRecentDirectChannels returns up to limit direct and group message channels for the user, or all of them
+	// when limit isn't positive, most recently posted in first, with the ids of the other members set as
+	// ParticipantIds. If excludeEmpty is true, channels that have never had a post are left out.
+	GetRecentDirectChannels(userId string, limit int, excludeEmpty bool) ([]*model.Channel, *model.AppError)
+	// GetRecentlyDeletedChannels returns the deleted channels of a team, including private ones, most
+	// recently deleted first. It isn't filtered by membership, so it's meant for system admins.
+	GetRecentlyDeletedChannels(teamId string, offset, limit int) ([]*model.Channel, *model.AppError)
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
+	// GetSavedPost returns the given post as saved by the given user.
+	GetSavedPost(userId, postId string) (*model.SavedPost, *model.AppError)
+	// GetSavedPostLabelsForUser returns the labels the given user saved posts under.
+	GetSavedPostLabelsForUser(userId string) ([]string, *model.AppError)
+	// GetSavedPostsForUser returns a page of the posts saved by the given user, most recently saved
+	// first. When a label is given, only the posts saved under it are returned.
+	GetSavedPostsForUser(userId string, label *string, page, perPage int) ([]*model.SavedPost, *model.AppError)
+	// GetSavedSearch returns the given saved search.
+	GetSavedSearch(savedSearchId string) (*model.SavedSearch, *model.AppError)
+	// GetSavedSearchesForUser returns the saved searches of the given user ordered by name.
+	GetSavedSearchesForUser(userId string) ([]*model.SavedSearch, *model.AppError)
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(channelId string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
 	// based on the type of session (Mobile, SSO, Web/LDAP).
 	GetSessionLengthInMillis(session *model.Session) int64
+	// GetStatusesByTeam returns the statuses of the members of the team, keyed by user id. Members
+	// that never had a status, and so are offline, are left out.
+	GetStatusesByTeam(teamId string) (map[string]*model.Status, *model.AppError)
 	// GetSuggestions returns suggestions for user input.
 	GetSuggestions(commandArgs *model.CommandArgs, commands []*model.Command, roleID string) []model.AutocompleteSuggestion
+	// GetTeamChannelsWithActivity returns a page of the public and private channels of a team along
+	// with their number of members.
+	GetTeamChannelsWithActivity(teamId string, page, perPage int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, *model.AppError)
 	// GetTeamGroupUsers returns the users who are associated to the team via GroupTeams and GroupMembers.
 	GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError)
+	// GetTeamMembersWithUnreadForUser returns the user's team memberships in the user's team order,
+	// each with the unread counts for that team attached.
+	GetTeamMembersWithUnreadForUser(userId string) ([]*model.TeamMemberWithUnread, *model.AppError)
 	// GetTeamSchemeChannelRoles Checks if a team has an override scheme and returns the scheme channel role names or default channel role names.
 	GetTeamSchemeChannelRoles(teamId string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
+	// GetTeamsForUserWithUnreads returns the teams the user belongs to in the user's team order, each
+	// with the user's unread message and mention counts for that team. Muted channels are counted the
+	// same way as for GetTeamsUnreadForUser.
+	GetTeamsForUserWithUnreads(userId string) ([]*model.TeamWithUnreads, *model.AppError)
+	// GetTeamsOrderForUser returns the ids of the teams the user belongs to, in the order the user
+	// has chosen for the team sidebar.
+	GetTeamsOrderForUser(userId string) ([]string, *model.AppError)
 	// GetTotalUsersStats is used for the DM list total
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
 	// HubRegister registers a connection to a hub.
@@ -210,6 +402,26 @@ type AppIface interface {
 	InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError)
 	// InstallPluginWithSignature verifies and installs plugin.
 	InstallPluginWithSignature(pluginFile, signature io.ReadSeeker) (*model.Manifest, *model.AppError)
+	// IsChannelReadOnly returns true if the channel moderations prevent channel members from posting, leaving it
+	// to the channel admins.
+	IsChannelReadOnly(channel *model.Channel) (bool, *model.AppError)
+	// IsIncomingWebhookDebuggingEnabled returns whether the requests received by an incoming webhook
+	// are being captured.
+	IsIncomingWebhookDebuggingEnabled(hookId string) bool
+	// IsMfaEnforcedForSession is IsMfaEnforcedForUser for the user of the session, reading the team roles from
+	// the team memberships loaded with the session rather than from the store.
+	IsMfaEnforcedForSession(session *model.Session, user *model.User) bool
+	// IsMfaEnforcedForUser reports whether the user must set up multi-factor authentication, either because it
+	// is enforced for everyone or because it is enforced for one of the roles of the user.
+	IsMfaEnforcedForUser(user *model.User) (bool, *model.AppError)
+	// IsSystemBotDM returns whether the post was sent by the system bot to the user, who can delete it
+	// without being allowed to delete the posts of others.
+	IsSystemBotDM(post *model.Post, userId string) bool
+	// IsUsernameOrEmailAvailable returns whether both the username and the email, each of which may be
+	// left empty, are free to sign up with. Emails can only be checked when they are shown to users.
+	// The checks are limited by the given key, usually the IP address of the client, so that they can't
+	// be used to enumerate the users.
+	IsUsernameOrEmailAvailable(username, email, rateLimitKey string) (bool, *model.AppError)
 	// IsUsernameTaken checks if the username is already used by another user. Return false if the username is invalid.
 	IsUsernameTaken(name string) bool
 	// LimitedClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
@@ -225,25 +437,50 @@ type AppIface interface {
 	// MoveChannel method is prone to data races if someone joins to channel during the move process. However this
 	// function is only exposed to sysadmins and the possibility of this edge case is realtively small.
 	MoveChannel(team *model.Team, channel *model.Channel, user *model.User) *model.AppError
+	// MoveUsersToTeam moves the given users from one team to another, joining them to the default
+	// channels of the target team, and returns the result of each move in the order of the given ids.
+	// A user who can't be moved doesn't stop the others, and a user who is already a member of the
+	// target team is only removed from the source team. Users are looked up in batches of
+	// MOVE_USERS_TO_TEAM_BATCH_SIZE.
+	MoveUsersToTeam(userIds []string, fromTeamId, toTeamId string) ([]*model.TeamMemberWithError, *model.AppError)
 	// NewWebConn returns a new WebConn instance.
 	NewWebConn(ws *websocket.Conn, session model.Session, t goi18n.TranslateFunc, locale string) *WebConn
 	// NewWebHub creates a new Hub.
 	NewWebHub() *Hub
+	// NotifyAdmins records an event that system admins should know about and posts it to the admin alerts
+	// channel, if one is configured. An event identical to one raised within the deduplication window
+	// increments the count of the existing notification and updates its post instead of creating a new one.
+	NotifyAdmins(notificationType, message string) (*model.AdminNotification, *model.AppError)
 	// NotifySessionsExpired is called periodically from the job server to notify any mobile sessions that have expired.
 	NotifySessionsExpired() *model.AppError
+	// OriginChecker returns the function checking the origin of websocket connections against the CORS
+	// policy, or nil to only allow connections from the same host when no origins are configured.
+	OriginChecker() func(*http.Request) bool
 	// OverrideIconURLIfEmoji changes the post icon override URL prop, if it has an emoji icon,
 	// so that it points to the URL (relative) of the emoji - static if emoji is default, /api if custom.
 	OverrideIconURLIfEmoji(post *model.Post)
 	// PatchBot applies the given patch to the bot and corresponding user.
 	PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
+	// PatchChannelBookmark applies the given patch to an existing bookmark on behalf of the given user,
+	// who must have uploaded any new file of the bookmark.
+	PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch, userId string) (*model.ChannelBookmark, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	// PatchSavedPost changes the label or the note of a saved post.
+	PatchSavedPost(savedPost *model.SavedPost, patch *model.SavedPostPatch) (*model.SavedPost, *model.AppError)
+	// PatchSavedSearch applies the given patch to an existing saved search. Changing the terms or
+	// starting to notify of new matches only reports the posts created from then on.
+	PatchSavedSearch(savedSearch *model.SavedSearch, patch *model.SavedSearchPatch) (*model.SavedSearch, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
 	// Caller must consume and close returned http.Response as necessary.
 	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
 	DoActionRequest(rawURL string, body []byte) (*http.Response, *model.AppError)
 	// PermanentDeleteBot permanently deletes a bot and its corresponding user.
 	PermanentDeleteBot(botUserId string) *model.AppError
+	// PreviewIncomingWebhook runs an incoming webhook request through the same processing as
+	// HandleIncomingWebhook without creating any posts, so integrations can check their payloads.
+	// Problems with the request are returned in the preview rather than as an error.
+	PreviewIncomingWebhook(hook *model.IncomingWebhook, req *model.IncomingWebhookRequest) (*model.IncomingWebhookPreview, *model.AppError)
 	// PromoteGuestToUser Convert user's roles and all his mermbership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(user *model.User, requestorId string) *model.AppError
@@ -251,15 +488,34 @@ type AppIface interface {
 	RenameChannel(channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// RepairChannelMembersDrift removes the members of the drift from its channel, keeping the exempt
+	// ones, and returns the ids of the users it removed. They are removed like any other member, so that
+	// the channel gets the usual system messages and websocket events. A member that can't be removed
+	// doesn't stop the others from being removed; an error listing the failed users is returned at the end.
+	RepairChannelMembersDrift(drift *model.ChannelMembersDrift) ([]string, *model.AppError)
+	// ResetRateLimit restores the full budget of the key, a user id or an IP address, for each class
+	// of requests. The header value is the one sent in the header the requests are varied by, if any.
+	ResetRateLimit(key string, headerValue string) *model.AppError
+	// RevokePostShareTokensForChannel revokes every post share token of the given channel, and returns
+	// the number of tokens revoked.
+	RevokePostShareTokensForChannel(channelId string) (int64, *model.AppError)
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError
+	// SaveConfigWithWarnings replaces the active configuration like SaveConfig, then waits for the
+	// checks made on the new configuration and returns the warnings they raised.
+	SaveConfigWithWarnings(newCfg *model.Config, sendConfigChangeClusterMessage bool) ([]string, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(term string, opts model.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
 	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SendSystemBotChannelPost posts the message, with the given props, as the system bot in the channel.
+	SendSystemBotChannelPost(channelId, message string, props model.StringInterface) (*model.Post, *model.AppError)
+	// SendSystemBotDM posts the message, with the given props, as the system bot in its direct channel
+	// with the user.
+	SendSystemBotDM(userId, message string, props model.StringInterface) (*model.Post, *model.AppError)
 	// ServePluginPublicRequest serves public plugin files
 	// at the URL http(s)://$SITE_URL/plugins/$PLUGIN_ID/public/{anything}
 	ServePluginPublicRequest(w http.ResponseWriter, r *http.Request)
@@ -269,12 +525,27 @@ type AppIface interface {
 	// This function deviates from other authorization checks in returning an error instead of just
 	// a boolean, allowing the permission failure to be exposed with more granularity.
 	SessionHasPermissionToManageBot(session model.Session, botUserId string) *model.AppError
+	// SessionHasPermissionToReadFile checks whether the session may read the given file, which it may
+	// if it uploaded the file or can read a channel the file was shared to, either by the file's post or
+	// by a channel bookmark.
+	SessionHasPermissionToReadFile(session model.Session, info *model.FileInfo) bool
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
 	// SetBotIconImage sets LHS icon for a bot.
 	SetBotIconImage(botUserId string, file io.ReadSeeker) *model.AppError
 	// SetBotIconImageFromMultiPartFile sets LHS icon for a bot.
 	SetBotIconImageFromMultiPartFile(botUserId string, imageData *multipart.FileHeader) *model.AppError
+	// SetChannelReadOnly turns the channel into an announcement channel where only channel admins can post, or
+	// reverts it. This patches the create_post channel moderation, so enabling it removes the permission from
+	// members and guests and disabling it restores whatever the team or system scheme allows.
+	SetChannelReadOnly(channel *model.Channel, userId string, enabled bool) (*model.Channel, *model.AppError)
+	// SetFeatureFlagOverride overrides the configured value of the feature flag, enabling it only for
+	// the users the override includes. The override applies to every cluster node without a restart.
+	SetFeatureFlagOverride(name string, override *model.FeatureFlagOverride) (*model.FeatureFlag, *model.AppError)
+	// SetSavedPostsForSession sets the saved state of the given posts for the user of the current
+	// session in their metadata, so that clients don't need to ask for it post by post. Posts must
+	// already be prepared for the client and never be broadcast to other users afterwards.
+	SetSavedPostsForSession(posts ...*model.Post)
 	// SetStatusLastActivityAt sets the last activity at for a user on the local app server and updates
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
@@ -305,8 +576,22 @@ type AppIface interface {
 	UpdateBotOwner(botUserId, newOwnerId string) (*model.Bot, *model.AppError)
 	// UpdateChannel updates a given channel by its Id. It also publishes the CHANNEL_UPDATED event.
 	UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
+	// UpdateChannelBookmark replaces the content of an existing bookmark with the one of the given
+	// bookmark on behalf of the given user, keeping its channel, owner and position. The user must have
+	// uploaded any new file of the bookmark.
+	UpdateChannelBookmark(bookmark, update *model.ChannelBookmark, userId string) (*model.ChannelBookmark, *model.AppError)
+	// UpdateChannelBookmarkSortOrder reorders the channel's bookmarks to match the given list of ids,
+	// which must contain every bookmark of the channel exactly once.
+	UpdateChannelBookmarkSortOrder(channelId string, bookmarkIds []string) ([]*model.ChannelBookmark, *model.AppError)
+	// UpdateChannelMemberManuallyManaged flags a channel admin as manually managed, so that the group sync
+	// leaves them in a group-constrained channel although they aren't a member of any of its groups.
+	UpdateChannelMemberManuallyManaged(channelId string, userId string, manuallyManaged bool) (*model.ChannelMember, *model.AppError)
 	// UpdateChannelScheme saves the new SchemeId of the channel passed.
 	UpdateChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
+	// UpdateTeamsOrderForUser saves the order of the team sidebar for the user. Every team in the
+	// given order must be one the user belongs to; any of the user's teams that are missing from
+	// it are appended.
+	UpdateTeamsOrderForUser(userId string, teamIds []string) ([]string, *model.AppError)
 	// UpdateWebConnUserActivity sets the LastUserActivityAt of the hub for the given session.
 	UpdateWebConnUserActivity(session model.Session, activityAt int64)
 	// UploadFile uploads a single file in form of a completely constructed byte array for a channel.
@@ -321,6 +606,8 @@ type AppIface interface {
 	// the same length. clientIds should either not be provided or have the same length as files and filenames.
 	// The provided files should be closed by the caller so that they are not leaked.
 	UploadFiles(teamId string, channelId string, userId string, files []io.ReadCloser, filenames []string, clientIds []string, now time.Time) (*model.FileUploadResponse, *model.AppError)
+	// UpsertGroupMembers adds the users to the group, restoring the memberships that were deleted.
+	UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)
 	// UserIsInAdminRoleGroup returns true at least one of the user's groups are configured to set the members as
 	// admins in the given syncable.
 	UserIsInAdminRoleGroup(userID, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError)
@@ -340,7 +627,7 @@ type AppIface interface {
 	AddSessionToCache(session *model.Session)
 	AddStatusCache(status *model.Status)
 	AddStatusCacheSkipClusterSend(status *model.Status)
-	AddTeamMember(teamId, userId string) (*model.TeamMember, *model.AppError)
+	AddTeamMember(teamId, userId, userRequestorId string) (*model.TeamMember, *model.AppError)
 	AddTeamMemberByInviteId(inviteId, userId string) (*model.TeamMember, *model.AppError)
 	AddTeamMemberByToken(userId, tokenId string) (*model.TeamMember, *model.AppError)
 	AddTeamMembers(teamId string, userIds []string, userRequestorId string, graceful bool) ([]*model.TeamMemberWithError, *model.AppError)
@@ -350,6 +637,7 @@ type AppIface interface {
 	AddUserToTeamByTeamId(teamId string, user *model.User) *model.AppError
 	AddUserToTeamByToken(userId string, tokenId string) (*model.Team, *model.AppError)
 	AdjustImage(file io.Reader) (*bytes.Buffer, *model.AppError)
+	AdminClientConfig() map[string]string
 	AllowOAuthAppAccessToUser(userId string, authRequest *model.AuthorizeRequest) (string, *model.AppError)
 	AsymmetricSigningKey() *ecdsa.PrivateKey
 	AttachDeviceId(sessionId string, deviceId string, expiresAt int64) *model.AppError
@@ -394,7 +682,6 @@ type AppIface interface {
 	Config() *model.Config
 	Context() context.Context
 	CopyFileInfos(userId string, fileIds []string) ([]string, *model.AppError)
-	CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError)
 	CreateChannelWithUser(channel *model.Channel, userId string) (*model.Channel, *model.AppError)
 	CreateCommand(cmd *model.Command) (*model.Command, *model.AppError)
 	CreateCommandWebhook(commandId string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError)
@@ -614,7 +901,7 @@ type AppIface interface {
 	GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError)
 	GetProfileImage(user *model.User) ([]byte, bool, *model.AppError)
 	GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError)
-	GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError)
+	GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError)
 	GetReactionsForPost(postId string) ([]*model.Reaction, *model.AppError)
 	GetRecentlyActiveUsersForTeam(teamId string) (map[string]*model.User, *model.AppError)
 	GetRecentlyActiveUsersForTeamPage(teamId string, page, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
@@ -754,7 +1041,6 @@ type AppIface interface {
 	Notification() einterfaces.NotificationInterface
 	NotificationsLog() *mlog.Logger
 	OpenInteractiveDialog(request model.OpenDialogRequest) *model.AppError
-	OriginChecker() func(*http.Request) bool
 	PatchChannel(channel *model.Channel, patch *model.ChannelPatch, userId string) (*model.Channel, *model.AppError)
 	PatchPost(postId string, patch *model.PostPatch) (*model.Post, *model.AppError)
 	PatchRole(role *model.Role, patch *model.RolePatch) (*model.Role, *model.AppError)
@@ -792,6 +1078,7 @@ type AppIface interface {
 	RegenerateOAuthAppSecret(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	RegenerateTeamInviteId(teamId string) (*model.Team, *model.AppError)
 	RegisterPluginCommand(pluginId string, command *model.Command) error
+	ReloadCertificates() *model.AppError
 	ReloadConfig() error
 	RemoveAllDeactivatedMembersFromChannel(channel *model.Channel) *model.AppError
 	RemoveConfigListener(id string)
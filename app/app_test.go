@@ -101,6 +101,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_CREATE_POST.Id,
 			model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			model.PERMISSION_USE_SLASH_COMMANDS.Id,
+			model.PERMISSION_ADD_BOOKMARK.Id,
 			model.PERMISSION_MANAGE_PUBLIC_CHANNEL_PROPERTIES.Id,
 			model.PERMISSION_DELETE_PUBLIC_CHANNEL.Id,
 			model.PERMISSION_MANAGE_PRIVATE_CHANNEL_PROPERTIES.Id,
@@ -112,6 +113,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 		"channel_admin": {
 			model.PERMISSION_MANAGE_CHANNEL_ROLES.Id,
 			model.PERMISSION_USE_GROUP_MENTIONS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		},
 		"team_user": {
 			model.PERMISSION_LIST_TEAM_CHANNELS.Id,
@@ -143,6 +145,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 			model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 			model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 			model.PERMISSION_DELETE_POST.Id,
 			model.PERMISSION_DELETE_OTHERS_POSTS.Id,
 		},
@@ -209,6 +212,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_LIST_PRIVATE_TEAMS.Id,
 			model.PERMISSION_JOIN_PRIVATE_TEAMS.Id,
 			model.PERMISSION_VIEW_MEMBERS.Id,
+			model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id,
 			model.PERMISSION_LIST_TEAM_CHANNELS.Id,
 			model.PERMISSION_JOIN_PUBLIC_CHANNELS.Id,
 			model.PERMISSION_READ_PUBLIC_CHANNEL.Id,
@@ -221,6 +225,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_CREATE_POST.Id,
 			model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			model.PERMISSION_USE_SLASH_COMMANDS.Id,
+			model.PERMISSION_ADD_BOOKMARK.Id,
 			model.PERMISSION_REMOVE_USER_FROM_TEAM.Id,
 			model.PERMISSION_MANAGE_TEAM.Id,
 			model.PERMISSION_IMPORT_TEAM.Id,
@@ -230,6 +235,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 			model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 			model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 			model.PERMISSION_USE_GROUP_MENTIONS.Id,
 			model.PERMISSION_EDIT_POST.Id,
 		},
@@ -293,6 +299,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_CREATE_POST.Id,
 			model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			model.PERMISSION_USE_SLASH_COMMANDS.Id,
+			model.PERMISSION_ADD_BOOKMARK.Id,
 			model.PERMISSION_DELETE_PUBLIC_CHANNEL.Id,
 			model.PERMISSION_DELETE_PRIVATE_CHANNEL.Id,
 			model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS.Id,
@@ -302,6 +309,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 		"channel_admin": {
 			model.PERMISSION_MANAGE_CHANNEL_ROLES.Id,
 			model.PERMISSION_USE_GROUP_MENTIONS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		},
 		"team_user": {
 			model.PERMISSION_LIST_TEAM_CHANNELS.Id,
@@ -333,6 +341,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 			model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 			model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 			model.PERMISSION_MANAGE_PUBLIC_CHANNEL_PROPERTIES.Id,
 			model.PERMISSION_MANAGE_PRIVATE_CHANNEL_PROPERTIES.Id,
 			model.PERMISSION_DELETE_POST.Id,
@@ -401,6 +410,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_LIST_PRIVATE_TEAMS.Id,
 			model.PERMISSION_JOIN_PRIVATE_TEAMS.Id,
 			model.PERMISSION_VIEW_MEMBERS.Id,
+			model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id,
 			model.PERMISSION_LIST_TEAM_CHANNELS.Id,
 			model.PERMISSION_JOIN_PUBLIC_CHANNELS.Id,
 			model.PERMISSION_READ_PUBLIC_CHANNEL.Id,
@@ -413,6 +423,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_CREATE_POST.Id,
 			model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			model.PERMISSION_USE_SLASH_COMMANDS.Id,
+			model.PERMISSION_ADD_BOOKMARK.Id,
 			model.PERMISSION_REMOVE_USER_FROM_TEAM.Id,
 			model.PERMISSION_MANAGE_TEAM.Id,
 			model.PERMISSION_IMPORT_TEAM.Id,
@@ -422,6 +433,7 @@ func TestDoAdvancedPermissionsMigration(t *testing.T) {
 			model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 			model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 			model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+			model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 			model.PERMISSION_USE_GROUP_MENTIONS.Id,
 			model.PERMISSION_EDIT_POST.Id,
 		},
@@ -569,13 +581,16 @@ func TestDoEmojisPermissionsMigration(t *testing.T) {
 		model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 		model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 		model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+		model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		model.PERMISSION_EDIT_POST.Id,
 		model.PERMISSION_CREATE_EMOJIS.Id,
 		model.PERMISSION_DELETE_EMOJIS.Id,
 		model.PERMISSION_DELETE_OTHERS_EMOJIS.Id,
 		model.PERMISSION_VIEW_MEMBERS.Id,
+		model.PERMISSION_MANAGE_CUSTOM_GROUPS.Id,
 		model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 		model.PERMISSION_USE_GROUP_MENTIONS.Id,
+		model.PERMISSION_ADD_BOOKMARK.Id,
 	}
 	sort.Strings(expectedSystemAdmin)
 
@@ -605,6 +620,7 @@ func TestDoEmojisPermissionsMigration(t *testing.T) {
 		model.PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 		model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 		model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+		model.PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		model.PERMISSION_DELETE_POST.Id,
 		model.PERMISSION_DELETE_OTHERS_POSTS.Id,
 		model.PERMISSION_CREATE_EMOJIS.Id,
@@ -616,6 +632,7 @@ func TestDoEmojisPermissionsMigration(t *testing.T) {
 		model.PERMISSION_REMOVE_REACTION.Id,
 		model.PERMISSION_USE_CHANNEL_MENTIONS.Id,
 		model.PERMISSION_USE_GROUP_MENTIONS.Id,
+		model.PERMISSION_ADD_BOOKMARK.Id,
 	}
 	sort.Strings(expected2)
 	sort.Strings(role2.Permissions)
@@ -105,6 +105,7 @@ func (a *App) MakeAuditRecord(event string, initialStatus string) *audit.Record
 func (s *Server) configureAudit(adt *audit.Audit) {
 	adt.OnQueueFull = s.onAuditTargetQueueFull
 	adt.OnError = s.onAuditError
+	adt.SetRedactedQueryParams(s.Config().ExperimentalAuditSettings.AdditionalRedactedQueryParams)
 
 	// Configure target for SysLog via TLS.
 	// See https://www.rsyslog.com/doc/v8-stable/tutorials/tls_cert_summary.html
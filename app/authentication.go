@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/services/mfa"
 	"github.com/mattermost/mattermost-server/v5/utils"
@@ -42,7 +43,21 @@ func (a *App) IsPasswordValid(password string) *model.AppError {
 		return nil
 	}
 
-	return utils.IsPasswordValidWithSettings(password, &a.Config().PasswordSettings)
+	if err := utils.IsPasswordValidWithSettings(password, &a.Config().PasswordSettings); err != nil {
+		return err
+	}
+
+	if *a.Config().ServiceSettings.CheckPasswordBreachEnabled && password != "" {
+		breached, err := utils.CheckPasswordBreach(password)
+		if err != nil {
+			// The breach API being unreachable shouldn't prevent users from setting a password.
+			mlog.Warn("Unable to check the password against the breach data", mlog.Err(err))
+		} else if breached {
+			return model.NewAppError("IsPasswordValid", "api.user.check_user_password.pwned.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
 }
 
 func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfaToken string) *model.AppError {
@@ -198,6 +213,53 @@ func (a *App) CheckUserMfa(user *model.User, token string) *model.AppError {
 	return nil
 }
 
+// IsMfaEnforcedForUser reports whether the user must set up multi-factor authentication, either because it
+// is enforced for everyone or because it is enforced for one of the roles of the user.
+func (a *App) IsMfaEnforcedForUser(user *model.User) (bool, *model.AppError) {
+	return a.isMfaEnforced(user, func() ([]*model.TeamMember, *model.AppError) {
+		return a.GetTeamMembersForUser(user.Id)
+	})
+}
+
+// IsMfaEnforcedForSession is IsMfaEnforcedForUser for the user of the session, reading the team roles from
+// the team memberships loaded with the session rather than from the store.
+func (a *App) IsMfaEnforcedForSession(session *model.Session, user *model.User) bool {
+	enforced, _ := a.isMfaEnforced(user, func() ([]*model.TeamMember, *model.AppError) {
+		return session.TeamMembers, nil
+	})
+	return enforced
+}
+
+// isMfaEnforced implements IsMfaEnforcedForUser, getting the team memberships of the user only when they
+// are needed.
+func (a *App) isMfaEnforced(user *model.User, getTeamMembers func() ([]*model.TeamMember, *model.AppError)) (bool, *model.AppError) {
+	if *a.Config().ServiceSettings.EnforceMultifactorAuthentication {
+		return !user.IsGuest() || *a.Config().GuestAccountsSettings.EnforceMultifactorAuthentication, nil
+	}
+
+	for _, role := range a.Config().ServiceSettings.EnforceMultifactorAuthenticationForRoles {
+		switch role {
+		case model.SYSTEM_ADMIN_ROLE_ID:
+			if model.IsInRole(user.Roles, model.SYSTEM_ADMIN_ROLE_ID) {
+				return true, nil
+			}
+		case model.TEAM_ADMIN_ROLE_ID:
+			members, err := getTeamMembers()
+			if err != nil {
+				return false, err
+			}
+
+			for _, member := range members {
+				if member.DeleteAt == 0 && (member.SchemeAdmin || model.IsInRole(member.Roles, model.TEAM_ADMIN_ROLE_ID)) {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func checkUserLoginAttempts(user *model.User, max int) *model.AppError {
 	if user.FailedAttempts >= max {
 		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
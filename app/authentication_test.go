@@ -50,3 +50,63 @@ func TestParseAuthTokenFromRequest(t *testing.T) {
 		require.Equal(t, tc.expectedLocation, location, "Wrong location on test "+strconv.Itoa(testnum))
 	}
 }
+
+func TestIsMfaEnforcedForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	teamAdmin := th.CreateUser()
+	th.LinkUserToTeam(teamAdmin, th.BasicTeam)
+	_, appErr := th.App.UpdateTeamMemberSchemeRoles(th.BasicTeam.Id, teamAdmin.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	isEnforced := func(user *model.User) bool {
+		enforced, appErr := th.App.IsMfaEnforcedForUser(user)
+		require.Nil(t, appErr)
+		return enforced
+	}
+
+	t.Run("enforced for no one", func(t *testing.T) {
+		require.False(t, isEnforced(th.BasicUser))
+		require.False(t, isEnforced(th.SystemAdminUser))
+		require.False(t, isEnforced(teamAdmin))
+	})
+
+	t.Run("enforced for everyone", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnforceMultifactorAuthentication = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnforceMultifactorAuthentication = false })
+
+		require.True(t, isEnforced(th.BasicUser))
+		require.True(t, isEnforced(th.SystemAdminUser))
+	})
+
+	t.Run("enforced for system admins", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.EnforceMultifactorAuthenticationForRoles = []string{model.SYSTEM_ADMIN_ROLE_ID}
+		})
+
+		require.False(t, isEnforced(th.BasicUser))
+		require.True(t, isEnforced(th.SystemAdminUser))
+		require.False(t, isEnforced(teamAdmin))
+	})
+
+	t.Run("enforced for system and team admins", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.EnforceMultifactorAuthenticationForRoles = []string{model.SYSTEM_ADMIN_ROLE_ID, model.TEAM_ADMIN_ROLE_ID}
+		})
+
+		require.False(t, isEnforced(th.BasicUser))
+		require.True(t, isEnforced(th.SystemAdminUser))
+		require.True(t, isEnforced(teamAdmin))
+
+		// The team roles of a session come from its team memberships.
+		session := &model.Session{
+			UserId:      teamAdmin.Id,
+			TeamMembers: []*model.TeamMember{{TeamId: th.BasicTeam.Id, UserId: teamAdmin.Id, SchemeAdmin: true}},
+		}
+		require.True(t, th.App.IsMfaEnforcedForSession(session, teamAdmin))
+
+		session.TeamMembers[0].SchemeAdmin = false
+		require.False(t, th.App.IsMfaEnforcedForSession(session, teamAdmin))
+	})
+}
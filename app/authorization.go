@@ -89,6 +89,29 @@ func (a *App) SessionHasPermissionToChannelByPost(session model.Session, postId
 	return a.SessionHasPermissionTo(session, permission)
 }
 
+// SessionHasPermissionToReadFile checks whether the session may read the given file, which it may
+// if it uploaded the file or can read a channel the file was shared to, either by the file's post or
+// by a channel bookmark.
+func (a *App) SessionHasPermissionToReadFile(session model.Session, info *model.FileInfo) bool {
+	if info.CreatorId == session.UserId {
+		return true
+	}
+
+	if info.PostId != "" {
+		return a.SessionHasPermissionToChannelByPost(session, info.PostId, model.PERMISSION_READ_CHANNEL)
+	}
+
+	if bookmarks, err := a.Srv().Store.ChannelBookmark().GetBookmarksForFile(info.Id); err == nil {
+		for _, bookmark := range bookmarks {
+			if a.SessionHasPermissionToChannel(session, bookmark.ChannelId, model.PERMISSION_READ_CHANNEL) {
+				return true
+			}
+		}
+	}
+
+	return a.SessionHasPermissionTo(session, model.PERMISSION_READ_CHANNEL)
+}
+
 func (a *App) SessionHasPermissionToCategory(session model.Session, userId, teamId, categoryId string) bool {
 	if a.SessionHasPermissionTo(session, model.PERMISSION_EDIT_OTHER_USERS) {
 		return true
@@ -53,7 +53,7 @@ func (a *App) CreateBasicUser(client *model.Client4) *model.AppError {
 	if err != nil {
 		return err
 	}
-	if _, err = a.Srv().Store.Team().SaveMember(&model.TeamMember{TeamId: basicteam.Id, UserId: ruser.Id}, *a.Config().TeamSettings.MaxUsersPerTeam); err != nil {
+	if _, err = a.Srv().Store.Team().SaveMember(&model.TeamMember{TeamId: basicteam.Id, UserId: ruser.Id}, a.maxUsersPerTeam()); err != nil {
 		return err
 	}
 
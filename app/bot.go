@@ -72,6 +72,10 @@ func (a *App) PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot,
 		return nil, err
 	}
 
+	if bot.Username == model.SYSTEM_BOT_USERNAME {
+		return nil, systemBotLockedError("PatchBot")
+	}
+
 	bot.Patch(botPatch)
 
 	user, err := a.Srv().Store.User().Get(botUserId)
@@ -141,6 +145,10 @@ func (a *App) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model
 		return nil, err
 	}
 
+	if isSystemBotUser(user) {
+		return nil, systemBotLockedError("UpdateBotActive")
+	}
+
 	if _, err = a.UpdateActive(user, active); err != nil {
 		return nil, err
 	}
@@ -186,6 +194,10 @@ func (a *App) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model
 
 // PermanentDeleteBot permanently deletes a bot and its corresponding user.
 func (a *App) PermanentDeleteBot(botUserId string) *model.AppError {
+	if user, err := a.Srv().Store.User().Get(botUserId); err == nil && isSystemBotUser(user) {
+		return systemBotLockedError("PermanentDeleteBot")
+	}
+
 	if err := a.Srv().Store.Bot().PermanentDelete(botUserId); err != nil {
 		var invErr *store.ErrInvalidInput
 		switch {
@@ -216,6 +228,10 @@ func (a *App) UpdateBotOwner(botUserId, newOwnerId string) (*model.Bot, *model.A
 		}
 	}
 
+	if bot.Username == model.SYSTEM_BOT_USERNAME {
+		return nil, systemBotLockedError("UpdateBotOwner")
+	}
+
 	bot.OwnerId = newOwnerId
 
 	bot, err = a.Srv().Store.Bot().Update(bot)
@@ -0,0 +1,385 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+const (
+	CERTIFICATE_REFRESH_INTERVAL = time.Hour
+
+	// Writing a certificate and its key usually takes more than one file event, so the reload waits
+	// for the files to settle.
+	CERTIFICATE_RELOAD_DELAY = time.Second
+
+	// The files are polled for changes when they can't be watched.
+	CERTIFICATE_POLL_INTERVAL = 30 * time.Second
+
+	OCSP_RESPONSE_MAX_SIZE = 1024 * 1024
+)
+
+// The admins are warned when the certificate expires within each of these numbers of days.
+var certificateExpiryWarningDays = []int{1, 7, 30}
+
+var errNoCertificateManager = errors.New("the TLS certificate isn't loaded from files")
+
+// certificateManager serves the TLS certificate loaded from the configured files. The certificate is
+// reloaded when the files change or on demand, with its OCSP response stapled when the issuer supports
+// it. A certificate that fails to load is ignored, so that the previous one keeps being served.
+type certificateManager struct {
+	certFile   string
+	keyFile    string
+	httpClient *http.Client
+	notify     func(message string)
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	ocspExpiry  time.Time
+	// warnedDays is the smallest entry of certificateExpiryWarningDays the admins were warned about
+	// for the current certificate, or 0.
+	warnedDays int
+
+	reloadTimer *time.Timer
+	watcher     *fsnotify.Watcher
+	modTimes    [2]time.Time
+	stapling    sync.WaitGroup
+	stop        chan struct{}
+	stopped     chan struct{}
+}
+
+// newCertificateManager loads the certificate and starts watching its files. The notify function is
+// called with the message to the admins when the certificate is about to expire.
+func newCertificateManager(certFile, keyFile string, httpClient *http.Client, notify func(message string)) (*certificateManager, error) {
+	m := &certificateManager{
+		certFile:   filepath.Clean(certFile),
+		keyFile:    filepath.Clean(keyFile),
+		httpClient: httpClient,
+		notify:     notify,
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := m.watch()
+	if err != nil {
+		mlog.Warn("Failed to watch the TLS certificate files, polling them instead", mlog.String("path", m.certFile), mlog.Err(err))
+		m.filesChanged()
+	}
+	m.watcher = watcher
+
+	go m.run()
+
+	return m, nil
+}
+
+func (m *certificateManager) watch() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the TLS certificate watcher")
+	}
+	for _, dir := range []string{filepath.Dir(m.certFile), filepath.Dir(m.keyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, errors.Wrapf(err, "failed to watch directory %s", dir)
+		}
+	}
+
+	return watcher, nil
+}
+
+// GetCertificate is meant to be used as the tls.Config's GetCertificate.
+func (m *certificateManager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.certificate, nil
+}
+
+// Reload loads the certificate from its files and swaps it for the one being served. Its OCSP
+// response is fetched in the background, so that a slow OCSP server doesn't hold up the startup.
+func (m *certificateManager) Reload() error {
+	certificate, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the TLS certificate")
+	}
+
+	certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the TLS certificate")
+	}
+
+	m.mutex.Lock()
+	// The admins were already warned about a certificate with the same expiry, as when the files
+	// are touched without being renewed.
+	if m.certificate == nil || !m.certificate.Leaf.NotAfter.Equal(certificate.Leaf.NotAfter) {
+		m.warnedDays = 0
+	}
+	m.certificate = &certificate
+	m.ocspExpiry = time.Time{}
+	m.mutex.Unlock()
+
+	mlog.Info("Loaded the TLS certificate", mlog.String("path", m.certFile), mlog.String("expires_at", certificate.Leaf.NotAfter.UTC().Format(time.RFC3339)))
+	m.checkExpiry()
+
+	m.stapling.Add(1)
+	go func() {
+		defer m.stapling.Done()
+		m.refreshStaple()
+	}()
+
+	return nil
+}
+
+// Close stops watching the files and refreshing the OCSP response.
+func (m *certificateManager) Close() {
+	close(m.stop)
+	<-m.stopped
+	m.stapling.Wait()
+}
+
+func (m *certificateManager) run() {
+	defer close(m.stopped)
+
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	var poll <-chan time.Time
+	if m.watcher != nil {
+		defer m.watcher.Close()
+		events, watchErrors = m.watcher.Events, m.watcher.Errors
+	} else {
+		pollTicker := time.NewTicker(CERTIFICATE_POLL_INTERVAL)
+		defer pollTicker.Stop()
+		poll = pollTicker.C
+	}
+
+	ticker := time.NewTicker(CERTIFICATE_REFRESH_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			path := filepath.Clean(event.Name)
+			if (path == m.certFile || path == m.keyFile) && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				m.scheduleReload()
+			}
+		case err := <-watchErrors:
+			mlog.Error("Failed while watching the TLS certificate", mlog.String("path", m.certFile), mlog.Err(err))
+		case <-poll:
+			if m.filesChanged() {
+				m.scheduleReload()
+			}
+		case <-ticker.C:
+			m.refreshStaple()
+			m.checkExpiry()
+		case <-m.stop:
+			m.mutex.Lock()
+			if m.reloadTimer != nil {
+				m.reloadTimer.Stop()
+			}
+			m.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// filesChanged returns whether the certificate or key file was modified since the last call.
+func (m *certificateManager) filesChanged() bool {
+	changed := false
+	for i, file := range []string{m.certFile, m.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(m.modTimes[i]) {
+			m.modTimes[i] = info.ModTime()
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (m *certificateManager) scheduleReload() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.reloadTimer != nil {
+		m.reloadTimer.Stop()
+	}
+	m.reloadTimer = time.AfterFunc(CERTIFICATE_RELOAD_DELAY, func() {
+		if err := m.Reload(); err != nil {
+			mlog.Error("Failed to reload the TLS certificate, keeping the previous one", mlog.String("path", m.certFile), mlog.Err(err))
+		}
+	})
+}
+
+// refreshStaple fetches a new OCSP response once half of the validity of the current one has passed.
+func (m *certificateManager) refreshStaple() {
+	m.mutex.RLock()
+	current := m.certificate
+	ocspExpiry := m.ocspExpiry
+	m.mutex.RUnlock()
+
+	if !ocspExpiry.IsZero() && time.Until(ocspExpiry) > CERTIFICATE_REFRESH_INTERVAL*12 {
+		return
+	}
+
+	certificate := *current
+	ocspExpiry, err := m.staple(&certificate)
+	if err != nil {
+		mlog.Warn("Failed to refresh the OCSP response of the TLS certificate", mlog.String("path", m.certFile), mlog.Err(err))
+		return
+	} else if ocspExpiry.IsZero() {
+		return
+	}
+
+	m.mutex.Lock()
+	// The certificate may have been reloaded in the meantime.
+	if m.certificate == current {
+		m.certificate = &certificate
+		m.ocspExpiry = ocspExpiry
+	}
+	m.mutex.Unlock()
+}
+
+// staple sets the OCSP response of the certificate and returns the time it expires at. Certificates
+// without an OCSP server or issuer are left as they are.
+func (m *certificateManager) staple(certificate *tls.Certificate) (time.Time, error) {
+	certificate.OCSPStaple = nil
+	if len(certificate.Leaf.OCSPServer) == 0 || len(certificate.Certificate) < 2 {
+		return time.Time{}, nil
+	}
+
+	issuer, err := x509.ParseCertificate(certificate.Certificate[1])
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse the issuer certificate")
+	}
+
+	request, err := ocsp.CreateRequest(certificate.Leaf, issuer, nil)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to create the OCSP request")
+	}
+
+	resp, err := m.httpClient.Post(certificate.Leaf.OCSPServer[0], "application/ocsp-request", bytes.NewReader(request))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to request the OCSP response")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("unexpected status code %d from the OCSP server", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, OCSP_RESPONSE_MAX_SIZE))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to read the OCSP response")
+	}
+
+	response, err := ocsp.ParseResponseForCert(body, certificate.Leaf, issuer)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse the OCSP response")
+	}
+
+	if response.Status != ocsp.Good {
+		return time.Time{}, fmt.Errorf("the OCSP server reported the certificate as %s", ocspStatusName(response.Status))
+	}
+
+	certificate.OCSPStaple = body
+
+	return response.NextUpdate, nil
+}
+
+func ocspStatusName(status int) string {
+	switch status {
+	case ocsp.Revoked:
+		return "revoked"
+	case ocsp.Unknown:
+		return "unknown"
+	default:
+		return fmt.Sprintf("status %d", status)
+	}
+}
+
+// checkExpiry warns the admins once for each threshold of certificateExpiryWarningDays the
+// certificate reaches.
+func (m *certificateManager) checkExpiry() {
+	m.mutex.Lock()
+	notAfter := m.certificate.Leaf.NotAfter
+	remaining := time.Until(notAfter)
+
+	days := 0
+	for _, threshold := range certificateExpiryWarningDays {
+		if remaining <= time.Duration(threshold)*24*time.Hour {
+			days = threshold
+			break
+		}
+	}
+	if days == 0 || days == m.warnedDays {
+		m.mutex.Unlock()
+		return
+	}
+	m.warnedDays = days
+	m.mutex.Unlock()
+
+	message := utils.T("app.certificate.expired", map[string]interface{}{"Path": m.certFile, "Date": notAfter.UTC().Format(time.RFC1123)})
+	if remaining > 0 {
+		message = utils.T("app.certificate.expiring", map[string]interface{}{"Path": m.certFile, "Days": int(remaining/(24*time.Hour)) + 1, "Date": notAfter.UTC().Format(time.RFC1123)})
+	}
+
+	mlog.Warn("The TLS certificate is expiring", mlog.String("path", m.certFile), mlog.String("expires_at", notAfter.UTC().Format(time.RFC3339)))
+	if m.notify != nil {
+		m.notify(message)
+	}
+}
+
+// ReloadCertificates reloads the TLS certificate from its files. The certificate being served is kept
+// when the new files are invalid.
+func (s *Server) ReloadCertificates() error {
+	s.certificateManagerLock.Lock()
+	defer s.certificateManagerLock.Unlock()
+
+	if s.certificateManager == nil {
+		return errNoCertificateManager
+	}
+
+	return s.certificateManager.Reload()
+}
+
+func (s *Server) notifyAdminsOfCertificateExpiry(message string) {
+	if _, appErr := New(ServerConnector(s)).NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_CERTIFICATE_EXPIRING, message); appErr != nil {
+		mlog.Error("Failed to notify admins of the TLS certificate expiry", mlog.Err(appErr))
+	}
+}
+
+func (a *App) ReloadCertificates() *model.AppError {
+	if err := a.Srv().ReloadCertificates(); err == errNoCertificateManager {
+		return model.NewAppError("ReloadCertificates", "app.certificate.reload.not_available.app_error", nil, "", http.StatusBadRequest)
+	} else if err != nil {
+		return model.NewAppError("ReloadCertificates", "app.certificate.reload.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func createTestCertificate(t *testing.T, template *x509.Certificate, issuer *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template.SerialNumber = serial
+
+	parent, parentKey := template, crypto.Signer(key)
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if issuer != nil {
+		certPEM = append(certPEM, issuer.certPEM...)
+	}
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: certPEM,
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestCertificate(t *testing.T, dir string, certificate *testCertificate) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cert.pem"), certificate.certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), certificate.keyPEM, 0600))
+}
+
+func servedSerialNumber(t *testing.T, m *certificateManager) *big.Int {
+	certificate, err := m.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	return certificate.Leaf.SerialNumber
+}
+
+func TestCertificateManagerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certificates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	validFor := func() *x509.Certificate {
+		return &x509.Certificate{
+			Subject:   pkix.Name{CommonName: "localhost"},
+			NotBefore: time.Now().Add(-time.Hour),
+			NotAfter:  time.Now().Add(365 * 24 * time.Hour),
+		}
+	}
+
+	first := createTestCertificate(t, validFor(), nil)
+	writeTestCertificate(t, dir, first)
+
+	m, err := newCertificateManager(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), http.DefaultClient, nil)
+	require.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, first.cert.SerialNumber, servedSerialNumber(t, m))
+
+	t.Run("invalid files keep the previous certificate", func(t *testing.T) {
+		mismatched := createTestCertificate(t, validFor(), nil)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cert.pem"), mismatched.certPEM, 0600))
+
+		require.Error(t, m.Reload())
+		assert.Equal(t, first.cert.SerialNumber, servedSerialNumber(t, m))
+	})
+
+	t.Run("reload on demand", func(t *testing.T) {
+		second := createTestCertificate(t, validFor(), nil)
+		writeTestCertificate(t, dir, second)
+
+		require.NoError(t, m.Reload())
+		assert.Equal(t, second.cert.SerialNumber, servedSerialNumber(t, m))
+	})
+
+	t.Run("reload when the files change", func(t *testing.T) {
+		third := createTestCertificate(t, validFor(), nil)
+		writeTestCertificate(t, dir, third)
+
+		assert.Eventually(t, func() bool {
+			return servedSerialNumber(t, m).Cmp(third.cert.SerialNumber) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("poll the files when they can't be watched", func(t *testing.T) {
+		m.filesChanged()
+		assert.False(t, m.filesChanged())
+
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "key.pem"), later, later))
+		assert.True(t, m.filesChanged())
+		assert.False(t, m.filesChanged())
+	})
+}
+
+func TestCertificateManagerExpiryWarnings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certificates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	expiring := createTestCertificate(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "localhost"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(5 * 24 * time.Hour),
+	}, nil)
+	writeTestCertificate(t, dir, expiring)
+
+	var messages []string
+	m, err := newCertificateManager(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), http.DefaultClient, func(message string) {
+		messages = append(messages, message)
+	})
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "expires in 5 days")
+	assert.Equal(t, 7, m.warnedDays)
+
+	m.checkExpiry()
+	assert.Len(t, messages, 1, "the admins should only be warned once for each threshold")
+
+	require.NoError(t, m.Reload())
+	assert.Len(t, messages, 1, "reloading the same certificate shouldn't warn the admins again")
+}
+
+func TestCertificateManagerOCSPStapling(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certificates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := createTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+
+	status := ocsp.Good
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		request, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		response, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: request.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Hour),
+			NextUpdate:   time.Now().Add(48 * time.Hour),
+			RevokedAt:    time.Now().Add(-time.Hour),
+		}, ca.key)
+		require.NoError(t, err)
+		w.Write(response)
+	}))
+	defer responder.Close()
+
+	leaf := createTestCertificate(t, &x509.Certificate{
+		Subject:    pkix.Name{CommonName: "localhost"},
+		NotBefore:  time.Now().Add(-time.Hour),
+		NotAfter:   time.Now().Add(365 * 24 * time.Hour),
+		OCSPServer: []string{responder.URL},
+	}, ca)
+	writeTestCertificate(t, dir, leaf)
+
+	m, err := newCertificateManager(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), http.DefaultClient, nil)
+	require.NoError(t, err)
+	defer m.Close()
+
+	// The OCSP response is fetched in the background.
+	m.stapling.Wait()
+
+	certificate, err := m.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.NotEmpty(t, certificate.OCSPStaple)
+
+	response, err := ocsp.ParseResponseForCert(certificate.OCSPStaple, leaf.cert, ca.cert)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.Good, response.Status)
+	assert.False(t, m.ocspExpiry.IsZero())
+
+	t.Run("revoked certificates aren't stapled", func(t *testing.T) {
+		status = ocsp.Revoked
+
+		require.NoError(t, m.Reload())
+		m.stapling.Wait()
+		certificate, err := m.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		assert.Empty(t, certificate.OCSPStaple)
+	})
+}
@@ -112,11 +112,11 @@ func (a *App) JoinDefaultChannels(teamId string, user *model.User, shouldBeAdmin
 
 		a.invalidateCacheForChannelMembers(channel.Id)
 
-		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_ADDED, "", channel.Id, "", nil)
-		message.Add("user_id", user.Id)
-		message.Add("team_id", channel.TeamId)
-		a.Publish(message)
-
+		actorId := ""
+		if requestor != nil {
+			actorId = requestor.Id
+		}
+		a.publishUserAddedToChannel(user.Id, channel, actorId)
 	}
 
 	return err
@@ -167,7 +167,7 @@ func (a *App) CreateChannelWithUser(channel *model.Channel, userId string) (*mod
 
 	channel.CreatorId = userId
 
-	rchannel, err := a.CreateChannel(channel, true)
+	rchannel, err := a.createChannel(channel, true, a.HasPermissionTo(userId, model.PERMISSION_MANAGE_SYSTEM))
 	if err != nil {
 		return nil, err
 	}
@@ -210,9 +210,30 @@ func (a *App) RenameChannel(channel *model.Channel, newChannelName string, newDi
 	return newChannel, nil
 }
 
+// CreateChannel creates the channel, counting it against the daily channel creation limit of its
+// creator, if any.
 func (a *App) CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
+	return a.createChannel(channel, addMember, false)
+}
+
+// CreateChannelBypassingThrottle creates the channel without counting it against the daily channel
+// creation limit of its creator. It's meant for admins and imports, which legitimately create many
+// channels at once. The maximum number of channels per team still applies.
+func (a *App) CreateChannelBypassingThrottle(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
+	return a.createChannel(channel, addMember, true)
+}
+
+func (a *App) createChannel(channel *model.Channel, addMember bool, bypassThrottle bool) (*model.Channel, *model.AppError) {
 	channel.DisplayName = strings.TrimSpace(channel.DisplayName)
-	sc, nErr := a.Srv().Store.Channel().Save(channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
+
+	// The channels created by the user since the start of the day, in UTC, are counted as the
+	// channel is saved, so that concurrent creations on any node can't exceed the limit.
+	maxChannelsPerCreator := int64(-1)
+	if maxChannels := *a.Config().TeamSettings.MaxChannelsCreatedPerUserPerDay; maxChannels > 0 && !bypassThrottle {
+		maxChannelsPerCreator = maxChannels
+	}
+
+	sc, nErr := a.Srv().Store.Channel().SaveWithCreatorLimit(channel, *a.Config().TeamSettings.MaxChannelsPerTeam, maxChannelsPerCreator, model.GetStartOfDayMillis(time.Now().UTC(), 0))
 	if nErr != nil {
 		var invErr *store.ErrInvalidInput
 		var cErr *store.ErrConflict
@@ -230,6 +251,8 @@ func (a *App) CreateChannel(channel *model.Channel, addMember bool) (*model.Chan
 			}
 		case errors.As(nErr, &cErr):
 			return sc, model.NewAppError("CreateChannel", store.CHANNEL_EXISTS_ERROR, nil, cErr.Error(), http.StatusBadRequest)
+		case errors.As(nErr, &ltErr) && ltErr.What == "channels_per_creator":
+			return nil, model.NewAppError("CreateChannel", "app.channel.create_channel.user_daily_limit.app_error", map[string]interface{}{"MaxChannelsCreatedPerUserPerDay": maxChannelsPerCreator}, ltErr.Error(), http.StatusTooManyRequests)
 		case errors.As(nErr, &ltErr):
 			return nil, model.NewAppError("CreateChannel", "store.sql_channel.save_channel.limit.app_error", nil, ltErr.Error(), http.StatusBadRequest)
 		case errors.As(nErr, &appErr): // in case we haven't converted to plain error.
@@ -982,6 +1005,132 @@ func buildChannelModerations(channelType string, memberRole *model.Role, guestRo
 	return channelModerations
 }
 
+// getCreatePostModeration returns the create_post channel moderation for the channel.
+func (a *App) getCreatePostModeration(channel *model.Channel) (*model.ChannelModeration, *model.AppError) {
+	moderations, err := a.GetChannelModerationsForChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, moderation := range moderations {
+		if moderation.Name == model.PERMISSION_CREATE_POST.Id {
+			return moderation, nil
+		}
+	}
+
+	return nil, model.NewAppError("getCreatePostModeration", "app.channel.read_only.moderation_not_found.app_error", nil, "channel_id="+channel.Id, http.StatusInternalServerError)
+}
+
+// IsChannelReadOnly returns true if the channel moderations prevent channel members from posting, leaving it
+// to the channel admins.
+func (a *App) IsChannelReadOnly(channel *model.Channel) (bool, *model.AppError) {
+	if channel.IsGroupOrDirect() {
+		return false, nil
+	}
+
+	moderation, err := a.getCreatePostModeration(channel)
+	if err != nil {
+		return false, err
+	}
+
+	return !moderation.Roles.Members.Value, nil
+}
+
+// FillInChannelReadOnly sets the computed IsReadOnly field of the channel. It is left unset
+// when the channel moderations can't be read, rather than failing the request.
+func (a *App) FillInChannelReadOnly(channel *model.Channel) {
+	readOnly, err := a.IsChannelReadOnly(channel)
+	if err != nil {
+		mlog.Warn("Failed to compute whether the channel is read-only", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return
+	}
+
+	channel.IsReadOnly = model.NewBool(readOnly)
+}
+
+// SetChannelReadOnly turns the channel into an announcement channel where only channel admins can post, or
+// reverts it. This patches the create_post channel moderation, so enabling it removes the permission from
+// members and guests and disabling it restores whatever the team or system scheme allows.
+func (a *App) SetChannelReadOnly(channel *model.Channel, userId string, enabled bool) (*model.Channel, *model.AppError) {
+	if channel.IsGroupOrDirect() {
+		return nil, model.NewAppError("SetChannelReadOnly", "app.channel.read_only.direct_channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	moderation, err := a.getCreatePostModeration(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	if moderation.Roles.Members.Value == !enabled {
+		channel.IsReadOnly = model.NewBool(enabled)
+		return channel, nil
+	}
+
+	patch := &model.ChannelModerationPatch{
+		Name:  model.NewString(model.PERMISSION_CREATE_POST.Id),
+		Roles: &model.ChannelModeratedRolesPatch{},
+	}
+	if enabled {
+		patch.Roles.Members = model.NewBool(false)
+		if moderation.Roles.Guests != nil {
+			patch.Roles.Guests = model.NewBool(false)
+		}
+	} else {
+		if !moderation.Roles.Members.Enabled {
+			return nil, model.NewAppError("SetChannelReadOnly", "app.channel.read_only.restricted.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+		}
+		patch.Roles.Members = model.NewBool(true)
+		if moderation.Roles.Guests != nil {
+			patch.Roles.Guests = model.NewBool(moderation.Roles.Guests.Enabled)
+		}
+	}
+
+	if _, err = a.PatchChannelModerationsForChannel(channel, []*model.ChannelModerationPatch{patch}); err != nil {
+		return nil, err
+	}
+
+	// Patching the moderations may have created or deleted the channel scheme
+	channel, err = a.GetChannel(channel.Id)
+	if err != nil {
+		return nil, err
+	}
+	channel.IsReadOnly = model.NewBool(enabled)
+
+	if err = a.postChannelReadOnlyMessage(userId, channel, enabled); err != nil {
+		mlog.Error("Failed to post read only change message", mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+
+	return channel, nil
+}
+
+func (a *App) postChannelReadOnlyMessage(userId string, channel *model.Channel, enabled bool) *model.AppError {
+	user, err := a.Srv().Store.User().Get(userId)
+	if err != nil {
+		return model.NewAppError("postChannelReadOnlyMessage", "app.channel.post_read_only_message.retrieve_user.error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	message := fmt.Sprintf(utils.T("app.channel.post_read_only_message.disabled"), user.Username)
+	if enabled {
+		message = fmt.Sprintf(utils.T("app.channel.post_read_only_message.enabled"), user.Username)
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   message,
+		Type:      model.POST_CHANGE_READ_ONLY,
+		UserId:    userId,
+		Props: model.StringInterface{
+			"username":  user.Username,
+			"read_only": enabled,
+		},
+	}
+	if _, err := a.CreatePost(post, channel, false, true); err != nil {
+		return model.NewAppError("postChannelReadOnlyMessage", "app.channel.post_read_only_message.post.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (a *App) UpdateChannelMemberRoles(channelId string, userId string, newRoles string) (*model.ChannelMember, *model.AppError) {
 	var member *model.ChannelMember
 	var err *model.AppError
@@ -1080,6 +1229,33 @@ func (a *App) UpdateChannelMemberSchemeRoles(channelId string, userId string, is
 	return member, nil
 }
 
+// UpdateChannelMemberManuallyManaged flags a channel admin as manually managed, so that the group sync
+// leaves them in a group-constrained channel although they aren't a member of any of its groups.
+func (a *App) UpdateChannelMemberManuallyManaged(channelId string, userId string, manuallyManaged bool) (*model.ChannelMember, *model.AppError) {
+	member, err := a.GetChannelMember(channelId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if manuallyManaged && !member.SchemeAdmin {
+		return nil, model.NewAppError("UpdateChannelMemberManuallyManaged", "app.channel.update_member_manually_managed.not_admin.app_error", nil, "channel_id="+channelId+", user_id="+userId, http.StatusBadRequest)
+	}
+
+	member.ManuallyManaged = manuallyManaged
+
+	member, err = a.Srv().Store.Channel().UpdateMember(member)
+	if err != nil {
+		return nil, err
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_MEMBER_UPDATED, "", "", userId, nil)
+	message.Add("channelMember", member.ToJson())
+	a.Publish(message)
+
+	a.InvalidateCacheForUser(userId)
+	return member, nil
+}
+
 func (a *App) UpdateChannelMemberNotifyProps(data map[string]string, channelId string, userId string) (*model.ChannelMember, *model.AppError) {
 	var member *model.ChannelMember
 	var err *model.AppError
@@ -1217,6 +1393,59 @@ func (a *App) DeleteChannel(channel *model.Channel, userId string) *model.AppErr
 	return nil
 }
 
+const ARCHIVE_CHANNELS_MAX = 200
+
+// ArchiveChannels archives each of the given channels on behalf of the session user, who needs the
+// permission to delete it. A channel that is skipped or fails doesn't stop the others, and the
+// outcome for each channel is returned in the given order. Default channels are never archived.
+func (a *App) ArchiveChannels(channelIds []string) ([]*model.ChannelArchiveResult, *model.AppError) {
+	if len(channelIds) == 0 || len(channelIds) > ARCHIVE_CHANNELS_MAX {
+		return nil, model.NewAppError("ArchiveChannels", "app.channel.archive_channels.count.app_error", map[string]interface{}{"Max": ARCHIVE_CHANNELS_MAX}, "", http.StatusBadRequest)
+	}
+
+	defaultChannels := make(map[string]bool)
+	for _, name := range a.DefaultChannelNames() {
+		defaultChannels[name] = true
+	}
+
+	results := make([]*model.ChannelArchiveResult, 0, len(channelIds))
+	for _, channelId := range channelIds {
+		err := a.archiveChannelForSession(channelId, defaultChannels)
+		results = append(results, &model.ChannelArchiveResult{
+			ChannelId: channelId,
+			Archived:  err == nil,
+			Error:     err,
+		})
+	}
+
+	return results, nil
+}
+
+func (a *App) archiveChannelForSession(channelId string, defaultChannels map[string]bool) *model.AppError {
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.Type == model.CHANNEL_DIRECT || channel.Type == model.CHANNEL_GROUP {
+		return model.NewAppError("ArchiveChannels", "api.channel.delete_channel.type.invalid", nil, "", http.StatusBadRequest)
+	}
+
+	if defaultChannels[channel.Name] {
+		return model.NewAppError("ArchiveChannels", "app.channel.archive_channels.default_channel.app_error", map[string]interface{}{"Channel": channel.Name}, "", http.StatusBadRequest)
+	}
+
+	permission := model.PERMISSION_DELETE_PUBLIC_CHANNEL
+	if channel.Type == model.CHANNEL_PRIVATE {
+		permission = model.PERMISSION_DELETE_PRIVATE_CHANNEL
+	}
+	if !a.SessionHasPermissionToChannel(*a.Session(), channel.Id, permission) {
+		return a.MakePermissionError(permission)
+	}
+
+	return a.DeleteChannel(channel, a.Session().UserId)
+}
+
 func (a *App) addUserToChannel(user *model.User, channel *model.Channel, teamMember *model.TeamMember) (*model.ChannelMember, *model.AppError) {
 	if channel.Type != model.CHANNEL_OPEN && channel.Type != model.CHANNEL_PRIVATE {
 		return nil, model.NewAppError("AddUserToChannel", "api.channel.add_user_to_channel.type.app_error", nil, "", http.StatusBadRequest)
@@ -1277,6 +1506,12 @@ func (a *App) addUserToChannel(user *model.User, channel *model.Channel, teamMem
 }
 
 func (a *App) AddUserToChannel(user *model.User, channel *model.Channel) (*model.ChannelMember, *model.AppError) {
+	return a.addUserToChannelByActor(user, channel, "")
+}
+
+// addUserToChannelByActor adds the user to the channel like AddUserToChannel, on behalf of the
+// user with the given id, which is empty when the user joins the channel by themself.
+func (a *App) addUserToChannelByActor(user *model.User, channel *model.Channel, actorId string) (*model.ChannelMember, *model.AppError) {
 	teamMember, err := a.Srv().Store.Team().GetMember(channel.TeamId, user.Id)
 
 	if err != nil {
@@ -1291,12 +1526,23 @@ func (a *App) AddUserToChannel(user *model.User, channel *model.Channel) (*model
 		return nil, err
 	}
 
+	a.publishUserAddedToChannel(user.Id, channel, actorId)
+
+	return newMember, nil
+}
+
+// publishUserAddedToChannel notifies the members of the channel that the user was added to it by
+// the actor, whose id is left empty when the user joined by themself.
+func (a *App) publishUserAddedToChannel(userId string, channel *model.Channel, actorId string) {
+	if actorId == userId {
+		actorId = ""
+	}
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_ADDED, "", channel.Id, "", nil)
-	message.Add("user_id", user.Id)
+	message.Add("user_id", userId)
 	message.Add("team_id", channel.TeamId)
+	message.Add("actor_id", actorId)
 	a.Publish(message)
-
-	return newMember, nil
 }
 
 func (a *App) AddChannelMember(userId string, channel *model.Channel, userRequestorId string, postRootId string) (*model.ChannelMember, *model.AppError) {
@@ -1322,7 +1568,7 @@ func (a *App) AddChannelMember(userId string, channel *model.Channel, userReques
 		}
 	}
 
-	cm, err := a.AddUserToChannel(user, channel)
+	cm, err := a.addUserToChannelByActor(user, channel, userRequestorId)
 	if err != nil {
 		return nil, err
 	}
@@ -1343,6 +1589,10 @@ func (a *App) AddChannelMember(userId string, channel *model.Channel, userReques
 		a.Srv().Go(func() {
 			a.PostAddToChannelMessage(userRequestor, user, channel, postRootId)
 		})
+
+		a.Srv().Go(func() {
+			a.notifyUserAddedToChannel(user, channel, userRequestor)
+		})
 	}
 
 	return cm, nil
@@ -1604,6 +1854,17 @@ func (a *App) GetAllChannelsCount(opts model.ChannelSearchOpts) (int64, *model.A
 	return count, nil
 }
 
+// GetTeamChannelsWithActivity returns a page of the public and private channels of a team along
+// with their number of members.
+func (a *App) GetTeamChannelsWithActivity(teamId string, page, perPage int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, *model.AppError) {
+	channels, err := a.Srv().Store.Channel().GetTeamChannelsWithActivity(teamId, page*perPage, perPage, opts)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamChannelsWithActivity", "app.channel.get_team_channels_with_activity.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return channels, nil
+}
+
 func (a *App) GetDeletedChannels(teamId string, offset int, limit int, userId string) (*model.ChannelList, *model.AppError) {
 	list, err := a.Srv().Store.Channel().GetDeleted(teamId, offset, limit, userId)
 	if err != nil {
@@ -1619,6 +1880,28 @@ func (a *App) GetDeletedChannels(teamId string, offset int, limit int, userId st
 	return list, nil
 }
 
+// GetDeletedChannelsForUserSince returns the channels of a team deleted after the given time that the
+// user is a member of, oldest deletion first, so clients can prune them from their local state.
+func (a *App) GetDeletedChannelsForUserSince(teamId, userId string, since int64) (*model.ChannelList, *model.AppError) {
+	channels, err := a.Srv().Store.Channel().GetDeletedByTeamForUserSince(teamId, userId, since)
+	if err != nil {
+		return nil, model.NewAppError("GetDeletedChannelsForUserSince", "app.channel.get_deleted_for_user_since.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &channels, nil
+}
+
+// GetRecentlyDeletedChannels returns the deleted channels of a team, including private ones, most
+// recently deleted first. It isn't filtered by membership, so it's meant for system admins.
+func (a *App) GetRecentlyDeletedChannels(teamId string, offset, limit int) ([]*model.Channel, *model.AppError) {
+	channels, err := a.Srv().Store.Channel().GetRecentlyDeleted(teamId, offset, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetRecentlyDeletedChannels", "app.channel.get_recently_deleted.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return channels, nil
+}
+
 func (a *App) GetChannelsUserNotIn(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
 	channels, err := a.Srv().Store.Channel().GetMoreChannels(teamId, userId, offset, limit)
 	if err != nil {
@@ -1631,8 +1914,8 @@ func (a *App) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string)
 	return a.Srv().Store.Channel().GetPublicChannelsByIdsForTeam(teamId, channelIds)
 }
 
-func (a *App) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
-	return a.Srv().Store.Channel().GetPublicChannelsForTeam(teamId, offset, limit)
+func (a *App) GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError) {
+	return a.Srv().Store.Channel().GetPublicChannelsForTeam(teamId, offset, limit, includeHidden)
 }
 
 func (a *App) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
@@ -1700,6 +1983,52 @@ func (a *App) GetChannelPinnedPostCount(channelId string) (int64, *model.AppErro
 	return a.Srv().Store.Channel().GetPinnedPostCount(channelId, true)
 }
 
+// CHANNEL_STATS_RECENT_DAYS is the number of days covered by the recent root post count of the channel stats.
+const CHANNEL_STATS_RECENT_DAYS = 7
+
+// GetChannelRecentRootPostCount returns the number of root posts created in the channel over the
+// last CHANNEL_STATS_RECENT_DAYS days.
+func (a *App) GetChannelRecentRootPostCount(channelId string) (int64, *model.AppError) {
+	since := model.GetMillis() - CHANNEL_STATS_RECENT_DAYS*dayInMilliseconds
+	count, err := a.Srv().Store.Channel().GetRootPostCountSince(channelId, since)
+	if err != nil {
+		return 0, model.NewAppError("GetChannelRecentRootPostCount", "app.channel.get_recent_root_post_count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// GetChannelStats returns the member, guest, pinned post and recent root post counts of the channel.
+func (a *App) GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError) {
+	memberCount, err := a.GetChannelMemberCount(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	guestCount, err := a.GetChannelGuestCount(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	pinnedPostCount, err := a.GetChannelPinnedPostCount(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	recentRootPostCount, err := a.GetChannelRecentRootPostCount(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ChannelStats{
+		ChannelId:           channelId,
+		MemberCount:         memberCount,
+		GuestCount:          guestCount,
+		PinnedPostCount:     pinnedPostCount,
+		RecentRootPostCount: recentRootPostCount,
+	}, nil
+}
+
 func (a *App) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
 	return a.Srv().Store.Channel().GetChannelCounts(teamId, userId)
 }
@@ -1717,6 +2046,29 @@ func (a *App) GetChannelUnread(channelId, userId string) (*model.ChannelUnread,
 	return channelUnread, nil
 }
 
+// GetChannelLastViewed returns the last post the user saw in the channel and when they last viewed
+// it, so clients can place the new messages divider where the server does. Both are empty when the
+// user never viewed the channel.
+func (a *App) GetChannelLastViewed(channelId, userId string) (*model.ChannelLastViewed, *model.AppError) {
+	postId, lastViewedAt, err := a.Srv().Store.Channel().GetLastViewedPostForUser(userId, channelId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetChannelLastViewed", "app.channel.get_last_viewed.missing.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetChannelLastViewed", "app.channel.get_last_viewed.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return &model.ChannelLastViewed{
+		ChannelId:    channelId,
+		UserId:       userId,
+		PostId:       postId,
+		LastViewedAt: lastViewedAt,
+	}, nil
+}
+
 func (a *App) JoinChannel(channel *model.Channel, userId string) *model.AppError {
 	userChan := make(chan store.StoreResult, 1)
 	memberChan := make(chan store.StoreResult, 1)
@@ -1771,6 +2123,10 @@ func (a *App) JoinChannel(channel *model.Channel, userId string) *model.AppError
 }
 
 func (a *App) postJoinChannelMessage(user *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	message := fmt.Sprintf(utils.T("api.channel.join_channel.post_and_forget"), user.Username)
 	postType := model.POST_JOIN_CHANNEL
 
@@ -1797,6 +2153,10 @@ func (a *App) postJoinChannelMessage(user *model.User, channel *model.Channel) *
 }
 
 func (a *App) postJoinTeamMessage(user *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message:   fmt.Sprintf(utils.T("api.team.join_team.post_and_forget"), user.Username),
@@ -1885,6 +2245,10 @@ func (a *App) LeaveChannel(channelId string, userId string) *model.AppError {
 }
 
 func (a *App) postLeaveChannelMessage(user *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		// Message here embeds `@username`, not just `username`, to ensure that mentions
@@ -1906,6 +2270,10 @@ func (a *App) postLeaveChannelMessage(user *model.User, channel *model.Channel)
 }
 
 func (a *App) PostAddToChannelMessage(user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	message := fmt.Sprintf(utils.T("api.channel.add_member.added"), addedUser.Username, user.Username)
 	postType := model.POST_ADD_TO_CHANNEL
 
@@ -1925,6 +2293,7 @@ func (a *App) PostAddToChannelMessage(user *model.User, addedUser *model.User, c
 			"username":                     user.Username,
 			model.POST_PROPS_ADDED_USER_ID: addedUser.Id,
 			"addedUsername":                addedUser.Username,
+			model.POST_PROPS_ACTOR_ID:      user.Id,
 		},
 	}
 
@@ -1936,6 +2305,10 @@ func (a *App) PostAddToChannelMessage(user *model.User, addedUser *model.User, c
 }
 
 func (a *App) postAddToTeamMessage(user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message:   fmt.Sprintf(utils.T("api.team.add_user_to_team.added"), addedUser.Username, user.Username),
@@ -1947,6 +2320,7 @@ func (a *App) postAddToTeamMessage(user *model.User, addedUser *model.User, chan
 			"username":                     user.Username,
 			model.POST_PROPS_ADDED_USER_ID: addedUser.Id,
 			"addedUsername":                addedUser.Username,
+			model.POST_PROPS_ACTOR_ID:      user.Id,
 		},
 	}
 
@@ -1958,6 +2332,10 @@ func (a *App) postAddToTeamMessage(user *model.User, addedUser *model.User, chan
 }
 
 func (a *App) postRemoveFromChannelMessage(removerUserId string, removedUser *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		// Message here embeds `@username`, not just `username`, to ensure that mentions
@@ -1967,8 +2345,9 @@ func (a *App) postRemoveFromChannelMessage(removerUserId string, removedUser *mo
 		Type:    model.POST_REMOVE_FROM_CHANNEL,
 		UserId:  removerUserId,
 		Props: model.StringInterface{
-			"removedUserId":   removedUser.Id,
-			"removedUsername": removedUser.Username,
+			"removedUserId":           removedUser.Id,
+			"removedUsername":         removedUser.Username,
+			model.POST_PROPS_ACTOR_ID: removerUserId,
 		},
 	}
 
@@ -2049,15 +2428,22 @@ func (a *App) removeUserFromChannel(userIdToRemove string, removerUserId string,
 		})
 	}
 
+	actorId := removerUserId
+	if actorId == userIdToRemove {
+		actorId = ""
+	}
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_REMOVED, "", channel.Id, "", nil)
 	message.Add("user_id", userIdToRemove)
 	message.Add("remover_id", removerUserId)
+	message.Add("actor_id", actorId)
 	a.Publish(message)
 
 	// because the removed user no longer belongs to the channel we need to send a separate websocket event
 	userMsg := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_REMOVED, "", "", userIdToRemove, nil)
 	userMsg.Add("channel_id", channel.Id)
 	userMsg.Add("remover_id", removerUserId)
+	userMsg.Add("actor_id", actorId)
 	a.Publish(userMsg)
 
 	return nil
@@ -2180,7 +2566,8 @@ func (a *App) AutocompleteChannels(teamId string, term string) (*model.ChannelLi
 func (a *App) AutocompleteChannelsForSearch(teamId string, userId string, term string) (*model.ChannelList, *model.AppError) {
 	includeDeleted := *a.Config().TeamSettings.ExperimentalViewArchivedChannels
 
-	term = strings.TrimSpace(term)
+	// Direct messages are suggested for in:@username, so match the term without the @.
+	term = strings.TrimPrefix(strings.TrimSpace(term), "@")
 
 	return a.Srv().Store.Channel().AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
 }
@@ -2341,6 +2728,10 @@ func (a *App) PermanentDeleteChannel(channel *model.Channel) *model.AppError {
 		return err
 	}
 
+	if nErr := a.Srv().Store.ChannelBookmark().PermanentDeleteByChannel(channel.Id); nErr != nil {
+		return model.NewAppError("PermanentDeleteChannel", "app.channel_bookmark.permanent_delete_by_channel.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
 	if nErr := a.Srv().Store.Channel().PermanentDelete(channel.Id); nErr != nil {
 		return model.NewAppError("PermanentDeleteChannel", "app.channel.permanent_delete.app_error", nil, nErr.Error(), http.StatusInternalServerError)
 	}
@@ -2635,7 +3026,17 @@ func (a *App) GetSidebarCategories(userId, teamId string) (*model.OrderedSidebar
 		categories, err = a.waitForSidebarCategories(userId, teamId)
 	}
 
-	return categories, err
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories.Categories {
+		if err := a.sortDirectMessagesCategoryByRecency(category); err != nil {
+			return nil, err
+		}
+	}
+
+	return categories, nil
 }
 
 // waitForSidebarCategories is used to get a user's sidebar categories after they've been created since there may be
@@ -2669,7 +3070,78 @@ func (a *App) GetSidebarCategoryOrder(userId, teamId string) ([]string, *model.A
 }
 
 func (a *App) GetSidebarCategory(categoryId string) (*model.SidebarCategoryWithChannels, *model.AppError) {
-	return a.Srv().Store.Channel().GetSidebarCategory(categoryId)
+	category, err := a.Srv().Store.Channel().GetSidebarCategory(categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.sortDirectMessagesCategoryByRecency(category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// sortDirectMessagesCategoryByRecency orders the channels of a direct messages category sorted by recency
+// so that the most recently posted in channels come first. Other categories are left untouched.
+func (a *App) sortDirectMessagesCategoryByRecency(category *model.SidebarCategoryWithChannels) *model.AppError {
+	if category.Type != model.SidebarCategoryDirectMessages || category.Sorting != model.SidebarCategorySortRecent || len(category.Channels) == 0 {
+		return nil
+	}
+
+	// Channels in the category can be less recent than direct channels the user moved to other categories,
+	// so all of them are fetched.
+	recentChannels, err := a.GetRecentDirectChannels(category.UserId, 0, false)
+	if err != nil {
+		return err
+	}
+
+	inCategory := make(map[string]bool, len(category.Channels))
+	for _, channelId := range category.Channels {
+		inCategory[channelId] = true
+	}
+
+	sorted := make([]string, 0, len(category.Channels))
+	for _, channel := range recentChannels {
+		if inCategory[channel.Id] {
+			sorted = append(sorted, channel.Id)
+			delete(inCategory, channel.Id)
+		}
+	}
+
+	// Keep anything not returned as a recent channel at the end in its existing order
+	for _, channelId := range category.Channels {
+		if inCategory[channelId] {
+			sorted = append(sorted, channelId)
+		}
+	}
+
+	category.Channels = sorted
+	return nil
+}
+
+// GetRecentDirectChannels returns up to limit direct and group message channels for the user, or all of them
+// when limit isn't positive, most recently posted in first, with the ids of the other members set as
+// ParticipantIds. If excludeEmpty is true, channels that have never had a post are left out.
+func (a *App) GetRecentDirectChannels(userId string, limit int, excludeEmpty bool) ([]*model.Channel, *model.AppError) {
+	channels, err := a.Srv().Store.Channel().GetRecentDirectChannels(userId, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetRecentDirectChannels", "app.channel.get_recent_direct_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !excludeEmpty {
+		return channels, nil
+	}
+
+	// Channels are ordered by their last post, so the empty ones are always at the end of the list
+	nonEmpty := make([]*model.Channel, 0, len(channels))
+	for _, channel := range channels {
+		if channel.LastPostAt > 0 {
+			nonEmpty = append(nonEmpty, channel)
+		}
+	}
+
+	return nonEmpty, nil
 }
 
 func (a *App) CreateSidebarCategory(userId, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError) {
@@ -2716,3 +3188,14 @@ func (a *App) DeleteSidebarCategory(userId, teamId, categoryId string) *model.Ap
 
 	return nil
 }
+
+// GetChannelMemberHistory returns a page of the memberships of the channel that were joined or left
+// between since and until.
+func (a *App) GetChannelMemberHistory(channelId string, since, until int64, page, perPage int) ([]*model.ChannelMemberHistoryResult, *model.AppError) {
+	histories, err := a.Srv().Store.ChannelMemberHistory().GetForChannel(channelId, since, until, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMemberHistory", "app.channel_member_history.get_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return histories, nil
+}
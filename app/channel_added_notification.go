@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+// isChannelAddedNotificationEnabled returns whether the user wants to be notified when added to a channel,
+// falling back to the server default when they haven't chosen.
+func (a *App) isChannelAddedNotificationEnabled(user *model.User) bool {
+	if value, ok := user.NotifyProps[model.CHANNEL_ADDED_NOTIFY_PROP]; ok && value != "" {
+		return value == "true"
+	}
+
+	return *a.Config().TeamSettings.DefaultChannelAddedNotification
+}
+
+// notifyUserAddedToChannel sends a direct message to the user added to the channel by someone else,
+// with a link to the channel. A nil addedBy means that the user was added by group synchronization.
+func (a *App) notifyUserAddedToChannel(user *model.User, channel *model.Channel, addedBy *model.User) {
+	if user.IsBot || channel.IsGroupOrDirect() || !a.isChannelAddedNotificationEnabled(user) {
+		return
+	}
+
+	if addedBy == nil && *a.Config().TeamSettings.ExcludeGroupSyncFromChannelAddedNotification {
+		return
+	} else if addedBy != nil && addedBy.Id == user.Id {
+		return
+	}
+
+	team, err := a.GetTeam(channel.TeamId)
+	if err != nil {
+		mlog.Error("Failed to get the team of the channel the user was added to", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+	link := a.GetSiteURL() + "/" + team.Name + "/channels/" + channel.Name
+
+	var message string
+	if addedBy == nil {
+		message = T("app.channel.added_notification.group_sync", map[string]interface{}{"ChannelName": channel.DisplayName, "TeamName": team.DisplayName, "Link": link})
+	} else {
+		message = T("app.channel.added_notification.user", map[string]interface{}{"Username": addedBy.Username, "ChannelName": channel.DisplayName, "TeamName": team.DisplayName, "Link": link})
+	}
+
+	if _, err := a.SendSystemBotDM(user.Id, message, nil); err != nil {
+		mlog.Error("Failed to notify the user added to a channel", mlog.String("user_id", user.Id), mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+}
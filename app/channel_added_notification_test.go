@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestNotifyUserAddedToChannel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.DefaultChannelAddedNotification = true
+		*cfg.TeamSettings.ExcludeGroupSyncFromChannelAddedNotification = true
+	})
+
+	botUserId, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+
+	notifications := func(user *model.User) []*model.Post {
+		dm, err := th.App.GetOrCreateDirectChannel(user.Id, botUserId)
+		require.Nil(t, err)
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+
+		var result []*model.Post
+		for _, post := range posts.ToSlice() {
+			if post.UserId == botUserId {
+				result = append(result, post)
+			}
+		}
+		return result
+	}
+
+	t.Run("added by another user", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, th.BasicUser)
+
+		posts := notifications(user)
+		require.Len(t, posts, 1)
+		assert.Contains(t, posts[0].Message, "@"+th.BasicUser.Username)
+		assert.Contains(t, posts[0].Message, th.App.GetSiteURL()+"/"+th.BasicTeam.Name+"/channels/"+th.BasicChannel.Name)
+	})
+
+	t.Run("self join", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, user)
+
+		assert.Empty(t, notifications(user))
+	})
+
+	t.Run("user opted out", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		user.NotifyProps[model.CHANNEL_ADDED_NOTIFY_PROP] = "false"
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, th.BasicUser)
+
+		assert.Empty(t, notifications(user))
+	})
+
+	t.Run("user opted in despite the server default", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.DefaultChannelAddedNotification = false
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.DefaultChannelAddedNotification = true
+		})
+
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		user.NotifyProps[model.CHANNEL_ADDED_NOTIFY_PROP] = "true"
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, th.BasicUser)
+
+		assert.Len(t, notifications(user), 1)
+	})
+
+	t.Run("group sync", func(t *testing.T) {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, nil)
+		assert.Empty(t, notifications(user))
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.ExcludeGroupSyncFromChannelAddedNotification = false
+		})
+
+		th.App.notifyUserAddedToChannel(user, th.BasicChannel, nil)
+		assert.Len(t, notifications(user), 1)
+	})
+}
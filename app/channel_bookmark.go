@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// GetChannelBookmarks returns the active bookmarks of the given channel in their sort order.
+func (a *App) GetChannelBookmarks(channelId string) ([]*model.ChannelBookmark, *model.AppError) {
+	bookmarks, err := a.Srv().Store.ChannelBookmark().GetBookmarksForChannel(channelId)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarks", "app.channel_bookmark.get_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return bookmarks, nil
+}
+
+// GetChannelBookmark returns the given bookmark.
+func (a *App) GetChannelBookmark(bookmarkId string, includeDeleted bool) (*model.ChannelBookmark, *model.AppError) {
+	bookmark, err := a.Srv().Store.ChannelBookmark().Get(bookmarkId, includeDeleted)
+	if err != nil {
+		return nil, channelBookmarkAppError("GetChannelBookmark", "app.channel_bookmark.get.app_error", err)
+	}
+
+	return bookmark, nil
+}
+
+// CreateChannelBookmark saves a new bookmark at the end of the channel's bookmarks.
+func (a *App) CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError) {
+	if err := a.validateChannelBookmark(bookmark, bookmark.OwnerId); err != nil {
+		return nil, err
+	}
+
+	saved, err := a.Srv().Store.ChannelBookmark().Save(bookmark)
+	if err != nil {
+		return nil, channelBookmarkAppError("CreateChannelBookmark", "app.channel_bookmark.save.app_error", err)
+	}
+
+	a.publishChannelBookmarkEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_CREATED, saved)
+
+	return saved, nil
+}
+
+// PatchChannelBookmark applies the given patch to an existing bookmark on behalf of the given user,
+// who must have uploaded any new file of the bookmark.
+func (a *App) PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch, userId string) (*model.ChannelBookmark, *model.AppError) {
+	fileId := bookmark.FileId
+	bookmark.Patch(patch)
+
+	return a.updateChannelBookmark("PatchChannelBookmark", bookmark, fileId, userId)
+}
+
+// UpdateChannelBookmark replaces the content of an existing bookmark with the one of the given
+// bookmark on behalf of the given user, keeping its channel, owner and position. The user must have
+// uploaded any new file of the bookmark.
+func (a *App) UpdateChannelBookmark(bookmark, update *model.ChannelBookmark, userId string) (*model.ChannelBookmark, *model.AppError) {
+	fileId := bookmark.FileId
+	bookmark.Type = update.Type
+	bookmark.DisplayName = update.DisplayName
+	bookmark.LinkUrl = update.LinkUrl
+	bookmark.FileId = update.FileId
+	bookmark.Emoji = update.Emoji
+
+	return a.updateChannelBookmark("UpdateChannelBookmark", bookmark, fileId, userId)
+}
+
+func (a *App) updateChannelBookmark(where string, bookmark *model.ChannelBookmark, previousFileId, userId string) (*model.ChannelBookmark, *model.AppError) {
+	// An unchanged file was checked when it was bookmarked.
+	uploaderId := ""
+	if bookmark.FileId != previousFileId {
+		uploaderId = userId
+	}
+
+	if err := a.validateChannelBookmark(bookmark, uploaderId); err != nil {
+		return nil, err
+	}
+
+	updated, err := a.Srv().Store.ChannelBookmark().Update(bookmark)
+	if err != nil {
+		return nil, channelBookmarkAppError(where, "app.channel_bookmark.update.app_error", err)
+	}
+
+	a.publishChannelBookmarkEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_UPDATED, updated)
+
+	return updated, nil
+}
+
+// DeleteChannelBookmark soft deletes the given bookmark.
+func (a *App) DeleteChannelBookmark(bookmark *model.ChannelBookmark) *model.AppError {
+	if err := a.Srv().Store.ChannelBookmark().Delete(bookmark.Id); err != nil {
+		return channelBookmarkAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.app_error", err)
+	}
+
+	a.publishChannelBookmarkEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_DELETED, bookmark)
+
+	return nil
+}
+
+// UpdateChannelBookmarkSortOrder reorders the channel's bookmarks to match the given list of ids,
+// which must contain every bookmark of the channel exactly once.
+func (a *App) UpdateChannelBookmarkSortOrder(channelId string, bookmarkIds []string) ([]*model.ChannelBookmark, *model.AppError) {
+	if err := a.Srv().Store.ChannelBookmark().UpdateSortOrder(channelId, bookmarkIds); err != nil {
+		return nil, channelBookmarkAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update_sort_order.app_error", err)
+	}
+
+	bookmarks, err := a.GetChannelBookmarks(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_BOOKMARK_SORTED, "", channelId, "", nil)
+	message.Add("bookmarks", model.ChannelBookmarksToJson(bookmarks))
+	a.Publish(message)
+
+	return bookmarks, nil
+}
+
+// validateChannelBookmark checks that the bookmark's channel can take bookmarks and that a file
+// bookmark refers to an existing file. Unless uploaderId is empty, the file must also be unattached
+// and uploaded by that user.
+func (a *App) validateChannelBookmark(bookmark *model.ChannelBookmark, uploaderId string) *model.AppError {
+	channel, err := a.GetChannel(bookmark.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.DeleteAt != 0 {
+		return model.NewAppError("validateChannelBookmark", "app.channel_bookmark.channel_archived.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	if bookmark.Type != model.CHANNEL_BOOKMARK_TYPE_FILE {
+		return nil
+	}
+
+	fileInfo, err := a.GetFileInfo(bookmark.FileId)
+	if err != nil {
+		return model.NewAppError("validateChannelBookmark", "app.channel_bookmark.invalid_file.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if uploaderId != "" && (fileInfo.PostId != "" || fileInfo.CreatorId != uploaderId) {
+		return model.NewAppError("validateChannelBookmark", "app.channel_bookmark.invalid_file.app_error", nil, "file_id="+fileInfo.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) publishChannelBookmarkEvent(event string, bookmark *model.ChannelBookmark) {
+	message := model.NewWebSocketEvent(event, "", bookmark.ChannelId, "", nil)
+	message.Add("bookmark", bookmark.ToJson())
+	a.Publish(message)
+}
+
+func channelBookmarkAppError(where, id string, err error) *model.AppError {
+	var nfErr *store.ErrNotFound
+	var invErr *store.ErrInvalidInput
+	var appErr *model.AppError
+	switch {
+	case errors.As(err, &nfErr):
+		return model.NewAppError(where, "app.channel_bookmark.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+	case errors.As(err, &invErr):
+		return model.NewAppError(where, id, nil, invErr.Error(), http.StatusBadRequest)
+	case errors.As(err, &appErr): // in case we haven't converted to plain error.
+		return appErr
+	default:
+		return model.NewAppError(where, id, nil, err.Error(), http.StatusInternalServerError)
+	}
+}
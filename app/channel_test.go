@@ -382,6 +382,80 @@ func TestCreateChannelDisplayNameTrimsWhitespace(t *testing.T) {
 	require.Equal(t, channel.DisplayName, "Public 1")
 }
 
+func TestCreateChannelDailyLimit(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxChannelsCreatedPerUserPerDay = 2 })
+
+	newChannel := func(creatorId string) *model.Channel {
+		return &model.Channel{DisplayName: "Channel", Name: "name-" + model.NewId(), Type: model.CHANNEL_OPEN, TeamId: th.BasicTeam.Id, CreatorId: creatorId}
+	}
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	for i := 0; i < 2; i++ {
+		_, err := th.App.CreateChannelWithUser(newChannel(""), user.Id)
+		require.Nil(t, err)
+	}
+
+	_, err := th.App.CreateChannelWithUser(newChannel(""), user.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.channel.create_channel.user_daily_limit.app_error", err.Id)
+	assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+
+	t.Run("failed creations aren't counted", func(t *testing.T) {
+		other := th.CreateUser()
+
+		channel, err := th.App.CreateChannel(newChannel(other.Id), false)
+		require.Nil(t, err)
+
+		duplicate := newChannel(other.Id)
+		duplicate.Name = channel.Name
+		_, err = th.App.CreateChannel(duplicate, false)
+		require.NotNil(t, err)
+
+		_, err = th.App.CreateChannel(newChannel(other.Id), false)
+		require.Nil(t, err)
+	})
+
+	t.Run("the throttle can be bypassed", func(t *testing.T) {
+		_, err := th.App.CreateChannelBypassingThrottle(newChannel(user.Id), false)
+		require.Nil(t, err)
+	})
+
+	t.Run("system admins aren't throttled", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := th.App.CreateChannelWithUser(newChannel(""), th.SystemAdminUser.Id)
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("channels created in other teams are counted", func(t *testing.T) {
+		other := th.CreateUser()
+		team := th.CreateTeam()
+
+		for i := 0; i < 2; i++ {
+			channel := newChannel(other.Id)
+			channel.TeamId = team.Id
+			_, err := th.App.CreateChannel(channel, false)
+			require.Nil(t, err)
+		}
+
+		_, err := th.App.CreateChannel(newChannel(other.Id), false)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.channel.create_channel.user_daily_limit.app_error", err.Id)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxChannelsCreatedPerUserPerDay = 0 })
+
+		_, err := th.App.CreateChannelWithUser(newChannel(""), user.Id)
+		require.Nil(t, err)
+	})
+}
+
 func TestUpdateChannelPrivacy(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -485,7 +559,7 @@ func TestAddUserToChannelCreatesChannelMemberHistoryRecord(t *testing.T) {
 
 	// create a user and add it to a channel
 	user := th.CreateUser()
-	_, err := th.App.AddTeamMember(th.BasicTeam.Id, user.Id)
+	_, err := th.App.AddTeamMember(th.BasicTeam.Id, user.Id, "")
 	require.Nil(t, err, "Failed to add user to team.")
 
 	groupUserIds := make([]string, 0)
@@ -509,6 +583,48 @@ func TestAddUserToChannelCreatesChannelMemberHistoryRecord(t *testing.T) {
 	assert.Equal(t, groupUserIds, channelMemberHistoryUserIds)
 }
 
+func TestSuppressJoinLeaveMessages(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	_, err := th.App.AddTeamMember(th.BasicTeam.Id, user.Id, "")
+	require.Nil(t, err)
+
+	channel := th.createChannel(th.BasicTeam, model.CHANNEL_OPEN)
+	channel, err = th.App.PatchChannel(channel, &model.ChannelPatch{SuppressJoinLeaveMessages: model.NewBool(true)}, th.SystemAdminUser.Id)
+	require.Nil(t, err)
+	require.True(t, channel.IsJoinLeaveMessagesSuppressed())
+
+	postCount := func() int {
+		postList, err := th.App.Srv().Store.Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 100}, false)
+		require.Nil(t, err)
+		return len(postList.Order)
+	}
+	initialCount := postCount()
+
+	_, err = th.App.AddChannelMember(user.Id, channel, th.BasicUser.Id, "")
+	require.Nil(t, err)
+
+	_, err = th.App.GetChannelMember(channel.Id, user.Id)
+	require.Nil(t, err, "user should still be added to the channel")
+
+	histories, nErr := th.App.Srv().Store.ChannelMemberHistory().GetUsersInChannelDuring(model.GetMillis()-100, model.GetMillis()+100, channel.Id)
+	require.Nil(t, nErr)
+	assert.Len(t, histories, 2, "membership history should still be recorded")
+
+	err = th.App.RemoveUserFromChannel(user.Id, th.BasicUser.Id, channel)
+	require.Nil(t, err)
+
+	err = th.App.JoinChannel(channel, user.Id)
+	require.Nil(t, err)
+
+	err = th.App.LeaveChannel(channel.Id, user.Id)
+	require.Nil(t, err)
+
+	assert.Equal(t, initialCount, postCount(), "no join or leave messages should have been posted")
+}
+
 func TestLeaveDefaultChannel(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -572,7 +688,7 @@ func TestAddChannelMemberNoUserRequestor(t *testing.T) {
 
 	// create a user and add it to a channel
 	user := th.CreateUser()
-	_, err := th.App.AddTeamMember(th.BasicTeam.Id, user.Id)
+	_, err := th.App.AddTeamMember(th.BasicTeam.Id, user.Id, "")
 	require.Nil(t, err)
 
 	groupUserIds := make([]string, 0)
@@ -608,6 +724,32 @@ func TestAddChannelMemberNoUserRequestor(t *testing.T) {
 	}
 }
 
+func TestChannelMembershipPostsIncludeActor(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.createChannel(th.BasicTeam, model.CHANNEL_OPEN)
+
+	lastPost := func() *model.Post {
+		postList, err := th.App.Srv().Store.Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1}, false)
+		require.Nil(t, err)
+		require.Len(t, postList.Order, 1)
+		return postList.Posts[postList.Order[0]]
+	}
+
+	err := th.App.PostAddToChannelMessage(th.BasicUser, th.BasicUser2, channel, "")
+	require.Nil(t, err)
+	post := lastPost()
+	assert.Equal(t, model.POST_ADD_TO_CHANNEL, post.Type)
+	assert.Equal(t, th.BasicUser.Id, post.GetProp(model.POST_PROPS_ACTOR_ID))
+
+	err = th.App.postRemoveFromChannelMessage(th.BasicUser.Id, th.BasicUser2, channel)
+	require.Nil(t, err)
+	post = lastPost()
+	assert.Equal(t, model.POST_REMOVE_FROM_CHANNEL, post.Type)
+	assert.Equal(t, th.BasicUser.Id, post.GetProp(model.POST_PROPS_ACTOR_ID))
+}
+
 func TestAppUpdateChannelScheme(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -996,9 +1138,9 @@ func TestGetPublicChannelsForTeam(t *testing.T) {
 	}
 
 	// Fetch public channels multipile times
-	channelList, err := th.App.GetPublicChannelsForTeam(team.Id, 0, 5)
+	channelList, err := th.App.GetPublicChannelsForTeam(team.Id, 0, 5, false)
 	require.Nil(t, err)
-	channelList2, err := th.App.GetPublicChannelsForTeam(team.Id, 5, 5)
+	channelList2, err := th.App.GetPublicChannelsForTeam(team.Id, 5, 5, false)
 	require.Nil(t, err)
 
 	channels := append(*channelList, *channelList2...)
@@ -1338,8 +1480,8 @@ func TestAddUserToChannel(t *testing.T) {
 	botUser, _ := th.App.GetUser(bot.UserId)
 	defer th.App.PermanentDeleteBot(botUser.Id)
 
-	th.App.AddTeamMember(th.BasicTeam.Id, ruser1.Id)
-	th.App.AddTeamMember(th.BasicTeam.Id, bot.UserId)
+	th.App.AddTeamMember(th.BasicTeam.Id, ruser1.Id, "")
+	th.App.AddTeamMember(th.BasicTeam.Id, bot.UserId, "")
 
 	group := th.CreateGroup()
 
@@ -1366,7 +1508,7 @@ func TestAddUserToChannel(t *testing.T) {
 	user2 := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
 	ruser2, _ := th.App.CreateUser(&user2)
 	defer th.App.PermanentDeleteUser(&user2)
-	th.App.AddTeamMember(th.BasicTeam.Id, ruser2.Id)
+	th.App.AddTeamMember(th.BasicTeam.Id, ruser2.Id, "")
 
 	_, err = th.App.UpsertGroupMember(group.Id, user2.Id)
 	require.Nil(t, err)
@@ -1420,8 +1562,8 @@ func TestRemoveUserFromChannel(t *testing.T) {
 	botUser, _ := th.App.GetUser(bot.UserId)
 	defer th.App.PermanentDeleteBot(botUser.Id)
 
-	th.App.AddTeamMember(th.BasicTeam.Id, ruser.Id)
-	th.App.AddTeamMember(th.BasicTeam.Id, bot.UserId)
+	th.App.AddTeamMember(th.BasicTeam.Id, ruser.Id, "")
+	th.App.AddTeamMember(th.BasicTeam.Id, bot.UserId, "")
 
 	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
 
@@ -1977,4 +2119,127 @@ func TestGetSidebarCategories(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Len(t, categories.Categories, 3)
 	})
+
+	t.Run("should order direct messages by recency", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		emptyChannel := th.CreateDmChannel(th.BasicUser2)
+		recentChannel := th.CreateDmChannel(th.CreateUser())
+		th.CreatePost(recentChannel)
+
+		categories, err := th.App.GetSidebarCategories(th.BasicUser.Id, th.BasicTeam.Id)
+		require.Nil(t, err)
+
+		var dmCategory *model.SidebarCategoryWithChannels
+		for _, category := range categories.Categories {
+			if category.Type == model.SidebarCategoryDirectMessages {
+				dmCategory = category
+			}
+		}
+		require.NotNil(t, dmCategory)
+		assert.Equal(t, []string{recentChannel.Id, emptyChannel.Id}, dmCategory.Channels)
+
+		category, err := th.App.GetSidebarCategory(dmCategory.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{recentChannel.Id, emptyChannel.Id}, category.Channels)
+	})
+}
+
+func TestGetRecentDirectChannels(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	otherUser := th.CreateUser()
+	emptyChannel := th.CreateDmChannel(th.BasicUser2)
+	recentChannel := th.CreateDmChannel(otherUser)
+	th.CreatePost(recentChannel)
+
+	channels, err := th.App.GetRecentDirectChannels(th.BasicUser.Id, 10, false)
+	require.Nil(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, recentChannel.Id, channels[0].Id)
+	assert.Equal(t, []string{otherUser.Id}, channels[0].ParticipantIds)
+	assert.Equal(t, emptyChannel.Id, channels[1].Id)
+
+	channels, err = th.App.GetRecentDirectChannels(th.BasicUser.Id, 10, true)
+	require.Nil(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, recentChannel.Id, channels[0].Id)
+}
+
+func TestArchiveChannels(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	publicChannel := th.CreateChannel(th.BasicTeam)
+	privateChannel, err := th.App.CreateChannel(&model.Channel{
+		TeamId:      th.BasicTeam.Id,
+		Name:        "private" + model.NewId(),
+		DisplayName: "Private",
+		Type:        model.CHANNEL_PRIVATE,
+	}, false)
+	require.Nil(t, err)
+	townSquare, err := th.App.GetChannelByName(model.DEFAULT_CHANNEL, th.BasicTeam.Id, false)
+	require.Nil(t, err)
+
+	t.Run("should require channels", func(t *testing.T) {
+		_, err := th.App.ArchiveChannels(nil)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("should check the permissions for each channel", func(t *testing.T) {
+		th.App.SetSession(&model.Session{UserId: th.BasicUser.Id, Roles: th.BasicUser.GetRawRoles()})
+
+		results, err := th.App.ArchiveChannels([]string{privateChannel.Id})
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Archived)
+		require.NotNil(t, results[0].Error)
+		assert.Equal(t, http.StatusForbidden, results[0].Error.StatusCode)
+
+		channel, err := th.App.GetChannel(privateChannel.Id)
+		require.Nil(t, err)
+		assert.Zero(t, channel.DeleteAt)
+	})
+
+	t.Run("should archive the channels and report the skipped ones", func(t *testing.T) {
+		th.App.SetSession(&model.Session{UserId: th.SystemAdminUser.Id, Roles: model.SYSTEM_ADMIN_ROLE_ID + " " + model.SYSTEM_USER_ROLE_ID})
+
+		missingId := model.NewId()
+		results, err := th.App.ArchiveChannels([]string{publicChannel.Id, townSquare.Id, privateChannel.Id, missingId})
+		require.Nil(t, err)
+		require.Len(t, results, 4)
+
+		assert.Equal(t, publicChannel.Id, results[0].ChannelId)
+		assert.True(t, results[0].Archived)
+		assert.Nil(t, results[0].Error)
+
+		assert.Equal(t, townSquare.Id, results[1].ChannelId)
+		assert.False(t, results[1].Archived)
+		require.NotNil(t, results[1].Error)
+		assert.Equal(t, "app.channel.archive_channels.default_channel.app_error", results[1].Error.Id)
+
+		assert.True(t, results[2].Archived)
+
+		assert.Equal(t, missingId, results[3].ChannelId)
+		assert.False(t, results[3].Archived)
+		assert.NotNil(t, results[3].Error)
+
+		for _, channelId := range []string{publicChannel.Id, privateChannel.Id} {
+			channel, err := th.App.GetChannel(channelId)
+			require.Nil(t, err)
+			assert.NotZero(t, channel.DeleteAt)
+
+			posts, err := th.App.GetPosts(channelId, 0, 1)
+			require.Nil(t, err)
+			require.Len(t, posts.Order, 1)
+			assert.Equal(t, model.POST_CHANNEL_DELETED, posts.Posts[posts.Order[0]].Type)
+		}
+
+		channel, err := th.App.GetChannel(townSquare.Id)
+		require.Nil(t, err)
+		assert.Zero(t, channel.DeleteAt)
+	})
 }
@@ -6,6 +6,7 @@ package app
 import (
 	"strings"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 )
 
@@ -27,6 +28,7 @@ func (a *App) registerAllClusterMessageHandlers() {
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_INSTALL_PLUGIN, a.clusterInstallPluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_REMOVE_PLUGIN, a.clusterRemovePluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_BUSY_STATE_CHANGED, a.clusterBusyStateChgHandler)
+	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_FEATURE_FLAG_OVERRIDES_CHANGED, a.clusterFeatureFlagOverridesChangedHandler)
 }
 
 func (a *App) clusterPublishHandler(msg *model.ClusterMessage) {
@@ -81,3 +83,15 @@ func (a *App) clusterRemovePluginHandler(msg *model.ClusterMessage) {
 func (a *App) clusterBusyStateChgHandler(msg *model.ClusterMessage) {
 	a.ServerBusyStateChanged(model.ServerBusyStateFromJson(strings.NewReader(msg.Data)))
 }
+
+func (a *App) clusterFeatureFlagOverridesChangedHandler(msg *model.ClusterMessage) {
+	// The override is carried in the message, since a read replica may not have the change yet.
+	var override *model.FeatureFlagOverride
+	if msg.Data != "" {
+		if override = model.FeatureFlagOverrideFromJson(strings.NewReader(msg.Data)); override == nil {
+			mlog.Error("Failed to decode the feature flag override", mlog.String("name", msg.Props["name"]))
+			return
+		}
+	}
+	a.Srv().setFeatureFlagOverride(msg.Props["name"], override)
+}
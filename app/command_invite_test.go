@@ -180,8 +180,8 @@ func TestInviteGroup(t *testing.T) {
 
 	th.BasicTeam.GroupConstrained = model.NewBool(true)
 	var err *model.AppError
-	_, _ = th.App.AddTeamMember(th.BasicTeam.Id, th.BasicUser.Id)
-	_, err = th.App.AddTeamMember(th.BasicTeam.Id, th.BasicUser2.Id)
+	_, _ = th.App.AddTeamMember(th.BasicTeam.Id, th.BasicUser.Id, "")
+	_, err = th.App.AddTeamMember(th.BasicTeam.Id, th.BasicUser2.Id, "")
 	require.Nil(t, err)
 	th.BasicTeam, _ = th.App.UpdateTeam(th.BasicTeam)
 
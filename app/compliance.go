@@ -8,6 +8,7 @@ import (
 
 	"net/http"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 )
 
@@ -53,3 +54,25 @@ func (a *App) GetComplianceFile(job *model.Compliance) ([]byte, *model.AppError)
 	}
 	return f, nil
 }
+
+// streamAuditsToSyslog points the audit store at the syslog server of the compliance settings, or stops streaming
+// when none is configured.
+func (s *Server) streamAuditsToSyslog(cfg *model.Config) {
+	protocol := *cfg.ComplianceSettings.SyslogProtocol
+	var caCert []byte
+	if *cfg.ComplianceSettings.SyslogEnableTLS {
+		protocol = model.COMPLIANCE_SYSLOG_PROTOCOL_TCP_TLS
+
+		if *cfg.ComplianceSettings.SyslogTLSCACertFile != "" {
+			var err error
+			if caCert, err = ioutil.ReadFile(*cfg.ComplianceSettings.SyslogTLSCACertFile); err != nil {
+				mlog.Error("Failed to read the certificate authorities of the audit syslog server", mlog.String("path", *cfg.ComplianceSettings.SyslogTLSCACertFile), mlog.Err(err))
+				return
+			}
+		}
+	}
+
+	if err := s.Store.Audit().StreamToSyslog(*cfg.ComplianceSettings.SyslogAddress, protocol, caCert); err != nil {
+		mlog.Error("Failed to stream audit records to syslog", mlog.String("address", *cfg.ComplianceSettings.SyslogAddress), mlog.Err(err))
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,12 @@ import (
 
 const (
 	ERROR_TERMS_OF_SERVICE_NO_ROWS_FOUND = "app.terms_of_service.get.no_rows.app_error"
+
+	SITE_URL_PING_TIMEOUT = 5 * time.Second
+
+	// Saving a shorter web session length warns of the active sessions that would be past the new
+	// length within this period.
+	SESSION_LENGTH_WARNING_PERIOD = 60 * 60 * 1000
 )
 
 func (s *Server) Config() *model.Config {
@@ -83,6 +90,10 @@ func (a *App) LimitedClientConfig() map[string]string {
 	return a.Srv().limitedClientConfig.Load().(map[string]string)
 }
 
+func (a *App) AdminClientConfig() map[string]string {
+	return a.Srv().adminClientConfig.Load().(map[string]string)
+}
+
 // Registers a function with a given listener to be called when the config is reloaded and may have changed. The function
 // will be called with two arguments: the old config and the new config. AddConfigListener returns a unique ID
 // for the listener that can later be used to remove it.
@@ -299,8 +310,9 @@ func (a *App) PostActionCookieSecret() []byte {
 }
 
 func (s *Server) regenerateClientConfig() {
-	clientConfig := config.GenerateClientConfig(s.Config(), s.diagnosticId, s.License())
-	limitedClientConfig := config.GenerateLimitedClientConfig(s.Config(), s.diagnosticId, s.License())
+	clientConfig := config.GenerateClientConfig(s.Config(), s.diagnosticId, s.License(), config.ClientConfigAudienceUser)
+	limitedClientConfig := config.GenerateClientConfig(s.Config(), s.diagnosticId, s.License(), config.ClientConfigAudienceAnonymous)
+	adminClientConfig := config.GenerateClientConfig(s.Config(), s.diagnosticId, s.License(), config.ClientConfigAudienceAdmin)
 
 	if clientConfig["EnableCustomTermsOfService"] == "true" {
 		termsOfService, err := s.Store.TermsOfService().GetLatest(true)
@@ -309,6 +321,7 @@ func (s *Server) regenerateClientConfig() {
 		} else {
 			clientConfig["CustomTermsOfServiceId"] = termsOfService.Id
 			limitedClientConfig["CustomTermsOfServiceId"] = termsOfService.Id
+			adminClientConfig["CustomTermsOfServiceId"] = termsOfService.Id
 		}
 	}
 
@@ -316,11 +329,13 @@ func (s *Server) regenerateClientConfig() {
 		der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
 		clientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
 		limitedClientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
+		adminClientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
 	}
 
 	clientConfigJSON, _ := json.Marshal(clientConfig)
 	s.clientConfig.Store(clientConfig)
 	s.limitedClientConfig.Store(limitedClientConfig)
+	s.adminClientConfig.Store(adminClientConfig)
 	s.clientConfigHash.Store(fmt.Sprintf("%x", md5.Sum(clientConfigJSON)))
 }
 
@@ -339,8 +354,12 @@ func (a *App) GetSiteURL() string {
 
 // ClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
 func (s *Server) ClientConfigWithComputed() map[string]string {
+	return s.clientConfigWithComputed(s.clientConfig.Load().(map[string]string))
+}
+
+func (s *Server) clientConfigWithComputed(clientConfig map[string]string) map[string]string {
 	respCfg := map[string]string{}
-	for k, v := range s.clientConfig.Load().(map[string]string) {
+	for k, v := range clientConfig {
 		respCfg[k] = v
 	}
 
@@ -375,6 +394,24 @@ func (a *App) LimitedClientConfigWithComputed() map[string]string {
 	return respCfg
 }
 
+// AdminClientConfigWithComputed gets the configuration in a format suitable for sending to a
+// system admin's client, which includes details about the deployment other users don't receive.
+func (a *App) AdminClientConfigWithComputed() map[string]string {
+	return a.Srv().clientConfigWithComputed(a.AdminClientConfig())
+}
+
+// DeprecatedClientConfig gets the deprecated fields of the configuration, logging that they were
+// sent so the clients still relying on them can be tracked down.
+func (a *App) DeprecatedClientConfig(audience config.ClientConfigAudience, userAgent string) map[string]string {
+	respCfg := config.GenerateDeprecatedClientConfig(a.Config(), a.DiagnosticId(), a.Srv().License(), audience)
+
+	for name := range respCfg {
+		mlog.Debug("Deprecated client config field sent", mlog.String("field", name), mlog.String("replacement", config.DeprecatedClientConfigFields[name]), mlog.String("user_agent", userAgent))
+	}
+
+	return respCfg
+}
+
 // GetConfigFile proxies access to the given configuration file to the underlying config store.
 func (a *App) GetConfigFile(name string) ([]byte, error) {
 	data, err := a.Srv().configStore.GetFile(name)
@@ -398,13 +435,45 @@ func (a *App) GetEnvironmentConfig() map[string]interface{} {
 	return a.EnvironmentConfig()
 }
 
-// SaveConfig replaces the active configuration, optionally notifying cluster peers.
+// SaveConfig replaces the active configuration, optionally notifying cluster peers. When the site
+// URL changes, it is checked to be reachable in the background, logging a warning if it isn't.
 func (s *Server) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError {
-	oldCfg, err := s.configStore.Set(newCfg)
+	_, err := s.saveConfig(newCfg, sendConfigChangeClusterMessage, "")
+	return err
+}
+
+// saveConfig replaces the active configuration, optionally notifying cluster peers. When the site
+// URL changes, it returns a channel receiving a warning if the new site URL isn't reachable, and
+// closed once the check is done. The given user, if any, is recorded by stores keeping history.
+func (s *Server) saveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool, userId string) (<-chan string, *model.AppError) {
+	oldSiteURL := *s.Config().ServiceSettings.SiteURL
+
+	if appErr := checkTeamDefaultClientLocalesSupported(newCfg); appErr != nil {
+		return nil, appErr
+	}
+
+	var oldCfg *model.Config
+	var err error
+	if historyStore, ok := s.configStore.(config.HistoryStore); ok {
+		oldCfg, err = historyStore.SetAs(newCfg, userId)
+	} else {
+		oldCfg, err = s.configStore.Set(newCfg)
+	}
 	if errors.Cause(err) == config.ErrReadOnlyConfiguration {
-		return model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, err.Error(), http.StatusForbidden)
+		return nil, model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, err.Error(), http.StatusForbidden)
 	} else if err != nil {
-		return model.NewAppError("saveConfig", "app.save_config.app_error", nil, err.Error(), http.StatusInternalServerError)
+		s.Go(func() {
+			message := utils.T("app.admin_notification.config_save_failed", map[string]interface{}{"Error": err.Error()})
+			if _, appErr := New(ServerConnector(s)).NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_CONFIG_SAVE_FAILED, message); appErr != nil {
+				mlog.Error("Failed to notify admins of a configuration save failure", mlog.Err(appErr))
+			}
+		})
+		return nil, model.NewAppError("saveConfig", "app.save_config.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var siteURLWarning <-chan string
+	if siteURL := *s.Config().ServiceSettings.SiteURL; siteURL != "" && siteURL != oldSiteURL {
+		siteURLWarning = s.checkSiteURLReachable(siteURL)
 	}
 
 	if s.Metrics != nil {
@@ -420,16 +489,145 @@ func (s *Server) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage
 		oldCfg = s.configStore.RemoveEnvironmentOverrides(oldCfg)
 		err := s.Cluster.ConfigChanged(oldCfg, newCfg, sendConfigChangeClusterMessage)
 		if err != nil {
-			return err
+			return siteURLWarning, err
+		}
+	}
+
+	return siteURLWarning, nil
+}
+
+// checkTeamDefaultClientLocalesSupported returns an error if a team of the given configuration
+// defaults to a locale the server has no translations for.
+func checkTeamDefaultClientLocalesSupported(cfg *model.Config) *model.AppError {
+	locales := utils.GetSupportedLocales()
+	for teamId, locale := range cfg.LocalizationSettings.TeamDefaultClientLocales {
+		if _, ok := locales[locale]; !ok {
+			return model.NewAppError("saveConfig", "app.save_config.team_default_client_locale.app_error", map[string]interface{}{"Locale": locale}, "team_id="+teamId, http.StatusBadRequest)
 		}
 	}
 
 	return nil
 }
 
+// checkSiteURLReachable pings the server through the given site URL in the background. A warning is
+// logged and sent on the returned channel if the ping fails, and the channel is closed once done.
+func (s *Server) checkSiteURLReachable(siteURL string) <-chan string {
+	warning := make(chan string, 1)
+	s.Go(func() {
+		defer close(warning)
+
+		if err := s.pingSiteURL(siteURL); err != nil {
+			mlog.Warn("The site URL is not reachable from the server. Links in emails and push notifications may be broken.", mlog.String("site_url", siteURL), mlog.Err(err))
+			warning <- fmt.Sprintf("The site URL %s is not reachable from the server: %s", siteURL, err.Error())
+		}
+	})
+	return warning
+}
+
+func (s *Server) pingSiteURL(siteURL string) error {
+	client := s.HTTPService.MakeClient(true)
+	client.Timeout = SITE_URL_PING_TIMEOUT
+
+	resp, err := client.Head(strings.TrimRight(siteURL, "/") + model.API_URL_SUFFIX + "/system/ping")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError {
-	return a.Srv().SaveConfig(newCfg, sendConfigChangeClusterMessage)
+	_, err := a.Srv().saveConfig(newCfg, sendConfigChangeClusterMessage, a.Session().UserId)
+	return err
+}
+
+// SaveConfigWithWarnings replaces the active configuration like SaveConfig, then waits for the
+// checks made on the new configuration and returns the warnings they raised.
+func (a *App) SaveConfigWithWarnings(newCfg *model.Config, sendConfigChangeClusterMessage bool) ([]string, *model.AppError) {
+	oldSessionLengthWebInDays := *a.Config().ServiceSettings.SessionLengthWebInDays
+
+	siteURLWarning, err := a.Srv().saveConfig(newCfg, sendConfigChangeClusterMessage, a.Session().UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	if siteURLWarning != nil {
+		if warning, ok := <-siteURLWarning; ok {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	if sessionLength := *a.Config().ServiceSettings.SessionLengthWebInDays; sessionLength < oldSessionLengthWebInDays {
+		if warning := a.checkSessionsOutlivingLength(sessionLength); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+// GetConfigHistory returns a page of the configurations saved, most recent first. Only the database
+// configuration store keeps history.
+func (a *App) GetConfigHistory(page, perPage int) ([]*model.ConfigHistoryEntry, *model.AppError) {
+	historyStore, ok := a.Srv().configStore.(config.HistoryStore)
+	if !ok {
+		return nil, model.NewAppError("GetConfigHistory", "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	entries, err := historyStore.GetHistory(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetConfigHistory", "app.config.get_history.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return entries, nil
+}
+
+// GetHistoricalConfig returns a previously saved configuration, with the secrets of the active
+// configuration.
+func (a *App) GetHistoricalConfig(id string) (*model.Config, *model.AppError) {
+	historyStore, ok := a.Srv().configStore.(config.HistoryStore)
+	if !ok {
+		return nil, model.NewAppError("GetHistoricalConfig", "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	cfg, err := historyStore.GetHistoricalConfig(id)
+	if errors.Cause(err) == config.ErrConfigurationNotFound {
+		return nil, model.NewAppError("GetHistoricalConfig", "app.config.get_historical_config.not_found.app_error", nil, "id="+id, http.StatusNotFound)
+	} else if err != nil {
+		return nil, model.NewAppError("GetHistoricalConfig", "app.config.get_historical_config.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	cfg.SetDefaults()
+
+	return cfg, nil
+}
+
+// checkSessionsOutlivingLength returns a warning with the count of active web sessions that are, or
+// within SESSION_LENGTH_WARNING_PERIOD will be, older than the given web session length. Sessions
+// keep the expiry they were given, so the new length only reaches them when their expiry is next
+// extended.
+func (a *App) checkSessionsOutlivingLength(sessionLengthWebInDays int) string {
+	sessionLength := int64(sessionLengthWebInDays) * dayInMilliseconds
+	createdBefore := model.GetMillis() + SESSION_LENGTH_WARNING_PERIOD - sessionLength
+
+	count, err := a.Srv().Store.Session().CountActiveWebSessionsCreatedBefore(createdBefore)
+	if err != nil {
+		mlog.Warn("Failed to count the sessions affected by the new session length", mlog.Err(err))
+		return ""
+	}
+	if count == 0 {
+		return ""
+	}
+
+	mlog.Warn("The new web session length is shorter than the age of active sessions. They keep their current expiry until it is next extended.", mlog.Int("session_length_web_in_days", sessionLengthWebInDays), mlog.Int64("sessions", count))
+	return fmt.Sprintf("%d active web sessions are older than the new web session length of %d days, or will be within the hour. They keep their current expiry: the new length applies to new sessions, and to existing ones when their expiry is next extended. Revoke the sessions to end them now.", count, sessionLengthWebInDays)
 }
 
 func (a *App) HandleMessageExportConfig(cfg *model.Config, appCfg *model.Config) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
@@ -159,3 +160,38 @@ func TestEnsureInstallationDate(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveConfigWithWarningsSessionLength(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SessionLengthWebInDays = 30 })
+
+	session, err := th.App.CreateSession(&model.Session{UserId: th.BasicUser.Id, ExpiresAt: model.GetMillis() + 30*dayInMilliseconds})
+	require.Nil(t, err)
+
+	createAt := model.GetMillis() - 2*dayInMilliseconds
+	_, sqlErr := th.GetSqlSupplier().GetMaster().Exec("UPDATE Sessions SET CreateAt = :CreateAt WHERE Id = :Id", map[string]interface{}{"CreateAt": createAt, "Id": session.Id})
+	require.NoError(t, sqlErr)
+
+	t.Run("no warning when the sessions outlive the new length", func(t *testing.T) {
+		cfg := th.App.Config().Clone()
+		*cfg.ServiceSettings.SessionLengthWebInDays = 20
+
+		warnings, err := th.App.SaveConfigWithWarnings(cfg, false)
+		require.Nil(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("warns of the sessions the new length would expire", func(t *testing.T) {
+		cfg := th.App.Config().Clone()
+		*cfg.ServiceSettings.SessionLengthWebInDays = 1
+
+		warnings, err := th.App.SaveConfigWithWarnings(cfg, false)
+		require.Nil(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "web session length of 1 days")
+		assert.Contains(t, warnings[0], "keep their current expiry")
+		assert.Equal(t, 1, *th.App.Config().ServiceSettings.SessionLengthWebInDays, "should save the config anyway")
+	})
+}
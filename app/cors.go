@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+var corsAllowedMethods = []string{
+	"POST",
+	"GET",
+	"OPTIONS",
+	"PUT",
+	"PATCH",
+	"DELETE",
+}
+
+// corsHandler applies the CORS policy of the configured origins to the requests it wraps. Preflight
+// requests are answered directly and never reach the wrapped handler.
+type corsHandler struct {
+	origins []*model.CorsOriginSettings
+	// log is only set when debugging of CORS is turned on.
+	log  *mlog.Logger
+	next http.Handler
+}
+
+func newCorsHandler(origins []*model.CorsOriginSettings, log *mlog.Logger, next http.Handler) *corsHandler {
+	return &corsHandler{
+		origins: origins,
+		log:     log,
+		next:    next,
+	}
+}
+
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		h.handlePreflight(w, r)
+		return
+	}
+
+	h.handleRequest(w, r)
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *corsHandler) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	headers := w.Header()
+	headers.Add("Vary", "Origin")
+	headers.Add("Vary", "Access-Control-Request-Method")
+	headers.Add("Vary", "Access-Control-Request-Headers")
+
+	origin := r.Header.Get("Origin")
+	method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+
+	settings := matchCorsOrigin(h.origins, origin)
+	if settings == nil {
+		h.debug("Preflight request from a disallowed origin", origin)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !isCorsAllowedMethod(method) {
+		h.debug("Preflight request for a disallowed method "+method, origin)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	setCorsAllowOrigin(headers, settings, origin)
+	headers.Set("Access-Control-Allow-Methods", method)
+	if requestHeaders := r.Header.Get("Access-Control-Request-Headers"); requestHeaders != "" {
+		headers.Set("Access-Control-Allow-Headers", requestHeaders)
+	}
+	if *settings.MaxAge > 0 {
+		headers.Set("Access-Control-Max-Age", strconv.Itoa(*settings.MaxAge))
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *corsHandler) handleRequest(w http.ResponseWriter, r *http.Request) {
+	headers := w.Header()
+	headers.Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+
+	settings := matchCorsOrigin(h.origins, origin)
+	if settings == nil {
+		h.debug("Request from a disallowed origin", origin)
+		return
+	}
+
+	setCorsAllowOrigin(headers, settings, origin)
+	if len(settings.ExposedHeaders) > 0 {
+		headers.Set("Access-Control-Expose-Headers", strings.Join(settings.ExposedHeaders, ", "))
+	}
+}
+
+func (h *corsHandler) debug(msg, origin string) {
+	if h.log != nil {
+		h.log.Info(msg, mlog.String("source", "cors"), mlog.String("origin", origin))
+	}
+}
+
+// matchCorsOrigin returns the settings of the first configured origin allowing the given origin.
+func matchCorsOrigin(origins []*model.CorsOriginSettings, origin string) *model.CorsOriginSettings {
+	for _, settings := range origins {
+		if settings.Matches(origin) {
+			return settings
+		}
+	}
+
+	return nil
+}
+
+func setCorsAllowOrigin(headers http.Header, settings *model.CorsOriginSettings, origin string) {
+	if *settings.Origin == model.CORS_ORIGIN_ANY {
+		headers.Set("Access-Control-Allow-Origin", model.CORS_ORIGIN_ANY)
+		return
+	}
+
+	headers.Set("Access-Control-Allow-Origin", origin)
+	if *settings.AllowCredentials {
+		headers.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func isCorsAllowedMethod(method string) bool {
+	for _, allowed := range corsAllowedMethods {
+		if method == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OriginChecker returns the function checking the origin of websocket connections against the CORS
+// policy, or nil to only allow connections from the same host when no origins are configured.
+func (a *App) OriginChecker() func(*http.Request) bool {
+	origins := a.Config().ServiceSettings.CorsOrigins
+	if len(origins) == 0 {
+		return nil
+	}
+
+	siteOrigin := ""
+	if siteURL, err := url.Parse(*a.Config().ServiceSettings.SiteURL); err == nil && siteURL.Host != "" {
+		siteURL.Path = ""
+		siteOrigin = siteURL.String()
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || (siteOrigin != "" && origin == siteOrigin) {
+			return true
+		}
+
+		return matchCorsOrigin(origins, origin) != nil
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestCorsHandler(t *testing.T) {
+	origins := []*model.CorsOriginSettings{
+		{Origin: model.NewString("https://portal.example.com"), AllowCredentials: model.NewBool(true), ExposedHeaders: []string{"X-Version-ID", "X-Request-ID"}, MaxAge: model.NewInt(600)},
+		{Origin: model.NewString("https://*.example.org"), MaxAge: model.NewInt(0)},
+	}
+	for _, origin := range origins {
+		origin.SetDefaults()
+	}
+
+	reached := false
+	handler := newCorsHandler(origins, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	serve := func(method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		reached = false
+		r := httptest.NewRequest(method, "/api/v4/users/me", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("credentialed request", func(t *testing.T) {
+		w := serve(http.MethodGet, "https://portal.example.com", nil)
+
+		assert.True(t, reached)
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Equal(t, "https://portal.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "X-Version-ID, X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("wildcard subdomain without credentials", func(t *testing.T) {
+		w := serve(http.MethodGet, "https://chat.example.org", nil)
+
+		assert.True(t, reached)
+		assert.Equal(t, "https://chat.example.org", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Empty(t, w.Header().Get("Access-Control-Expose-Headers"))
+	})
+
+	t.Run("null origin", func(t *testing.T) {
+		w := serve(http.MethodGet, "null", nil)
+
+		assert.True(t, reached)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("mismatched scheme", func(t *testing.T) {
+		w := serve(http.MethodGet, "http://portal.example.com", nil)
+
+		assert.True(t, reached)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("same origin request", func(t *testing.T) {
+		w := serve(http.MethodGet, "", nil)
+
+		assert.True(t, reached)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("preflight request", func(t *testing.T) {
+		w := serve(http.MethodOptions, "https://portal.example.com", map[string]string{
+			"Access-Control-Request-Method":  "put",
+			"Access-Control-Request-Headers": "Content-Type, X-CSRF-Token",
+		})
+
+		assert.False(t, reached)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://portal.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "PUT", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, X-CSRF-Token", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("preflight request without caching", func(t *testing.T) {
+		w := serve(http.MethodOptions, "https://chat.example.org", map[string]string{"Access-Control-Request-Method": "GET"})
+
+		assert.False(t, reached)
+		assert.Equal(t, "https://chat.example.org", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("preflight request from a disallowed origin", func(t *testing.T) {
+		w := serve(http.MethodOptions, "https://example.org", map[string]string{"Access-Control-Request-Method": "GET"})
+
+		assert.False(t, reached)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("preflight request for a disallowed method", func(t *testing.T) {
+		w := serve(http.MethodOptions, "https://portal.example.com", map[string]string{"Access-Control-Request-Method": "TRACE"})
+
+		assert.False(t, reached)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("options request that isn't a preflight", func(t *testing.T) {
+		serve(http.MethodOptions, "https://portal.example.com", nil)
+
+		assert.True(t, reached)
+	})
+}
+
+func TestCorsHandlerAnyOrigin(t *testing.T) {
+	origins := []*model.CorsOriginSettings{{Origin: model.NewString(model.CORS_ORIGIN_ANY)}}
+	origins[0].SetDefaults()
+
+	handler := newCorsHandler(origins, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v4/system/ping", nil)
+	r.Header.Set("Origin", "https://anywhere.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, model.CORS_ORIGIN_ANY, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestOriginChecker(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	request := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/api/v4/websocket", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{}
+	})
+	require.Nil(t, th.App.OriginChecker())
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "https://chat.example.com/subpath"
+		cfg.ServiceSettings.CorsOrigins = []*model.CorsOriginSettings{{Origin: model.NewString("https://*.example.org")}}
+	})
+	checker := th.App.OriginChecker()
+	require.NotNil(t, checker)
+
+	assert.True(t, checker(request("")))
+	assert.True(t, checker(request("https://chat.example.com")))
+	assert.True(t, checker(request("https://portal.example.org")))
+	assert.False(t, checker(request("http://portal.example.org")))
+	assert.False(t, checker(request("https://example.net")))
+	assert.False(t, checker(request("null")))
+}
@@ -264,6 +264,7 @@ func (s *Server) trackConfig() {
 		"enable_insecure_outgoing_connections":                    *cfg.ServiceSettings.EnableInsecureOutgoingConnections,
 		"enable_incoming_webhooks":                                cfg.ServiceSettings.EnableIncomingWebhooks,
 		"enable_outgoing_webhooks":                                cfg.ServiceSettings.EnableOutgoingWebhooks,
+		"max_outgoing_webhook_timeout":                            *cfg.ServiceSettings.MaxOutgoingWebhookTimeout,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"enable_only_admin_integrations":                          *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_EnableOnlyAdminIntegrations,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
@@ -271,6 +272,8 @@ func (s *Server) trackConfig() {
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
 		"enable_custom_emoji":                                     *cfg.ServiceSettings.EnableCustomEmoji,
 		"enable_emoji_picker":                                     *cfg.ServiceSettings.EnableEmojiPicker,
+		"default_emoji_skin_tone":                                 *cfg.ServiceSettings.DefaultEmojiSkinTone,
+		"max_custom_emoji_per_user":                               *cfg.ServiceSettings.MaxCustomEmojiPerUser,
 		"enable_gif_picker":                                       *cfg.ServiceSettings.EnableGifPicker,
 		"gfycat_api_key":                                          isDefault(*cfg.ServiceSettings.GfycatApiKey, model.SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY),
 		"gfycat_api_secret":                                       isDefault(*cfg.ServiceSettings.GfycatApiSecret, model.SERVICE_SETTINGS_DEFAULT_GFYCAT_API_SECRET),
@@ -280,6 +283,7 @@ func (s *Server) trackConfig() {
 		"enable_developer":                                        *cfg.ServiceSettings.EnableDeveloper,
 		"enable_multifactor_authentication":                       *cfg.ServiceSettings.EnableMultifactorAuthentication,
 		"enforce_multifactor_authentication":                      *cfg.ServiceSettings.EnforceMultifactorAuthentication,
+		"enforce_multifactor_authentication_for_roles":            strings.Join(cfg.ServiceSettings.EnforceMultifactorAuthenticationForRoles, ","),
 		"enable_oauth_service_provider":                           cfg.ServiceSettings.EnableOAuthServiceProvider,
 		"connection_security":                                     *cfg.ServiceSettings.ConnectionSecurity,
 		"tls_strict_transport":                                    *cfg.ServiceSettings.TLSStrictTransport,
@@ -298,15 +302,26 @@ func (s *Server) trackConfig() {
 		"isdefault_read_timeout":                                  isDefault(*cfg.ServiceSettings.ReadTimeout, model.SERVICE_SETTINGS_DEFAULT_READ_TIMEOUT),
 		"isdefault_write_timeout":                                 isDefault(*cfg.ServiceSettings.WriteTimeout, model.SERVICE_SETTINGS_DEFAULT_WRITE_TIMEOUT),
 		"isdefault_idle_timeout":                                  isDefault(*cfg.ServiceSettings.IdleTimeout, model.SERVICE_SETTINGS_DEFAULT_IDLE_TIMEOUT),
+		"isdefault_read_header_timeout":                           isDefault(*cfg.ServiceSettings.ReadHeaderTimeout, 0),
+		"max_conns_per_ip":                                        *cfg.ServiceSettings.MaxConnsPerIP,
+		"check_password_breach_enabled":                           *cfg.ServiceSettings.CheckPasswordBreachEnabled,
+		"enable_h2c":                                              *cfg.ServiceSettings.EnableH2C,
 		"isdefault_google_developer_key":                          isDefault(cfg.ServiceSettings.GoogleDeveloperKey, ""),
 		"isdefault_allow_cors_from":                               isDefault(*cfg.ServiceSettings.AllowCorsFrom, model.SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM),
 		"isdefault_cors_exposed_headers":                          isDefault(cfg.ServiceSettings.CorsExposedHeaders, ""),
 		"cors_allow_credentials":                                  *cfg.ServiceSettings.CorsAllowCredentials,
+		"cors_origins":                                            len(cfg.ServiceSettings.CorsOrigins),
 		"cors_debug":                                              *cfg.ServiceSettings.CorsDebug,
 		"isdefault_allowed_untrusted_internal_connections":        isDefault(*cfg.ServiceSettings.AllowedUntrustedInternalConnections, ""),
 		"restrict_post_delete":                                    *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_RestrictPostDelete,
 		"allow_edit_post":                                         *cfg.ServiceSettings.DEPRECATED_DO_NOT_USE_AllowEditPost,
 		"post_edit_time_limit":                                    *cfg.ServiceSettings.PostEditTimeLimit,
+		"max_reactions_before_collapse":                           *cfg.ServiceSettings.MaxReactionsBeforeCollapse,
+		"max_replies_per_thread":                                  *cfg.ServiceSettings.MaxRepliesPerThread,
+		"enable_post_share_tokens":                                *cfg.ServiceSettings.EnablePostShareTokens,
+		"enable_websocket_post_creation":                          *cfg.ServiceSettings.EnableWebSocketPostCreation,
+		"post_share_token_expiry_in_hours":                        *cfg.ServiceSettings.PostShareTokenExpiryInHours,
+		"post_share_token_include_thread":                         *cfg.ServiceSettings.PostShareTokenIncludeThread,
 		"enable_user_typing_messages":                             *cfg.ServiceSettings.EnableUserTypingMessages,
 		"enable_channel_viewed_messages":                          *cfg.ServiceSettings.EnableChannelViewedMessages,
 		"time_between_user_typing_updates_milliseconds":           *cfg.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds,
@@ -334,6 +349,7 @@ func (s *Server) trackConfig() {
 		"enable_latex":                                            *cfg.ServiceSettings.EnableLatex,
 		"enable_opentracing":                                      *cfg.ServiceSettings.EnableOpenTracing,
 		"experimental_data_prefetch":                              *cfg.ServiceSettings.ExperimentalDataPrefetch,
+		"enable_experimental_app_bar_menu":                        *cfg.ServiceSettings.EnableExperimentalAppBarMenu,
 		"enable_local_mode":                                       *cfg.ServiceSettings.EnableLocalMode,
 	})
 
@@ -348,6 +364,7 @@ func (s *Server) trackConfig() {
 		"restrict_public_channel_deletion":          *cfg.TeamSettings.DEPRECATED_DO_NOT_USE_RestrictPublicChannelDeletion,
 		"restrict_private_channel_deletion":         *cfg.TeamSettings.DEPRECATED_DO_NOT_USE_RestrictPrivateChannelDeletion,
 		"enable_open_server":                        *cfg.TeamSettings.EnableOpenServer,
+		"restrict_team_invite_to_email":             *cfg.TeamSettings.RestrictTeamInviteToEmail,
 		"enable_user_deactivation":                  *cfg.TeamSettings.EnableUserDeactivation,
 		"enable_custom_brand":                       *cfg.TeamSettings.EnableCustomBrand,
 		"restrict_direct_message":                   *cfg.TeamSettings.RestrictDirectMessage,
@@ -355,9 +372,11 @@ func (s *Server) trackConfig() {
 		"enable_confirm_notifications_to_channel":   *cfg.TeamSettings.EnableConfirmNotificationsToChannel,
 		"max_users_per_team":                        *cfg.TeamSettings.MaxUsersPerTeam,
 		"max_channels_per_team":                     *cfg.TeamSettings.MaxChannelsPerTeam,
+		"max_channels_created_per_user_per_day":     *cfg.TeamSettings.MaxChannelsCreatedPerUserPerDay,
 		"teammate_name_display":                     *cfg.TeamSettings.TeammateNameDisplay,
 		"experimental_view_archived_channels":       *cfg.TeamSettings.ExperimentalViewArchivedChannels,
 		"lock_teammate_name_display":                *cfg.TeamSettings.LockTeammateNameDisplay,
+		"default_sidebar_sorting":                   *cfg.TeamSettings.DefaultSidebarSorting,
 		"isdefault_site_name":                       isDefault(cfg.TeamSettings.SiteName, "Mattermost"),
 		"isdefault_custom_brand_text":               isDefault(*cfg.TeamSettings.CustomBrandText, model.TEAM_SETTINGS_DEFAULT_CUSTOM_BRAND_TEXT),
 		"isdefault_custom_description_text":         isDefault(*cfg.TeamSettings.CustomDescriptionText, model.TEAM_SETTINGS_DEFAULT_CUSTOM_DESCRIPTION_TEXT),
@@ -369,6 +388,11 @@ func (s *Server) trackConfig() {
 		"experimental_town_square_is_read_only":     *cfg.TeamSettings.ExperimentalTownSquareIsReadOnly,
 		"experimental_primary_team":                 isDefault(*cfg.TeamSettings.ExperimentalPrimaryTeam, ""),
 		"experimental_default_channels":             len(cfg.TeamSettings.ExperimentalDefaultChannels),
+		"enable_inactive_channel_archiving":         *cfg.TeamSettings.EnableInactiveChannelArchiving,
+		"inactive_channel_archive_days":             *cfg.TeamSettings.InactiveChannelArchiveDays,
+		"inactive_channel_archive_warning_days":     *cfg.TeamSettings.InactiveChannelArchiveWarningDays,
+		"default_channel_added_notification":        *cfg.TeamSettings.DefaultChannelAddedNotification,
+		"exclude_group_sync_channel_added":          *cfg.TeamSettings.ExcludeGroupSyncFromChannelAddedNotification,
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_CLIENT_REQ, map[string]interface{}{
@@ -405,15 +429,16 @@ func (s *Server) trackConfig() {
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_AUDIT, map[string]interface{}{
-		"syslog_enabled":        *cfg.ExperimentalAuditSettings.SysLogEnabled,
-		"syslog_insecure":       *cfg.ExperimentalAuditSettings.SysLogInsecure,
-		"syslog_max_queue_size": *cfg.ExperimentalAuditSettings.SysLogMaxQueueSize,
-		"file_enabled":          *cfg.ExperimentalAuditSettings.FileEnabled,
-		"file_max_size_mb":      *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
-		"file_max_age_days":     *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
-		"file_max_backups":      *cfg.ExperimentalAuditSettings.FileMaxBackups,
-		"file_compress":         *cfg.ExperimentalAuditSettings.FileCompress,
-		"file_max_queue_size":   *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
+		"syslog_enabled":                   *cfg.ExperimentalAuditSettings.SysLogEnabled,
+		"syslog_insecure":                  *cfg.ExperimentalAuditSettings.SysLogInsecure,
+		"syslog_max_queue_size":            *cfg.ExperimentalAuditSettings.SysLogMaxQueueSize,
+		"file_enabled":                     *cfg.ExperimentalAuditSettings.FileEnabled,
+		"file_max_size_mb":                 *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
+		"file_max_age_days":                *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
+		"file_max_backups":                 *cfg.ExperimentalAuditSettings.FileMaxBackups,
+		"file_compress":                    *cfg.ExperimentalAuditSettings.FileCompress,
+		"file_max_queue_size":              *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
+		"additional_redacted_query_params": len(cfg.ExperimentalAuditSettings.AdditionalRedactedQueryParams),
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_NOTIFICATION_LOG, map[string]interface{}{
@@ -427,53 +452,58 @@ func (s *Server) trackConfig() {
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_PASSWORD, map[string]interface{}{
-		"minimum_length": *cfg.PasswordSettings.MinimumLength,
-		"lowercase":      *cfg.PasswordSettings.Lowercase,
-		"number":         *cfg.PasswordSettings.Number,
-		"uppercase":      *cfg.PasswordSettings.Uppercase,
-		"symbol":         *cfg.PasswordSettings.Symbol,
+		"minimum_length":                   *cfg.PasswordSettings.MinimumLength,
+		"lowercase":                        *cfg.PasswordSettings.Lowercase,
+		"number":                           *cfg.PasswordSettings.Number,
+		"uppercase":                        *cfg.PasswordSettings.Uppercase,
+		"symbol":                           *cfg.PasswordSettings.Symbol,
+		"minimum_change_interval_in_hours": *cfg.PasswordSettings.MinimumChangeIntervalInHours,
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_FILE, map[string]interface{}{
-		"enable_public_links":     cfg.FileSettings.EnablePublicLink,
-		"driver_name":             *cfg.FileSettings.DriverName,
-		"isdefault_directory":     isDefault(*cfg.FileSettings.Directory, model.FILE_SETTINGS_DEFAULT_DIRECTORY),
-		"isabsolute_directory":    filepath.IsAbs(*cfg.FileSettings.Directory),
-		"amazon_s3_ssl":           *cfg.FileSettings.AmazonS3SSL,
-		"amazon_s3_sse":           *cfg.FileSettings.AmazonS3SSE,
-		"amazon_s3_signv2":        *cfg.FileSettings.AmazonS3SignV2,
-		"amazon_s3_trace":         *cfg.FileSettings.AmazonS3Trace,
-		"max_file_size":           *cfg.FileSettings.MaxFileSize,
-		"enable_file_attachments": *cfg.FileSettings.EnableFileAttachments,
-		"enable_mobile_upload":    *cfg.FileSettings.EnableMobileUpload,
-		"enable_mobile_download":  *cfg.FileSettings.EnableMobileDownload,
+		"enable_public_links":                cfg.FileSettings.EnablePublicLink,
+		"driver_name":                        *cfg.FileSettings.DriverName,
+		"isdefault_directory":                isDefault(*cfg.FileSettings.Directory, model.FILE_SETTINGS_DEFAULT_DIRECTORY),
+		"isabsolute_directory":               filepath.IsAbs(*cfg.FileSettings.Directory),
+		"amazon_s3_ssl":                      *cfg.FileSettings.AmazonS3SSL,
+		"amazon_s3_sse":                      *cfg.FileSettings.AmazonS3SSE,
+		"amazon_s3_signv2":                   *cfg.FileSettings.AmazonS3SignV2,
+		"amazon_s3_trace":                    *cfg.FileSettings.AmazonS3Trace,
+		"disable_local_storage":              *cfg.FileSettings.DisableLocalStorage,
+		"max_file_size":                      *cfg.FileSettings.MaxFileSize,
+		"enable_file_attachments":            *cfg.FileSettings.EnableFileAttachments,
+		"enable_mobile_upload":               *cfg.FileSettings.EnableMobileUpload,
+		"enable_mobile_download":             *cfg.FileSettings.EnableMobileDownload,
+		"admins_bypass_disabled_attachments": *cfg.FileSettings.AdminsBypassDisabledAttachments,
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_EMAIL, map[string]interface{}{
-		"enable_sign_up_with_email":            cfg.EmailSettings.EnableSignUpWithEmail,
-		"enable_sign_in_with_email":            *cfg.EmailSettings.EnableSignInWithEmail,
-		"enable_sign_in_with_username":         *cfg.EmailSettings.EnableSignInWithUsername,
-		"require_email_verification":           cfg.EmailSettings.RequireEmailVerification,
-		"send_email_notifications":             cfg.EmailSettings.SendEmailNotifications,
-		"use_channel_in_email_notifications":   *cfg.EmailSettings.UseChannelInEmailNotifications,
-		"email_notification_contents_type":     *cfg.EmailSettings.EmailNotificationContentsType,
-		"enable_smtp_auth":                     *cfg.EmailSettings.EnableSMTPAuth,
-		"connection_security":                  cfg.EmailSettings.ConnectionSecurity,
-		"send_push_notifications":              *cfg.EmailSettings.SendPushNotifications,
-		"push_notification_contents":           *cfg.EmailSettings.PushNotificationContents,
-		"enable_email_batching":                *cfg.EmailSettings.EnableEmailBatching,
-		"email_batching_buffer_size":           *cfg.EmailSettings.EmailBatchingBufferSize,
-		"email_batching_interval":              *cfg.EmailSettings.EmailBatchingInterval,
-		"enable_preview_mode_banner":           *cfg.EmailSettings.EnablePreviewModeBanner,
-		"isdefault_feedback_name":              isDefault(cfg.EmailSettings.FeedbackName, ""),
-		"isdefault_feedback_email":             isDefault(cfg.EmailSettings.FeedbackEmail, ""),
-		"isdefault_reply_to_address":           isDefault(cfg.EmailSettings.ReplyToAddress, ""),
-		"isdefault_feedback_organization":      isDefault(*cfg.EmailSettings.FeedbackOrganization, model.EMAIL_SETTINGS_DEFAULT_FEEDBACK_ORGANIZATION),
-		"skip_server_certificate_verification": *cfg.EmailSettings.SkipServerCertificateVerification,
-		"isdefault_login_button_color":         isDefault(*cfg.EmailSettings.LoginButtonColor, ""),
-		"isdefault_login_button_border_color":  isDefault(*cfg.EmailSettings.LoginButtonBorderColor, ""),
-		"isdefault_login_button_text_color":    isDefault(*cfg.EmailSettings.LoginButtonTextColor, ""),
-		"smtp_server_timeout":                  *cfg.EmailSettings.SMTPServerTimeout,
+		"enable_sign_up_with_email":                       cfg.EmailSettings.EnableSignUpWithEmail,
+		"enable_sign_in_with_email":                       *cfg.EmailSettings.EnableSignInWithEmail,
+		"enable_sign_in_with_username":                    *cfg.EmailSettings.EnableSignInWithUsername,
+		"require_email_verification":                      cfg.EmailSettings.RequireEmailVerification,
+		"send_email_notifications":                        cfg.EmailSettings.SendEmailNotifications,
+		"use_channel_in_email_notifications":              *cfg.EmailSettings.UseChannelInEmailNotifications,
+		"send_email_notifications_from_user_display_name": *cfg.EmailSettings.SendEmailNotificationsFromUserDisplayName,
+		"email_notification_contents_type":                *cfg.EmailSettings.EmailNotificationContentsType,
+		"enable_smtp_auth":                                *cfg.EmailSettings.EnableSMTPAuth,
+		"connection_security":                             cfg.EmailSettings.ConnectionSecurity,
+		"send_push_notifications":                         *cfg.EmailSettings.SendPushNotifications,
+		"push_notification_contents":                      *cfg.EmailSettings.PushNotificationContents,
+		"enable_email_batching":                           *cfg.EmailSettings.EnableEmailBatching,
+		"email_batching_buffer_size":                      *cfg.EmailSettings.EmailBatchingBufferSize,
+		"email_batching_interval":                         *cfg.EmailSettings.EmailBatchingInterval,
+		"enable_preview_mode_banner":                      *cfg.EmailSettings.EnablePreviewModeBanner,
+		"isdefault_feedback_name":                         isDefault(cfg.EmailSettings.FeedbackName, ""),
+		"isdefault_feedback_email":                        isDefault(cfg.EmailSettings.FeedbackEmail, ""),
+		"isdefault_reply_to_address":                      isDefault(cfg.EmailSettings.ReplyToAddress, ""),
+		"isdefault_feedback_organization":                 isDefault(*cfg.EmailSettings.FeedbackOrganization, model.EMAIL_SETTINGS_DEFAULT_FEEDBACK_ORGANIZATION),
+		"skip_server_certificate_verification":            *cfg.EmailSettings.SkipServerCertificateVerification,
+		"isdefault_login_button_color":                    isDefault(*cfg.EmailSettings.LoginButtonColor, ""),
+		"isdefault_login_button_border_color":             isDefault(*cfg.EmailSettings.LoginButtonBorderColor, ""),
+		"isdefault_login_button_text_color":               isDefault(*cfg.EmailSettings.LoginButtonTextColor, ""),
+		"smtp_server_timeout":                             *cfg.EmailSettings.SMTPServerTimeout,
+		"isdefault_template_override_directory":           isDefault(*cfg.EmailSettings.TemplateOverrideDirectory, ""),
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_RATE, map[string]interface{}{
@@ -484,6 +514,10 @@ func (s *Server) trackConfig() {
 		"max_burst":                *cfg.RateLimitSettings.MaxBurst,
 		"memory_store_size":        *cfg.RateLimitSettings.MemoryStoreSize,
 		"isdefault_vary_by_header": isDefault(cfg.RateLimitSettings.VaryByHeader, ""),
+		"auth_per_sec":             *cfg.RateLimitSettings.AuthPerSec,
+		"auth_max_burst":           *cfg.RateLimitSettings.AuthMaxBurst,
+		"files_per_sec":            *cfg.RateLimitSettings.FilesPerSec,
+		"files_max_burst":          *cfg.RateLimitSettings.FilesMaxBurst,
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_PRIVACY, map[string]interface{}{
@@ -523,6 +557,7 @@ func (s *Server) trackConfig() {
 		"connection_security":                    *cfg.LdapSettings.ConnectionSecurity,
 		"skip_certificate_verification":          *cfg.LdapSettings.SkipCertificateVerification,
 		"sync_interval_minutes":                  *cfg.LdapSettings.SyncIntervalMinutes,
+		"repair_channel_members_drift":           *cfg.LdapSettings.RepairChannelMembersDrift,
 		"query_timeout":                          *cfg.LdapSettings.QueryTimeout,
 		"max_page_size":                          *cfg.LdapSettings.MaxPageSize,
 		"isdefault_first_name_attribute":         isDefault(*cfg.LdapSettings.FirstNameAttribute, model.LDAP_SETTINGS_DEFAULT_FIRST_NAME_ATTRIBUTE),
@@ -546,14 +581,19 @@ func (s *Server) trackConfig() {
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_COMPLIANCE, map[string]interface{}{
-		"enable":       *cfg.ComplianceSettings.Enable,
-		"enable_daily": *cfg.ComplianceSettings.EnableDaily,
+		"enable":                    *cfg.ComplianceSettings.Enable,
+		"enable_daily":              *cfg.ComplianceSettings.EnableDaily,
+		"isnotempty_syslog_address": !isDefault(*cfg.ComplianceSettings.SyslogAddress, ""),
+		"syslog_protocol":           *cfg.ComplianceSettings.SyslogProtocol,
+		"syslog_enable_tls":         *cfg.ComplianceSettings.SyslogEnableTLS,
+		"isnotempty_syslog_ca_cert": !isDefault(*cfg.ComplianceSettings.SyslogTLSCACertFile, ""),
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_LOCALIZATION, map[string]interface{}{
-		"default_server_locale": *cfg.LocalizationSettings.DefaultServerLocale,
-		"default_client_locale": *cfg.LocalizationSettings.DefaultClientLocale,
-		"available_locales":     *cfg.LocalizationSettings.AvailableLocales,
+		"default_server_locale":       *cfg.LocalizationSettings.DefaultServerLocale,
+		"default_client_locale":       *cfg.LocalizationSettings.DefaultClientLocale,
+		"available_locales":           *cfg.LocalizationSettings.AvailableLocales,
+		"team_default_client_locales": len(cfg.LocalizationSettings.TeamDefaultClientLocales),
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_SAML, map[string]interface{}{
@@ -701,11 +741,12 @@ func (s *Server) trackConfig() {
 	s.SendDiagnostic(TRACK_CONFIG_PLUGIN, pluginConfigData)
 
 	s.SendDiagnostic(TRACK_CONFIG_DATA_RETENTION, map[string]interface{}{
-		"enable_message_deletion": *cfg.DataRetentionSettings.EnableMessageDeletion,
-		"enable_file_deletion":    *cfg.DataRetentionSettings.EnableFileDeletion,
-		"message_retention_days":  *cfg.DataRetentionSettings.MessageRetentionDays,
-		"file_retention_days":     *cfg.DataRetentionSettings.FileRetentionDays,
-		"deletion_job_start_time": *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"enable_message_deletion":             *cfg.DataRetentionSettings.EnableMessageDeletion,
+		"enable_file_deletion":                *cfg.DataRetentionSettings.EnableFileDeletion,
+		"message_retention_days":              *cfg.DataRetentionSettings.MessageRetentionDays,
+		"file_retention_days":                 *cfg.DataRetentionSettings.FileRetentionDays,
+		"deletion_job_start_time":             *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"edited_post_original_retention_days": *cfg.DataRetentionSettings.EditedPostOriginalRetentionDays,
 	})
 
 	s.SendDiagnostic(TRACK_CONFIG_MESSAGE_EXPORT, map[string]interface{}{
@@ -11,7 +11,7 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/retryutils"
 
 	"github.com/pkg/errors"
 )
@@ -39,7 +39,7 @@ func (a *App) DownloadFromURL(downloadURL string) ([]byte, error) {
 	client.Timeout = HTTP_REQUEST_TIMEOUT
 
 	var resp *http.Response
-	err = utils.ProgressiveRetry(func() error {
+	err = retryutils.ProgressiveRetry(func() error {
 		resp, err = client.Get(downloadURL)
 
 		if err != nil {
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const EDIT_RETENTION_BATCH_SIZE = 1000
+
+// DeleteExpiredEditOriginals permanently deletes the originals kept when posts are edited, once
+// the edit is older than DataRetentionSettings.EditedPostOriginalRetentionDays, and returns how many
+// were deleted. With the setting at 0 the originals are kept as long as the post they belong to,
+// and nothing is deleted here.
+func (a *App) DeleteExpiredEditOriginals() (int64, *model.AppError) {
+	retentionDays := *a.Config().DataRetentionSettings.EditedPostOriginalRetentionDays
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+
+	endTime := model.GetMillisForTime(time.Now().AddDate(0, 0, -retentionDays))
+
+	var total int64
+	for {
+		deleted, err := a.Srv().Store.Post().PermanentDeleteEditOriginalsBatch(endTime, EDIT_RETENTION_BATCH_SIZE)
+		if err != nil {
+			return total, err
+		}
+
+		total += deleted
+		if deleted < EDIT_RETENTION_BATCH_SIZE {
+			break
+		}
+	}
+
+	mlog.Debug("Deleted expired edit originals", mlog.Int64("count", total))
+
+	return total, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestDeleteExpiredEditOriginals(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+
+	makeEditOriginal := func(days int) *model.Post {
+		original := th.CreatePost(th.BasicChannel)
+		original.OriginalId = post.Id
+		original.DeleteAt = model.GetMillis() - int64(days)*dayInMilliseconds
+		original, err := th.App.Srv().Store.Post().Overwrite(original)
+		require.Nil(t, err)
+		return original
+	}
+
+	exists := func(postId string) bool {
+		posts, err := th.App.Srv().Store.Post().GetPostsByIds([]string{postId})
+		require.Nil(t, err)
+		return len(posts) == 1
+	}
+
+	recent := makeEditOriginal(2)
+	old := makeEditOriginal(10)
+
+	t.Run("keeps originals as long as their post by default", func(t *testing.T) {
+		deleted, err := th.App.DeleteExpiredEditOriginals()
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), deleted)
+		assert.True(t, exists(old.Id))
+	})
+
+	t.Run("deletes originals of edits older than the retention", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.DataRetentionSettings.EditedPostOriginalRetentionDays = 5 })
+
+		_, err := th.App.DeleteExpiredEditOriginals()
+		require.Nil(t, err)
+		assert.False(t, exists(old.Id))
+		assert.True(t, exists(recent.Id))
+		assert.True(t, exists(post.Id))
+	})
+}
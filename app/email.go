@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"io"
 	"net/url"
 	"path"
@@ -542,6 +543,38 @@ func (es *EmailService) SendRemoveExpiredLicenseEmail(email string, locale, site
 	return nil
 }
 
+// SendTemplateTestEmail renders the given email template with sample values for the fields it
+// uses and sends it to the given address, so that template overrides can be checked.
+func (es *EmailService) SendTemplateTestEmail(templateName, to string) *model.AppError {
+	templates := es.srv.HTMLTemplates()
+	if templates == nil || templates.Lookup(templateName) == nil {
+		return model.NewAppError("SendTemplateTestEmail", "app.email.test_template.not_found.app_error", map[string]interface{}{"Name": templateName}, "", http.StatusBadRequest)
+	}
+
+	bodyPage := es.newEmailTemplate(templateName, "")
+	props, html := utils.TemplateFields(templates, templateName)
+	for _, prop := range props {
+		if _, ok := bodyPage.Props[prop]; !ok {
+			bodyPage.Props[prop] = "Sample " + prop
+		}
+	}
+	for _, field := range html {
+		bodyPage.Html[field] = template.HTML("Sample " + field)
+	}
+
+	var body bytes.Buffer
+	if err := bodyPage.RenderToWriter(&body); err != nil {
+		return model.NewAppError("SendTemplateTestEmail", "app.email.test_template.render.app_error", map[string]interface{}{"Name": templateName}, err.Error(), http.StatusInternalServerError)
+	}
+
+	subject := utils.T("app.email.test_template.subject", map[string]interface{}{"Name": templateName})
+	if err := es.sendMail(to, subject, body.String()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *EmailService) sendNotificationMail(to, subject, htmlBody string) *model.AppError {
 	if !*es.srv.Config().EmailSettings.SendEmailNotifications {
 		return nil
@@ -549,6 +582,17 @@ func (es *EmailService) sendNotificationMail(to, subject, htmlBody string) *mode
 	return es.sendMail(to, subject, htmlBody)
 }
 
+// sendNotificationMailFromName sends a notification email under the given display name instead of
+// EmailSettings.FeedbackName.
+func (es *EmailService) sendNotificationMailFromName(to, fromName, subject, htmlBody string) *model.AppError {
+	if !*es.srv.Config().EmailSettings.SendEmailNotifications {
+		return nil
+	}
+
+	license := es.srv.License()
+	return mailservice.SendMailFromNameUsingConfig(to, fromName, subject, htmlBody, es.srv.Config(), license != nil && *license.Features.Compliance)
+}
+
 func (es *EmailService) sendMail(to, subject, htmlBody string) *model.AppError {
 	license := es.srv.License()
 	return mailservice.SendMailUsingConfig(to, subject, htmlBody, es.srv.Config(), license != nil && *license.Features.Compliance)
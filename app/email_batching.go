@@ -201,7 +201,7 @@ func (es *EmailService) sendBatchedEmailNotification(userId string, notification
 		return
 	}
 
-	translateFunc := utils.GetUserTranslations(user.Locale)
+	translateFunc := es.srv.getRecipientTranslations(user)
 	displayNameFormat := *es.srv.Config().TeamSettings.TeammateNameDisplay
 
 	var contents string
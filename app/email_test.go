@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/services/mailservice"
 )
 
 func TestCondenseSiteURL(t *testing.T) {
@@ -29,3 +31,37 @@ func TestCondenseSiteURL(t *testing.T) {
 	require.Equal(t, "chat.mattermost.com:8080/subpath", condenseSiteURL("http://chat.mattermost.com:8080/subpath"))
 	require.Equal(t, "chat.mattermost.com:8080/subpath", condenseSiteURL("http://chat.mattermost.com:8080/subpath/"))
 }
+
+func TestSendTemplateTestEmail(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("unknown template", func(t *testing.T) {
+		err := th.App.Srv().EmailService.SendTemplateTestEmail("not_a_template", "test@example.com")
+		require.NotNil(t, err)
+		require.Equal(t, "app.email.test_template.not_found.app_error", err.Id)
+	})
+
+	t.Run("known template", func(t *testing.T) {
+		emailTo := "template-test@example.com"
+		mailservice.DeleteMailBox(emailTo)
+
+		err := th.App.Srv().EmailService.SendTemplateTestEmail("post_body_full", emailTo)
+		require.Nil(t, err)
+
+		var resultsMailbox mailservice.JSONMessageHeaderInbucket
+		err2 := mailservice.RetryInbucket(5, func() error {
+			var err error
+			resultsMailbox, err = mailservice.GetMailBox(emailTo)
+			return err
+		})
+		if err2 != nil {
+			t.Log(err2)
+			t.Log("No email was received, maybe due load on the server. Skipping this verification")
+			return
+		}
+
+		require.NotEmpty(t, resultsMailbox)
+		require.Contains(t, resultsMailbox[0].Subject, "post_body_full")
+	})
+}
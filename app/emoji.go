@@ -60,6 +60,11 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, model.NewAppError("createEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	maxEmoji := a.customEmojiLimit(sessionUserId)
+	if err := a.checkCustomEmojiLimit(sessionUserId, maxEmoji); err != nil {
+		return nil, err
+	}
+
 	imageData := multiPartImageData.File["image"]
 	if len(imageData) == 0 {
 		err := model.NewAppError("Context", "api.context.invalid_body_param.app_error", map[string]interface{}{"Name": "createEmoji"}, "", http.StatusBadRequest)
@@ -70,8 +75,13 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, err
 	}
 
-	emoji, err := a.Srv().Store.Emoji().Save(emoji)
+	// The limit is checked again when saving, as other emoji may have been created in the meantime.
+	emoji, err := a.Srv().Store.Emoji().SaveWithCreatorLimit(emoji, maxEmoji)
 	if err != nil {
+		var ltErr *store.ErrLimitExceeded
+		if errors.As(err, &ltErr) {
+			return nil, customEmojiLimitError(maxEmoji)
+		}
 		return nil, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
@@ -81,6 +91,40 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 	return emoji, nil
 }
 
+// customEmojiLimit returns the number of custom emoji the user is allowed to own, or -1 when the user
+// isn't limited. System admins aren't limited.
+func (a *App) customEmojiLimit(userId string) int64 {
+	max := *a.Config().ServiceSettings.MaxCustomEmojiPerUser
+	if max <= 0 || a.HasPermissionTo(userId, model.PERMISSION_MANAGE_SYSTEM) {
+		return -1
+	}
+
+	return int64(max)
+}
+
+// checkCustomEmojiLimit returns an error if the user already owns the given number of custom emoji, so
+// that the image isn't uploaded for an emoji that can't be saved.
+func (a *App) checkCustomEmojiLimit(userId string, max int64) *model.AppError {
+	if max < 0 {
+		return nil
+	}
+
+	count, err := a.Srv().Store.Emoji().CountByCreator(userId)
+	if err != nil {
+		return model.NewAppError("createEmoji", "app.emoji.count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if count >= max {
+		return customEmojiLimitError(max)
+	}
+
+	return nil
+}
+
+func customEmojiLimitError(max int64) *model.AppError {
+	return model.NewAppError("createEmoji", "api.emoji.create.too_many.app_error", map[string]interface{}{"Max": max}, "", http.StatusBadRequest)
+}
+
 func (a *App) GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
 	list, err := a.Srv().Store.Emoji().GetList(page*perPage, perPage, sort)
 	if err != nil {
@@ -96,6 +96,24 @@ func RegisterJobsExpiryNotifyJobInterface(f func(*App) tjobs.ExpiryNotifyJobInte
 	jobsExpiryNotifyInterface = f
 }
 
+var jobsInactiveChannelArchiveInterface func(*App) tjobs.InactiveChannelArchiveJobInterface
+
+func RegisterJobsInactiveChannelArchiveJobInterface(f func(*App) tjobs.InactiveChannelArchiveJobInterface) {
+	jobsInactiveChannelArchiveInterface = f
+}
+
+var jobsEditRetentionInterface func(*App) tjobs.EditRetentionJobInterface
+
+func RegisterJobsEditRetentionJobInterface(f func(*App) tjobs.EditRetentionJobInterface) {
+	jobsEditRetentionInterface = f
+}
+
+var jobsChannelMembersDriftInterface func(*App) tjobs.ChannelMembersDriftJobInterface
+
+func RegisterJobsChannelMembersDriftJobInterface(f func(*App) tjobs.ChannelMembersDriftJobInterface) {
+	jobsChannelMembersDriftInterface = f
+}
+
 var ldapInterface func(*App) einterfaces.LdapInterface
 
 func RegisterLdapInterface(f func(*App) einterfaces.LdapInterface) {
@@ -363,6 +363,11 @@ func (a *App) exportAllPosts(writer io.Writer) *model.AppError {
 				return err
 			}
 
+			postLine.Post.SavedBy, err = a.buildPostSaves(post.Id)
+			if err != nil {
+				return err
+			}
+
 			postLine.Post.Reactions = &[]ReactionImportData{}
 			if post.HasReactions {
 				postLine.Post.Reactions, err = a.BuildPostReactions(post.Id)
@@ -400,6 +405,33 @@ func (a *App) buildPostReplies(postId string) (*[]ReplyImportData, *model.AppErr
 	return &replies, nil
 }
 
+// buildPostSaves returns the users who saved the given post along with their labels and notes. Nil
+// is returned when nobody saved the post.
+func (a *App) buildPostSaves(postId string) (*[]SavedPostImportData, *model.AppError) {
+	savedPosts, nErr := a.Srv().Store.SavedPost().GetForPost(postId)
+	if nErr != nil {
+		return nil, model.NewAppError("buildPostSaves", "app.saved_post.get_for_post.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	if len(savedPosts) == 0 {
+		return nil, nil
+	}
+
+	var saves []SavedPostImportData
+	for _, savedPost := range savedPosts {
+		user, err := a.Srv().Store.User().Get(savedPost.UserId)
+		if err != nil {
+			if err.Id == store.MISSING_ACCOUNT_ERROR { // the user that saved the post might've been deleted by now
+				continue
+			}
+			return nil, err
+		}
+		saves = append(saves, *ImportSavedPostFromSavedPost(user, savedPost))
+	}
+
+	return &saves, nil
+}
+
 func (a *App) BuildPostReactions(postId string) (*[]ReactionImportData, *model.AppError) {
 	var reactionsOfPost []ReactionImportData
 
@@ -569,6 +601,11 @@ func (a *App) exportAllDirectPosts(writer io.Writer) *model.AppError {
 
 			postLine := ImportLineForDirectPost(post)
 			postLine.DirectPost.Replies = replies
+
+			postLine.DirectPost.SavedBy, err = a.buildPostSaves(post.Id)
+			if err != nil {
+				return err
+			}
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return err
 			}
@@ -106,7 +106,7 @@ func ImportUserTeamDataFromTeamMember(member *model.TeamMemberForExport) *UserTe
 }
 
 func ImportUserChannelDataFromChannelMemberAndPreferences(member *model.ChannelMemberForExport, preferences *model.Preferences) *UserChannelImportData {
-	rolesList := strings.Fields(member.Roles)
+	rolesList := member.GetRoles()
 	if member.SchemeAdmin {
 		rolesList = append(rolesList, model.CHANNEL_ADMIN_ROLE_ID)
 	}
@@ -195,6 +195,15 @@ func ImportReactionFromPost(user *model.User, reaction *model.Reaction) *Reactio
 	}
 }
 
+func ImportSavedPostFromSavedPost(user *model.User, savedPost *model.SavedPost) *SavedPostImportData {
+	return &SavedPostImportData{
+		User:    &user.Username,
+		Label:   &savedPost.Label,
+		Note:    &savedPost.Note,
+		SavedAt: &savedPost.SavedAt,
+	}
+}
+
 func ImportLineFromEmoji(emoji *model.Emoji, filePath string) *LineImportData {
 	return &LineImportData{
 		Type: "emoji",
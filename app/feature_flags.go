@@ -0,0 +1,272 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+// featureFlagOverride is a model.FeatureFlagOverride prepared for evaluation.
+type featureFlagOverride struct {
+	rolloutPercentage int
+	userIds           map[string]bool
+	teamIds           map[string]bool
+}
+
+// featureFlagsState is a snapshot of the configured feature flags and their overrides. It is
+// never modified once stored, allowing flags to be evaluated without locking.
+type featureFlagsState struct {
+	configured map[string]bool
+	overrides  model.FeatureFlagOverrides
+	evaluators map[string]*featureFlagOverride
+}
+
+func newFeatureFlagsState(configured map[string]bool, overrides model.FeatureFlagOverrides) *featureFlagsState {
+	state := &featureFlagsState{
+		configured: configured,
+		overrides:  overrides,
+		evaluators: make(map[string]*featureFlagOverride, len(overrides)),
+	}
+
+	for name, override := range overrides {
+		if override == nil {
+			continue
+		}
+
+		evaluator := &featureFlagOverride{
+			rolloutPercentage: override.RolloutPercentage,
+			userIds:           make(map[string]bool, len(override.UserIds)),
+			teamIds:           make(map[string]bool, len(override.TeamIds)),
+		}
+		for _, userId := range override.UserIds {
+			evaluator.userIds[userId] = true
+		}
+		for _, teamId := range override.TeamIds {
+			evaluator.teamIds[teamId] = true
+		}
+		state.evaluators[name] = evaluator
+	}
+
+	return state
+}
+
+// enabled returns whether the flag is enabled for the user. The user's teams are only looked up
+// when the flag is overridden for some teams and the user isn't otherwise included.
+func (st *featureFlagsState) enabled(name, userId string, getTeamIds func(userId string) []string) bool {
+	configured, ok := st.configured[name]
+	if !ok {
+		return false
+	}
+
+	override, ok := st.evaluators[name]
+	if !ok {
+		return configured
+	}
+
+	if userId == "" {
+		return false
+	}
+
+	if override.userIds[userId] || featureFlagBucket(name, userId) < override.rolloutPercentage {
+		return true
+	}
+
+	if len(override.teamIds) > 0 {
+		for _, teamId := range getTeamIds(userId) {
+			if override.teamIds[teamId] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// featureFlagBucket places the user in one of 100 buckets for the flag. The flag name is part of
+// the hash so that the same users aren't always the first ones to get every feature.
+func featureFlagBucket(name, userId string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name + ":" + userId))
+	return int(h.Sum32() % model.FEATURE_FLAG_OVERRIDE_MAX_ROLLOUT_PERCENTAGE)
+}
+
+// initFeatureFlags loads the feature flag overrides and keeps the configured flags up to date.
+func (s *Server) initFeatureFlags() {
+	overrides, err := s.getFeatureFlagOverrides()
+	if err != nil {
+		mlog.Error("Failed to load the feature flag overrides", mlog.Err(err))
+		overrides = model.FeatureFlagOverrides{}
+	}
+
+	s.featureFlags.Store(newFeatureFlagsState(s.Config().FeatureFlags.ToMap(), overrides))
+
+	s.AddConfigListener(func(_, cfg *model.Config) {
+		s.featureFlagsMutex.Lock()
+		defer s.featureFlagsMutex.Unlock()
+
+		s.featureFlags.Store(newFeatureFlagsState(cfg.FeatureFlags.ToMap(), s.featureFlagsState().overrides))
+	})
+}
+
+func (s *Server) featureFlagsState() *featureFlagsState {
+	if state, ok := s.featureFlags.Load().(*featureFlagsState); ok {
+		return state
+	}
+
+	return newFeatureFlagsState(s.Config().FeatureFlags.ToMap(), nil)
+}
+
+// setFeatureFlagOverride replaces the override of a single flag, or removes it when nil.
+func (s *Server) setFeatureFlagOverride(name string, override *model.FeatureFlagOverride) {
+	s.featureFlagsMutex.Lock()
+	defer s.featureFlagsMutex.Unlock()
+
+	state := s.featureFlagsState()
+	overrides := make(model.FeatureFlagOverrides, len(state.overrides)+1)
+	for flag, flagOverride := range state.overrides {
+		overrides[flag] = flagOverride
+	}
+	if override != nil {
+		overrides[name] = override
+	} else {
+		delete(overrides, name)
+	}
+
+	s.featureFlags.Store(newFeatureFlagsState(state.configured, overrides))
+}
+
+// getFeatureFlagOverrides reads the overrides saved for every flag, each in its own row of the
+// Systems table.
+func (s *Server) getFeatureFlagOverrides() (model.FeatureFlagOverrides, *model.AppError) {
+	props, err := s.Store.System().Get()
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := model.FeatureFlagOverrides{}
+	for key, value := range props {
+		if !strings.HasPrefix(key, model.SYSTEM_FEATURE_FLAG_OVERRIDE_PREFIX) || value == "" {
+			continue
+		}
+
+		var override *model.FeatureFlagOverride
+		if err := json.Unmarshal([]byte(value), &override); err != nil {
+			return nil, model.NewAppError("getFeatureFlagOverrides", "app.feature_flags.get_overrides.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		overrides[strings.TrimPrefix(key, model.SYSTEM_FEATURE_FLAG_OVERRIDE_PREFIX)] = override
+	}
+
+	return overrides, nil
+}
+
+// saveFeatureFlagOverride saves the override of the flag, or deletes it when nil, and sends it to
+// the other cluster nodes. Each flag has its own row, so that concurrent
+// changes to different flags from different nodes don't overwrite each other.
+func (a *App) saveFeatureFlagOverride(name string, override *model.FeatureFlagOverride) *model.AppError {
+	key := model.SYSTEM_FEATURE_FLAG_OVERRIDE_PREFIX + name
+	if override != nil {
+		if err := a.Srv().Store.System().SaveOrUpdate(&model.System{Name: key, Value: override.ToJson()}); err != nil {
+			return model.NewAppError("saveFeatureFlagOverride", "app.feature_flags.save_overrides.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	} else {
+		if _, err := a.Srv().Store.System().PermanentDeleteByName(key); err != nil {
+			return model.NewAppError("saveFeatureFlagOverride", "app.feature_flags.save_overrides.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.Srv().setFeatureFlagOverride(name, override)
+
+	if a.Cluster() != nil {
+		msg := &model.ClusterMessage{
+			Event:            model.CLUSTER_EVENT_FEATURE_FLAG_OVERRIDES_CHANGED,
+			SendType:         model.CLUSTER_SEND_RELIABLE,
+			WaitForAllToSend: true,
+			Props:            map[string]string{"name": name},
+		}
+		if override != nil {
+			msg.Data = override.ToJson()
+		}
+		a.Cluster().SendClusterMessage(msg)
+	}
+
+	return nil
+}
+
+// FeatureEnabled returns whether the feature flag is enabled for the user. Flags that aren't
+// overridden take their configured value, while overridden flags are only enabled for the users
+// the override includes. Unknown flags are disabled.
+func (a *App) FeatureEnabled(name, userId string) bool {
+	return a.Srv().featureFlagsState().enabled(name, userId, a.featureFlagTeamIds)
+}
+
+// GetFeatureFlagsForUser returns whether each feature flag is enabled for the user, by name.
+func (a *App) GetFeatureFlagsForUser(userId string) map[string]bool {
+	state := a.Srv().featureFlagsState()
+
+	flags := make(map[string]bool, len(state.configured))
+	for name := range state.configured {
+		flags[name] = state.enabled(name, userId, a.featureFlagTeamIds)
+	}
+
+	return flags
+}
+
+func (a *App) featureFlagTeamIds(userId string) []string {
+	teamIds, err := a.Srv().Store.Team().GetUserTeamIds(userId, true)
+	if err != nil {
+		mlog.Warn("Failed to get the teams of the user to evaluate feature flags", mlog.String("user_id", userId), mlog.Err(err))
+		return nil
+	}
+
+	return teamIds
+}
+
+// GetFeatureFlags returns every feature flag along with its configured value and override.
+func (a *App) GetFeatureFlags() []*model.FeatureFlag {
+	return model.FeatureFlagsFromConfig(&a.Config().FeatureFlags, a.Srv().featureFlagsState().overrides)
+}
+
+// SetFeatureFlagOverride overrides the configured value of the feature flag, enabling it only for
+// the users the override includes. The override applies to every cluster node without a restart.
+func (a *App) SetFeatureFlagOverride(name string, override *model.FeatureFlagOverride) (*model.FeatureFlag, *model.AppError) {
+	configured, ok := a.Config().FeatureFlags.ToMap()[name]
+	if !ok {
+		return nil, model.NewAppError("SetFeatureFlagOverride", "app.feature_flags.unknown.app_error", nil, "name="+name, http.StatusNotFound)
+	}
+
+	if err := override.IsValid(); err != nil {
+		return nil, err
+	}
+
+	override.UserIds = utils.RemoveDuplicatesFromStringArray(override.UserIds)
+	override.TeamIds = utils.RemoveDuplicatesFromStringArray(override.TeamIds)
+
+	if err := a.saveFeatureFlagOverride(name, override); err != nil {
+		return nil, err
+	}
+
+	return &model.FeatureFlag{Name: name, Configured: configured, Override: override}, nil
+}
+
+// DeleteFeatureFlagOverride removes the override of the feature flag, restoring its configured
+// value.
+func (a *App) DeleteFeatureFlagOverride(name string) (*model.FeatureFlag, *model.AppError) {
+	configured, ok := a.Config().FeatureFlags.ToMap()[name]
+	if !ok {
+		return nil, model.NewAppError("DeleteFeatureFlagOverride", "app.feature_flags.unknown.app_error", nil, "name="+name, http.StatusNotFound)
+	}
+
+	if err := a.saveFeatureFlagOverride(name, nil); err != nil {
+		return nil, err
+	}
+
+	return &model.FeatureFlag{Name: name, Configured: configured}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestFeatureFlagsStateEnabled(t *testing.T) {
+	userId := model.NewId()
+	teamId := model.NewId()
+	noTeams := func(string) []string { return nil }
+	inTeam := func(string) []string { return []string{teamId} }
+
+	t.Run("configured value without override", func(t *testing.T) {
+		state := newFeatureFlagsState(map[string]bool{"On": true, "Off": false}, nil)
+		assert.True(t, state.enabled("On", userId, noTeams))
+		assert.True(t, state.enabled("On", "", noTeams))
+		assert.False(t, state.enabled("Off", userId, noTeams))
+		assert.False(t, state.enabled("Unknown", userId, noTeams))
+	})
+
+	t.Run("override replaces the configured value", func(t *testing.T) {
+		state := newFeatureFlagsState(map[string]bool{"Flag": true}, model.FeatureFlagOverrides{
+			"Flag": {},
+		})
+		assert.False(t, state.enabled("Flag", userId, noTeams))
+	})
+
+	t.Run("allowed users and teams", func(t *testing.T) {
+		state := newFeatureFlagsState(map[string]bool{"Users": false, "Teams": false}, model.FeatureFlagOverrides{
+			"Users": {UserIds: []string{userId}},
+			"Teams": {TeamIds: []string{teamId}},
+		})
+		assert.True(t, state.enabled("Users", userId, noTeams))
+		assert.False(t, state.enabled("Users", model.NewId(), noTeams))
+		assert.True(t, state.enabled("Teams", userId, inTeam))
+		assert.False(t, state.enabled("Teams", userId, noTeams))
+		assert.False(t, state.enabled("Teams", "", inTeam))
+	})
+
+	t.Run("teams are only looked up when needed", func(t *testing.T) {
+		state := newFeatureFlagsState(map[string]bool{"Flag": false}, model.FeatureFlagOverrides{
+			"Flag": {UserIds: []string{userId}},
+		})
+		lookups := 0
+		countLookups := func(string) []string {
+			lookups++
+			return nil
+		}
+		state.enabled("Flag", userId, countLookups)
+		state.enabled("Flag", model.NewId(), countLookups)
+		assert.Zero(t, lookups)
+	})
+
+	t.Run("percentage rollout", func(t *testing.T) {
+		state := newFeatureFlagsState(map[string]bool{"None": false, "All": false, "Half": false}, model.FeatureFlagOverrides{
+			"None": {RolloutPercentage: 0},
+			"All":  {RolloutPercentage: 100},
+			"Half": {RolloutPercentage: 50},
+		})
+
+		enabled := 0
+		for i := 0; i < 1000; i++ {
+			id := model.NewId()
+			assert.False(t, state.enabled("None", id, noTeams))
+			assert.True(t, state.enabled("All", id, noTeams))
+			if state.enabled("Half", id, noTeams) {
+				enabled++
+			}
+			assert.Equal(t, state.enabled("Half", id, noTeams), state.enabled("Half", id, noTeams), "evaluation must be stable")
+		}
+		assert.InDelta(t, 500, enabled, 100)
+	})
+}
+
+func TestFeatureFlagOverrides(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	assert.False(t, th.App.FeatureEnabled("TestFeature", th.BasicUser.Id))
+
+	t.Run("configured value", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FeatureFlags.TestFeature = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FeatureFlags.TestFeature = false })
+
+		assert.True(t, th.App.FeatureEnabled("TestFeature", th.BasicUser.Id))
+		assert.True(t, th.App.GetFeatureFlagsForUser(th.BasicUser.Id)["TestFeature"])
+	})
+
+	t.Run("override for a user", func(t *testing.T) {
+		flag, err := th.App.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{UserIds: []string{th.BasicUser.Id, th.BasicUser.Id}})
+		require.Nil(t, err)
+		assert.Equal(t, []string{th.BasicUser.Id}, flag.Override.UserIds)
+
+		assert.True(t, th.App.FeatureEnabled("TestFeature", th.BasicUser.Id))
+		assert.False(t, th.App.FeatureEnabled("TestFeature", th.BasicUser2.Id))
+
+		flags := th.App.GetFeatureFlags()
+		require.Len(t, flags, 1)
+		assert.Equal(t, flag.Override, flags[0].Override)
+
+		overrides, err := th.App.Srv().getFeatureFlagOverrides()
+		require.Nil(t, err)
+		assert.Equal(t, flag.Override, overrides["TestFeature"])
+	})
+
+	t.Run("override for a team", func(t *testing.T) {
+		_, err := th.App.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{TeamIds: []string{th.BasicTeam.Id}})
+		require.Nil(t, err)
+
+		assert.True(t, th.App.FeatureEnabled("TestFeature", th.BasicUser2.Id))
+		assert.False(t, th.App.FeatureEnabled("TestFeature", th.CreateUser().Id))
+	})
+
+	t.Run("deleting the override restores the configured value", func(t *testing.T) {
+		flag, err := th.App.DeleteFeatureFlagOverride("TestFeature")
+		require.Nil(t, err)
+		assert.Nil(t, flag.Override)
+
+		assert.False(t, th.App.FeatureEnabled("TestFeature", th.BasicUser2.Id))
+	})
+
+	t.Run("override received from another node", func(t *testing.T) {
+		override := &model.FeatureFlagOverride{UserIds: []string{th.BasicUser2.Id}}
+		th.App.clusterFeatureFlagOverridesChangedHandler(&model.ClusterMessage{
+			Props: map[string]string{"name": "TestFeature"},
+			Data:  override.ToJson(),
+		})
+		assert.True(t, th.App.FeatureEnabled("TestFeature", th.BasicUser2.Id))
+
+		th.App.clusterFeatureFlagOverridesChangedHandler(&model.ClusterMessage{
+			Props: map[string]string{"name": "TestFeature"},
+		})
+		assert.False(t, th.App.FeatureEnabled("TestFeature", th.BasicUser2.Id))
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		_, err := th.App.SetFeatureFlagOverride("TestFeature", &model.FeatureFlagOverride{RolloutPercentage: 101})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		_, err := th.App.SetFeatureFlagOverride("Unknown", &model.FeatureFlagOverride{})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+		_, err = th.App.DeleteFeatureFlagOverride("Unknown")
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+}
@@ -395,6 +395,10 @@ func (a *App) UploadFiles(teamId string, channelId string, userId string, files
 		return nil, model.NewAppError("UploadFiles", "api.file.upload_file.incorrect_number_of_files.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if err := a.checkChannelIdAllowsFileAttachments(channelId, userId); err != nil {
+		return nil, err
+	}
+
 	resStruct := &model.FileUploadResponse{
 		FileInfos: []*model.FileInfo{},
 		ClientIds: []string{},
@@ -432,14 +436,51 @@ func (a *App) UploadFiles(teamId string, channelId string, userId string, files
 	return resStruct, nil
 }
 
+// checkChannelAllowsFileAttachments returns an error if files can't be uploaded to the given
+// channel or attached to its posts by the given user. System admins are subject to the same
+// restriction unless the config lets them bypass it.
+func (a *App) checkChannelAllowsFileAttachments(channel *model.Channel, userId string) *model.AppError {
+	if !channel.IsFileAttachmentsDisabled() {
+		return nil
+	}
+
+	if *a.Config().FileSettings.AdminsBypassDisabledAttachments {
+		if user, err := a.GetUser(userId); err == nil && a.RolesGrantPermission(user.GetRoles(), model.PERMISSION_MANAGE_SYSTEM.Id) {
+			return nil
+		}
+	}
+
+	return model.NewAppError("checkChannelAllowsFileAttachments", "app.channel.file_attachments_disabled.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden)
+}
+
+// checkChannelIdAllowsFileAttachments is checkChannelAllowsFileAttachments for upload paths that
+// only have the id of the channel. Missing channels are left to the callers to reject.
+func (a *App) checkChannelIdAllowsFileAttachments(channelId string, userId string) *model.AppError {
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return a.checkChannelAllowsFileAttachments(channel, userId)
+}
+
 // UploadFile uploads a single file in form of a completely constructed byte array for a channel.
 func (a *App) UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError) {
-	_, err := a.GetChannel(channelId)
+	channel, err := a.GetChannel(channelId)
 	if err != nil && channelId != "" {
 		return nil, model.NewAppError("UploadFile", "api.file.upload_file.incorrect_channelId.app_error",
 			map[string]interface{}{"channelId": channelId}, "", http.StatusBadRequest)
 	}
 
+	if channel != nil {
+		if err := a.checkChannelAllowsFileAttachments(channel, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	info, _, appError := a.DoUploadFileExpectModification(time.Now(), "noteam", channelId, "nouser", filename, data)
 	if appError != nil {
 		return nil, appError
@@ -604,6 +645,9 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		return nil, t.newAppError("api.file.upload_file.too_large_detailed.app_error",
 			"", http.StatusRequestEntityTooLarge, "Length", t.ContentLength, "Limit", t.maxFileSize)
 	}
+	if aerr := a.checkChannelIdAllowsFileAttachments(t.ChannelId, t.UserId); aerr != nil {
+		return nil, aerr
+	}
 
 	var aerr *model.AppError
 	if !t.Raw && t.fileinfo.IsImage() {
@@ -1111,6 +1155,30 @@ func (a *App) GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([
 	return a.Srv().Store.FileInfo().GetWithOptions(page, perPage, opt)
 }
 
+// GetPostIdsWithFileExtensions returns the ids of the posts with attachments having one of the given
+// extensions, uploaded since the given time. Extensions are matched case insensitively, with or without
+// the leading period.
+func (a *App) GetPostIdsWithFileExtensions(extensions []string, since int64, page, perPage int) ([]string, *model.AppError) {
+	var normalized []string
+	for _, extension := range extensions {
+		extension = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(extension)), ".")
+		if extension != "" {
+			normalized = append(normalized, extension)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, model.NewAppError("GetPostIdsWithFileExtensions", "api.context.invalid_param.app_error", map[string]interface{}{"Name": "extensions"}, "", http.StatusBadRequest)
+	}
+
+	postIds, err := a.Srv().Store.FileInfo().GetPostsWithFileExtensions(normalized, since, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetPostIdsWithFileExtensions", "app.file_info.get_posts_with_file_extensions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return postIds, nil
+}
+
 func (a *App) GetFile(fileId string) ([]byte, *model.AppError) {
 	info, err := a.GetFileInfo(fileId)
 	if err != nil {
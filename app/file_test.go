@@ -111,6 +111,16 @@ func TestUploadFile(t *testing.T) {
 	value := fmt.Sprintf("%v/teams/noteam/channels/%v/users/nouser/%v/%v",
 		time.Now().Format("20060102"), channelId, info1.Id, filename)
 	assert.Equal(t, value, info1.Path, "Stored file at incorrect path")
+
+	channel := th.CreateChannel(th.BasicTeam)
+	channel.DisableFileAttachments = model.NewBool(true)
+	_, err = th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	info2, err := th.App.UploadFile(data, channel.Id, filename)
+	require.NotNil(t, err, "uploads to a channel with file attachments disabled should fail")
+	require.Nil(t, info2)
+	assert.Equal(t, "app.channel.file_attachments_disabled.app_error", err.Id)
 }
 
 func TestParseOldFilenames(t *testing.T) {
@@ -35,6 +35,15 @@ func (a *App) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
 	return a.Srv().Store.Group().Create(group)
 }
 
+// CreateCustomGroup creates a group whose members are managed through the API instead of being
+// synced from LDAP.
+func (a *App) CreateCustomGroup(group *model.Group) (*model.Group, *model.AppError) {
+	group.Source = model.GroupSourceCustom
+	// Custom groups have no remote counterpart, but the remote id must be unique within a source
+	group.RemoteId = model.NewId()
+	return a.CreateGroup(group)
+}
+
 func (a *App) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
 	updatedGroup, err := a.Srv().Store.Group().Update(group)
 
@@ -47,16 +56,41 @@ func (a *App) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
 	return updatedGroup, err
 }
 
+// DeleteGroup unlinks the group from its teams and channels before deleting it, so that a failure
+// part way leaves a group that can still be found and deleted again.
 func (a *App) DeleteGroup(groupID string) (*model.Group, *model.AppError) {
-	deletedGroup, err := a.Srv().Store.Group().Delete(groupID)
+	group, err := a.Srv().Store.Group().Get(groupID)
+	if err != nil {
+		return nil, err
+	}
 
-	if err == nil {
-		messageWs := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_RECEIVED_GROUP, "", "", "", nil)
-		messageWs.Add("group", deletedGroup.ToJson())
-		a.Publish(messageWs)
+	// LDAP groups keep their links, so that they're back in place when the sync restores the group.
+	if group.IsCustom() {
+		// The channels go first, since unlinking a team also unlinks every channel of the group
+		for _, syncableType := range []model.GroupSyncableType{model.GroupSyncableTypeChannel, model.GroupSyncableTypeTeam} {
+			groupSyncables, err := a.Srv().Store.Group().GetAllGroupSyncablesByGroupId(groupID, syncableType)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, groupSyncable := range groupSyncables {
+				if _, err := a.DeleteGroupSyncable(groupID, groupSyncable.SyncableId, syncableType); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
-	return deletedGroup, err
+	deletedGroup, err := a.Srv().Store.Group().Delete(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	messageWs := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_RECEIVED_GROUP, "", "", "", nil)
+	messageWs.Add("group", deletedGroup.ToJson())
+	a.Publish(messageWs)
+
+	return deletedGroup, nil
 }
 
 func (a *App) GetGroupMemberCount(groupID string) (int64, *model.AppError) {
@@ -88,6 +122,34 @@ func (a *App) DeleteGroupMember(groupID string, userID string) (*model.GroupMemb
 	return a.Srv().Store.Group().DeleteMember(groupID, userID)
 }
 
+// UpsertGroupMembers adds the users to the group, restoring the memberships that were deleted.
+func (a *App) UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	members := make([]*model.GroupMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		member, err := a.UpsertGroupMember(groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// DeleteGroupMembers removes the users from the group.
+func (a *App) DeleteGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	members := make([]*model.GroupMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		member, err := a.DeleteGroupMember(groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
 func (a *App) UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
 	gs, err := a.Srv().Store.Group().GetGroupSyncable(groupSyncable.GroupId, groupSyncable.SyncableId, groupSyncable.Type)
 	if err != nil && err.Id != "store.sql_group.no_rows" {
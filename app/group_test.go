@@ -76,6 +76,24 @@ func TestCreateGroup(t *testing.T) {
 	require.Nil(t, g)
 }
 
+func TestCreateCustomGroup(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	// Several custom groups can be created, even though none of them has a remote id
+	for i := 0; i < 2; i++ {
+		id := model.NewId()
+		g, err := th.App.CreateCustomGroup(&model.Group{
+			DisplayName: "dn_" + id,
+			Name:        model.NewString("name" + id),
+			Source:      model.GroupSourceLdap,
+		})
+		require.Nil(t, err)
+		require.Equal(t, model.GroupSourceCustom, g.Source)
+		require.NotEmpty(t, g.RemoteId)
+	}
+}
+
 func TestUpdateGroup(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -101,6 +119,75 @@ func TestDeleteGroup(t *testing.T) {
 	require.Nil(t, g)
 }
 
+func TestDeleteGroupKeepsLdapSyncables(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	group := th.CreateGroup()
+
+	_, err := th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, th.BasicTeam.Id, false))
+	require.Nil(t, err)
+	_, err = th.App.UpsertGroupSyncable(model.NewGroupChannel(group.Id, th.BasicChannel.Id, false))
+	require.Nil(t, err)
+
+	_, err = th.App.DeleteGroup(group.Id)
+	require.Nil(t, err)
+
+	groupTeams, err := th.App.GetGroupSyncables(group.Id, model.GroupSyncableTypeTeam)
+	require.Nil(t, err)
+	require.Len(t, groupTeams, 1, "an LDAP group should get its links back when the sync restores it")
+
+	groupChannels, err := th.App.GetGroupSyncables(group.Id, model.GroupSyncableTypeChannel)
+	require.Nil(t, err)
+	require.Len(t, groupChannels, 1)
+}
+
+func TestDeleteGroupUnlinksSyncables(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group, err := th.App.CreateCustomGroup(&model.Group{DisplayName: "dn_" + model.NewId()})
+	require.Nil(t, err)
+
+	_, err = th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, th.BasicTeam.Id, false))
+	require.Nil(t, err)
+	_, err = th.App.UpsertGroupSyncable(model.NewGroupChannel(group.Id, th.BasicChannel.Id, false))
+	require.Nil(t, err)
+
+	_, err = th.App.DeleteGroup(group.Id)
+	require.Nil(t, err)
+
+	groupTeams, err := th.App.GetGroupSyncables(group.Id, model.GroupSyncableTypeTeam)
+	require.Nil(t, err)
+	require.Empty(t, groupTeams)
+
+	groupChannels, err := th.App.GetGroupSyncables(group.Id, model.GroupSyncableTypeChannel)
+	require.Nil(t, err)
+	require.Empty(t, groupChannels)
+}
+
+func TestUpsertAndDeleteGroupMembers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	group := th.CreateGroup()
+
+	members, err := th.App.UpsertGroupMembers(group.Id, []string{th.BasicUser.Id, th.BasicUser2.Id})
+	require.Nil(t, err)
+	require.Len(t, members, 2)
+
+	count, err := th.App.GetGroupMemberCount(group.Id)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), count)
+
+	members, err = th.App.DeleteGroupMembers(group.Id, []string{th.BasicUser.Id})
+	require.Nil(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, th.BasicUser.Id, members[0].UserId)
+
+	count, err = th.App.GetGroupMemberCount(group.Id)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), count)
+}
+
 func TestCreateOrRestoreGroupMember(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -266,7 +266,7 @@ func (a *App) importChannel(data *ChannelImportData, dryRun bool) *model.AppErro
 	}
 
 	if channel.Id == "" {
-		if _, err := a.CreateChannel(channel, false); err != nil {
+		if _, err := a.CreateChannelBypassingThrottle(channel, false); err != nil {
 			return err
 		}
 	} else {
@@ -754,7 +754,7 @@ func (a *App) importUserTeams(user *model.User, data *[]UserTeamImportData) *mod
 
 	newMembers := []*model.TeamMember{}
 	if len(newTeamMembers) > 0 {
-		newMembers, err = a.Srv().Store.Team().SaveMultipleMembers(newTeamMembers, *a.Config().TeamSettings.MaxUsersPerTeam)
+		newMembers, err = a.Srv().Store.Team().SaveMultipleMembers(newTeamMembers, a.maxUsersPerTeam())
 		if err != nil {
 			return err
 		}
@@ -966,6 +966,62 @@ func (a *App) importReaction(data *ReactionImportData, post *model.Post, dryRun
 	return nil
 }
 
+// importSavedPosts saves the given post for the users who flagged or saved it, and flags it for the
+// users who saved it. Posts saved again are given the imported label and note.
+func (a *App) importSavedPosts(post *model.Post, flaggedBy *[]string, savedBy *[]SavedPostImportData, users map[string]*model.User) *model.AppError {
+	if flaggedBy != nil {
+		for _, username := range *flaggedBy {
+			_, err := a.Srv().Store.SavedPost().Save(&model.SavedPost{
+				UserId:  users[username].Id,
+				PostId:  post.Id,
+				SavedAt: post.CreateAt,
+			})
+			var cErr *store.ErrConflict
+			if err != nil && !errors.As(err, &cErr) {
+				return model.NewAppError("BulkImport", "app.import.import_post.save_saved_posts.error", nil, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	if savedBy == nil {
+		return nil
+	}
+
+	var preferences model.Preferences
+	for _, data := range *savedBy {
+		savedPost := &model.SavedPost{
+			UserId:  users[*data.User].Id,
+			PostId:  post.Id,
+			SavedAt: *data.SavedAt,
+		}
+		if data.Label != nil {
+			savedPost.Label = *data.Label
+		}
+		if data.Note != nil {
+			savedPost.Note = *data.Note
+		}
+
+		_, err := a.Srv().Store.SavedPost().Save(savedPost)
+		var cErr *store.ErrConflict
+		if errors.As(err, &cErr) {
+			_, err = a.Srv().Store.SavedPost().Update(savedPost)
+		}
+		if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_saved_posts.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		preferences = append(preferences, flaggedPostPreference(savedPost.UserId, post.Id))
+	}
+
+	if len(preferences) > 0 {
+		if err := a.Srv().Store.Preference().Save(&preferences); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_post.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(data []ReplyImportData, post *model.Post, teamId string, dryRun bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1199,6 +1255,11 @@ func (a *App) importMultiplePostLines(lines []LineImportWorkerData, dryRun bool)
 		if line.Post.FlaggedBy != nil {
 			usernames = append(usernames, *line.Post.FlaggedBy...)
 		}
+		if line.Post.SavedBy != nil {
+			for _, savedPost := range *line.Post.SavedBy {
+				usernames = append(usernames, *savedPost.User)
+			}
+		}
 		teamNames[i] = *line.Post.Team
 		postsData[i] = line.Post
 	}
@@ -1325,6 +1386,10 @@ func (a *App) importMultiplePostLines(lines []LineImportWorkerData, dryRun bool)
 			}
 		}
 
+		if err := a.importSavedPosts(postWithData.post, postWithData.postData.FlaggedBy, postWithData.postData.SavedBy, users); err != nil {
+			return postWithData.lineNumber, err
+		}
+
 		if postWithData.postData.Reactions != nil {
 			for _, reaction := range *postWithData.postData.Reactions {
 				reaction := reaction
@@ -1466,6 +1531,11 @@ func (a *App) importMultipleDirectPostLines(lines []LineImportWorkerData, dryRun
 		if line.DirectPost.FlaggedBy != nil {
 			usernames = append(usernames, *line.DirectPost.FlaggedBy...)
 		}
+		if line.DirectPost.SavedBy != nil {
+			for _, savedPost := range *line.DirectPost.SavedBy {
+				usernames = append(usernames, *savedPost.User)
+			}
+		}
 		usernames = append(usernames, *line.DirectPost.ChannelMembers...)
 	}
 
@@ -1601,6 +1671,10 @@ func (a *App) importMultipleDirectPostLines(lines []LineImportWorkerData, dryRun
 			}
 		}
 
+		if err := a.importSavedPosts(postWithData.post, postWithData.directPostData.FlaggedBy, postWithData.directPostData.SavedBy, users); err != nil {
+			return postWithData.lineNumber, err
+		}
+
 		if postWithData.directPostData.Reactions != nil {
 			for _, reaction := range *postWithData.directPostData.Reactions {
 				reaction := reaction
@@ -115,6 +115,13 @@ type ReactionImportData struct {
 	EmojiName *string `json:"emoji_name"`
 }
 
+type SavedPostImportData struct {
+	User    *string `json:"user"`
+	Label   *string `json:"label,omitempty"`
+	Note    *string `json:"note,omitempty"`
+	SavedAt *int64  `json:"saved_at"`
+}
+
 type ReplyImportData struct {
 	User *string `json:"user"`
 
@@ -136,6 +143,7 @@ type PostImportData struct {
 	CreateAt *int64                 `json:"create_at"`
 
 	FlaggedBy   *[]string               `json:"flagged_by,omitempty"`
+	SavedBy     *[]SavedPostImportData  `json:"saved_by,omitempty"`
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Replies     *[]ReplyImportData      `json:"replies,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
@@ -157,6 +165,7 @@ type DirectPostImportData struct {
 	CreateAt *int64                 `json:"create_at"`
 
 	FlaggedBy   *[]string               `json:"flagged_by"`
+	SavedBy     *[]SavedPostImportData  `json:"saved_by,omitempty"`
 	Reactions   *[]ReactionImportData   `json:"reactions"`
 	Replies     *[]ReplyImportData      `json:"replies"`
 	Attachments *[]AttachmentImportData `json:"attachments"`
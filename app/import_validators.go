@@ -396,6 +396,28 @@ func validateReactionImportData(data *ReactionImportData, parentCreateAt int64)
 	return nil
 }
 
+func validateSavedPostImportData(data *SavedPostImportData) *model.AppError {
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_saved_post_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.SavedAt == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_saved_post_import_data.saved_at_missing.error", nil, "", http.StatusBadRequest)
+	} else if *data.SavedAt == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_saved_post_import_data.saved_at_zero.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Label != nil && utf8.RuneCountInString(*data.Label) > model.SAVED_POST_LABEL_MAX_RUNES {
+		return model.NewAppError("BulkImport", "app.import.validate_saved_post_import_data.label_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Note != nil && utf8.RuneCountInString(*data.Note) > model.SAVED_POST_NOTE_MAX_RUNES {
+		return model.NewAppError("BulkImport", "app.import.validate_saved_post_import_data.note_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func validateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPostSize int) *model.AppError {
 	if data.User == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_reply_import_data.user_missing.error", nil, "", http.StatusBadRequest)
@@ -461,6 +483,15 @@ func validatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.props_too_large.error", nil, "", http.StatusBadRequest)
 	}
 
+	if data.SavedBy != nil {
+		for _, savedPost := range *data.SavedBy {
+			savedPost := savedPost
+			if err := validateSavedPostImportData(&savedPost); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -543,6 +574,26 @@ func validateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.SavedBy != nil {
+		for _, savedPost := range *data.SavedBy {
+			savedPost := savedPost
+			if err := validateSavedPostImportData(&savedPost); err != nil {
+				return err
+			}
+
+			found := false
+			for _, member := range *data.ChannelMembers {
+				if *savedPost.User == member {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return model.NewAppError("BulkImport", "app.import.validate_direct_post_import_data.unknown_flagger.error", map[string]interface{}{"Username": *savedPost.User}, "", http.StatusBadRequest)
+			}
+		}
+	}
+
 	if data.Reactions != nil {
 		for _, reaction := range *data.Reactions {
 			reaction := reaction
@@ -831,6 +831,60 @@ func TestImportValidateReactionImportData(t *testing.T) {
 	require.NotNil(t, err, "Should have failed due parent with newer create-at value.")
 }
 
+func TestImportValidateSavedPostImportData(t *testing.T) {
+	// Test with minimum required valid properties.
+	data := SavedPostImportData{
+		User:    ptrStr("username"),
+		SavedAt: ptrInt64(model.GetMillis()),
+	}
+	err := validateSavedPostImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with all valid properties.
+	data.Label = ptrStr("Follow up")
+	data.Note = ptrStr("Ask about the release date")
+	err = validateSavedPostImportData(&data)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with missing required properties.
+	data = SavedPostImportData{
+		SavedAt: ptrInt64(model.GetMillis()),
+	}
+	err = validateSavedPostImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	data = SavedPostImportData{
+		User: ptrStr("username"),
+	}
+	err = validateSavedPostImportData(&data)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	// Test with invalid SavedAt.
+	data = SavedPostImportData{
+		User:    ptrStr("username"),
+		SavedAt: ptrInt64(0),
+	}
+	err = validateSavedPostImportData(&data)
+	require.NotNil(t, err, "Should have failed due to 0 saved at.")
+
+	// Test with too long label and note.
+	data = SavedPostImportData{
+		User:    ptrStr("username"),
+		SavedAt: ptrInt64(model.GetMillis()),
+		Label:   ptrStr(strings.Repeat("a", model.SAVED_POST_LABEL_MAX_RUNES+1)),
+	}
+	err = validateSavedPostImportData(&data)
+	require.NotNil(t, err, "Should have failed due to too long label.")
+
+	data = SavedPostImportData{
+		User:    ptrStr("username"),
+		SavedAt: ptrInt64(model.GetMillis()),
+		Note:    ptrStr(strings.Repeat("a", model.SAVED_POST_NOTE_MAX_RUNES+1)),
+	}
+	err = validateSavedPostImportData(&data)
+	require.NotNil(t, err, "Should have failed due to too long note.")
+}
+
 func TestImportValidateReplyImportData(t *testing.T) {
 	// Test with minimum required valid properties.
 	parentCreateAt := model.GetMillis() - 100
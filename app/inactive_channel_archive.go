@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+const (
+	inactiveChannelsBatchSize = 100
+	dayInMilliseconds         = 24 * 60 * 60 * 1000
+)
+
+// ArchiveInactiveChannelsForTeam goes through the public and private channels of the team that have
+// had no posts for the configured number of days. Channels are first warned with a system message and
+// archived once the warning period has elapsed without new posts. The display names of the archived
+// channels are sent to the team admins and returned.
+func (a *App) ArchiveInactiveChannelsForTeam(team *model.Team) ([]string, *model.AppError) {
+	archiveDays := team.GetInactiveChannelArchiveDays(int64(*a.Config().TeamSettings.InactiveChannelArchiveDays))
+	if archiveDays <= 0 {
+		return nil, nil
+	}
+
+	// A team may override the archive period with one shorter than the server-wide warning period.
+	warningDays := int64(*a.Config().TeamSettings.InactiveChannelArchiveWarningDays)
+	if warningDays >= archiveDays {
+		warningDays = archiveDays - 1
+	}
+
+	botUserId, err := a.EnsureSystemBot()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultChannels := make(map[string]bool)
+	for _, name := range a.DefaultChannelNames() {
+		defaultChannels[name] = true
+	}
+
+	now := model.GetMillis()
+	warnBefore := now - (archiveDays-warningDays)*dayInMilliseconds
+	archiveBefore := now - warningDays*dayInMilliseconds
+	if warningDays == 0 {
+		archiveBefore = warnBefore
+	}
+
+	var archived []string
+	archive := func(channel *model.Channel) {
+		if err := a.DeleteChannel(channel, botUserId); err != nil {
+			mlog.Error("Failed to archive inactive channel", mlog.String("channel_id", channel.Id), mlog.Err(err))
+			return
+		}
+		archived = append(archived, channel.DisplayName)
+	}
+
+	// The warning counts as a post, so a warned channel only shows up once the warning period has
+	// elapsed without anyone posting.
+	if err := a.forEachInactiveChannel(team.Id, archiveBefore, true, defaultChannels, archive); err != nil {
+		return archived, err
+	}
+
+	if warningDays > 0 {
+		err = a.forEachInactiveChannel(team.Id, warnBefore, false, defaultChannels, func(channel *model.Channel) {
+			if err := a.postInactiveChannelWarning(channel, botUserId, warningDays); err != nil {
+				mlog.Error("Failed to warn inactive channel", mlog.String("channel_id", channel.Id), mlog.Err(err))
+			}
+		})
+	} else {
+		err = a.forEachInactiveChannel(team.Id, archiveBefore, false, defaultChannels, archive)
+	}
+	if err != nil {
+		return archived, err
+	}
+
+	if len(archived) > 0 {
+		if err := a.sendInactiveChannelArchiveReport(team, archived); err != nil {
+			return archived, err
+		}
+	}
+
+	return archived, nil
+}
+
+// forEachInactiveChannel calls f with each public and private channel of the team, except the default
+// ones, that has had no posts since the given time and whose last post is, or isn't, the inactivity warning.
+func (a *App) forEachInactiveChannel(teamId string, inactiveSince int64, warned bool, defaultChannels map[string]bool, f func(*model.Channel)) *model.AppError {
+	afterId := ""
+	for {
+		channels, err := a.Srv().Store.Channel().GetInactiveChannelsForTeam(teamId, inactiveSince, warned, afterId, inactiveChannelsBatchSize)
+		if err != nil {
+			return model.NewAppError("ArchiveInactiveChannelsForTeam", "app.channel.archive_inactive.get_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, channel := range channels {
+			afterId = channel.Id
+
+			if !defaultChannels[channel.Name] {
+				f(channel)
+			}
+		}
+
+		if len(channels) < inactiveChannelsBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) postInactiveChannelWarning(channel *model.Channel, botUserId string, warningDays int64) *model.AppError {
+	post := &model.Post{
+		ChannelId: channel.Id,
+		Message:   utils.T("app.channel.archive_inactive.warning", map[string]interface{}{"Days": warningDays}),
+		Type:      model.POST_INACTIVE_WARNING,
+		UserId:    botUserId,
+	}
+
+	if _, err := a.CreatePost(post, channel, false, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) sendInactiveChannelArchiveReport(team *model.Team, archived []string) *model.AppError {
+	var admins []*model.TeamMember
+	for page := 0; ; page++ {
+		members, err := a.GetTeamMembers(team.Id, page*inactiveChannelsBatchSize, inactiveChannelsBatchSize, &model.TeamMembersGetOptions{ExcludeDeletedUsers: true})
+		if err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			if member.SchemeAdmin {
+				admins = append(admins, member)
+			}
+		}
+
+		if len(members) < inactiveChannelsBatchSize {
+			break
+		}
+	}
+
+	channelList := "- " + strings.Join(archived, "\n- ")
+	for _, admin := range admins {
+		user, err := a.GetUser(admin.UserId)
+		if err != nil {
+			mlog.Error("Failed to get team admin for inactive channel report", mlog.String("user_id", admin.UserId), mlog.Err(err))
+			continue
+		}
+
+		T := utils.GetUserTranslations(user.Locale)
+		message := fmt.Sprintf("%s\n%s", T("app.channel.archive_inactive.report", map[string]interface{}{"TeamName": team.DisplayName, "Count": len(archived)}), channelList)
+
+		if _, err := a.SendSystemBotDM(user.Id, message, nil); err != nil {
+			mlog.Error("Failed to send inactive channel report", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestArchiveInactiveChannelsForTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.InactiveChannelArchiveDays = 90
+		*cfg.TeamSettings.InactiveChannelArchiveWarningDays = 7
+	})
+
+	_, err := th.App.UpdateTeamMemberSchemeRoles(th.BasicTeam.Id, th.BasicUser.Id, false, true, true)
+	require.Nil(t, err)
+
+	makeInactive := func(channel *model.Channel, days int64) {
+		channel, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		channel.CreateAt = model.GetMillis() - days*dayInMilliseconds
+		channel.LastPostAt = channel.CreateAt
+		_, nErr := th.App.Srv().Store.Channel().Update(channel)
+		require.Nil(t, nErr)
+	}
+
+	isWarned := func(channel *model.Channel) bool {
+		posts, err := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: channel.Id, Page: 0, PerPage: 1})
+		require.Nil(t, err)
+		return len(posts.Order) == 1 && posts.Posts[posts.Order[0]].Type == model.POST_INACTIVE_WARNING
+	}
+
+	t.Run("should warn and later archive an inactive channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		makeInactive(channel, 85)
+
+		archived, err := th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+
+		assert.True(t, isWarned(channel))
+
+		// Nothing happens while the warning period hasn't elapsed.
+		archived, err = th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+
+		makeInactive(channel, 8)
+
+		archived, err = th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Equal(t, []string{channel.DisplayName}, archived)
+
+		channel, err = th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, channel.DeleteAt)
+
+		botUserId, err := th.App.EnsureSystemBot()
+		require.Nil(t, err)
+		dm, err := th.App.GetOrCreateDirectChannel(botUserId, th.BasicUser.Id)
+		require.Nil(t, err)
+		posts, err := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: dm.Id, Page: 0, PerPage: 10})
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+		assert.Contains(t, posts.Posts[posts.Order[0]].Message, channel.DisplayName)
+	})
+
+	t.Run("should not warn a channel that has been posted in", func(t *testing.T) {
+		channel := th.CreateChannel(th.BasicTeam)
+		makeInactive(channel, 85)
+		th.CreatePost(channel)
+
+		archived, err := th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+
+		assert.False(t, isWarned(channel))
+	})
+
+	t.Run("should skip default and excluded channels", func(t *testing.T) {
+		offTopic, err := th.App.GetChannelByName("off-topic", th.BasicTeam.Id, false)
+		require.Nil(t, err)
+		makeInactive(offTopic, 100)
+
+		excluded := th.CreateChannel(th.BasicTeam)
+		excluded, err = th.App.PatchChannel(excluded, &model.ChannelPatch{ExcludeFromAutoArchive: model.NewBool(true)}, th.BasicUser.Id)
+		require.Nil(t, err)
+		makeInactive(excluded, 100)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.InactiveChannelArchiveWarningDays = 0
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.InactiveChannelArchiveWarningDays = 7
+		})
+
+		archived, err := th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+	})
+
+	t.Run("should archive without warning when the warning period is zero", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.InactiveChannelArchiveWarningDays = 0
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.InactiveChannelArchiveWarningDays = 7
+		})
+
+		channel := th.CreatePrivateChannel(th.BasicTeam)
+		makeInactive(channel, 91)
+
+		archived, err := th.App.ArchiveInactiveChannelsForTeam(th.BasicTeam)
+		require.Nil(t, err)
+		assert.Equal(t, []string{channel.DisplayName}, archived)
+	})
+
+	t.Run("should use the team override", func(t *testing.T) {
+		team := th.CreateTeam()
+		team.InactiveChannelArchiveDays = model.NewInt64(0)
+		team, err := th.App.UpdateTeam(team)
+		require.Nil(t, err)
+
+		channel := th.CreateChannel(team)
+		makeInactive(channel, 365)
+
+		archived, err := th.App.ArchiveInactiveChannelsForTeam(team)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+
+		team.InactiveChannelArchiveDays = model.NewInt64(30)
+		team, err = th.App.UpdateTeam(team)
+		require.Nil(t, err)
+
+		archived, err = th.App.ArchiveInactiveChannelsForTeam(team)
+		require.Nil(t, err)
+		assert.Empty(t, archived)
+
+		assert.True(t, isWarned(channel))
+	})
+}
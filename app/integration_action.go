@@ -156,11 +156,7 @@ func (a *App) DoPostActionWithCookie(postId, actionId, userId, selectedOption st
 		originalIsPinned = post.IsPinned
 		originalHasReactions = post.HasReactions
 
-		if post.RootId == "" {
-			rootPostId = post.Id
-		} else {
-			rootPostId = post.RootId
-		}
+		rootPostId = post.GetRootId()
 
 		upstreamURL = action.Integration.URL
 	}
@@ -196,6 +192,8 @@ func (a *App) DoPostActionWithCookie(postId, actionId, userId, selectedOption st
 		upstreamRequest.TeamName = team.Name
 	}
 
+	upstreamRequest.Context = a.filterIntegrationContext(upstreamRequest.Context)
+
 	if upstreamRequest.Type == model.POST_ACTION_TYPE_SELECT {
 		if selectedOption != "" {
 			if upstreamRequest.Context == nil {
@@ -265,6 +263,25 @@ func (a *App) DoPostActionWithCookie(postId, actionId, userId, selectedOption st
 	return clientTriggerId, nil
 }
 
+// filterIntegrationContext returns a copy of the given post action context with only the keys allowed by
+// ServiceSettings.IntegrationContextAllowedKeys, so arbitrary context set by plugins or webhooks can't be
+// forwarded to integrations. Every key is allowed when the setting is nil.
+func (a *App) filterIntegrationContext(context map[string]interface{}) map[string]interface{} {
+	allowedKeys := a.Config().ServiceSettings.IntegrationContextAllowedKeys
+	if allowedKeys == nil || context == nil {
+		return context
+	}
+
+	filtered := make(map[string]interface{}, len(context))
+	for _, key := range allowedKeys {
+		if value, ok := context[key]; ok {
+			filtered[key] = value
+		}
+	}
+
+	return filtered
+}
+
 // Perform an HTTP POST request to an integration's action endpoint.
 // Caller must consume and close returned http.Response as necessary.
 // For internal requests, requests are routed directly to a plugin ServerHTTP hook
@@ -431,6 +431,81 @@ func TestPostActionProps(t *testing.T) {
 	assert.Equal(t, false, newPost.GetProp("from_webhook"))
 }
 
+func TestPostActionContextAllowedKeys(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	var context map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := model.PostActionIntegrationRequestFromJson(r.Body)
+		require.NotNil(t, request)
+		context = request.Context
+
+		fmt.Fprintf(w, `{}`)
+	}))
+	defer ts.Close()
+
+	interactivePost := model.Post{
+		Message:   "Interactive post",
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Props: model.StringInterface{
+			"attachments": []*model.SlackAttachment{
+				{
+					Text: "hello",
+					Actions: []*model.PostAction{
+						{
+							Integration: &model.PostActionIntegration{
+								Context: model.StringInterface{
+									"s":   "foo",
+									"url": "http://169.254.169.254/",
+								},
+								URL: ts.URL,
+							},
+							Name: "action",
+							Type: model.POST_ACTION_TYPE_SELECT,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	post, err := th.App.CreatePostAsUser(&interactivePost, "", true)
+	require.Nil(t, err)
+	attachments, ok := post.GetProp("attachments").([]*model.SlackAttachment)
+	require.True(t, ok)
+	actionId := attachments[0].Actions[0].Id
+
+	t.Run("all keys are forwarded by default", func(t *testing.T) {
+		_, err = th.App.DoPostAction(post.Id, actionId, th.BasicUser.Id, "")
+		require.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"s": "foo", "url": "http://169.254.169.254/"}, context)
+	})
+
+	t.Run("only allowed keys are forwarded", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.IntegrationContextAllowedKeys = []string{"s"} })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.IntegrationContextAllowedKeys = nil })
+
+		_, err = th.App.DoPostAction(post.Id, actionId, th.BasicUser.Id, "option")
+		require.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"s": "foo", "selected_option": "option"}, context)
+	})
+
+	t.Run("no keys are forwarded with an empty allowlist", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.IntegrationContextAllowedKeys = []string{} })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.IntegrationContextAllowedKeys = nil })
+
+		_, err = th.App.DoPostAction(post.Id, actionId, th.BasicUser.Id, "")
+		require.Nil(t, err)
+		assert.Empty(t, context)
+	})
+}
+
 func TestSubmitInteractiveDialog(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
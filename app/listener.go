@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+// limitedListener is a net.Listener that closes the new connections from an IP address that already
+// has the maximum number of open connections, and reports the open and rejected connections to the
+// metrics. The IP address is the remote address of the connection, so every client behind a proxy
+// shares the proxy's limit.
+type limitedListener struct {
+	net.Listener
+	maxConnsPerIP int
+	metrics       einterfaces.MetricsInterface
+
+	mutex sync.Mutex
+	conns map[string]int
+}
+
+// newLimitedListener wraps the listener. A maxConnsPerIP of 0 only reports the connections.
+func newLimitedListener(listener net.Listener, maxConnsPerIP int, metrics einterfaces.MetricsInterface) *limitedListener {
+	return &limitedListener{
+		Listener:      listener,
+		maxConnsPerIP: maxConnsPerIP,
+		metrics:       metrics,
+		conns:         make(map[string]int),
+	}
+}
+
+func (l *limitedListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		ip := conn.RemoteAddr().String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+
+		if !l.acquire(ip) {
+			mlog.Debug("Rejected connection over the limit of connections per IP address", mlog.String("ip", ip), mlog.Int("max_conns_per_ip", l.maxConnsPerIP))
+			if l.metrics != nil {
+				l.metrics.IncrementHttpRejectedConnections()
+			}
+			conn.Close()
+			continue
+		}
+
+		if l.metrics != nil {
+			l.metrics.IncrementHttpActiveConnections()
+		}
+
+		return &limitedConn{Conn: conn, listener: l, ip: ip}, nil
+	}
+}
+
+func (l *limitedListener) acquire(ip string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.maxConnsPerIP > 0 && l.conns[ip] >= l.maxConnsPerIP {
+		return false
+	}
+	l.conns[ip]++
+
+	return true
+}
+
+func (l *limitedListener) release(ip string) {
+	l.mutex.Lock()
+	if l.conns[ip] <= 1 {
+		delete(l.conns, ip)
+	} else {
+		l.conns[ip]--
+	}
+	l.mutex.Unlock()
+
+	if l.metrics != nil {
+		l.metrics.DecrementHttpActiveConnections()
+	}
+}
+
+// limitedConn gives its slot back to the listener when closed. Hijacked connections, such as the
+// websockets, keep their slot until they are closed too.
+type limitedConn struct {
+	net.Conn
+	listener *limitedListener
+	ip       string
+	once     sync.Once
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(func() {
+		c.listener.release(c.ip)
+	})
+
+	return err
+}
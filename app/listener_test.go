@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces/mocks"
+)
+
+func TestLimitedListener(t *testing.T) {
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("IncrementHttpActiveConnections").Return()
+	metrics.On("DecrementHttpActiveConnections").Return()
+	metrics.On("IncrementHttpRejectedConnections").Return()
+
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listener := newLimitedListener(inner, 2, metrics)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				close(accepted)
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", inner.Addr().String())
+		require.NoError(t, err)
+		return conn
+	}
+
+	isClosed := func(conn net.Conn) bool {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err := conn.Read(make([]byte, 1))
+		return err != nil && !isTimeout(err)
+	}
+
+	client1 := dial()
+	defer client1.Close()
+	server1 := <-accepted
+	client2 := dial()
+	defer client2.Close()
+	server2 := <-accepted
+
+	rejected := dial()
+	defer rejected.Close()
+	assert.True(t, isClosed(rejected), "the connection over the limit should be closed")
+	metrics.AssertNumberOfCalls(t, "IncrementHttpRejectedConnections", 1)
+
+	require.NoError(t, server1.Close())
+	server1.Close()
+	metrics.AssertNumberOfCalls(t, "DecrementHttpActiveConnections", 1)
+
+	client3 := dial()
+	defer client3.Close()
+	server3 := <-accepted
+	defer server3.Close()
+	defer server2.Close()
+
+	metrics.AssertNumberOfCalls(t, "IncrementHttpActiveConnections", 3)
+	metrics.AssertNumberOfCalls(t, "IncrementHttpRejectedConnections", 1)
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
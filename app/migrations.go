@@ -15,6 +15,8 @@ import (
 const ADVANCED_PERMISSIONS_MIGRATION_KEY = "AdvancedPermissionsMigrationComplete"
 const EMOJIS_PERMISSIONS_MIGRATION_KEY = "EmojisPermissionsMigrationComplete"
 const GUEST_ROLES_CREATION_MIGRATION_KEY = "GuestRolesCreationMigrationComplete"
+const SAVED_POSTS_MIGRATION_KEY = "SavedPostsMigrationComplete"
+const SAVED_POSTS_MIGRATION_BATCH_SIZE = 1000
 
 // This function migrates the default built in roles from code/config to the database.
 func (a *App) DoAdvancedPermissionsMigration() {
@@ -241,10 +243,47 @@ func (a *App) DoGuestRolesCreationMigration() {
 	}
 }
 
+// DoSavedPostsMigration saves the posts flagged before saved posts existed, so that they show up
+// in the saved posts of their users.
+func (a *App) DoSavedPostsMigration() {
+	// If the migration is already marked as completed, don't do it again.
+	if _, err := a.Srv().Store.System().GetByName(SAVED_POSTS_MIGRATION_KEY); err == nil {
+		return
+	}
+
+	// The flagged posts are migrated in batches, to keep each statement short on large installations.
+	var total int64
+	userId, postId := "", ""
+	for {
+		migrated, lastUserId, lastPostId, err := a.Srv().Store.SavedPost().MigrateFlaggedPosts(userId, postId, SAVED_POSTS_MIGRATION_BATCH_SIZE)
+		if err != nil {
+			mlog.Critical("Failed to migrate flagged posts to saved posts.", mlog.Err(err))
+			return
+		}
+		if lastUserId == "" {
+			break
+		}
+
+		total += migrated
+		userId, postId = lastUserId, lastPostId
+	}
+	mlog.Info("Migrated flagged posts to saved posts.", mlog.Int64("count", total))
+
+	system := model.System{
+		Name:  SAVED_POSTS_MIGRATION_KEY,
+		Value: "true",
+	}
+
+	if err := a.Srv().Store.System().Save(&system); err != nil {
+		mlog.Critical("Failed to mark saved posts migration as completed.", mlog.Err(err))
+	}
+}
+
 func (a *App) DoAppMigrations() {
 	a.DoAdvancedPermissionsMigration()
 	a.DoEmojisPermissionsMigration()
 	a.DoGuestRolesCreationMigration()
+	a.DoSavedPostsMigration()
 	// This migration always must be the last, because can be based on previous
 	// migrations. For example, it needs the guest roles migration.
 	a.DoPermissionsMigrations()
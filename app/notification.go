@@ -190,7 +190,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 			}
 
 			if a.userAllowsEmail(profileMap[id], channelMemberNotifyPropsMap[id], post) {
-				a.sendNotificationEmail(notification, profileMap[id], team)
+				a.sendNotificationEmail(notification, profileMap[id], team, mentions.Mentions[id])
 			}
 		}
 	}
@@ -270,9 +270,11 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				status = &model.Status{UserId: id, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
 
-			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) {
-				mentionType := mentions.Mentions[id]
+			mentionType := mentions.Mentions[id]
 
+			// Users who muted the channel are only notified when they're mentioned directly.
+			if !isMutedWithoutDirectMention(channelMemberNotifyPropsMap[id], mentionType) &&
+				ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) {
 				replyToThreadType := ""
 				if mentionType == ThreadMention {
 					replyToThreadType = model.COMMENTS_NOTIFY_ANY
@@ -363,14 +365,33 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		}
 	}
 
-	if len(mentionedUsersList) != 0 {
-		message.Add("mentions", model.ArrayToJson(mentionedUsersList))
+	// Mentions in muted direct and group channels are still counted on the channel, but aren't
+	// sent to clients so that they don't light up the unread indicators.
+	websocketMentions := mentionedUsersList
+	if channel.IsGroupOrDirect() {
+		websocketMentions = make([]string, 0, len(mentionedUsersList))
+		for _, id := range mentionedUsersList {
+			if channelMemberNotifyPropsMap[id][model.MARK_UNREAD_NOTIFY_PROP] != model.CHANNEL_MARK_UNREAD_MENTION {
+				websocketMentions = append(websocketMentions, id)
+			}
+		}
+	}
+
+	if len(websocketMentions) != 0 {
+		message.Add("mentions", model.ArrayToJson(websocketMentions))
 	}
 
 	a.Publish(message)
 	return mentionedUsersList, nil
 }
 
+// isMutedWithoutDirectMention returns whether the user muted the channel of a post that doesn't
+// mention them directly, in which case they aren't notified of it. Mentions of the whole channel,
+// of a group or in a thread aren't direct mentions.
+func isMutedWithoutDirectMention(channelMemberNotifyProps model.StringMap, mentionType MentionType) bool {
+	return channelMemberNotifyProps[model.MARK_UNREAD_NOTIFY_PROP] == model.CHANNEL_MARK_UNREAD_MENTION && mentionType != KeywordMention
+}
+
 func (a *App) userAllowsEmail(user *model.User, channelMemberNotificationProps model.StringMap, post *model.Post) bool {
 	userAllowsEmails := user.NotifyProps[model.EMAIL_NOTIFY_PROP] != "false"
 	if channelEmail, ok := channelMemberNotificationProps[model.EMAIL_NOTIFY_PROP]; ok {
@@ -18,13 +18,26 @@ import (
 	"github.com/mattermost/mattermost-server/v5/utils"
 )
 
-func (a *App) sendNotificationEmail(notification *PostNotification, user *model.User, team *model.Team) *model.AppError {
+const (
+	SENT_NOTIFICATION_EMAILS_CACHE_SIZE = 25000
+	SENT_NOTIFICATION_EMAILS_CACHE_TTL  = 5 * time.Minute
+)
+
+func (a *App) sendNotificationEmail(notification *PostNotification, user *model.User, team *model.Team, mentionType MentionType) *model.AppError {
 	channel := notification.Channel
 	post := notification.Post
 
+	mentionType = notificationEmailReason(post, user, mentionType)
+
+	if !a.Srv().claimNotificationEmail(user.Id, post.Id) {
+		mlog.Debug("Skipped sending a duplicate notification email", mlog.String("user_id", user.Id), mlog.String("post_id", post.Id))
+		return nil
+	}
+
 	if channel.IsGroupOrDirect() {
 		teams, err := a.Srv().Store.Team().GetTeamsByUserId(user.Id)
 		if err != nil {
+			a.Srv().releaseNotificationEmail(user.Id, post.Id)
 			return err
 		}
 
@@ -65,7 +78,7 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 		// fall back to sending a single email if we can't batch it for some reason
 	}
 
-	translateFunc := utils.GetUserTranslations(user.Locale)
+	translateFunc := a.Srv().getRecipientTranslations(user)
 
 	var useMilitaryTime bool
 	if data, err := a.Srv().Store.Preference().Get(user.Id, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, model.PREFERENCE_NAME_USE_MILITARY_TIME); err != nil {
@@ -89,18 +102,31 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 		subjectText = getDirectMessageNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, senderName, useMilitaryTime)
 	} else if channel.Type == model.CHANNEL_GROUP {
 		subjectText = getGroupMessageNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, channelName, emailNotificationContentsType, useMilitaryTime)
-	} else if *a.Config().EmailSettings.UseChannelInEmailNotifications {
-		subjectText = getNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, team.DisplayName+" ("+channelName+")", useMilitaryTime)
 	} else {
-		subjectText = getNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, team.DisplayName, useMilitaryTime)
+		teamName := team.DisplayName
+		if *a.Config().EmailSettings.UseChannelInEmailNotifications {
+			teamName += " (" + channelName + ")"
+		}
+
+		if mentionType == ThreadMention || mentionType == CommentMention {
+			subjectText = getThreadReplyNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, teamName, useMilitaryTime)
+		} else {
+			subjectText = getNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, teamName, useMilitaryTime)
+		}
 	}
 
 	landingURL := a.GetSiteURL() + "/landing#/" + team.Name
 	var bodyText = a.getNotificationEmailBody(user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc)
 
+	fromName := *a.Config().EmailSettings.FeedbackName
+	if *a.Config().EmailSettings.SendEmailNotificationsFromUserDisplayName {
+		fromName = translateFunc("app.notification.from_user_display_name", map[string]interface{}{"DisplayName": senderName})
+	}
+
 	a.Srv().Go(func() {
-		if err := a.Srv().EmailService.sendNotificationMail(user.Email, html.UnescapeString(subjectText), bodyText); err != nil {
+		if err := a.Srv().EmailService.sendNotificationMailFromName(user.Email, fromName, html.UnescapeString(subjectText), bodyText); err != nil {
 			mlog.Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(err))
+			a.Srv().releaseNotificationEmail(user.Id, post.Id)
 		}
 	})
 
@@ -141,6 +167,22 @@ func getNotificationEmailSubject(user *model.User, post *model.Post, translateFu
 	return translateFunc("app.notification.subject.notification.full", subjectParameters)
 }
 
+/**
+ * Computes the subject line for email messages about replies in threads the user follows, by
+ * having started or commented on them, without mentioning the user
+ */
+func getThreadReplyNotificationEmailSubject(user *model.User, post *model.Post, translateFunc i18n.TranslateFunc, siteName string, teamName string, useMilitaryTime bool) string {
+	t := getFormattedPostTime(user, post, useMilitaryTime, translateFunc)
+	var subjectParameters = map[string]interface{}{
+		"SiteName": siteName,
+		"TeamName": teamName,
+		"Month":    t.Month,
+		"Day":      t.Day,
+		"Year":     t.Year,
+	}
+	return translateFunc("app.notification.subject.thread_reply.full", subjectParameters)
+}
+
 /**
  * Computes the subject line for group email messages
  */
@@ -313,6 +355,10 @@ func (a *App) generateHyperlinkForChannels(postMessage, teamName, teamURL string
 }
 
 func (s *Server) GetMessageForNotification(post *model.Post, translateFunc i18n.TranslateFunc) string {
+	if post.IsSystemMessage() {
+		return FormatSystemMessage(post, translateFunc)
+	}
+
 	if len(strings.TrimSpace(post.Message)) != 0 || len(post.FileIds) == 0 {
 		return post.Message
 	}
@@ -347,3 +393,50 @@ func (s *Server) GetMessageForNotification(post *model.Post, translateFunc i18n.
 func (a *App) GetMessageForNotification(post *model.Post, translateFunc i18n.TranslateFunc) string {
 	return a.Srv().GetMessageForNotification(post, translateFunc)
 }
+
+// notificationEmailReason returns the richest reason the post gives for emailing the user about it,
+// so that every trigger of an email about the post agrees on it. A reply in a thread the user follows
+// that also mentions them is emailed as a mention, whichever trigger claims the email first.
+func notificationEmailReason(post *model.Post, user *model.User, mentionType MentionType) MentionType {
+	if mentionType != ThreadMention && mentionType != CommentMention {
+		return mentionType
+	}
+
+	keywords := addMentionKeywordsForUser(map[string][]string{}, user, nil, nil, false)
+	if _, ok := getExplicitMentions(post, keywords, nil).Mentions[user.Id]; ok {
+		return KeywordMention
+	}
+
+	return mentionType
+}
+
+// claimNotificationEmail records that the user is being sent an email about the post, and returns
+// false when they already got one in the last few minutes. A post can notify a user more than once,
+// e.g. as a mention and as a reply in a thread they follow, and this makes sure that only results in
+// one email. The records are kept in a cache of the cache provider, so they're shared by the nodes of
+// a cluster when the cache provider is.
+func (s *Server) claimNotificationEmail(userId, postId string) bool {
+	s.sentNotificationEmailsMutex.Lock()
+	defer s.sentNotificationEmailsMutex.Unlock()
+
+	key := userId + postId
+
+	var claimed bool
+	if err := s.sentNotificationEmailsCache.Get(key, &claimed); err == nil {
+		return false
+	}
+
+	if err := s.sentNotificationEmailsCache.SetWithExpiry(key, true, SENT_NOTIFICATION_EMAILS_CACHE_TTL); err != nil {
+		mlog.Warn("Failed to record a sent notification email", mlog.String("user_id", userId), mlog.String("post_id", postId), mlog.Err(err))
+	}
+
+	return true
+}
+
+// releaseNotificationEmail removes the record of an email that couldn't be sent, so that a later
+// trigger can send it.
+func (s *Server) releaseNotificationEmail(userId, postId string) {
+	if err := s.sentNotificationEmailsCache.Remove(userId + postId); err != nil {
+		mlog.Warn("Failed to remove the record of a notification email", mlog.String("user_id", userId), mlog.String("post_id", postId), mlog.Err(err))
+	}
+}
@@ -65,6 +65,86 @@ func TestGetNotificationEmailSubject(t *testing.T) {
 	require.Regexp(t, regexp.MustCompile("^"+regexp.QuoteMeta(expectedPrefix)), subject, fmt.Sprintf("Expected subject line prefix '%s', got %s", expectedPrefix, subject))
 }
 
+func TestGetThreadReplyNotificationEmailSubject(t *testing.T) {
+	expectedPrefix := "[http://localhost:8065] Reply in a thread you follow in team on"
+	user := &model.User{}
+	post := &model.Post{
+		CreateAt: 1501804801000,
+	}
+	translateFunc := utils.GetUserTranslations("en")
+	subject := getThreadReplyNotificationEmailSubject(user, post, translateFunc, "http://localhost:8065", "team", true)
+	require.Regexp(t, regexp.MustCompile("^"+regexp.QuoteMeta(expectedPrefix)), subject, fmt.Sprintf("Expected subject line prefix '%s', got %s", expectedPrefix, subject))
+}
+
+func TestClaimNotificationEmail(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	userId := model.NewId()
+	postId := model.NewId()
+
+	assert.True(t, th.Server.claimNotificationEmail(userId, postId))
+	assert.False(t, th.Server.claimNotificationEmail(userId, postId), "a second trigger for the same post shouldn't send another email")
+
+	th.Server.releaseNotificationEmail(userId, postId)
+	assert.True(t, th.Server.claimNotificationEmail(userId, postId), "a released email should be sent by a later trigger")
+
+	assert.True(t, th.Server.claimNotificationEmail(userId, model.NewId()))
+	assert.True(t, th.Server.claimNotificationEmail(model.NewId(), postId))
+}
+
+func TestNotificationEmailReason(t *testing.T) {
+	user := &model.User{Id: model.NewId(), Username: "user1", NotifyProps: model.StringMap{}}
+
+	mentioningPost := &model.Post{Message: "hello @user1"}
+	assert.Equal(t, KeywordMention, notificationEmailReason(mentioningPost, user, ThreadMention), "a mention should win over a followed thread")
+	assert.Equal(t, KeywordMention, notificationEmailReason(mentioningPost, user, CommentMention))
+	assert.Equal(t, DMMention, notificationEmailReason(mentioningPost, user, DMMention))
+
+	otherPost := &model.Post{Message: "hello @user2"}
+	assert.Equal(t, ThreadMention, notificationEmailReason(otherPost, user, ThreadMention))
+	assert.Equal(t, CommentMention, notificationEmailReason(otherPost, user, CommentMention))
+}
+
+func TestSendNotificationEmailOncePerPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableEmailBatching = true
+		*cfg.EmailSettings.EmailBatchingInterval = 3600
+	})
+	th.App.Srv().EmailService.InitEmailBatching()
+	job := th.App.Srv().EmailService.EmailBatching
+
+	newNotification := func() *PostNotification {
+		return &PostNotification{
+			Post:       th.CreatePost(th.BasicChannel),
+			Channel:    th.BasicChannel,
+			ProfileMap: map[string]*model.User{th.BasicUser.Id: th.BasicUser, th.BasicUser2.Id: th.BasicUser2},
+			Sender:     th.BasicUser,
+		}
+	}
+
+	t.Run("mentioned in a followed thread", func(t *testing.T) {
+		notification := newNotification()
+
+		require.Nil(t, th.App.sendNotificationEmail(notification, th.BasicUser2, th.BasicTeam, KeywordMention))
+		require.Nil(t, th.App.sendNotificationEmail(notification, th.BasicUser2, th.BasicTeam, ThreadMention))
+
+		job.handleNewNotifications()
+		require.Len(t, job.pendingNotifications[th.BasicUser2.Id], 1)
+		assert.Equal(t, notification.Post.Id, job.pendingNotifications[th.BasicUser2.Id][0].post.Id)
+	})
+
+	t.Run("other posts are still emailed", func(t *testing.T) {
+		require.Nil(t, th.App.sendNotificationEmail(newNotification(), th.BasicUser2, th.BasicTeam, ThreadMention))
+
+		job.handleNewNotifications()
+		require.Len(t, job.pendingNotifications[th.BasicUser2.Id], 2)
+	})
+}
+
 func TestGetNotificationEmailBodyFullNotificationPublicChannel(t *testing.T) {
 	th := SetupWithStoreMock(t)
 	defer th.TearDown()
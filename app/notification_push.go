@@ -16,7 +16,6 @@ import (
 	"github.com/mattermost/go-i18n/i18n"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
 )
 
 type notificationType string
@@ -201,7 +200,7 @@ func (a *App) clearPushNotificationSync(currentSessionId, userId, channelId stri
 		ContentAvailable: 1,
 	}
 
-	unreadCount, err := a.Srv().Store.User().GetUnreadCount(userId)
+	unreadCount, err := a.getMobileAppBadgeCount(userId)
 	if err != nil {
 		return err
 	}
@@ -228,7 +227,7 @@ func (a *App) updateMobileAppBadgeSync(userId string) *model.AppError {
 		ContentAvailable: 1,
 	}
 
-	unreadCount, err := a.Srv().Store.User().GetUnreadCount(userId)
+	unreadCount, err := a.getMobileAppBadgeCount(userId)
 	if err != nil {
 		return err
 	}
@@ -238,6 +237,18 @@ func (a *App) updateMobileAppBadgeSync(userId string) *model.AppError {
 	return a.sendPushNotificationToAllSessions(msg, userId, "")
 }
 
+// getMobileAppBadgeCount returns the unread count shown on the user's mobile app badge. Mentions in
+// muted direct and group channels are still recorded on the channels but are left out of the badge,
+// unless the user has chosen to include them.
+func (a *App) getMobileAppBadgeCount(userId string) (int64, *model.AppError) {
+	excludeMutedDirect := true
+	if pref, err := a.Srv().Store.Preference().Get(userId, model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_MUTED_CHANNEL_MENTIONS_IN_BADGE); err == nil && pref.Value == "true" {
+		excludeMutedDirect = false
+	}
+
+	return a.Srv().Store.User().GetUnreadCount(userId, excludeMutedDirect)
+}
+
 func (a *App) UpdateMobileAppBadge(userId string) {
 	a.Srv().PushNotificationsHub.notificationsChan <- PushNotification{
 		notificationType: notificationTypeUpdateBadge,
@@ -407,8 +418,8 @@ func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps m
 		channelNotify = model.CHANNEL_NOTIFY_DEFAULT
 	}
 
-	// If the channel is muted do not send push notifications
-	if channelNotifyProps[model.MARK_UNREAD_NOTIFY_PROP] == model.CHANNEL_MARK_UNREAD_MENTION {
+	// If the channel is muted only send push notifications for mentions
+	if channelNotifyProps[model.MARK_UNREAD_NOTIFY_PROP] == model.CHANNEL_MARK_UNREAD_MENTION && !wasMentioned {
 		return false
 	}
 
@@ -479,7 +490,7 @@ func (a *App) BuildPushNotificationMessage(contentsConfig string, post *model.Po
 		msg = a.buildFullPushNotificationMessage(contentsConfig, post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
 	}
 
-	unreadCount, err := a.Srv().Store.User().GetUnreadCount(user.Id)
+	unreadCount, err := a.getMobileAppBadgeCount(user.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -489,7 +500,7 @@ func (a *App) BuildPushNotificationMessage(contentsConfig string, post *model.Po
 }
 
 func (a *App) buildIdLoadedPushNotificationMessage(post *model.Post, user *model.User) *model.PushNotification {
-	userLocale := utils.GetUserTranslations(user.Locale)
+	userLocale := a.Srv().getRecipientTranslations(user)
 	msg := &model.PushNotification{
 		PostId:     post.Id,
 		ChannelId:  post.ChannelId,
@@ -538,10 +549,15 @@ func (a *App) buildFullPushNotificationMessage(contentsConfig string, post *mode
 		msg.FromWebhook = fw
 	}
 
-	userLocale := utils.GetUserTranslations(user.Locale)
+	userLocale := a.Srv().getRecipientTranslations(user)
 	hasFiles := post.FileIds != nil && len(post.FileIds) > 0
 
-	msg.Message = a.getPushNotificationMessage(contentsConfig, post.Message, explicitMention, channelWideMention, hasFiles, msg.SenderName, channelName, channel.Type, replyToThreadType, userLocale)
+	postMessage := post.Message
+	if post.IsSystemMessage() {
+		postMessage = FormatSystemMessage(post, userLocale)
+	}
+
+	msg.Message = a.getPushNotificationMessage(contentsConfig, postMessage, explicitMention, channelWideMention, hasFiles, msg.SenderName, channelName, channel.Type, replyToThreadType, userLocale)
 
 	return msg
 }
@@ -326,6 +326,15 @@ func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
 			isMuted:              true,
 			expected:             false,
 		},
+		{
+			name:                 "When default is ALL, channel is MUTED and has mentions",
+			userNotifySetting:    model.USER_NOTIFY_ALL,
+			channelNotifySetting: "",
+			withSystemPost:       false,
+			wasMentioned:         true,
+			isMuted:              true,
+			expected:             true,
+		},
 	}
 
 	for _, tc := range tt {
@@ -1122,7 +1131,7 @@ func TestClearPushNotificationSync(t *testing.T) {
 	mockStore := th.App.Srv().Store.(*mocks.Store)
 	mockUserStore := mocks.UserStore{}
 	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
-	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string")).Return(int64(1), nil)
+	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(int64(1), nil)
 	mockPostStore := mocks.PostStore{}
 	mockPostStore.On("GetMaxPostSize").Return(65535, nil)
 	mockSystemStore := mocks.SystemStore{}
@@ -1132,10 +1141,13 @@ func TestClearPushNotificationSync(t *testing.T) {
 	mockSessionStore := mocks.SessionStore{}
 	mockSessionStore.On("GetSessionsWithActiveDeviceIds", mock.AnythingOfType("string")).Return([]*model.Session{sess1, sess2}, nil)
 	mockSessionStore.On("UpdateDeviceId", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return("testdeviceID", nil)
+	mockPreferenceStore := mocks.PreferenceStore{}
+	mockPreferenceStore.On("Get", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, model.NewAppError("", "", nil, "", http.StatusNotFound))
 	mockStore.On("User").Return(&mockUserStore)
 	mockStore.On("Post").Return(&mockPostStore)
 	mockStore.On("System").Return(&mockSystemStore)
 	mockStore.On("Session").Return(&mockSessionStore)
+	mockStore.On("Preference").Return(&mockPreferenceStore)
 
 	th.App.UpdateConfig(func(cfg *model.Config) {
 		*cfg.EmailSettings.PushNotificationServer = pushServer.URL
@@ -1176,7 +1188,7 @@ func TestUpdateMobileAppBadgeSync(t *testing.T) {
 	mockStore := th.App.Srv().Store.(*mocks.Store)
 	mockUserStore := mocks.UserStore{}
 	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
-	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string")).Return(int64(1), nil)
+	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(int64(1), nil)
 	mockPostStore := mocks.PostStore{}
 	mockPostStore.On("GetMaxPostSize").Return(65535, nil)
 	mockSystemStore := mocks.SystemStore{}
@@ -1186,10 +1198,13 @@ func TestUpdateMobileAppBadgeSync(t *testing.T) {
 	mockSessionStore := mocks.SessionStore{}
 	mockSessionStore.On("GetSessionsWithActiveDeviceIds", mock.AnythingOfType("string")).Return([]*model.Session{sess1, sess2}, nil)
 	mockSessionStore.On("UpdateDeviceId", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return("testdeviceID", nil)
+	mockPreferenceStore := mocks.PreferenceStore{}
+	mockPreferenceStore.On("Get", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, model.NewAppError("", "", nil, "", http.StatusNotFound))
 	mockStore.On("User").Return(&mockUserStore)
 	mockStore.On("Post").Return(&mockPostStore)
 	mockStore.On("System").Return(&mockSystemStore)
 	mockStore.On("Session").Return(&mockSessionStore)
+	mockStore.On("Preference").Return(&mockPreferenceStore)
 
 	th.App.UpdateConfig(func(cfg *model.Config) {
 		*cfg.EmailSettings.PushNotificationServer = pushServer.URL
@@ -1206,6 +1221,36 @@ func TestUpdateMobileAppBadgeSync(t *testing.T) {
 	assert.Equal(t, model.PUSH_TYPE_UPDATE_BADGE, handler.notifications()[1].Type)
 }
 
+func TestGetMobileAppBadgeCount(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockStore := th.App.Srv().Store.(*mocks.Store)
+	mockUserStore := mocks.UserStore{}
+	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
+	mockUserStore.On("GetUnreadCount", "user1", true).Return(int64(4), nil)
+	mockUserStore.On("GetUnreadCount", "user1", false).Return(int64(6), nil)
+	mockPreferenceStore := mocks.PreferenceStore{}
+	mockStore.On("User").Return(&mockUserStore)
+	mockStore.On("Preference").Return(&mockPreferenceStore)
+
+	t.Run("should leave out muted direct channels", func(t *testing.T) {
+		mockPreferenceStore.On("Get", "user1", model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_MUTED_CHANNEL_MENTIONS_IN_BADGE).Return(nil, model.NewAppError("", "", nil, "", http.StatusNotFound)).Once()
+
+		count, err := th.App.getMobileAppBadgeCount("user1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("should include muted direct channels when the user prefers it", func(t *testing.T) {
+		mockPreferenceStore.On("Get", "user1", model.PREFERENCE_CATEGORY_NOTIFICATIONS, model.PREFERENCE_NAME_MUTED_CHANNEL_MENTIONS_IN_BADGE).Return(&model.Preference{Value: "true"}, nil).Once()
+
+		count, err := th.App.getMobileAppBadgeCount("user1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(6), count)
+	})
+}
+
 func TestSendAckToPushProxy(t *testing.T) {
 	th := SetupWithStoreMock(t)
 	defer th.TearDown()
@@ -1277,7 +1322,7 @@ func TestAllPushNotifications(t *testing.T) {
 			ExpiresAt: model.GetMillis() + 100000,
 		})
 		require.Nil(t, err)
-		_, err = th.App.AddTeamMember(th.BasicTeam.Id, u.Id)
+		_, err = th.App.AddTeamMember(th.BasicTeam.Id, u.Id, "")
 		require.Nil(t, err)
 		th.AddUserToChannel(u, th.BasicChannel)
 		testData = append(testData, userSession{
@@ -1375,7 +1420,7 @@ func BenchmarkPushNotificationThroughput(b *testing.B) {
 	mockStore := th.App.Srv().Store.(*mocks.Store)
 	mockUserStore := mocks.UserStore{}
 	mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
-	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string")).Return(int64(1), nil)
+	mockUserStore.On("GetUnreadCount", mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(int64(1), nil)
 	mockPostStore := mocks.PostStore{}
 	mockPostStore.On("GetMaxPostSize").Return(65535, nil)
 	mockSystemStore := mocks.SystemStore{}
@@ -70,6 +70,85 @@ func TestSendNotifications(t *testing.T) {
 	require.Empty(t, mentions)
 }
 
+func TestSendNotificationsMutedDirectChannel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	dm, appErr := th.App.GetOrCreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.UpdateChannelMemberNotifyProps(map[string]string{model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_MENTION}, dm.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	post, appErr := th.App.CreatePostMissingChannel(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: dm.Id,
+		Message:   "dm message",
+	}, false)
+	require.Nil(t, appErr)
+
+	mentions, err := th.App.SendNotifications(post, th.BasicTeam, dm, th.BasicUser, nil, true)
+	require.NoError(t, err)
+	require.True(t, utils.StringInSlice(th.BasicUser2.Id, mentions), "mentions", mentions)
+
+	// The mention is still recorded on the channel.
+	member, appErr := th.App.GetChannelMember(dm.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(1), member.MentionCount)
+
+	t.Run("should not count towards team unreads", func(t *testing.T) {
+		teamUnreads, appErr := th.App.GetTeamsUnreadForUser("", th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		for _, teamUnread := range teamUnreads {
+			assert.Zero(t, teamUnread.MsgCount, teamUnread.TeamId)
+			assert.Zero(t, teamUnread.MentionCount, teamUnread.TeamId)
+		}
+	})
+
+	t.Run("should not count towards the badge unless preferred", func(t *testing.T) {
+		count, appErr := th.App.getMobileAppBadgeCount(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, count)
+
+		appErr = th.App.UpdatePreferences(th.BasicUser2.Id, model.Preferences{{
+			UserId:   th.BasicUser2.Id,
+			Category: model.PREFERENCE_CATEGORY_NOTIFICATIONS,
+			Name:     model.PREFERENCE_NAME_MUTED_CHANNEL_MENTIONS_IN_BADGE,
+			Value:    "true",
+		}})
+		require.Nil(t, appErr)
+
+		count, appErr = th.App.getMobileAppBadgeCount(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestIsMutedWithoutDirectMention(t *testing.T) {
+	muted := model.StringMap{model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_MENTION}
+	unmuted := model.StringMap{model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_ALL}
+
+	for name, tc := range map[string]struct {
+		NotifyProps model.StringMap
+		MentionType MentionType
+		Expected    bool
+	}{
+		"muted, not mentioned":             {muted, NoMention, true},
+		"muted, mentioned directly":        {muted, KeywordMention, false},
+		"muted, channel mention":           {muted, ChannelMention, true},
+		"muted, group mention":             {muted, GroupMention, true},
+		"muted, reply in thread":           {muted, CommentMention, true},
+		"muted direct channel":             {muted, DMMention, true},
+		"not muted, not mentioned":         {unmuted, NoMention, false},
+		"not muted, channel mention":       {unmuted, ChannelMention, false},
+		"no channel notify props, mention": {model.StringMap{}, KeywordMention, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, isMutedWithoutDirectMention(tc.NotifyProps, tc.MentionType))
+		})
+	}
+}
+
 func TestSendNotificationsWithManyUsers(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -52,6 +52,10 @@ const (
 	PERMISSION_CREATE_POST                       = "create_post"
 	PERMISSION_CREATE_POST_PUBLIC                = "create_post_public"
 	PERMISSION_USE_GROUP_MENTIONS                = "use_group_mentions"
+	PERMISSION_ADD_BOOKMARK                      = "add_bookmark"
+	PERMISSION_MANAGE_CHANNEL_ROLES              = "manage_channel_roles"
+	PERMISSION_MANAGE_CHANNEL_READ_ONLY          = "manage_channel_read_only"
+	PERMISSION_MANAGE_CUSTOM_GROUPS              = "manage_custom_groups"
 	PERMISSION_ADD_REACTION                      = "add_reaction"
 	PERMISSION_REMOVE_REACTION                   = "remove_reaction"
 	PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS     = "manage_public_channel_members"
@@ -425,6 +429,37 @@ func (a *App) getAddUseGroupMentionsPermissionMigration() (permissionsMap, error
 	}, nil
 }
 
+func (a *App) getAddBookmarkPermissionMigration() (permissionsMap, error) {
+	return permissionsMap{
+		permissionTransformation{
+			On: permissionAnd(
+				isNotRole(model.CHANNEL_GUEST_ROLE_ID),
+				isNotSchemeRole("Channel Guest Role for Scheme"),
+				permissionOr(permissionExists(PERMISSION_CREATE_POST), permissionExists(PERMISSION_CREATE_POST_PUBLIC)),
+			),
+			Add: []string{PERMISSION_ADD_BOOKMARK},
+		},
+	}, nil
+}
+
+func (a *App) getAddManageChannelReadOnlyPermissionMigration() (permissionsMap, error) {
+	return permissionsMap{
+		permissionTransformation{
+			On:  permissionExists(PERMISSION_MANAGE_CHANNEL_ROLES),
+			Add: []string{PERMISSION_MANAGE_CHANNEL_READ_ONLY},
+		},
+	}, nil
+}
+
+func (a *App) getAddManageCustomGroupsPermissionMigration() (permissionsMap, error) {
+	return permissionsMap{
+		permissionTransformation{
+			On:  isRole(model.SYSTEM_ADMIN_ROLE_ID),
+			Add: []string{PERMISSION_MANAGE_CUSTOM_GROUPS},
+		},
+	}, nil
+}
+
 // DoPermissionsMigrations execute all the permissions migrations need by the current version.
 func (a *App) DoPermissionsMigrations() error {
 	PermissionsMigrations := []struct {
@@ -442,6 +477,9 @@ func (a *App) DoPermissionsMigrations() error {
 		{Key: model.MIGRATION_KEY_ADD_MANAGE_GUESTS_PERMISSIONS, Migration: a.getAddManageGuestsPermissionsMigration},
 		{Key: model.MIGRATION_KEY_CHANNEL_MODERATIONS_PERMISSIONS, Migration: a.channelModerationPermissionsMigration},
 		{Key: model.MIGRATION_KEY_ADD_USE_GROUP_MENTIONS_PERMISSION, Migration: a.getAddUseGroupMentionsPermissionMigration},
+		{Key: model.MIGRATION_KEY_ADD_BOOKMARK_PERMISSION, Migration: a.getAddBookmarkPermissionMigration},
+		{Key: model.MIGRATION_KEY_ADD_MANAGE_CHANNEL_READ_ONLY_PERMISSION, Migration: a.getAddManageChannelReadOnlyPermissionMigration},
+		{Key: model.MIGRATION_KEY_ADD_MANAGE_CUSTOM_GROUPS_PERMISSION, Migration: a.getAddManageCustomGroupsPermissionMigration},
 	}
 
 	for _, migration := range PermissionsMigrations {
@@ -176,6 +176,7 @@ func (a *App) InitPlugins(pluginDir, webappPluginDir string) {
 		mlog.Error("Failed to start up plugins", mlog.Err(err))
 		return
 	}
+	env.SetHealthCheckFailureListener(a.notifyAdminsOfPluginHealthCheckFailure)
 	a.SetPluginsEnvironment(env)
 
 	if err := a.SyncPlugins(); err != nil {
@@ -431,6 +432,39 @@ func (a *App) GetPlugins() (*model.PluginsResponse, *model.AppError) {
 	return resp, nil
 }
 
+// GetPluginEffectiveConfig returns the configuration an installed plugin is running with: the
+// defaults from its settings schema merged with any admin overrides, with secrets redacted.
+func (a *App) GetPluginEffectiveConfig(id string) (map[string]interface{}, *model.AppError) {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("GetPluginEffectiveConfig", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	availablePlugins, err := pluginsEnvironment.Available()
+	if err != nil {
+		return nil, model.NewAppError("GetPluginEffectiveConfig", "app.plugin.get_plugins.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	id = strings.ToLower(id)
+
+	var manifest *model.Manifest
+	for _, p := range availablePlugins {
+		if p.Manifest != nil && p.Manifest.Id == id {
+			manifest = p.Manifest
+			break
+		}
+	}
+
+	if manifest == nil {
+		return nil, model.NewAppError("GetPluginEffectiveConfig", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
+	}
+
+	config := manifest.EffectiveConfig(a.Config().PluginSettings.Plugins[id])
+	manifest.SanitizeConfig(config)
+
+	return config, nil
+}
+
 // GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 // and plugins that are installed locally.
 func (a *App) GetMarketplacePlugins(filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError) {
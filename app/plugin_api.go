@@ -36,19 +36,7 @@ func NewPluginAPI(a *App, manifest *model.Manifest) *PluginAPI {
 }
 
 func (api *PluginAPI) LoadPluginConfiguration(dest interface{}) error {
-	finalConfig := make(map[string]interface{})
-
-	// First set final config to defaults
-	if api.manifest.SettingsSchema != nil {
-		for _, setting := range api.manifest.SettingsSchema.Settings {
-			finalConfig[strings.ToLower(setting.Key)] = setting.Default
-		}
-	}
-
-	// If we have settings given we override the defaults with them
-	for setting, value := range api.app.Config().PluginSettings.Plugins[api.id] {
-		finalConfig[strings.ToLower(setting)] = value
-	}
+	finalConfig := api.manifest.EffectiveConfig(api.app.Config().PluginSettings.Plugins[api.id])
 
 	if pluginSettingsJsonBytes, err := json.Marshal(finalConfig); err != nil {
 		api.logger.Error("Error marshaling config for plugin", mlog.Err(err))
@@ -185,7 +173,7 @@ func (api *PluginAPI) GetTeamsForUser(userId string) ([]*model.Team, *model.AppE
 }
 
 func (api *PluginAPI) CreateTeamMember(teamId, userId string) (*model.TeamMember, *model.AppError) {
-	return api.app.AddTeamMember(teamId, userId)
+	return api.app.AddTeamMember(teamId, userId, "")
 }
 
 func (api *PluginAPI) CreateTeamMembers(teamId string, userIds []string, requestorId string) ([]*model.TeamMember, *model.AppError) {
@@ -323,22 +311,54 @@ func (api *PluginAPI) GetLDAPUserAttributes(userId string, attributes []string)
 		return nil, model.NewAppError("GetLdapUserAttributes", "ent.ldap.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	user, err := api.app.GetUser(userId)
+	authData, err := api.ldapAuthData(userId)
 	if err != nil {
 		return nil, err
 	}
 
-	if user.AuthData == nil {
+	if authData == "" {
 		return map[string]string{}, nil
 	}
 
+	return api.app.Ldap().GetUserAttributes(authData, attributes)
+}
+
+func (api *PluginAPI) GetLDAPUserAttributeValues(userId string, attributes []string) (map[string][]string, *model.AppError) {
+	if api.app.Ldap() == nil {
+		return nil, model.NewAppError("GetLDAPUserAttributeValues", "ent.ldap.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	authData, err := api.ldapAuthData(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if authData == "" {
+		return map[string][]string{}, nil
+	}
+
+	return api.app.Ldap().GetUserAttributeValues(authData, attributes)
+}
+
+// ldapAuthData returns the LDAP id of the given user, or an empty string if the user's attributes
+// shouldn't be looked up in LDAP.
+func (api *PluginAPI) ldapAuthData(userId string) (string, *model.AppError) {
+	user, err := api.app.GetUser(userId)
+	if err != nil {
+		return "", err
+	}
+
+	if user.AuthData == nil {
+		return "", nil
+	}
+
 	// Only bother running the query if the user's auth service is LDAP or it's SAML and sync is enabled.
 	if user.AuthService == model.USER_AUTH_SERVICE_LDAP ||
 		(user.AuthService == model.USER_AUTH_SERVICE_SAML && *api.app.Config().SamlSettings.EnableSyncWithLdap) {
-		return api.app.Ldap().GetUserAttributes(*user.AuthData, attributes)
+		return *user.AuthData, nil
 	}
 
-	return map[string]string{}, nil
+	return "", nil
 }
 
 func (api *PluginAPI) CreateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
@@ -354,7 +374,7 @@ func (api *PluginAPI) DeleteChannel(channelId string) *model.AppError {
 }
 
 func (api *PluginAPI) GetPublicChannelsForTeam(teamId string, page, perPage int) ([]*model.Channel, *model.AppError) {
-	channels, err := api.app.GetPublicChannelsForTeam(teamId, page*perPage, perPage)
+	channels, err := api.app.GetPublicChannelsForTeam(teamId, page*perPage, perPage, true)
 	if err != nil {
 		return nil, err
 	}
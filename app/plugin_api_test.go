@@ -451,6 +451,56 @@ func TestPluginAPIGetUsersInTeam(t *testing.T) {
 	}
 }
 
+func TestPluginAPIGetLDAPUserAttributeValues(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	api := th.SetupPluginAPI()
+
+	t.Run("ldap disabled", func(t *testing.T) {
+		th.App.Srv().Ldap = nil
+
+		_, err := api.GetLDAPUserAttributeValues(model.NewId(), []string{"memberOf"})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+	})
+
+	ldapMock := &mocks.LdapInterface{}
+	th.App.Srv().Ldap = ldapMock
+	defer func() { th.App.Srv().Ldap = nil }()
+
+	t.Run("multi-valued attributes", func(t *testing.T) {
+		authData := model.NewId()
+		user, err := th.App.CreateUser(&model.User{
+			Email:       strings.ToLower(model.NewId()) + "success+test@example.com",
+			Username:    "ldapuser" + model.NewId(),
+			AuthService: model.USER_AUTH_SERVICE_LDAP,
+			AuthData:    &authData,
+		})
+		require.Nil(t, err)
+		defer th.App.PermanentDeleteUser(user)
+
+		values := map[string][]string{
+			"memberOf": {"cn=developers,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+			"mail":     {user.Email},
+		}
+		ldapMock.On("GetUserAttributeValues", authData, []string{"memberOf", "mail"}).Return(values, nil).Once()
+
+		attributes, err := api.GetLDAPUserAttributeValues(user.Id, []string{"memberOf", "mail"})
+		require.Nil(t, err)
+		assert.Equal(t, values, attributes)
+		ldapMock.AssertExpectations(t)
+	})
+
+	t.Run("non-ldap user", func(t *testing.T) {
+		user := th.CreateUser()
+		defer th.App.PermanentDeleteUser(user)
+
+		attributes, err := api.GetLDAPUserAttributeValues(user.Id, []string{"memberOf"})
+		require.Nil(t, err)
+		assert.Empty(t, attributes)
+	})
+}
+
 func TestPluginAPIGetFile(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5,6 +5,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -294,6 +295,86 @@ func TestHookMessageWillBePosted(t *testing.T) {
 	})
 }
 
+func TestHookMessageWillBePostedWithContext(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{
+		`
+		package main
+
+		import (
+			"context"
+
+			"github.com/mattermost/mattermost-server/v5/plugin"
+			"github.com/mattermost/mattermost-server/v5/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+			return nil, "the context hook should be preferred"
+		}
+
+		func (p *MyPlugin) MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string) {
+			if _, ok := ctx.Deadline(); ok {
+				post.Message = post.Message + "_deadline"
+			}
+			if c, ok := plugin.PluginContextFromContext(ctx); ok && c.RequestId != "" {
+				post.Message = post.Message + "_" + c.RequestId
+			}
+			return post, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+		`,
+		`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/v5/plugin"
+			"github.com/mattermost/mattermost-server/v5/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+			post.Message = post.Message + "_fallback"
+			return post, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+		`,
+	}, th.App, th.App.NewPluginAPI)
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	th.App.SetContext(ctx)
+	th.App.SetRequestId("requestid")
+
+	post := &model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "message",
+		CreateAt:  model.GetMillis() - 10000,
+	}
+	post, err := th.App.CreatePost(post, th.BasicChannel, false, true)
+	require.Nil(t, err)
+
+	// The plugins run in an unspecified order.
+	assert.Contains(t, post.Message, "_deadline_requestid")
+	assert.Contains(t, post.Message, "_fallback")
+}
+
 func TestHookMessageHasBeenPosted(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
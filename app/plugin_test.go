@@ -24,7 +24,6 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
 	"github.com/mattermost/mattermost-server/v5/testlib"
-	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 )
 
@@ -685,7 +684,7 @@ func TestProcessPrepackagedPlugins(t *testing.T) {
 	require.True(t, found, "failed to find prepackaged plugins directory")
 
 	testPluginPath := filepath.Join(testsPath, "testplugin.tar.gz")
-	fileErr = utils.CopyFile(testPluginPath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
+	fileErr = fileutils.CopyFile(testPluginPath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz"))
 	require.NoError(t, fileErr)
 
 	t.Run("automatic, enabled plugin, no signature", func(t *testing.T) {
@@ -756,16 +755,16 @@ func TestProcessPrepackagedPlugins(t *testing.T) {
 
 		// Add signature
 		testPluginSignaturePath := filepath.Join(testsPath, "testplugin.tar.gz.sig")
-		err := utils.CopyFile(testPluginSignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
+		err := fileutils.CopyFile(testPluginSignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
 		require.NoError(t, err)
 
 		// Add second plugin
 		testPlugin2Path := filepath.Join(testsPath, "testplugin2.tar.gz")
-		err = utils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
+		err = fileutils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
 		require.NoError(t, err)
 
 		testPlugin2SignaturePath := filepath.Join(testsPath, "testplugin2.tar.gz.sig")
-		err = utils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
+		err = fileutils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
 		require.NoError(t, err)
 
 		plugins := th.App.processPrepackagedPlugins(prepackagedPluginsDir)
@@ -790,7 +789,7 @@ func TestProcessPrepackagedPlugins(t *testing.T) {
 
 		// Add signature
 		testPluginSignaturePath := filepath.Join(testsPath, "testplugin.tar.gz.sig")
-		err := utils.CopyFile(testPluginSignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
+		err := fileutils.CopyFile(testPluginSignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin.tar.gz.sig"))
 		require.NoError(t, err)
 
 		// Install first plugin and enable
@@ -809,11 +808,11 @@ func TestProcessPrepackagedPlugins(t *testing.T) {
 
 		// Add second plugin
 		testPlugin2Path := filepath.Join(testsPath, "testplugin2.tar.gz")
-		err = utils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
+		err = fileutils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
 		require.NoError(t, err)
 
 		testPlugin2SignaturePath := filepath.Join(testsPath, "testplugin2.tar.gz.sig")
-		err = utils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
+		err = fileutils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
 		require.NoError(t, err)
 
 		plugins := th.App.processPrepackagedPlugins(prepackagedPluginsDir)
@@ -845,11 +844,11 @@ func TestProcessPrepackagedPlugins(t *testing.T) {
 		env := th.App.GetPluginsEnvironment()
 
 		testPlugin2Path := filepath.Join(testsPath, "testplugin2.tar.gz")
-		err := utils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
+		err := fileutils.CopyFile(testPlugin2Path, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz"))
 		require.NoError(t, err)
 
 		testPlugin2SignaturePath := filepath.Join(testsPath, "testplugin2.tar.gz.sig")
-		err = utils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
+		err = fileutils.CopyFile(testPlugin2SignaturePath, filepath.Join(prepackagedPluginsDir, "testplugin2.tar.gz.sig"))
 		require.NoError(t, err)
 
 		plugins := th.App.processPrepackagedPlugins(prepackagedPluginsDir)
@@ -4,6 +4,10 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,20 +16,69 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
-	"github.com/mattermost/mattermost-server/v5/services/cache"
 	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/mattermost/mattermost-server/v5/utils"
 )
 
 const (
-	PENDING_POST_IDS_CACHE_SIZE = 25000
-	PENDING_POST_IDS_CACHE_TTL  = 30 * time.Second
-	PAGE_DEFAULT                = 0
+	PENDING_POST_IDS_TTL = 5 * time.Minute
+	PAGE_DEFAULT         = 0
 )
 
+// PENDING_POST_IDS_KV_PLUGIN_ID is the plugin id under which the pending post ids seen recently are
+// kept in the plugin key value store, along with the response to the request creating their post.
+// The colon isn't allowed in plugin ids, so no plugin can read or overwrite them.
+const PENDING_POST_IDS_KV_PLUGIN_ID = "mattermost:pendingposts"
+
+// pendingPostResponsePending is kept for a pending post id while its post is being created.
+var pendingPostResponsePending = []byte("pending")
+
+// CreatePostForSession creates a post on behalf of the user of the session, as requested through
+// the REST API or a websocket. Only system admins may choose the creation time of the post. The
+// post created, or the one requested on failure, is added to the given audit record.
+func (a *App) CreatePostForSession(session model.Session, post *model.Post, setOnline bool, auditRec *audit.Record) (*model.Post, *model.AppError) {
+	post.UserId = session.UserId
+	auditRec.AddMeta("post", post)
+
+	hasPermission := false
+	if a.SessionHasPermissionToChannel(session, post.ChannelId, model.PERMISSION_CREATE_POST) {
+		hasPermission = true
+	} else if channel, err := a.GetChannel(post.ChannelId); err == nil {
+		// Temporary permission check method until advanced permissions, please do not copy
+		if channel.Type == model.CHANNEL_OPEN && a.SessionHasPermissionToTeam(session, channel.TeamId, model.PERMISSION_CREATE_POST_PUBLIC) {
+			hasPermission = true
+		}
+	}
+
+	if !hasPermission {
+		return nil, model.NewAppError("CreatePostForSession", "api.context.permissions.app_error", nil, "userId="+session.UserId+", permission="+model.PERMISSION_CREATE_POST.Id, http.StatusForbidden)
+	}
+
+	if post.CreateAt != 0 && !a.SessionHasPermissionTo(session, model.PERMISSION_MANAGE_SYSTEM) {
+		post.CreateAt = 0
+	}
+
+	rp, err := a.CreatePostAsUser(a.PostWithProxyRemovedFromImageURLs(post), session.Id, setOnline)
+	if err != nil {
+		return nil, err
+	}
+	auditRec.Success()
+	auditRec.AddMeta("post", rp) // overwrite meta
+
+	if setOnline {
+		a.SetStatusOnline(session.UserId, false)
+	}
+
+	a.UpdateLastActivityAtIfNeeded(session)
+
+	// Note that rp has already had PreparePostForClient called on it by CreatePost
+	return rp, nil
+}
+
 func (a *App) CreatePostAsUser(post *model.Post, currentSessionId string, setOnline bool) (*model.Post, *model.AppError) {
 	// Check that channel has not been deleted
 	channel, errCh := a.Srv().Store.Channel().Get(post.ChannelId, true)
@@ -107,7 +160,27 @@ func (a *App) CreatePostMissingChannel(post *model.Post, triggerWebhooks bool) (
 	return a.CreatePost(post, channel, triggerWebhooks, true)
 }
 
-// deduplicateCreatePost attempts to make posting idempotent within a caching window.
+// pendingPostIdsKey returns the key of the given pending post id in the plugin key value store.
+// Pending post ids are generated by clients, so they're scoped to the user sending them, and hashed
+// to fit the key column.
+func pendingPostIdsKey(post *model.Post) string {
+	sum := sha256.Sum256([]byte(post.UserId + ":" + post.PendingPostId))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// pendingPostResponse returns the record of the given pending post id, holding the given response.
+func pendingPostResponse(post *model.Post, response []byte) *model.PluginKeyValue {
+	return &model.PluginKeyValue{
+		PluginId: PENDING_POST_IDS_KV_PLUGIN_ID,
+		Key:      pendingPostIdsKey(post),
+		Value:    response,
+		ExpireAt: model.GetMillis() + int64(PENDING_POST_IDS_TTL/time.Millisecond),
+	}
+}
+
+// deduplicateCreatePost attempts to make posting idempotent within PENDING_POST_IDS_TTL. Pending
+// post ids are recorded in the database, so that a retry reaching another node of a cluster is
+// deduplicated too.
 func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, err *model.AppError) {
 	// We rely on the client sending the pending post id across "duplicate" requests. If there
 	// isn't one, we can't deduplicate, so allow creation normally.
@@ -115,33 +188,36 @@ func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, er
 		return nil, nil
 	}
 
-	const unknownPostId = ""
-
-	// Query the cache atomically for the given pending post id, saving a record if
-	// it hasn't previously been seen.
-	var postId string
-	nErr := a.Srv().seenPendingPostIdsCache.Get(post.PendingPostId, &postId)
-	if nErr == cache.ErrKeyNotFound {
-		a.Srv().seenPendingPostIdsCache.SetWithExpiry(post.PendingPostId, unknownPostId, PENDING_POST_IDS_CACHE_TTL)
+	// Record the pending post id atomically, unless it has previously been seen.
+	recorded, err := a.Srv().Store.Plugin().CompareAndSet(pendingPostResponse(post, pendingPostResponsePending), nil)
+	if err != nil {
+		return nil, model.NewAppError("errorGetPostId", "api.post.error_get_post_id.pending", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if recorded {
 		return nil, nil
 	}
 
-	if nErr != nil {
-		return nil, model.NewAppError("errorGetPostId", "api.post.error_get_post_id.pending", nil, "", http.StatusInternalServerError)
+	response, err := a.Srv().Store.Plugin().GetFromMaster(PENDING_POST_IDS_KV_PLUGIN_ID, pendingPostIdsKey(post))
+	if err != nil && err.StatusCode != http.StatusNotFound {
+		return nil, model.NewAppError("errorGetPostId", "api.post.error_get_post_id.pending", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	// If another thread saved the cache record, but hasn't yet updated it with the actual post
-	// id (because it's still saving), notify the client with an error. Ideally, we'd wait
-	// for the other thread, but coordinating that adds complexity to the happy path.
-	if postId == unknownPostId {
+	// If another thread saved the record, but hasn't yet updated it with the response (because it's
+	// still saving) or has just failed and removed it, notify the client with an error. Ideally,
+	// we'd wait for the other thread, but coordinating that adds complexity to the happy path.
+	if err != nil || bytes.Equal(response.Value, pendingPostResponsePending) {
 		return nil, model.NewAppError("deduplicateCreatePost", "api.post.deduplicate_create_post.pending", nil, "", http.StatusInternalServerError)
 	}
 
-	// If the other thread finished creating the post, return the created post back to the
-	// client, making the API call feel idempotent.
-	actualPost, err := a.GetSinglePost(postId)
-	if err != nil {
-		return nil, model.NewAppError("deduplicateCreatePost", "api.post.deduplicate_create_post.failed_to_get", nil, err.Error(), http.StatusInternalServerError)
+	// If the other thread finished creating the post, return the post it created back to the
+	// client as it was returned then, making the API call feel idempotent.
+	actualPost := model.PostFromJson(bytes.NewReader(response.Value))
+	if actualPost == nil {
+		return nil, model.NewAppError("deduplicateCreatePost", "api.post.deduplicate_create_post.failed_to_get", nil, "", http.StatusInternalServerError)
+	}
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostCreateDeduplicated()
 	}
 
 	mlog.Debug("Deduplicated create post", mlog.String("post_id", actualPost.Id), mlog.String("pending_post_id", post.PendingPostId))
@@ -149,6 +225,20 @@ func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, er
 	return actualPost, nil
 }
 
+// CreatedPostResponse returns the JSON response to a request creating the given post. A post
+// created with a pending post id gets the response recorded for it, so that retries deduplicated
+// by CreatePost are answered byte for byte as the original request was.
+func (a *App) CreatedPostResponse(post *model.Post) string {
+	if post.PendingPostId != "" {
+		response, err := a.Srv().Store.Plugin().GetFromMaster(PENDING_POST_IDS_KV_PLUGIN_ID, pendingPostIdsKey(post))
+		if err == nil && !bytes.Equal(response.Value, pendingPostResponsePending) {
+			return string(response.Value)
+		}
+	}
+
+	return post.ToJson()
+}
+
 func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhooks, setOnline bool) (savedPost *model.Post, err *model.AppError) {
 	foundPost, err := a.deduplicateCreatePost(post)
 	if err != nil {
@@ -158,19 +248,23 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		return foundPost, nil
 	}
 
-	// If we get this far, we've recorded the client-provided pending post id to the cache.
-	// Remove it if we fail below, allowing a proper retry by the client.
+	// If we get this far, we've recorded the client-provided pending post id. Remove it if we fail
+	// below, allowing a proper retry by the client, or record the response for the retries.
 	defer func() {
 		if post.PendingPostId == "" {
 			return
 		}
 
 		if err != nil {
-			a.Srv().seenPendingPostIdsCache.Remove(post.PendingPostId)
+			if _, nErr := a.Srv().Store.Plugin().CompareAndDelete(pendingPostResponse(post, nil), pendingPostResponsePending); nErr != nil {
+				mlog.Warn("Failed to remove pending post id", mlog.String("pending_post_id", post.PendingPostId), mlog.Err(nErr))
+			}
 			return
 		}
 
-		a.Srv().seenPendingPostIdsCache.SetWithExpiry(post.PendingPostId, savedPost.Id, PENDING_POST_IDS_CACHE_TTL)
+		if _, nErr := a.Srv().Store.Plugin().CompareAndSet(pendingPostResponse(post, []byte(savedPost.ToJson())), pendingPostResponsePending); nErr != nil {
+			mlog.Warn("Failed to record the response to a pending post id", mlog.String("pending_post_id", post.PendingPostId), mlog.Err(nErr))
+		}
 	}()
 
 	post.SanitizeProps()
@@ -201,6 +295,12 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		return nil, model.NewAppError("createPost", "api.post.create_post.town_square_read_only", nil, "", http.StatusForbidden)
 	}
 
+	if len(post.FileIds) > 0 {
+		if err = a.checkChannelAllowsFileAttachments(channel, user.Id); err != nil {
+			return nil, err
+		}
+	}
+
 	var ephemeralPost *model.Post
 	if post.Type == "" && !a.HasPermissionToChannel(user.Id, channel.Id, model.PERMISSION_USE_CHANNEL_MENTIONS) {
 		mention := post.DisableMentionHighlights()
@@ -234,6 +334,13 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 			return nil, model.NewAppError("createPost", "api.post.create_post.root_id.app_error", nil, "", http.StatusBadRequest)
 		}
 
+		if maxReplies := *a.Config().ServiceSettings.MaxRepliesPerThread; maxReplies > 0 &&
+			rootPost.ReplyCount >= int64(maxReplies) &&
+			!post.IsSystemMessage() &&
+			!a.RolesGrantPermission(user.GetRoles(), model.PERMISSION_MANAGE_SYSTEM.Id) {
+			return nil, model.NewAppError("createPost", "api.post.create_post.thread_reply_limit.app_error", map[string]interface{}{"MaxReplies": maxReplies}, "root_id="+post.RootId, http.StatusForbidden)
+		}
+
 		if post.ParentId == "" {
 			post.ParentId = post.RootId
 		}
@@ -267,9 +374,13 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		var rejectionError *model.AppError
-		pluginContext := a.PluginContext()
+		ctx := a.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx = plugin.NewContextWithPluginContext(ctx, a.PluginContext())
 		pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
-			replacementPost, rejectionReason := hooks.MessageWillBePosted(pluginContext, post)
+			replacementPost, rejectionReason := hooks.MessageWillBePostedWithContext(ctx, post)
 			if rejectionReason != "" {
 				id := "Post rejected by plugin. " + rejectionReason
 				if rejectionReason == plugin.DismissPostError {
@@ -283,7 +394,7 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 			}
 
 			return true
-		}, plugin.MessageWillBePostedId)
+		}, plugin.MessageWillBePostedWithContextId)
 
 		if rejectionError != nil {
 			return nil, rejectionError
@@ -295,10 +406,6 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		return nil, err
 	}
 
-	// Update the mapping from pending post id to the actual post id, for any clients that
-	// might be duplicating requests.
-	a.Srv().seenPendingPostIdsCache.SetWithExpiry(post.PendingPostId, rpost.Id, PENDING_POST_IDS_CACHE_TTL)
-
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv().Go(func() {
 			pluginContext := a.PluginContext()
@@ -559,6 +666,12 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 	}
 
 	if !safeUpdate {
+		if len(post.FileIds) > 0 && !oldPost.FileIds.Equals(post.FileIds) {
+			if err = a.checkChannelAllowsFileAttachments(channel, oldPost.UserId); err != nil {
+				return nil, err
+			}
+		}
+
 		newPost.IsPinned = post.IsPinned
 		newPost.HasReactions = post.HasReactions
 		newPost.FileIds = post.FileIds
@@ -586,6 +699,12 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		}
 	}
 
+	if newPost.Message != oldPost.Message {
+		if nErr := a.Srv().Store.Post().SaveEditHistory(oldPost); nErr != nil {
+			return nil, model.NewAppError("UpdatePost", "app.post.save_edit_history.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
 	rpost, err := a.Srv().Store.Post().Update(newPost, oldPost)
 	if err != nil {
 		return nil, err
@@ -666,6 +785,20 @@ func (a *App) GetPostThread(postId string, skipFetchThreads bool) (*model.PostLi
 	return a.Srv().Store.Post().Get(postId, skipFetchThreads)
 }
 
+// GetPostEditHistory returns the previous contents of the given post, oldest first.
+func (a *App) GetPostEditHistory(postId string) ([]*model.PostEdit, *model.AppError) {
+	// The history of a deleted post isn't found, like the post itself.
+	if _, err := a.Srv().Store.Post().GetSingle(postId); err != nil {
+		return nil, err
+	}
+
+	postEdits, err := a.Srv().Store.Post().GetEditHistory(postId)
+	if err != nil {
+		return nil, model.NewAppError("GetPostEditHistory", "app.post.get_edit_history.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return postEdits, nil
+}
+
 func (a *App) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
 	return a.Srv().Store.Post().GetFlaggedPosts(userId, offset, limit)
 }
@@ -678,6 +811,102 @@ func (a *App) GetFlaggedPostsForChannel(userId, channelId string, offset int, li
 	return a.Srv().Store.Post().GetFlaggedPostsForChannel(userId, channelId, offset, limit)
 }
 
+// GetFlaggedPostsWithContext returns a page of the posts flagged by the user, most recently flagged
+// first, prepared for the client with the names of their channel and team. Posts in channels the
+// user can no longer read are left out, so a page may hold fewer posts than the limit.
+func (a *App) GetFlaggedPostsWithContext(userId string, offset, limit int) (*model.PostList, *model.AppError) {
+	savedPosts, err := a.Srv().Store.SavedPost().GetForUser(userId, nil, offset, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetFlaggedPostsWithContext", "app.saved_post.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	pl := model.NewPostList()
+	if len(savedPosts) == 0 {
+		return pl, nil
+	}
+
+	postIds := make([]string, 0, len(savedPosts))
+	for _, savedPost := range savedPosts {
+		postIds = append(postIds, savedPost.PostId)
+	}
+
+	posts, appErr := a.Srv().Store.Post().GetPostsByIds(postIds)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	postsById := make(map[string]*model.Post, len(posts))
+	channelIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if post.DeleteAt != 0 {
+			continue
+		}
+		postsById[post.Id] = post
+		channelIds = append(channelIds, post.ChannelId)
+	}
+
+	channels, appErr := a.Srv().Store.Channel().GetChannelsByIds(channelIds, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channelsById := make(map[string]*model.Channel, len(channels))
+	for _, channel := range channels {
+		if a.HasPermissionToChannel(userId, channel.Id, model.PERMISSION_READ_CHANNEL) {
+			channelsById[channel.Id] = channel
+		}
+	}
+
+	teamsById := make(map[string]*model.Team)
+	for _, channel := range channelsById {
+		if channel.TeamId == "" || teamsById[channel.TeamId] != nil {
+			continue
+		}
+
+		team, appErr := a.Srv().Store.Team().Get(channel.TeamId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		teamsById[team.Id] = team
+	}
+
+	for _, savedPost := range savedPosts {
+		post, ok := postsById[savedPost.PostId]
+		if !ok || channelsById[post.ChannelId] == nil {
+			continue
+		}
+
+		pl.AddPost(post)
+		pl.AddOrder(post.Id)
+	}
+
+	pl = a.PreparePostListForClient(pl)
+
+	for _, savedPost := range savedPosts {
+		post, ok := pl.Posts[savedPost.PostId]
+		if !ok {
+			continue
+		}
+
+		channel := channelsById[post.ChannelId]
+		channelContext := &model.PostChannelContext{
+			ChannelName:        channel.Name,
+			ChannelDisplayName: channel.DisplayName,
+			ChannelType:        channel.Type,
+			TeamId:             channel.TeamId,
+		}
+		if team := teamsById[channel.TeamId]; team != nil {
+			channelContext.TeamName = team.Name
+			channelContext.TeamDisplayName = team.DisplayName
+		}
+
+		post.Metadata.SavedPost = savedPost
+		post.Metadata.ChannelContext = channelContext
+	}
+
+	return pl, nil
+}
+
 func (a *App) GetPermalinkPost(postId string, userId string) (*model.PostList, *model.AppError) {
 	list, err := a.Srv().Store.Post().Get(postId, false)
 	if err != nil {
@@ -881,7 +1110,10 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 func (a *App) DeleteFlaggedPosts(postId string) {
 	if err := a.Srv().Store.Preference().DeleteCategoryAndName(model.PREFERENCE_CATEGORY_FLAGGED_POST, postId); err != nil {
 		mlog.Warn("Unable to delete flagged post preference when deleting post.", mlog.Err(err))
-		return
+	}
+
+	if err := a.Srv().Store.SavedPost().DeleteForPost(postId); err != nil {
+		mlog.Warn("Unable to delete saved posts when deleting post.", mlog.Err(err))
 	}
 }
 
@@ -896,33 +1128,8 @@ func (a *App) DeletePostFiles(post *model.Post) {
 }
 
 func (a *App) parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId string, includeDeleted bool) (*model.Channel, error) {
-	if strings.HasPrefix(channelName, "@") && strings.Contains(channelName, ",") {
-		var userIds []string
-		users, err := a.GetUsersByUsernames(strings.Split(channelName[1:], ","), false, nil)
-		if err != nil {
-			return nil, err
-		}
-		for _, user := range users {
-			userIds = append(userIds, user.Id)
-		}
-
-		channel, err := a.GetGroupChannel(userIds)
-		if err != nil {
-			return nil, err
-		}
-		return channel, nil
-	}
-
-	if strings.HasPrefix(channelName, "@") && !strings.Contains(channelName, ",") {
-		user, err := a.GetUserByUsername(channelName[1:])
-		if err != nil {
-			return nil, err
-		}
-		channel, err := a.GetOrCreateDirectChannel(userId, user.Id)
-		if err != nil {
-			return nil, err
-		}
-		return channel, nil
+	if usernames, ok := model.SearchChannelTargetUsernames(channelName); ok {
+		return a.getDirectOrGroupChannelForSearch(usernames, userId)
 	}
 
 	channel, err := a.GetChannelByName(channelName, teamId, includeDeleted)
@@ -932,6 +1139,37 @@ func (a *App) parseAndFetchChannelIdByNameFromInFilter(channelName, userId, team
 	return channel, nil
 }
 
+// getDirectOrGroupChannelForSearch returns the existing direct or group message between the searcher
+// and the given users, without creating it.
+func (a *App) getDirectOrGroupChannelForSearch(usernames []string, userId string) (*model.Channel, error) {
+	users, err := a.GetUsersByUsernames(usernames, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(usernames) {
+		return nil, store.NewErrNotFound("User", "usernames="+strings.Join(usernames, ","))
+	}
+
+	userIds := []string{userId}
+	for _, user := range users {
+		if user.Id != userId {
+			userIds = append(userIds, user.Id)
+		}
+	}
+
+	var channelName string
+	switch len(userIds) {
+	case 1:
+		channelName = model.GetDMNameFromIds(userId, userId)
+	case 2:
+		channelName = model.GetDMNameFromIds(userIds[0], userIds[1])
+	default:
+		channelName = model.GetGroupNameFromUserIds(userIds)
+	}
+
+	return a.Srv().Store.Channel().GetByName("", channelName, true)
+}
+
 func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*model.SearchParams, modifierFun func(*model.SearchParams)) (*model.PostList, *model.AppError) {
 	var wg sync.WaitGroup
 
@@ -973,7 +1211,14 @@ func (a *App) convertChannelNamesToChannelIds(channels []string, userId string,
 	for idx, channelName := range channels {
 		channel, err := a.parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId, includeDeletedChannels)
 		if err != nil {
-			mlog.Error("error getting channel id by name from in filter", mlog.Err(err))
+			// Unknown channels are left as they are, so the search finds nothing in them.
+			var nfErr *store.ErrNotFound
+			var appErr *model.AppError
+			if errors.As(err, &nfErr) || (errors.As(err, &appErr) && appErr.StatusCode == http.StatusNotFound) {
+				mlog.Debug("Channel from in filter not found", mlog.String("channel_name", channelName))
+			} else {
+				mlog.Error("error getting channel id by name from in filter", mlog.Err(err))
+			}
 			continue
 		}
 		channels[idx] = channel.Id
@@ -1012,9 +1257,11 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 	}
 
 	finalParamsList := []*model.SearchParams{}
+	timeZone := a.getSearchTimeZone(userId)
 
 	for _, params := range paramsList {
 		params.OrTerms = isOrSearch
+		params.TimeZone = timeZone
 		// Don't allow users to search for "*"
 		if params.Terms != "*" {
 			// Convert channel names to channel IDs
@@ -1042,6 +1289,21 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 	return postSearchResults, nil
 }
 
+// getSearchTimeZone returns the time zone the search dates of the given user are in, falling back to their
+// automatic time zone if they picked a manual time zone but never set it.
+func (a *App) getSearchTimeZone(userId string) string {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return ""
+	}
+
+	if timeZone := user.GetPreferredTimezone(); timeZone != "" {
+		return timeZone
+	}
+
+	return user.Timezone["automaticTimezone"]
+}
+
 func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo, *model.AppError) {
 
 	pchan := make(chan store.StoreResult, 1)
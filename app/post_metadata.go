@@ -55,15 +55,46 @@ func (a *App) PreparePostListForClient(originalList *model.PostList) *model.Post
 		PrevPostId: originalList.PrevPostId,
 	}
 
+	reactionsByPostId := a.getReactionsForPostList(originalList)
+
 	for id, originalPost := range originalList.Posts {
-		post := a.PreparePostForClient(originalPost, false, false)
+		post := a.preparePostForClient(originalPost, false, false, reactionsByPostId)
 
 		list.Posts[id] = post
 	}
 
+	posts := make([]*model.Post, 0, len(list.Posts))
+	for _, post := range list.Posts {
+		posts = append(posts, post)
+	}
+	a.SetSavedPostsForSession(posts...)
+
 	return list
 }
 
+// getReactionsForPostList fetches the reactions of all the posts of the list at once, rather than
+// post by post. Nil is returned if fetching them fails, leaving each post to fetch its own.
+func (a *App) getReactionsForPostList(list *model.PostList) map[string][]*model.Reaction {
+	var postIds []string
+	for _, post := range list.Posts {
+		if post.HasReactions && post.DeleteAt == 0 {
+			postIds = append(postIds, post.Id)
+		}
+	}
+
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	reactionsByPostId, err := a.Srv().Store.Reaction().GetForPosts(postIds)
+	if err != nil {
+		mlog.Warn("Failed to get reactions for a list of posts", mlog.Err(err))
+		return nil
+	}
+
+	return reactionsByPostId
+}
+
 // OverrideIconURLIfEmoji changes the post icon override URL prop, if it has an emoji icon,
 // so that it points to the URL (relative) of the emoji - static if emoji is default, /api if custom.
 func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
@@ -85,6 +116,12 @@ func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
 }
 
 func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool) *model.Post {
+	return a.preparePostForClient(originalPost, isNewPost, isEditPost, nil)
+}
+
+// preparePostForClient prepares the post like PreparePostForClient, taking its reactions from
+// reactionsByPostId when they were already fetched along with those of other posts.
+func (a *App) preparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool, reactionsByPostId map[string][]*model.Reaction) *model.Post {
 	post := originalPost.Clone()
 
 	// Proxy image links before constructing metadata so that requests go through the proxy
@@ -101,11 +138,12 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 	}
 
 	// Emojis and reaction counts
-	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post); err != nil {
+	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post, reactionsByPostId); err != nil {
 		mlog.Warn("Failed to get emojis and reactions for a post", mlog.String("post_id", post.Id), mlog.Err(err))
 	} else {
 		post.Metadata.Emojis = emojis
 		post.Metadata.Reactions = reactions
+		post.Metadata.ReactionCounts = getReactionCounts(reactions)
 	}
 
 	// Files
@@ -139,13 +177,16 @@ func (a *App) getFileMetadataForPost(post *model.Post, fromMaster bool) ([]*mode
 	return a.GetFileInfosForPost(post.Id, fromMaster)
 }
 
-func (a *App) getEmojisAndReactionsForPost(post *model.Post) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
+func (a *App) getEmojisAndReactionsForPost(post *model.Post, reactionsByPostId map[string][]*model.Reaction) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
 	var reactions []*model.Reaction
 	if post.HasReactions {
-		var err *model.AppError
-		reactions, err = a.GetReactionsForPost(post.Id)
-		if err != nil {
-			return nil, nil, err
+		var ok bool
+		if reactions, ok = reactionsByPostId[post.Id]; !ok {
+			var err *model.AppError
+			reactions, err = a.GetReactionsForPost(post.Id)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
@@ -157,6 +198,19 @@ func (a *App) getEmojisAndReactionsForPost(post *model.Post) ([]*model.Emoji, []
 	return emojis, reactions, nil
 }
 
+func getReactionCounts(reactions []*model.Reaction) map[string]int {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, reaction := range reactions {
+		counts[reaction.EmojiName]++
+	}
+
+	return counts
+}
+
 func (a *App) getEmbedForPost(post *model.Post, firstLink string, isNewPost bool) (*model.PostEmbed, error) {
 	if _, ok := post.GetProps()["attachments"]; ok {
 		return &model.PostEmbed{
@@ -58,6 +58,33 @@ func TestPreparePostListForClient(t *testing.T) {
 	})
 }
 
+func TestPreparePostListForClientReactions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	withReactions := th.CreatePost(th.BasicChannel)
+	withoutReactions := th.CreatePost(th.BasicChannel)
+
+	_, err := th.App.SaveReactionForPost(&model.Reaction{
+		UserId:    th.BasicUser.Id,
+		PostId:    withReactions.Id,
+		EmojiName: "smile",
+	})
+	require.Nil(t, err)
+
+	postList, err := th.App.GetPosts(th.BasicChannel.Id, 0, 10)
+	require.Nil(t, err)
+
+	clientPostList := th.App.PreparePostListForClient(postList)
+
+	reactions := clientPostList.Posts[withReactions.Id].Metadata.Reactions
+	require.Len(t, reactions, 1)
+	assert.Equal(t, "smile", reactions[0].EmojiName)
+	assert.Equal(t, map[string]int{"smile": 1}, clientPostList.Posts[withReactions.Id].Metadata.ReactionCounts)
+
+	assert.Empty(t, clientPostList.Posts[withoutReactions.Id].Metadata.Reactions)
+}
+
 func TestPreparePostForClient(t *testing.T) {
 	var serverURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -169,6 +196,7 @@ func TestPreparePostForClient(t *testing.T) {
 		assert.Equal(t, reaction1, clientPost.Metadata.Reactions[0], "first reaction is incorrect")
 		assert.Equal(t, reaction2, clientPost.Metadata.Reactions[1], "second reaction is incorrect")
 		assert.Equal(t, reaction3, clientPost.Metadata.Reactions[2], "third reaction is incorrect")
+		assert.Equal(t, map[string]int{"smile": 2, "ice_cream": 1}, clientPost.Metadata.ReactionCounts, "should've populated ReactionCounts")
 	})
 
 	t.Run("files", func(t *testing.T) {
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// CreatePostShareToken creates a token granting read access to the given post to the members of the
+// team of its channel, for as long as the config allows. Posts of direct and group channels can't
+// be shared, since those channels don't belong to a team.
+func (a *App) CreatePostShareToken(post *model.Post, creatorId string) (*model.PostShareToken, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostShareTokens {
+		return nil, model.NewAppError("CreatePostShareToken", "app.post_share_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	channel, appErr := a.GetChannel(post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.TeamId == "" {
+		return nil, model.NewAppError("CreatePostShareToken", "app.post_share_token.no_team.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	createAt := model.GetMillis()
+	expiry := time.Duration(*a.Config().ServiceSettings.PostShareTokenExpiryInHours) * time.Hour
+
+	token, err := a.Srv().Store.PostShareToken().Save(&model.PostShareToken{
+		PostId:        post.Id,
+		ChannelId:     channel.Id,
+		TeamId:        channel.TeamId,
+		CreatorId:     creatorId,
+		IncludeThread: *a.Config().ServiceSettings.PostShareTokenIncludeThread,
+		CreateAt:      createAt,
+		ExpiresAt:     createAt + int64(expiry/time.Millisecond),
+	})
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("CreatePostShareToken", "app.post_share_token.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return token, nil
+}
+
+// GetPostShareTokenForSession returns the given post share token if it grants access to the user of
+// the session, which requires the feature to be enabled, the token not to have expired and the user
+// to be a member of the team of the token.
+func (a *App) GetPostShareTokenForSession(session model.Session, tokenString string) (*model.PostShareToken, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostShareTokens {
+		return nil, model.NewAppError("GetPostShareTokenForSession", "app.post_share_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	token, err := a.Srv().Store.PostShareToken().Get(tokenString)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetPostShareTokenForSession", "app.post_share_token.invalid.app_error", nil, "", http.StatusForbidden)
+		}
+		return nil, model.NewAppError("GetPostShareTokenForSession", "app.post_share_token.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if token.IsExpired() {
+		return nil, model.NewAppError("GetPostShareTokenForSession", "app.post_share_token.invalid.app_error", nil, "expired", http.StatusForbidden)
+	}
+
+	if !a.SessionHasPermissionToTeam(session, token.TeamId, model.PERMISSION_VIEW_TEAM) {
+		return nil, model.NewAppError("GetPostShareTokenForSession", "app.post_share_token.invalid.app_error", nil, "team_id="+token.TeamId, http.StatusForbidden)
+	}
+
+	return token, nil
+}
+
+// RevokePostShareTokensForChannel revokes every post share token of the given channel, and returns
+// the number of tokens revoked.
+func (a *App) RevokePostShareTokensForChannel(channelId string) (int64, *model.AppError) {
+	count, err := a.Srv().Store.PostShareToken().DeleteForChannel(channelId)
+	if err != nil {
+		return 0, model.NewAppError("RevokePostShareTokensForChannel", "app.post_share_token.delete_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
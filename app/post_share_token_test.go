@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestCreatePostShareToken(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePostShareTokens = false })
+
+		_, err := th.App.CreatePostShareToken(post, th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.post_share_token.disabled.app_error", err.Id)
+	})
+
+	t.Run("expires after the configured number of hours", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnablePostShareTokens = true
+			*cfg.ServiceSettings.PostShareTokenExpiryInHours = 2
+			*cfg.ServiceSettings.PostShareTokenIncludeThread = false
+		})
+
+		token, err := th.App.CreatePostShareToken(post, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, th.BasicChannel.Id, token.ChannelId)
+		assert.Equal(t, th.BasicTeam.Id, token.TeamId)
+		assert.Equal(t, th.BasicUser.Id, token.CreatorId)
+		assert.False(t, token.IncludeThread)
+		assert.Equal(t, int64(2*60*60*1000), token.ExpiresAt-token.CreateAt)
+	})
+}
+
+func TestGetPostShareTokenForSession(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePostShareTokens = true })
+
+	post := th.CreatePost(th.BasicChannel)
+	token, err := th.App.CreatePostShareToken(post, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	t.Run("team member", func(t *testing.T) {
+		fetched, err := th.App.GetPostShareTokenForSession(model.Session{UserId: th.BasicUser2.Id}, token.Token)
+		require.Nil(t, err)
+		assert.Equal(t, token.PostId, fetched.PostId)
+	})
+
+	t.Run("user outside the team", func(t *testing.T) {
+		user := th.CreateUser()
+
+		_, err := th.App.GetPostShareTokenForSession(model.Session{UserId: user.Id}, token.Token)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.post_share_token.invalid.app_error", err.Id)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := th.App.GetPostShareTokenForSession(model.Session{UserId: th.BasicUser2.Id}, model.NewId())
+		require.NotNil(t, err)
+		assert.Equal(t, "app.post_share_token.invalid.app_error", err.Id)
+	})
+}
@@ -46,6 +46,60 @@ func TestCreatePostDeduplicate(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, post.Id, duplicatePost.Id, "should have returned previously created post id")
 		require.Equal(t, "message", duplicatePost.Message)
+		require.Equal(t, post.ToJson(), duplicatePost.ToJson(), "should have returned the post as it was first returned")
+	})
+
+	t.Run("response is recorded in the database for other nodes", func(t *testing.T) {
+		pendingPostId := model.NewId()
+		post, err := th.App.CreatePostAsUser(&model.Post{
+			UserId:        th.BasicUser.Id,
+			ChannelId:     th.BasicChannel.Id,
+			Message:       "message",
+			PendingPostId: pendingPostId,
+		}, "", true)
+		require.Nil(t, err)
+
+		kv, err := th.App.Srv().Store.Plugin().Get(PENDING_POST_IDS_KV_PLUGIN_ID, pendingPostIdsKey(post))
+		require.Nil(t, err)
+		require.Equal(t, post.ToJson(), string(kv.Value))
+		require.Equal(t, string(kv.Value), th.App.CreatedPostResponse(post))
+
+		// A response recorded by another node is returned as it is.
+		kv.Value = []byte(`{"id":"` + post.Id + `","message":"recorded elsewhere","pending_post_id":"` + pendingPostId + `"}`)
+		_, err = th.App.Srv().Store.Plugin().SaveOrUpdate(kv)
+		require.Nil(t, err)
+
+		duplicatePost, err := th.App.CreatePostAsUser(&model.Post{
+			UserId:        th.BasicUser.Id,
+			ChannelId:     th.BasicChannel.Id,
+			Message:       "message",
+			PendingPostId: pendingPostId,
+		}, "", true)
+		require.Nil(t, err)
+		require.Equal(t, post.Id, duplicatePost.Id)
+		require.Equal(t, "recorded elsewhere", duplicatePost.Message)
+		require.Equal(t, string(kv.Value), th.App.CreatedPostResponse(duplicatePost))
+	})
+
+	t.Run("pending post ids are scoped to the user", func(t *testing.T) {
+		pendingPostId := model.NewId()
+		post, err := th.App.CreatePostAsUser(&model.Post{
+			UserId:        th.BasicUser.Id,
+			ChannelId:     th.BasicChannel.Id,
+			Message:       "message",
+			PendingPostId: pendingPostId,
+		}, "", true)
+		require.Nil(t, err)
+
+		otherPost, err := th.App.CreatePostAsUser(&model.Post{
+			UserId:        th.BasicUser2.Id,
+			ChannelId:     th.BasicChannel.Id,
+			Message:       "other message",
+			PendingPostId: pendingPostId,
+		}, "", true)
+		require.Nil(t, err)
+		require.NotEqual(t, post.Id, otherPost.Id, "should have created a new post")
+		require.Equal(t, th.BasicUser2.Id, otherPost.UserId)
 	})
 
 	t.Run("post rejected by plugin leaves cache ready for non-deduplicated try", func(t *testing.T) {
@@ -176,7 +230,8 @@ func TestCreatePostDeduplicate(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, "message", post.Message)
 
-		time.Sleep(PENDING_POST_IDS_CACHE_TTL)
+		// Expire the record rather than waiting out the TTL.
+		require.Nil(t, th.App.Srv().Store.Plugin().Delete(PENDING_POST_IDS_KV_PLUGIN_ID, pendingPostIdsKey(post)))
 
 		duplicatePost, err := th.App.CreatePostAsUser(&model.Post{
 			UserId:        th.BasicUser.Id,
@@ -324,6 +379,49 @@ func TestUpdatePostInArchivedChannel(t *testing.T) {
 	require.Equal(t, "api.post.update_post.can_not_update_post_in_deleted.error", err.Id)
 }
 
+func TestPostFileAttachmentsInChannelWithAttachmentsDisabled(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	post := th.CreatePost(channel)
+
+	channel.DisableFileAttachments = model.NewBool(true)
+	channel, err := th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	t.Run("create", func(t *testing.T) {
+		_, err = th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "attached", FileIds: []string{model.NewId()}}, channel, false, true)
+		require.NotNil(t, err)
+		require.Equal(t, "app.channel.file_attachments_disabled.app_error", err.Id)
+
+		_, err = th.App.CreatePost(&model.Post{UserId: th.BasicUser.Id, ChannelId: channel.Id, Message: "not attached"}, channel, false, true)
+		require.Nil(t, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated := post.Clone()
+		updated.FileIds = []string{model.NewId()}
+
+		_, err = th.App.UpdatePost(updated, false)
+		require.NotNil(t, err)
+		require.Equal(t, "app.channel.file_attachments_disabled.app_error", err.Id)
+	})
+
+	t.Run("system admins only if allowed", func(t *testing.T) {
+		adminPost := &model.Post{UserId: th.SystemAdminUser.Id, ChannelId: channel.Id, Message: "attached", FileIds: []string{model.NewId()}}
+
+		_, err = th.App.CreatePost(adminPost.Clone(), channel, false, true)
+		require.NotNil(t, err)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.AdminsBypassDisabledAttachments = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.AdminsBypassDisabledAttachments = false })
+
+		_, err = th.App.CreatePost(adminPost.Clone(), channel, false, true)
+		require.Nil(t, err)
+	})
+}
+
 func TestPostReplyToPostWhereRootPosterLeftChannel(t *testing.T) {
 	// This test ensures that when replying to a root post made by a user who has since left the channel, the reply
 	// post completes successfully. This is a regression test for PLT-6523.
@@ -826,6 +924,48 @@ func TestPatchPost(t *testing.T) {
 	})
 }
 
+func TestCreatePostThreadReplyLimit(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxRepliesPerThread = 2 })
+
+	rootPost := th.CreatePost(th.BasicChannel)
+
+	reply := func(userId string) *model.AppError {
+		_, err := th.App.CreatePostAsUser(&model.Post{
+			UserId:    userId,
+			ChannelId: th.BasicChannel.Id,
+			RootId:    rootPost.Id,
+			Message:   "reply",
+		}, "", true)
+		return err
+	}
+
+	require.Nil(t, reply(th.BasicUser.Id))
+	require.Nil(t, reply(th.BasicUser.Id))
+
+	t.Run("replies past the limit are rejected", func(t *testing.T) {
+		err := reply(th.BasicUser.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.post.create_post.thread_reply_limit.app_error", err.Id)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		th.LinkUserToTeam(th.SystemAdminUser, th.BasicTeam)
+		th.AddUserToChannel(th.SystemAdminUser, th.BasicChannel)
+
+		require.Nil(t, reply(th.SystemAdminUser.Id))
+	})
+
+	t.Run("zero means unlimited", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxRepliesPerThread = 0 })
+
+		require.Nil(t, reply(th.BasicUser.Id))
+	})
+}
+
 func TestCreatePostAsUser(t *testing.T) {
 	t.Run("marks channel as viewed for regular user", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -1180,6 +1320,109 @@ func TestSearchPostsInTeamForUser(t *testing.T) {
 	})
 }
 
+func TestSearchPostsInTeamForUserInDirectAndGroupMessages(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ElasticsearchSettings.EnableSearching = false
+	})
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	gm := th.CreateGroupChannel(th.BasicUser2, th.SystemAdminUser)
+	channelPost := th.CreatePost(th.BasicChannel)
+	dmPost := th.CreatePost(dm)
+	gmPost := th.CreatePost(gm)
+
+	search := func(terms string) []string {
+		results, err := th.App.SearchPostsInTeamForUser(terms, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+		require.Nil(t, err)
+		return results.Order
+	}
+
+	t.Run("should search in a direct message", func(t *testing.T) {
+		assert.Equal(t, []string{dmPost.Id}, search("in:@"+th.BasicUser2.Username))
+	})
+
+	t.Run("should search in a group message", func(t *testing.T) {
+		assert.Equal(t, []string{gmPost.Id}, search("in:@"+th.BasicUser2.Username+","+th.SystemAdminUser.Username))
+		assert.Equal(t, []string{gmPost.Id}, search("in:@"+th.SystemAdminUser.Username+",@"+th.BasicUser.Username+",@"+th.BasicUser2.Username))
+		assert.Equal(t, []string{gmPost.Id}, search("in:"+gm.Name))
+	})
+
+	t.Run("should exclude a direct message", func(t *testing.T) {
+		order := search("-in:@" + th.BasicUser2.Username)
+		assert.Contains(t, order, channelPost.Id)
+		assert.Contains(t, order, gmPost.Id)
+		assert.NotContains(t, order, dmPost.Id)
+	})
+
+	t.Run("should find nothing without an existing conversation", func(t *testing.T) {
+		user := th.CreateUser()
+
+		assert.Empty(t, search("in:@"+user.Username))
+		assert.Empty(t, search("in:@"+model.NewId()))
+
+		_, err := th.App.Srv().Store.Channel().GetByName("", model.GetDMNameFromIds(th.BasicUser.Id, user.Id), false)
+		assert.Error(t, err, "searching shouldn't create the direct message")
+	})
+}
+
+func TestSearchPostsInTeamForUserInTimeZone(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ElasticsearchSettings.EnableSearching = false
+	})
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.Nil(t, err)
+
+	// Evening posts in New York, which are on the next day in UTC
+	eveningPost, appErr := th.App.CreatePost(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "timezone",
+		CreateAt:  model.GetMillisForTime(time.Date(2024, time.March, 1, 21, 0, 0, 0, newYork)),
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+	dstPost, appErr := th.App.CreatePost(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "timezone",
+		CreateAt:  model.GetMillisForTime(time.Date(2024, time.March, 10, 22, 30, 0, 0, newYork)),
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	search := func(terms string) []string {
+		results, appErr := th.App.SearchPostsInTeamForUser(terms, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+		require.Nil(t, appErr)
+		return results.Order
+	}
+
+	t.Run("without a time zone", func(t *testing.T) {
+		assert.Empty(t, search("timezone on:2024-03-01"))
+		assert.Equal(t, []string{eveningPost.Id}, search("timezone on:2024-03-02"))
+	})
+
+	t.Run("with an automatic time zone", func(t *testing.T) {
+		user := th.BasicUser
+		user.Timezone = model.StringMap{"useAutomaticTimezone": "true", "automaticTimezone": "America/New_York", "manualTimezone": ""}
+		_, appErr := th.App.UpdateUser(user, false)
+		require.Nil(t, appErr)
+
+		assert.Equal(t, []string{eveningPost.Id}, search("timezone on:2024-03-01"))
+		assert.Empty(t, search("timezone on:2024-03-02"))
+		assert.Equal(t, []string{dstPost.Id}, search("timezone on:2024-03-10"))
+		assert.Equal(t, []string{dstPost.Id}, search("timezone after:2024-03-09 before:2024-03-11"))
+	})
+
+	t.Run("with an explicit offset", func(t *testing.T) {
+		assert.Equal(t, []string{eveningPost.Id}, search("timezone on:2024-03-02+00:00"))
+	})
+}
+
 func TestCountMentionsFromPost(t *testing.T) {
 	t.Run("should not count posts without mentions", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -1845,3 +2088,44 @@ func TestFillInPostProps(t *testing.T) {
 		assert.Equal(t, post1.Props, model.StringInterface{"disable_group_highlight": true})
 	})
 }
+
+func TestGetFlaggedPostsWithContext(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+	dmChannel := th.CreateDmChannel(th.BasicUser2)
+
+	first := th.CreatePost(th.BasicChannel)
+	second := th.CreatePost(dmChannel)
+	lostAccess := th.CreatePost(privateChannel)
+
+	for _, post := range []*model.Post{first, second, lostAccess} {
+		_, err := th.App.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: post.Id})
+		require.Nil(t, err)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.Nil(t, th.App.RemoveUserFromChannel(th.BasicUser.Id, th.SystemAdminUser.Id, privateChannel))
+
+	postList, err := th.App.GetFlaggedPostsWithContext(th.BasicUser.Id, 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, []string{second.Id, first.Id}, postList.Order)
+
+	channelContext := postList.Posts[first.Id].Metadata.ChannelContext
+	require.NotNil(t, channelContext)
+	assert.Equal(t, th.BasicChannel.DisplayName, channelContext.ChannelDisplayName)
+	assert.Equal(t, th.BasicTeam.Id, channelContext.TeamId)
+	assert.Equal(t, th.BasicTeam.Name, channelContext.TeamName)
+	assert.Equal(t, th.BasicTeam.DisplayName, channelContext.TeamDisplayName)
+	assert.NotNil(t, postList.Posts[first.Id].Metadata.SavedPost)
+
+	channelContext = postList.Posts[second.Id].Metadata.ChannelContext
+	require.NotNil(t, channelContext)
+	assert.Equal(t, model.CHANNEL_DIRECT, channelContext.ChannelType)
+	assert.Empty(t, channelContext.TeamName)
+
+	postList, err = th.App.GetFlaggedPostsWithContext(th.BasicUser.Id, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{first.Id}, postList.Order)
+}
@@ -53,6 +53,8 @@ func (a *App) UpdatePreferences(userId string, preferences model.Preferences) *m
 		return err
 	}
 
+	a.syncSavedPostsWithPreferences(userId, preferences, false)
+
 	if err := a.Srv().Store.Channel().UpdateSidebarChannelsByPreferences(&preferences); err != nil {
 		return model.NewAppError("UpdatePreferences", "api.preference.update_preferences.update_sidebar.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -84,6 +86,8 @@ func (a *App) DeletePreferences(userId string, preferences model.Preferences) *m
 		}
 	}
 
+	a.syncSavedPostsWithPreferences(userId, preferences, true)
+
 	if err := a.Srv().Store.Channel().DeleteSidebarChannelsByPreferences(&preferences); err != nil {
 		return model.NewAppError("DeletePreferences", "api.preference.delete_preferences.update_sidebar.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -4,45 +4,88 @@
 package app
 
 import (
+	"context"
 	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/cache"
 	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/pkg/errors"
 	"github.com/throttled/throttled"
-	"github.com/throttled/throttled/store/memstore"
 )
 
+const (
+	RATE_LIMIT_CLASS_DEFAULT = "default"
+	RATE_LIMIT_CLASS_AUTH    = "auth"
+	RATE_LIMIT_CLASS_FILES   = "files"
+)
+
+// The requests to these paths, and the paths below them, are limited by the stricter budget of
+// RATE_LIMIT_CLASS_AUTH.
+var rateLimitAuthPaths = []string{
+	"/api/v4/users/login",
+	"/api/v4/users/password/reset",
+	"/api/v4/users/mfa",
+	"/api/v4/users/email/verify",
+	"/api/v4/users/availability",
+	"/oauth/access_token",
+}
+
+var rateLimitClasses = []string{RATE_LIMIT_CLASS_DEFAULT, RATE_LIMIT_CLASS_AUTH, RATE_LIMIT_CLASS_FILES}
+
+type rateLimitKeyContextKey struct{}
+
 type RateLimiter struct {
-	throttledRateLimiter *throttled.GCRARateLimiter
+	limiters             map[string]*throttled.GCRARateLimiter
+	store                rateLimitStore
+	subpath              string
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
+
+	// userIdForToken returns the id of the user the session token belongs to, or an empty string
+	// when it isn't known without querying the database.
+	userIdForToken func(token string) string
 }
 
+// NewRateLimiter creates a rate limiter keeping its counters in memory.
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
-	store, err := memstore.New(*settings.MemoryStoreSize)
-	if err != nil {
-		return nil, errors.Wrap(err, utils.T("api.server.start_server.rate_limiting_memory_store"))
+	if *settings.MemoryStoreSize <= 0 {
+		return nil, errors.New(utils.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
-	quota := throttled.RateQuota{
-		MaxRate:  throttled.PerSec(*settings.PerSec),
-		MaxBurst: *settings.MaxBurst,
+	return newRateLimiter(settings, trustedProxyIPHeader, newRateLimitCacheStore(cache.NewLRU(&cache.LRUOptions{
+		Name: "RateLimit",
+		Size: *settings.MemoryStoreSize,
+	})))
+}
+
+// newRateLimiter creates a rate limiter keeping its counters in the given store.
+func newRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string, store rateLimitStore) (*RateLimiter, error) {
+	quotas := map[string]throttled.RateQuota{
+		RATE_LIMIT_CLASS_DEFAULT: {MaxRate: throttled.PerSec(*settings.PerSec), MaxBurst: *settings.MaxBurst},
+		RATE_LIMIT_CLASS_AUTH:    {MaxRate: throttled.PerSec(*settings.AuthPerSec), MaxBurst: *settings.AuthMaxBurst},
+		RATE_LIMIT_CLASS_FILES:   {MaxRate: throttled.PerSec(*settings.FilesPerSec), MaxBurst: *settings.FilesMaxBurst},
 	}
 
-	throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
-	if err != nil {
-		return nil, errors.Wrap(err, utils.T("api.server.start_server.rate_limiting_rate_limiter"))
+	limiters := make(map[string]*throttled.GCRARateLimiter, len(quotas))
+	for class, quota := range quotas {
+		limiter, err := throttled.NewGCRARateLimiter(store, quota)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.T("api.server.start_server.rate_limiting_rate_limiter"))
+		}
+		limiters[class] = limiter
 	}
 
 	return &RateLimiter{
-		throttledRateLimiter: throttledRateLimiter,
+		limiters:             limiters,
+		store:                store,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -54,9 +97,16 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
 	if rl.useAuth {
+		// An empty token would put every request sending one in the same budget.
 		token, tokenLocation := ParseAuthTokenFromRequest(r)
-		if tokenLocation != TokenLocationNotFound {
-			key += token
+		if tokenLocation != TokenLocationNotFound && token != "" {
+			// Key by user when possible, so that all the sessions of a user share the same budget.
+			// Authenticated requests aren't varied by the header, so that their budget is the one
+			// UserIdRateLimit takes from.
+			if userId := rl.lookupUserId(token); userId != "" {
+				return userId
+			}
+			return token
 		} else if rl.useIP { // If we don't find an authentication token and IP based is enabled, fall back to IP
 			key += utils.GetIpAddress(r, rl.trustedProxyIPHeader)
 		}
@@ -66,15 +116,33 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 
 	// Note that most of the time the user won't have to set this because the utils.GetIpAddress above tries the
 	// most common headers anyway.
-	if rl.header != "" {
-		key += strings.ToLower(r.Header.Get(rl.header))
+	return rl.keyWithHeader(key, r.Header.Get(rl.header))
+}
+
+// keyWithHeader returns the key of the requests from the given principal sending the given value in
+// the header the requests are varied by, if any.
+func (rl *RateLimiter) keyWithHeader(key string, headerValue string) string {
+	if rl.header == "" {
+		return key
 	}
 
-	return key
+	return key + strings.ToLower(headerValue)
+}
+
+func (rl *RateLimiter) lookupUserId(token string) string {
+	if rl.userIdForToken == nil {
+		return ""
+	}
+
+	return rl.userIdForToken(token)
 }
 
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimitWriter(RATE_LIMIT_CLASS_DEFAULT, key, w)
+}
+
+func (rl *RateLimiter) rateLimitWriter(class string, key string, w http.ResponseWriter) bool {
+	limited, context, err := rl.limiters[class].RateLimit(rateLimitStoreKey(class, key), 1)
 	if err != nil {
 		mlog.Critical("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
 		return false
@@ -83,46 +151,157 @@ func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
 	setRateLimitHeaders(w, context)
 
 	if limited {
-		mlog.Error("Denied due to throttling settings code=429", mlog.String("key", key))
+		mlog.Error("Denied due to throttling settings code=429", mlog.String("key", key), mlog.String("class", class))
 		http.Error(w, "limit exceeded", 429)
 	}
 
 	return limited
 }
 
-func (rl *RateLimiter) UserIdRateLimit(userId string, w http.ResponseWriter) bool {
-	if rl.useAuth {
-		if rl.RateLimitWriter(userId, w) {
-			return true
-		}
+// WebSocketRateLimit limits a websocket request standing in for a REST request, taking from the
+// same budget the REST request would. The key is the one generated for the request that opened the
+// websocket, and the request is then limited by the id of the user as well, as done by
+// UserIdRateLimit.
+func (rl *RateLimiter) WebSocketRateLimit(key string, userId string) bool {
+	if key == "" && rl.useAuth {
+		key = userId
 	}
+
+	if key != "" && rl.rateLimit(RATE_LIMIT_CLASS_DEFAULT, key) {
+		return true
+	}
+
+	if rl.useAuth && key != userId {
+		return rl.rateLimit(RATE_LIMIT_CLASS_DEFAULT, userId)
+	}
+
 	return false
 }
 
+func (rl *RateLimiter) rateLimit(class string, key string) bool {
+	limited, _, err := rl.limiters[class].RateLimit(rateLimitStoreKey(class, key), 1)
+	if err != nil {
+		mlog.Critical("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
+		return false
+	}
+
+	if limited {
+		mlog.Error("Denied due to throttling settings code=429", mlog.String("key", key), mlog.String("class", class))
+	}
+
+	return limited
+}
+
+// UserIdRateLimit limits the request by the id of the user once the session is known, unless the
+// request was already limited by that user.
+func (rl *RateLimiter) UserIdRateLimit(userId string, w http.ResponseWriter, r *http.Request) bool {
+	if !rl.useAuth {
+		return false
+	}
+
+	if key, ok := r.Context().Value(rateLimitKeyContextKey{}).(string); ok && key == userId {
+		return false
+	}
+
+	return rl.rateLimitWriter(rl.rateLimitClass(r), userId, w)
+}
+
 func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		key := rl.GenerateKey(r)
-		limited := rl.RateLimitWriter(key, w)
+		limited := rl.rateLimitWriter(rl.rateLimitClass(r), key, w)
 
 		if !limited {
-			wrappedHandler.ServeHTTP(w, r)
+			wrappedHandler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rateLimitKeyContextKey{}, key)))
 		}
 	})
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// GetStatus returns the current budget of the key for each class of requests, without consuming it.
+// The key is a user id, or an IP address or session token, along with the value of the header the
+// requests are varied by, if any.
+func (rl *RateLimiter) GetStatus(key string, headerValue string) ([]*model.RateLimitStatus, error) {
+	key = rl.principalKey(key, headerValue)
+
+	statuses := make([]*model.RateLimitStatus, 0, len(rateLimitClasses))
+	for _, class := range rateLimitClasses {
+		_, context, err := rl.limiters[class].RateLimit(rateLimitStoreKey(class, key), 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the %s rate limit of key %s", class, key)
+		}
+
+		statuses = append(statuses, &model.RateLimitStatus{
+			Class:      class,
+			Limit:      context.Limit,
+			Remaining:  context.Remaining,
+			ResetAfter: int64(context.ResetAfter / time.Millisecond),
+		})
+	}
+
+	return statuses, nil
+}
+
+// Reset restores the full budget of the key, given like for GetStatus, for each class of requests.
+func (rl *RateLimiter) Reset(key string, headerValue string) error {
+	key = rl.principalKey(key, headerValue)
+
+	for _, class := range rateLimitClasses {
+		if err := rl.store.Remove(rateLimitStoreKey(class, key)); err != nil {
+			return errors.Wrapf(err, "failed to reset the %s rate limit of key %s", class, key)
+		}
+	}
+
+	return nil
+}
+
+// principalKey returns the key the limiter uses for the requests of the principal, the same way
+// GenerateKey does. Requests keyed by user or session token aren't varied by the header.
+func (rl *RateLimiter) principalKey(key string, headerValue string) string {
+	if rl.useAuth && model.IsValidId(key) {
+		return key
+	}
+
+	return rl.keyWithHeader(key, headerValue)
+}
+
+func rateLimitStoreKey(class, key string) string {
+	return class + ":" + key
+}
+
+// rateLimitClass returns the class of the request, which decides the budget it's limited by.
+func (rl *RateLimiter) rateLimitClass(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, rl.subpath)
+
+	for _, authPath := range rateLimitAuthPaths {
+		if path == authPath || strings.HasPrefix(path, authPath+"/") {
+			return RATE_LIMIT_CLASS_AUTH
+		}
+	}
+
+	if r.Method == http.MethodGet && (strings.HasPrefix(path, "/api/v4/files/") || (strings.HasPrefix(path, "/files/") && strings.HasSuffix(path, "/public"))) {
+		return RATE_LIMIT_CLASS_FILES
+	}
+
+	return RATE_LIMIT_CLASS_DEFAULT
+}
+
+// Copied from https://github.com/throttled/throttled http.go, with the RateLimit-* headers of the
+// IETF draft alongside the original ones.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
 	if v := context.Limit; v >= 0 {
 		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Add("RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
 		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Add("RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
 		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Add("RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
@@ -130,3 +309,33 @@ func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResul
 		w.Header().Add("Retry-After", strconv.Itoa(vi))
 	}
 }
+
+// GetRateLimitStatus returns the current budget of the key, a user id or an IP address, for each
+// class of requests. The header value is the one sent in the header the requests are varied by, if
+// any.
+func (a *App) GetRateLimitStatus(key string, headerValue string) ([]*model.RateLimitStatus, *model.AppError) {
+	if a.Srv().RateLimiter == nil {
+		return nil, model.NewAppError("GetRateLimitStatus", "app.rate_limit.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	statuses, err := a.Srv().RateLimiter.GetStatus(key, headerValue)
+	if err != nil {
+		return nil, model.NewAppError("GetRateLimitStatus", "app.rate_limit.get_status.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return statuses, nil
+}
+
+// ResetRateLimit restores the full budget of the key, a user id or an IP address, for each class
+// of requests. The header value is the one sent in the header the requests are varied by, if any.
+func (a *App) ResetRateLimit(key string, headerValue string) *model.AppError {
+	if a.Srv().RateLimiter == nil {
+		return model.NewAppError("ResetRateLimit", "app.rate_limit.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if err := a.Srv().RateLimiter.Reset(key, headerValue); err != nil {
+		return model.NewAppError("ResetRateLimit", "app.rate_limit.reset.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
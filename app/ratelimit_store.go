@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/throttled/throttled"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/cache"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// RATE_LIMIT_KV_PLUGIN_ID is the plugin id under which the rate limit counters of a cluster are
+// kept in the plugin key value store. The colon isn't allowed in plugin ids, so no plugin can read
+// or overwrite the counters.
+const RATE_LIMIT_KV_PLUGIN_ID = "mattermost:ratelimit"
+
+// rateLimitStore keeps the state of the rate limiters.
+type rateLimitStore interface {
+	throttled.GCRAStore
+	Remove(key string) error
+}
+
+// rateLimitCacheStore keeps the state of the rate limiters in a cache, which limits the requests
+// made to this node only.
+type rateLimitCacheStore struct {
+	cache cache.Cache
+	mutex sync.Mutex
+}
+
+func newRateLimitCacheStore(counters cache.Cache) *rateLimitCacheStore {
+	return &rateLimitCacheStore{cache: counters}
+}
+
+func (s *rateLimitCacheStore) get(key string) (int64, error) {
+	var value int64
+	if err := s.cache.Get(key, &value); err == cache.ErrKeyNotFound {
+		return -1, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (s *rateLimitCacheStore) GetWithTime(key string) (int64, time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	value, err := s.get(key)
+	return value, time.Now(), err
+}
+
+func (s *rateLimitCacheStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if current, err := s.get(key); err != nil {
+		return false, err
+	} else if current != -1 {
+		return false, nil
+	}
+
+	// Peeking at a key that doesn't exist sets it with no ttl, which the cache would keep forever.
+	if ttl <= 0 {
+		return true, nil
+	}
+
+	return true, s.cache.SetWithExpiry(key, value, ttl)
+}
+
+func (s *rateLimitCacheStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if current, err := s.get(key); err != nil {
+		return false, err
+	} else if current != old {
+		return false, nil
+	}
+
+	if ttl <= 0 {
+		return true, s.cache.Remove(key)
+	}
+
+	return true, s.cache.SetWithExpiry(key, new, ttl)
+}
+
+func (s *rateLimitCacheStore) Remove(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cache.Remove(key)
+}
+
+// rateLimitKVStore keeps the state of the rate limiters in the plugin key value store, so that the
+// nodes of a cluster share the same budgets. Counters are read from the master and updated with
+// compare-and-set, so nodes racing on the same key retry rather than let extra requests through.
+// Expired counters are removed along with the expired plugin keys.
+type rateLimitKVStore struct {
+	store store.PluginStore
+}
+
+func newRateLimitKVStore(pluginStore store.PluginStore) *rateLimitKVStore {
+	return &rateLimitKVStore{store: pluginStore}
+}
+
+// kvKey hashes the key, which may hold a header value of any length, to fit the key column.
+func (s *rateLimitKVStore) kvKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (s *rateLimitKVStore) kv(key string, value int64, ttl time.Duration) *model.PluginKeyValue {
+	return &model.PluginKeyValue{
+		PluginId: RATE_LIMIT_KV_PLUGIN_ID,
+		Key:      s.kvKey(key),
+		Value:    []byte(strconv.FormatInt(value, 10)),
+		ExpireAt: model.GetMillis() + int64(ttl/time.Millisecond),
+	}
+}
+
+func (s *rateLimitKVStore) GetWithTime(key string) (int64, time.Time, error) {
+	kv, err := s.store.GetFromMaster(RATE_LIMIT_KV_PLUGIN_ID, s.kvKey(key))
+	if err != nil && err.StatusCode == http.StatusNotFound {
+		return -1, time.Now(), nil
+	} else if err != nil {
+		return 0, time.Now(), err
+	}
+
+	value, parseErr := strconv.ParseInt(string(kv.Value), 10, 64)
+	if parseErr != nil {
+		return 0, time.Now(), parseErr
+	}
+
+	return value, time.Now(), nil
+}
+
+func (s *rateLimitKVStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	// Peeking at a key that doesn't exist sets it with no ttl, which would be kept forever.
+	if ttl <= 0 {
+		return true, nil
+	}
+
+	set, err := s.store.CompareAndSet(s.kv(key, value, ttl), nil)
+	if err != nil {
+		return false, err
+	}
+
+	return set, nil
+}
+
+func (s *rateLimitKVStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	oldValue := []byte(strconv.FormatInt(old, 10))
+
+	if ttl <= 0 {
+		deleted, err := s.store.CompareAndDelete(&model.PluginKeyValue{PluginId: RATE_LIMIT_KV_PLUGIN_ID, Key: s.kvKey(key)}, oldValue)
+		if err != nil {
+			return false, err
+		}
+		return deleted, nil
+	}
+
+	swapped, err := s.store.CompareAndSet(s.kv(key, new, ttl), oldValue)
+	if err != nil {
+		return false, err
+	}
+
+	return swapped, nil
+}
+
+func (s *rateLimitKVStore) Remove(key string) error {
+	if err := s.store.Delete(RATE_LIMIT_KV_PLUGIN_ID, s.kvKey(key)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +23,10 @@ func genRateLimitSettings(useAuth, useIP bool, header string) *model.RateLimitSe
 		VaryByRemoteAddr: model.NewBool(useIP),
 		VaryByUser:       model.NewBool(useAuth),
 		VaryByHeader:     header,
+		AuthPerSec:       model.NewInt(1),
+		AuthMaxBurst:     model.NewInt(2),
+		FilesPerSec:      model.NewInt(50),
+		FilesMaxBurst:    model.NewInt(500),
 	}
 }
 
@@ -64,7 +69,7 @@ func TestGenerateKey(t *testing.T) {
 		{false, false, "myheader", "notme", "notme", "resultkey", "resultkey"},
 		{true, true, "", "resultkey", "ipaddr", "notme", "resultkey"},
 		{true, true, "", "", "ipaddr", "notme", "ipaddr"},
-		{true, true, "myheader", "resultkey", "ipaddr", "hadd", "resultkeyhadd"},
+		{true, true, "myheader", "resultkey", "ipaddr", "hadd", "resultkey"},
 		{true, true, "myheader", "", "ipaddr", "hadd", "ipaddrhadd"},
 	}
 
@@ -102,3 +107,212 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+func TestGenerateKey_UserId(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.10.10.5:80"
+	req.AddCookie(&http.Cookie{Name: model.SESSION_COOKIE_TOKEN, Value: "token"})
+
+	rateLimiter, _ := NewRateLimiter(genRateLimitSettings(true, true, ""), nil)
+	rateLimiter.userIdForToken = func(token string) string {
+		if token == "token" {
+			return "userid"
+		}
+		return ""
+	}
+	require.Equal(t, "userid", rateLimiter.GenerateKey(req))
+
+	rateLimiter.userIdForToken = func(string) string { return "" }
+	require.Equal(t, "token", rateLimiter.GenerateKey(req), "the token should be used when the session isn't cached")
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.10.10.5:80"
+	req.Header.Set(model.HEADER_AUTH, model.HEADER_BEARER+" ")
+	require.Equal(t, "10.10.10.5", rateLimiter.GenerateKey(req), "an empty token should fall back to the IP address")
+}
+
+func TestRateLimitClass(t *testing.T) {
+	cases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"POST", "/api/v4/users/login", RATE_LIMIT_CLASS_AUTH},
+		{"POST", "/api/v4/users/password/reset/send", RATE_LIMIT_CLASS_AUTH},
+		{"POST", "/oauth/access_token", RATE_LIMIT_CLASS_AUTH},
+		{"POST", "/api/v4/users/availability", RATE_LIMIT_CLASS_AUTH},
+		{"GET", "/api/v4/files/fileid/preview", RATE_LIMIT_CLASS_FILES},
+		{"GET", "/files/fileid/public", RATE_LIMIT_CLASS_FILES},
+		{"POST", "/api/v4/files", RATE_LIMIT_CLASS_DEFAULT},
+		{"GET", "/api/v4/users/me", RATE_LIMIT_CLASS_DEFAULT},
+		{"GET", "/api/v4/users/username/login", RATE_LIMIT_CLASS_DEFAULT},
+		{"GET", "/api/v4/channels/channelid/posts?q=/api/v4/files/", RATE_LIMIT_CLASS_DEFAULT},
+		{"GET", "/plugins/myplugin/files/public", RATE_LIMIT_CLASS_DEFAULT},
+	}
+
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(false, true, ""), nil)
+	require.NoError(t, err)
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rateLimiter.rateLimitClass(httptest.NewRequest(tc.method, tc.path, nil)))
+		})
+	}
+
+	t.Run("subpath", func(t *testing.T) {
+		rateLimiter.subpath = "/company/mattermost"
+		defer func() { rateLimiter.subpath = "" }()
+
+		assert.Equal(t, RATE_LIMIT_CLASS_AUTH, rateLimiter.rateLimitClass(httptest.NewRequest("POST", "/company/mattermost/api/v4/users/login", nil)))
+		assert.Equal(t, RATE_LIMIT_CLASS_FILES, rateLimiter.rateLimitClass(httptest.NewRequest("GET", "/company/mattermost/files/fileid/public", nil)))
+	})
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(false, true, ""), nil)
+	require.NoError(t, err)
+
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(method, path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":80"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("auth requests have their own budget", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			w := serve("POST", "/api/v4/users/login", "10.0.0.1")
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, strconv.Itoa(2-i), w.Header().Get("RateLimit-Remaining"))
+			assert.Equal(t, w.Header().Get("X-RateLimit-Remaining"), w.Header().Get("RateLimit-Remaining"))
+		}
+
+		w := serve("POST", "/api/v4/users/login", "10.0.0.1")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusOK, serve("GET", "/api/v4/users/me", "10.0.0.1").Code)
+		assert.Equal(t, http.StatusOK, serve("POST", "/api/v4/users/login", "10.0.0.2").Code)
+	})
+
+	t.Run("status and reset", func(t *testing.T) {
+		statuses, err := rateLimiter.GetStatus("10.0.0.1", "")
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+
+		byClass := map[string]*model.RateLimitStatus{}
+		for _, status := range statuses {
+			byClass[status.Class] = status
+		}
+		assert.Equal(t, 0, byClass[RATE_LIMIT_CLASS_AUTH].Remaining)
+		assert.Equal(t, 100, byClass[RATE_LIMIT_CLASS_DEFAULT].Remaining)
+		assert.Equal(t, 501, byClass[RATE_LIMIT_CLASS_FILES].Remaining)
+
+		statuses, err = rateLimiter.GetStatus("10.0.0.1", "")
+		require.NoError(t, err)
+		assert.Equal(t, 100, statuses[0].Remaining, "getting the status shouldn't consume the budget")
+
+		require.NoError(t, rateLimiter.Reset("10.0.0.1", ""))
+		assert.Equal(t, http.StatusOK, serve("POST", "/api/v4/users/login", "10.0.0.1").Code)
+	})
+}
+
+func TestRateLimiterStatusKey(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(true, true, "X-Client"), nil)
+	require.NoError(t, err)
+	userId := model.NewId()
+	rateLimiter.userIdForToken = func(token string) string { return userId }
+
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(token string) {
+		req := httptest.NewRequest("GET", "/api/v4/users/me", nil)
+		req.RemoteAddr = "10.0.0.1:80"
+		req.Header.Set("X-Client", "Mobile")
+		if token != "" {
+			req.Header.Set(model.HEADER_AUTH, model.HEADER_BEARER+" "+token)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	remaining := func(key, headerValue string) int {
+		statuses, err := rateLimiter.GetStatus(key, headerValue)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			if status.Class == RATE_LIMIT_CLASS_DEFAULT {
+				return status.Remaining
+			}
+		}
+		return -1
+	}
+
+	full := remaining(model.NewId(), "")
+
+	serve("token")
+	assert.Equal(t, full-1, remaining(userId, ""), "requests of a user shouldn't be varied by the header")
+
+	serve("")
+	assert.Equal(t, full, remaining("10.0.0.1", ""))
+	assert.Equal(t, full-1, remaining("10.0.0.1", "mobile"))
+
+	require.NoError(t, rateLimiter.Reset(userId, ""))
+	assert.Equal(t, full, remaining(userId, ""))
+}
+
+func TestUserIdRateLimit(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(true, true, ""), nil)
+	require.NoError(t, err)
+	rateLimiter.userIdForToken = func(string) string { return "userid" }
+
+	var limitedByUser bool
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limitedByUser = rateLimiter.UserIdRateLimit("userid", w, r)
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v4/users/me", nil)
+	req.AddCookie(&http.Cookie{Name: model.SESSION_COOKIE_TOKEN, Value: "token"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.False(t, limitedByUser)
+
+	statuses, err := rateLimiter.GetStatus("userid", "")
+	require.NoError(t, err)
+	assert.Equal(t, 100, statuses[0].Remaining, "the request should only count once against the user")
+}
+
+func TestWebSocketRateLimit(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(true, true, ""), nil)
+	require.NoError(t, err)
+
+	remaining := func(key string) int {
+		statuses, err := rateLimiter.GetStatus(key, "")
+		require.NoError(t, err)
+		return statuses[0].Remaining
+	}
+
+	t.Run("limits by the key of the websocket and by the user", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.False(t, rateLimiter.WebSocketRateLimit("10.0.0.1", "userid1"))
+		}
+
+		assert.Equal(t, 99, remaining("10.0.0.1"))
+		assert.Equal(t, 99, remaining("userid1"))
+	})
+
+	t.Run("counts once when keyed by the user", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.False(t, rateLimiter.WebSocketRateLimit("userid2", "userid2"))
+		}
+
+		assert.Equal(t, 99, remaining("userid2"))
+	})
+
+	t.Run("falls back to the user without a key", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.False(t, rateLimiter.WebSocketRateLimit("", "userid3"))
+		}
+
+		assert.Equal(t, 99, remaining("userid3"))
+	})
+}
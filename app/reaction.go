@@ -67,27 +67,19 @@ func (a *App) GetReactionsForPost(postId string) ([]*model.Reaction, *model.AppE
 }
 
 func (a *App) GetBulkReactionsForPosts(postIds []string) (map[string][]*model.Reaction, *model.AppError) {
-	reactions := make(map[string][]*model.Reaction)
-
-	allReactions, err := a.Srv().Store.Reaction().BulkGetForPosts(postIds)
+	reactions, err := a.Srv().Store.Reaction().GetForPosts(postIds)
 	if err != nil {
 		return nil, model.NewAppError("GetBulkReactionsForPosts", "app.reaction.bulk_get_for_post_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	for _, reaction := range allReactions {
-		reactionsForPost := reactions[reaction.PostId]
-		reactionsForPost = append(reactionsForPost, reaction)
-
-		reactions[reaction.PostId] = reactionsForPost
-	}
-
 	reactions = populateEmptyReactions(postIds, reactions)
 	return reactions, nil
 }
 
 func populateEmptyReactions(postIds []string, reactions map[string][]*model.Reaction) map[string][]*model.Reaction {
 	for _, postId := range postIds {
-		if _, present := reactions[postId]; !present {
+		// Reactions read from the cache may be nil rather than empty.
+		if reactions[postId] == nil {
 			reactions[postId] = []*model.Reaction{}
 		}
 	}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// GetSavedPostsForUser returns a page of the posts saved by the given user, most recently saved
+// first. When a label is given, only the posts saved under it are returned.
+func (a *App) GetSavedPostsForUser(userId string, label *string, page, perPage int) ([]*model.SavedPost, *model.AppError) {
+	savedPosts, err := a.Srv().Store.SavedPost().GetForUser(userId, label, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetSavedPostsForUser", "app.saved_post.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return savedPosts, nil
+}
+
+// GetSavedPostLabelsForUser returns the labels the given user saved posts under.
+func (a *App) GetSavedPostLabelsForUser(userId string) ([]string, *model.AppError) {
+	labels, err := a.Srv().Store.SavedPost().GetLabelsForUser(userId)
+	if err != nil {
+		return nil, model.NewAppError("GetSavedPostLabelsForUser", "app.saved_post.get_labels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return labels, nil
+}
+
+// GetSavedPost returns the given post as saved by the given user.
+func (a *App) GetSavedPost(userId, postId string) (*model.SavedPost, *model.AppError) {
+	savedPost, err := a.Srv().Store.SavedPost().Get(userId, postId)
+	if err != nil {
+		return nil, savedPostAppError("GetSavedPost", "app.saved_post.get.app_error", err)
+	}
+
+	return savedPost, nil
+}
+
+// CreateSavedPost saves a post for a user and flags it, so that it also shows up wherever flagged
+// posts are listed.
+func (a *App) CreateSavedPost(savedPost *model.SavedPost) (*model.SavedPost, *model.AppError) {
+	saved, err := a.Srv().Store.SavedPost().Save(savedPost)
+	if err != nil {
+		return nil, savedPostAppError("CreateSavedPost", "app.saved_post.save.app_error", err)
+	}
+
+	if appErr := a.UpdatePreferences(saved.UserId, model.Preferences{flaggedPostPreference(saved.UserId, saved.PostId)}); appErr != nil {
+		return nil, appErr
+	}
+
+	return saved, nil
+}
+
+// PatchSavedPost changes the label or the note of a saved post.
+func (a *App) PatchSavedPost(savedPost *model.SavedPost, patch *model.SavedPostPatch) (*model.SavedPost, *model.AppError) {
+	savedPost.Patch(patch)
+
+	updated, err := a.Srv().Store.SavedPost().Update(savedPost)
+	if err != nil {
+		return nil, savedPostAppError("PatchSavedPost", "app.saved_post.update.app_error", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSavedPost unsaves a post for a user, which also unflags it.
+func (a *App) DeleteSavedPost(savedPost *model.SavedPost) *model.AppError {
+	return a.DeletePreferences(savedPost.UserId, model.Preferences{flaggedPostPreference(savedPost.UserId, savedPost.PostId)})
+}
+
+// syncSavedPostsWithPreferences saves the posts flagged and unsaves the posts unflagged through the
+// given preferences, keeping the label and note of the posts that were already saved.
+func (a *App) syncSavedPostsWithPreferences(userId string, preferences model.Preferences, deleted bool) {
+	for _, preference := range preferences {
+		if preference.Category != model.PREFERENCE_CATEGORY_FLAGGED_POST {
+			continue
+		}
+
+		if !deleted && preference.Value == "true" {
+			_, err := a.Srv().Store.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: preference.Name})
+			var cErr *store.ErrConflict
+			if err != nil && !errors.As(err, &cErr) {
+				mlog.Warn("Failed to save a flagged post", mlog.String("post_id", preference.Name), mlog.Err(err))
+			}
+			continue
+		}
+
+		err := a.Srv().Store.SavedPost().Delete(userId, preference.Name)
+		var nfErr *store.ErrNotFound
+		if err != nil && !errors.As(err, &nfErr) {
+			mlog.Warn("Failed to unsave an unflagged post", mlog.String("post_id", preference.Name), mlog.Err(err))
+		}
+	}
+}
+
+// SetSavedPostsForSession sets the saved state of the given posts for the user of the current
+// session in their metadata, so that clients don't need to ask for it post by post. Posts must
+// already be prepared for the client and never be broadcast to other users afterwards.
+func (a *App) SetSavedPostsForSession(posts ...*model.Post) {
+	userId := a.Session().UserId
+	if userId == "" {
+		return
+	}
+
+	postsById := make(map[string]*model.Post, len(posts))
+	postIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if post.DeleteAt != 0 || post.Metadata == nil {
+			continue
+		}
+		postsById[post.Id] = post
+		postIds = append(postIds, post.Id)
+	}
+
+	if len(postIds) == 0 {
+		return
+	}
+
+	savedPostsByPostId, err := a.Srv().Store.SavedPost().GetForPosts(userId, postIds)
+	if err != nil {
+		mlog.Warn("Failed to get the saved posts of a user", mlog.String("user_id", userId), mlog.Err(err))
+		return
+	}
+
+	for postId, savedPost := range savedPostsByPostId {
+		postsById[postId].Metadata.SavedPost = savedPost
+	}
+}
+
+func flaggedPostPreference(userId, postId string) model.Preference {
+	return model.Preference{
+		UserId:   userId,
+		Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
+		Name:     postId,
+		Value:    "true",
+	}
+}
+
+func savedPostAppError(where, id string, err error) *model.AppError {
+	var nfErr *store.ErrNotFound
+	var invErr *store.ErrInvalidInput
+	var cErr *store.ErrConflict
+	var appErr *model.AppError
+	switch {
+	case errors.As(err, &nfErr):
+		return model.NewAppError(where, "app.saved_post.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+	case errors.As(err, &invErr):
+		return model.NewAppError(where, id, nil, invErr.Error(), http.StatusBadRequest)
+	case errors.As(err, &cErr):
+		return model.NewAppError(where, "app.saved_post.already_saved.app_error", nil, cErr.Error(), http.StatusBadRequest)
+	case errors.As(err, &appErr): // in case we haven't converted to plain error.
+		return appErr
+	default:
+		return model.NewAppError(where, id, nil, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestSavedPostsFollowFlaggedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+	preference := flaggedPostPreference(th.BasicUser.Id, post.Id)
+
+	t.Run("flagging a post saves it", func(t *testing.T) {
+		require.Nil(t, th.App.UpdatePreferences(th.BasicUser.Id, model.Preferences{preference}))
+
+		savedPost, err := th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+		require.Nil(t, err)
+		assert.Empty(t, savedPost.Label)
+	})
+
+	t.Run("flagging a saved post again keeps its label", func(t *testing.T) {
+		savedPost, err := th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+		require.Nil(t, err)
+		_, err = th.App.PatchSavedPost(savedPost, &model.SavedPostPatch{Label: model.NewString("Follow up")})
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.UpdatePreferences(th.BasicUser.Id, model.Preferences{preference}))
+
+		savedPost, err = th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "Follow up", savedPost.Label)
+	})
+
+	t.Run("unflagging a post unsaves it", func(t *testing.T) {
+		require.Nil(t, th.App.DeletePreferences(th.BasicUser.Id, model.Preferences{preference}))
+
+		_, err := th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.saved_post.not_found.app_error", err.Id)
+	})
+
+	t.Run("saving a post flags it", func(t *testing.T) {
+		_, err := th.App.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: post.Id, Note: "Read later"})
+		require.Nil(t, err)
+
+		_, err = th.App.GetPreferenceByCategoryAndNameForUser(th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id)
+		require.Nil(t, err)
+	})
+
+	t.Run("unsaving a post unflags it", func(t *testing.T) {
+		savedPost, err := th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+		require.Nil(t, err)
+
+		require.Nil(t, th.App.DeleteSavedPost(savedPost))
+
+		_, err = th.App.GetPreferenceByCategoryAndNameForUser(th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id)
+		require.NotNil(t, err)
+	})
+}
+
+func TestDeleteFlaggedPostsDeletesSavedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+
+	_, err := th.App.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: post.Id})
+	require.Nil(t, err)
+	_, err = th.App.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser2.Id, PostId: post.Id})
+	require.Nil(t, err)
+
+	th.App.DeleteFlaggedPosts(post.Id)
+
+	_, err = th.App.GetSavedPost(th.BasicUser.Id, post.Id)
+	require.NotNil(t, err)
+	_, err = th.App.GetSavedPost(th.BasicUser2.Id, post.Id)
+	require.NotNil(t, err)
+}
+
+func TestPreparePostListForClientSavedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	saved := th.CreatePost(th.BasicChannel)
+	notSaved := th.CreatePost(th.BasicChannel)
+
+	_, err := th.App.CreateSavedPost(&model.SavedPost{UserId: th.BasicUser.Id, PostId: saved.Id, Label: "Follow up"})
+	require.Nil(t, err)
+
+	postList, err := th.App.GetPosts(th.BasicChannel.Id, 0, 10)
+	require.Nil(t, err)
+
+	t.Run("saved state is set for the user of the session", func(t *testing.T) {
+		th.App.SetSession(&model.Session{UserId: th.BasicUser.Id})
+
+		clientPostList := th.App.PreparePostListForClient(postList)
+
+		savedPost := clientPostList.Posts[saved.Id].Metadata.SavedPost
+		require.NotNil(t, savedPost)
+		assert.Equal(t, "Follow up", savedPost.Label)
+		assert.Nil(t, clientPostList.Posts[notSaved.Id].Metadata.SavedPost)
+	})
+
+	t.Run("saved state isn't set for other users", func(t *testing.T) {
+		th.App.SetSession(&model.Session{UserId: th.BasicUser2.Id})
+
+		clientPostList := th.App.PreparePostListForClient(postList)
+
+		assert.Nil(t, clientPostList.Posts[saved.Id].Metadata.SavedPost)
+	})
+
+	t.Run("saved state isn't set without a session", func(t *testing.T) {
+		th.App.SetSession(&model.Session{})
+
+		clientPostList := th.App.PreparePostListForClient(postList)
+
+		assert.Nil(t, clientPostList.Posts[saved.Id].Metadata.SavedPost)
+	})
+}
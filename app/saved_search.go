@@ -0,0 +1,274 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+const (
+	// savedSearchEvaluationInterval is how often the saved searches notifying of new matches are
+	// run. A saved search is never run again before the interval has elapsed since its last run.
+	savedSearchEvaluationInterval = 15 * time.Minute
+	// savedSearchEvaluationBatchSize is the number of saved searches fetched to be run at a time.
+	savedSearchEvaluationBatchSize = 100
+	// savedSearchMaxNewMatches is the number of posts fetched each time a saved search is run,
+	// which bounds the cost of running it.
+	savedSearchMaxNewMatches = 10
+)
+
+// GetSavedSearchesForUser returns the saved searches of the given user ordered by name.
+func (a *App) GetSavedSearchesForUser(userId string) ([]*model.SavedSearch, *model.AppError) {
+	savedSearches, err := a.Srv().Store.SavedSearch().GetForUser(userId)
+	if err != nil {
+		return nil, model.NewAppError("GetSavedSearchesForUser", "app.saved_search.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return savedSearches, nil
+}
+
+// GetSavedSearch returns the given saved search.
+func (a *App) GetSavedSearch(savedSearchId string) (*model.SavedSearch, *model.AppError) {
+	savedSearch, err := a.Srv().Store.SavedSearch().Get(savedSearchId)
+	if err != nil {
+		return nil, savedSearchAppError("GetSavedSearch", "app.saved_search.get.app_error", err)
+	}
+
+	return savedSearch, nil
+}
+
+// CreateSavedSearch saves a new search for a member of the saved search's team, as long as the
+// user hasn't reached the number of saved searches they may have.
+func (a *App) CreateSavedSearch(savedSearch *model.SavedSearch) (*model.SavedSearch, *model.AppError) {
+	member, err := a.GetTeamMember(savedSearch.TeamId, savedSearch.UserId)
+	if err != nil || member.DeleteAt != 0 {
+		return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.invalid_team.app_error", nil, "team_id="+savedSearch.TeamId, http.StatusBadRequest)
+	}
+
+	if err := a.checkSavedSearchLimits(savedSearch, true); err != nil {
+		return nil, err
+	}
+
+	saved, nErr := a.Srv().Store.SavedSearch().Save(savedSearch)
+	if nErr != nil {
+		return nil, savedSearchAppError("CreateSavedSearch", "app.saved_search.save.app_error", nErr)
+	}
+
+	return saved, nil
+}
+
+// PatchSavedSearch applies the given patch to an existing saved search. Changing the terms or
+// starting to notify of new matches only reports the posts created from then on.
+func (a *App) PatchSavedSearch(savedSearch *model.SavedSearch, patch *model.SavedSearchPatch) (*model.SavedSearch, *model.AppError) {
+	wasNotifying := savedSearch.NotifyOnNewMatch
+	terms := savedSearch.Terms
+
+	savedSearch.Patch(patch)
+
+	if savedSearch.NotifyOnNewMatch && !wasNotifying {
+		if err := a.checkSavedSearchLimits(savedSearch, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if (savedSearch.NotifyOnNewMatch && !wasNotifying) || savedSearch.Terms != terms {
+		savedSearch.LastEvaluatedAt = model.GetMillis()
+	}
+
+	updated, err := a.Srv().Store.SavedSearch().Update(savedSearch)
+	if err != nil {
+		return nil, savedSearchAppError("PatchSavedSearch", "app.saved_search.update.app_error", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSavedSearch deletes the given saved search.
+func (a *App) DeleteSavedSearch(savedSearch *model.SavedSearch) *model.AppError {
+	if err := a.Srv().Store.SavedSearch().Delete(savedSearch.Id); err != nil {
+		return savedSearchAppError("DeleteSavedSearch", "app.saved_search.delete.app_error", err)
+	}
+
+	return nil
+}
+
+// checkSavedSearchLimits checks that adding the given saved search, or making it notify of new
+// matches, keeps its user within the number of saved searches they may have.
+func (a *App) checkSavedSearchLimits(savedSearch *model.SavedSearch, isNew bool) *model.AppError {
+	savedSearches, err := a.GetSavedSearchesForUser(savedSearch.UserId)
+	if err != nil {
+		return err
+	}
+
+	if isNew && len(savedSearches) >= model.SAVED_SEARCH_MAX_PER_USER {
+		return model.NewAppError("checkSavedSearchLimits", "app.saved_search.too_many.app_error", map[string]interface{}{"Max": model.SAVED_SEARCH_MAX_PER_USER}, "user_id="+savedSearch.UserId, http.StatusBadRequest)
+	}
+
+	if !savedSearch.NotifyOnNewMatch {
+		return nil
+	}
+
+	notifying := 0
+	for _, existing := range savedSearches {
+		if existing.NotifyOnNewMatch && existing.Id != savedSearch.Id {
+			notifying++
+		}
+	}
+
+	if notifying >= model.SAVED_SEARCH_MAX_NOTIFY_PER_USER {
+		return model.NewAppError("checkSavedSearchLimits", "app.saved_search.too_many_notify.app_error", map[string]interface{}{"Max": model.SAVED_SEARCH_MAX_NOTIFY_PER_USER}, "user_id="+savedSearch.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func runSavedSearchEvaluationJob(a *App) {
+	model.CreateRecurringTask("Saved Search Evaluation", func() {
+		a.EvaluateSavedSearches()
+	}, savedSearchEvaluationInterval)
+}
+
+// EvaluateSavedSearches runs the saved searches notifying of new matches that weren't run during
+// the last evaluation interval, and sends their users a direct message listing the posts created
+// since the previous run. Searches are run on behalf of their users, so only posts in channels the
+// users can read at that time are reported.
+func (a *App) EvaluateSavedSearches() {
+	if !a.IsLeader() || !*a.Config().ServiceSettings.EnablePostSearch {
+		return
+	}
+
+	evaluatedBefore := model.GetMillis() - int64(savedSearchEvaluationInterval/time.Millisecond)
+	evaluated := make(map[string]bool)
+	botUserId := ""
+
+	for {
+		savedSearches, err := a.Srv().Store.SavedSearch().GetToEvaluate(evaluatedBefore, savedSearchEvaluationBatchSize)
+		if err != nil {
+			mlog.Error("Failed to get the saved searches to evaluate", mlog.Err(err))
+			return
+		}
+
+		if len(savedSearches) == 0 {
+			return
+		}
+
+		if botUserId == "" {
+			var appErr *model.AppError
+			if botUserId, appErr = a.EnsureSystemBot(); appErr != nil {
+				mlog.Error("Failed to get the system bot", mlog.Err(appErr))
+				return
+			}
+		}
+
+		// A search whose last evaluation failed to be recorded comes back in the next batch, so it
+		// is skipped rather than run again, and a batch of such searches ends the run.
+		evaluatedAny := false
+		for _, savedSearch := range savedSearches {
+			if evaluated[savedSearch.Id] {
+				continue
+			}
+			evaluated[savedSearch.Id] = true
+			evaluatedAny = true
+
+			a.evaluateSavedSearch(savedSearch, botUserId)
+		}
+
+		if len(savedSearches) < savedSearchEvaluationBatchSize || !evaluatedAny {
+			return
+		}
+	}
+}
+
+func (a *App) evaluateSavedSearch(savedSearch *model.SavedSearch, botUserId string) {
+	// Posts created while the search runs are left for the next run.
+	evaluatedAt := model.GetMillis()
+
+	defer func() {
+		if err := a.Srv().Store.SavedSearch().UpdateLastEvaluatedAt(savedSearch.Id, evaluatedAt); err != nil {
+			mlog.Error("Failed to update the last evaluation of a saved search", mlog.String("saved_search_id", savedSearch.Id), mlog.Err(err))
+		}
+	}()
+
+	user, err := a.GetUser(savedSearch.UserId)
+	if err != nil || user.DeleteAt != 0 {
+		return
+	}
+
+	member, err := a.GetTeamMember(savedSearch.TeamId, savedSearch.UserId)
+	if err != nil || member.DeleteAt != 0 {
+		return
+	}
+
+	results, err := a.SearchPostsInTeamForUser(savedSearch.Terms, savedSearch.UserId, savedSearch.TeamId, savedSearch.IsOrSearch, false, 0, 0, savedSearchMaxNewMatches)
+	if err != nil {
+		mlog.Warn("Failed to evaluate a saved search", mlog.String("saved_search_id", savedSearch.Id), mlog.Err(err))
+		return
+	}
+
+	var newPostIds []string
+	for _, postId := range results.Order {
+		post := results.Posts[postId]
+		if post == nil || post.CreateAt <= savedSearch.LastEvaluatedAt || post.CreateAt > evaluatedAt {
+			continue
+		}
+		if post.UserId == savedSearch.UserId || post.UserId == botUserId {
+			continue
+		}
+		newPostIds = append(newPostIds, postId)
+	}
+
+	if len(newPostIds) == 0 {
+		return
+	}
+
+	if err := a.sendSavedSearchMatches(savedSearch, user, newPostIds); err != nil {
+		mlog.Error("Failed to send the new matches of a saved search", mlog.String("saved_search_id", savedSearch.Id), mlog.Err(err))
+	}
+}
+
+func (a *App) sendSavedSearchMatches(savedSearch *model.SavedSearch, user *model.User, postIds []string) *model.AppError {
+	team, err := a.GetTeam(savedSearch.TeamId)
+	if err != nil {
+		return err
+	}
+
+	links := make([]string, len(postIds))
+	for i, postId := range postIds {
+		links[i] = a.GetSiteURL() + "/" + team.Name + "/pl/" + postId
+	}
+
+	T := utils.GetUserTranslations(user.Locale)
+	message := fmt.Sprintf("%s\n- %s", T("app.saved_search.new_matches", map[string]interface{}{"Name": savedSearch.Name, "TeamName": team.DisplayName}), strings.Join(links, "\n- "))
+
+	if _, err := a.SendSystemBotDM(user.Id, message, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func savedSearchAppError(where, id string, err error) *model.AppError {
+	var nfErr *store.ErrNotFound
+	var invErr *store.ErrInvalidInput
+	var appErr *model.AppError
+	switch {
+	case errors.As(err, &nfErr):
+		return model.NewAppError(where, "app.saved_search.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+	case errors.As(err, &invErr):
+		return model.NewAppError(where, id, nil, invErr.Error(), http.StatusBadRequest)
+	case errors.As(err, &appErr): // in case we haven't converted to plain error.
+		return appErr
+	default:
+		return model.NewAppError(where, id, nil, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestEvaluateSavedSearches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	botUserId, appErr := th.App.EnsureSystemBot()
+	require.Nil(t, appErr)
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	// The user isn't a member of this channel, so posts in it must never be reported to them.
+	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+
+	notifications := func() []*model.Post {
+		dm, err := th.App.GetOrCreateDirectChannel(user.Id, botUserId)
+		require.Nil(t, err)
+		posts, err := th.App.GetPosts(dm.Id, 0, 10)
+		require.Nil(t, err)
+
+		var result []*model.Post
+		for _, post := range posts.ToSlice() {
+			if post.UserId == botUserId {
+				result = append(result, post)
+			}
+		}
+		return result
+	}
+
+	post := func(channel *model.Channel, message string) *model.Post {
+		rpost, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: channel.Id,
+			Message:   message,
+		}, channel, false, false)
+		require.Nil(t, err)
+		return rpost
+	}
+
+	savedSearch, appErr := th.App.CreateSavedSearch(&model.SavedSearch{
+		UserId:           user.Id,
+		TeamId:           th.BasicTeam.Id,
+		Name:             "Outages",
+		Terms:            "outage",
+		NotifyOnNewMatch: true,
+	})
+	require.Nil(t, appErr)
+
+	// Saved searches are only run once the evaluation interval has elapsed since their last run.
+	rewind := func() {
+		lastEvaluatedAt := model.GetMillis() - int64(2*savedSearchEvaluationInterval/time.Millisecond)
+		require.Nil(t, th.App.Srv().Store.SavedSearch().UpdateLastEvaluatedAt(savedSearch.Id, lastEvaluatedAt))
+	}
+
+	rewind()
+	match := post(th.BasicChannel, "database outage")
+	hidden := post(privateChannel, "network outage")
+	post(th.BasicChannel, "all good")
+
+	th.App.EvaluateSavedSearches()
+
+	posts := notifications()
+	require.Len(t, posts, 1)
+	assert.Contains(t, posts[0].Message, "Outages")
+	assert.Contains(t, posts[0].Message, th.App.GetSiteURL()+"/"+th.BasicTeam.Name+"/pl/"+match.Id)
+	assert.NotContains(t, posts[0].Message, hidden.Id)
+
+	another := post(th.BasicChannel, "another outage")
+
+	t.Run("not run again before the interval has elapsed", func(t *testing.T) {
+		th.App.EvaluateSavedSearches()
+
+		assert.Len(t, notifications(), 1)
+	})
+
+	t.Run("matches already reported are not reported again", func(t *testing.T) {
+		updated, appErr := th.App.GetSavedSearch(savedSearch.Id)
+		require.Nil(t, appErr)
+
+		th.App.evaluateSavedSearch(updated, botUserId)
+
+		posts := notifications()
+		require.Len(t, posts, 2)
+		// Notifications are listed newest first.
+		assert.Contains(t, posts[0].Message, another.Id)
+		assert.NotContains(t, posts[0].Message, match.Id)
+	})
+
+	t.Run("deactivated users are not notified", func(t *testing.T) {
+		rewind()
+		post(th.BasicChannel, "yet another outage")
+
+		_, appErr := th.App.UpdateActive(user, false)
+		require.Nil(t, appErr)
+		defer th.App.UpdateActive(user, true)
+
+		th.App.EvaluateSavedSearches()
+
+		assert.Len(t, notifications(), 2)
+	})
+}
@@ -191,6 +191,21 @@ func (a *App) GetChannelsForScheme(scheme *model.Scheme, offset int, limit int)
 	return a.Srv().Store.Channel().GetChannelsByScheme(scheme.Id, offset, limit)
 }
 
+// GetAllChannelPolicies returns a page of the channels that override the permissions of their team
+// with a scheme of their own, along with that scheme and the names of the channel and its team.
+func (a *App) GetAllChannelPolicies(page, perPage int) ([]*model.ChannelPolicyWithChannelInfo, *model.AppError) {
+	if err := a.IsPhase2MigrationCompleted(); err != nil {
+		return nil, err
+	}
+
+	policies, err := a.Srv().Store.Scheme().GetChannelPolicies(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetAllChannelPolicies", "app.scheme.get_channel_policies.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return policies, nil
+}
+
 func (s *Server) IsPhase2MigrationCompleted() *model.AppError {
 	if s.phase2PermissionsMigrationComplete {
 		return nil
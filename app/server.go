@@ -23,10 +23,12 @@ import (
 	sentryhttp "github.com/getsentry/sentry-go/http"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
-	"github.com/rs/cors"
 	rudder "github.com/rudderlabs/analytics-go"
+	"github.com/throttled/throttled"
 
 	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/config"
@@ -77,6 +79,9 @@ type Server struct {
 
 	localModeServer *http.Server
 
+	certificateManager     *certificateManager
+	certificateManagerLock sync.Mutex
+
 	didFinishListen chan struct{}
 
 	goroutineCount      int32
@@ -88,6 +93,10 @@ type Server struct {
 
 	EmailService *EmailService
 
+	// userAvailabilityRateLimiter limits the checks of whether usernames and emails are taken,
+	// whether or not rate limiting is enabled, since they can be made without logging in.
+	userAvailabilityRateLimiter *throttled.GCRARateLimiter
+
 	hubs     []*Hub
 	hashSeed maphash.Seed
 
@@ -105,11 +114,16 @@ type Server struct {
 
 	timezones *timezones.Timezones
 
+	incomingWebhookDebugCache cache.Cache
+	incomingWebhookDebugMutex sync.Mutex
+
+	sentNotificationEmailsCache cache.Cache
+	sentNotificationEmailsMutex sync.Mutex
+
 	newStore func() store.Store
 
 	htmlTemplateWatcher     *utils.HTMLTemplateWatcher
 	sessionCache            cache.Cache
-	seenPendingPostIdsCache cache.Cache
 	statusCache             cache.Cache
 	configListenerId        string
 	licenseListenerId       string
@@ -126,9 +140,22 @@ type Server struct {
 	pluginCommands     []*PluginCommand
 	pluginCommandsLock sync.RWMutex
 
+	supportPacketLock        sync.Mutex
+	supportPacketGeneratedAt time.Time
+	supportPacketGenerating  bool
+
+	adminNotificationLock sync.Mutex
+
+	systemBotLock sync.Mutex
+	systemBotId   atomic.Value // string
+
 	clientConfig        atomic.Value
 	clientConfigHash    atomic.Value
 	limitedClientConfig atomic.Value
+	adminClientConfig   atomic.Value
+
+	featureFlags      atomic.Value // *featureFlagsState
+	featureFlagsMutex sync.Mutex
 
 	diagnosticId string
 	rudderClient rudder.Client
@@ -251,12 +278,17 @@ func NewServer(options ...Option) (*Server, error) {
 	s.sessionCache = s.CacheProvider.NewCache(&cache.CacheOptions{
 		Size: model.SESSION_CACHE_SIZE,
 	})
-	s.seenPendingPostIdsCache = s.CacheProvider.NewCache(&cache.CacheOptions{
-		Size: PENDING_POST_IDS_CACHE_SIZE,
-	})
 	s.statusCache = s.CacheProvider.NewCache(&cache.CacheOptions{
 		Size: model.STATUS_CACHE_SIZE,
 	})
+	s.incomingWebhookDebugCache = s.CacheProvider.NewCache(&cache.CacheOptions{
+		Size: INCOMING_WEBHOOK_DEBUG_CACHE_SIZE,
+		Name: "IncomingWebhookDebug",
+	})
+	s.sentNotificationEmailsCache = s.CacheProvider.NewCache(&cache.CacheOptions{
+		Size: SENT_NOTIFICATION_EMAILS_CACHE_SIZE,
+		Name: "SentNotificationEmails",
+	})
 
 	s.createPushNotificationsHub()
 
@@ -320,17 +352,37 @@ func NewServer(options ...Option) (*Server, error) {
 	if htmlTemplateWatcher, err := utils.NewHTMLTemplateWatcher("templates"); err != nil {
 		mlog.Error("Failed to parse server templates", mlog.Err(err))
 	} else {
+		htmlTemplateWatcher.SetOverrideDirectory(*s.Config().EmailSettings.TemplateOverrideDirectory)
+		s.AddConfigListener(func(prevCfg, cfg *model.Config) {
+			htmlTemplateWatcher.SetOverrideDirectory(*cfg.EmailSettings.TemplateOverrideDirectory)
+		})
 		s.htmlTemplateWatcher = htmlTemplateWatcher
 	}
 
 	s.Store = s.newStore()
 
+	if *s.Config().ComplianceSettings.SyslogAddress != "" {
+		s.streamAuditsToSyslog(s.Config())
+	}
+	s.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if *oldCfg.ComplianceSettings.SyslogAddress != *newCfg.ComplianceSettings.SyslogAddress ||
+			*oldCfg.ComplianceSettings.SyslogProtocol != *newCfg.ComplianceSettings.SyslogProtocol ||
+			*oldCfg.ComplianceSettings.SyslogEnableTLS != *newCfg.ComplianceSettings.SyslogEnableTLS ||
+			*oldCfg.ComplianceSettings.SyslogTLSCACertFile != *newCfg.ComplianceSettings.SyslogTLSCACertFile {
+			s.streamAuditsToSyslog(newCfg)
+		}
+	})
+
 	emailService, err := NewEmailService(s)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to initialize email service")
 	}
 	s.EmailService = emailService
 
+	if s.userAvailabilityRateLimiter, err = newUserAvailabilityRateLimiter(); err != nil {
+		return nil, errors.Wrapf(err, "unable to initialize user availability rate limiting")
+	}
+
 	if model.BuildEnterpriseReady == "true" {
 		s.LoadLicense()
 	}
@@ -358,8 +410,13 @@ func NewServer(options ...Option) (*Server, error) {
 	}
 
 	s.ensureDiagnosticId()
+	s.initFeatureFlags()
 	s.regenerateClientConfig()
 
+	if _, appErr := fakeApp.EnsureSystemBot(); appErr != nil {
+		mlog.Error("Failed to ensure the system bot", mlog.Err(appErr))
+	}
+
 	s.clusterLeaderListenerId = s.AddClusterLeaderChangedListener(func() {
 		mlog.Info("Cluster leader changed. Determining if job schedulers should be running:", mlog.Bool("isLeader", s.IsLeader()))
 		if s.Jobs != nil && s.Jobs.Schedulers != nil {
@@ -463,6 +520,9 @@ func NewServer(options ...Option) (*Server, error) {
 		s.Audit = &audit.Audit{}
 		s.Audit.Init(audit.DefMaxQueueSize)
 		s.configureAudit(s.Audit)
+		s.AddConfigListener(func(_, cfg *model.Config) {
+			s.Audit.SetRedactedQueryParams(cfg.ExperimentalAuditSettings.AdditionalRedactedQueryParams)
+		})
 	}
 
 	if license == nil || !*license.Features.AdvancedLogging {
@@ -629,6 +689,13 @@ func (s *Server) StopHTTPServer() {
 		s.Server.Close()
 		s.Server = nil
 	}
+
+	s.certificateManagerLock.Lock()
+	if s.certificateManager != nil {
+		s.certificateManager.Close()
+		s.certificateManager = nil
+	}
+	s.certificateManagerLock.Unlock()
 }
 
 func (s *Server) Shutdown() error {
@@ -690,6 +757,11 @@ func (s *Server) Shutdown() error {
 	}
 
 	if s.Store != nil {
+		if *s.Config().ComplianceSettings.SyslogAddress != "" {
+			if err := s.Store.Audit().StreamToSyslog("", "", nil); err != nil {
+				mlog.Error("Unable to stop streaming audit records to syslog", mlog.Err(err))
+			}
+		}
 		s.Store.Close()
 	}
 
@@ -738,15 +810,6 @@ func (s *Server) WaitForGoroutines() {
 	}
 }
 
-var corsAllowedMethods = []string{
-	"POST",
-	"GET",
-	"OPTIONS",
-	"PUT",
-	"PATCH",
-	"DELETE",
-}
-
 // golang.org/x/crypto/acme/autocert/autocert.go
 func handleHTTPRedirect(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" && r.Method != "HEAD" {
@@ -778,35 +841,45 @@ func (s *Server) Start() error {
 		handler = sentryHandler.Handle(handler)
 	}
 
-	if allowedOrigins := *s.Config().ServiceSettings.AllowCorsFrom; allowedOrigins != "" {
-		exposedCorsHeaders := *s.Config().ServiceSettings.CorsExposedHeaders
-		allowCredentials := *s.Config().ServiceSettings.CorsAllowCredentials
-		debug := *s.Config().ServiceSettings.CorsDebug
-		corsWrapper := cors.New(cors.Options{
-			AllowedOrigins:   strings.Fields(allowedOrigins),
-			AllowedMethods:   corsAllowedMethods,
-			AllowedHeaders:   []string{"*"},
-			ExposedHeaders:   strings.Fields(exposedCorsHeaders),
-			MaxAge:           86400,
-			AllowCredentials: allowCredentials,
-			Debug:            debug,
-		})
-
+	if origins := s.Config().ServiceSettings.CorsOrigins; len(origins) > 0 {
+		var corsLog *mlog.Logger
 		// If we have debugging of CORS turned on then forward messages to logs
-		if debug {
-			corsWrapper.Log = s.Log.StdLog(mlog.String("source", "cors"))
+		if *s.Config().ServiceSettings.CorsDebug {
+			corsLog = s.Log
 		}
 
-		handler = corsWrapper.Handler(handler)
+		handler = newCorsHandler(origins, corsLog, handler)
 	}
 
 	if *s.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err := NewRateLimiter(&s.Config().RateLimitSettings, s.Config().ServiceSettings.TrustedProxyIPHeader)
+		// The counters of a cluster are kept in the database, so that every node takes from the
+		// same budgets.
+		var counters rateLimitStore
+		if s.Cluster != nil {
+			counters = newRateLimitKVStore(s.Store.Plugin())
+		} else {
+			counters = newRateLimitCacheStore(s.CacheProvider.NewCache(&cache.CacheOptions{
+				Size: *s.Config().RateLimitSettings.MemoryStoreSize,
+				Name: "RateLimit",
+			}))
+		}
+
+		rateLimiter, err := newRateLimiter(&s.Config().RateLimitSettings, s.Config().ServiceSettings.TrustedProxyIPHeader, counters)
 		if err != nil {
 			return err
 		}
+		if subpath, err := utils.GetSubpathFromConfig(s.Config()); err == nil && subpath != "/" {
+			rateLimiter.subpath = subpath
+		}
+		rateLimiter.userIdForToken = func(token string) string {
+			var session *model.Session
+			if err := s.sessionCache.Get(token, &session); err != nil || session == nil {
+				return ""
+			}
+			return session.UserId
+		}
 
 		s.RateLimiter = rateLimiter
 		handler = rateLimiter.RateLimitHandler(handler)
@@ -819,12 +892,36 @@ func (s *Server) Start() error {
 		return err
 	}
 
+	// Websocket connections are hijacked from the server, so the idle timeout never applies to them.
+	idleTimeout := time.Duration(*s.Config().ServiceSettings.IdleTimeout) * time.Second
+	http2Server := &http2.Server{IdleTimeout: idleTimeout}
+
+	tlsEnabled := *s.Config().ServiceSettings.ConnectionSecurity == model.CONN_SECURITY_TLS
+	if *s.Config().ServiceSettings.EnableH2C && !tlsEnabled {
+		mlog.Info("HTTP/2 over cleartext is enabled")
+		handler = h2c.NewHandler(handler, http2Server)
+	}
+
 	s.Server = &http.Server{
-		Handler:      handler,
-		ReadTimeout:  time.Duration(*s.Config().ServiceSettings.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(*s.Config().ServiceSettings.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(*s.Config().ServiceSettings.IdleTimeout) * time.Second,
-		ErrorLog:     errStdLog,
+		Handler:           handler,
+		ReadTimeout:       time.Duration(*s.Config().ServiceSettings.ReadTimeout) * time.Second,
+		ReadHeaderTimeout: time.Duration(*s.Config().ServiceSettings.ReadHeaderTimeout) * time.Second,
+		WriteTimeout:      time.Duration(*s.Config().ServiceSettings.WriteTimeout) * time.Second,
+		IdleTimeout:       idleTimeout,
+		ErrorLog:          errStdLog,
+	}
+
+	// Certificates from files are served through the manager so that they can be reloaded without a restart.
+	var certificates *certificateManager
+	if tlsEnabled && !*s.Config().ServiceSettings.UseLetsEncrypt {
+		certificates, err = newCertificateManager(*s.Config().ServiceSettings.TLSCertFile, *s.Config().ServiceSettings.TLSKeyFile, s.HTTPService.MakeClient(true), s.notifyAdminsOfCertificateExpiry)
+		if err != nil {
+			return err
+		}
+
+		s.certificateManagerLock.Lock()
+		s.certificateManager = certificates
+		s.certificateManagerLock.Unlock()
 	}
 
 	addr := *s.Config().ServiceSettings.ListenAddress
@@ -843,6 +940,10 @@ func (s *Server) Start() error {
 	}
 	s.ListenAddr = listener.Addr().(*net.TCPAddr)
 
+	if maxConnsPerIP := *s.Config().ServiceSettings.MaxConnsPerIP; maxConnsPerIP > 0 || s.Metrics != nil {
+		listener = newLimitedListener(listener, maxConnsPerIP, s.Metrics)
+	}
+
 	logListeningPort := fmt.Sprintf("Server is listening on %v", listener.Addr().String())
 	mlog.Info(logListeningPort, mlog.String("address", listener.Addr().String()))
 
@@ -890,7 +991,7 @@ func (s *Server) Start() error {
 	s.didFinishListen = make(chan struct{})
 	go func() {
 		var err error
-		if *s.Config().ServiceSettings.ConnectionSecurity == model.CONN_SECURITY_TLS {
+		if tlsEnabled {
 
 			tlsConfig := &tls.Config{
 				PreferServerCipherSuites: true,
@@ -938,19 +1039,17 @@ func (s *Server) Start() error {
 				tlsConfig.CipherSuites = cipherSuites
 			}
 
-			certFile := ""
-			keyFile := ""
-
 			if *s.Config().ServiceSettings.UseLetsEncrypt {
 				tlsConfig.GetCertificate = m.GetCertificate
-				tlsConfig.NextProtos = append(tlsConfig.NextProtos, "h2")
 			} else {
-				certFile = *s.Config().ServiceSettings.TLSCertFile
-				keyFile = *s.Config().ServiceSettings.TLSKeyFile
+				tlsConfig.GetCertificate = certificates.GetCertificate
 			}
 
 			s.Server.TLSConfig = tlsConfig
-			err = s.Server.ServeTLS(listener, certFile, keyFile)
+			if err = http2.ConfigureServer(s.Server, http2Server); err != nil {
+				mlog.Warn("Unable to configure HTTP/2, falling back to HTTP/1.1", mlog.Err(err))
+			}
+			err = s.Server.ServeTLS(listener, "", "")
 		} else {
 			err = s.Server.Serve(listener)
 		}
@@ -1001,21 +1100,6 @@ func (s *Server) stopLocalModeServer() {
 	}
 }
 
-func (a *App) OriginChecker() func(*http.Request) bool {
-	if allowed := *a.Config().ServiceSettings.AllowCorsFrom; allowed != "" {
-		if allowed != "*" {
-			siteURL, err := url.Parse(*a.Config().ServiceSettings.SiteURL)
-			if err == nil {
-				siteURL.Path = ""
-				allowed += " " + siteURL.String()
-			}
-		}
-
-		return utils.OriginChecker(allowed)
-	}
-	return nil
-}
-
 func (s *Server) checkPushNotificationServerUrl() {
 	notificationServer := *s.Config().EmailSettings.PushNotificationServer
 	if strings.HasPrefix(notificationServer, "http://") {
@@ -1099,6 +1183,10 @@ func doDiagnostics(s *Server) {
 
 func doTokenCleanup(s *Server) {
 	s.Store.Token().Cleanup()
+
+	if err := s.Store.PostShareToken().DeleteExpired(model.GetMillis()); err != nil {
+		mlog.Error("Failed to delete expired post share tokens", mlog.Err(err))
+	}
 }
 
 func doCommandWebhookCleanup(s *Server) {
@@ -1122,6 +1210,8 @@ func doLicenseExpirationCheck(a *App) {
 		return
 	}
 
+	a.notifyAdminsOfLicenseExpiration(license)
+
 	if !license.IsPastGracePeriod() {
 		mlog.Debug("License is not past the grace period.")
 		return
@@ -1331,4 +1421,8 @@ func (s *Server) initJobs() {
 	if jobsMigrationsInterface != nil {
 		s.Jobs.Migrations = jobsMigrationsInterface(s)
 	}
+
+	s.Jobs.SetJobErrorListener(func(job *model.Job, jobError *model.AppError) {
+		New(ServerConnector(s)).notifyAdminsOfJobFailure(job, jobError)
+	})
 }
@@ -887,7 +887,7 @@ func (a *App) oldImportChannel(channel *model.Channel, sChannel SlackChannel, us
 		return sc
 	case channel.Type == model.CHANNEL_GROUP:
 		channel.Type = model.CHANNEL_PRIVATE
-		sc, err := a.CreateChannel(channel, false)
+		sc, err := a.CreateChannelBypassingThrottle(channel, false)
 		if err != nil {
 			return nil
 		}
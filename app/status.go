@@ -42,6 +42,30 @@ func (a *App) GetAllStatuses() map[string]*model.Status {
 	return statusMap
 }
 
+// GetStatusesByTeam returns the statuses of the members of the team, keyed by user id. Members
+// that never had a status, and so are offline, are left out.
+func (a *App) GetStatusesByTeam(teamId string) (map[string]*model.Status, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return map[string]*model.Status{}, nil
+	}
+
+	statuses, err := a.Srv().Store.Status().GetByTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	statusMap := make(map[string]*model.Status, len(statuses))
+	for _, status := range statuses {
+		// The cache is updated before the database, so it holds the latest status
+		if cachedStatus := a.GetStatusFromCache(status.UserId); cachedStatus != nil {
+			status = cachedStatus
+		}
+		statusMap[status.UserId] = status
+	}
+
+	return statusMap, nil
+}
+
 func (a *App) GetStatusesByIds(userIds []string) (map[string]interface{}, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableUserStatuses {
 		return map[string]interface{}{}, nil
@@ -34,7 +34,7 @@ func (a *App) createDefaultChannelMemberships(since int64, channelID *string) er
 
 		// First add user to team
 		if tmem == nil {
-			_, err = a.AddTeamMember(channel.TeamId, userChannel.UserID)
+			_, err = a.AddTeamMember(channel.TeamId, userChannel.UserID, "")
 			if err != nil {
 				if err.Id == "api.team.join_user_to_team.allowed_domains.app_error" {
 					a.Log().Info("User not added to channel - the domain associated with the user is not in the list of allowed team domains",
@@ -62,6 +62,12 @@ func (a *App) createDefaultChannelMemberships(since int64, channelID *string) er
 			} else {
 				return err
 			}
+		} else if !*a.Config().TeamSettings.ExcludeGroupSyncFromChannelAddedNotification {
+			if user, userErr := a.GetUser(userChannel.UserID); userErr == nil {
+				a.Srv().Go(func() {
+					a.notifyUserAddedToChannel(user, channel, nil)
+				})
+			}
 		}
 
 		a.Log().Info("added channelmember",
@@ -83,7 +89,7 @@ func (a *App) createDefaultTeamMemberships(since int64, teamID *string) error {
 	}
 
 	for _, userTeam := range teamMembers {
-		_, err := a.AddTeamMember(userTeam.TeamID, userTeam.UserID)
+		_, err := a.AddTeamMember(userTeam.TeamID, userTeam.UserID, "")
 		if err != nil {
 			if err.Id == "api.team.join_user_to_team.allowed_domains.app_error" {
 				a.Log().Info("User not added to team - the domain associated with the user is not in the list of allowed team domains",
@@ -170,6 +176,10 @@ func (a *App) deleteGroupConstrainedChannelMemberships(channelID *string) error
 	}
 
 	for _, userChannel := range channelMembers {
+		if userChannel.IsExemptFromGroupSync() {
+			continue
+		}
+
 		channel, err := a.GetChannel(userChannel.ChannelId)
 		if err != nil {
 			return err
@@ -189,6 +199,94 @@ func (a *App) deleteGroupConstrainedChannelMemberships(channelID *string) error
 	return nil
 }
 
+// GetChannelMembersDrift returns the members of the group-constrained channel that aren't members of
+// any of its groups, which is the case when the group sync failed to remove them.
+func (a *App) GetChannelMembersDrift(channel *model.Channel) (*model.ChannelMembersDrift, *model.AppError) {
+	if !channel.IsGroupConstrained() {
+		return nil, model.NewAppError("GetChannelMembersDrift", "app.channel.get_members_drift.not_group_constrained.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	channelMembers, err := a.ChannelMembersToRemove(&channel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &model.ChannelMembersDrift{ChannelId: channel.Id, UserIds: []string{}, ExemptUserIds: []string{}}
+	for _, channelMember := range channelMembers {
+		addToChannelMembersDrift(drift, channelMember)
+	}
+
+	return drift, nil
+}
+
+// GetChannelMembersDrifts returns the drift of every group-constrained channel that has members
+// who aren't members of any of its groups.
+func (a *App) GetChannelMembersDrifts() ([]*model.ChannelMembersDrift, *model.AppError) {
+	channelMembers, err := a.ChannelMembersToRemove(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []*model.ChannelMembersDrift
+	driftsByChannelId := map[string]*model.ChannelMembersDrift{}
+	for _, channelMember := range channelMembers {
+		drift, ok := driftsByChannelId[channelMember.ChannelId]
+		if !ok {
+			drift = &model.ChannelMembersDrift{ChannelId: channelMember.ChannelId, UserIds: []string{}, ExemptUserIds: []string{}}
+			driftsByChannelId[channelMember.ChannelId] = drift
+			drifts = append(drifts, drift)
+		}
+		addToChannelMembersDrift(drift, channelMember)
+	}
+
+	return drifts, nil
+}
+
+func addToChannelMembersDrift(drift *model.ChannelMembersDrift, channelMember *model.ChannelMember) {
+	if channelMember.IsExemptFromGroupSync() {
+		drift.ExemptUserIds = append(drift.ExemptUserIds, channelMember.UserId)
+	} else {
+		drift.UserIds = append(drift.UserIds, channelMember.UserId)
+	}
+}
+
+// RepairChannelMembersDrift removes the members of the drift from its channel, keeping the exempt
+// ones, and returns the ids of the users it removed. They are removed like any other member, so that
+// the channel gets the usual system messages and websocket events. A member that can't be removed
+// doesn't stop the others from being removed; an error listing the failed users is returned at the end.
+func (a *App) RepairChannelMembersDrift(drift *model.ChannelMembersDrift) ([]string, *model.AppError) {
+	channel, err := a.GetChannel(drift.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	removedUserIds := []string{}
+	failedUserIds := []string{}
+	for _, userId := range drift.UserIds {
+		if err := a.RemoveUserFromChannel(userId, "", channel); err != nil {
+			a.Log().Error("failed to remove drifted channelmember",
+				mlog.String("user_id", userId),
+				mlog.String("channel_id", channel.Id),
+				mlog.Err(err),
+			)
+			failedUserIds = append(failedUserIds, userId)
+			continue
+		}
+
+		a.Log().Info("removed drifted channelmember",
+			mlog.String("user_id", userId),
+			mlog.String("channel_id", channel.Id),
+		)
+		removedUserIds = append(removedUserIds, userId)
+	}
+
+	if len(failedUserIds) > 0 {
+		return removedUserIds, model.NewAppError("RepairChannelMembersDrift", "app.channel.repair_members_drift.app_error", map[string]interface{}{"Count": len(failedUserIds)}, "channel_id="+channel.Id+", user_ids="+strings.Join(failedUserIds, ","), http.StatusInternalServerError)
+	}
+
+	return removedUserIds, nil
+}
+
 // SyncSyncableRoles updates the SchemeAdmin field value of the given syncable's members based on the configuration of
 // the member's group memberships and the configuration of those groups to the syncable. This method should only
 // be invoked on group-synced (aka group-constrained) syncables.
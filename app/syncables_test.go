@@ -266,7 +266,7 @@ func TestCreateDefaultMemberships(t *testing.T) {
 	}
 
 	// Add other user so that user can leave channel
-	_, err = th.App.AddTeamMember(singersTeam.Id, singer1.Id)
+	_, err = th.App.AddTeamMember(singersTeam.Id, singer1.Id, "")
 	if err != nil {
 		t.Errorf("unable to add user to team: %s", err.Error())
 	}
@@ -383,7 +383,7 @@ func TestDeleteGroupMemberships(t *testing.T) {
 	var err *model.AppError
 	// add users to teams and channels
 	for _, userID := range userIDs {
-		_, err = th.App.AddTeamMember(th.BasicTeam.Id, userID)
+		_, err = th.App.AddTeamMember(th.BasicTeam.Id, userID, "")
 		require.Nil(t, err)
 
 		_, err = th.App.AddChannelMember(userID, th.BasicChannel, "", "")
@@ -439,6 +439,90 @@ func TestDeleteGroupMemberships(t *testing.T) {
 	require.Equal(t, th.SystemAdminUser.Id, (*cmembers)[0].UserId)
 }
 
+func TestChannelMembersDrift(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group := th.CreateGroup()
+
+	userIDs := []string{th.BasicUser.Id, th.BasicUser2.Id, th.SystemAdminUser.Id}
+
+	var err *model.AppError
+	for _, userID := range userIDs {
+		_, err = th.App.AddTeamMember(th.BasicTeam.Id, userID, "")
+		require.Nil(t, err)
+
+		_, err = th.App.AddChannelMember(userID, th.BasicChannel, "", "")
+		require.Nil(t, err)
+	}
+
+	channel := th.BasicChannel
+
+	t.Run("channel must be group-constrained", func(t *testing.T) {
+		_, err = th.App.GetChannelMembersDrift(channel)
+		require.NotNil(t, err)
+		require.Equal(t, "app.channel.get_members_drift.not_group_constrained.app_error", err.Id)
+	})
+
+	channel.GroupConstrained = model.NewBool(true)
+	channel, err = th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	_, err = th.App.UpsertGroupSyncable(model.NewGroupChannel(group.Id, channel.Id, true))
+	require.Nil(t, err)
+	_, err = th.App.UpsertGroupMember(group.Id, th.SystemAdminUser.Id)
+	require.Nil(t, err)
+
+	// BasicUser is the channel admin, since it created the channel, but that alone doesn't exempt them
+	drift, err := th.App.GetChannelMembersDrift(channel)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, drift.UserIds)
+	require.Empty(t, drift.ExemptUserIds)
+
+	t.Run("only channel admins can be manually managed", func(t *testing.T) {
+		_, err = th.App.UpdateChannelMemberManuallyManaged(channel.Id, th.BasicUser2.Id, true)
+		require.NotNil(t, err)
+		require.Equal(t, "app.channel.update_member_manually_managed.not_admin.app_error", err.Id)
+	})
+
+	_, err = th.App.UpdateChannelMemberManuallyManaged(channel.Id, th.BasicUser.Id, true)
+	require.Nil(t, err)
+
+	drift, err = th.App.GetChannelMembersDrift(channel)
+	require.Nil(t, err)
+	require.Equal(t, channel.Id, drift.ChannelId)
+	require.Equal(t, []string{th.BasicUser2.Id}, drift.UserIds)
+	require.Equal(t, []string{th.BasicUser.Id}, drift.ExemptUserIds)
+
+	drifts, err := th.App.GetChannelMembersDrifts()
+	require.Nil(t, err)
+	require.Contains(t, drifts, drift)
+
+	removedUserIds, err := th.App.RepairChannelMembersDrift(drift)
+	require.Nil(t, err)
+	require.Equal(t, []string{th.BasicUser2.Id}, removedUserIds)
+
+	cmembers, err := th.App.GetChannelMembersPage(channel.Id, 0, 99)
+	require.Nil(t, err)
+	require.Len(t, *cmembers, 2)
+	for _, cmember := range *cmembers {
+		require.NotEqual(t, th.BasicUser2.Id, cmember.UserId)
+	}
+
+	drift, err = th.App.GetChannelMembersDrift(channel)
+	require.Nil(t, err)
+	require.Empty(t, drift.UserIds)
+	require.Equal(t, []string{th.BasicUser.Id}, drift.ExemptUserIds)
+
+	// The group sync keeps the exempt members too, along with their admin role
+	th.App.SyncRolesAndMembership(channel.Id, model.GroupSyncableTypeChannel)
+
+	cmember, err := th.App.GetChannelMember(channel.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+	require.True(t, cmember.SchemeAdmin)
+	require.True(t, cmember.ManuallyManaged)
+}
+
 func TestSyncSyncableRoles(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -473,7 +557,7 @@ func TestSyncSyncableRoles(t *testing.T) {
 		require.Nil(t, err)
 
 		var tm *model.TeamMember
-		tm, err = th.App.AddTeamMember(team.Id, user.Id)
+		tm, err = th.App.AddTeamMember(team.Id, user.Id, "")
 		require.Nil(t, err)
 		require.False(t, tm.SchemeAdmin)
 
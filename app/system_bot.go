@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const SYSTEM_BOT_DISPLAY_NAME = "System"
+
+// EnsureSystemBot returns the id of the bot that messages sent by the server itself are posted as,
+// creating the bot if it doesn't exist yet. It is called at startup, so that the bot exists before
+// any message is sent, and the id is cached from then on.
+func (a *App) EnsureSystemBot() (string, *model.AppError) {
+	if botUserId, _ := a.Srv().systemBotId.Load().(string); botUserId != "" {
+		return botUserId, nil
+	}
+
+	a.Srv().systemBotLock.Lock()
+	defer a.Srv().systemBotLock.Unlock()
+
+	if botUserId, _ := a.Srv().systemBotId.Load().(string); botUserId != "" {
+		return botUserId, nil
+	}
+
+	user, err := a.GetUserByUsername(model.SYSTEM_BOT_USERNAME)
+	if err != nil && err.StatusCode != http.StatusNotFound {
+		return "", err
+	}
+
+	if user != nil && !user.IsBot {
+		// A user signed up with the username before it was reserved. Their account is left as it is,
+		// and the bot can't be created until an admin renames it.
+		return "", model.NewAppError("EnsureSystemBot", "app.system_bot.username_taken.app_error", map[string]interface{}{"Username": model.SYSTEM_BOT_USERNAME}, "user_id="+user.Id, http.StatusConflict)
+	}
+
+	if user == nil {
+		bot, err := a.CreateBot(&model.Bot{
+			Username:    model.SYSTEM_BOT_USERNAME,
+			DisplayName: SYSTEM_BOT_DISPLAY_NAME,
+			Description: "Sends the messages of the server.",
+			OwnerId:     model.SYSTEM_BOT_USERNAME,
+		})
+		if err != nil {
+			return "", err
+		}
+		user = &model.User{Id: bot.UserId}
+	}
+
+	a.Srv().systemBotId.Store(user.Id)
+
+	return user.Id, nil
+}
+
+// SendSystemBotDM posts the message, with the given props, as the system bot in its direct channel
+// with the user.
+func (a *App) SendSystemBotDM(userId, message string, props model.StringInterface) (*model.Post, *model.AppError) {
+	botUserId, err := a.EnsureSystemBot()
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetOrCreateDirectChannel(botUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.createSystemBotPost(botUserId, channel, message, props)
+}
+
+// SendSystemBotChannelPost posts the message, with the given props, as the system bot in the channel.
+func (a *App) SendSystemBotChannelPost(channelId, message string, props model.StringInterface) (*model.Post, *model.AppError) {
+	botUserId, err := a.EnsureSystemBot()
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.createSystemBotPost(botUserId, channel, message, props)
+}
+
+func (a *App) createSystemBotPost(botUserId string, channel *model.Channel, message string, props model.StringInterface) (*model.Post, *model.AppError) {
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    botUserId,
+		Message:   message,
+	}
+	for key, value := range props {
+		post.AddProp(key, value)
+	}
+
+	return a.CreatePost(post, channel, false, false)
+}
+
+// IsSystemBotDM returns whether the post was sent by the system bot to the user, who can delete it
+// without being allowed to delete the posts of others.
+func (a *App) IsSystemBotDM(post *model.Post, userId string) bool {
+	user, err := a.GetUser(post.UserId)
+	if err != nil || !isSystemBotUser(user) {
+		return false
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return false
+	}
+
+	return channel.Type == model.CHANNEL_DIRECT && channel.Name == model.GetDMNameFromIds(user.Id, userId)
+}
+
+func isSystemBotUser(user *model.User) bool {
+	return user.IsBot && user.Username == model.SYSTEM_BOT_USERNAME
+}
+
+// checkSystemBotUsernameReserved returns an error if a user, other than a bot, would take the username
+// of the system bot.
+func checkSystemBotUsernameReserved(where string, user *model.User) *model.AppError {
+	if user.IsBot || user.Username != model.SYSTEM_BOT_USERNAME {
+		return nil
+	}
+
+	return model.NewAppError(where, "app.system_bot.username_reserved.app_error", map[string]interface{}{"Username": model.SYSTEM_BOT_USERNAME}, "", http.StatusBadRequest)
+}
+
+func systemBotLockedError(where string) *model.AppError {
+	return model.NewAppError(where, "app.system_bot.locked.app_error", nil, "", http.StatusForbidden)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestEnsureSystemBot(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	botUserId, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+
+	bot, err := th.App.GetBot(botUserId, false)
+	require.Nil(t, err)
+	assert.Equal(t, model.SYSTEM_BOT_USERNAME, bot.Username)
+
+	again, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+	assert.Equal(t, botUserId, again)
+
+	t.Run("is locked", func(t *testing.T) {
+		_, err := th.App.PatchBot(botUserId, &model.BotPatch{DisplayName: model.NewString("renamed")})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+		_, err = th.App.UpdateBotActive(botUserId, false)
+		require.NotNil(t, err)
+
+		err = th.App.PermanentDeleteBot(botUserId)
+		require.NotNil(t, err)
+
+		_, err = th.App.PatchUser(botUserId, &model.UserPatch{Nickname: model.NewString("renamed")}, true)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+		user, err := th.App.GetUser(botUserId)
+		require.Nil(t, err)
+		user.Nickname = "renamed"
+		_, err = th.App.UpdateUserAsUser(user, true)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+		_, err = th.App.GetBot(botUserId, false)
+		require.Nil(t, err)
+	})
+
+	t.Run("isn't listed with the other bots", func(t *testing.T) {
+		bots, err := th.App.GetBots(&model.BotGetOptions{Page: 0, PerPage: 200})
+		require.Nil(t, err)
+		for _, bot := range bots {
+			assert.NotEqual(t, botUserId, bot.UserId)
+		}
+	})
+}
+
+func TestEnsureSystemBotUsernameTaken(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	botUserId, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+
+	// Start over from a server where a user signed up with the username of the bot before it was reserved.
+	require.NoError(t, th.App.Srv().Store.Bot().PermanentDelete(botUserId))
+	require.Nil(t, th.App.Srv().Store.User().PermanentDelete(botUserId))
+	th.App.Srv().systemBotId.Store("")
+
+	user, err := th.App.Srv().Store.User().Save(&model.User{
+		Email:    "success+" + model.NewId() + "@simulator.amazonses.com",
+		Username: model.SYSTEM_BOT_USERNAME,
+		Password: "passwd1",
+	})
+	require.Nil(t, err)
+
+	_, err = th.App.EnsureSystemBot()
+	require.NotNil(t, err)
+	assert.Equal(t, "app.system_bot.username_taken.app_error", err.Id)
+
+	user, err = th.App.GetUser(user.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.SYSTEM_BOT_USERNAME, user.Username)
+
+	// The bot is created once an admin renamed the user.
+	user.Username = "renamed" + model.NewId()
+	_, err = th.App.UpdateUser(user, false)
+	require.Nil(t, err)
+
+	botUserId, err = th.App.EnsureSystemBot()
+	require.Nil(t, err)
+	assert.NotEqual(t, user.Id, botUserId)
+}
+
+func TestSystemBotUsernameReserved(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, err := th.App.CreateUser(&model.User{
+		Email:    "success+" + model.NewId() + "@simulator.amazonses.com",
+		Username: model.SYSTEM_BOT_USERNAME,
+		Password: "passwd1",
+	})
+	require.NotNil(t, err)
+	assert.Equal(t, "app.system_bot.username_reserved.app_error", err.Id)
+
+	user := th.BasicUser.DeepCopy()
+	user.Username = model.SYSTEM_BOT_USERNAME
+	_, err = th.App.UpdateUser(user, false)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.system_bot.username_reserved.app_error", err.Id)
+}
+
+func TestSendSystemBotDM(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post, err := th.App.SendSystemBotDM(th.BasicUser.Id, "hello", model.StringInterface{"from_system": true})
+	require.Nil(t, err)
+	assert.Equal(t, "hello", post.Message)
+	assert.Equal(t, true, post.GetProp("from_system"))
+
+	botUserId, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+	assert.Equal(t, botUserId, post.UserId)
+
+	channel, err := th.App.GetChannel(post.ChannelId)
+	require.Nil(t, err)
+	assert.Equal(t, model.GetDMNameFromIds(botUserId, th.BasicUser.Id), channel.Name)
+
+	assert.True(t, th.App.IsSystemBotDM(post, th.BasicUser.Id))
+	assert.False(t, th.App.IsSystemBotDM(post, th.BasicUser2.Id))
+	assert.False(t, th.App.IsSystemBotDM(th.BasicPost, th.BasicUser.Id))
+}
+
+func TestSendSystemBotChannelPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post, err := th.App.SendSystemBotChannelPost(th.BasicChannel.Id, "hello", nil)
+	require.Nil(t, err)
+	assert.Equal(t, th.BasicChannel.Id, post.ChannelId)
+
+	botUserId, err := th.App.EnsureSystemBot()
+	require.Nil(t, err)
+	assert.Equal(t, botUserId, post.UserId)
+
+	assert.False(t, th.App.IsSystemBotDM(post, th.BasicUser.Id))
+}
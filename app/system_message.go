@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/go-i18n/i18n"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+// FormatSystemMessage renders a system post in the language of the given translations, from the
+// props it was created with. System posts are stored with a message in the server locale, so
+// anything rendered server side for a specific recipient, like notifications and exports, should
+// go through here instead of using the stored message. Posts of types that can't be rendered, or
+// missing the props needed to render them, fall back to the stored message.
+//
+// The arguments are passed to the translations in a fixed order, so translations that need the
+// names in a different order, as is common for right-to-left languages, should use explicit
+// argument indexes such as %[2]v.
+func FormatSystemMessage(post *model.Post, T i18n.TranslateFunc) string {
+	switch post.Type {
+	case model.POST_JOIN_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.join_channel.post_and_forget", "username")
+	case model.POST_GUEST_JOIN_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.guest_join_channel.post_and_forget", "username")
+	case model.POST_LEAVE_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.leave.left", "@username")
+	case model.POST_ADD_TO_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.add_member.added", "addedUsername", "username")
+	case model.POST_ADD_GUEST_TO_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.add_guest.added", "addedUsername", "username")
+	case model.POST_REMOVE_FROM_CHANNEL:
+		return formatSystemMessage(post, T, "api.channel.remove_member.removed", "@removedUsername")
+	case model.POST_JOIN_TEAM:
+		return formatSystemMessage(post, T, "api.team.join_team.post_and_forget", "username")
+	case model.POST_LEAVE_TEAM:
+		return formatSystemMessage(post, T, "api.team.leave.left", "username")
+	case model.POST_ADD_TO_TEAM:
+		return formatSystemMessage(post, T, "api.team.add_user_to_team.added", "addedUsername", "username")
+	case model.POST_REMOVE_FROM_TEAM:
+		return formatSystemMessage(post, T, "api.team.remove_user_from_team.removed", "username")
+	case model.POST_HEADER_CHANGE:
+		return formatChangeSystemMessage(post, T, "api.channel.post_update_channel_header_message_and_forget", "old_header", "new_header")
+	case model.POST_PURPOSE_CHANGE:
+		return formatChangeSystemMessage(post, T, "app.channel.post_update_channel_purpose_message", "old_purpose", "new_purpose")
+	case model.POST_DISPLAYNAME_CHANGE:
+		return formatSystemMessage(post, T, "api.channel.post_update_channel_displayname_message_and_forget.updated_from", "username", "old_displayname", "new_displayname")
+	}
+
+	return post.Message
+}
+
+// formatSystemMessage fills in the given translation with the values of the given props, in order.
+// Props prefixed with @ are usernames to be rendered as mentions.
+func formatSystemMessage(post *model.Post, T i18n.TranslateFunc, translationId string, propNames ...string) string {
+	args := make([]interface{}, len(propNames))
+	for i, name := range propNames {
+		prefix := ""
+		if name[0] == '@' {
+			prefix = "@"
+			name = name[1:]
+		}
+
+		value, ok := post.GetProp(name).(string)
+		if !ok {
+			return post.Message
+		}
+		args[i] = prefix + value
+	}
+
+	return fmt.Sprintf(T(translationId), args...)
+}
+
+// formatChangeSystemMessage renders the message of a post changing a channel field, picking the
+// translation depending on whether the field was set, removed or updated.
+func formatChangeSystemMessage(post *model.Post, T i18n.TranslateFunc, translationPrefix, oldPropName, newPropName string) string {
+	oldValue, _ := post.GetProp(oldPropName).(string)
+	newValue, _ := post.GetProp(newPropName).(string)
+
+	if oldValue == "" {
+		return formatSystemMessage(post, T, translationPrefix+".updated_to", "username", newPropName)
+	} else if newValue == "" {
+		return formatSystemMessage(post, T, translationPrefix+".removed", "username", oldPropName)
+	}
+
+	return formatSystemMessage(post, T, translationPrefix+".updated_from", "username", oldPropName, newPropName)
+}
+
+// getRecipientTranslations returns the translations to render server side content for the given
+// user, falling back to the default client locale for users who never picked a locale.
+func (s *Server) getRecipientTranslations(user *model.User) i18n.TranslateFunc {
+	locale := user.Locale
+	if locale == "" {
+		locale = *s.Config().LocalizationSettings.DefaultClientLocale
+	}
+
+	return utils.GetUserTranslations(locale)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+func TestFormatSystemMessage(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	T := utils.GetUserTranslations("en")
+
+	for name, tc := range map[string]struct {
+		post     *model.Post
+		expected string
+	}{
+		"join channel": {
+			post:     &model.Post{Type: model.POST_JOIN_CHANNEL, Props: model.StringInterface{"username": "alice"}},
+			expected: "alice joined the channel.",
+		},
+		"leave channel": {
+			post:     &model.Post{Type: model.POST_LEAVE_CHANNEL, Props: model.StringInterface{"username": "alice"}},
+			expected: "@alice left the channel.",
+		},
+		"add to channel": {
+			post:     &model.Post{Type: model.POST_ADD_TO_CHANNEL, Props: model.StringInterface{"username": "alice", "addedUsername": "bob"}},
+			expected: "bob added to the channel by alice.",
+		},
+		"remove from channel": {
+			post:     &model.Post{Type: model.POST_REMOVE_FROM_CHANNEL, Props: model.StringInterface{"removedUsername": "bob"}},
+			expected: "@bob removed from the channel.",
+		},
+		"join team": {
+			post:     &model.Post{Type: model.POST_JOIN_TEAM, Props: model.StringInterface{"username": "alice"}},
+			expected: "alice joined the team.",
+		},
+		"add to team": {
+			post:     &model.Post{Type: model.POST_ADD_TO_TEAM, Props: model.StringInterface{"username": "alice", "addedUsername": "bob"}},
+			expected: "bob added to the team by alice.",
+		},
+		"header set": {
+			post:     &model.Post{Type: model.POST_HEADER_CHANGE, Props: model.StringInterface{"username": "alice", "old_header": "", "new_header": "new"}},
+			expected: "alice updated the channel header to: new",
+		},
+		"header removed": {
+			post:     &model.Post{Type: model.POST_HEADER_CHANGE, Props: model.StringInterface{"username": "alice", "old_header": "old", "new_header": ""}},
+			expected: "alice removed the channel header (was: old)",
+		},
+		"header updated": {
+			post:     &model.Post{Type: model.POST_HEADER_CHANGE, Props: model.StringInterface{"username": "alice", "old_header": "old", "new_header": "new"}},
+			expected: "alice updated the channel header from: old to: new",
+		},
+		"missing props fall back to the stored message": {
+			post:     &model.Post{Type: model.POST_ADD_TO_CHANNEL, Message: "stored", Props: model.StringInterface{"username": "alice"}},
+			expected: "stored",
+		},
+		"unknown types fall back to the stored message": {
+			post:     &model.Post{Type: model.POST_EPHEMERAL, Message: "stored"},
+			expected: "stored",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FormatSystemMessage(tc.post, T))
+		})
+	}
+
+	t.Run("uses the given locale", func(t *testing.T) {
+		post := &model.Post{Type: model.POST_JOIN_CHANNEL, Message: "alice joined the channel.", Props: model.StringInterface{"username": "alice"}}
+
+		assert.Equal(t, "alice se unió al canal.", FormatSystemMessage(post, utils.GetUserTranslations("es")))
+	})
+
+	t.Run("lets translations reorder names", func(t *testing.T) {
+		rtl := func(translationID string, args ...interface{}) string {
+			if translationID == "api.channel.add_member.added" {
+				return "%[2]v הוסיף את %[1]v לערוץ."
+			}
+			return translationID
+		}
+		post := &model.Post{Type: model.POST_ADD_TO_CHANNEL, Props: model.StringInterface{"username": "alice", "addedUsername": "bob"}}
+
+		assert.Equal(t, "alice הוסיף את bob לערוץ.", FormatSystemMessage(post, rtl))
+	})
+}
+
+func TestGetRecipientTranslations(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.LocalizationSettings.DefaultClientLocale = "es"
+		*cfg.LocalizationSettings.AvailableLocales = ""
+	})
+
+	post := &model.Post{Type: model.POST_JOIN_CHANNEL, Props: model.StringInterface{"username": "alice"}}
+
+	T := th.App.Srv().getRecipientTranslations(&model.User{Locale: "en"})
+	assert.Equal(t, "alice joined the channel.", FormatSystemMessage(post, T))
+
+	T = th.App.Srv().getRecipientTranslations(&model.User{})
+	assert.Equal(t, "alice se unió al canal.", FormatSystemMessage(post, T), "should fall back to the default client locale")
+}
+
+func TestSystemMessageNotificationsUseRecipientLocale(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	receiver := th.CreateUser()
+	receiver.Locale = "es"
+
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Type:      model.POST_ADD_TO_CHANNEL,
+		Message:   fmt.Sprintf("%v added to the channel by %v.", receiver.Username, th.BasicUser.Username),
+		Props: model.StringInterface{
+			"username":      th.BasicUser.Username,
+			"addedUsername": receiver.Username,
+		},
+	}
+	expected := fmt.Sprintf("%v agregado al canal por %v", receiver.Username, th.BasicUser.Username)
+
+	t.Run("email", func(t *testing.T) {
+		message := th.App.Srv().GetMessageForNotification(post, th.App.Srv().getRecipientTranslations(receiver))
+		assert.Equal(t, expected, message)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		msg, err := th.App.BuildPushNotificationMessage(model.FULL_NOTIFICATION, post, receiver, th.BasicChannel, th.BasicChannel.Name, "System", true, false, "")
+		require.Nil(t, err)
+		assert.Equal(t, "System: "+expected, msg.Message)
+	})
+}
@@ -13,9 +13,12 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/disintegration/imaging"
+	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
@@ -23,6 +26,8 @@ import (
 	"github.com/mattermost/mattermost-server/v5/utils"
 )
 
+const MOVE_USERS_TO_TEAM_BATCH_SIZE = 100
+
 func (a *App) CreateTeam(team *model.Team) (*model.Team, *model.AppError) {
 	team.InviteId = ""
 	rteam, err := a.Srv().Store.Team().Save(team)
@@ -96,6 +101,16 @@ func (a *App) isTeamEmailAllowed(user *model.User, team *model.Team) bool {
 	return a.isEmailAddressAllowed(email, allowedDomains)
 }
 
+// checkTeamInviteEmailAllowed returns an error when invite links are restricted to the allowed
+// domains and the user's email doesn't match them. Guests are checked against the guest domains.
+func (a *App) checkTeamInviteEmailAllowed(user *model.User, team *model.Team, where string) *model.AppError {
+	if !*a.Config().TeamSettings.RestrictTeamInviteToEmail || a.isTeamEmailAllowed(user, team) {
+		return nil
+	}
+
+	return model.NewAppError(where, "api.team.invite_email_domain_mismatch.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+}
+
 func (a *App) getAllowedDomains(user *model.User, team *model.Team) []string {
 	if user.IsGuest() {
 		return []string{*a.Config().GuestAccountsSettings.RestrictCreationToDomains}
@@ -142,6 +157,8 @@ func (a *App) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
 	oldTeam.AllowedDomains = team.AllowedDomains
 	oldTeam.LastTeamIconUpdate = team.LastTeamIconUpdate
 	oldTeam.GroupConstrained = team.GroupConstrained
+	oldTeam.InactiveChannelArchiveDays = team.InactiveChannelArchiveDays
+	oldTeam.AutoJoinDomains = team.AutoJoinDomains
 
 	oldTeam, err = a.updateTeamUnsanitized(oldTeam)
 	if err != nil {
@@ -557,6 +574,10 @@ func (a *App) AddUserToTeamByInviteId(inviteId string, userId string) (*model.Te
 	}
 	user := result.Data.(*model.User)
 
+	if err := a.checkTeamInviteEmailAllowed(user, team, "AddUserToTeamByInviteId"); err != nil {
+		return nil, err
+	}
+
 	if err := a.JoinUserToTeam(team, user, ""); err != nil {
 		return nil, err
 	}
@@ -564,6 +585,36 @@ func (a *App) AddUserToTeamByInviteId(inviteId string, userId string) (*model.Te
 	return team, nil
 }
 
+// maxUsersPerTeam returns the maximum number of active members a team can have, or -1 when the
+// number of members is unlimited.
+func (a *App) maxUsersPerTeam() int {
+	if maxUsers := *a.Config().TeamSettings.MaxUsersPerTeam; maxUsers > 0 {
+		return maxUsers
+	}
+
+	return -1
+}
+
+// checkTeamHasRoomForMember returns an error if the given team already has as many active members
+// as the config allows.
+func (a *App) checkTeamHasRoomForMember(teamId string) *model.AppError {
+	maxUsers := a.maxUsersPerTeam()
+	if maxUsers < 0 {
+		return nil
+	}
+
+	membersCount, err := a.Srv().Store.Team().GetActiveMemberCount(teamId, nil)
+	if err != nil {
+		return err
+	}
+
+	if membersCount >= int64(maxUsers) {
+		return model.NewAppError("checkTeamHasRoomForMember", "app.team.max_users.app_error", nil, "team_id="+teamId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 // Returns three values:
 // 1. a pointer to the team member, if successful
 // 2. a boolean: true if the user has a non-deleted team member for that team already, otherwise false.
@@ -591,8 +642,12 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 	rtm, err := a.Srv().Store.Team().GetMember(team.Id, user.Id)
 	if err != nil {
 		// Membership appears to be missing. Lets try to add.
+		if err = a.checkTeamHasRoomForMember(tm.TeamId); err != nil {
+			return nil, false, err
+		}
+
 		var tmr *model.TeamMember
-		tmr, err = a.Srv().Store.Team().SaveMember(tm, *a.Config().TeamSettings.MaxUsersPerTeam)
+		tmr, err = a.Srv().Store.Team().SaveMember(tm, a.maxUsersPerTeam())
 		if err != nil {
 			return nil, false, err
 		}
@@ -605,15 +660,10 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 		return rtm, true, nil
 	}
 
-	membersCount, err := a.Srv().Store.Team().GetActiveMemberCount(tm.TeamId, nil)
-	if err != nil {
+	if err = a.checkTeamHasRoomForMember(tm.TeamId); err != nil {
 		return nil, false, err
 	}
 
-	if membersCount >= int64(*a.Config().TeamSettings.MaxUsersPerTeam) {
-		return nil, false, model.NewAppError("joinUserToTeam", "app.team.join_user_to_team.max_accounts.app_error", nil, "teamId="+tm.TeamId, http.StatusBadRequest)
-	}
-
 	member, err := a.Srv().Store.Team().UpdateMember(tm)
 	if err != nil {
 		return nil, false, err
@@ -622,6 +672,42 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 	return member, false, nil
 }
 
+// applyTeamDefaultClientLocale sets the locale of a user who just joined their first team to the
+// default locale of that team, if it has one. The locale users chose always wins, even when it is
+// the server's default, so only users whose locale was defaulted at signup are changed.
+func (a *App) applyTeamDefaultClientLocale(team *model.Team, user *model.User) *model.AppError {
+	locale, ok := a.Config().LocalizationSettings.TeamDefaultClientLocales[team.Id]
+	if !ok || user.Props[model.USER_PROP_DEFAULTED_LOCALE] != "true" {
+		return nil
+	}
+
+	members, err := a.Srv().Store.Team().GetTeamsForUser(user.Id)
+	if err != nil {
+		return err
+	}
+	if len(members) > 1 {
+		return nil
+	}
+
+	ruser, err := a.Srv().Store.User().Get(user.Id)
+	if err != nil {
+		return err
+	}
+	ruser.Locale = locale
+	delete(ruser.Props, model.USER_PROP_DEFAULTED_LOCALE)
+
+	updatedUser, err := a.UpdateUser(ruser, false)
+	if err != nil {
+		return err
+	}
+	user.Locale = updatedUser.Locale
+	user.Props = updatedUser.Props
+
+	a.sendUpdatedUserEvent(*updatedUser)
+
+	return nil
+}
+
 func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId string) *model.AppError {
 	if !a.isTeamEmailAllowed(user, team) {
 		return model.NewAppError("JoinUserToTeam", "api.team.join_user_to_team.allowed_domains.app_error", nil, "", http.StatusBadRequest)
@@ -662,6 +748,15 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 		)
 	}
 
+	if err := a.applyTeamDefaultClientLocale(team, user); err != nil {
+		mlog.Error(
+			"Encountered an issue applying the default locale of the team.",
+			mlog.String("user_id", user.Id),
+			mlog.String("team_id", team.Id),
+			mlog.Err(err),
+		)
+	}
+
 	shouldBeAdmin := team.Email == user.Email
 
 	if !user.IsGuest() {
@@ -680,10 +775,16 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 	a.InvalidateCacheForUser(user.Id)
 	a.invalidateCacheForUserTeams(user.Id)
 
-	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_ADDED_TO_TEAM, "", "", user.Id, nil)
-	message.Add("team_id", team.Id)
-	message.Add("user_id", user.Id)
-	a.Publish(message)
+	a.publishAddedToTeam(user.Id, team.Id, userRequestorId)
+
+	if err := a.repairTeamsOrderForUser(user.Id); err != nil {
+		mlog.Error(
+			"Encountered an issue updating the team order.",
+			mlog.String("user_id", user.Id),
+			mlog.String("team_id", team.Id),
+			mlog.Err(err),
+		)
+	}
 
 	return nil
 }
@@ -801,8 +902,8 @@ func (a *App) GetTeamMembersByIds(teamId string, userIds []string, restrictions
 	return a.Srv().Store.Team().GetMembersByIds(teamId, userIds, restrictions)
 }
 
-func (a *App) AddTeamMember(teamId, userId string) (*model.TeamMember, *model.AppError) {
-	if _, err := a.AddUserToTeam(teamId, userId, ""); err != nil {
+func (a *App) AddTeamMember(teamId, userId, userRequestorId string) (*model.TeamMember, *model.AppError) {
+	if _, err := a.AddUserToTeam(teamId, userId, userRequestorId); err != nil {
 		return nil, err
 	}
 
@@ -811,12 +912,21 @@ func (a *App) AddTeamMember(teamId, userId string) (*model.TeamMember, *model.Ap
 		return nil, err
 	}
 
+	return teamMember, nil
+}
+
+// publishAddedToTeam notifies the user that they were added to the team by the actor, whose id is
+// left empty when the user joined by themself.
+func (a *App) publishAddedToTeam(userId, teamId, actorId string) {
+	if actorId == userId {
+		actorId = ""
+	}
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_ADDED_TO_TEAM, "", "", userId, nil)
 	message.Add("team_id", teamId)
 	message.Add("user_id", userId)
+	message.Add("actor_id", actorId)
 	a.Publish(message)
-
-	return teamMember, nil
 }
 
 func (a *App) AddTeamMembers(teamId string, userIds []string, userRequestorId string, graceful bool) ([]*model.TeamMemberWithError, *model.AppError) {
@@ -842,16 +952,93 @@ func (a *App) AddTeamMembers(teamId string, userIds []string, userRequestorId st
 			UserId: userId,
 			Member: teamMember,
 		})
-
-		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_ADDED_TO_TEAM, "", "", userId, nil)
-		message.Add("team_id", teamId)
-		message.Add("user_id", userId)
-		a.Publish(message)
 	}
 
 	return membersWithErrors, nil
 }
 
+// MoveUsersToTeam moves the given users from one team to another, joining them to the default
+// channels of the target team, and returns the result of each move in the order of the given ids.
+// A user who can't be moved doesn't stop the others, and a user who is already a member of the
+// target team is only removed from the source team. Users are looked up in batches of
+// MOVE_USERS_TO_TEAM_BATCH_SIZE.
+func (a *App) MoveUsersToTeam(userIds []string, fromTeamId, toTeamId string) ([]*model.TeamMemberWithError, *model.AppError) {
+	if fromTeamId == toTeamId {
+		return nil, model.NewAppError("MoveUsersToTeam", "app.team.move_users_to_team.same_team.app_error", nil, "team_id="+toTeamId, http.StatusBadRequest)
+	}
+
+	fromTeam, err := a.GetTeam(fromTeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	toTeam, err := a.GetTeam(toTeamId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.TeamMemberWithError, 0, len(userIds))
+	for start := 0; start < len(userIds); start += MOVE_USERS_TO_TEAM_BATCH_SIZE {
+		end := start + MOVE_USERS_TO_TEAM_BATCH_SIZE
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+		batch := userIds[start:end]
+
+		users, err := a.Srv().Store.User().GetProfileByIds(batch, nil, false)
+		if err != nil {
+			return nil, err
+		}
+
+		usersById := make(map[string]*model.User, len(users))
+		for _, user := range users {
+			usersById[user.Id] = user
+		}
+
+		for _, userId := range batch {
+			result := &model.TeamMemberWithError{UserId: userId}
+			result.Member, result.Error = a.moveUserToTeam(usersById[userId], userId, fromTeam, toTeam)
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func (a *App) moveUserToTeam(user *model.User, userId string, fromTeam, toTeam *model.Team) (member *model.TeamMember, appErr *model.AppError) {
+	auditRec := a.MakeAuditRecord("moveUserToTeam", audit.Fail)
+	defer func() { a.LogAuditRec(auditRec, appErr) }()
+	auditRec.AddMeta("user_id", userId)
+	auditRec.AddMeta("from_team", fromTeam)
+	auditRec.AddMeta("to_team", toTeam)
+
+	if user == nil {
+		return nil, model.NewAppError("MoveUsersToTeam", "app.team.move_users_to_team.user_not_found.app_error", nil, "user_id="+userId, http.StatusNotFound)
+	}
+
+	if fromMember, err := a.GetTeamMember(fromTeam.Id, user.Id); err != nil || fromMember.DeleteAt != 0 {
+		return nil, model.NewAppError("MoveUsersToTeam", "app.team.move_users_to_team.not_member.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	// Join the target team first, so a user who can't join it stays in the source team.
+	if err := a.JoinUserToTeam(toTeam, user, ""); err != nil {
+		return nil, err
+	}
+
+	if err := a.LeaveTeam(fromTeam, user, ""); err != nil {
+		return nil, err
+	}
+
+	member, err := a.GetTeamMember(toTeam.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	auditRec.Success()
+
+	return member, nil
+}
+
 func (a *App) AddTeamMemberByToken(userId, tokenId string) (*model.TeamMember, *model.AppError) {
 	team, err := a.AddUserToTeamByToken(userId, tokenId)
 	if err != nil {
@@ -883,6 +1070,31 @@ func (a *App) AddTeamMemberByInviteId(inviteId, userId string) (*model.TeamMembe
 	return teamMember, nil
 }
 
+// joinUserToAutoJoinTeams adds a user to every team with an auto-join domain matching the user's
+// email address, once the address is verified when verification is required. Group constrained
+// teams are left alone since their membership is managed by groups.
+func (a *App) joinUserToAutoJoinTeams(user *model.User) *model.AppError {
+	teams, err := a.Srv().Store.Team().GetAllWithAutoJoinDomains()
+	if err != nil {
+		return model.NewAppError("joinUserToAutoJoinTeams", "app.team.get_auto_join_teams.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, team := range teams {
+		// CheckEmailDomain allows any email when there are no domains, so a value made only of
+		// separators must not be treated as a match.
+		domains := team.GetAutoJoinDomains()
+		if len(domains) == 0 || team.IsGroupConstrained() || !CheckEmailDomain(user.Email, strings.Join(domains, " ")) {
+			continue
+		}
+
+		if err := a.JoinUserToTeam(team, user, ""); err != nil {
+			mlog.Error("Failed to auto-join user to team", mlog.String("user_id", user.Id), mlog.String("team_id", team.Id), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
+
 func (a *App) GetTeamUnread(teamId, userId string) (*model.TeamUnread, *model.AppError) {
 	channelUnreads, err := a.Srv().Store.Team().GetChannelUnreadsForTeam(teamId, userId)
 	if err != nil {
@@ -896,16 +1108,28 @@ func (a *App) GetTeamUnread(teamId, userId string) (*model.TeamUnread, *model.Ap
 	}
 
 	for _, cu := range channelUnreads {
-		teamUnread.MentionCount += cu.MentionCount
-
-		if cu.NotifyProps[model.MARK_UNREAD_NOTIFY_PROP] != model.CHANNEL_MARK_UNREAD_MENTION {
-			teamUnread.MsgCount += cu.MsgCount
-		}
+		addChannelUnreadToTeamUnread(teamUnread, cu)
 	}
 
 	return teamUnread, nil
 }
 
+// addChannelUnreadToTeamUnread adds the unread messages and mentions of the channel to the team's counts.
+// Messages in muted channels aren't counted, and muted direct and group channels, which don't belong to
+// a team, aren't counted at all.
+func addChannelUnreadToTeamUnread(tu *model.TeamUnread, cu *model.ChannelUnread) {
+	muted := cu.NotifyProps[model.MARK_UNREAD_NOTIFY_PROP] == model.CHANNEL_MARK_UNREAD_MENTION
+	if muted && cu.TeamId == "" {
+		return
+	}
+
+	tu.MentionCount += cu.MentionCount
+
+	if !muted {
+		tu.MsgCount += cu.MsgCount
+	}
+}
+
 func (a *App) RemoveUserFromTeam(teamId string, userId string, requestorId string) *model.AppError {
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
@@ -942,9 +1166,15 @@ func (a *App) RemoveUserFromTeam(teamId string, userId string, requestorId strin
 
 func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId string) *model.AppError {
 	// Send the websocket message before we actually do the remove so the user being removed gets it.
+	actorId := requestorId
+	if actorId == teamMember.UserId {
+		actorId = ""
+	}
+
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_LEAVE_TEAM, teamMember.TeamId, "", "", nil)
 	message.Add("user_id", teamMember.UserId)
 	message.Add("team_id", teamMember.TeamId)
+	message.Add("actor_id", actorId)
 	a.Publish(message)
 
 	user, err := a.Srv().Store.User().Get(teamMember.UserId)
@@ -991,6 +1221,15 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 	a.InvalidateCacheForUser(user.Id)
 	a.invalidateCacheForUserTeams(user.Id)
 
+	if err := a.repairTeamsOrderForUser(user.Id); err != nil {
+		mlog.Error(
+			"Encountered an issue updating the team order.",
+			mlog.String("user_id", user.Id),
+			mlog.String("team_id", teamMember.TeamId),
+			mlog.Err(err),
+		)
+	}
+
 	return nil
 }
 
@@ -1052,6 +1291,10 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 }
 
 func (a *App) postLeaveTeamMessage(user *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message:   fmt.Sprintf(utils.T("api.team.leave.left"), user.Username),
@@ -1070,6 +1313,10 @@ func (a *App) postLeaveTeamMessage(user *model.User, channel *model.Channel) *mo
 }
 
 func (a *App) postRemoveFromTeamMessage(user *model.User, channel *model.Channel) *model.AppError {
+	if channel.IsJoinLeaveMessagesSuppressed() {
+		return nil
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message:   fmt.Sprintf(utils.T("api.team.remove_user_from_team.removed"), user.Username),
@@ -1324,27 +1571,16 @@ func (a *App) GetTeamsUnreadForUser(excludeTeamId string, userId string) ([]*mod
 	members := []*model.TeamUnread{}
 	membersMap := make(map[string]*model.TeamUnread)
 
-	unreads := func(cu *model.ChannelUnread, tu *model.TeamUnread) *model.TeamUnread {
-		tu.MentionCount += cu.MentionCount
-
-		if cu.NotifyProps[model.MARK_UNREAD_NOTIFY_PROP] != model.CHANNEL_MARK_UNREAD_MENTION {
-			tu.MsgCount += cu.MsgCount
-		}
-
-		return tu
-	}
-
 	for i := range data {
 		id := data[i].TeamId
-		if mu, ok := membersMap[id]; ok {
-			membersMap[id] = unreads(data[i], mu)
-		} else {
-			membersMap[id] = unreads(data[i], &model.TeamUnread{
+		if _, ok := membersMap[id]; !ok {
+			membersMap[id] = &model.TeamUnread{
 				MsgCount:     0,
 				MentionCount: 0,
 				TeamId:       id,
-			})
+			}
 		}
+		addChannelUnreadToTeamUnread(membersMap[id], data[i])
 	}
 
 	for _, val := range membersMap {
@@ -1354,6 +1590,296 @@ func (a *App) GetTeamsUnreadForUser(excludeTeamId string, userId string) ([]*mod
 	return members, nil
 }
 
+// GetTeamsOrderForUser returns the ids of the teams the user belongs to, in the order the user
+// has chosen for the team sidebar.
+func (a *App) GetTeamsOrderForUser(userId string) ([]string, *model.AppError) {
+	teams, err := a.GetTeamsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	savedOrder, err := a.getSavedTeamsOrderForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeTeamsOrder(savedOrder, teams), nil
+}
+
+// UpdateTeamsOrderForUser saves the order of the team sidebar for the user. Every team in the
+// given order must be one the user belongs to; any of the user's teams that are missing from
+// it are appended.
+func (a *App) UpdateTeamsOrderForUser(userId string, teamIds []string) ([]string, *model.AppError) {
+	teams, err := a.GetTeamsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	memberOf := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		memberOf[team.Id] = true
+	}
+
+	seen := make(map[string]bool, len(teamIds))
+	for _, teamId := range teamIds {
+		if !memberOf[teamId] || seen[teamId] {
+			return nil, model.NewAppError("UpdateTeamsOrderForUser", "app.team.update_teams_order.invalid_team.app_error", nil, "team_id="+teamId, http.StatusBadRequest)
+		}
+		seen[teamId] = true
+	}
+
+	order := normalizeTeamsOrder(teamIds, teams)
+	if err := a.saveTeamsOrderForUser(userId, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetTeamMembersWithUnreadForUser returns the user's team memberships in the user's team order,
+// each with the unread counts for that team attached.
+func (a *App) GetTeamMembersWithUnreadForUser(userId string) ([]*model.TeamMemberWithUnread, *model.AppError) {
+	members, err := a.GetTeamMembersForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unreads, err := a.GetTeamsUnreadForUser("", userId)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := a.GetTeamsOrderForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadByTeam := make(map[string]*model.TeamUnread, len(unreads))
+	for _, unread := range unreads {
+		unreadByTeam[unread.TeamId] = unread
+	}
+
+	position := make(map[string]int, len(order))
+	for i, teamId := range order {
+		position[teamId] = i
+	}
+
+	result := make([]*model.TeamMemberWithUnread, 0, len(members))
+	for _, member := range members {
+		memberWithUnread := &model.TeamMemberWithUnread{TeamMember: *member}
+		if unread, ok := unreadByTeam[member.TeamId]; ok {
+			memberWithUnread.MsgCount = unread.MsgCount
+			memberWithUnread.MentionCount = unread.MentionCount
+		}
+		result = append(result, memberWithUnread)
+	}
+
+	// Memberships outside of the team order, such as ones the user has left, are sorted last.
+	sort.SliceStable(result, func(i, j int) bool {
+		pi, ok := position[result[i].TeamId]
+		if !ok {
+			pi = len(order)
+		}
+		pj, ok := position[result[j].TeamId]
+		if !ok {
+			pj = len(order)
+		}
+		return pi < pj
+	})
+
+	return result, nil
+}
+
+// GetTeamsForUserWithUnreads returns the teams the user belongs to in the user's team order, each
+// with the user's unread message and mention counts for that team. Muted channels are counted the
+// same way as for GetTeamsUnreadForUser.
+func (a *App) GetTeamsForUserWithUnreads(userId string) ([]*model.TeamWithUnreads, *model.AppError) {
+	teams, err := a.GetTeamsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unreads, err := a.GetTeamsUnreadForUser("", userId)
+	if err != nil {
+		return nil, err
+	}
+
+	savedOrder, err := a.getSavedTeamsOrderForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadByTeam := make(map[string]*model.TeamUnread, len(unreads))
+	for _, unread := range unreads {
+		unreadByTeam[unread.TeamId] = unread
+	}
+
+	teamsById := make(map[string]*model.Team, len(teams))
+	for _, team := range teams {
+		teamsById[team.Id] = team
+	}
+
+	order := normalizeTeamsOrder(savedOrder, teams)
+	result := make([]*model.TeamWithUnreads, 0, len(order))
+	for _, teamId := range order {
+		teamWithUnreads := &model.TeamWithUnreads{Team: *teamsById[teamId]}
+		if unread, ok := unreadByTeam[teamId]; ok {
+			teamWithUnreads.MsgCount = unread.MsgCount
+			teamWithUnreads.MentionCount = unread.MentionCount
+		}
+		result = append(result, teamWithUnreads)
+	}
+
+	return result, nil
+}
+
+// repairTeamsOrderForUser brings the user's saved team order in line with the teams they
+// currently belong to, notifying the user's clients if it changed.
+func (a *App) repairTeamsOrderForUser(userId string) *model.AppError {
+	teams, err := a.GetTeamsForUser(userId)
+	if err != nil {
+		return err
+	}
+
+	savedOrder, err := a.getSavedTeamsOrderForUser(userId)
+	if err != nil {
+		return err
+	}
+
+	order := normalizeTeamsOrder(savedOrder, teams)
+	if strings.Join(order, ",") == strings.Join(savedOrder, ",") {
+		return nil
+	}
+
+	return a.saveTeamsOrderForUser(userId, order)
+}
+
+// teamsOrderChunkSize is the number of team ids saved per preference, which keeps the value of each
+// preference within the length limit of preferences.
+const teamsOrderChunkSize = 70
+
+// teamsOrderChunkIndex returns the position of the preference in the user's team order, which is
+// saved in chunks named after their position, the first one having no name.
+func teamsOrderChunkIndex(preference model.Preference) (int, bool) {
+	if preference.Name == "" {
+		return 0, true
+	}
+
+	index, err := strconv.Atoi(preference.Name)
+	if err != nil || index <= 0 {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func (a *App) getSavedTeamsOrderForUser(userId string) ([]string, *model.AppError) {
+	preferences, err := a.Srv().Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_TEAMS_ORDER)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(map[int]string, len(preferences))
+	for _, preference := range preferences {
+		if index, ok := teamsOrderChunkIndex(preference); ok {
+			chunks[index] = preference.Value
+		}
+	}
+
+	order := []string{}
+	for index := 0; chunks[index] != ""; index++ {
+		order = append(order, strings.Split(chunks[index], ",")...)
+	}
+
+	return order, nil
+}
+
+func (a *App) saveTeamsOrderForUser(userId string, order []string) *model.AppError {
+	existing, err := a.Srv().Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_TEAMS_ORDER)
+	if err != nil {
+		return err
+	}
+
+	preferences := model.Preferences{}
+	for start := 0; start == 0 || start < len(order); start += teamsOrderChunkSize {
+		end := start + teamsOrderChunkSize
+		if end > len(order) {
+			end = len(order)
+		}
+
+		name := ""
+		if start > 0 {
+			name = strconv.Itoa(start / teamsOrderChunkSize)
+		}
+
+		preferences = append(preferences, model.Preference{
+			UserId:   userId,
+			Category: model.PREFERENCE_CATEGORY_TEAMS_ORDER,
+			Name:     name,
+			Value:    strings.Join(order[start:end], ","),
+		})
+	}
+
+	if err := a.Srv().Store.Preference().Save(&preferences); err != nil {
+		return err
+	}
+
+	// Drop the chunks left over from a longer order
+	for _, preference := range existing {
+		if index, ok := teamsOrderChunkIndex(preference); ok && index >= len(preferences) {
+			if err := a.Srv().Store.Preference().Delete(userId, preference.Category, preference.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_TEAMS_ORDER_UPDATED, "", "", userId, nil)
+	message.Add("order", order)
+	a.Publish(message)
+
+	return nil
+}
+
+// normalizeTeamsOrder drops any teams from the order that are not in the given teams, then
+// appends the teams missing from the order sorted by display name so that new teams land in a
+// deterministic position.
+func normalizeTeamsOrder(order []string, teams []*model.Team) []string {
+	teamsById := make(map[string]*model.Team, len(teams))
+	for _, team := range teams {
+		teamsById[team.Id] = team
+	}
+
+	normalized := make([]string, 0, len(teams))
+	seen := make(map[string]bool, len(teams))
+	for _, teamId := range order {
+		if _, ok := teamsById[teamId]; ok && !seen[teamId] {
+			normalized = append(normalized, teamId)
+			seen[teamId] = true
+		}
+	}
+
+	var missing []*model.Team
+	for _, team := range teams {
+		if !seen[team.Id] {
+			missing = append(missing, team)
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		nameI, nameJ := strings.ToLower(missing[i].DisplayName), strings.ToLower(missing[j].DisplayName)
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return missing[i].Id < missing[j].Id
+	})
+
+	for _, team := range missing {
+		normalized = append(normalized, team.Id)
+	}
+
+	return normalized
+}
+
 func (a *App) PermanentDeleteTeamId(teamId string) *model.AppError {
 	team, err := a.GetTeam(teamId)
 	if err != nil {
@@ -455,6 +455,73 @@ func TestAddUserToTeamByTeamId(t *testing.T) {
 
 }
 
+func TestAddTeamMemberByInviteIdRestrictTeamInviteToEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	createUser := func(domain string) *model.User {
+		user := model.User{Email: strings.ToLower(model.NewId()) + "success+test@" + domain, Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+		ruser, err := th.App.CreateUser(&user)
+		require.Nil(t, err)
+		return ruser
+	}
+	matchingUser := createUser("example.com")
+	mismatchingUser := createUser("invalid.com")
+	anyUser := createUser("invalid.com")
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.RestrictTeamInviteToEmail = true
+		*cfg.TeamSettings.RestrictCreationToDomains = "example.com"
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.RestrictTeamInviteToEmail = false
+		*cfg.TeamSettings.RestrictCreationToDomains = ""
+	})
+
+	t.Run("domain match", func(t *testing.T) {
+		member, err := th.App.AddTeamMemberByInviteId(th.BasicTeam.InviteId, matchingUser.Id)
+		require.Nil(t, err)
+		require.Equal(t, matchingUser.Id, member.UserId)
+	})
+
+	t.Run("domain mismatch", func(t *testing.T) {
+		_, err := th.App.AddTeamMemberByInviteId(th.BasicTeam.InviteId, mismatchingUser.Id)
+		require.NotNil(t, err)
+		require.Equal(t, "api.team.invite_email_domain_mismatch.app_error", err.Id)
+
+		_, err = th.App.GetTeamMember(th.BasicTeam.Id, mismatchingUser.Id)
+		require.NotNil(t, err, "should not have joined the team")
+	})
+
+	t.Run("guests are checked against the guest domains", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.RestrictCreationToDomains = "guest.com" })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.RestrictCreationToDomains = "" })
+
+		createGuest := func(domain string) *model.User {
+			guest := model.User{Email: strings.ToLower(model.NewId()) + "success+test@" + domain, Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+			rguest, err := th.App.CreateGuest(&guest)
+			require.Nil(t, err)
+			return rguest
+		}
+
+		member, err := th.App.AddTeamMemberByInviteId(th.BasicTeam.InviteId, createGuest("guest.com").Id)
+		require.Nil(t, err)
+		require.NotNil(t, member)
+
+		_, err = th.App.AddTeamMemberByInviteId(th.BasicTeam.InviteId, createGuest("example.com").Id)
+		require.NotNil(t, err)
+		require.Equal(t, "api.team.invite_email_domain_mismatch.app_error", err.Id)
+	})
+
+	t.Run("empty domain list", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.RestrictCreationToDomains = "" })
+
+		member, err := th.App.AddTeamMemberByInviteId(th.BasicTeam.InviteId, anyUser.Id)
+		require.Nil(t, err)
+		require.Equal(t, anyUser.Id, member.UserId)
+	})
+}
+
 func TestPermanentDeleteTeam(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -498,7 +565,7 @@ func TestPermanentDeleteTeam(t *testing.T) {
 		th.App.PermanentDeleteTeam(team)
 	}()
 
-	channels, err := th.App.GetPublicChannelsForTeam(team.Id, 0, 1000)
+	channels, err := th.App.GetPublicChannelsForTeam(team.Id, 0, 1000, false)
 	require.Nil(t, err)
 
 	for _, channel := range *channels {
@@ -782,6 +849,25 @@ func TestJoinUserToTeam(t *testing.T) {
 
 		_, _, err = th.App.joinUserToTeam(team, ruser2)
 		require.NotNil(t, err, "Should fail")
+		assert.Equal(t, "app.team.max_users.app_error", err.Id)
+	})
+
+	t.Run("new join without a limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.MaxUsersPerTeam = 0 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { cfg.TeamSettings.MaxUsersPerTeam = &one })
+
+		user1 := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+		ruser1, _ := th.App.CreateUser(&user1)
+		user2 := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+		ruser2, _ := th.App.CreateUser(&user2)
+
+		defer th.App.PermanentDeleteUser(&user1)
+		defer th.App.PermanentDeleteUser(&user2)
+
+		_, _, err = th.App.joinUserToTeam(team, ruser1)
+		require.Nil(t, err)
+		_, _, err = th.App.joinUserToTeam(team, ruser2)
+		require.Nil(t, err)
 	})
 
 	t.Run("re-join alfter leaving with limit problem", func(t *testing.T) {
@@ -844,6 +930,239 @@ func TestJoinUserToTeam(t *testing.T) {
 	})
 }
 
+func TestJoinUserToAutoJoinTeams(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	createTeam := func(autoJoinDomains string) *model.Team {
+		id := model.NewId()
+		team, err := th.App.CreateTeam(&model.Team{
+			DisplayName:     "dn_" + id,
+			Name:            "name" + id,
+			Email:           "success+" + id + "@simulator.amazonses.com",
+			Type:            model.TEAM_OPEN,
+			AutoJoinDomains: autoJoinDomains,
+		})
+		require.Nil(t, err)
+		return team
+	}
+
+	createUser := func(email string) *model.User {
+		user, err := th.App.CreateUser(&model.User{Email: email, Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", EmailVerified: true})
+		require.Nil(t, err)
+		return user
+	}
+
+	isMember := func(team *model.Team, user *model.User) bool {
+		member, err := th.App.GetTeamMember(team.Id, user.Id)
+		return err == nil && member.DeleteAt == 0
+	}
+
+	domain := strings.ToLower(model.NewId()) + ".com"
+	team1 := createTeam(domain)
+	team2 := createTeam("@Other.com, " + domain)
+	team3 := createTeam("other.com")
+	team4 := createTeam(" , ")
+
+	t.Run("should join all teams with a matching domain", func(t *testing.T) {
+		user := createUser("success+" + model.NewId() + "@" + domain)
+
+		assert.True(t, isMember(team1, user))
+		assert.True(t, isMember(team2, user))
+		assert.False(t, isMember(team3, user))
+		assert.False(t, isMember(team4, user))
+	})
+
+	t.Run("should not join any team without a matching domain", func(t *testing.T) {
+		user := createUser("success+" + model.NewId() + "@sub." + domain)
+
+		assert.False(t, isMember(team1, user))
+		assert.False(t, isMember(team2, user))
+		assert.False(t, isMember(team3, user))
+		assert.False(t, isMember(team4, user))
+	})
+
+	t.Run("should not wait for the email address to be verified if verification isn't required", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.RequireEmailVerification = false })
+
+		user, err := th.App.CreateUser(&model.User{Email: "success+" + model.NewId() + "@" + domain, Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1"})
+		require.Nil(t, err)
+		assert.True(t, isMember(team1, user))
+		assert.True(t, isMember(team2, user))
+	})
+
+	t.Run("should wait for the email address to be verified if verification is required", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.RequireEmailVerification = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.RequireEmailVerification = false })
+
+		user, err := th.App.CreateUser(&model.User{Email: "success+" + model.NewId() + "@" + domain, Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1"})
+		require.Nil(t, err)
+		assert.False(t, isMember(team1, user))
+
+		require.Nil(t, th.App.VerifyUserEmail(user.Id, user.Email))
+		assert.True(t, isMember(team1, user))
+		assert.True(t, isMember(team2, user))
+	})
+
+	t.Run("should not join group constrained teams", func(t *testing.T) {
+		team := createTeam(domain)
+		team.GroupConstrained = model.NewBool(true)
+		team, err := th.App.UpdateTeam(team)
+		require.Nil(t, err)
+
+		user := createUser("success+" + model.NewId() + "@" + domain)
+
+		assert.True(t, isMember(team1, user))
+		assert.False(t, isMember(team, user))
+	})
+}
+
+func TestMoveUsersToTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	toTeam := th.CreateTeam()
+
+	alreadyMember := th.CreateUser()
+	th.LinkUserToTeam(alreadyMember, th.BasicTeam)
+	th.LinkUserToTeam(alreadyMember, toTeam)
+
+	notMember := th.CreateUser()
+
+	userIds := []string{th.BasicUser2.Id, model.NewId(), alreadyMember.Id, notMember.Id}
+	results, err := th.App.MoveUsersToTeam(userIds, th.BasicTeam.Id, toTeam.Id)
+	require.Nil(t, err)
+	require.Len(t, results, len(userIds))
+
+	for i, result := range results {
+		assert.Equal(t, userIds[i], result.UserId)
+	}
+
+	t.Run("moved user", func(t *testing.T) {
+		require.Nil(t, results[0].Error)
+		require.NotNil(t, results[0].Member)
+		assert.Equal(t, toTeam.Id, results[0].Member.TeamId)
+
+		member, err := th.App.GetTeamMember(th.BasicTeam.Id, th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, member.DeleteAt)
+
+		_, err = th.App.GetChannelMember(th.BasicChannel.Id, th.BasicUser2.Id)
+		assert.NotNil(t, err, "should have left the channels of the source team")
+
+		townSquare, err := th.App.GetChannelByName(model.DEFAULT_CHANNEL, toTeam.Id, false)
+		require.Nil(t, err)
+		_, err = th.App.GetChannelMember(townSquare.Id, th.BasicUser2.Id)
+		assert.Nil(t, err, "should have joined the default channels of the target team")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		require.NotNil(t, results[1].Error)
+		assert.Equal(t, "app.team.move_users_to_team.user_not_found.app_error", results[1].Error.Id)
+	})
+
+	t.Run("user already in the target team", func(t *testing.T) {
+		require.Nil(t, results[2].Error)
+		assert.Equal(t, toTeam.Id, results[2].Member.TeamId)
+
+		member, err := th.App.GetTeamMember(th.BasicTeam.Id, alreadyMember.Id)
+		require.Nil(t, err)
+		assert.NotZero(t, member.DeleteAt)
+	})
+
+	t.Run("user not in the source team", func(t *testing.T) {
+		require.NotNil(t, results[3].Error)
+		assert.Equal(t, "app.team.move_users_to_team.not_member.app_error", results[3].Error.Id)
+
+		_, err := th.App.GetTeamMember(toTeam.Id, notMember.Id)
+		assert.NotNil(t, err, "should not have joined the target team")
+	})
+
+	t.Run("same team", func(t *testing.T) {
+		_, err := th.App.MoveUsersToTeam([]string{th.BasicUser.Id}, th.BasicTeam.Id, th.BasicTeam.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.team.move_users_to_team.same_team.app_error", err.Id)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := th.App.MoveUsersToTeam([]string{th.BasicUser.Id}, th.BasicTeam.Id, model.NewId())
+		require.NotNil(t, err)
+	})
+}
+
+func TestJoinUserToTeamAppliesTeamDefaultClientLocale(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.LocalizationSettings.DefaultClientLocale = "en"
+		cfg.LocalizationSettings.TeamDefaultClientLocales = map[string]string{th.BasicTeam.Id: "de"}
+	})
+
+	createUser := func(locale string) *model.User {
+		id := model.NewId()
+		user, err := th.App.CreateUser(&model.User{Email: "success+" + id + "@simulator.amazonses.com", Username: "un_" + id, Password: "passwd1", Locale: locale})
+		require.Nil(t, err)
+		return user
+	}
+
+	getLocale := func(user *model.User) string {
+		ruser, err := th.App.GetUser(user.Id)
+		require.Nil(t, err)
+		return ruser.Locale
+	}
+
+	t.Run("new user without a locale", func(t *testing.T) {
+		user := createUser("")
+		require.Nil(t, th.App.JoinUserToTeam(th.BasicTeam, user, ""))
+
+		assert.Equal(t, "de", getLocale(user))
+		assert.Equal(t, "de", user.Locale)
+	})
+
+	t.Run("new user with a locale", func(t *testing.T) {
+		user := createUser("fr")
+		require.Nil(t, th.App.JoinUserToTeam(th.BasicTeam, user, ""))
+
+		assert.Equal(t, "fr", getLocale(user))
+	})
+
+	t.Run("new user who picked the default locale", func(t *testing.T) {
+		user := createUser("en")
+		require.Nil(t, th.App.JoinUserToTeam(th.BasicTeam, user, ""))
+
+		assert.Equal(t, "en", getLocale(user))
+	})
+
+	t.Run("user who picked a locale after signup", func(t *testing.T) {
+		user := createUser("")
+		user.Locale = "fr"
+		user, err := th.App.UpdateUser(user, false)
+		require.Nil(t, err)
+		user.Locale = "en"
+		user, err = th.App.UpdateUser(user, false)
+		require.Nil(t, err)
+		require.Nil(t, th.App.JoinUserToTeam(th.BasicTeam, user, ""))
+
+		assert.Equal(t, "en", getLocale(user))
+	})
+
+	t.Run("user already on another team", func(t *testing.T) {
+		user := createUser("")
+		require.Nil(t, th.App.JoinUserToTeam(th.CreateTeam(), user, ""))
+		require.Nil(t, th.App.JoinUserToTeam(th.BasicTeam, user, ""))
+
+		assert.Equal(t, "en", getLocale(user))
+	})
+
+	t.Run("team without a default locale", func(t *testing.T) {
+		user := createUser("")
+		require.Nil(t, th.App.JoinUserToTeam(th.CreateTeam(), user, ""))
+
+		assert.Equal(t, "en", getLocale(user))
+	})
+}
+
 func TestAppUpdateTeamScheme(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1136,3 +1455,142 @@ func TestInvalidateAllEmailInvites(t *testing.T) {
 	_, err = th.App.Srv().Store.Token().GetByToken(t3.Token)
 	require.Nil(t, err)
 }
+
+func TestNormalizeTeamsOrder(t *testing.T) {
+	teamA := &model.Team{Id: model.NewId(), DisplayName: "Alpha"}
+	teamB := &model.Team{Id: model.NewId(), DisplayName: "bravo"}
+	teamC := &model.Team{Id: model.NewId(), DisplayName: "Charlie"}
+	teams := []*model.Team{teamC, teamA, teamB}
+
+	t.Run("empty order sorts by display name", func(t *testing.T) {
+		assert.Equal(t, []string{teamA.Id, teamB.Id, teamC.Id}, normalizeTeamsOrder(nil, teams))
+	})
+
+	t.Run("saved order is kept and missing teams are appended", func(t *testing.T) {
+		assert.Equal(t, []string{teamC.Id, teamA.Id, teamB.Id}, normalizeTeamsOrder([]string{teamC.Id}, teams))
+	})
+
+	t.Run("unknown and duplicate teams are dropped", func(t *testing.T) {
+		order := []string{teamB.Id, model.NewId(), teamB.Id, teamA.Id, teamC.Id}
+		assert.Equal(t, []string{teamB.Id, teamA.Id, teamC.Id}, normalizeTeamsOrder(order, teams))
+	})
+}
+
+func TestUpdateTeamsOrderForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team2 := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, team2)
+
+	order, err := th.App.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{team2.Id, th.BasicTeam.Id})
+	require.Nil(t, err)
+	assert.Equal(t, []string{team2.Id, th.BasicTeam.Id}, order)
+
+	t.Run("order is returned for the user", func(t *testing.T) {
+		order, err := th.App.GetTeamsOrderForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{team2.Id, th.BasicTeam.Id}, order)
+
+		members, err := th.App.GetTeamMembersWithUnreadForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, team2.Id, members[0].TeamId)
+		assert.Equal(t, th.BasicTeam.Id, members[1].TeamId)
+	})
+
+	t.Run("teams the user does not belong to are rejected", func(t *testing.T) {
+		_, err := th.App.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{th.CreateTeam().Id})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.team.update_teams_order.invalid_team.app_error", err.Id)
+	})
+
+	t.Run("duplicate teams are rejected", func(t *testing.T) {
+		_, err := th.App.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{team2.Id, team2.Id})
+		require.NotNil(t, err)
+	})
+
+	t.Run("joining and leaving a team repairs the order", func(t *testing.T) {
+		team3 := th.CreateTeam()
+		th.LinkUserToTeam(th.BasicUser, team3)
+
+		order, err := th.App.getSavedTeamsOrderForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{team2.Id, th.BasicTeam.Id, team3.Id}, order)
+
+		require.Nil(t, th.App.RemoveUserFromTeam(team2.Id, th.BasicUser.Id, th.BasicUser.Id))
+
+		order, err = th.App.getSavedTeamsOrderForUser(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{th.BasicTeam.Id, team3.Id}, order)
+	})
+
+	t.Run("long orders are saved in chunks", func(t *testing.T) {
+		userId := model.NewId()
+
+		longOrder := make([]string, 2*teamsOrderChunkSize+1)
+		for i := range longOrder {
+			longOrder[i] = model.NewId()
+		}
+
+		require.Nil(t, th.App.saveTeamsOrderForUser(userId, longOrder))
+
+		preferences, err := th.App.Srv().Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_TEAMS_ORDER)
+		require.Nil(t, err)
+		assert.Len(t, preferences, 3)
+
+		order, err := th.App.getSavedTeamsOrderForUser(userId)
+		require.Nil(t, err)
+		assert.Equal(t, longOrder, order)
+
+		require.Nil(t, th.App.saveTeamsOrderForUser(userId, longOrder[:2]))
+
+		preferences, err = th.App.Srv().Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_TEAMS_ORDER)
+		require.Nil(t, err)
+		assert.Len(t, preferences, 1)
+
+		order, err = th.App.getSavedTeamsOrderForUser(userId)
+		require.Nil(t, err)
+		assert.Equal(t, longOrder[:2], order)
+	})
+}
+
+func TestGetTeamsForUserWithUnreads(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team2 := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, team2)
+
+	_, err := th.App.UpdateTeamsOrderForUser(th.BasicUser.Id, []string{team2.Id, th.BasicTeam.Id})
+	require.Nil(t, err)
+
+	_, err = th.App.CreatePost(&model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "@" + th.BasicUser.Username,
+	}, th.BasicChannel, false, true)
+	require.Nil(t, err)
+
+	teams, err := th.App.GetTeamsForUserWithUnreads(th.BasicUser.Id)
+	require.Nil(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, team2.Id, teams[0].Id)
+	assert.Zero(t, teams[0].MsgCount)
+	assert.Zero(t, teams[0].MentionCount)
+	assert.Equal(t, th.BasicTeam.Id, teams[1].Id)
+	assert.Equal(t, int64(1), teams[1].MsgCount)
+	assert.Equal(t, int64(1), teams[1].MentionCount)
+
+	t.Run("muted channels only count mentions", func(t *testing.T) {
+		_, err := th.App.UpdateChannelMemberNotifyProps(map[string]string{model.MARK_UNREAD_NOTIFY_PROP: model.CHANNEL_MARK_UNREAD_MENTION}, th.BasicChannel.Id, th.BasicUser.Id)
+		require.Nil(t, err)
+
+		teams, err := th.App.GetTeamsForUserWithUnreads(th.BasicUser.Id)
+		require.Nil(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, th.BasicTeam.Id, teams[1].Id)
+		assert.Zero(t, teams[1].MsgCount)
+		assert.Equal(t, int64(1), teams[1].MentionCount)
+	})
+}
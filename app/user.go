@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disintegration/imaging"
 	"github.com/golang/freetype"
@@ -35,6 +36,8 @@ import (
 	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/memstore"
 )
 
 const (
@@ -45,6 +48,10 @@ const (
 	PASSWORD_RECOVER_EXPIRY_TIME  = 1000 * 60 * 60      // 1 hour
 	INVITATION_EXPIRY_TIME        = 1000 * 60 * 60 * 48 // 48 hours
 	IMAGE_PROFILE_PIXEL_DIMENSION = 128
+
+	userAvailabilityRateLimitingMemstoreSize = 65536
+	userAvailabilityRateLimitingPerMinute    = 30
+	userAvailabilityRateLimitingMaxBurst     = 30
 )
 
 func (a *App) CreateUserWithToken(user *model.User, token *model.Token) (*model.User, *model.AppError) {
@@ -126,6 +133,10 @@ func (a *App) CreateUserWithInviteId(user *model.User, inviteId, redirect string
 		return nil, model.NewAppError("CreateUserWithInviteId", "api.team.invite_members.invalid_email.app_error", map[string]interface{}{"Addresses": team.AllowedDomains}, "", http.StatusForbidden)
 	}
 
+	if err := a.checkTeamInviteEmailAllowed(user, team, "CreateUserWithInviteId"); err != nil {
+		return nil, err
+	}
+
 	user.EmailVerified = false
 
 	ruser, err := a.CreateUser(user)
@@ -152,6 +163,12 @@ func (a *App) CreateUserAsAdmin(user *model.User, redirect string) (*model.User,
 		return nil, err
 	}
 
+	if ruser.AuthService == "" {
+		if err := a.setPasswordSetByAdmin(ruser.Id, true); err != nil {
+			mlog.Error("Failed to record that the password was set by an admin", mlog.String("user_id", ruser.Id), mlog.Err(err))
+		}
+	}
+
 	if err := a.Srv().EmailService.sendWelcomeEmail(ruser.Id, ruser.Email, ruser.EmailVerified, ruser.Locale, a.GetSiteURL(), redirect); err != nil {
 		mlog.Error("Failed to send welcome email on create admin user", mlog.Err(err))
 	}
@@ -250,14 +267,27 @@ func (a *App) createUserOrGuest(user *model.User, guest bool) (*model.User, *mod
 		user.Roles = model.SYSTEM_ADMIN_ROLE_ID + " " + model.SYSTEM_USER_ROLE_ID
 	}
 
+	// Users who didn't pick a supported locale get the default one, and are marked so that
+	// the default locale of the first team they join can still apply to them.
 	if _, ok := utils.GetSupportedLocales()[user.Locale]; !ok {
 		user.Locale = *a.Config().LocalizationSettings.DefaultClientLocale
+		user.MakeNonNil()
+		user.Props[model.USER_PROP_DEFAULTED_LOCALE] = "true"
 	}
 
 	ruser, err := a.createUser(user)
 	if err != nil {
 		return nil, err
 	}
+
+	// When email verification is required, users whose address isn't verified yet join the teams
+	// once it is, in VerifyUserEmail.
+	if !guest && (ruser.EmailVerified || !*a.Config().EmailSettings.RequireEmailVerification) {
+		if err := a.joinUserToAutoJoinTeams(ruser); err != nil {
+			mlog.Error("Failed to auto-join user to teams", mlog.String("user_id", ruser.Id), mlog.Err(err))
+		}
+	}
+
 	// This message goes to everyone, so the teamId, channelId and userId are irrelevant
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_NEW_USER, "", "", "", nil)
 	message.Add("user_id", ruser.Id)
@@ -279,10 +309,17 @@ func (a *App) createUserOrGuest(user *model.User, guest bool) (*model.User, *mod
 func (a *App) createUser(user *model.User) (*model.User, *model.AppError) {
 	user.MakeNonNil()
 
-	if err := a.IsPasswordValid(user.Password); user.AuthService == "" && err != nil {
+	if err := checkSystemBotUsernameReserved("createUser", user); err != nil {
 		return nil, err
 	}
 
+	// SSO users have no password to validate, nor to check against breaches.
+	if user.AuthService == "" {
+		if err := a.IsPasswordValid(user.Password); err != nil {
+			return nil, err
+		}
+	}
+
 	ruser, err := a.Srv().Store.User().Save(user)
 	if err != nil {
 		mlog.Error("Couldn't save the user", mlog.Err(err))
@@ -423,6 +460,62 @@ func (a *App) GetUserByUsername(username string) (*model.User, *model.AppError)
 	return result, nil
 }
 
+func newUserAvailabilityRateLimiter() (*throttled.GCRARateLimiter, error) {
+	store, err := memstore.New(userAvailabilityRateLimitingMemstoreSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to setup the user availability rate limiting memstore: %w", err)
+	}
+
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerMin(userAvailabilityRateLimitingPerMinute),
+		MaxBurst: userAvailabilityRateLimitingMaxBurst,
+	}
+
+	return throttled.NewGCRARateLimiter(store, quota)
+}
+
+// IsUsernameOrEmailAvailable returns whether both the username and the email, each of which may be
+// left empty, are free to sign up with. Emails can only be checked when they are shown to users.
+// The checks are limited by the given key, usually the IP address of the client, so that they can't
+// be used to enumerate the users.
+func (a *App) IsUsernameOrEmailAvailable(username, email, rateLimitKey string) (bool, *model.AppError) {
+	limited, _, err := a.Srv().userAvailabilityRateLimiter.RateLimit(rateLimitKey, 1)
+	if err != nil {
+		return false, model.NewAppError("IsUsernameOrEmailAvailable", "app.user.check_availability.rate_limit.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if limited {
+		return false, model.NewAppError("IsUsernameOrEmailAvailable", "app.user.check_availability.too_many_requests.app_error", nil, "", http.StatusTooManyRequests)
+	}
+
+	if !*a.Config().TeamSettings.EnableUserCreation {
+		return false, model.NewAppError("IsUsernameOrEmailAvailable", "app.user.check_availability.user_creation_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if email != "" && !*a.Config().PrivacySettings.ShowEmailAddress {
+		return false, model.NewAppError("IsUsernameOrEmailAvailable", "app.user.check_availability.email_hidden.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if username != "" {
+		user, err := a.GetUserByUsername(model.NormalizeUsername(username))
+		if err != nil && err.StatusCode != http.StatusNotFound {
+			return false, err
+		} else if user != nil {
+			return false, nil
+		}
+	}
+
+	if email != "" {
+		user, err := a.GetUserByEmail(model.NormalizeEmail(email))
+		if err != nil && err.StatusCode != http.StatusNotFound {
+			return false, err
+		} else if user != nil {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func (a *App) GetUserByEmail(email string) (*model.User, *model.AppError) {
 	user, err := a.Srv().Store.User().GetByEmail(email)
 	if err != nil {
@@ -928,9 +1021,43 @@ func (a *App) UpdatePasswordAsUser(userId, currentPassword, newPassword string)
 		return err
 	}
 
+	if err := a.checkPasswordChangeInterval(user); err != nil {
+		return err
+	}
+
 	T := utils.GetUserTranslations(user.Locale)
 
-	return a.UpdatePasswordSendEmail(user, newPassword, T("api.user.update_password.menu"))
+	if err := a.UpdatePasswordSendEmail(user, newPassword, T("api.user.update_password.menu")); err != nil {
+		return err
+	}
+
+	if err := a.setPasswordSetByAdmin(user.Id, false); err != nil {
+		mlog.Error("Failed to clear that the password was set by an admin", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	return nil
+}
+
+// checkPasswordChangeInterval returns an error if the user changed their password less than the
+// configured minimum interval ago. It only applies to users changing their own password, so that
+// admins and password reset emails can still reset it, and not to passwords last set by an admin,
+// which users are expected to replace.
+func (a *App) checkPasswordChangeInterval(user *model.User) *model.AppError {
+	interval := int64(*a.Config().PasswordSettings.MinimumChangeIntervalInHours) * 60 * 60 * 1000
+	if interval <= 0 {
+		return nil
+	}
+
+	if _, err := a.Srv().Store.Preference().Get(user.Id, model.PREFERENCE_CATEGORY_PASSWORD, model.PREFERENCE_NAME_PASSWORD_SET_BY_ADMIN); err == nil {
+		return nil
+	}
+
+	nextChangeAt := user.LastPasswordUpdate + interval
+	if model.GetMillis() >= nextChangeAt {
+		return nil
+	}
+
+	return model.NewAppError("updatePassword", "api.user.update_password.too_soon.app_error", map[string]interface{}{"NextChangeAt": time.Unix(0, nextChangeAt*int64(time.Millisecond)).UTC().Format(time.RFC1123)}, "next_change_at="+strconv.FormatInt(nextChangeAt, 10), http.StatusBadRequest)
 }
 
 func (a *App) userDeactivated(userId string) *model.AppError {
@@ -997,6 +1124,15 @@ func (a *App) UpdateActive(user *model.User, active bool) (*model.User, *model.A
 		if err := a.userDeactivated(ruser.Id); err != nil {
 			return nil, err
 		}
+
+		if userUpdate.Old.DeleteAt == 0 {
+			a.Srv().Go(func() {
+				message := utils.T("app.admin_notification.user_deactivated", map[string]interface{}{"Username": ruser.Username})
+				if _, err := a.NotifyAdmins(model.ADMIN_NOTIFICATION_TYPE_USER_DEACTIVATED, message); err != nil {
+					mlog.Error("Failed to notify admins of a user deactivation", mlog.String("user_id", ruser.Id), mlog.Err(err))
+				}
+			})
+		}
 	}
 
 	a.invalidateUserChannelMembersCaches(user.Id)
@@ -1045,6 +1181,10 @@ func (a *App) SanitizeProfile(user *model.User, asAdmin bool) {
 }
 
 func (a *App) UpdateUserAsUser(user *model.User, asAdmin bool) (*model.User, *model.AppError) {
+	if prev, err := a.Srv().Store.User().Get(user.Id); err == nil && isSystemBotUser(prev) {
+		return nil, systemBotLockedError("UpdateUserAsUser")
+	}
+
 	updatedUser, err := a.UpdateUser(user, true)
 	if err != nil {
 		return nil, err
@@ -1061,6 +1201,10 @@ func (a *App) PatchUser(userId string, patch *model.UserPatch, asAdmin bool) (*m
 		return nil, err
 	}
 
+	if isSystemBotUser(user) {
+		return nil, systemBotLockedError("PatchUser")
+	}
+
 	user.Patch(patch)
 
 	updatedUser, err := a.UpdateUser(user, true)
@@ -1130,6 +1274,12 @@ func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User,
 		}
 	}
 
+	if user.Username != prev.Username {
+		if err := checkSystemBotUsernameReserved("UpdateUser", user); err != nil {
+			return nil, err
+		}
+	}
+
 	// Don't set new eMail on user account if email verification is required, this will be done as a post-verification action
 	// to avoid users being able to set non-controlled eMails as their account email
 	newEmail := ""
@@ -1150,6 +1300,11 @@ func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User,
 		}
 	}
 
+	// A locale set after signup was picked by the user, so it's no longer a default.
+	if user.Locale != prev.Locale && user.Props[model.USER_PROP_DEFAULTED_LOCALE] != "" {
+		delete(user.Props, model.USER_PROP_DEFAULTED_LOCALE)
+	}
+
 	userUpdate, err := a.Srv().Store.User().Update(user, false)
 	if err != nil {
 		return nil, err
@@ -1241,13 +1396,37 @@ func (a *App) UpdateMfa(activate bool, userId, token string) *model.AppError {
 	return nil
 }
 
+// UpdatePasswordByUserIdSendEmail sets the password of the user as an admin, so that the user may
+// replace it without waiting for the minimum password change interval.
 func (a *App) UpdatePasswordByUserIdSendEmail(userId, newPassword, method string) *model.AppError {
 	user, err := a.GetUser(userId)
 	if err != nil {
 		return err
 	}
 
-	return a.UpdatePasswordSendEmail(user, newPassword, method)
+	if err := a.UpdatePasswordSendEmail(user, newPassword, method); err != nil {
+		return err
+	}
+
+	if err := a.setPasswordSetByAdmin(user.Id, true); err != nil {
+		mlog.Error("Failed to record that the password was set by an admin", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	return nil
+}
+
+// setPasswordSetByAdmin records whether the current password of the user was set by an admin.
+func (a *App) setPasswordSetByAdmin(userId string, setByAdmin bool) *model.AppError {
+	if !setByAdmin {
+		return a.Srv().Store.Preference().Delete(userId, model.PREFERENCE_CATEGORY_PASSWORD, model.PREFERENCE_NAME_PASSWORD_SET_BY_ADMIN)
+	}
+
+	return a.Srv().Store.Preference().Save(&model.Preferences{{
+		UserId:   userId,
+		Category: model.PREFERENCE_CATEGORY_PASSWORD,
+		Name:     model.PREFERENCE_NAME_PASSWORD_SET_BY_ADMIN,
+		Value:    "true",
+	}})
 }
 
 func (a *App) UpdatePassword(user *model.User, newPassword string) *model.AppError {
@@ -1318,6 +1497,10 @@ func (a *App) ResetPasswordFromToken(userSuppliedTokenString, newPassword string
 		return err
 	}
 
+	if err := a.setPasswordSetByAdmin(user.Id, false); err != nil {
+		mlog.Error("Failed to clear that the password was set by an admin", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
 	if err := a.DeleteToken(token); err != nil {
 		mlog.Error("Failed to delete token", mlog.Err(err))
 	}
@@ -1480,6 +1663,14 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		return err
 	}
 
+	if err := a.Srv().Store.SavedSearch().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.user.permanentdeleteuser.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.SavedPost().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.user.permanentdeleteuser.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	if err := a.Srv().Store.Channel().PermanentDeleteMembersByUser(user.Id); err != nil {
 		return err
 	}
@@ -1662,6 +1853,11 @@ func (a *App) GetFilteredUsersStats(options *model.UserCountOptions) (*model.Use
 }
 
 func (a *App) VerifyUserEmail(userId, email string) *model.AppError {
+	prev, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
 	if _, err := a.Srv().Store.User().VerifyEmail(userId, email); err != nil {
 		return err
 	}
@@ -1674,6 +1870,14 @@ func (a *App) VerifyUserEmail(userId, email string) *model.AppError {
 		return err
 	}
 
+	// Joining the teams of the user's email domain waits for the address to be verified, when
+	// verification is required.
+	if !prev.EmailVerified && !user.IsGuest() && *a.Config().EmailSettings.RequireEmailVerification {
+		if err := a.joinUserToAutoJoinTeams(user); err != nil {
+			mlog.Error("Failed to auto-join user to teams", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
 	a.sendUpdatedUserEvent(*user)
 
 	return nil
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"image"
 	"image/color"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -261,6 +262,46 @@ func TestUpdateActiveBotsSideEffect(t *testing.T) {
 	th.App.UpdateActive(th.BasicUser, true)
 }
 
+func TestCheckPasswordChangeInterval(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	hour := int64(60 * 60 * 1000)
+	user := &model.User{LastPasswordUpdate: model.GetMillis() - 2*hour}
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MinimumChangeIntervalInHours = 0 })
+
+		assert.Nil(t, th.App.checkPasswordChangeInterval(user))
+	})
+
+	t.Run("changed too recently", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MinimumChangeIntervalInHours = 3 })
+
+		err := th.App.checkPasswordChangeInterval(user)
+		require.NotNil(t, err)
+		assert.Equal(t, "api.user.update_password.too_soon.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("interval elapsed", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MinimumChangeIntervalInHours = 2 })
+
+		assert.Nil(t, th.App.checkPasswordChangeInterval(user))
+	})
+
+	t.Run("password set by an admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MinimumChangeIntervalInHours = 3 })
+
+		resetUser := &model.User{Id: model.NewId(), LastPasswordUpdate: user.LastPasswordUpdate}
+		require.Nil(t, th.App.setPasswordSetByAdmin(resetUser.Id, true))
+		assert.Nil(t, th.App.checkPasswordChangeInterval(resetUser))
+
+		require.Nil(t, th.App.setPasswordSetByAdmin(resetUser.Id, false))
+		assert.NotNil(t, th.App.checkPasswordChangeInterval(resetUser))
+	})
+}
+
 func TestUpdateOAuthUserAttrs(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -619,6 +660,26 @@ func TestCreateUserWithInviteId(t *testing.T) {
 		require.NotNil(t, err)
 		require.Equal(t, "api.team.invite_members.invalid_email.app_error", err.Id)
 	})
+
+	t.Run("invite restricted to the allowed domains", func(t *testing.T) {
+		th.BasicTeam.AllowedDomains = ""
+		_, err := th.App.Srv().Store.Team().Update(th.BasicTeam)
+		require.Nil(t, err)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.RestrictTeamInviteToEmail = true
+			*cfg.TeamSettings.RestrictCreationToDomains = "mattermost.com"
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.RestrictTeamInviteToEmail = false
+			*cfg.TeamSettings.RestrictCreationToDomains = ""
+		})
+
+		restrictedUser := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+		_, err = th.App.CreateUserWithInviteId(&restrictedUser, th.BasicTeam.InviteId, "")
+		require.NotNil(t, err)
+		require.Equal(t, "api.team.invite_email_domain_mismatch.app_error", err.Id)
+	})
 }
 
 func TestCreateUserWithToken(t *testing.T) {
@@ -878,6 +939,18 @@ func TestPasswordRecovery(t *testing.T) {
 
 	err = th.App.ResetPasswordFromToken(token.Token, "abcdefgh")
 	assert.NotNil(t, err)
+
+	// Password reset sooner than the minimum interval between password changes, which doesn't apply
+	// to users who forgot their password
+	th.App.UpdateConfig(func(c *model.Config) {
+		*c.PasswordSettings.MinimumChangeIntervalInHours = 1
+	})
+
+	token, err = th.App.CreatePasswordRecoveryToken(th.BasicUser.Id, th.BasicUser.Email)
+	assert.Nil(t, err)
+
+	err = th.App.ResetPasswordFromToken(token.Token, "abcdefghi")
+	assert.Nil(t, err)
 }
 
 func TestGetViewUsersRestrictions(t *testing.T) {
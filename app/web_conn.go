@@ -4,7 +4,12 @@
 package app
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -39,6 +44,8 @@ type WebConn struct {
 	Sequence         int64
 	UserId           string
 
+	capabilities              map[string]bool
+	rateLimitKey              string
 	allChannelMembers         map[string]string
 	lastAllChannelMembersTime int64
 	lastUserActivityAt        int64
@@ -117,6 +124,45 @@ func (wc *WebConn) SetSession(v *model.Session) {
 	wc.session.Store(v)
 }
 
+// SetCapabilities records the optional features the client asked for when connecting, among the
+// ones listed in model.WEBSOCKET_CAPABILITIES. It must be called before the connection is pumped.
+func (wc *WebConn) SetCapabilities(capabilities []string) {
+	wc.capabilities = make(map[string]bool)
+	for _, capability := range capabilities {
+		for _, known := range model.WEBSOCKET_CAPABILITIES {
+			if capability == known {
+				wc.capabilities[capability] = true
+			}
+		}
+	}
+}
+
+// HasCapability returns whether the client asked for the given optional feature when connecting,
+// and the feature is enabled.
+func (wc *WebConn) HasCapability(capability string) bool {
+	if !wc.capabilities[capability] {
+		return false
+	}
+
+	switch capability {
+	case model.WEBSOCKET_CAPABILITY_CREATE_POST:
+		return *wc.App.Config().ServiceSettings.EnableWebSocketPostCreation
+	}
+
+	return true
+}
+
+// RateLimitKey returns the key the request opening the connection was rate limited by.
+func (wc *WebConn) RateLimitKey() string {
+	return wc.rateLimitKey
+}
+
+// SetRateLimitKey sets the key the request opening the connection was rate limited by. It must be
+// called before the connection is pumped.
+func (wc *WebConn) SetRateLimitKey(key string) {
+	wc.rateLimitKey = key
+}
+
 // Pump starts the WebConn instance. After this, the websocket
 // is ready to send/receive messages.
 func (wc *WebConn) Pump() {
@@ -137,7 +183,14 @@ func (wc *WebConn) readPump() {
 	defer func() {
 		wc.WebSocket.Close()
 	}()
-	wc.WebSocket.SetReadLimit(model.SOCKET_MAX_MESSAGE_SIZE_KB)
+	maxMessageSize := wc.maxMessageSize()
+	if wc.HasCapability(model.WEBSOCKET_CAPABILITY_CREATE_POST) {
+		// Messages somewhat over the limit are read and answered with an error, so that a post
+		// that's too long doesn't cost the client its connection.
+		wc.WebSocket.SetReadLimit(2 * maxMessageSize)
+	} else {
+		wc.WebSocket.SetReadLimit(maxMessageSize)
+	}
 	wc.WebSocket.SetReadDeadline(time.Now().Add(pongWaitTime))
 	wc.WebSocket.SetPongHandler(func(string) error {
 		wc.WebSocket.SetReadDeadline(time.Now().Add(pongWaitTime))
@@ -150,8 +203,31 @@ func (wc *WebConn) readPump() {
 	})
 
 	for {
+		_, r, err := wc.WebSocket.NextReader()
+		if err != nil {
+			wc.logSocketErr("websocket.read", err)
+			return
+		}
+
+		data, err := ioutil.ReadAll(io.LimitReader(r, maxMessageSize+1))
+		if err != nil {
+			wc.logSocketErr("websocket.read", err)
+			return
+		}
+
+		if int64(len(data)) > maxMessageSize {
+			if _, err := io.Copy(ioutil.Discard, r); err != nil {
+				wc.logSocketErr("websocket.read", err)
+				return
+			}
+
+			appErr := model.NewAppError("readPump", "api.web_socket.read.message_too_large.app_error", map[string]interface{}{"Max": maxMessageSize}, "", http.StatusRequestEntityTooLarge)
+			returnWebSocketError(wc.App, wc, &model.WebSocketRequest{Seq: truncatedWebSocketRequestSeq(data)}, appErr)
+			continue
+		}
+
 		var req model.WebSocketRequest
-		if err := wc.WebSocket.ReadJSON(&req); err != nil {
+		if err := json.Unmarshal(data, &req); err != nil {
 			wc.logSocketErr("websocket.read", err)
 			return
 		}
@@ -159,6 +235,48 @@ func (wc *WebConn) readPump() {
 	}
 }
 
+// maxMessageSize returns the size of the largest message read from the client. Clients creating
+// posts over the connection may send a post of the longest message the REST API accepts, whose
+// characters take up to 6 bytes each once escaped in JSON, along with the rest of the request.
+func (wc *WebConn) maxMessageSize() int64 {
+	if !wc.HasCapability(model.WEBSOCKET_CAPABILITY_CREATE_POST) {
+		return model.SOCKET_MAX_MESSAGE_SIZE_KB
+	}
+
+	return int64(model.SOCKET_MAX_MESSAGE_SIZE_KB + wc.App.MaxPostSize()*len(`\u0000`))
+}
+
+// truncatedWebSocketRequestSeq returns the sequence number of a request from the start of its
+// JSON, or 0 when it can't be found there.
+func truncatedWebSocketRequestSeq(data []byte) int64 {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return 0
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return 0
+		}
+
+		if key == "seq" {
+			var seq int64
+			if err := decoder.Decode(&seq); err != nil {
+				return 0
+			}
+			return seq
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return 0
+		}
+	}
+
+	return 0
+}
+
 func (wc *WebConn) writePump() {
 	ticker := time.NewTicker(pingInterval)
 	authTicker := time.NewTicker(authCheckInterval)
@@ -287,6 +405,14 @@ func (wc *WebConn) IsAuthenticated() bool {
 func (wc *WebConn) createHelloMessage() *model.WebSocketEvent {
 	msg := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_HELLO, "", "", wc.UserId, nil)
 	msg.Add("server_version", fmt.Sprintf("%v.%v.%v.%v", model.CurrentVersion, model.BuildNumber, wc.App.ClientConfigHash(), wc.App.Srv().License() != nil))
+
+	capabilities := []string{}
+	for _, capability := range model.WEBSOCKET_CAPABILITIES {
+		if wc.HasCapability(capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	msg.Add("capabilities", capabilities)
 	return msg
 }
 
@@ -98,3 +98,10 @@ func TestWebConnShouldSendEvent(t *testing.T) {
 	event3 := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_UPDATE_TEAM, "wrongId", "", "", nil)
 	assert.False(t, basicUserWc.shouldSendEvent(event3))
 }
+
+func TestTruncatedWebSocketRequestSeq(t *testing.T) {
+	assert.Equal(t, int64(5), truncatedWebSocketRequestSeq([]byte(`{"seq":5,"action":"create_post","data":{"post":"{\"message\":\"aaa`)))
+	assert.Equal(t, int64(5), truncatedWebSocketRequestSeq([]byte(`{"action":"create_post","seq":5,"data":{"post":"`)))
+	assert.Equal(t, int64(0), truncatedWebSocketRequestSeq([]byte(`{"action":"create_post","data":{"post":"aaa`)))
+	assert.Equal(t, int64(0), truncatedWebSocketRequestSeq([]byte(`junk`)))
+}
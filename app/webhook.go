@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
@@ -106,7 +107,7 @@ func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.
 		url := hook.CallbackURLs[i]
 
 		a.Srv().Go(func() {
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType)
+			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, a.outgoingWebhookTimeout(hook))
 			if err != nil {
 				mlog.Error("Event POST failed.", mlog.Err(err))
 				return
@@ -146,7 +147,24 @@ func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.
 	}
 }
 
-func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string) (*model.OutgoingWebhookResponse, error) {
+// outgoingWebhookTimeout returns how long to wait for the callbacks of the hook, or zero when the
+// hook uses the default timeout.
+func (a *App) outgoingWebhookTimeout(hook *model.OutgoingWebhook) time.Duration {
+	if hook.Timeout <= 0 {
+		return 0
+	}
+
+	timeout := hook.Timeout
+	if maxTimeout := *a.Config().ServiceSettings.MaxOutgoingWebhookTimeout; timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+
+	return time.Duration(timeout) * time.Second
+}
+
+// doOutgoingWebhookRequest posts the body to the url, waiting for the given timeout, or for the
+// default one when it is zero.
+func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, timeout time.Duration) (*model.OutgoingWebhookResponse, error) {
 	req, err := http.NewRequest("POST", url, body)
 	if err != nil {
 		return nil, err
@@ -155,7 +173,12 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := a.HTTPService().MakeClient(false).Do(req)
+	client := a.HTTPService().MakeClient(false)
+	if timeout > 0 {
+		client.Timeout = timeout
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +270,27 @@ func SplitWebhookPost(post *model.Post, maxPostSize int) ([]*model.Post, *model.
 }
 
 func (a *App) CreateWebhookPost(userId string, channel *model.Channel, text, overrideUsername, overrideIconUrl, overrideIconEmoji string, props model.StringInterface, postType string, postRootId string) (*model.Post, *model.AppError) {
+	splits, err := a.prepareWebhookPosts(userId, channel, text, overrideUsername, overrideIconUrl, overrideIconEmoji, props, postType, postRootId)
+	if err != nil {
+		return nil, err
+	}
+
+	if metrics := a.Metrics(); metrics != nil {
+		metrics.IncrementWebhookPost()
+	}
+
+	for _, split := range splits {
+		if _, err := a.CreatePostMissingChannel(split, false); err != nil {
+			return nil, model.NewAppError("CreateWebhookPost", "api.post.create_webhook_post.creating.app_error", nil, "err="+err.Message, http.StatusInternalServerError)
+		}
+	}
+
+	return splits[0], nil
+}
+
+// prepareWebhookPosts builds the posts for a webhook message without creating them. The message is
+// split into several posts when it is longer than the maximum post size.
+func (a *App) prepareWebhookPosts(userId string, channel *model.Channel, text, overrideUsername, overrideIconUrl, overrideIconEmoji string, props model.StringInterface, postType string, postRootId string) ([]*model.Post, *model.AppError) {
 	// parse links into Markdown format
 	linkWithTextRegex := regexp.MustCompile(`<([^\n<\|>]+)\|([^\n>]+)>`)
 	text = linkWithTextRegex.ReplaceAllString(text, "[${2}](${1})")
@@ -259,10 +303,6 @@ func (a *App) CreateWebhookPost(userId string, channel *model.Channel, text, ove
 		return nil, err
 	}
 
-	if metrics := a.Metrics(); metrics != nil {
-		metrics.IncrementWebhookPost()
-	}
-
 	if *a.Config().ServiceSettings.EnablePostUsernameOverride {
 		if len(overrideUsername) != 0 {
 			post.AddProp("override_username", overrideUsername)
@@ -292,18 +332,7 @@ func (a *App) CreateWebhookPost(userId string, channel *model.Channel, text, ove
 		}
 	}
 
-	splits, err := SplitWebhookPost(post, a.MaxPostSize())
-	if err != nil {
-		return nil, err
-	}
-
-	for _, split := range splits {
-		if _, err := a.CreatePostMissingChannel(split, false); err != nil {
-			return nil, model.NewAppError("CreateWebhookPost", "api.post.create_webhook_post.creating.app_error", nil, "err="+err.Message, http.StatusInternalServerError)
-		}
-	}
-
-	return splits[0], nil
+	return SplitWebhookPost(post, a.MaxPostSize())
 }
 
 func (a *App) CreateIncomingWebhookForChannel(creatorId string, channel *model.Channel, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
@@ -370,6 +399,10 @@ func (a *App) DeleteIncomingWebhook(hookId string) *model.AppError {
 
 	a.invalidateCacheForWebhook(hookId)
 
+	if err := a.DisableIncomingWebhookDebugging(hookId); err != nil {
+		mlog.Warn("Failed to disable debugging for the deleted incoming webhook", mlog.String("hook_id", hookId), mlog.Err(err))
+	}
+
 	return nil
 }
 
@@ -574,13 +607,11 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.parse.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	text := req.Text
-	if len(text) == 0 && req.Attachments == nil {
+	if len(req.Text) == 0 && req.Attachments == nil {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.text.app_error", nil, "", http.StatusBadRequest)
 	}
 
 	channelName := req.ChannelName
-	webhookType := req.Type
 
 	var hook *model.IncomingWebhook
 	if result := <-hchan; result.Err != nil {
@@ -596,19 +627,7 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 		close(uchan)
 	}()
 
-	if len(req.Props) == 0 {
-		req.Props = make(model.StringInterface)
-	}
-
-	req.Props["webhook_display_name"] = hook.DisplayName
-
-	text = a.ProcessSlackText(text)
-	req.Attachments = a.ProcessSlackAttachments(req.Attachments)
-	// attachments is in here for slack compatibility
-	if len(req.Attachments) > 0 {
-		req.Props["attachments"] = req.Attachments
-		webhookType = model.POST_SLACK_ATTACHMENT
-	}
+	text, webhookType := a.processIncomingWebhookRequest(hook, req)
 
 	var channel *model.Channel
 	var cchan chan store.StoreResult
@@ -702,6 +721,125 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 	return err
 }
 
+// processIncomingWebhookRequest converts the Slack formatting of the request and adds the hook's
+// props, returning the text and post type to use for the webhook post.
+func (a *App) processIncomingWebhookRequest(hook *model.IncomingWebhook, req *model.IncomingWebhookRequest) (string, string) {
+	webhookType := req.Type
+
+	if len(req.Props) == 0 {
+		req.Props = make(model.StringInterface)
+	}
+
+	req.Props["webhook_display_name"] = hook.DisplayName
+
+	text := a.ProcessSlackText(req.Text)
+	req.Attachments = a.ProcessSlackAttachments(req.Attachments)
+	// attachments is in here for slack compatibility
+	if len(req.Attachments) > 0 {
+		req.Props["attachments"] = req.Attachments
+		webhookType = model.POST_SLACK_ATTACHMENT
+	}
+
+	return text, webhookType
+}
+
+// PreviewIncomingWebhook runs an incoming webhook request through the same processing as
+// HandleIncomingWebhook without creating any posts, so integrations can check their payloads.
+// Problems with the request are returned in the preview rather than as an error.
+func (a *App) PreviewIncomingWebhook(hook *model.IncomingWebhook, req *model.IncomingWebhookRequest) (*model.IncomingWebhookPreview, *model.AppError) {
+	if req == nil {
+		return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.parse.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	preview := &model.IncomingWebhookPreview{
+		Posts:  []*model.Post{},
+		Errors: []*model.AppError{},
+	}
+
+	if len(req.Text) == 0 && req.Attachments == nil {
+		preview.Errors = append(preview.Errors, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.text.app_error", nil, "", http.StatusBadRequest))
+	}
+
+	for i, attachment := range req.Attachments {
+		for j, field := range attachment.Fields {
+			if field == nil || (field.Title == "" && field.Value == nil) {
+				preview.Errors = append(preview.Errors, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.preview.attachment_field.app_error", map[string]interface{}{"Attachment": i, "Field": j}, "", http.StatusBadRequest))
+			}
+		}
+	}
+
+	channel, err := a.getIncomingWebhookPreviewChannel(hook, req.ChannelName)
+	if err != nil {
+		preview.Errors = append(preview.Errors, err)
+		channel = &model.Channel{Id: hook.ChannelId}
+	}
+
+	text, webhookType := a.processIncomingWebhookRequest(hook, req)
+
+	overrideUsername := hook.Username
+	if req.Username != "" {
+		overrideUsername = req.Username
+	}
+
+	overrideIconUrl := hook.IconURL
+	if req.IconURL != "" {
+		overrideIconUrl = req.IconURL
+	}
+
+	posts, err := a.prepareWebhookPosts(hook.UserId, channel, text, overrideUsername, overrideIconUrl, req.IconEmoji, req.Props, webhookType, "")
+	if err != nil {
+		preview.Errors = append(preview.Errors, err)
+		return preview, nil
+	}
+
+	if len(posts) > 1 {
+		preview.Errors = append(preview.Errors, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.preview.text_length.app_error", map[string]interface{}{"Max": a.MaxPostSize(), "Count": len(posts)}, "", http.StatusBadRequest))
+	}
+
+	preview.Posts = posts
+
+	return preview, nil
+}
+
+// getIncomingWebhookPreviewChannel returns the channel that a request with the given channel name
+// would post to. Direct channels aren't created, so only the user is checked in that case.
+func (a *App) getIncomingWebhookPreviewChannel(hook *model.IncomingWebhook, channelName string) (*model.Channel, *model.AppError) {
+	if len(channelName) == 0 {
+		return &model.Channel{Id: hook.ChannelId}, nil
+	}
+
+	if channelName[0] == '@' {
+		if hook.ChannelLocked {
+			return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.channel_locked.app_error", nil, "", http.StatusForbidden)
+		}
+		if _, err := a.Srv().Store.User().GetByUsername(channelName[1:]); err != nil {
+			return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.user.app_error", nil, "err="+err.Message, http.StatusBadRequest)
+		}
+		return &model.Channel{Type: model.CHANNEL_DIRECT}, nil
+	}
+
+	channel, err := a.Srv().Store.Channel().GetByName(hook.TeamId, strings.TrimPrefix(channelName, "#"), true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.channel.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if hook.ChannelLocked && hook.ChannelId != channel.Id {
+		return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.channel_locked.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if channel.Type != model.CHANNEL_OPEN && !a.HasPermissionToChannel(hook.UserId, channel.Id, model.PERMISSION_READ_CHANNEL) {
+		return nil, model.NewAppError("PreviewIncomingWebhook", "web.incoming_webhook.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	return channel, nil
+}
+
 func (a *App) CreateCommandWebhook(commandId string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError) {
 	hook := &model.CommandWebhook{
 		CommandId: commandId,
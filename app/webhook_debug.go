@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/cache"
+)
+
+const INCOMING_WEBHOOK_DEBUG_CACHE_SIZE = 1000
+
+func (s *Server) getIncomingWebhookDebugSession(hookId string) *model.IncomingWebhookDebugSession {
+	var session *model.IncomingWebhookDebugSession
+	if err := s.incomingWebhookDebugCache.Get(hookId, &session); err != nil {
+		if err != cache.ErrKeyNotFound {
+			mlog.Warn("Failed to get the incoming webhook debug session", mlog.String("hook_id", hookId), mlog.Err(err))
+		}
+		return nil
+	}
+
+	if session == nil || session.HookId != hookId || session.ExpiresAt <= model.GetMillis() {
+		return nil
+	}
+
+	return session
+}
+
+func (s *Server) setIncomingWebhookDebugSession(session *model.IncomingWebhookDebugSession) error {
+	ttl := time.Duration(session.ExpiresAt-model.GetMillis()) * time.Millisecond
+	if ttl <= 0 {
+		return s.incomingWebhookDebugCache.Remove(session.HookId)
+	}
+
+	return s.incomingWebhookDebugCache.SetWithExpiry(session.HookId, session, ttl)
+}
+
+// EnableIncomingWebhookDebugging starts capturing the requests received by an incoming webhook
+// for the next hour. Enabling it again extends the capture, keeping the requests captured so far.
+func (a *App) EnableIncomingWebhookDebugging(hookId string) (*model.IncomingWebhookDebugSession, *model.AppError) {
+	a.Srv().incomingWebhookDebugMutex.Lock()
+	defer a.Srv().incomingWebhookDebugMutex.Unlock()
+
+	session := a.Srv().getIncomingWebhookDebugSession(hookId)
+	if session == nil {
+		session = &model.IncomingWebhookDebugSession{
+			HookId:   hookId,
+			Requests: []*model.IncomingWebhookRequestCapture{},
+		}
+	}
+	session.ExpiresAt = model.GetMillis() + model.INCOMING_WEBHOOK_DEBUG_DURATION
+
+	if err := a.Srv().setIncomingWebhookDebugSession(session); err != nil {
+		return nil, model.NewAppError("EnableIncomingWebhookDebugging", "app.incoming_webhook.debug.enable.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return session, nil
+}
+
+// DisableIncomingWebhookDebugging stops capturing the requests received by an incoming webhook and
+// discards the ones captured so far.
+func (a *App) DisableIncomingWebhookDebugging(hookId string) *model.AppError {
+	a.Srv().incomingWebhookDebugMutex.Lock()
+	defer a.Srv().incomingWebhookDebugMutex.Unlock()
+
+	if err := a.Srv().incomingWebhookDebugCache.Remove(hookId); err != nil {
+		return model.NewAppError("DisableIncomingWebhookDebugging", "app.incoming_webhook.debug.disable.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetIncomingWebhookDebugSession returns the requests captured for an incoming webhook. The
+// session has no requests and a zero ExpiresAt when debugging isn't enabled for the webhook.
+func (a *App) GetIncomingWebhookDebugSession(hookId string) *model.IncomingWebhookDebugSession {
+	a.Srv().incomingWebhookDebugMutex.Lock()
+	defer a.Srv().incomingWebhookDebugMutex.Unlock()
+
+	if session := a.Srv().getIncomingWebhookDebugSession(hookId); session != nil {
+		return session
+	}
+
+	return &model.IncomingWebhookDebugSession{
+		HookId:   hookId,
+		Requests: []*model.IncomingWebhookRequestCapture{},
+	}
+}
+
+// IsIncomingWebhookDebuggingEnabled returns whether the requests received by an incoming webhook
+// are being captured.
+func (a *App) IsIncomingWebhookDebuggingEnabled(hookId string) bool {
+	a.Srv().incomingWebhookDebugMutex.Lock()
+	defer a.Srv().incomingWebhookDebugMutex.Unlock()
+
+	return a.Srv().getIncomingWebhookDebugSession(hookId) != nil
+}
+
+// CaptureIncomingWebhookRequest records a request received by an incoming webhook, if debugging is
+// enabled for it.
+func (a *App) CaptureIncomingWebhookRequest(hookId string, capture *model.IncomingWebhookRequestCapture) {
+	a.Srv().incomingWebhookDebugMutex.Lock()
+	defer a.Srv().incomingWebhookDebugMutex.Unlock()
+
+	session := a.Srv().getIncomingWebhookDebugSession(hookId)
+	if session == nil {
+		return
+	}
+
+	session.AddRequest(capture)
+
+	if err := a.Srv().setIncomingWebhookDebugSession(session); err != nil {
+		mlog.Warn("Failed to capture the incoming webhook request", mlog.String("hook_id", hookId), mlog.Err(err))
+	}
+}
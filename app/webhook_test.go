@@ -362,6 +362,78 @@ Date:   Thu Mar 1 19:46:48 2018 +0300
 	assert.Equal(t, expectedText, post.Message)
 }
 
+func TestPreviewIncomingWebhook(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, err)
+	defer th.App.DeleteIncomingWebhook(hook.Id)
+
+	t.Run("valid payload", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{
+			Text:        "<http://example.com|example>",
+			Attachments: []*model.SlackAttachment{{Text: "text", Fields: []*model.SlackAttachmentField{{Title: "title", Value: "value"}}}},
+		})
+		require.Nil(t, err)
+		assert.Empty(t, preview.Errors)
+		require.Len(t, preview.Posts, 1)
+		assert.Equal(t, "[example](http://example.com)", preview.Posts[0].Message)
+		assert.Equal(t, model.POST_SLACK_ATTACHMENT, preview.Posts[0].Type)
+		assert.Equal(t, th.BasicChannel.Id, preview.Posts[0].ChannelId)
+		assert.Contains(t, preview.Posts[0].GetProps(), "from_webhook")
+
+		posts, err := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, Page: 0, PerPage: 10})
+		require.Nil(t, err)
+		for _, post := range posts.Posts {
+			assert.NotEqual(t, "[example](http://example.com)", post.Message)
+		}
+	})
+
+	t.Run("missing text and attachments", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{})
+		require.Nil(t, err)
+		require.Len(t, preview.Errors, 1)
+		assert.Equal(t, "web.incoming_webhook.text.app_error", preview.Errors[0].Id)
+	})
+
+	t.Run("bad attachment fields", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{
+			Attachments: []*model.SlackAttachment{{Fields: []*model.SlackAttachmentField{{Title: "title"}, {}}}},
+		})
+		require.Nil(t, err)
+		require.Len(t, preview.Errors, 1)
+		assert.Equal(t, "web.incoming_webhook.preview.attachment_field.app_error", preview.Errors[0].Id)
+		require.Len(t, preview.Posts, 1)
+	})
+
+	t.Run("oversized text", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{
+			Text: strings.Repeat("a", th.App.MaxPostSize()+1),
+		})
+		require.Nil(t, err)
+		require.Len(t, preview.Errors, 1)
+		assert.Equal(t, "web.incoming_webhook.preview.text_length.app_error", preview.Errors[0].Id)
+		assert.Len(t, preview.Posts, 2)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{Text: "text", ChannelName: "#" + model.NewId()})
+		require.Nil(t, err)
+		require.Len(t, preview.Errors, 1)
+		assert.Equal(t, "web.incoming_webhook.channel.app_error", preview.Errors[0].Id)
+	})
+
+	t.Run("direct message", func(t *testing.T) {
+		preview, err := th.App.PreviewIncomingWebhook(hook, &model.IncomingWebhookRequest{Text: "text", ChannelName: "@" + th.BasicUser2.Username})
+		require.Nil(t, err)
+		assert.Empty(t, preview.Errors)
+		require.Len(t, preview.Posts, 1)
+	})
+}
+
 func TestSplitWebhookPost(t *testing.T) {
 	type TestCase struct {
 		Post     *model.Post
@@ -723,6 +795,17 @@ func (r InfiniteReader) Read(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+func TestOutgoingWebhookTimeout(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxOutgoingWebhookTimeout = 60 })
+
+	assert.Equal(t, time.Duration(0), th.App.outgoingWebhookTimeout(&model.OutgoingWebhook{}))
+	assert.Equal(t, 5*time.Second, th.App.outgoingWebhookTimeout(&model.OutgoingWebhook{Timeout: 5}))
+	assert.Equal(t, 60*time.Second, th.App.outgoingWebhookTimeout(&model.OutgoingWebhook{Timeout: 600}))
+}
+
 func TestDoOutgoingWebhookRequest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -738,7 +821,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
 		require.Nil(t, err)
 
 		assert.NotNil(t, resp)
@@ -752,7 +835,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
 		require.NotNil(t, err)
 		require.IsType(t, &json.SyntaxError{}, err)
 	})
@@ -763,7 +846,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
 		require.NotNil(t, err)
 		require.Equal(t, io.ErrUnexpectedEOF, err)
 	})
@@ -774,7 +857,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
 		require.NotNil(t, err)
 		require.IsType(t, &json.SyntaxError{}, err)
 	})
@@ -794,9 +877,26 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 			th.App.HTTPService().(*httpservice.HTTPServiceImpl).RequestTimeout = httpservice.RequestTimeout
 		}()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
+		require.NotNil(t, err)
+		require.IsType(t, &url.Error{}, err)
+	})
+
+	t.Run("with a slow response and a hook timeout", func(t *testing.T) {
+		releaseHandler := make(chan interface{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-releaseHandler
+		}))
+		defer server.Close()
+		defer close(releaseHandler)
+
+		// The default timeout is left alone, so only the hook timeout can end the request
+		start := time.Now()
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 500*time.Millisecond)
 		require.NotNil(t, err)
 		require.IsType(t, &url.Error{}, err)
+		require.True(t, time.Since(start) < httpservice.RequestTimeout)
 	})
 
 	t.Run("without response", func(t *testing.T) {
@@ -804,7 +904,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", 0)
 		require.Nil(t, err)
 		require.Nil(t, resp)
 	})
@@ -6,6 +6,7 @@ package audit
 import (
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/mattermost/logr"
 	"github.com/mattermost/logr/format"
@@ -23,6 +24,11 @@ type Audit struct {
 
 	// OnError is called when an error occurs while writing an audit record.
 	OnError func(err error)
+
+	// redactedParams are the lower case names of the query parameters redacted from URLs, see
+	// SetRedactedQueryParams.
+	redactedParams      map[string]bool
+	redactedParamsMutex sync.RWMutex
 }
 
 func (a *Audit) Init(maxQueueSize int) {
@@ -57,6 +63,8 @@ func (a *Audit) MakeJSONFormatter() *format.JSON {
 
 // LogRecord emits an audit record with complete info.
 func (a *Audit) LogRecord(level Level, rec Record) {
+	rec = a.redactRecord(rec)
+
 	flds := logr.Fields{}
 	flds[KeyAPIPath] = rec.APIPath
 	flds[KeyEvent] = rec.Event
@@ -14,6 +14,7 @@ const (
 	KeyClient    = "client"
 	KeyIPAddress = "ip_address"
 	KeyClusterID = "cluster_id"
+	KeyQuery     = "query"
 
 	Success = "success"
 	Attempt = "attempt"
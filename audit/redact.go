@@ -0,0 +1,196 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/francoispqt/gojay"
+)
+
+// RedactedValue replaces the values of redacted query parameters.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedQueryParams lists the query parameters redacted from URLs in audit records, as
+// they commonly carry credentials.
+var DefaultRedactedQueryParams = []string{
+	"access_token",
+	"client_secret",
+	"code",
+	"password",
+	"refresh_token",
+	"secret",
+	"share_token",
+	"token",
+}
+
+var defaultRedactedParams = makeRedactedParams(nil)
+
+// SetRedactedQueryParams sets the query parameters redacted from URLs in audit records, in addition
+// to DefaultRedactedQueryParams. Parameter names are matched regardless of case. It may be called
+// again, such as when the configuration changes, while records are being logged.
+func (a *Audit) SetRedactedQueryParams(params []string) {
+	redacted := makeRedactedParams(params)
+
+	a.redactedParamsMutex.Lock()
+	defer a.redactedParamsMutex.Unlock()
+	a.redactedParams = redacted
+}
+
+func (a *Audit) getRedactedParams() map[string]bool {
+	a.redactedParamsMutex.RLock()
+	defer a.redactedParamsMutex.RUnlock()
+
+	if a.redactedParams == nil {
+		return defaultRedactedParams
+	}
+	return a.redactedParams
+}
+
+func makeRedactedParams(params []string) map[string]bool {
+	redacted := make(map[string]bool, len(DefaultRedactedQueryParams)+len(params))
+	for _, param := range DefaultRedactedQueryParams {
+		redacted[param] = true
+	}
+	for _, param := range params {
+		if param = strings.TrimSpace(param); param != "" {
+			redacted[strings.ToLower(param)] = true
+		}
+	}
+
+	return redacted
+}
+
+// redactRecord redacts the sensitive query parameters of the request's query and of the URLs in
+// the metadata of the given record. Metadata values stored under the name of a redacted parameter
+// are redacted as a whole, at any depth.
+func (a *Audit) redactRecord(rec Record) Record {
+	redacted := a.getRedactedParams()
+
+	if len(rec.Meta) > 0 {
+		meta := make(Meta, len(rec.Meta))
+		for k, v := range rec.Meta {
+			if k == KeyQuery {
+				if query, ok := v.(string); ok {
+					meta[k] = redactQuery(query, redacted)
+					continue
+				}
+			}
+			meta[k] = redactValue(k, v, redacted)
+		}
+		rec.Meta = meta
+	}
+
+	return rec
+}
+
+// redactValue redacts the given metadata value, stored under the given key. Values other than
+// strings, slices and maps, such as structs, are redacted in their JSON form.
+func redactValue(key string, v interface{}, redacted map[string]bool) interface{} {
+	switch val := v.(type) {
+	case nil, bool, int, int64, float64, json.Number:
+		return v
+	case string:
+		if redacted[strings.ToLower(key)] {
+			return RedactedValue
+		}
+		return redactURL(val, redacted)
+	case []string:
+		values := make([]string, len(val))
+		for i, s := range val {
+			values[i] = redactValue(key, s, redacted).(string)
+		}
+		return values
+	case []interface{}:
+		values := make([]interface{}, len(val))
+		for i, item := range val {
+			values[i] = redactValue(key, item, redacted)
+		}
+		return values
+	case map[string]string:
+		values := make(map[string]string, len(val))
+		for k, s := range val {
+			values[k] = redactValue(k, s, redacted).(string)
+		}
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			values[k] = redactValue(k, item, redacted)
+		}
+		return values
+	}
+
+	var data []byte
+	var err error
+	if obj, ok := v.(gojay.MarshalerJSONObject); ok {
+		data, err = gojay.MarshalJSONObject(obj)
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return v
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return v
+	}
+
+	return redactValue(key, generic, redacted)
+}
+
+// redactURL replaces the values of the redacted query parameters of the given URL. Anything that
+// isn't a URL with a query is returned as is.
+func redactURL(s string, redacted map[string]bool) string {
+	if !strings.Contains(s, "?") {
+		return s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.RawQuery == "" {
+		return s
+	}
+
+	query := redactQuery(u.RawQuery, redacted)
+	if query == u.RawQuery {
+		return s
+	}
+
+	u.RawQuery = query
+	return u.String()
+}
+
+// redactQuery replaces the values of the redacted parameters of the given raw query.
+func redactQuery(query string, redacted map[string]bool) string {
+	changed := false
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		rawKey := pair
+		if idx := strings.Index(pair, "="); idx >= 0 {
+			rawKey = pair[:idx]
+		}
+
+		key := rawKey
+		if unescaped, err := url.QueryUnescape(rawKey); err == nil {
+			key = unescaped
+		}
+
+		if redacted[strings.ToLower(key)] {
+			pairs[i] = rawKey + "=" + RedactedValue
+			changed = true
+		}
+	}
+
+	if !changed {
+		return query
+	}
+
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactURL(t *testing.T) {
+	adt := &Audit{}
+	adt.SetRedactedQueryParams([]string{" Api_Key ", ""})
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "no query", in: "/api/v4/users/me", want: "/api/v4/users/me"},
+		{name: "not a url", in: "some text", want: "some text"},
+		{name: "nothing to redact", in: "/api/v4/users?page=1&per_page=60", want: "/api/v4/users?page=1&per_page=60"},
+		{name: "default param", in: "/oauth/complete?code=abc&state=xyz", want: "/oauth/complete?code=[REDACTED]&state=xyz"},
+		{name: "several params keep order", in: "https://example.com/hook?token=t1&a=1&password=p&b=2",
+			want: "https://example.com/hook?token=[REDACTED]&a=1&password=[REDACTED]&b=2"},
+		{name: "case insensitive", in: "/path?Access_Token=abc", want: "/path?Access_Token=[REDACTED]"},
+		{name: "additional param", in: "/path?API_KEY=abc&x=1", want: "/path?API_KEY=[REDACTED]&x=1"},
+		{name: "param without value", in: "/path?secret", want: "/path?secret=[REDACTED]"},
+		{name: "escaped param name", in: "/path?pass%77ord=abc", want: "/path?pass%77ord=[REDACTED]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, redactURL(tt.in, adt.getRedactedParams()))
+		})
+	}
+
+	t.Run("defaults only", func(t *testing.T) {
+		require.Equal(t, "/path?api_key=abc&token=[REDACTED]", redactURL("/path?api_key=abc&token=abc", (&Audit{}).getRedactedParams()))
+	})
+}
+
+type redactTestStruct struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func TestRedactRecord(t *testing.T) {
+	adt := &Audit{}
+
+	meta := Meta{
+		KeyQuery: "share_token=abc&page=1",
+		"url":    "https://example.com/plugin.tar.gz?token=abc",
+		"urls":   []string{"/a?password=p", "/b"},
+		"code":   404,
+		"token":  "abc",
+		"props": map[string]interface{}{
+			"secret": "s",
+			"nested": map[string]string{"link": "/c?access_token=t"},
+		},
+		"struct": &redactTestStruct{Name: "plugin", URL: "/d?client_secret=s"},
+	}
+	rec := Record{
+		APIPath: "/api/v4/oauth",
+		Meta:    meta,
+	}
+
+	redacted := adt.redactRecord(rec)
+	require.Equal(t, "/api/v4/oauth", redacted.APIPath)
+	require.Equal(t, "share_token=[REDACTED]&page=1", redacted.Meta[KeyQuery])
+	require.Equal(t, "https://example.com/plugin.tar.gz?token=[REDACTED]", redacted.Meta["url"])
+	require.Equal(t, []string{"/a?password=[REDACTED]", "/b"}, redacted.Meta["urls"])
+	require.Equal(t, 404, redacted.Meta["code"])
+	require.Equal(t, RedactedValue, redacted.Meta["token"])
+	require.Equal(t, map[string]interface{}{
+		"secret": RedactedValue,
+		"nested": map[string]string{"link": "/c?access_token=[REDACTED]"},
+	}, redacted.Meta["props"])
+	require.Equal(t, map[string]interface{}{"name": "plugin", "url": "/d?client_secret=[REDACTED]"}, redacted.Meta["struct"])
+
+	// The original record is left untouched.
+	require.Equal(t, "https://example.com/plugin.tar.gz?token=abc", meta["url"])
+	require.Equal(t, []string{"/a?password=p", "/b"}, meta["urls"])
+	require.Equal(t, "s", meta["props"].(map[string]interface{})["secret"])
+}
+
+func TestSetRedactedQueryParamsAgain(t *testing.T) {
+	adt := &Audit{}
+	adt.SetRedactedQueryParams([]string{"api_key"})
+	require.Equal(t, "/path?api_key=[REDACTED]", redactURL("/path?api_key=abc", adt.getRedactedParams()))
+
+	adt.SetRedactedQueryParams(nil)
+	require.Equal(t, "/path?api_key=abc", redactURL("/path?api_key=abc", adt.getRedactedParams()))
+}
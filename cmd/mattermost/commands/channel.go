@@ -210,7 +210,7 @@ func createChannelCmdF(command *cobra.Command, args []string) error {
 		CreatorId:   "",
 	}
 
-	createdChannel, errCreatedChannel := a.CreateChannel(channel, false)
+	createdChannel, errCreatedChannel := a.CreateChannelBypassingThrottle(channel, false)
 	if errCreatedChannel != nil {
 		return errCreatedChannel
 	}
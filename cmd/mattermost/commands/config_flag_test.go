@@ -10,7 +10,6 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 )
 
@@ -21,7 +20,7 @@ func TestConfigFlag(t *testing.T) {
 
 	i18n, ok := fileutils.FindDir("i18n")
 	require.True(t, ok)
-	require.NoError(t, utils.CopyDir(i18n, filepath.Join(dir, "i18n")))
+	require.NoError(t, fileutils.CopyDir(i18n, filepath.Join(dir, "i18n")))
 
 	prevDir, err := os.Getwd()
 	require.NoError(t, err)
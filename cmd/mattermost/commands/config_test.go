@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -551,7 +552,7 @@ func TestConfigMigrate(t *testing.T) {
 	defer fs.Close()
 
 	t.Run("Should error with too few parameters", func(t *testing.T) {
-		assert.Error(t, th.RunCommand(t, "config", "migrate", fileDSN))
+		assert.Error(t, th.RunCommand(t, "config", "migrate"))
 	})
 
 	t.Run("Should error with too many parameters", func(t *testing.T) {
@@ -569,6 +570,21 @@ func TestConfigMigrate(t *testing.T) {
 	t.Run("Should fail passing an invalid source", func(t *testing.T) {
 		assert.Error(t, th.RunCommand(t, "config", "migrate", "invalid/path", sqlDSN))
 	})
+
+	t.Run("Should upgrade a single config file in place", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "config-migrate")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"ServiceSettings": {"AllowCorsFrom": "https://example.com"}}`), 0600))
+
+		output := th.CheckCommand(t, "config", "migrate", path)
+		assert.Contains(t, output, "Applied migration 1:")
+
+		output = th.CheckCommand(t, "config", "migrate", path)
+		assert.Contains(t, output, "Config is already at the current version.")
+	})
 }
 
 func contains(configMap map[string]interface{}, v interface{}, configSettings []string) bool {
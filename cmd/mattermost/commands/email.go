@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+var EmailCmd = &cobra.Command{
+	Use:   "email",
+	Short: "Management of email",
+}
+
+var EmailTestTemplateCmd = &cobra.Command{
+	Use:     "test-template",
+	Short:   "Send a test email of a template",
+	Long:    "Render an email template, including the overrides of the template override directory, with sample data and send it to an address for verification.",
+	Example: "  email test-template --name post_body_full --to me@example.com",
+	RunE:    emailTestTemplateCmdF,
+}
+
+func init() {
+	EmailTestTemplateCmd.Flags().String("name", "", "Name of the template to render, e.g. post_body_full")
+	EmailTestTemplateCmd.Flags().String("to", "", "Email address to send the rendered template to")
+
+	EmailCmd.AddCommand(EmailTestTemplateCmd)
+	RootCmd.AddCommand(EmailCmd)
+}
+
+func emailTestTemplateCmdF(command *cobra.Command, args []string) error {
+	name, errName := command.Flags().GetString("name")
+	if errName != nil || name == "" {
+		return errors.New("Name is required")
+	}
+
+	to, errTo := command.Flags().GetString("to")
+	if errTo != nil || to == "" {
+		return errors.New("To is required")
+	}
+
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Srv().Shutdown()
+
+	if err := a.Srv().EmailService.SendTemplateTestEmail(name, to); err != nil {
+		return err
+	}
+
+	CommandPrettyPrintln("Sent the " + name + " template to " + to)
+
+	return nil
+}
@@ -56,6 +56,15 @@ var ChannelGroupListCmd = &cobra.Command{
 	RunE:    channelGroupListCmdF,
 }
 
+var ChannelGroupDriftCmd = &cobra.Command{
+	Use:     "drift [team]:[channel]",
+	Short:   "Shows the channel members that aren't members of the channel groups",
+	Long:    "Shows the members of a group-constrained channel that aren't members of any of its groups, and optionally removes them",
+	Example: "  group channel drift myteam:mychannel --apply",
+	Args:    cobra.ExactArgs(1),
+	RunE:    channelGroupDriftCmdF,
+}
+
 var TeamGroupCmd = &cobra.Command{
 	Use:   "team",
 	Short: "Management of team groups",
@@ -95,11 +104,14 @@ var TeamGroupListCmd = &cobra.Command{
 }
 
 func init() {
+	ChannelGroupDriftCmd.Flags().Bool("apply", false, "Remove the drifted members from the channel.")
+
 	ChannelGroupCmd.AddCommand(
 		ChannelGroupEnableCmd,
 		ChannelGroupDisableCmd,
 		ChannelGroupStatusCmd,
 		ChannelGroupListCmd,
+		ChannelGroupDriftCmd,
 	)
 
 	TeamGroupCmd.AddCommand(
@@ -223,6 +235,50 @@ func channelGroupListCmdF(command *cobra.Command, args []string) error {
 	return nil
 }
 
+func channelGroupDriftCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Srv().Shutdown()
+
+	channel := getChannelFromChannelArg(a, args[0])
+	if channel == nil {
+		return errors.New("Unable to find channel '" + args[0] + "'")
+	}
+
+	drift, appErr := a.GetChannelMembersDrift(channel)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, userId := range drift.UserIds {
+		CommandPrettyPrintln(userId)
+	}
+	for _, userId := range drift.ExemptUserIds {
+		CommandPrettyPrintln(userId + " (exempt: manually managed channel admin)")
+	}
+
+	if apply, _ := command.Flags().GetBool("apply"); !apply {
+		return nil
+	}
+
+	removedUserIds, appErr := a.RepairChannelMembersDrift(drift)
+
+	// Members removed before a failure are audited too.
+	auditRec := a.MakeAuditRecord("channelGroupDrift", audit.Success)
+	auditRec.AddMeta("channel", channel)
+	auditRec.AddMeta("removed_user_ids", removedUserIds)
+	if appErr != nil {
+		auditRec.Fail()
+		a.LogAuditRec(auditRec, appErr)
+		return appErr
+	}
+	a.LogAuditRec(auditRec, nil)
+
+	return nil
+}
+
 func teamGroupEnableCmdF(command *cobra.Command, args []string) error {
 	a, err := InitDBCommandContextCobra(command)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,12 +27,24 @@ import (
 const (
 	DEACTIVATED_USER = "deactivated"
 	GUEST_USER       = "guest"
+
+	// Roughly one post out of ten starts a thread.
+	DEFAULT_THREAD_DEPTH_DISTRIBUTION = "0:90,1:3,2:3,4:2,8:2"
+
+	SAMPLE_ATTACHMENTS = 10
 )
 
 var SampleDataCmd = &cobra.Command{
 	Use:   "sampledata",
 	Short: "Generate sample data",
-	RunE:  sampleDataCmdF,
+	Long: `Generate sample data and load it into the database.
+
+The same seed always generates the same data. The data is loaded through the bulk importer, which
+writes posts and replies to the store in batches without sending notifications or websocket events,
+and keeps channel message counts and last post times up to date. Once loaded, the data is checked
+against what was generated.`,
+	Example: "  sampledata --seed 10 --teams 5 --users 500 --posts-per-channel 1000 --file-attachments-percentage 5 --profile",
+	RunE:    sampleDataCmdF,
 }
 
 func init() {
@@ -51,6 +64,9 @@ func init() {
 	SampleDataCmd.Flags().IntP("workers", "w", 2, "How many workers to run during the import.")
 	SampleDataCmd.Flags().String("profile-images", "", "Optional. Path to folder with images to randomly pick as user profile image.")
 	SampleDataCmd.Flags().StringP("bulk", "b", "", "Optional. Path to write a JSONL bulk file instead of loading into the database.")
+	SampleDataCmd.Flags().Int("file-attachments-percentage", 0, "The percentage of posts and replies with a file attachment.")
+	SampleDataCmd.Flags().String("thread-depth-distribution", DEFAULT_THREAD_DEPTH_DISTRIBUTION, "Comma separated list of replies:weight pairs used to pick how many replies each post gets.")
+	SampleDataCmd.Flags().Bool("profile", false, "Print how long each phase took.")
 	RootCmd.AddCommand(SampleDataCmd)
 }
 
@@ -85,14 +101,15 @@ func randomReaction(users []string, parentCreateAt int64) app.ReactionImportData
 	}
 }
 
-func randomReply(users []string, parentCreateAt int64) app.ReplyImportData {
+func randomReply(users []string, parentCreateAt int64, options samplePostOptions) app.ReplyImportData {
 	user := users[rand.Intn(len(users))]
 	message := randomMessage(users)
 	date := parentCreateAt + int64(rand.Intn(100000))
 	return app.ReplyImportData{
-		User:     &user,
-		Message:  &message,
-		CreateAt: &date,
+		User:        &user,
+		Message:     &message,
+		CreateAt:    &date,
+		Attachments: randomAttachments(options),
 	}
 }
 
@@ -138,6 +155,207 @@ func randomMessage(users []string) string {
 	return message
 }
 
+type threadDepthWeight struct {
+	replies int
+	weight  int
+}
+
+type samplePostOptions struct {
+	threadDepths          []threadDepthWeight
+	attachments           []string
+	attachmentsPercentage int
+}
+
+func parseThreadDepthDistribution(distribution string) ([]threadDepthWeight, error) {
+	threadDepths := []threadDepthWeight{}
+	totalWeight := 0
+	for _, pair := range strings.Split(distribution, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid replies:weight pair %q", pair)
+		}
+		replies, err := strconv.Atoi(parts[0])
+		if err != nil || replies < 0 {
+			return nil, fmt.Errorf("invalid number of replies %q", parts[0])
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q", parts[1])
+		}
+		threadDepths = append(threadDepths, threadDepthWeight{replies: replies, weight: weight})
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil, errors.New("the weights can't all be zero")
+	}
+	return threadDepths, nil
+}
+
+func randomThreadDepth(threadDepths []threadDepthWeight) int {
+	totalWeight := 0
+	for _, threadDepth := range threadDepths {
+		totalWeight += threadDepth.weight
+	}
+	selector := rand.Intn(totalWeight)
+	for _, threadDepth := range threadDepths {
+		if selector < threadDepth.weight {
+			return threadDepth.replies
+		}
+		selector -= threadDepth.weight
+	}
+	return 0
+}
+
+func randomReplies(users []string, parentCreateAt int64, options samplePostOptions) []app.ReplyImportData {
+	replies := []app.ReplyImportData{}
+	for i := randomThreadDepth(options.threadDepths); i > 0; i-- {
+		replies = append(replies, randomReply(users, parentCreateAt, options))
+	}
+	return replies
+}
+
+func randomAttachments(options samplePostOptions) *[]app.AttachmentImportData {
+	if options.attachmentsPercentage == 0 || len(options.attachments) == 0 {
+		return nil
+	}
+	if rand.Intn(100) >= options.attachmentsPercentage {
+		return nil
+	}
+	attachment := options.attachments[rand.Intn(len(options.attachments))]
+	return &[]app.AttachmentImportData{{Path: &attachment}}
+}
+
+// createSampleAttachments writes a few small text files to be used as post attachments.
+func createSampleAttachments(dir string) ([]string, error) {
+	attachments := []string{}
+	for i := 0; i < SAMPLE_ATTACHMENTS; i++ {
+		attachment := path.Join(dir, fmt.Sprintf("attachment-%d.txt", i))
+		if err := ioutil.WriteFile(attachment, []byte(fake.Paragraphs()), 0644); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+type sampleChannelKey struct {
+	team    string
+	channel string
+}
+
+// sampleDataVerifier keeps track of the generated data so that it can be
+// checked against the store once it has been imported.
+type sampleDataVerifier struct {
+	teamMembers    map[string]map[string]bool
+	channelMembers map[sampleChannelKey]map[string]bool
+	deactivated    map[string]bool
+	channelPosts   map[sampleChannelKey]int64
+	channelLastAt  map[sampleChannelKey]int64
+}
+
+func newSampleDataVerifier() *sampleDataVerifier {
+	return &sampleDataVerifier{
+		teamMembers:    map[string]map[string]bool{},
+		channelMembers: map[sampleChannelKey]map[string]bool{},
+		deactivated:    map[string]bool{},
+		channelPosts:   map[sampleChannelKey]int64{},
+		channelLastAt:  map[sampleChannelKey]int64{},
+	}
+}
+
+func (v *sampleDataVerifier) addUser(line app.LineImportData) {
+	user := line.User
+	// Usernames can repeat, in which case the importer updates the existing user
+	// and adds the new memberships to the ones it already had.
+	v.deactivated[*user.Username] = *user.DeleteAt != 0
+	for _, team := range *user.Teams {
+		if v.teamMembers[*team.Name] == nil {
+			v.teamMembers[*team.Name] = map[string]bool{}
+		}
+		v.teamMembers[*team.Name][*user.Username] = true
+		for _, channel := range *team.Channels {
+			key := sampleChannelKey{team: *team.Name, channel: *channel.Name}
+			if v.channelMembers[key] == nil {
+				v.channelMembers[key] = map[string]bool{}
+			}
+			v.channelMembers[key][*user.Username] = true
+		}
+	}
+}
+
+func (v *sampleDataVerifier) addPost(line app.LineImportData) {
+	post := line.Post
+	key := sampleChannelKey{team: *post.Team, channel: *post.Channel}
+	v.addPostAt(key, *post.CreateAt)
+	for _, reply := range *post.Replies {
+		v.addPostAt(key, *reply.CreateAt)
+	}
+}
+
+func (v *sampleDataVerifier) addPostAt(key sampleChannelKey, createAt int64) {
+	v.channelPosts[key]++
+	if createAt > v.channelLastAt[key] {
+		v.channelLastAt[key] = createAt
+	}
+}
+
+// verify checks the member counts, message counts and last post times of the
+// generated teams and channels. Reply counts are computed when threads are
+// read, so the message counts cover the replies too.
+func (v *sampleDataVerifier) verify(a *app.App, teamsAndChannels map[string][]string) error {
+	problems := []string{}
+	for teamName, channels := range teamsAndChannels {
+		team, appErr := a.Srv().Store.Team().GetByName(teamName)
+		if appErr != nil {
+			return fmt.Errorf("unable to get team %s: %s", teamName, appErr.Error())
+		}
+
+		teamMembers, appErr := a.Srv().Store.Team().GetTotalMemberCount(team.Id, nil)
+		if appErr != nil {
+			return fmt.Errorf("unable to count the members of team %s: %s", teamName, appErr.Error())
+		}
+		if expected := int64(len(v.teamMembers[teamName])); teamMembers != expected {
+			problems = append(problems, fmt.Sprintf("team %s has %d members, expected %d", teamName, teamMembers, expected))
+		}
+
+		for _, channelName := range channels {
+			key := sampleChannelKey{team: teamName, channel: channelName}
+			channel, err := a.Srv().Store.Channel().GetByName(team.Id, channelName, false)
+			if err != nil {
+				return fmt.Errorf("unable to get channel %s in team %s: %w", channelName, teamName, err)
+			}
+
+			channelMembers, appErr := a.Srv().Store.Channel().GetMemberCount(channel.Id, false)
+			if appErr != nil {
+				return fmt.Errorf("unable to count the members of channel %s in team %s: %s", channelName, teamName, appErr.Error())
+			}
+			// Deactivated users aren't counted as channel members.
+			var expectedMembers int64
+			for username := range v.channelMembers[key] {
+				if !v.deactivated[username] {
+					expectedMembers++
+				}
+			}
+			if channelMembers != expectedMembers {
+				problems = append(problems, fmt.Sprintf("channel %s in team %s has %d members, expected %d", channelName, teamName, channelMembers, expectedMembers))
+			}
+
+			if channel.TotalMsgCount != v.channelPosts[key] {
+				problems = append(problems, fmt.Sprintf("channel %s in team %s has %d messages, expected %d", channelName, teamName, channel.TotalMsgCount, v.channelPosts[key]))
+			}
+			if channel.LastPostAt < v.channelLastAt[key] {
+				problems = append(problems, fmt.Sprintf("channel %s in team %s has its last post at %d, expected at least %d", channelName, teamName, channel.LastPostAt, v.channelLastAt[key]))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New("Sample data verification failed:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
 func sampleDataCmdF(command *cobra.Command, args []string) error {
 	a, err := InitDBCommandContextCobra(command)
 	if err != nil {
@@ -209,6 +427,22 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.New("Invalid profile-images parameter")
 	}
+	attachmentsPercentage, err := command.Flags().GetInt("file-attachments-percentage")
+	if err != nil || attachmentsPercentage < 0 || attachmentsPercentage > 100 {
+		return errors.New("Invalid file-attachments-percentage parameter")
+	}
+	threadDepthDistribution, err := command.Flags().GetString("thread-depth-distribution")
+	if err != nil {
+		return errors.New("Invalid thread-depth-distribution parameter")
+	}
+	threadDepths, err := parseThreadDepthDistribution(threadDepthDistribution)
+	if err != nil {
+		return fmt.Errorf("Invalid thread-depth-distribution parameter: %s", err.Error())
+	}
+	profile, err := command.Flags().GetBool("profile")
+	if err != nil {
+		return errors.New("Invalid profile parameter")
+	}
 	profileImages := []string{}
 	if profileImagesPath != "" {
 		var profileImagesStat os.FileInfo
@@ -257,6 +491,13 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 		}
 	}
 
+	profilePhase := func(phase string, start time.Time) {
+		if profile {
+			CommandPrintErrorln(fmt.Sprintf("%s took %s", phase, time.Since(start)))
+		}
+	}
+	generateStart := time.Now()
+
 	encoder := json.NewEncoder(bulkFile)
 	version := 1
 	encoder.Encode(app.LineImportData{Type: "version", Version: &version})
@@ -264,6 +505,27 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 	fake.Seed(seed)
 	rand.Seed(seed)
 
+	postOptions := samplePostOptions{
+		threadDepths:          threadDepths,
+		attachmentsPercentage: attachmentsPercentage,
+	}
+	if attachmentsPercentage > 0 {
+		attachmentsDir, err := ioutil.TempDir("", ".mattermost-sample-attachments-")
+		if err != nil {
+			return errors.New("Unable to create the attachments folder.")
+		}
+		if bulk == "" {
+			defer os.RemoveAll(attachmentsDir)
+		} else {
+			CommandPrintErrorln("Attachments written to " + attachmentsDir)
+		}
+		postOptions.attachments, err = createSampleAttachments(attachmentsDir)
+		if err != nil {
+			return errors.New("Unable to write the attachments.")
+		}
+	}
+	verifier := newSampleDataVerifier()
+
 	teamsAndChannels := make(map[string][]string)
 	for i := 0; i < teams; i++ {
 		teamLine := createTeam(i)
@@ -289,26 +551,30 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 	for i := 0; i < users; i++ {
 		userLine := createUser(i, teamMemberships, channelMemberships, teamsAndChannels, profileImages, "")
 		encoder.Encode(userLine)
+		verifier.addUser(userLine)
 		allUsers = append(allUsers, *userLine.User.Username)
 	}
 	for i := 0; i < guests; i++ {
 		userLine := createUser(i, teamMemberships, channelMemberships, teamsAndChannels, profileImages, GUEST_USER)
 		encoder.Encode(userLine)
+		verifier.addUser(userLine)
 		allUsers = append(allUsers, *userLine.User.Username)
 	}
 	for i := 0; i < deactivatedUsers; i++ {
 		userLine := createUser(i, teamMemberships, channelMemberships, teamsAndChannels, profileImages, DEACTIVATED_USER)
 		encoder.Encode(userLine)
+		verifier.addUser(userLine)
 		allUsers = append(allUsers, *userLine.User.Username)
 	}
 
-	for team, channels := range teamsAndChannels {
-		for _, channel := range channels {
+	for _, team := range teamsList {
+		for _, channel := range teamsAndChannels[team] {
 			dates := sortedRandomDates(postsPerChannel)
 
 			for i := 0; i < postsPerChannel; i++ {
-				postLine := createPost(team, channel, allUsers, dates[i])
+				postLine := createPost(team, channel, allUsers, dates[i], postOptions)
 				encoder.Encode(postLine)
+				verifier.addPost(postLine)
 			}
 		}
 	}
@@ -326,7 +592,7 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 
 		dates := sortedRandomDates(postsPerDirectChannel)
 		for j := 0; j < postsPerDirectChannel; j++ {
-			postLine := createDirectPost([]string{user1, user2}, dates[j])
+			postLine := createDirectPost([]string{user1, user2}, dates[j], postOptions)
 			encoder.Encode(postLine)
 		}
 	}
@@ -356,22 +622,33 @@ func sampleDataCmdF(command *cobra.Command, args []string) error {
 
 		dates := sortedRandomDates(postsPerGroupChannel)
 		for j := 0; j < postsPerGroupChannel; j++ {
-			postLine := createDirectPost(users, dates[j])
+			postLine := createDirectPost(users, dates[j], postOptions)
 			encoder.Encode(postLine)
 		}
 	}
 
+	profilePhase("Generating the data", generateStart)
+
 	if bulk == "" {
 		_, err := bulkFile.Seek(0, 0)
 		if err != nil {
 			return errors.New("Unable to read correctly the temporary file.")
 		}
 
+		importStart := time.Now()
 		var importErr *model.AppError
 		importErr, lineNumber := a.BulkImport(bulkFile, false, workers)
 		if importErr != nil {
 			return fmt.Errorf("%s: %s, %s (line: %d)", importErr.Where, importErr.Message, importErr.DetailedError, lineNumber)
 		}
+		profilePhase("Importing the data", importStart)
+
+		verifyStart := time.Now()
+		if err := verifier.verify(a, teamsAndChannels); err != nil {
+			return err
+		}
+		profilePhase("Verifying the data", verifyStart)
+
 		auditRec := a.MakeAuditRecord("sampleData", audit.Success)
 		auditRec.AddMeta("file", bulkFile.Name())
 		a.LogAuditRec(auditRec, nil)
@@ -629,7 +906,7 @@ func createChannel(idx int, teamName string) app.LineImportData {
 	}
 }
 
-func createPost(team string, channel string, allUsers []string, createAt int64) app.LineImportData {
+func createPost(team string, channel string, allUsers []string, createAt int64, options samplePostOptions) app.LineImportData {
 	message := randomMessage(allUsers)
 	create_at := createAt
 	user := allUsers[rand.Intn(len(allUsers))]
@@ -650,25 +927,18 @@ func createPost(team string, channel string, allUsers []string, createAt int64)
 		}
 	}
 
-	replies := []app.ReplyImportData{}
-	if rand.Intn(10) == 0 {
-		for {
-			replies = append(replies, randomReply(allUsers, create_at))
-			if rand.Intn(4) == 0 {
-				break
-			}
-		}
-	}
+	replies := randomReplies(allUsers, create_at, options)
 
 	post := app.PostImportData{
-		Team:      &team,
-		Channel:   &channel,
-		User:      &user,
-		Message:   &message,
-		CreateAt:  &create_at,
-		FlaggedBy: &flagged_by,
-		Reactions: &reactions,
-		Replies:   &replies,
+		Team:        &team,
+		Channel:     &channel,
+		User:        &user,
+		Message:     &message,
+		CreateAt:    &create_at,
+		FlaggedBy:   &flagged_by,
+		Reactions:   &reactions,
+		Replies:     &replies,
+		Attachments: randomAttachments(options),
 	}
 	return app.LineImportData{
 		Type: "post",
@@ -689,7 +959,7 @@ func createDirectChannel(members []string) app.LineImportData {
 	}
 }
 
-func createDirectPost(members []string, createAt int64) app.LineImportData {
+func createDirectPost(members []string, createAt int64, options samplePostOptions) app.LineImportData {
 	message := randomMessage(members)
 	create_at := createAt
 	user := members[rand.Intn(len(members))]
@@ -710,15 +980,7 @@ func createDirectPost(members []string, createAt int64) app.LineImportData {
 		}
 	}
 
-	replies := []app.ReplyImportData{}
-	if rand.Intn(10) == 0 {
-		for {
-			replies = append(replies, randomReply(members, create_at))
-			if rand.Intn(4) == 0 {
-				break
-			}
-		}
-	}
+	replies := randomReplies(members, create_at, options)
 
 	post := app.DirectPostImportData{
 		ChannelMembers: &members,
@@ -728,6 +990,7 @@ func createDirectPost(members []string, createAt int64) app.LineImportData {
 		FlaggedBy:      &flagged_by,
 		Reactions:      &reactions,
 		Replies:        &replies,
+		Attachments:    randomAttachments(options),
 	}
 	return app.LineImportData{
 		Type:       "direct_post",
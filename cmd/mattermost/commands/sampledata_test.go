@@ -21,4 +21,30 @@ func TestSampledataBadParameters(t *testing.T) {
 
 	// should fail because you have more channel memberships than channels per team
 	require.Error(t, th.RunCommand(t, "sampledata", "--channels-per-team", "10", "--channel-memberships", "11"))
+
+	// should fail because the attachments percentage is out of range
+	require.Error(t, th.RunCommand(t, "sampledata", "--file-attachments-percentage", "101"))
+
+	// should fail because the thread depth distribution can't be parsed
+	require.Error(t, th.RunCommand(t, "sampledata", "--thread-depth-distribution", "0:90,1"))
+}
+
+func TestSampledataVerification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	require.NoError(t, th.RunCommand(t, "sampledata", "--teams", "2", "--channels-per-team", "3", "--users", "6", "--guests", "1", "--deactivated-users", "1", "--channel-memberships", "2", "--posts-per-channel", "10", "--direct-channels", "2", "--group-channels", "1", "--file-attachments-percentage", "20", "--thread-depth-distribution", "0:1,3:1"))
+}
+
+func TestSampledataThreadDepthDistribution(t *testing.T) {
+	threadDepths, err := parseThreadDepthDistribution("0:90, 1:6,5:4")
+	require.NoError(t, err)
+	require.Equal(t, []threadDepthWeight{{replies: 0, weight: 90}, {replies: 1, weight: 6}, {replies: 5, weight: 4}}, threadDepths)
+
+	for _, distribution := range []string{"", "1", "1:", "a:1", "1:a", "-1:1", "1:-1", "0:0,1:0"} {
+		_, err := parseThreadDepthDistribution(distribution)
+		require.Error(t, err, distribution)
+	}
+
+	require.Equal(t, 3, randomThreadDepth([]threadDepthWeight{{replies: 1, weight: 0}, {replies: 3, weight: 1}}))
 }
@@ -90,12 +90,24 @@ func runServer(configStore config.Store, disableConfigWatch bool, usedPlatform b
 
 	notifyReady()
 
+	// Reload the TLS certificate on SIGHUP, e.g. after renewing it
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+	defer signal.Stop(reloadChan)
+
 	// wait for kill signal before attempting to gracefully shutdown
 	// the running service
 	signal.Notify(interruptChan, syscall.SIGINT, syscall.SIGTERM)
-	<-interruptChan
-
-	return nil
+	for {
+		select {
+		case <-reloadChan:
+			if err := server.ReloadCertificates(); err != nil {
+				mlog.Error("Failed to reload the TLS certificate", mlog.Err(err))
+			}
+		case <-interruptChan:
+			return nil
+		}
+	}
 }
 
 func notifyReady() {
@@ -5,320 +5,403 @@ package config
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 )
 
-// GenerateClientConfig renders the given configuration for a client.
-func GenerateClientConfig(c *model.Config, diagnosticID string, license *model.License) map[string]string {
-	props := GenerateLimitedClientConfig(c, diagnosticID, license)
-
-	props["SiteURL"] = strings.TrimRight(*c.ServiceSettings.SiteURL, "/")
-	props["EnableUserDeactivation"] = strconv.FormatBool(*c.TeamSettings.EnableUserDeactivation)
-	props["RestrictDirectMessage"] = *c.TeamSettings.RestrictDirectMessage
-	props["EnableXToLeaveChannelsFromLHS"] = strconv.FormatBool(*c.TeamSettings.EnableXToLeaveChannelsFromLHS)
-	props["TeammateNameDisplay"] = *c.TeamSettings.TeammateNameDisplay
-	props["LockTeammateNameDisplay"] = strconv.FormatBool(*c.TeamSettings.LockTeammateNameDisplay)
-	props["ExperimentalPrimaryTeam"] = *c.TeamSettings.ExperimentalPrimaryTeam
-	props["ExperimentalViewArchivedChannels"] = strconv.FormatBool(*c.TeamSettings.ExperimentalViewArchivedChannels)
-
-	props["EnableBotAccountCreation"] = strconv.FormatBool(*c.ServiceSettings.EnableBotAccountCreation)
-	props["EnableOAuthServiceProvider"] = strconv.FormatBool(*c.ServiceSettings.EnableOAuthServiceProvider)
-	props["GoogleDeveloperKey"] = *c.ServiceSettings.GoogleDeveloperKey
-	props["EnableIncomingWebhooks"] = strconv.FormatBool(*c.ServiceSettings.EnableIncomingWebhooks)
-	props["EnableOutgoingWebhooks"] = strconv.FormatBool(*c.ServiceSettings.EnableOutgoingWebhooks)
-	props["EnableCommands"] = strconv.FormatBool(*c.ServiceSettings.EnableCommands)
-	props["EnablePostUsernameOverride"] = strconv.FormatBool(*c.ServiceSettings.EnablePostUsernameOverride)
-	props["EnablePostIconOverride"] = strconv.FormatBool(*c.ServiceSettings.EnablePostIconOverride)
-	props["EnableUserAccessTokens"] = strconv.FormatBool(*c.ServiceSettings.EnableUserAccessTokens)
-	props["EnableLinkPreviews"] = strconv.FormatBool(*c.ServiceSettings.EnableLinkPreviews)
-	props["EnableTesting"] = strconv.FormatBool(*c.ServiceSettings.EnableTesting)
-	props["EnableDeveloper"] = strconv.FormatBool(*c.ServiceSettings.EnableDeveloper)
-	props["PostEditTimeLimit"] = fmt.Sprintf("%v", *c.ServiceSettings.PostEditTimeLimit)
-	props["MinimumHashtagLength"] = fmt.Sprintf("%v", *c.ServiceSettings.MinimumHashtagLength)
-	props["CloseUnusedDirectMessages"] = strconv.FormatBool(*c.ServiceSettings.CloseUnusedDirectMessages)
-	props["EnablePreviewFeatures"] = strconv.FormatBool(*c.ServiceSettings.EnablePreviewFeatures)
-	props["EnableTutorial"] = strconv.FormatBool(*c.ServiceSettings.EnableTutorial)
-	props["ExperimentalEnableDefaultChannelLeaveJoinMessages"] = strconv.FormatBool(*c.ServiceSettings.ExperimentalEnableDefaultChannelLeaveJoinMessages)
-	props["ExperimentalGroupUnreadChannels"] = *c.ServiceSettings.ExperimentalGroupUnreadChannels
-	props["EnableSVGs"] = strconv.FormatBool(*c.ServiceSettings.EnableSVGs)
-	props["EnableMarketplace"] = strconv.FormatBool(*c.PluginSettings.EnableMarketplace)
-	props["EnableLatex"] = strconv.FormatBool(*c.ServiceSettings.EnableLatex)
-	props["ExtendSessionLengthWithActivity"] = strconv.FormatBool(*c.ServiceSettings.ExtendSessionLengthWithActivity)
-
-	// This setting is only temporary, so keep using the old setting name for the mobile and web apps
-	props["ExperimentalEnablePostMetadata"] = "true"
-	props["ExperimentalEnableClickToReply"] = strconv.FormatBool(*c.ExperimentalSettings.EnableClickToReply)
-
-	if *c.ServiceSettings.ExperimentalChannelOrganization || *c.ServiceSettings.ExperimentalGroupUnreadChannels != model.GROUP_UNREAD_CHANNELS_DISABLED {
-		props["ExperimentalChannelOrganization"] = strconv.FormatBool(true)
-	} else {
-		props["ExperimentalChannelOrganization"] = strconv.FormatBool(false)
-	}
+// ClientConfigAudience is who a client config is rendered for. Each audience also receives the
+// fields of the audiences before it.
+type ClientConfigAudience int
+
+const (
+	// ClientConfigAudienceAnonymous is a client that hasn't logged in yet.
+	ClientConfigAudienceAnonymous ClientConfigAudience = iota
+	// ClientConfigAudienceUser is a logged in user.
+	ClientConfigAudienceUser
+	// ClientConfigAudienceAdmin is a logged in system admin.
+	ClientConfigAudienceAdmin
+)
 
-	props["ExperimentalChannelSidebarOrganization"] = *c.ServiceSettings.ExperimentalChannelSidebarOrganization
-	props["ExperimentalEnableAutomaticReplies"] = strconv.FormatBool(*c.TeamSettings.ExperimentalEnableAutomaticReplies)
-	props["ExperimentalTimezone"] = strconv.FormatBool(*c.DisplaySettings.ExperimentalTimezone)
-
-	props["ExperimentalDataPrefetch"] = strconv.FormatBool(*c.ServiceSettings.ExperimentalDataPrefetch)
-
-	props["SendEmailNotifications"] = strconv.FormatBool(*c.EmailSettings.SendEmailNotifications)
-	props["SendPushNotifications"] = strconv.FormatBool(*c.EmailSettings.SendPushNotifications)
-	props["RequireEmailVerification"] = strconv.FormatBool(*c.EmailSettings.RequireEmailVerification)
-	props["EnableEmailBatching"] = strconv.FormatBool(*c.EmailSettings.EnableEmailBatching)
-	props["EnablePreviewModeBanner"] = strconv.FormatBool(*c.EmailSettings.EnablePreviewModeBanner)
-	props["EmailNotificationContentsType"] = *c.EmailSettings.EmailNotificationContentsType
-
-	props["ShowEmailAddress"] = strconv.FormatBool(*c.PrivacySettings.ShowEmailAddress)
-	props["ShowFullName"] = strconv.FormatBool(*c.PrivacySettings.ShowFullName)
-
-	props["EnableFileAttachments"] = strconv.FormatBool(*c.FileSettings.EnableFileAttachments)
-	props["EnablePublicLink"] = strconv.FormatBool(*c.FileSettings.EnablePublicLink)
-
-	props["AvailableLocales"] = *c.LocalizationSettings.AvailableLocales
-	props["SQLDriverName"] = *c.SqlSettings.DriverName
-
-	props["EnableEmojiPicker"] = strconv.FormatBool(*c.ServiceSettings.EnableEmojiPicker)
-	props["EnableGifPicker"] = strconv.FormatBool(*c.ServiceSettings.EnableGifPicker)
-	props["GfycatApiKey"] = *c.ServiceSettings.GfycatApiKey
-	props["GfycatApiSecret"] = *c.ServiceSettings.GfycatApiSecret
-	props["MaxFileSize"] = strconv.FormatInt(*c.FileSettings.MaxFileSize, 10)
-
-	props["MaxNotificationsPerChannel"] = strconv.FormatInt(*c.TeamSettings.MaxNotificationsPerChannel, 10)
-	props["EnableConfirmNotificationsToChannel"] = strconv.FormatBool(*c.TeamSettings.EnableConfirmNotificationsToChannel)
-	props["TimeBetweenUserTypingUpdatesMilliseconds"] = strconv.FormatInt(*c.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds, 10)
-	props["EnableUserTypingMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableUserTypingMessages)
-	props["EnableChannelViewedMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableChannelViewedMessages)
-
-	props["RunJobs"] = strconv.FormatBool(*c.JobSettings.RunJobs)
-
-	props["EnableEmailInvitations"] = strconv.FormatBool(*c.ServiceSettings.EnableEmailInvitations)
-
-	// Set default values for all options that require a license.
-	props["ExperimentalHideTownSquareinLHS"] = "false"
-	props["ExperimentalTownSquareIsReadOnly"] = "false"
-	props["ExperimentalEnableAuthenticationTransfer"] = "true"
-	props["LdapNicknameAttributeSet"] = "false"
-	props["LdapFirstNameAttributeSet"] = "false"
-	props["LdapLastNameAttributeSet"] = "false"
-	props["LdapPictureAttributeSet"] = "false"
-	props["LdapPositionAttributeSet"] = "false"
-	props["EnableCompliance"] = "false"
-	props["EnableMobileFileDownload"] = "true"
-	props["EnableMobileFileUpload"] = "true"
-	props["SamlFirstNameAttributeSet"] = "false"
-	props["SamlLastNameAttributeSet"] = "false"
-	props["SamlNicknameAttributeSet"] = "false"
-	props["SamlPositionAttributeSet"] = "false"
-	props["EnableCluster"] = "false"
-	props["EnableMetrics"] = "false"
-	props["EnableBanner"] = "false"
-	props["BannerText"] = ""
-	props["BannerColor"] = ""
-	props["BannerTextColor"] = ""
-	props["AllowBannerDismissal"] = "false"
-	props["EnableThemeSelection"] = "true"
-	props["DefaultTheme"] = ""
-	props["AllowCustomThemes"] = "true"
-	props["AllowedThemes"] = ""
-	props["DataRetentionEnableMessageDeletion"] = "false"
-	props["DataRetentionMessageRetentionDays"] = "0"
-	props["DataRetentionEnableFileDeletion"] = "false"
-	props["DataRetentionFileRetentionDays"] = "0"
-	props["CustomUrlSchemes"] = strings.Join(c.DisplaySettings.CustomUrlSchemes, ",")
-	props["IsDefaultMarketplace"] = strconv.FormatBool(*c.PluginSettings.MarketplaceUrl == model.PLUGIN_SETTINGS_DEFAULT_MARKETPLACE_URL)
-
-	if license != nil {
-		props["ExperimentalHideTownSquareinLHS"] = strconv.FormatBool(*c.TeamSettings.ExperimentalHideTownSquareinLHS)
-		props["ExperimentalTownSquareIsReadOnly"] = strconv.FormatBool(*c.TeamSettings.ExperimentalTownSquareIsReadOnly)
-		props["ExperimentalEnableAuthenticationTransfer"] = strconv.FormatBool(*c.ServiceSettings.ExperimentalEnableAuthenticationTransfer)
-
-		if *license.Features.LDAP {
-			props["LdapNicknameAttributeSet"] = strconv.FormatBool(*c.LdapSettings.NicknameAttribute != "")
-			props["LdapFirstNameAttributeSet"] = strconv.FormatBool(*c.LdapSettings.FirstNameAttribute != "")
-			props["LdapLastNameAttributeSet"] = strconv.FormatBool(*c.LdapSettings.LastNameAttribute != "")
-			props["LdapPictureAttributeSet"] = strconv.FormatBool(*c.LdapSettings.PictureAttribute != "")
-			props["LdapPositionAttributeSet"] = strconv.FormatBool(*c.LdapSettings.PositionAttribute != "")
-		}
+// clientConfigField is a field of the client config.
+type clientConfigField struct {
+	// Audience is the first audience the field is sent to.
+	Audience ClientConfigAudience
 
-		if *license.Features.Compliance {
-			props["EnableCompliance"] = strconv.FormatBool(*c.ComplianceSettings.Enable)
-			props["EnableMobileFileDownload"] = strconv.FormatBool(*c.FileSettings.EnableMobileDownload)
-			props["EnableMobileFileUpload"] = strconv.FormatBool(*c.FileSettings.EnableMobileUpload)
-		}
+	// Setting is the path of the setting sent as is, e.g. TeamSettings.SiteName.
+	Setting string
 
-		if *license.Features.SAML {
-			props["SamlFirstNameAttributeSet"] = strconv.FormatBool(*c.SamlSettings.FirstNameAttribute != "")
-			props["SamlLastNameAttributeSet"] = strconv.FormatBool(*c.SamlSettings.LastNameAttribute != "")
-			props["SamlNicknameAttributeSet"] = strconv.FormatBool(*c.SamlSettings.NicknameAttribute != "")
-			props["SamlPositionAttributeSet"] = strconv.FormatBool(*c.SamlSettings.PositionAttribute != "")
+	// Value renders fields that aren't a single setting.
+	Value func(c *model.Config, diagnosticID string) string
 
-			// do this under the correct licensed feature
-			props["ExperimentalClientSideCertEnable"] = strconv.FormatBool(*c.ExperimentalSettings.ClientSideCertEnable)
-			props["ExperimentalClientSideCertCheck"] = *c.ExperimentalSettings.ClientSideCertCheck
-		}
+	// Licensed returns whether the license features allow the field. Unlicensed fields are sent
+	// with the Unlicensed value instead, or left out when OmitUnlicensed is set.
+	Licensed       func(features *model.Features) bool
+	Unlicensed     string
+	OmitUnlicensed bool
 
-		if *license.Features.Cluster {
-			props["EnableCluster"] = strconv.FormatBool(*c.ClusterSettings.Enable)
-		}
+	// Computed fields are filled in by the app layer, since they don't come from the config.
+	Computed bool
+}
 
-		if *license.Features.Cluster {
-			props["EnableMetrics"] = strconv.FormatBool(*c.MetricsSettings.Enable)
-		}
+// DeprecatedClientConfigFields are the fields clients no longer need, along with what replaces
+// them. They are still sent to clients, unless asked not to, until they are removed.
+var DeprecatedClientConfigFields = map[string]string{
+	"DiagnosticsEnabled":             "use EnableDiagnostics",
+	"ExperimentalEnablePostMetadata": "post metadata is always enabled",
+}
 
-		if *license.Features.Announcement {
-			props["EnableBanner"] = strconv.FormatBool(*c.AnnouncementSettings.EnableBanner)
-			props["BannerText"] = *c.AnnouncementSettings.BannerText
-			props["BannerColor"] = *c.AnnouncementSettings.BannerColor
-			props["BannerTextColor"] = *c.AnnouncementSettings.BannerTextColor
-			props["AllowBannerDismissal"] = strconv.FormatBool(*c.AnnouncementSettings.AllowBannerDismissal)
+func anyLicense(*model.Features) bool { return true }
+
+// clientConfigFields is the allowlist of the fields sent to clients, by name. A setting missing
+// from here is never exposed to clients. Logged in users additionally receive their feature flags
+// as a JSON map in FeatureFlags.
+var clientConfigFields = map[string]clientConfigField{
+	"Version":              {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.CurrentVersion }},
+	"BuildNumber":          {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.BuildNumber }},
+	"BuildDate":            {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.BuildDate }},
+	"BuildHash":            {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.BuildHash }},
+	"BuildHashEnterprise":  {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.BuildHashEnterprise }},
+	"BuildEnterpriseReady": {Audience: ClientConfigAudienceAnonymous, Value: func(*model.Config, string) string { return model.BuildEnterpriseReady }},
+
+	"SiteName": {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.SiteName"},
+	"WebsocketURL": {Audience: ClientConfigAudienceAnonymous, Value: func(c *model.Config, _ string) string {
+		return strings.TrimRight(*c.ServiceSettings.WebsocketURL, "/")
+	}},
+	"WebsocketPort":       {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.WebsocketPort"},
+	"WebsocketSecurePort": {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.WebsocketSecurePort"},
+	"EnableUserCreation":  {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.EnableUserCreation"},
+	"EnableOpenServer":    {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.EnableOpenServer"},
+
+	"AndroidLatestVersion": {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.AndroidLatestVersion"},
+	"AndroidMinVersion":    {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.AndroidMinVersion"},
+	"DesktopLatestVersion": {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.DesktopLatestVersion"},
+	"DesktopMinVersion":    {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.DesktopMinVersion"},
+	"IosLatestVersion":     {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.IosLatestVersion"},
+	"IosMinVersion":        {Audience: ClientConfigAudienceAnonymous, Setting: "ClientRequirements.IosMinVersion"},
+
+	"EnableDiagnostics":  {Audience: ClientConfigAudienceAnonymous, Setting: "LogSettings.EnableDiagnostics"},
+	"DiagnosticsEnabled": {Audience: ClientConfigAudienceAnonymous, Setting: "LogSettings.EnableDiagnostics"},
+	"DiagnosticId": {Audience: ClientConfigAudienceAnonymous, Value: func(_ *model.Config, diagnosticID string) string {
+		return diagnosticID
+	}},
+
+	"EnableSignUpWithEmail":       {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.EnableSignUpWithEmail"},
+	"EnableSignInWithEmail":       {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.EnableSignInWithEmail"},
+	"EnableSignInWithUsername":    {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.EnableSignInWithUsername"},
+	"EmailLoginButtonColor":       {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.LoginButtonColor"},
+	"EmailLoginButtonBorderColor": {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.LoginButtonBorderColor"},
+	"EmailLoginButtonTextColor":   {Audience: ClientConfigAudienceAnonymous, Setting: "EmailSettings.LoginButtonTextColor"},
+	"EnableSignUpWithGitLab":      {Audience: ClientConfigAudienceAnonymous, Setting: "GitLabSettings.Enable"},
+
+	"TermsOfServiceLink":     {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.TermsOfServiceLink"},
+	"PrivacyPolicyLink":      {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.PrivacyPolicyLink"},
+	"AboutLink":              {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.AboutLink"},
+	"HelpLink":               {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.HelpLink"},
+	"ReportAProblemLink":     {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.ReportAProblemLink"},
+	"SupportEmail":           {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.SupportEmail"},
+	"EnableAskCommunityLink": {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.EnableAskCommunityLink"},
+
+	"DefaultClientLocale":    {Audience: ClientConfigAudienceAnonymous, Setting: "LocalizationSettings.DefaultClientLocale"},
+	"EnableCustomEmoji":      {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.EnableCustomEmoji"},
+	"AppDownloadLink":        {Audience: ClientConfigAudienceAnonymous, Setting: "NativeAppSettings.AppDownloadLink"},
+	"AndroidAppDownloadLink": {Audience: ClientConfigAudienceAnonymous, Setting: "NativeAppSettings.AndroidAppDownloadLink"},
+	"IosAppDownloadLink":     {Audience: ClientConfigAudienceAnonymous, Setting: "NativeAppSettings.IosAppDownloadLink"},
+	"HasImageProxy":          {Audience: ClientConfigAudienceAnonymous, Setting: "ImageProxySettings.Enable"},
+	"PluginsEnabled":         {Audience: ClientConfigAudienceAnonymous, Setting: "PluginSettings.Enable"},
+
+	"PasswordMinimumLength":    {Audience: ClientConfigAudienceAnonymous, Setting: "PasswordSettings.MinimumLength"},
+	"PasswordRequireLowercase": {Audience: ClientConfigAudienceAnonymous, Setting: "PasswordSettings.Lowercase"},
+	"PasswordRequireUppercase": {Audience: ClientConfigAudienceAnonymous, Setting: "PasswordSettings.Uppercase"},
+	"PasswordRequireNumber":    {Audience: ClientConfigAudienceAnonymous, Setting: "PasswordSettings.Number"},
+	"PasswordRequireSymbol":    {Audience: ClientConfigAudienceAnonymous, Setting: "PasswordSettings.Symbol"},
+
+	"EnableCustomBrand":     {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.EnableCustomBrand"},
+	"CustomBrandText":       {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.CustomBrandText"},
+	"CustomDescriptionText": {Audience: ClientConfigAudienceAnonymous, Setting: "TeamSettings.CustomDescriptionText"},
+
+	"EnableLdap":                 {Audience: ClientConfigAudienceAnonymous, Setting: "LdapSettings.Enable", Licensed: ldapLicensed, Unlicensed: "false"},
+	"LdapLoginFieldName":         {Audience: ClientConfigAudienceAnonymous, Setting: "LdapSettings.LoginFieldName", Licensed: ldapLicensed},
+	"LdapLoginButtonColor":       {Audience: ClientConfigAudienceAnonymous, Setting: "LdapSettings.LoginButtonColor", Licensed: ldapLicensed},
+	"LdapLoginButtonBorderColor": {Audience: ClientConfigAudienceAnonymous, Setting: "LdapSettings.LoginButtonBorderColor", Licensed: ldapLicensed},
+	"LdapLoginButtonTextColor":   {Audience: ClientConfigAudienceAnonymous, Setting: "LdapSettings.LoginButtonTextColor", Licensed: ldapLicensed},
+
+	"EnableSaml":                 {Audience: ClientConfigAudienceAnonymous, Setting: "SamlSettings.Enable", Licensed: samlLicensed, Unlicensed: "false"},
+	"SamlLoginButtonText":        {Audience: ClientConfigAudienceAnonymous, Setting: "SamlSettings.LoginButtonText", Licensed: samlLicensed},
+	"SamlLoginButtonColor":       {Audience: ClientConfigAudienceAnonymous, Setting: "SamlSettings.LoginButtonColor", Licensed: samlLicensed},
+	"SamlLoginButtonBorderColor": {Audience: ClientConfigAudienceAnonymous, Setting: "SamlSettings.LoginButtonBorderColor", Licensed: samlLicensed},
+	"SamlLoginButtonTextColor":   {Audience: ClientConfigAudienceAnonymous, Setting: "SamlSettings.LoginButtonTextColor", Licensed: samlLicensed},
+
+	"EnableSignUpWithGoogle": {Audience: ClientConfigAudienceAnonymous, Setting: "GoogleSettings.Enable", Unlicensed: "false",
+		Licensed: func(f *model.Features) bool { return *f.GoogleOAuth }},
+	"EnableSignUpWithOffice365": {Audience: ClientConfigAudienceAnonymous, Setting: "Office365Settings.Enable", Unlicensed: "false",
+		Licensed: func(f *model.Features) bool { return *f.Office365OAuth }},
+
+	"EnableMultifactorAuthentication":          {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.EnableMultifactorAuthentication"},
+	"EnforceMultifactorAuthentication":         {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.EnforceMultifactorAuthentication", Licensed: mfaLicensed, Unlicensed: "false"},
+	"EnforceMultifactorAuthenticationForRoles": {Audience: ClientConfigAudienceAnonymous, Setting: "ServiceSettings.EnforceMultifactorAuthenticationForRoles", Licensed: mfaLicensed},
+
+	"EnableGuestAccounts":                           {Audience: ClientConfigAudienceAnonymous, Setting: "GuestAccountsSettings.Enable"},
+	"GuestAccountsEnforceMultifactorAuthentication": {Audience: ClientConfigAudienceAnonymous, Setting: "GuestAccountsSettings.EnforceMultifactorAuthentication"},
+
+	"EnableCustomTermsOfService":             {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.CustomTermsOfServiceEnabled", Licensed: customTermsOfServiceLicensed, OmitUnlicensed: true},
+	"CustomTermsOfServiceReAcceptancePeriod": {Audience: ClientConfigAudienceAnonymous, Setting: "SupportSettings.CustomTermsOfServiceReAcceptancePeriod", Licensed: customTermsOfServiceLicensed, OmitUnlicensed: true},
+	"CustomTermsOfServiceId":                 {Audience: ClientConfigAudienceAnonymous, Computed: true},
+	"AsymmetricSigningPublicKey":             {Audience: ClientConfigAudienceAnonymous, Computed: true},
+	"NoAccounts":                             {Audience: ClientConfigAudienceAnonymous, Computed: true},
+
+	"SiteURL": {Audience: ClientConfigAudienceUser, Value: func(c *model.Config, _ string) string {
+		return strings.TrimRight(*c.ServiceSettings.SiteURL, "/")
+	}},
+	"MaxPostSize":      {Audience: ClientConfigAudienceUser, Computed: true},
+	"InstallationDate": {Audience: ClientConfigAudienceUser, Computed: true},
+
+	"EnableUserDeactivation":           {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.EnableUserDeactivation"},
+	"RestrictDirectMessage":            {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.RestrictDirectMessage"},
+	"EnableXToLeaveChannelsFromLHS":    {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.EnableXToLeaveChannelsFromLHS"},
+	"TeammateNameDisplay":              {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.TeammateNameDisplay"},
+	"LockTeammateNameDisplay":          {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.LockTeammateNameDisplay"},
+	"DefaultSidebarSorting":            {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.DefaultSidebarSorting"},
+	"ExperimentalPrimaryTeam":          {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.ExperimentalPrimaryTeam"},
+	"ExperimentalViewArchivedChannels": {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.ExperimentalViewArchivedChannels"},
+
+	"EnableBotAccountCreation":   {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableBotAccountCreation"},
+	"EnableOAuthServiceProvider": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableOAuthServiceProvider"},
+	"GoogleDeveloperKey":         {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.GoogleDeveloperKey"},
+	"EnableIncomingWebhooks":     {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableIncomingWebhooks"},
+	"EnableOutgoingWebhooks":     {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableOutgoingWebhooks"},
+	"EnableCommands":             {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableCommands"},
+	"EnablePostUsernameOverride": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnablePostUsernameOverride"},
+	"EnablePostIconOverride":     {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnablePostIconOverride"},
+	"EnableUserAccessTokens":     {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableUserAccessTokens"},
+	"EnableLinkPreviews":         {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableLinkPreviews"},
+	"EnablePostShareTokens":      {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnablePostShareTokens"},
+	"EnableTesting":              {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableTesting"},
+	"EnableDeveloper":            {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableDeveloper"},
+	"PostEditTimeLimit":          {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.PostEditTimeLimit"},
+	"MaxReactionsBeforeCollapse": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.MaxReactionsBeforeCollapse"},
+	"MaxRepliesPerThread":        {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.MaxRepliesPerThread"},
+	"MinimumHashtagLength":       {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.MinimumHashtagLength"},
+	"CloseUnusedDirectMessages":  {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.CloseUnusedDirectMessages"},
+	"EnablePreviewFeatures":      {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnablePreviewFeatures"},
+	"EnableTutorial":             {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableTutorial"},
+	"EnableSVGs":                 {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableSVGs"},
+	"EnableLatex":                {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableLatex"},
+	"EnableMarketplace":          {Audience: ClientConfigAudienceUser, Setting: "PluginSettings.EnableMarketplace"},
+	"IsDefaultMarketplace": {Audience: ClientConfigAudienceUser, Value: func(c *model.Config, _ string) string {
+		return strconv.FormatBool(*c.PluginSettings.MarketplaceUrl == model.PLUGIN_SETTINGS_DEFAULT_MARKETPLACE_URL)
+	}},
+	"ExtendSessionLengthWithActivity": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExtendSessionLengthWithActivity"},
+
+	"ExperimentalEnableDefaultChannelLeaveJoinMessages": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExperimentalEnableDefaultChannelLeaveJoinMessages"},
+	"ExperimentalGroupUnreadChannels":                   {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExperimentalGroupUnreadChannels"},
+	"ExperimentalEnablePostMetadata":                    {Audience: ClientConfigAudienceUser, Value: func(*model.Config, string) string { return "true" }},
+	"ExperimentalEnableClickToReply":                    {Audience: ClientConfigAudienceUser, Setting: "ExperimentalSettings.EnableClickToReply"},
+	"ExperimentalChannelOrganization": {Audience: ClientConfigAudienceUser, Value: func(c *model.Config, _ string) string {
+		return strconv.FormatBool(*c.ServiceSettings.ExperimentalChannelOrganization || *c.ServiceSettings.ExperimentalGroupUnreadChannels != model.GROUP_UNREAD_CHANNELS_DISABLED)
+	}},
+	"ExperimentalChannelSidebarOrganization": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExperimentalChannelSidebarOrganization"},
+	"ExperimentalEnableAutomaticReplies":     {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.ExperimentalEnableAutomaticReplies"},
+	"ExperimentalTimezone":                   {Audience: ClientConfigAudienceUser, Setting: "DisplaySettings.ExperimentalTimezone"},
+	"ExperimentalDataPrefetch":               {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExperimentalDataPrefetch"},
+	"EnableExperimentalAppBarMenu":           {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableExperimentalAppBarMenu"},
+
+	"SendEmailNotifications":        {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.SendEmailNotifications"},
+	"SendPushNotifications":         {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.SendPushNotifications"},
+	"RequireEmailVerification":      {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.RequireEmailVerification"},
+	"EnableEmailBatching":           {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.EnableEmailBatching"},
+	"EnablePreviewModeBanner":       {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.EnablePreviewModeBanner"},
+	"EmailNotificationContentsType": {Audience: ClientConfigAudienceUser, Setting: "EmailSettings.EmailNotificationContentsType"},
+
+	"ShowEmailAddress": {Audience: ClientConfigAudienceUser, Setting: "PrivacySettings.ShowEmailAddress"},
+	"ShowFullName":     {Audience: ClientConfigAudienceUser, Setting: "PrivacySettings.ShowFullName"},
+
+	"EnableFileAttachments":           {Audience: ClientConfigAudienceUser, Setting: "FileSettings.EnableFileAttachments"},
+	"AdminsBypassDisabledAttachments": {Audience: ClientConfigAudienceUser, Setting: "FileSettings.AdminsBypassDisabledAttachments"},
+	"EnablePublicLink":                {Audience: ClientConfigAudienceUser, Setting: "FileSettings.EnablePublicLink"},
+	"MaxFileSize":                     {Audience: ClientConfigAudienceUser, Setting: "FileSettings.MaxFileSize"},
+	"AvailableLocales":                {Audience: ClientConfigAudienceUser, Setting: "LocalizationSettings.AvailableLocales"},
+
+	"EnableEmojiPicker":     {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableEmojiPicker"},
+	"DefaultEmojiSkinTone":  {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.DefaultEmojiSkinTone"},
+	"MaxCustomEmojiPerUser": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.MaxCustomEmojiPerUser"},
+	"EnableGifPicker":       {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableGifPicker"},
+	"GfycatApiKey":          {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.GfycatApiKey"},
+	"GfycatApiSecret":       {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.GfycatApiSecret"},
+
+	"MaxNotificationsPerChannel":               {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.MaxNotificationsPerChannel"},
+	"EnableConfirmNotificationsToChannel":      {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.EnableConfirmNotificationsToChannel"},
+	"UserStatusAwayTimeout":                    {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.UserStatusAwayTimeout"},
+	"TimeBetweenUserTypingUpdatesMilliseconds": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds"},
+	"EnableUserTypingMessages":                 {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableUserTypingMessages"},
+	"EnableChannelViewedMessages":              {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableChannelViewedMessages"},
+	"EnableEmailInvitations":                   {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.EnableEmailInvitations"},
+	"CustomUrlSchemes":                         {Audience: ClientConfigAudienceUser, Setting: "DisplaySettings.CustomUrlSchemes"},
+
+	"ExperimentalHideTownSquareinLHS":          {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.ExperimentalHideTownSquareinLHS", Licensed: anyLicense, Unlicensed: "false"},
+	"ExperimentalTownSquareIsReadOnly":         {Audience: ClientConfigAudienceUser, Setting: "TeamSettings.ExperimentalTownSquareIsReadOnly", Licensed: anyLicense, Unlicensed: "false"},
+	"ExperimentalEnableAuthenticationTransfer": {Audience: ClientConfigAudienceUser, Setting: "ServiceSettings.ExperimentalEnableAuthenticationTransfer", Licensed: anyLicense, Unlicensed: "true"},
+
+	"LdapNicknameAttributeSet":  {Audience: ClientConfigAudienceUser, Value: settingIsSet("LdapSettings.NicknameAttribute"), Licensed: ldapLicensed, Unlicensed: "false"},
+	"LdapFirstNameAttributeSet": {Audience: ClientConfigAudienceUser, Value: settingIsSet("LdapSettings.FirstNameAttribute"), Licensed: ldapLicensed, Unlicensed: "false"},
+	"LdapLastNameAttributeSet":  {Audience: ClientConfigAudienceUser, Value: settingIsSet("LdapSettings.LastNameAttribute"), Licensed: ldapLicensed, Unlicensed: "false"},
+	"LdapPictureAttributeSet":   {Audience: ClientConfigAudienceUser, Value: settingIsSet("LdapSettings.PictureAttribute"), Licensed: ldapLicensed, Unlicensed: "false"},
+	"LdapPositionAttributeSet":  {Audience: ClientConfigAudienceUser, Value: settingIsSet("LdapSettings.PositionAttribute"), Licensed: ldapLicensed, Unlicensed: "false"},
+
+	"EnableCompliance":         {Audience: ClientConfigAudienceUser, Setting: "ComplianceSettings.Enable", Licensed: complianceLicensed, Unlicensed: "false"},
+	"EnableMobileFileDownload": {Audience: ClientConfigAudienceUser, Setting: "FileSettings.EnableMobileDownload", Licensed: complianceLicensed, Unlicensed: "true"},
+	"EnableMobileFileUpload":   {Audience: ClientConfigAudienceUser, Setting: "FileSettings.EnableMobileUpload", Licensed: complianceLicensed, Unlicensed: "true"},
+
+	"SamlFirstNameAttributeSet":        {Audience: ClientConfigAudienceUser, Value: settingIsSet("SamlSettings.FirstNameAttribute"), Licensed: samlLicensed, Unlicensed: "false"},
+	"SamlLastNameAttributeSet":         {Audience: ClientConfigAudienceUser, Value: settingIsSet("SamlSettings.LastNameAttribute"), Licensed: samlLicensed, Unlicensed: "false"},
+	"SamlNicknameAttributeSet":         {Audience: ClientConfigAudienceUser, Value: settingIsSet("SamlSettings.NicknameAttribute"), Licensed: samlLicensed, Unlicensed: "false"},
+	"SamlPositionAttributeSet":         {Audience: ClientConfigAudienceUser, Value: settingIsSet("SamlSettings.PositionAttribute"), Licensed: samlLicensed, Unlicensed: "false"},
+	"ExperimentalClientSideCertEnable": {Audience: ClientConfigAudienceUser, Setting: "ExperimentalSettings.ClientSideCertEnable", Licensed: samlLicensed, OmitUnlicensed: true},
+	"ExperimentalClientSideCertCheck":  {Audience: ClientConfigAudienceUser, Setting: "ExperimentalSettings.ClientSideCertCheck", Licensed: samlLicensed, OmitUnlicensed: true},
+
+	"EnableBanner":         {Audience: ClientConfigAudienceUser, Setting: "AnnouncementSettings.EnableBanner", Licensed: announcementLicensed, Unlicensed: "false"},
+	"BannerText":           {Audience: ClientConfigAudienceUser, Setting: "AnnouncementSettings.BannerText", Licensed: announcementLicensed},
+	"BannerColor":          {Audience: ClientConfigAudienceUser, Setting: "AnnouncementSettings.BannerColor", Licensed: announcementLicensed},
+	"BannerTextColor":      {Audience: ClientConfigAudienceUser, Setting: "AnnouncementSettings.BannerTextColor", Licensed: announcementLicensed},
+	"AllowBannerDismissal": {Audience: ClientConfigAudienceUser, Setting: "AnnouncementSettings.AllowBannerDismissal", Licensed: announcementLicensed, Unlicensed: "false"},
+
+	"EnableThemeSelection": {Audience: ClientConfigAudienceUser, Setting: "ThemeSettings.EnableThemeSelection", Licensed: themeManagementLicensed, Unlicensed: "true"},
+	"DefaultTheme":         {Audience: ClientConfigAudienceUser, Setting: "ThemeSettings.DefaultTheme", Licensed: themeManagementLicensed},
+	"AllowCustomThemes":    {Audience: ClientConfigAudienceUser, Setting: "ThemeSettings.AllowCustomThemes", Licensed: themeManagementLicensed, Unlicensed: "true"},
+	"AllowedThemes":        {Audience: ClientConfigAudienceUser, Setting: "ThemeSettings.AllowedThemes", Licensed: themeManagementLicensed},
+
+	"DataRetentionEnableMessageDeletion": {Audience: ClientConfigAudienceUser, Setting: "DataRetentionSettings.EnableMessageDeletion", Licensed: dataRetentionLicensed, Unlicensed: "false"},
+	"DataRetentionMessageRetentionDays":  {Audience: ClientConfigAudienceUser, Setting: "DataRetentionSettings.MessageRetentionDays", Licensed: dataRetentionLicensed, Unlicensed: "0"},
+	"DataRetentionEnableFileDeletion":    {Audience: ClientConfigAudienceUser, Setting: "DataRetentionSettings.EnableFileDeletion", Licensed: dataRetentionLicensed, Unlicensed: "false"},
+	"DataRetentionFileRetentionDays":     {Audience: ClientConfigAudienceUser, Setting: "DataRetentionSettings.FileRetentionDays", Licensed: dataRetentionLicensed, Unlicensed: "0"},
+
+	// These describe the deployment itself, which only system admins need to know about.
+	"SQLDriverName": {Audience: ClientConfigAudienceAdmin, Setting: "SqlSettings.DriverName"},
+	"RunJobs":       {Audience: ClientConfigAudienceAdmin, Setting: "JobSettings.RunJobs"},
+	"EnableCluster": {Audience: ClientConfigAudienceAdmin, Setting: "ClusterSettings.Enable", Licensed: clusterLicensed, Unlicensed: "false"},
+	"EnableMetrics": {Audience: ClientConfigAudienceAdmin, Setting: "MetricsSettings.Enable", Licensed: clusterLicensed, Unlicensed: "false"},
+}
+
+func ldapLicensed(f *model.Features) bool                 { return *f.LDAP }
+func samlLicensed(f *model.Features) bool                 { return *f.SAML }
+func mfaLicensed(f *model.Features) bool                  { return *f.MFA }
+func complianceLicensed(f *model.Features) bool           { return *f.Compliance }
+func clusterLicensed(f *model.Features) bool              { return *f.Cluster }
+func announcementLicensed(f *model.Features) bool         { return *f.Announcement }
+func themeManagementLicensed(f *model.Features) bool      { return *f.ThemeManagement }
+func dataRetentionLicensed(f *model.Features) bool        { return *f.DataRetention }
+func customTermsOfServiceLicensed(f *model.Features) bool { return *f.CustomTermsOfService }
+
+// settingIsSet renders whether the given string setting is set.
+func settingIsSet(setting string) func(c *model.Config, diagnosticID string) string {
+	return func(c *model.Config, _ string) string {
+		return strconv.FormatBool(renderClientConfigSetting(c, setting) != "")
+	}
+}
+
+// renderClientConfigSetting renders the setting at the given path the way clients expect it:
+// booleans and numbers as text and lists joined by commas.
+func renderClientConfigSetting(c *model.Config, setting string) string {
+	v := reflect.ValueOf(c).Elem()
+	for _, name := range strings.Split(setting, ".") {
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			panic(fmt.Sprintf("unknown client config setting %s", setting))
 		}
+	}
 
-		if *license.Features.ThemeManagement {
-			props["EnableThemeSelection"] = strconv.FormatBool(*c.ThemeSettings.EnableThemeSelection)
-			props["DefaultTheme"] = *c.ThemeSettings.DefaultTheme
-			props["AllowCustomThemes"] = strconv.FormatBool(*c.ThemeSettings.AllowCustomThemes)
-			props["AllowedThemes"] = strings.Join(c.ThemeSettings.AllowedThemes, ",")
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
 		}
+		v = v.Elem()
+	}
 
-		if *license.Features.DataRetention {
-			props["DataRetentionEnableMessageDeletion"] = strconv.FormatBool(*c.DataRetentionSettings.EnableMessageDeletion)
-			props["DataRetentionMessageRetentionDays"] = strconv.FormatInt(int64(*c.DataRetentionSettings.MessageRetentionDays), 10)
-			props["DataRetentionEnableFileDeletion"] = strconv.FormatBool(*c.DataRetentionSettings.EnableFileDeletion)
-			props["DataRetentionFileRetentionDays"] = strconv.FormatInt(int64(*c.DataRetentionSettings.FileRetentionDays), 10)
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).String()
 		}
+		return strings.Join(values, ",")
 	}
 
-	return props
+	panic(fmt.Sprintf("unsupported client config setting %s of kind %s", setting, v.Kind()))
+}
+
+func (f clientConfigField) render(c *model.Config, diagnosticID string, license *model.License) (string, bool) {
+	if f.Licensed != nil && (license == nil || !f.Licensed(license.Features)) {
+		return f.Unlicensed, !f.OmitUnlicensed
+	}
+
+	if f.Value != nil {
+		return f.Value(c, diagnosticID), true
+	}
+
+	return renderClientConfigSetting(c, f.Setting), true
 }
 
-// GenerateLimitedClientConfig renders the given configuration for an untrusted client.
-func GenerateLimitedClientConfig(c *model.Config, diagnosticID string, license *model.License) map[string]string {
+// GenerateClientConfig renders the given configuration for the given audience, leaving out
+// deprecated fields and fields computed by the app layer.
+func GenerateClientConfig(c *model.Config, diagnosticID string, license *model.License, audience ClientConfigAudience) map[string]string {
 	props := make(map[string]string)
 
-	props["Version"] = model.CurrentVersion
-	props["BuildNumber"] = model.BuildNumber
-	props["BuildDate"] = model.BuildDate
-	props["BuildHash"] = model.BuildHash
-	props["BuildHashEnterprise"] = model.BuildHashEnterprise
-	props["BuildEnterpriseReady"] = model.BuildEnterpriseReady
-
-	props["EnableBotAccountCreation"] = strconv.FormatBool(*c.ServiceSettings.EnableBotAccountCreation)
-
-	props["SiteName"] = *c.TeamSettings.SiteName
-	props["WebsocketURL"] = strings.TrimRight(*c.ServiceSettings.WebsocketURL, "/")
-	props["WebsocketPort"] = fmt.Sprintf("%v", *c.ServiceSettings.WebsocketPort)
-	props["WebsocketSecurePort"] = fmt.Sprintf("%v", *c.ServiceSettings.WebsocketSecurePort)
-	props["EnableUserCreation"] = strconv.FormatBool(*c.TeamSettings.EnableUserCreation)
-	props["EnableOpenServer"] = strconv.FormatBool(*c.TeamSettings.EnableOpenServer)
-
-	props["AndroidLatestVersion"] = c.ClientRequirements.AndroidLatestVersion
-	props["AndroidMinVersion"] = c.ClientRequirements.AndroidMinVersion
-	props["DesktopLatestVersion"] = c.ClientRequirements.DesktopLatestVersion
-	props["DesktopMinVersion"] = c.ClientRequirements.DesktopMinVersion
-	props["IosLatestVersion"] = c.ClientRequirements.IosLatestVersion
-	props["IosMinVersion"] = c.ClientRequirements.IosMinVersion
-
-	props["EnableDiagnostics"] = strconv.FormatBool(*c.LogSettings.EnableDiagnostics)
-
-	props["EnableSignUpWithEmail"] = strconv.FormatBool(*c.EmailSettings.EnableSignUpWithEmail)
-	props["EnableSignInWithEmail"] = strconv.FormatBool(*c.EmailSettings.EnableSignInWithEmail)
-	props["EnableSignInWithUsername"] = strconv.FormatBool(*c.EmailSettings.EnableSignInWithUsername)
-
-	props["EmailLoginButtonColor"] = *c.EmailSettings.LoginButtonColor
-	props["EmailLoginButtonBorderColor"] = *c.EmailSettings.LoginButtonBorderColor
-	props["EmailLoginButtonTextColor"] = *c.EmailSettings.LoginButtonTextColor
-
-	props["EnableSignUpWithGitLab"] = strconv.FormatBool(*c.GitLabSettings.Enable)
-
-	props["TermsOfServiceLink"] = *c.SupportSettings.TermsOfServiceLink
-	props["PrivacyPolicyLink"] = *c.SupportSettings.PrivacyPolicyLink
-	props["AboutLink"] = *c.SupportSettings.AboutLink
-	props["HelpLink"] = *c.SupportSettings.HelpLink
-	props["ReportAProblemLink"] = *c.SupportSettings.ReportAProblemLink
-	props["SupportEmail"] = *c.SupportSettings.SupportEmail
-	props["EnableAskCommunityLink"] = strconv.FormatBool(*c.SupportSettings.EnableAskCommunityLink)
-
-	props["DefaultClientLocale"] = *c.LocalizationSettings.DefaultClientLocale
-
-	props["EnableCustomEmoji"] = strconv.FormatBool(*c.ServiceSettings.EnableCustomEmoji)
-	props["AppDownloadLink"] = *c.NativeAppSettings.AppDownloadLink
-	props["AndroidAppDownloadLink"] = *c.NativeAppSettings.AndroidAppDownloadLink
-	props["IosAppDownloadLink"] = *c.NativeAppSettings.IosAppDownloadLink
-
-	props["DiagnosticId"] = diagnosticID
-	props["DiagnosticsEnabled"] = strconv.FormatBool(*c.LogSettings.EnableDiagnostics)
-
-	props["HasImageProxy"] = strconv.FormatBool(*c.ImageProxySettings.Enable)
-
-	props["PluginsEnabled"] = strconv.FormatBool(*c.PluginSettings.Enable)
-
-	props["PasswordMinimumLength"] = fmt.Sprintf("%v", *c.PasswordSettings.MinimumLength)
-	props["PasswordRequireLowercase"] = strconv.FormatBool(*c.PasswordSettings.Lowercase)
-	props["PasswordRequireUppercase"] = strconv.FormatBool(*c.PasswordSettings.Uppercase)
-	props["PasswordRequireNumber"] = strconv.FormatBool(*c.PasswordSettings.Number)
-	props["PasswordRequireSymbol"] = strconv.FormatBool(*c.PasswordSettings.Symbol)
-
-	// Set default values for all options that require a license.
-	props["EnableCustomBrand"] = "false"
-	props["CustomBrandText"] = ""
-	props["CustomDescriptionText"] = ""
-	props["EnableLdap"] = "false"
-	props["LdapLoginFieldName"] = ""
-	props["LdapLoginButtonColor"] = ""
-	props["LdapLoginButtonBorderColor"] = ""
-	props["LdapLoginButtonTextColor"] = ""
-	props["EnableSaml"] = "false"
-	props["SamlLoginButtonText"] = ""
-	props["SamlLoginButtonColor"] = ""
-	props["SamlLoginButtonBorderColor"] = ""
-	props["SamlLoginButtonTextColor"] = ""
-	props["EnableSignUpWithGoogle"] = "false"
-	props["EnableSignUpWithOffice365"] = "false"
-	props["EnableCustomBrand"] = strconv.FormatBool(*c.TeamSettings.EnableCustomBrand)
-	props["CustomBrandText"] = *c.TeamSettings.CustomBrandText
-	props["CustomDescriptionText"] = *c.TeamSettings.CustomDescriptionText
-	props["EnableMultifactorAuthentication"] = strconv.FormatBool(*c.ServiceSettings.EnableMultifactorAuthentication)
-	props["EnforceMultifactorAuthentication"] = "false"
-	props["EnableGuestAccounts"] = strconv.FormatBool(*c.GuestAccountsSettings.Enable)
-	props["GuestAccountsEnforceMultifactorAuthentication"] = strconv.FormatBool(*c.GuestAccountsSettings.EnforceMultifactorAuthentication)
-
-	if license != nil {
-		if *license.Features.LDAP {
-			props["EnableLdap"] = strconv.FormatBool(*c.LdapSettings.Enable)
-			props["LdapLoginFieldName"] = *c.LdapSettings.LoginFieldName
-			props["LdapLoginButtonColor"] = *c.LdapSettings.LoginButtonColor
-			props["LdapLoginButtonBorderColor"] = *c.LdapSettings.LoginButtonBorderColor
-			props["LdapLoginButtonTextColor"] = *c.LdapSettings.LoginButtonTextColor
+	for name, field := range clientConfigFields {
+		if field.Audience > audience || field.Computed {
+			continue
 		}
-
-		if *license.Features.SAML {
-			props["EnableSaml"] = strconv.FormatBool(*c.SamlSettings.Enable)
-			props["SamlLoginButtonText"] = *c.SamlSettings.LoginButtonText
-			props["SamlLoginButtonColor"] = *c.SamlSettings.LoginButtonColor
-			props["SamlLoginButtonBorderColor"] = *c.SamlSettings.LoginButtonBorderColor
-			props["SamlLoginButtonTextColor"] = *c.SamlSettings.LoginButtonTextColor
+		if _, deprecated := DeprecatedClientConfigFields[name]; deprecated {
+			continue
 		}
 
-		if *license.Features.GoogleOAuth {
-			props["EnableSignUpWithGoogle"] = strconv.FormatBool(*c.GoogleSettings.Enable)
+		if value, ok := field.render(c, diagnosticID, license); ok {
+			props[name] = value
 		}
+	}
 
-		if *license.Features.Office365OAuth {
-			props["EnableSignUpWithOffice365"] = strconv.FormatBool(*c.Office365Settings.Enable)
-		}
+	return props
+}
+
+// GenerateDeprecatedClientConfig renders the deprecated fields of the given configuration for the
+// given audience, for the clients still asking for them.
+func GenerateDeprecatedClientConfig(c *model.Config, diagnosticID string, license *model.License, audience ClientConfigAudience) map[string]string {
+	props := make(map[string]string)
 
-		if *license.Features.CustomTermsOfService {
-			props["EnableCustomTermsOfService"] = strconv.FormatBool(*c.SupportSettings.CustomTermsOfServiceEnabled)
-			props["CustomTermsOfServiceReAcceptancePeriod"] = strconv.FormatInt(int64(*c.SupportSettings.CustomTermsOfServiceReAcceptancePeriod), 10)
+	for name := range DeprecatedClientConfigFields {
+		field := clientConfigFields[name]
+		if field.Audience > audience {
+			continue
 		}
 
-		if *license.Features.MFA {
-			props["EnforceMultifactorAuthentication"] = strconv.FormatBool(*c.ServiceSettings.EnforceMultifactorAuthentication)
+		if value, ok := field.render(c, diagnosticID, license); ok {
+			props[name] = value
 		}
 	}
 
 	return props
 }
+
+// ClientConfigFieldNames returns the names of the fields the given audience may receive, including
+// the fields computed by the app layer but excluding deprecated fields, sorted by name.
+func ClientConfigFieldNames(audience ClientConfigAudience) []string {
+	names := []string{}
+	for name, field := range clientConfigFields {
+		if _, deprecated := DeprecatedClientConfigFields[name]; deprecated || field.Audience > audience {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -48,6 +48,7 @@ func TestGetClientConfig(t *testing.T) {
 				"WebsocketURL":                     "ws://mattermost.example.com:8065",
 				"WebsocketPort":                    "80",
 				"WebsocketSecurePort":              "443",
+				"MaxReactionsBeforeCollapse":       "0",
 			},
 		},
 		{
@@ -178,6 +179,50 @@ func TestGetClientConfig(t *testing.T) {
 				"ShowFullName": "true",
 			},
 		},
+		{
+			"default user status away timeout",
+			&model.Config{},
+			"tag1",
+			nil,
+			map[string]string{
+				"UserStatusAwayTimeout": "300",
+			},
+		},
+		{
+			"user status away timeout",
+			&model.Config{
+				TeamSettings: model.TeamSettings{
+					UserStatusAwayTimeout: model.NewInt64(600),
+				},
+			},
+			"tag1",
+			nil,
+			map[string]string{
+				"UserStatusAwayTimeout": "600",
+			},
+		},
+		{
+			"experimental app bar menu disabled by default",
+			&model.Config{},
+			"tag1",
+			nil,
+			map[string]string{
+				"EnableExperimentalAppBarMenu": "false",
+			},
+		},
+		{
+			"experimental app bar menu enabled",
+			&model.Config{
+				ServiceSettings: model.ServiceSettings{
+					EnableExperimentalAppBarMenu: bToP(true),
+				},
+			},
+			"tag1",
+			nil,
+			map[string]string{
+				"EnableExperimentalAppBarMenu": "true",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -190,7 +235,7 @@ func TestGetClientConfig(t *testing.T) {
 				testCase.license.Features.SetDefaults()
 			}
 
-			configMap := config.GenerateClientConfig(testCase.config, testCase.diagnosticID, testCase.license)
+			configMap := config.GenerateClientConfig(testCase.config, testCase.diagnosticID, testCase.license, config.ClientConfigAudienceUser)
 			for expectedField, expectedValue := range testCase.expectedFields {
 				actualValue, ok := configMap[expectedField]
 				if assert.True(t, ok, fmt.Sprintf("config does not contain %v", expectedField)) {
@@ -269,7 +314,7 @@ func TestGetLimitedClientConfig(t *testing.T) {
 				testCase.license.Features.SetDefaults()
 			}
 
-			configMap := config.GenerateLimitedClientConfig(testCase.config, testCase.diagnosticID, testCase.license)
+			configMap := config.GenerateClientConfig(testCase.config, testCase.diagnosticID, testCase.license, config.ClientConfigAudienceAnonymous)
 			for expectedField, expectedValue := range testCase.expectedFields {
 				actualValue, ok := configMap[expectedField]
 				if assert.True(t, ok, fmt.Sprintf("config does not contain %v", expectedField)) {
@@ -280,6 +325,125 @@ func TestGetLimitedClientConfig(t *testing.T) {
 	}
 }
 
+func TestClientConfigAudiences(t *testing.T) {
+	t.Parallel()
+
+	// Every field exposed to clients must be listed here on purpose, along with the first audience
+	// receiving it. Computed fields are filled in by the app layer.
+	anonymousFields := []string{
+		"AboutLink", "AndroidAppDownloadLink", "AndroidLatestVersion", "AndroidMinVersion", "AppDownloadLink",
+		"BuildDate", "BuildEnterpriseReady", "BuildHash", "BuildHashEnterprise", "BuildNumber",
+		"CustomBrandText", "CustomDescriptionText", "CustomTermsOfServiceReAcceptancePeriod",
+		"DefaultClientLocale", "DesktopLatestVersion", "DesktopMinVersion", "DiagnosticId",
+		"EmailLoginButtonBorderColor", "EmailLoginButtonColor", "EmailLoginButtonTextColor",
+		"EnableAskCommunityLink", "EnableCustomBrand", "EnableCustomEmoji", "EnableCustomTermsOfService",
+		"EnableDiagnostics", "EnableGuestAccounts", "EnableLdap", "EnableMultifactorAuthentication",
+		"EnableOpenServer", "EnableSaml", "EnableSignInWithEmail", "EnableSignInWithUsername",
+		"EnableSignUpWithEmail", "EnableSignUpWithGitLab", "EnableSignUpWithGoogle", "EnableSignUpWithOffice365",
+		"EnableUserCreation", "EnforceMultifactorAuthentication", "EnforceMultifactorAuthenticationForRoles",
+		"GuestAccountsEnforceMultifactorAuthentication", "HasImageProxy", "HelpLink",
+		"IosAppDownloadLink", "IosLatestVersion", "IosMinVersion",
+		"LdapLoginButtonBorderColor", "LdapLoginButtonColor", "LdapLoginButtonTextColor", "LdapLoginFieldName",
+		"PasswordMinimumLength", "PasswordRequireLowercase", "PasswordRequireNumber", "PasswordRequireSymbol",
+		"PasswordRequireUppercase", "PluginsEnabled", "PrivacyPolicyLink", "ReportAProblemLink",
+		"SamlLoginButtonBorderColor", "SamlLoginButtonColor", "SamlLoginButtonText", "SamlLoginButtonTextColor",
+		"SiteName", "SupportEmail", "TermsOfServiceLink", "Version",
+		"WebsocketPort", "WebsocketSecurePort", "WebsocketURL",
+	}
+	anonymousComputedFields := []string{"AsymmetricSigningPublicKey", "CustomTermsOfServiceId", "NoAccounts"}
+
+	userFields := []string{
+		"AdminsBypassDisabledAttachments", "AllowBannerDismissal", "AllowCustomThemes", "AllowedThemes",
+		"AvailableLocales", "BannerColor", "BannerText", "BannerTextColor", "CloseUnusedDirectMessages",
+		"CustomUrlSchemes", "DataRetentionEnableFileDeletion", "DataRetentionEnableMessageDeletion",
+		"DataRetentionFileRetentionDays", "DataRetentionMessageRetentionDays", "DefaultEmojiSkinTone",
+		"DefaultSidebarSorting", "DefaultTheme", "EmailNotificationContentsType", "EnableBanner",
+		"EnableBotAccountCreation", "EnableChannelViewedMessages", "EnableCommands", "EnableCompliance",
+		"EnableConfirmNotificationsToChannel", "EnableDeveloper", "EnableEmailBatching", "EnableEmailInvitations",
+		"EnableEmojiPicker", "EnableExperimentalAppBarMenu", "EnableFileAttachments", "EnableGifPicker",
+		"EnableIncomingWebhooks", "EnableLatex", "EnableLinkPreviews", "EnableMarketplace",
+		"EnableMobileFileDownload", "EnableMobileFileUpload", "EnableOAuthServiceProvider", "EnableOutgoingWebhooks",
+		"EnablePostIconOverride", "EnablePostShareTokens", "EnablePostUsernameOverride", "EnablePreviewFeatures",
+		"EnablePreviewModeBanner", "EnablePublicLink", "EnableSVGs", "EnableTesting", "EnableThemeSelection",
+		"EnableTutorial", "EnableUserAccessTokens", "EnableUserDeactivation", "EnableUserTypingMessages",
+		"EnableXToLeaveChannelsFromLHS", "ExperimentalChannelOrganization", "ExperimentalChannelSidebarOrganization",
+		"ExperimentalClientSideCertCheck", "ExperimentalClientSideCertEnable", "ExperimentalDataPrefetch",
+		"ExperimentalEnableAuthenticationTransfer", "ExperimentalEnableAutomaticReplies",
+		"ExperimentalEnableClickToReply", "ExperimentalEnableDefaultChannelLeaveJoinMessages",
+		"ExperimentalGroupUnreadChannels", "ExperimentalHideTownSquareinLHS", "ExperimentalPrimaryTeam",
+		"ExperimentalTimezone", "ExperimentalTownSquareIsReadOnly", "ExperimentalViewArchivedChannels",
+		"ExtendSessionLengthWithActivity", "GfycatApiKey", "GfycatApiSecret", "GoogleDeveloperKey",
+		"IsDefaultMarketplace", "LdapFirstNameAttributeSet", "LdapLastNameAttributeSet", "LdapNicknameAttributeSet",
+		"LdapPictureAttributeSet", "LdapPositionAttributeSet", "LockTeammateNameDisplay", "MaxCustomEmojiPerUser",
+		"MaxFileSize", "MaxNotificationsPerChannel", "MaxReactionsBeforeCollapse", "MaxRepliesPerThread",
+		"MinimumHashtagLength", "PostEditTimeLimit", "RequireEmailVerification", "RestrictDirectMessage",
+		"SamlFirstNameAttributeSet", "SamlLastNameAttributeSet", "SamlNicknameAttributeSet",
+		"SamlPositionAttributeSet", "SendEmailNotifications", "SendPushNotifications", "ShowEmailAddress",
+		"ShowFullName", "SiteURL", "TeammateNameDisplay", "TimeBetweenUserTypingUpdatesMilliseconds",
+		"UserStatusAwayTimeout",
+	}
+	userComputedFields := []string{"InstallationDate", "MaxPostSize"}
+
+	adminFields := []string{"EnableCluster", "EnableMetrics", "RunJobs", "SQLDriverName"}
+
+	testCases := []struct {
+		audience       config.ClientConfigAudience
+		fields         []string
+		computedFields []string
+	}{
+		{config.ClientConfigAudienceAnonymous, anonymousFields, anonymousComputedFields},
+		{config.ClientConfigAudienceUser, append(append([]string{}, anonymousFields...), userFields...), append(append([]string{}, anonymousComputedFields...), userComputedFields...)},
+		{config.ClientConfigAudienceAdmin, append(append(append([]string{}, anonymousFields...), userFields...), adminFields...), append(append([]string{}, anonymousComputedFields...), userComputedFields...)},
+	}
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	license := &model.License{Features: &model.Features{}}
+	license.Features.SetDefaults()
+
+	for _, testCase := range testCases {
+		configMap := config.GenerateClientConfig(cfg, "tag1", license, testCase.audience)
+
+		fields := []string{}
+		for field := range configMap {
+			fields = append(fields, field)
+		}
+		assert.ElementsMatch(t, testCase.fields, fields, "audience %d", testCase.audience)
+
+		assert.ElementsMatch(t, append(append([]string{}, testCase.fields...), testCase.computedFields...), config.ClientConfigFieldNames(testCase.audience), "audience %d", testCase.audience)
+	}
+
+	t.Run("unlicensed fields are left out or defaulted", func(t *testing.T) {
+		configMap := config.GenerateClientConfig(cfg, "tag1", nil, config.ClientConfigAudienceAdmin)
+
+		assert.NotContains(t, configMap, "EnableCustomTermsOfService")
+		assert.NotContains(t, configMap, "ExperimentalClientSideCertEnable")
+		assert.Equal(t, "false", configMap["EnableCluster"])
+		assert.Equal(t, "", configMap["AllowedThemes"])
+	})
+}
+
+func TestGetDeprecatedClientConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	for name := range config.DeprecatedClientConfigFields {
+		assert.NotContains(t, config.GenerateClientConfig(cfg, "", nil, config.ClientConfigAudienceAdmin), name)
+		assert.NotContains(t, config.ClientConfigFieldNames(config.ClientConfigAudienceAdmin), name)
+	}
+
+	assert.Equal(t, map[string]string{
+		"DiagnosticsEnabled": "true",
+	}, config.GenerateDeprecatedClientConfig(cfg, "", nil, config.ClientConfigAudienceAnonymous))
+
+	assert.Equal(t, map[string]string{
+		"DiagnosticsEnabled":             "true",
+		"ExperimentalEnablePostMetadata": "true",
+	}, config.GenerateDeprecatedClientConfig(cfg, "", nil, config.ClientConfigAudienceUser))
+}
+
 func sToP(s string) *string {
 	return &s
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
 )
@@ -112,6 +113,20 @@ func (cs *commonStore) load(f io.ReadCloser, needsSave bool, validate func(*mode
 		return errors.Wrapf(err, "failed to unmarshal config without env overrides")
 	}
 
+	// Bring configurations written by an older server up to date before defaults fill in any
+	// settings that were moved or renamed since.
+	applied, err := upgradeConfig(loadedCfg)
+	if err != nil {
+		return err
+	}
+	if _, err = upgradeConfig(loadedCfgWithoutEnvOverrides); err != nil {
+		return err
+	}
+	for _, step := range applied {
+		mlog.Info("Applied config migration", mlog.String("migration", step))
+	}
+	needsSave = needsSave || len(applied) > 0
+
 	// SetDefaults generates various keys and salts if not previously configured. Determine if
 	// such a change will be made before invoking.
 	needsSave = needsSave || loadedCfg.SqlSettings.AtRestEncryptKey == nil || len(*loadedCfg.SqlSettings.AtRestEncryptKey) == 0
@@ -25,6 +25,7 @@ func init() {
 		},
 	}
 	minimalConfig = &model.Config{
+		ConfigVersion: model.NewInt(model.CURRENT_CONFIG_VERSION),
 		ServiceSettings: model.ServiceSettings{
 			SiteURL: sToP("http://minimal"),
 		},
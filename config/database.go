@@ -6,6 +6,7 @@ package config
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"io/ioutil"
 	"strings"
 
@@ -27,6 +28,16 @@ import (
 // It is imposed by MySQL's default max_allowed_packet value of 4Mb.
 const MaxWriteLength = 4 * 1024 * 1024
 
+// MaxHistoryLength defines the number of previous configurations kept in the Configurations table.
+// Older configurations are pruned when a new one is saved.
+const MaxHistoryLength = 100
+
+var (
+	// ErrConfigurationNotFound is returned when fetching a configuration that was never saved or
+	// was pruned.
+	ErrConfigurationNotFound = errors.New("configuration not found")
+)
+
 // DatabaseStore is a config store backed by a database.
 type DatabaseStore struct {
 	commonStore
@@ -100,6 +111,18 @@ func initializeConfigurationsTable(db *sqlx.DB) error {
 		return errors.Wrap(err, "failed to create ConfigurationFiles table")
 	}
 
+	// Record who saved each configuration and what changed, and whether the secrets of a previous
+	// configuration were removed.
+	if err = addColumnIfNotExists(db, "Configurations", "UserId", "VARCHAR(26) NULL"); err != nil {
+		return err
+	}
+	if err = addColumnIfNotExists(db, "Configurations", "Changes", "TEXT NULL"); err != nil {
+		return err
+	}
+	if err = addColumnIfNotExists(db, "Configurations", "SecretsRemoved", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+		return err
+	}
+
 	// Change from TEXT (65535 limit) to MEDIUM TEXT (16777215) on MySQL. This is a
 	// backwards-compatible migration for any existing schema.
 	// Also fix using the wrong encoding initially
@@ -126,6 +149,31 @@ func initializeConfigurationsTable(db *sqlx.DB) error {
 	return nil
 }
 
+// addColumnIfNotExists adds the given column to a table created by initializeConfigurationsTable.
+func addColumnIfNotExists(db *sqlx.DB, table, column, definition string) error {
+	query := "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+	if db.DriverName() == "postgres" {
+		// Postgres folds unquoted identifiers to lower case.
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?"
+		table = strings.ToLower(table)
+		column = strings.ToLower(column)
+	}
+
+	var count int64
+	if err := db.QueryRow(db.Rebind(query), table, column).Scan(&count); err != nil {
+		return errors.Wrapf(err, "failed to check for %s.%s", table, column)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := db.Exec("ALTER TABLE " + table + " ADD " + column + " " + definition); err != nil {
+		return errors.Wrapf(err, "failed to add %s.%s", table, column)
+	}
+
+	return nil
+}
+
 // parseDSN splits up a connection string into a driver name and data source name.
 //
 // For example:
@@ -160,7 +208,14 @@ func parseDSN(dsn string) (string, string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (ds *DatabaseStore) Set(newCfg *model.Config) (*model.Config, error) {
-	return ds.commonStore.set(newCfg, true, ds.commonStore.validate, ds.persist)
+	return ds.SetAs(newCfg, "")
+}
+
+// SetAs replaces the current configuration like Set, recording the user making the change.
+func (ds *DatabaseStore) SetAs(newCfg *model.Config, userId string) (*model.Config, error) {
+	return ds.commonStore.set(newCfg, true, ds.commonStore.validate, func(cfg *model.Config) error {
+		return ds.persistAs(cfg, userId)
+	})
 }
 
 // maxLength identifies the maximum length of a configuration or configuration file
@@ -174,6 +229,16 @@ func (ds *DatabaseStore) checkLength(length int) error {
 
 // persist writes the configuration to the configured database.
 func (ds *DatabaseStore) persist(cfg *model.Config) error {
+	return ds.persistAs(cfg, "")
+}
+
+// persistAs writes the configuration to the configured database, recording the user saving it and
+// the settings changed.
+//
+// The configuration it replaces is kept without its secrets, so that the secrets are only stored in
+// the active configuration. Rolling back to a previous configuration keeps the secrets of the
+// active one.
+func (ds *DatabaseStore) persistAs(cfg *model.Config, userId string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
@@ -199,31 +264,68 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		}
 	}()
 
-	params := map[string]interface{}{
-		"id":        id,
-		"value":     value,
-		"create_at": createAt,
-		"key":       "ConfigurationId",
-	}
-
 	// Skip the persist altogether if we're effectively writing the same configuration.
+	var oldId string
 	var oldValue []byte
-	row := ds.db.QueryRow("SELECT Value FROM Configurations WHERE Active")
-	if err := row.Scan(&oldValue); err != nil && err != sql.ErrNoRows {
+	row := ds.db.QueryRow("SELECT Id, Value FROM Configurations WHERE Active")
+	if err := row.Scan(&oldId, &oldValue); err != nil && err != sql.ErrNoRows {
 		return errors.Wrap(err, "failed to query active configuration")
 	}
 	if bytes.Equal(oldValue, b) {
 		return nil
 	}
 
-	if _, err := tx.Exec("UPDATE Configurations SET Active = NULL WHERE Active"); err != nil {
-		return errors.Wrap(err, "failed to deactivate current configuration")
+	changes := []string{}
+	if len(oldValue) > 0 {
+		oldCfg, err := historicalConfigFromJson(oldValue)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal active configuration")
+		}
+		changes = configChanges(oldCfg, cfg)
+
+		removeSecrets(oldCfg)
+		redactedOldValue, err := marshalConfig(oldCfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize active configuration")
+		}
+
+		query, args, err := sqlx.Named("UPDATE Configurations SET Active = NULL, Value = :value, SecretsRemoved = TRUE WHERE Id = :id", map[string]interface{}{
+			"id":    oldId,
+			"value": string(redactedOldValue),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+			return errors.Wrap(err, "failed to deactivate current configuration")
+		}
+	}
+
+	changesJson, err := json.Marshal(changes)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize changes")
+	}
+
+	params := map[string]interface{}{
+		"id":        id,
+		"value":     value,
+		"create_at": createAt,
+		"user_id":   userId,
+		"changes":   string(changesJson),
 	}
 
-	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active) VALUES (:id, :value, :create_at, TRUE)", params); err != nil {
+	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, UserId, Changes, SecretsRemoved) VALUES (:id, :value, :create_at, TRUE, :user_id, :changes, FALSE)", params); err != nil {
 		return errors.Wrap(err, "failed to record new configuration")
 	}
 
+	if err := pruneHistory(tx); err != nil {
+		return err
+	}
+
+	if err := removeHistorySecrets(tx); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return errors.Wrap(err, "failed to commit transaction")
 	}
@@ -231,6 +333,166 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 	return nil
 }
 
+// removeHistorySecrets removes the secrets of the previous configurations saved before history
+// was kept, which were deactivated along with their secrets.
+func removeHistorySecrets(tx *sqlx.Tx) error {
+	type historyRow struct {
+		Id    string
+		Value []byte
+	}
+	var rows []historyRow
+
+	// Read every row before updating any, since a transaction can't run statements while iterating.
+	result, err := tx.Query("SELECT Id, Value FROM Configurations WHERE Active IS NULL AND NOT SecretsRemoved")
+	if err != nil {
+		return errors.Wrap(err, "failed to query previous configurations")
+	}
+	for result.Next() {
+		var row historyRow
+		if err := result.Scan(&row.Id, &row.Value); err != nil {
+			result.Close()
+			return errors.Wrap(err, "failed to scan previous configuration")
+		}
+		rows = append(rows, row)
+	}
+	result.Close()
+	if err := result.Err(); err != nil {
+		return errors.Wrap(err, "failed to iterate previous configurations")
+	}
+
+	for _, row := range rows {
+		cfg, err := historicalConfigFromJson(row.Value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal configuration %s", row.Id)
+		}
+
+		removeSecrets(cfg)
+		b, err := marshalConfig(cfg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize configuration %s", row.Id)
+		}
+
+		query, args, err := sqlx.Named("UPDATE Configurations SET Value = :value, SecretsRemoved = TRUE WHERE Id = :id", map[string]interface{}{
+			"id":    row.Id,
+			"value": string(b),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(tx.Rebind(query), args...); err != nil {
+			return errors.Wrapf(err, "failed to update configuration %s", row.Id)
+		}
+	}
+
+	return nil
+}
+
+// pruneHistory removes the oldest previous configurations beyond MaxHistoryLength.
+func pruneHistory(tx *sqlx.Tx) error {
+	var oldestCreateAt int64
+	err := tx.QueryRow(tx.Rebind("SELECT CreateAt FROM Configurations WHERE Active IS NULL ORDER BY CreateAt DESC LIMIT 1 OFFSET ?"), MaxHistoryLength-1).Scan(&oldestCreateAt)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to query previous configurations")
+	}
+
+	if _, err := tx.Exec(tx.Rebind("DELETE FROM Configurations WHERE Active IS NULL AND CreateAt < ?"), oldestCreateAt); err != nil {
+		return errors.Wrap(err, "failed to prune previous configurations")
+	}
+
+	return nil
+}
+
+// historicalConfigFromJson unmarshals a configuration as stored in the Configurations table. Unlike
+// unmarshalConfig, it leaves settings missing from older configurations unset.
+func historicalConfigFromJson(data []byte) (*model.Config, error) {
+	var cfg model.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// GetHistory fetches a page of the saved configurations, most recent first.
+func (ds *DatabaseStore) GetHistory(offset, limit int) ([]*model.ConfigHistoryEntry, error) {
+	query, args, err := sqlx.Named("SELECT Id, CreateAt, UserId, Active, Changes FROM Configurations ORDER BY CreateAt DESC, Id LIMIT :limit OFFSET :offset", map[string]interface{}{
+		"limit":  limit,
+		"offset": offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(ds.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query configurations")
+	}
+	defer rows.Close()
+
+	entries := []*model.ConfigHistoryEntry{}
+	for rows.Next() {
+		var entry model.ConfigHistoryEntry
+		var userId, changes sql.NullString
+		var active sql.NullBool
+		if err := rows.Scan(&entry.Id, &entry.CreateAt, &userId, &active, &changes); err != nil {
+			return nil, errors.Wrap(err, "failed to scan configuration")
+		}
+
+		entry.UserId = userId.String
+		entry.Active = active.Valid && active.Bool
+		entry.Changes = []string{}
+		if changes.Valid && changes.String != "" {
+			if err := json.Unmarshal([]byte(changes.String), &entry.Changes); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal changes of configuration %s", entry.Id)
+			}
+		}
+
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate configurations")
+	}
+
+	return entries, nil
+}
+
+// GetHistoricalConfig fetches a saved configuration by id. Previous configurations are kept
+// without their secrets, so they are given the secrets of the active configuration.
+func (ds *DatabaseStore) GetHistoricalConfig(id string) (*model.Config, error) {
+	query, args, err := sqlx.Named("SELECT Value FROM Configurations WHERE Id = :id", map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	if err = ds.db.QueryRow(ds.db.Rebind(query), args...).Scan(&value); err == sql.ErrNoRows {
+		return nil, ErrConfigurationNotFound
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to query configuration %s", id)
+	}
+
+	cfg, err := historicalConfigFromJson(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal configuration %s", id)
+	}
+
+	var activeValue []byte
+	if err = ds.db.QueryRow("SELECT Value FROM Configurations WHERE Active").Scan(&activeValue); err != nil {
+		return nil, errors.Wrap(err, "failed to query active configuration")
+	}
+	activeCfg, err := historicalConfigFromJson(activeValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal active configuration")
+	}
+	copySecrets(cfg, activeCfg)
+
+	return cfg, nil
+}
+
 // Load updates the current configuration from the backing store.
 func (ds *DatabaseStore) Load() (err error) {
 	var needsSave bool
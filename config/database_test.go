@@ -1018,3 +1018,128 @@ func TestDatabaseStoreString(t *testing.T) {
 		assert.False(t, strings.Contains(maskedDSN, "mostest"))
 	}
 }
+
+func TestDatabaseStoreHistory(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	sqlSettings := mainHelper.GetSQLSettings()
+
+	t.Run("records user and changes", func(t *testing.T) {
+		initialId, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+		defer tearDown()
+
+		ds, err := config.NewDatabaseStore(getDsn(*sqlSettings.DriverName, *sqlSettings.DataSource))
+		require.NoError(t, err)
+		defer ds.Close()
+
+		userId := model.NewId()
+		newCfg := ds.Get().Clone()
+		newCfg.ServiceSettings.SiteURL = sToP("http://history")
+		_, err = ds.SetAs(newCfg, userId)
+		require.NoError(t, err)
+
+		entries, err := ds.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.True(t, entries[0].Active)
+		assert.Equal(t, userId, entries[0].UserId)
+		assert.Equal(t, []string{"ServiceSettings.SiteURL"}, entries[0].Changes)
+
+		assert.Equal(t, initialId, entries[1].Id)
+		assert.False(t, entries[1].Active)
+		assert.Equal(t, "", entries[1].UserId)
+		assert.Equal(t, []string{}, entries[1].Changes)
+
+		entries, err = ds.GetHistory(1, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, initialId, entries[0].Id)
+	})
+
+	t.Run("removes secrets of previous configurations", func(t *testing.T) {
+		initialId, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+		defer tearDown()
+
+		// A configuration deactivated before history was kept still holds its secrets.
+		db := sqlx.NewDb(mainHelper.GetSQLSupplier().GetMaster().Db, *sqlSettings.DriverName)
+		cfgData, err := config.MarshalConfig(minimalConfig)
+		require.NoError(t, err)
+		legacyId := model.NewId()
+		_, err = db.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt) VALUES(:Id, :Value, :CreateAt)", map[string]interface{}{
+			"Id":       legacyId,
+			"Value":    cfgData,
+			"CreateAt": model.GetMillis() - 1000,
+		})
+		require.NoError(t, err)
+
+		ds, err := config.NewDatabaseStore(getDsn(*sqlSettings.DriverName, *sqlSettings.DataSource))
+		require.NoError(t, err)
+		defer ds.Close()
+
+		newCfg := ds.Get().Clone()
+		newCfg.ServiceSettings.SiteURL = sToP("http://history")
+		_, err = ds.Set(newCfg)
+		require.NoError(t, err)
+
+		for _, id := range []string{initialId, legacyId} {
+			var value []byte
+			err = db.QueryRow(db.Rebind("SELECT Value FROM Configurations WHERE Id = ?"), id).Scan(&value)
+			require.NoError(t, err)
+			assert.NotContains(t, string(value), *minimalConfig.FileSettings.PublicLinkSalt)
+			assert.NotContains(t, string(value), *minimalConfig.SqlSettings.AtRestEncryptKey)
+		}
+
+		cfg, err := ds.GetHistoricalConfig(initialId)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minimal", *cfg.ServiceSettings.SiteURL)
+		assert.Equal(t, *minimalConfig.FileSettings.PublicLinkSalt, *cfg.FileSettings.PublicLinkSalt)
+
+		// Previous configurations are given the secrets of the active configuration.
+		newCfg = ds.Get().Clone()
+		newCfg.SqlSettings.AtRestEncryptKey = sToP(model.NewRandomString(32))
+		_, err = ds.Set(newCfg)
+		require.NoError(t, err)
+
+		cfg, err = ds.GetHistoricalConfig(initialId)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minimal", *cfg.ServiceSettings.SiteURL)
+		assert.Equal(t, *newCfg.SqlSettings.AtRestEncryptKey, *cfg.SqlSettings.AtRestEncryptKey)
+	})
+
+	t.Run("unknown configuration", func(t *testing.T) {
+		_, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+		defer tearDown()
+
+		ds, err := config.NewDatabaseStore(getDsn(*sqlSettings.DriverName, *sqlSettings.DataSource))
+		require.NoError(t, err)
+		defer ds.Close()
+
+		_, err = ds.GetHistoricalConfig(model.NewId())
+		assert.Equal(t, config.ErrConfigurationNotFound, err)
+	})
+
+	t.Run("prunes previous configurations", func(t *testing.T) {
+		_, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+		defer tearDown()
+
+		ds, err := config.NewDatabaseStore(getDsn(*sqlSettings.DriverName, *sqlSettings.DataSource))
+		require.NoError(t, err)
+		defer ds.Close()
+
+		for i := 0; i < config.MaxHistoryLength+5; i++ {
+			newCfg := ds.Get().Clone()
+			newCfg.ServiceSettings.SiteURL = sToP(fmt.Sprintf("http://history%d", i))
+			_, err = ds.Set(newCfg)
+			require.NoError(t, err)
+
+			// Keep creation times distinct.
+			time.Sleep(time.Millisecond)
+		}
+
+		entries, err := ds.GetHistory(0, 2*config.MaxHistoryLength)
+		require.NoError(t, err)
+		assert.Len(t, entries, config.MaxHistoryLength+1)
+	})
+}
@@ -24,3 +24,33 @@ func UnmarshalConfig(r io.Reader, allowEnvironmentOverrides bool) (*model.Config
 func InitializeConfigurationsTable(db *sqlx.DB) error {
 	return initializeConfigurationsTable(db)
 }
+
+// UpgradeConfig exposes the internal upgradeConfig to tests only.
+func UpgradeConfig(cfg *model.Config) ([]string, error) {
+	return upgradeConfig(cfg)
+}
+
+// ConfigMigrationVersions exposes the versions of the internal configMigrations to tests only.
+func ConfigMigrationVersions() []int {
+	versions := make([]int, 0, len(configMigrations))
+	for _, migration := range configMigrations {
+		versions = append(versions, migration.version)
+	}
+
+	return versions
+}
+
+// ConfigChanges exposes the internal configChanges to tests only.
+func ConfigChanges(oldCfg, newCfg *model.Config) []string {
+	return configChanges(oldCfg, newCfg)
+}
+
+// RemoveSecrets exposes the internal removeSecrets to tests only.
+func RemoveSecrets(cfg *model.Config) {
+	removeSecrets(cfg)
+}
+
+// CopySecrets exposes the internal copySecrets to tests only.
+func CopySecrets(dst, src *model.Config) {
+	copySecrets(dst, src)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// configChanges returns the settings differing between the given configurations, in dot notation.
+func configChanges(oldCfg, newCfg *model.Config) []string {
+	changes := []string{}
+	configChangesRec(reflect.ValueOf(*oldCfg), reflect.ValueOf(*newCfg), "", &changes)
+	sort.Strings(changes)
+
+	return changes
+}
+
+// configChangesRec walks the given values side by side, collecting the paths of the differing
+// leaves. Nested settings structs are walked, while anything else is compared as a whole.
+func configChangesRec(oldVal, newVal reflect.Value, path string, changes *[]string) {
+	if oldVal.Kind() == reflect.Ptr && newVal.Kind() == reflect.Ptr && !oldVal.IsNil() && !newVal.IsNil() && oldVal.Elem().Kind() == reflect.Struct {
+		oldVal = oldVal.Elem()
+		newVal = newVal.Elem()
+	}
+
+	if oldVal.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*changes = append(*changes, path)
+		}
+		return
+	}
+
+	for i := 0; i < oldVal.NumField(); i++ {
+		fieldPath := oldVal.Type().Field(i).Name
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		configChangesRec(oldVal.Field(i), newVal.Field(i), fieldPath, changes)
+	}
+}
+
+// copySecrets replaces the settings of dst holding secrets, matching those redacted by
+// model.Config.Sanitize, with the ones of src.
+func copySecrets(dst, src *model.Config) {
+	dst.LdapSettings.BindPassword = src.LdapSettings.BindPassword
+	dst.FileSettings.PublicLinkSalt = src.FileSettings.PublicLinkSalt
+	dst.FileSettings.AmazonS3SecretAccessKey = src.FileSettings.AmazonS3SecretAccessKey
+	dst.EmailSettings.SMTPPassword = src.EmailSettings.SMTPPassword
+	dst.GitLabSettings.Secret = src.GitLabSettings.Secret
+	dst.SqlSettings.DataSource = src.SqlSettings.DataSource
+	dst.SqlSettings.DataSourceReplicas = src.SqlSettings.DataSourceReplicas
+	dst.SqlSettings.DataSourceSearchReplicas = src.SqlSettings.DataSourceSearchReplicas
+	dst.SqlSettings.AtRestEncryptKey = src.SqlSettings.AtRestEncryptKey
+	dst.ElasticsearchSettings.Password = src.ElasticsearchSettings.Password
+}
+
+// removeSecrets unsets the settings of the given configuration holding secrets.
+func removeSecrets(cfg *model.Config) {
+	copySecrets(cfg, &model.Config{})
+}
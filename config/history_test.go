@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/config"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestConfigChanges(t *testing.T) {
+	oldCfg := &model.Config{}
+	oldCfg.SetDefaults()
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.Equal(t, []string{}, config.ConfigChanges(oldCfg, oldCfg.Clone()))
+	})
+
+	t.Run("changed settings", func(t *testing.T) {
+		newCfg := oldCfg.Clone()
+		newCfg.ServiceSettings.SiteURL = model.NewString("http://changed")
+		newCfg.TeamSettings.MaxUsersPerTeam = model.NewInt(*oldCfg.TeamSettings.MaxUsersPerTeam + 1)
+		newCfg.SqlSettings.DataSourceReplicas = []string{"replica"}
+		newCfg.PluginSettings.Plugins = map[string]map[string]interface{}{"plugin": {"key": "value"}}
+
+		assert.Equal(t, []string{
+			"PluginSettings.Plugins",
+			"ServiceSettings.SiteURL",
+			"SqlSettings.DataSourceReplicas",
+			"TeamSettings.MaxUsersPerTeam",
+		}, config.ConfigChanges(oldCfg, newCfg))
+	})
+
+	t.Run("unset settings", func(t *testing.T) {
+		newCfg := oldCfg.Clone()
+		newCfg.ServiceSettings.SiteURL = nil
+
+		assert.Equal(t, []string{"ServiceSettings.SiteURL"}, config.ConfigChanges(oldCfg, newCfg))
+	})
+}
+
+func TestRemoveSecrets(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.EmailSettings.SMTPPassword = model.NewString("smtp password")
+	cfg.SqlSettings.DataSourceReplicas = []string{"replica"}
+	cfg.FileSettings.PublicLinkSalt = model.NewString(model.NewRandomString(32))
+	original := cfg.Clone()
+
+	config.RemoveSecrets(cfg)
+	assert.Nil(t, cfg.EmailSettings.SMTPPassword)
+	assert.Nil(t, cfg.SqlSettings.DataSource)
+	assert.Nil(t, cfg.SqlSettings.DataSourceReplicas)
+	assert.Nil(t, cfg.SqlSettings.AtRestEncryptKey)
+	assert.Nil(t, cfg.FileSettings.PublicLinkSalt)
+	assert.Equal(t, *original.ServiceSettings.SiteURL, *cfg.ServiceSettings.SiteURL)
+
+	b, err := config.MarshalConfig(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "smtp password")
+	assert.NotContains(t, string(b), *original.FileSettings.PublicLinkSalt)
+
+	config.CopySecrets(cfg, original)
+	assert.Equal(t, original, cfg)
+}
@@ -56,6 +56,21 @@ type Store interface {
 	Close() error
 }
 
+// HistoryStore is a Store keeping the configurations previously saved.
+type HistoryStore interface {
+	Store
+
+	// SetAs replaces the current configuration like Set, recording the user making the change.
+	SetAs(newCfg *model.Config, userId string) (*model.Config, error)
+
+	// GetHistory fetches a page of the saved configurations, most recent first.
+	GetHistory(offset, limit int) ([]*model.ConfigHistoryEntry, error)
+
+	// GetHistoricalConfig fetches a saved configuration by id, returning ErrConfigurationNotFound
+	// if no such configuration was kept.
+	GetHistoricalConfig(id string) (*model.Config, error)
+}
+
 // NewStore creates a database or file store given a data source name by which to connect.
 func NewStore(dsn string, watch bool) (Store, error) {
 	if strings.HasPrefix(dsn, "mysql://") || strings.HasPrefix(dsn, "postgres://") {
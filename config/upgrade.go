@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// configMigration upgrades a configuration written with the previous version of the schema to the
+// given version.
+type configMigration struct {
+	version     int
+	description string
+	migrate     func(cfg *model.Config)
+}
+
+// configMigrations are applied in order to configurations older than their version. Migrations
+// run before defaults are set, so any setting may still be unset. The last migration's version
+// must match model.CURRENT_CONFIG_VERSION.
+var configMigrations = []configMigration{
+	{
+		version:     1,
+		description: "Move AllowCorsFrom, CorsExposedHeaders and CorsAllowCredentials to CorsOrigins",
+		migrate:     migrateCorsOrigins,
+	},
+	{
+		version:     2,
+		description: "Reset a UserStatusAwayTimeout that isn't positive to the default",
+		migrate:     migrateUserStatusAwayTimeout,
+	},
+}
+
+// upgradeConfig applies the migrations newer than the version of the given configuration, returning
+// the descriptions of those applied. Configurations without a version predate versioning.
+//
+// Configurations written by a newer server are refused, since settings this server doesn't know
+// about would otherwise be dropped or reset the next time the configuration is saved.
+func upgradeConfig(cfg *model.Config) ([]string, error) {
+	version := 0
+	if cfg.ConfigVersion != nil {
+		version = *cfg.ConfigVersion
+	}
+
+	if version > model.CURRENT_CONFIG_VERSION {
+		return nil, fmt.Errorf("config version %d is newer than version %d supported by this server: upgrade the server or restore a configuration saved by this version", version, model.CURRENT_CONFIG_VERSION)
+	}
+
+	applied := []string{}
+	for _, migration := range configMigrations {
+		if migration.version <= version {
+			continue
+		}
+
+		migration.migrate(cfg)
+		cfg.ConfigVersion = model.NewInt(migration.version)
+		applied = append(applied, fmt.Sprintf("%d: %s", migration.version, migration.description))
+	}
+
+	return applied, nil
+}
+
+// UpgradeFile migrates the configuration file at the given path to the current version in place,
+// returning the descriptions of the migrations applied. The file is left untouched if it is
+// already up to date.
+func UpgradeFile(path string) ([]string, error) {
+	resolvedPath, err := resolveConfigFilePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(resolvedPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", resolvedPath)
+	}
+
+	cfg, _, err := unmarshalConfig(bytes.NewReader(data), false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", resolvedPath)
+	}
+
+	applied, err := upgradeConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) == 0 {
+		return applied, nil
+	}
+
+	cfg.SetDefaults()
+	b, err := marshalConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize")
+	}
+
+	info, err := os.Stat(resolvedPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat %s", resolvedPath)
+	}
+
+	if err := ioutil.WriteFile(resolvedPath, b, info.Mode()); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", resolvedPath)
+	}
+
+	return applied, nil
+}
+
+// migrateCorsOrigins moves the deprecated CORS settings to CorsOrigins, unless it was already set,
+// then resets them so that they can't be migrated again should CorsOrigins be emptied later on.
+// Origins that CorsOrigins wouldn't accept are dropped rather than failing the validation of the
+// whole configuration.
+func migrateCorsOrigins(cfg *model.Config) {
+	if cfg.ServiceSettings.CorsOrigins == nil {
+		cfg.ServiceSettings.CorsOrigins = cfg.ServiceSettings.MigratedCorsOrigins()
+	}
+
+	cfg.ServiceSettings.AllowCorsFrom = model.NewString(model.SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM)
+	cfg.ServiceSettings.CorsExposedHeaders = model.NewString("")
+	cfg.ServiceSettings.CorsAllowCredentials = model.NewBool(false)
+}
+
+// migrateUserStatusAwayTimeout resets a UserStatusAwayTimeout of zero or less, which older servers
+// accepted but no longer passes validation, to the default.
+func migrateUserStatusAwayTimeout(cfg *model.Config) {
+	if cfg.TeamSettings.UserStatusAwayTimeout != nil && *cfg.TeamSettings.UserStatusAwayTimeout <= 0 {
+		cfg.TeamSettings.UserStatusAwayTimeout = model.NewInt64(model.TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT)
+	}
+}
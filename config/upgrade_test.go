@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/config"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestConfigMigrationVersions(t *testing.T) {
+	versions := config.ConfigMigrationVersions()
+	require.NotEmpty(t, versions)
+
+	for i := 1; i < len(versions); i++ {
+		assert.Greater(t, versions[i], versions[i-1], "migrations must be ordered by version")
+	}
+	assert.Equal(t, model.CURRENT_CONFIG_VERSION, versions[len(versions)-1], "the last migration must match the current config version")
+}
+
+func TestUpgradeConfig(t *testing.T) {
+	t.Run("unversioned config", func(t *testing.T) {
+		cfg := &model.Config{}
+
+		applied, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+		assert.Len(t, applied, len(config.ConfigMigrationVersions()))
+		require.NotNil(t, cfg.ConfigVersion)
+		assert.Equal(t, model.CURRENT_CONFIG_VERSION, *cfg.ConfigVersion)
+	})
+
+	t.Run("current config", func(t *testing.T) {
+		cfg := &model.Config{ConfigVersion: model.NewInt(model.CURRENT_CONFIG_VERSION)}
+
+		applied, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+		assert.Equal(t, model.CURRENT_CONFIG_VERSION, *cfg.ConfigVersion)
+	})
+
+	t.Run("newer config", func(t *testing.T) {
+		cfg := &model.Config{ConfigVersion: model.NewInt(model.CURRENT_CONFIG_VERSION + 1)}
+
+		applied, err := config.UpgradeConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "newer than version")
+		assert.Nil(t, applied)
+		assert.Equal(t, model.CURRENT_CONFIG_VERSION+1, *cfg.ConfigVersion)
+	})
+}
+
+func TestUpgradeConfigCorsOrigins(t *testing.T) {
+	t.Run("legacy settings", func(t *testing.T) {
+		cfg := &model.Config{
+			ServiceSettings: model.ServiceSettings{
+				AllowCorsFrom:        model.NewString("https://example.com"),
+				CorsExposedHeaders:   model.NewString("X-Custom"),
+				CorsAllowCredentials: model.NewBool(true),
+			},
+		}
+
+		_, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+
+		require.Len(t, cfg.ServiceSettings.CorsOrigins, 1)
+		assert.Equal(t, "https://example.com", *cfg.ServiceSettings.CorsOrigins[0].Origin)
+		assert.Equal(t, []string{"X-Custom"}, cfg.ServiceSettings.CorsOrigins[0].ExposedHeaders)
+		assert.True(t, *cfg.ServiceSettings.CorsOrigins[0].AllowCredentials)
+		assert.Equal(t, model.SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM, *cfg.ServiceSettings.AllowCorsFrom)
+		assert.Equal(t, "", *cfg.ServiceSettings.CorsExposedHeaders)
+		assert.False(t, *cfg.ServiceSettings.CorsAllowCredentials)
+	})
+
+	t.Run("invalid legacy origins", func(t *testing.T) {
+		cfg := &model.Config{
+			ServiceSettings: model.ServiceSettings{
+				AllowCorsFrom: model.NewString("example.com https://Example.com/"),
+			},
+		}
+
+		_, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+
+		require.Len(t, cfg.ServiceSettings.CorsOrigins, 1)
+		assert.Equal(t, "https://example.com", *cfg.ServiceSettings.CorsOrigins[0].Origin)
+
+		cfg.SetDefaults()
+		assert.Nil(t, cfg.IsValid())
+	})
+
+	t.Run("no legacy settings", func(t *testing.T) {
+		cfg := &model.Config{}
+
+		_, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+
+		assert.NotNil(t, cfg.ServiceSettings.CorsOrigins)
+		assert.Empty(t, cfg.ServiceSettings.CorsOrigins)
+	})
+
+	t.Run("cors origins already set", func(t *testing.T) {
+		origins := []*model.CorsOriginSettings{{Origin: model.NewString("https://other.example.com")}}
+		cfg := &model.Config{
+			ServiceSettings: model.ServiceSettings{
+				AllowCorsFrom: model.NewString("https://example.com"),
+				CorsOrigins:   origins,
+			},
+		}
+
+		_, err := config.UpgradeConfig(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, origins, cfg.ServiceSettings.CorsOrigins)
+		assert.Equal(t, model.SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM, *cfg.ServiceSettings.AllowCorsFrom)
+	})
+}
+
+func TestUpgradeConfigUserStatusAwayTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		Name     string
+		Timeout  *int64
+		Expected *int64
+	}{
+		{"unset", nil, nil},
+		{"positive", model.NewInt64(60), model.NewInt64(60)},
+		{"zero", model.NewInt64(0), model.NewInt64(model.TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT)},
+		{"negative", model.NewInt64(-1), model.NewInt64(model.TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT)},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := &model.Config{
+				ConfigVersion: model.NewInt(1),
+				TeamSettings: model.TeamSettings{
+					UserStatusAwayTimeout: tc.Timeout,
+				},
+			}
+
+			applied, err := config.UpgradeConfig(cfg)
+			require.NoError(t, err)
+			assert.Len(t, applied, 1)
+			assert.Equal(t, tc.Expected, cfg.TeamSettings.UserStatusAwayTimeout)
+
+			cfg.SetDefaults()
+			assert.Nil(t, cfg.IsValid())
+		})
+	}
+}
+
+func TestUpgradeFile(t *testing.T) {
+	setup := func(t *testing.T, data string) (string, func()) {
+		t.Helper()
+
+		tempDir, err := ioutil.TempDir("", "TestUpgradeFile")
+		require.NoError(t, err)
+
+		path := filepath.Join(tempDir, "config.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+
+		return path, func() {
+			os.RemoveAll(tempDir)
+		}
+	}
+
+	t.Run("older config", func(t *testing.T) {
+		path, tearDown := setup(t, `{"ServiceSettings": {"SiteURL": "http://upgrade", "AllowCorsFrom": "https://example.com"}}`)
+		defer tearDown()
+
+		applied, err := config.UpgradeFile(path)
+		require.NoError(t, err)
+		assert.Len(t, applied, len(config.ConfigMigrationVersions()))
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		cfg := model.ConfigFromJson(bytes.NewReader(data))
+		require.NotNil(t, cfg)
+		assert.Equal(t, model.CURRENT_CONFIG_VERSION, *cfg.ConfigVersion)
+		assert.Equal(t, "http://upgrade", *cfg.ServiceSettings.SiteURL)
+		require.Len(t, cfg.ServiceSettings.CorsOrigins, 1)
+		assert.Equal(t, "https://example.com", *cfg.ServiceSettings.CorsOrigins[0].Origin)
+	})
+
+	t.Run("current config", func(t *testing.T) {
+		original := fmt.Sprintf(`{"ConfigVersion": %d}`, model.CURRENT_CONFIG_VERSION)
+		path, tearDown := setup(t, original)
+		defer tearDown()
+
+		applied, err := config.UpgradeFile(path)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, original, string(data))
+	})
+
+	t.Run("newer config", func(t *testing.T) {
+		path, tearDown := setup(t, `{"ConfigVersion": 1000}`)
+		defer tearDown()
+
+		_, err := config.UpgradeFile(path)
+		require.Error(t, err)
+	})
+
+	t.Run("file store refuses newer config", func(t *testing.T) {
+		path, tearDown := setup(t, `{"ConfigVersion": 1000}`)
+		defer tearDown()
+
+		_, err := config.NewFileStore(path, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "newer than version")
+	})
+}
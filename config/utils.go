@@ -10,6 +10,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/mergeutils"
 )
 
 // desanitize replaces fake settings with their actual values.
@@ -127,13 +128,21 @@ func FixInvalidLocales(cfg *model.Config) bool {
 		*cfg.LocalizationSettings.AvailableLocales = strings.Join(utils.RemoveDuplicatesFromStringArray(strings.Split(availableLocales, ",")), ",")
 	}
 
+	for teamId, locale := range cfg.LocalizationSettings.TeamDefaultClientLocales {
+		if _, ok := locales[locale]; !ok {
+			delete(cfg.LocalizationSettings.TeamDefaultClientLocales, teamId)
+			mlog.Warn("TeamDefaultClientLocales must only contain supported locales. Removing the default locale of the team.", mlog.String("team_id", teamId), mlog.String("locale", locale))
+			changed = true
+		}
+	}
+
 	return changed
 }
 
 // Merge merges two configs together. The receiver's values are overwritten with the patch's
 // values except when the patch's values are nil.
-func Merge(cfg *model.Config, patch *model.Config, mergeConfig *utils.MergeConfig) (*model.Config, error) {
-	ret, err := utils.Merge(cfg, patch, mergeConfig)
+func Merge(cfg *model.Config, patch *model.Config, mergeConfig *mergeutils.MergeConfig) (*model.Config, error) {
+	ret, err := mergeutils.Merge(cfg, patch, mergeConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +139,21 @@ func TestFixInvalidLocales(t *testing.T) {
 	assert.True(t, changed)
 	assert.NotContains(t, *cfg.LocalizationSettings.AvailableLocales, *cfg.LocalizationSettings.DefaultServerLocale, "DefaultServerLocale should not be added to AvailableLocales")
 	assert.Contains(t, *cfg.LocalizationSettings.AvailableLocales, *cfg.LocalizationSettings.DefaultClientLocale, "DefaultClientLocale should have been added to AvailableLocales")
+
+	// validate TeamDefaultClientLocales
+	*cfg.LocalizationSettings.DefaultServerLocale = "en"
+	*cfg.LocalizationSettings.DefaultClientLocale = "en"
+	*cfg.LocalizationSettings.AvailableLocales = ""
+	supportedTeamId := model.NewId()
+	unsupportedTeamId := model.NewId()
+	cfg.LocalizationSettings.TeamDefaultClientLocales = map[string]string{supportedTeamId: "de"}
+	changed = FixInvalidLocales(cfg)
+	assert.False(t, changed)
+
+	cfg.LocalizationSettings.TeamDefaultClientLocales[unsupportedTeamId] = "junk"
+	changed = FixInvalidLocales(cfg)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{supportedTeamId: "de"}, cfg.LocalizationSettings.TeamDefaultClientLocales)
 }
 
 func TestStripPassword(t *testing.T) {
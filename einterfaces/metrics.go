@@ -8,6 +8,7 @@ type MetricsInterface interface {
 	StopServer()
 
 	IncrementPostCreate()
+	IncrementPostCreateDeduplicated()
 	IncrementWebhookPost()
 	IncrementPostSentEmail()
 	IncrementPostSentPush()
@@ -16,6 +17,15 @@ type MetricsInterface interface {
 
 	IncrementHttpRequest()
 	IncrementHttpError()
+	IncrementHttpActiveConnections()
+	DecrementHttpActiveConnections()
+	IncrementHttpRejectedConnections()
+
+	// The route of these is the path template of the API route that handled the request, such as
+	// /api/v4/channels/{channel_id}/posts, so there is one series per route rather than per URL.
+	ObserveApiRouteDuration(route, method string, elapsed float64)
+	ObserveApiRouteResponseSize(route, method string, size float64)
+	IncrementApiRouteStatusClass(route, method, statusClass string)
 
 	IncrementClusterRequest()
 	ObserveClusterRequestDuration(elapsed float64)
@@ -35,6 +45,9 @@ type MetricsInterface interface {
 	IncrementMemCacheInvalidationCounterSession()
 
 	IncrementWebsocketEvent(eventType string)
+	IncrementWebSocketConnect()
+	IncrementWebSocketDisconnect()
+	IncrementWebSocketOriginCheckFailure()
 	IncrementWebSocketBroadcast(eventType string)
 	IncrementWebSocketBroadcastBufferSize(hub string, amount float64)
 	DecrementWebSocketBroadcastBufferSize(hub string, amount float64)
@@ -208,6 +208,31 @@ func (_m *LdapInterface) GetUser(id string) (*model.User, *model.AppError) {
 	return r0, r1
 }
 
+// GetUserAttributeValues provides a mock function with given fields: id, attributes
+func (_m *LdapInterface) GetUserAttributeValues(id string, attributes []string) (map[string][]string, *model.AppError) {
+	ret := _m.Called(id, attributes)
+
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func(string, []string) map[string][]string); ok {
+		r0 = rf(id, attributes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, []string) *model.AppError); ok {
+		r1 = rf(id, attributes)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetUserAttributes provides a mock function with given fields: id, attributes
 func (_m *LdapInterface) GetUserAttributes(id string, attributes []string) (map[string]string, *model.AppError) {
 	ret := _m.Called(id, attributes)
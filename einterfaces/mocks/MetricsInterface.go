@@ -21,6 +21,11 @@ func (_m *MetricsInterface) AddMemCacheMissCounter(cacheName string, amount floa
 	_m.Called(cacheName, amount)
 }
 
+// DecrementHttpActiveConnections provides a mock function with given fields:
+func (_m *MetricsInterface) DecrementHttpActiveConnections() {
+	_m.Called()
+}
+
 // DecrementWebSocketBroadcastBufferSize provides a mock function with given fields: hub, amount
 func (_m *MetricsInterface) DecrementWebSocketBroadcastBufferSize(hub string, amount float64) {
 	_m.Called(hub, amount)
@@ -31,6 +36,11 @@ func (_m *MetricsInterface) DecrementWebSocketBroadcastUsersRegistered(hub strin
 	_m.Called(hub, amount)
 }
 
+// IncrementApiRouteStatusClass provides a mock function with given fields: route, method, statusClass
+func (_m *MetricsInterface) IncrementApiRouteStatusClass(route string, method string, statusClass string) {
+	_m.Called(route, method, statusClass)
+}
+
 // IncrementChannelIndexCounter provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementChannelIndexCounter() {
 	_m.Called()
@@ -56,11 +66,21 @@ func (_m *MetricsInterface) IncrementEtagMissCounter(route string) {
 	_m.Called(route)
 }
 
+// IncrementHttpActiveConnections provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementHttpActiveConnections() {
+	_m.Called()
+}
+
 // IncrementHttpError provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementHttpError() {
 	_m.Called()
 }
 
+// IncrementHttpRejectedConnections provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementHttpRejectedConnections() {
+	_m.Called()
+}
+
 // IncrementHttpRequest provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementHttpRequest() {
 	_m.Called()
@@ -116,6 +136,11 @@ func (_m *MetricsInterface) IncrementPostCreate() {
 	_m.Called()
 }
 
+// IncrementPostCreateDeduplicated provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementPostCreateDeduplicated() {
+	_m.Called()
+}
+
 // IncrementPostFileAttachment provides a mock function with given fields: count
 func (_m *MetricsInterface) IncrementPostFileAttachment(count int) {
 	_m.Called(count)
@@ -161,6 +186,21 @@ func (_m *MetricsInterface) IncrementWebSocketBroadcastUsersRegistered(hub strin
 	_m.Called(hub, amount)
 }
 
+// IncrementWebSocketConnect provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementWebSocketConnect() {
+	_m.Called()
+}
+
+// IncrementWebSocketDisconnect provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementWebSocketDisconnect() {
+	_m.Called()
+}
+
+// IncrementWebSocketOriginCheckFailure provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementWebSocketOriginCheckFailure() {
+	_m.Called()
+}
+
 // IncrementWebhookPost provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementWebhookPost() {
 	_m.Called()
@@ -176,6 +216,16 @@ func (_m *MetricsInterface) ObserveApiEndpointDuration(endpoint string, method s
 	_m.Called(endpoint, method, statusCode, elapsed)
 }
 
+// ObserveApiRouteDuration provides a mock function with given fields: route, method, elapsed
+func (_m *MetricsInterface) ObserveApiRouteDuration(route string, method string, elapsed float64) {
+	_m.Called(route, method, elapsed)
+}
+
+// ObserveApiRouteResponseSize provides a mock function with given fields: route, method, size
+func (_m *MetricsInterface) ObserveApiRouteResponseSize(route string, method string, size float64) {
+	_m.Called(route, method, size)
+}
+
 // ObserveClusterRequestDuration provides a mock function with given fields: elapsed
 func (_m *MetricsInterface) ObserveClusterRequestDuration(elapsed float64) {
 	_m.Called(elapsed)
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channelmembersdrift
+
+import (
+	"github.com/mattermost/mattermost-server/v5/app"
+	tjobs "github.com/mattermost/mattermost-server/v5/jobs/interfaces"
+)
+
+type ChannelMembersDriftJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsChannelMembersDriftJobInterface(func(a *app.App) tjobs.ChannelMembersDriftJobInterface {
+		return &ChannelMembersDriftJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channelmembersdrift
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	SchedFreqHours = 24
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *ChannelMembersDriftJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_CHANNEL_MEMBERS_DRIFT
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	// Group-constrained channels are only kept in line with their groups by the LDAP group sync.
+	return *cfg.LdapSettings.EnableSync
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := time.Now().Add(SchedFreqHours * time.Hour)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	if pendingJobs {
+		return nil, nil
+	}
+
+	// Scheduled runs only report the drift, unless they're configured to repair it too.
+	data := map[string]string{
+		JOB_DATA_KEY_APPLY: strconv.FormatBool(*cfg.LdapSettings.RepairChannelMembersDrift),
+	}
+
+	if job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_CHANNEL_MEMBERS_DRIFT, data); err != nil {
+		return nil, err
+	} else {
+		return job, nil
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channelmembersdrift
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/jobs"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	JobName = "ChannelMembersDrift"
+
+	// JOB_DATA_KEY_APPLY makes the job remove the drifted members when set to "true", rather than
+	// only reporting them.
+	JOB_DATA_KEY_APPLY            = "apply"
+	JOB_DATA_KEY_DRIFTED_CHANNELS = "drifted_channels"
+	JOB_DATA_KEY_DRIFTED_MEMBERS  = "drifted_members"
+	JOB_DATA_KEY_FAILED_CHANNELS  = "failed_channels"
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *ChannelMembersDriftJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      JobName,
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv().Jobs,
+		app:       m.App,
+	}
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Warn("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	drifts, err := worker.app.GetChannelMembersDrifts()
+	if err != nil {
+		mlog.Error("Worker: Failed to get the channel members drift", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+	apply := job.Data[JOB_DATA_KEY_APPLY] == "true"
+
+	driftedMembers := 0
+	failedChannels := 0
+	for _, drift := range drifts {
+		driftedMembers += len(drift.UserIds)
+
+		mlog.Warn("Worker: Channel members drifted from the channel's groups",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("channel_id", drift.ChannelId),
+			mlog.Int("drifted_members", len(drift.UserIds)),
+			mlog.Int("exempt_members", len(drift.ExemptUserIds)))
+
+		if apply {
+			// A channel that can't be repaired doesn't stop the others from being repaired.
+			if _, err := worker.app.RepairChannelMembersDrift(drift); err != nil {
+				mlog.Error("Worker: Failed to repair the channel members drift", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("channel_id", drift.ChannelId), mlog.String("error", err.Error()))
+				failedChannels++
+			}
+		}
+	}
+
+	job.Data[JOB_DATA_KEY_DRIFTED_CHANNELS] = strconv.Itoa(len(drifts))
+	job.Data[JOB_DATA_KEY_DRIFTED_MEMBERS] = strconv.Itoa(driftedMembers)
+
+	if failedChannels > 0 {
+		job.Data[JOB_DATA_KEY_FAILED_CHANNELS] = strconv.Itoa(failedChannels)
+		worker.setJobError(job, model.NewAppError("ChannelMembersDriftWorker", "jobs.channel_members_drift.repair.app_error", map[string]interface{}{"Count": failedChannels}, "", http.StatusInternalServerError))
+		return
+	}
+
+	mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+	worker.setJobSuccess(job)
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv().Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv().Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
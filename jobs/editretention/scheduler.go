@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package editretention
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	SchedFreqHours = 24
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *EditRetentionJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_EDIT_RETENTION
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	// Only enabled when edit originals don't share the retention of their posts.
+	return *cfg.DataRetentionSettings.EditedPostOriginalRetentionDays > 0
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := time.Now().Add(SchedFreqHours * time.Hour)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	if pendingJobs {
+		return nil, nil
+	}
+
+	data := map[string]string{}
+
+	if job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_EDIT_RETENTION, data); err != nil {
+		return nil, err
+	} else {
+		return job, nil
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package inactivechannelarchive
+
+import (
+	"github.com/mattermost/mattermost-server/v5/app"
+	tjobs "github.com/mattermost/mattermost-server/v5/jobs/interfaces"
+)
+
+type InactiveChannelArchiveJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsInactiveChannelArchiveJobInterface(func(a *app.App) tjobs.InactiveChannelArchiveJobInterface {
+		return &InactiveChannelArchiveJobInterfaceImpl{a}
+	})
+}
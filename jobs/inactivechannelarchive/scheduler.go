@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package inactivechannelarchive
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	SchedFreqHours = 24
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *InactiveChannelArchiveJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return JobName + "Scheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.TeamSettings.EnableInactiveChannelArchiving
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := time.Now().Add(SchedFreqHours * time.Hour)
+	return &nextTime
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	if pendingJobs {
+		return nil, nil
+	}
+
+	data := map[string]string{}
+
+	// Pick up where the previous run left off if it didn't get through all the teams.
+	jobs, err := scheduler.App.Srv().Store.Job().GetAllByTypePage(model.JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) > 0 && (jobs[0].Status == model.JOB_STATUS_ERROR || jobs[0].Status == model.JOB_STATUS_CANCELED) {
+		if lastTeamId, ok := jobs[0].Data[JOB_DATA_KEY_LAST_TEAM_ID]; ok {
+			mlog.Debug("Resuming inactive channel archiving from previous job", mlog.String("scheduler", scheduler.Name()), mlog.String("previous_job_id", jobs[0].Id))
+			data[JOB_DATA_KEY_LAST_TEAM_ID] = lastTeamId
+		}
+	}
+
+	if job, err := scheduler.App.Srv().Jobs.CreateJob(model.JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE, data); err != nil {
+		return nil, err
+	} else {
+		return job, nil
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package interfaces
+
+import "github.com/mattermost/mattermost-server/v5/model"
+
+type ChannelMembersDriftJobInterface interface {
+	MakeWorker() model.Worker
+	MakeScheduler() model.Scheduler
+}
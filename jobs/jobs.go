@@ -97,9 +97,18 @@ func (srv *JobServer) SetJobError(job *model.Job, jobError *model.AppError) *mod
 		}
 	}
 
+	if srv.jobErrorListener != nil {
+		srv.jobErrorListener(job, jobError)
+	}
+
 	return nil
 }
 
+// SetJobErrorListener sets a function to be called whenever a job fails with an error.
+func (srv *JobServer) SetJobErrorListener(listener func(job *model.Job, jobError *model.AppError)) {
+	srv.jobErrorListener = listener
+}
+
 func (srv *JobServer) SetJobCanceled(job *model.Job) *model.AppError {
 	if _, err := srv.Store.Job().UpdateStatus(job.Id, model.JOB_STATUS_CANCELED); err != nil {
 		return err
@@ -135,6 +135,27 @@ func (watcher *Watcher) PollAndNotify() {
 				default:
 				}
 			}
+		} else if job.Type == model.JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE {
+			if watcher.workers.InactiveChannelArchive != nil {
+				select {
+				case watcher.workers.InactiveChannelArchive.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_EDIT_RETENTION {
+			if watcher.workers.EditRetention != nil {
+				select {
+				case watcher.workers.EditRetention.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_CHANNEL_MEMBERS_DRIFT {
+			if watcher.workers.ChannelMembersDrift != nil {
+				select {
+				case watcher.workers.ChannelMembersDrift.JobChannel() <- *job:
+				default:
+				}
+			}
 		}
 	}
 }
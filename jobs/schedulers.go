@@ -66,6 +66,18 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 		schedulers.schedulers = append(schedulers.schedulers, expiryNotifyInterface.MakeScheduler())
 	}
 
+	if inactiveChannelArchiveInterface := srv.InactiveChannelArchive; inactiveChannelArchiveInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, inactiveChannelArchiveInterface.MakeScheduler())
+	}
+
+	if editRetentionInterface := srv.EditRetention; editRetentionInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, editRetentionInterface.MakeScheduler())
+	}
+
+	if channelMembersDriftInterface := srv.ChannelMembersDrift; channelMembersDriftInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, channelMembersDriftInterface.MakeScheduler())
+	}
+
 	schedulers.nextRunTimes = make([]*time.Time, len(schedulers.schedulers))
 	return schedulers
 }
@@ -26,6 +26,11 @@ type JobServer struct {
 	Plugins                 tjobs.PluginsJobInterface
 	BleveIndexer            tjobs.IndexerJobInterface
 	ExpiryNotify            tjobs.ExpiryNotifyJobInterface
+	InactiveChannelArchive  tjobs.InactiveChannelArchiveJobInterface
+	EditRetention           tjobs.EditRetentionJobInterface
+	ChannelMembersDrift     tjobs.ChannelMembersDriftJobInterface
+
+	jobErrorListener func(job *model.Job, jobError *model.AppError)
 }
 
 func NewJobServer(configService configservice.ConfigService, store store.Store) *JobServer {
@@ -25,6 +25,9 @@ type Workers struct {
 	Plugins                  model.Worker
 	BleveIndexing            model.Worker
 	ExpiryNotify             model.Worker
+	InactiveChannelArchive   model.Worker
+	EditRetention            model.Worker
+	ChannelMembersDrift      model.Worker
 
 	listenerId string
 }
@@ -70,6 +73,18 @@ func (srv *JobServer) InitWorkers() *Workers {
 	if expiryNotifyInterface := srv.ExpiryNotify; expiryNotifyInterface != nil {
 		workers.ExpiryNotify = expiryNotifyInterface.MakeWorker()
 	}
+
+	if inactiveChannelArchiveInterface := srv.InactiveChannelArchive; inactiveChannelArchiveInterface != nil {
+		workers.InactiveChannelArchive = inactiveChannelArchiveInterface.MakeWorker()
+	}
+
+	if editRetentionInterface := srv.EditRetention; editRetentionInterface != nil {
+		workers.EditRetention = editRetentionInterface.MakeWorker()
+	}
+
+	if channelMembersDriftInterface := srv.ChannelMembersDrift; channelMembersDriftInterface != nil {
+		workers.ChannelMembersDrift = channelMembersDriftInterface.MakeWorker()
+	}
 	return workers
 }
 
@@ -113,6 +128,18 @@ func (workers *Workers) Start() *Workers {
 			go workers.ExpiryNotify.Run()
 		}
 
+		if workers.InactiveChannelArchive != nil {
+			go workers.InactiveChannelArchive.Run()
+		}
+
+		if workers.EditRetention != nil {
+			go workers.EditRetention.Run()
+		}
+
+		if workers.ChannelMembersDrift != nil {
+			go workers.ChannelMembersDrift.Run()
+		}
+
 		go workers.Watcher.Start()
 	})
 
@@ -214,6 +241,18 @@ func (workers *Workers) Stop() *Workers {
 		workers.ExpiryNotify.Stop()
 	}
 
+	if workers.InactiveChannelArchive != nil {
+		workers.InactiveChannelArchive.Stop()
+	}
+
+	if workers.EditRetention != nil {
+		workers.EditRetention.Stop()
+	}
+
+	if workers.ChannelMembersDrift != nil {
+		workers.ChannelMembersDrift.Stop()
+	}
+
 	mlog.Info("Stopped workers")
 
 	return workers
@@ -102,7 +102,11 @@ func manualTest(c *web.Context, w http.ResponseWriter, r *http.Request) {
 		}
 
 		c.App.Srv().Store.User().VerifyEmail(user.Id, user.Email)
-		c.App.Srv().Store.Team().SaveMember(&model.TeamMember{TeamId: teamID, UserId: user.Id}, *c.App.Config().TeamSettings.MaxUsersPerTeam)
+		maxUsersPerTeam := *c.App.Config().TeamSettings.MaxUsersPerTeam
+		if maxUsersPerTeam == 0 {
+			maxUsersPerTeam = -1
+		}
+		c.App.Srv().Store.Team().SaveMember(&model.TeamMember{TeamId: teamID, UserId: user.Id}, maxUsersPerTeam)
 
 		userID = user.Id
 
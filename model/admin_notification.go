@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ADMIN_NOTIFICATION_TYPE_USER_DEACTIVATED           = "user_deactivated"
+	ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED = "plugin_health_check_failed"
+	ADMIN_NOTIFICATION_TYPE_LICENSE_EXPIRING           = "license_expiring"
+	ADMIN_NOTIFICATION_TYPE_JOB_FAILED                 = "job_failed"
+	ADMIN_NOTIFICATION_TYPE_CONFIG_SAVE_FAILED         = "config_save_failed"
+	ADMIN_NOTIFICATION_TYPE_CERTIFICATE_EXPIRING       = "certificate_expiring"
+
+	ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES = 4000
+
+	// Identical notifications raised within this window are collapsed into a single one.
+	ADMIN_NOTIFICATION_DEDUPLICATION_WINDOW = 60 * 60 * 1000
+)
+
+// AdminNotification is an event that system admins should know about, such as a plugin crashing
+// or a job failing. Count is the number of times the same event was raised in a row.
+type AdminNotification struct {
+	Id       string `json:"id"`
+	CreateAt int64  `json:"create_at"`
+	UpdateAt int64  `json:"update_at"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+	Count    int64  `json:"count"`
+	PostId   string `json:"post_id"`
+}
+
+func IsValidAdminNotificationType(notificationType string) bool {
+	switch notificationType {
+	case ADMIN_NOTIFICATION_TYPE_USER_DEACTIVATED,
+		ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED,
+		ADMIN_NOTIFICATION_TYPE_LICENSE_EXPIRING,
+		ADMIN_NOTIFICATION_TYPE_JOB_FAILED,
+		ADMIN_NOTIFICATION_TYPE_CONFIG_SAVE_FAILED,
+		ADMIN_NOTIFICATION_TYPE_CERTIFICATE_EXPIRING:
+		return true
+	}
+
+	return false
+}
+
+func (o *AdminNotification) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidAdminNotificationType(o.Type) {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Message == "" || utf8.RuneCountInString(o.Message) > ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.message.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Count < 1 {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.count.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.PostId != "" && !IsValidId(o.PostId) {
+		return NewAppError("AdminNotification.IsValid", "model.admin_notification.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *AdminNotification) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if o.Count == 0 {
+		o.Count = 1
+	}
+}
+
+func (o *AdminNotification) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *AdminNotification) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AdminNotificationsToJson(o []*AdminNotification) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AdminNotificationsFromJson(data io.Reader) []*AdminNotification {
+	var o []*AdminNotification
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
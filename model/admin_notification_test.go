@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminNotificationIsValid(t *testing.T) {
+	notification := &AdminNotification{
+		Type:    ADMIN_NOTIFICATION_TYPE_JOB_FAILED,
+		Message: "job failed",
+	}
+	notification.PreSave()
+	require.Nil(t, notification.IsValid())
+	assert.Equal(t, int64(1), notification.Count)
+
+	testCases := []struct {
+		Description string
+		Modify      func(n *AdminNotification)
+	}{
+		{"invalid id", func(n *AdminNotification) { n.Id = "junk" }},
+		{"no create at", func(n *AdminNotification) { n.CreateAt = 0 }},
+		{"no update at", func(n *AdminNotification) { n.UpdateAt = 0 }},
+		{"unknown type", func(n *AdminNotification) { n.Type = "unknown" }},
+		{"empty message", func(n *AdminNotification) { n.Message = "" }},
+		{"message too long", func(n *AdminNotification) { n.Message = strings.Repeat("a", ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES+1) }},
+		{"no count", func(n *AdminNotification) { n.Count = 0 }},
+		{"invalid post id", func(n *AdminNotification) { n.PostId = "junk" }},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			invalid := *notification
+			testCase.Modify(&invalid)
+			assert.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestAdminNotificationsJson(t *testing.T) {
+	notification := &AdminNotification{
+		Type:    ADMIN_NOTIFICATION_TYPE_LICENSE_EXPIRING,
+		Message: "license expiring",
+		PostId:  NewId(),
+	}
+	notification.PreSave()
+
+	notifications := AdminNotificationsFromJson(strings.NewReader(AdminNotificationsToJson([]*AdminNotification{notification})))
+	require.Len(t, notifications, 1)
+	assert.Equal(t, notification, notifications[0])
+}
@@ -16,6 +16,9 @@ const (
 	BOT_DISPLAY_NAME_MAX_RUNES = USER_FIRST_NAME_MAX_RUNES
 	BOT_DESCRIPTION_MAX_RUNES  = 1024
 	BOT_CREATOR_ID_MAX_RUNES   = KEY_VALUE_PLUGIN_ID_MAX_RUNES // UserId or PluginId
+
+	// SYSTEM_BOT_USERNAME is the username of the bot the messages sent by the server itself are posted as.
+	SYSTEM_BOT_USERNAME = "system-bot"
 )
 
 // Bot is a special type of User meant for programmatic interactions.
@@ -45,8 +48,10 @@ type BotGetOptions struct {
 	OwnerId        string
 	IncludeDeleted bool
 	OnlyOrphaned   bool
-	Page           int
-	PerPage        int
+	// IncludeSystemBot includes the system bot, which can't be managed like other bots.
+	IncludeSystemBot bool
+	Page             int
+	PerPage          int
 }
 
 // BotList is a list of bots.
@@ -34,23 +34,29 @@ const (
 )
 
 type Channel struct {
-	Id               string                 `json:"id"`
-	CreateAt         int64                  `json:"create_at"`
-	UpdateAt         int64                  `json:"update_at"`
-	DeleteAt         int64                  `json:"delete_at"`
-	TeamId           string                 `json:"team_id"`
-	Type             string                 `json:"type"`
-	DisplayName      string                 `json:"display_name"`
-	Name             string                 `json:"name"`
-	Header           string                 `json:"header"`
-	Purpose          string                 `json:"purpose"`
-	LastPostAt       int64                  `json:"last_post_at"`
-	TotalMsgCount    int64                  `json:"total_msg_count"`
-	ExtraUpdateAt    int64                  `json:"extra_update_at"`
-	CreatorId        string                 `json:"creator_id"`
-	SchemeId         *string                `json:"scheme_id"`
-	Props            map[string]interface{} `json:"props" db:"-"`
-	GroupConstrained *bool                  `json:"group_constrained"`
+	Id                                      string                 `json:"id"`
+	CreateAt                                int64                  `json:"create_at"`
+	UpdateAt                                int64                  `json:"update_at"`
+	DeleteAt                                int64                  `json:"delete_at"`
+	TeamId                                  string                 `json:"team_id"`
+	Type                                    string                 `json:"type"`
+	DisplayName                             string                 `json:"display_name"`
+	Name                                    string                 `json:"name"`
+	Header                                  string                 `json:"header"`
+	Purpose                                 string                 `json:"purpose"`
+	LastPostAt                              int64                  `json:"last_post_at"`
+	TotalMsgCount                           int64                  `json:"total_msg_count"`
+	ExtraUpdateAt                           int64                  `json:"extra_update_at"`
+	CreatorId                               string                 `json:"creator_id"`
+	SchemeId                                *string                `json:"scheme_id"`
+	Props                                   map[string]interface{} `json:"props" db:"-"`
+	GroupConstrained                        *bool                  `json:"group_constrained"`
+	SuppressJoinLeaveMessages               *bool                  `json:"suppress_join_leave_messages"`
+	ExperimentalHideChannelFromPublicSearch *bool                  `json:"experimental_hide_channel_from_public_search"`
+	ExcludeFromAutoArchive                  *bool                  `json:"exclude_from_auto_archive"`
+	DisableFileAttachments                  *bool                  `json:"disable_file_attachments"`
+	ParticipantIds                          []string               `json:"participant_ids,omitempty" db:"-"`
+	IsReadOnly                              *bool                  `json:"is_read_only,omitempty" db:"-"`
 }
 
 type ChannelWithTeamData struct {
@@ -65,12 +71,30 @@ type ChannelsWithCount struct {
 	TotalCount int64                    `json:"total_count"`
 }
 
+// ChannelWithActivity is a channel along with its number of members, as used when managing the
+// channels of a team. The channel's LastPostAt tells when it was last active.
+type ChannelWithActivity struct {
+	Channel
+	MemberCount int64 `json:"member_count"`
+}
+
+// ChannelActivityOpts describes which channels of a team to list along with their activity, and in
+// which order.
+type ChannelActivityOpts struct {
+	IncludeDeleted bool
+	SortByActivity bool
+}
+
 type ChannelPatch struct {
-	DisplayName      *string `json:"display_name"`
-	Name             *string `json:"name"`
-	Header           *string `json:"header"`
-	Purpose          *string `json:"purpose"`
-	GroupConstrained *bool   `json:"group_constrained"`
+	DisplayName                             *string `json:"display_name"`
+	Name                                    *string `json:"name"`
+	Header                                  *string `json:"header"`
+	Purpose                                 *string `json:"purpose"`
+	GroupConstrained                        *bool   `json:"group_constrained"`
+	SuppressJoinLeaveMessages               *bool   `json:"suppress_join_leave_messages"`
+	ExperimentalHideChannelFromPublicSearch *bool   `json:"experimental_hide_channel_from_public_search"`
+	ExcludeFromAutoArchive                  *bool   `json:"exclude_from_auto_archive"`
+	DisableFileAttachments                  *bool   `json:"disable_file_attachments"`
 }
 
 type ChannelForExport struct {
@@ -289,6 +313,22 @@ func (o *Channel) Patch(patch *ChannelPatch) {
 	if patch.GroupConstrained != nil {
 		o.GroupConstrained = patch.GroupConstrained
 	}
+
+	if patch.SuppressJoinLeaveMessages != nil {
+		o.SuppressJoinLeaveMessages = patch.SuppressJoinLeaveMessages
+	}
+
+	if patch.ExperimentalHideChannelFromPublicSearch != nil {
+		o.ExperimentalHideChannelFromPublicSearch = patch.ExperimentalHideChannelFromPublicSearch
+	}
+
+	if patch.ExcludeFromAutoArchive != nil {
+		o.ExcludeFromAutoArchive = patch.ExcludeFromAutoArchive
+	}
+
+	if patch.DisableFileAttachments != nil {
+		o.DisableFileAttachments = patch.DisableFileAttachments
+	}
 }
 
 func (o *Channel) MakeNonNil() {
@@ -307,6 +347,30 @@ func (o *Channel) IsGroupConstrained() bool {
 	return o.GroupConstrained != nil && *o.GroupConstrained
 }
 
+// IsJoinLeaveMessagesSuppressed returns true if join, leave, add and remove system messages
+// should not be posted to the channel.
+func (o *Channel) IsJoinLeaveMessagesSuppressed() bool {
+	return o.SuppressJoinLeaveMessages != nil && *o.SuppressJoinLeaveMessages
+}
+
+// IsHiddenFromPublicSearch returns true if the channel should not be listed in the team's
+// public channel directory.
+func (o *Channel) IsHiddenFromPublicSearch() bool {
+	return o.ExperimentalHideChannelFromPublicSearch != nil && *o.ExperimentalHideChannelFromPublicSearch
+}
+
+// IsExcludedFromAutoArchive returns true if the channel must never be archived by the
+// inactive channel archive job.
+func (o *Channel) IsExcludedFromAutoArchive() bool {
+	return o.ExcludeFromAutoArchive != nil && *o.ExcludeFromAutoArchive
+}
+
+// IsFileAttachmentsDisabled returns true if files can't be uploaded to the channel or attached to
+// its posts.
+func (o *Channel) IsFileAttachmentsDisabled() bool {
+	return o.DisableFileAttachments != nil && *o.DisableFileAttachments
+}
+
 func (o *Channel) GetOtherUserIdForDM(userId string) string {
 	if o.Type != CHANNEL_DIRECT {
 		return ""
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ChannelArchiveResult is the outcome of archiving one channel of a bulk archive. Error is set when
+// the channel was skipped or failed to be archived.
+type ChannelArchiveResult struct {
+	ChannelId string    `json:"channel_id"`
+	Archived  bool      `json:"archived"`
+	Error     *AppError `json:"error,omitempty"`
+}
+
+func ChannelArchiveResultsToJson(o []*ChannelArchiveResult) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelArchiveResultsFromJson(data io.Reader) []*ChannelArchiveResult {
+	var o []*ChannelArchiveResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	CHANNEL_BOOKMARK_TYPE_LINK = "link"
+	CHANNEL_BOOKMARK_TYPE_FILE = "file"
+
+	CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES = 64
+	CHANNEL_BOOKMARK_LINK_URL_MAX_RUNES     = 1024
+	CHANNEL_BOOKMARK_EMOJI_MAX_RUNES        = 64
+)
+
+// ChannelBookmark is a link or file pinned to the top of a channel.
+type ChannelBookmark struct {
+	Id          string `json:"id"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+	DeleteAt    int64  `json:"delete_at"`
+	ChannelId   string `json:"channel_id"`
+	OwnerId     string `json:"owner_id"`
+	FileId      string `json:"file_id"`
+	DisplayName string `json:"display_name"`
+	SortOrder   int64  `json:"sort_order"`
+	LinkUrl     string `json:"link_url,omitempty"`
+	Emoji       string `json:"emoji,omitempty"`
+	Type        string `json:"type"`
+}
+
+// ChannelBookmarkPatch is a description of what fields to update on an existing bookmark.
+type ChannelBookmarkPatch struct {
+	FileId      *string `json:"file_id"`
+	DisplayName *string `json:"display_name"`
+	LinkUrl     *string `json:"link_url"`
+	Emoji       *string `json:"emoji"`
+}
+
+// IsValid validates the bookmark and returns an error if it isn't configured correctly.
+func (o *ChannelBookmark) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.OwnerId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.owner_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.DisplayName == "" || utf8.RuneCountInString(o.DisplayName) > CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.display_name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Emoji) > CHANNEL_BOOKMARK_EMOJI_MAX_RUNES {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.emoji.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Type {
+	case CHANNEL_BOOKMARK_TYPE_LINK:
+		if o.FileId != "" || !IsValidHttpUrl(o.LinkUrl) || utf8.RuneCountInString(o.LinkUrl) > CHANNEL_BOOKMARK_LINK_URL_MAX_RUNES {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.link_url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	case CHANNEL_BOOKMARK_TYPE_FILE:
+		if o.LinkUrl != "" || !IsValidId(o.FileId) {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.file_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PreSave should be run before saving a new bookmark to the database.
+func (o *ChannelBookmark) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+	o.DeleteAt = 0
+}
+
+// PreUpdate should be run before saving an updated bookmark to the database.
+func (o *ChannelBookmark) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// Patch modifies an existing bookmark with optional fields from the given patch.
+func (o *ChannelBookmark) Patch(patch *ChannelBookmarkPatch) {
+	if patch.FileId != nil {
+		o.FileId = *patch.FileId
+	}
+
+	if patch.DisplayName != nil {
+		o.DisplayName = *patch.DisplayName
+	}
+
+	if patch.LinkUrl != nil {
+		o.LinkUrl = *patch.LinkUrl
+	}
+
+	if patch.Emoji != nil {
+		o.Emoji = *patch.Emoji
+	}
+}
+
+func (o *ChannelBookmark) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelBookmarkFromJson(data io.Reader) *ChannelBookmark {
+	var o *ChannelBookmark
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ChannelBookmarksToJson(o []*ChannelBookmark) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelBookmarksFromJson(data io.Reader) []*ChannelBookmark {
+	var o []*ChannelBookmark
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *ChannelBookmarkPatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelBookmarkPatchFromJson(data io.Reader) *ChannelBookmarkPatch {
+	var o *ChannelBookmarkPatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBookmarkIsValid(t *testing.T) {
+	newBookmark := func() *ChannelBookmark {
+		bookmark := &ChannelBookmark{
+			ChannelId:   NewId(),
+			OwnerId:     NewId(),
+			DisplayName: "Runbook",
+			LinkUrl:     "https://example.com/runbook",
+			Type:        CHANNEL_BOOKMARK_TYPE_LINK,
+		}
+		bookmark.PreSave()
+		return bookmark
+	}
+
+	testCases := []struct {
+		Description string
+		Modify      func(b *ChannelBookmark)
+		Valid       bool
+	}{
+		{"valid link", func(b *ChannelBookmark) {}, true},
+		{"valid file", func(b *ChannelBookmark) {
+			b.Type = CHANNEL_BOOKMARK_TYPE_FILE
+			b.LinkUrl = ""
+			b.FileId = NewId()
+		}, true},
+		{"invalid id", func(b *ChannelBookmark) { b.Id = "junk" }, false},
+		{"missing create at", func(b *ChannelBookmark) { b.CreateAt = 0 }, false},
+		{"missing update at", func(b *ChannelBookmark) { b.UpdateAt = 0 }, false},
+		{"invalid channel id", func(b *ChannelBookmark) { b.ChannelId = "junk" }, false},
+		{"invalid owner id", func(b *ChannelBookmark) { b.OwnerId = "" }, false},
+		{"empty display name", func(b *ChannelBookmark) { b.DisplayName = "" }, false},
+		{"long display name", func(b *ChannelBookmark) {
+			b.DisplayName = strings.Repeat("a", CHANNEL_BOOKMARK_DISPLAY_NAME_MAX_RUNES+1)
+		}, false},
+		{"long emoji", func(b *ChannelBookmark) { b.Emoji = strings.Repeat("a", CHANNEL_BOOKMARK_EMOJI_MAX_RUNES+1) }, false},
+		{"link without url", func(b *ChannelBookmark) { b.LinkUrl = "" }, false},
+		{"link with non http url", func(b *ChannelBookmark) { b.LinkUrl = "ftp://example.com" }, false},
+		{"link with file", func(b *ChannelBookmark) { b.FileId = NewId() }, false},
+		{"file without file id", func(b *ChannelBookmark) {
+			b.Type = CHANNEL_BOOKMARK_TYPE_FILE
+			b.LinkUrl = ""
+		}, false},
+		{"file with url", func(b *ChannelBookmark) {
+			b.Type = CHANNEL_BOOKMARK_TYPE_FILE
+			b.FileId = NewId()
+		}, false},
+		{"unknown type", func(b *ChannelBookmark) { b.Type = "folder" }, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			bookmark := newBookmark()
+			testCase.Modify(bookmark)
+			if testCase.Valid {
+				assert.Nil(t, bookmark.IsValid())
+			} else {
+				assert.NotNil(t, bookmark.IsValid())
+			}
+		})
+	}
+}
+
+func TestChannelBookmarkPatch(t *testing.T) {
+	bookmark := &ChannelBookmark{
+		DisplayName: "Runbook",
+		LinkUrl:     "https://example.com/runbook",
+		Emoji:       "book",
+	}
+
+	bookmark.Patch(&ChannelBookmarkPatch{
+		DisplayName: NewString("Playbook"),
+		Emoji:       NewString(""),
+	})
+
+	assert.Equal(t, "Playbook", bookmark.DisplayName)
+	assert.Equal(t, "https://example.com/runbook", bookmark.LinkUrl)
+	assert.Equal(t, "", bookmark.Emoji)
+}
+
+func TestChannelBookmarkJson(t *testing.T) {
+	bookmark := &ChannelBookmark{
+		Id:          NewId(),
+		ChannelId:   NewId(),
+		DisplayName: "Runbook",
+		LinkUrl:     "https://example.com/runbook",
+		Type:        CHANNEL_BOOKMARK_TYPE_LINK,
+	}
+
+	result := ChannelBookmarkFromJson(strings.NewReader(bookmark.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, bookmark, result)
+
+	results := ChannelBookmarksFromJson(strings.NewReader(ChannelBookmarksToJson([]*ChannelBookmark{bookmark})))
+	require.Len(t, results, 1)
+	assert.Equal(t, bookmark, results[0])
+}
@@ -93,3 +93,14 @@ func ChannelListWithTeamDataFromJson(data io.Reader) *ChannelListWithTeamData {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+func ChannelsWithActivityToJson(o []*ChannelWithActivity) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelsWithActivityFromJson(data io.Reader) []*ChannelWithActivity {
+	var o []*ChannelWithActivity
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -54,6 +54,10 @@ type ChannelMember struct {
 	SchemeUser    bool      `json:"scheme_user"`
 	SchemeAdmin   bool      `json:"scheme_admin"`
 	ExplicitRoles string    `json:"explicit_roles"`
+	IsMuted       *bool     `json:"is_muted,omitempty"`
+	// ManuallyManaged marks a channel admin that the group sync leaves in a group-constrained
+	// channel, although they aren't a member of any of its groups.
+	ManuallyManaged bool `json:"manually_managed"`
 }
 
 type ChannelMembers []ChannelMember
@@ -160,10 +164,39 @@ func (o *ChannelMember) PreUpdate() {
 	o.LastUpdateAt = GetMillis()
 }
 
+// GetRoles returns the member's roles as a list, ignoring any extra whitespace.
 func (o *ChannelMember) GetRoles() []string {
 	return strings.Fields(o.Roles)
 }
 
+// SetRoles sets the member's roles from a list.
+func (o *ChannelMember) SetRoles(roles []string) {
+	o.Roles = strings.Join(roles, " ")
+}
+
+// IsExemptFromGroupSync returns true if the group sync leaves the member in a group-constrained
+// channel, and keeps their admin role, whatever their group memberships.
+func (o *ChannelMember) IsExemptFromGroupSync() bool {
+	return o.SchemeAdmin && o.ManuallyManaged
+}
+
+// IsChannelMuted returns true if the member has muted the channel.
+func (o *ChannelMember) IsChannelMuted() bool {
+	return o.NotifyProps[MARK_UNREAD_NOTIFY_PROP] == CHANNEL_MARK_UNREAD_MENTION
+}
+
+// SetIsMuted sets the computed IsMuted field from the member's notify props.
+func (o *ChannelMember) SetIsMuted() {
+	o.IsMuted = NewBool(o.IsChannelMuted())
+}
+
+// SetIsMuted sets the computed IsMuted field of each member.
+func (o *ChannelMembers) SetIsMuted() {
+	for i := range *o {
+		(*o)[i].SetIsMuted()
+	}
+}
+
 func IsChannelNotifyLevelValid(notifyLevel string) bool {
 	return notifyLevel == CHANNEL_NOTIFY_DEFAULT ||
 		notifyLevel == CHANNEL_NOTIFY_ALL ||
@@ -3,14 +3,30 @@
 
 package model
 
+import (
+	"encoding/json"
+	"io"
+)
+
 type ChannelMemberHistoryResult struct {
-	ChannelId string
-	UserId    string
-	JoinTime  int64
-	LeaveTime *int64
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	JoinTime  int64  `json:"join_time"`
+	LeaveTime *int64 `json:"leave_time"`
 
 	// these two fields are never set in the database - when we SELECT, we join on Users to get them
-	UserEmail string `db:"Email"`
-	Username  string
-	IsBot     bool
+	UserEmail string `json:"user_email" db:"Email"`
+	Username  string `json:"username"`
+	IsBot     bool   `json:"is_bot"`
+}
+
+func ChannelMemberHistoryResultListToJson(l []*ChannelMemberHistoryResult) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelMemberHistoryResultListFromJson(data io.Reader) []*ChannelMemberHistoryResult {
+	var o []*ChannelMemberHistoryResult
+	json.NewDecoder(data).Decode(&o)
+	return o
 }
@@ -48,6 +48,57 @@ func TestChannelMemberIsValid(t *testing.T) {
 	require.Error(t, o.IsValid(), "should be invalid")
 }
 
+func TestChannelMemberSetIsMuted(t *testing.T) {
+	members := ChannelMembers{
+		{NotifyProps: StringMap{MARK_UNREAD_NOTIFY_PROP: CHANNEL_MARK_UNREAD_MENTION}},
+		{NotifyProps: StringMap{MARK_UNREAD_NOTIFY_PROP: CHANNEL_MARK_UNREAD_ALL}},
+		{},
+	}
+
+	members.SetIsMuted()
+
+	require.True(t, *members[0].IsMuted)
+	require.False(t, *members[1].IsMuted)
+	require.False(t, *members[2].IsMuted)
+	require.Contains(t, members[0].ToJson(), `"is_muted":true`)
+}
+
+func TestChannelMemberRoles(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Roles       string
+		Expected    []string
+	}{
+		{"empty", "", []string{}},
+		{"only whitespace", "   ", []string{}},
+		{"single role", "channel_user", []string{"channel_user"}},
+		{"multiple roles", "channel_user channel_admin", []string{"channel_user", "channel_admin"}},
+		{"extra whitespace", "  channel_user   channel_admin ", []string{"channel_user", "channel_admin"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			o := ChannelMember{Roles: testCase.Roles}
+			require.Equal(t, testCase.Expected, o.GetRoles())
+
+			o.SetRoles(o.GetRoles())
+			require.Equal(t, strings.Join(testCase.Expected, " "), o.Roles)
+			require.Equal(t, testCase.Expected, o.GetRoles())
+		})
+	}
+
+	o := ChannelMember{}
+	o.SetRoles(nil)
+	require.Equal(t, "", o.Roles)
+}
+
+func TestChannelMemberIsExemptFromGroupSync(t *testing.T) {
+	require.False(t, (&ChannelMember{}).IsExemptFromGroupSync())
+	require.False(t, (&ChannelMember{SchemeAdmin: true}).IsExemptFromGroupSync())
+	require.False(t, (&ChannelMember{ManuallyManaged: true}).IsExemptFromGroupSync())
+	require.True(t, (&ChannelMember{SchemeAdmin: true, ManuallyManaged: true}).IsExemptFromGroupSync())
+}
+
 func TestChannelUnreadJson(t *testing.T) {
 	o := ChannelUnread{ChannelId: NewId(), TeamId: NewId(), MsgCount: 5, MentionCount: 3}
 	json := o.ToJson()
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ChannelMembersDrift lists the members of a group-constrained channel that aren't members of any of
+// the channel's groups, and so should have been removed by the group sync.
+type ChannelMembersDrift struct {
+	ChannelId string `json:"channel_id"`
+	// UserIds are the members to remove to bring the channel back in line with its groups.
+	UserIds []string `json:"user_ids"`
+	// ExemptUserIds are the channel admins flagged as manually managed that aren't members of the
+	// groups either. The group sync leaves them in the channel, so they are kept.
+	ExemptUserIds []string `json:"exempt_user_ids"`
+}
+
+func (o *ChannelMembersDrift) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMembersDriftFromJson(data io.Reader) *ChannelMembersDrift {
+	var o *ChannelMembersDrift
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ChannelPolicyWithChannelInfo is the permission scheme a channel overrides the scheme of its team
+// with, along with the names of the channel and its team.
+type ChannelPolicyWithChannelInfo struct {
+	ChannelId          string `json:"channel_id"`
+	ChannelName        string `json:"channel_name"`
+	ChannelDisplayName string `json:"channel_display_name"`
+	ChannelType        string `json:"channel_type"`
+	TeamId             string `json:"team_id"`
+	TeamName           string `json:"team_name"`
+	TeamDisplayName    string `json:"team_display_name"`
+
+	SchemeId                string `json:"scheme_id"`
+	SchemeName              string `json:"scheme_name"`
+	SchemeDisplayName       string `json:"scheme_display_name"`
+	DefaultChannelAdminRole string `json:"default_channel_admin_role"`
+	DefaultChannelUserRole  string `json:"default_channel_user_role"`
+	DefaultChannelGuestRole string `json:"default_channel_guest_role"`
+}
+
+func ChannelPolicyWithChannelInfoListToJson(l []*ChannelPolicyWithChannelInfo) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ChannelPolicyWithChannelInfoListFromJson(data io.Reader) []*ChannelPolicyWithChannelInfo {
+	var o []*ChannelPolicyWithChannelInfo
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -35,6 +35,16 @@ const (
 	SidebarCategorySortAlphabetical SidebarCategorySorting = "alpha"
 )
 
+// IsValidSidebarSorting returns whether the sorting is one of the modes a user can choose for their
+// sidebar, which excludes SidebarCategorySortDefault.
+func IsValidSidebarSorting(sorting string) bool {
+	switch SidebarCategorySorting(sorting) {
+	case SidebarCategorySortManual, SidebarCategorySortRecent, SidebarCategorySortAlphabetical:
+		return true
+	}
+	return false
+}
+
 // SidebarCategory represents the corresponding DB table
 // SortOrder is never returned to the user and only used for queries
 type SidebarCategory struct {
@@ -9,10 +9,11 @@ import (
 )
 
 type ChannelStats struct {
-	ChannelId       string `json:"channel_id"`
-	MemberCount     int64  `json:"member_count"`
-	GuestCount      int64  `json:"guest_count"`
-	PinnedPostCount int64  `json:"pinnedpost_count"`
+	ChannelId           string `json:"channel_id"`
+	MemberCount         int64  `json:"member_count"`
+	GuestCount          int64  `json:"guest_count"`
+	PinnedPostCount     int64  `json:"pinnedpost_count"`
+	RecentRootPostCount int64  `json:"recent_root_post_count"`
 }
 
 func (o *ChannelStats) ToJson() string {
@@ -48,6 +48,40 @@ func TestChannelPatch(t *testing.T) {
 	require.Equal(t, *p.Header, o.Header)
 	require.Equal(t, *p.Purpose, o.Purpose)
 	require.Equal(t, *p.GroupConstrained, *o.GroupConstrained)
+	require.Nil(t, o.SuppressJoinLeaveMessages)
+
+	o.Patch(&ChannelPatch{SuppressJoinLeaveMessages: NewBool(true)})
+	require.True(t, *o.SuppressJoinLeaveMessages)
+}
+
+func TestChannelIsHiddenFromPublicSearch(t *testing.T) {
+	o := Channel{}
+	require.False(t, o.IsHiddenFromPublicSearch())
+
+	o.Patch(&ChannelPatch{ExperimentalHideChannelFromPublicSearch: NewBool(true)})
+	require.True(t, o.IsHiddenFromPublicSearch())
+}
+
+func TestChannelIsFileAttachmentsDisabled(t *testing.T) {
+	o := Channel{}
+	require.False(t, o.IsFileAttachmentsDisabled())
+
+	o.Patch(&ChannelPatch{DisableFileAttachments: NewBool(true)})
+	require.True(t, o.IsFileAttachmentsDisabled())
+
+	o.Patch(&ChannelPatch{DisableFileAttachments: NewBool(false)})
+	require.False(t, o.IsFileAttachmentsDisabled())
+}
+
+func TestChannelIsJoinLeaveMessagesSuppressed(t *testing.T) {
+	o := Channel{}
+	require.False(t, o.IsJoinLeaveMessagesSuppressed())
+
+	o.SuppressJoinLeaveMessages = NewBool(false)
+	require.False(t, o.IsJoinLeaveMessagesSuppressed())
+
+	o.SuppressJoinLeaveMessages = NewBool(true)
+	require.True(t, o.IsJoinLeaveMessagesSuppressed())
 }
 
 func TestChannelIsValid(t *testing.T) {
@@ -39,3 +39,23 @@ func ChannelViewResponseFromJson(data io.Reader) *ChannelViewResponse {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+// ChannelLastViewed is where a user stopped reading a channel: the last post they saw and when
+// they last viewed the channel.
+type ChannelLastViewed struct {
+	ChannelId    string `json:"channel_id"`
+	UserId       string `json:"user_id"`
+	PostId       string `json:"post_id"`
+	LastViewedAt int64  `json:"last_viewed_at"`
+}
+
+func (o *ChannelLastViewed) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelLastViewedFromJson(data io.Reader) *ChannelLastViewed {
+	var o *ChannelLastViewed
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
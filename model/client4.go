@@ -31,7 +31,7 @@ const (
 	HEADER_CSRF_TOKEN         = "X-CSRF-Token"
 	HEADER_BEARER             = "BEARER"
 	HEADER_AUTH               = "Authorization"
-	HEADER_REQUESTED_WITH     = "X-Requested-With"
+	HEADER_REQUESTED_WITH     = "X-Requested-With" // Deprecated: send HEADER_CSRF_TOKEN to pass the CSRF check instead.
 	HEADER_REQUESTED_WITH_XML = "XMLHttpRequest"
 	STATUS                    = "status"
 	STATUS_OK                 = "OK"
@@ -285,6 +285,30 @@ func (c *Client4) GetChannelMemberRoute(channelId, userId string) string {
 	return fmt.Sprintf(c.GetChannelMembersRoute(channelId)+"/%v", userId)
 }
 
+func (c *Client4) GetChannelBookmarksRoute(channelId string) string {
+	return fmt.Sprintf(c.GetChannelRoute(channelId) + "/bookmarks")
+}
+
+func (c *Client4) GetChannelBookmarkRoute(channelId, bookmarkId string) string {
+	return fmt.Sprintf(c.GetChannelBookmarksRoute(channelId)+"/%v", bookmarkId)
+}
+
+func (c *Client4) GetSavedSearchesRoute(userId string) string {
+	return fmt.Sprintf(c.GetUserRoute(userId) + "/saved_searches")
+}
+
+func (c *Client4) GetSavedSearchRoute(userId, savedSearchId string) string {
+	return fmt.Sprintf(c.GetSavedSearchesRoute(userId)+"/%v", savedSearchId)
+}
+
+func (c *Client4) GetSavedPostsRoute(userId string) string {
+	return fmt.Sprintf(c.GetUserRoute(userId) + "/saved_posts")
+}
+
+func (c *Client4) GetSavedPostRoute(userId, postId string) string {
+	return fmt.Sprintf(c.GetSavedPostsRoute(userId)+"/%v", postId)
+}
+
 func (c *Client4) GetPostsRoute() string {
 	return "/posts"
 }
@@ -461,6 +485,10 @@ func (c *Client4) GetSchemesRoute() string {
 	return "/schemes"
 }
 
+func (c *Client4) GetPermissionsRoute() string {
+	return "/permissions"
+}
+
 func (c *Client4) GetSchemeRoute(id string) string {
 	return c.GetSchemesRoute() + fmt.Sprintf("/%v", id)
 }
@@ -1144,6 +1172,24 @@ func (c *Client4) UpdateUserMfa(userId, code string, activate bool) (bool, *Resp
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// CheckUserAvailability returns whether both the username and the email, either of which may be
+// left empty, are free to sign up with.
+func (c *Client4) CheckUserAvailability(username, email string) (bool, *Response) {
+	requestBody := map[string]string{"username": username, "email": email}
+	r, err := c.DoApiPost(c.GetUsersRoute()+"/availability", MapToJson(requestBody))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data := StringInterfaceFromJson(r.Body)
+	available, ok := data["available"].(bool)
+	if !ok {
+		return false, BuildResponse(r)
+	}
+	return available, BuildResponse(r)
+}
+
 // CheckUserMfa checks whether a user has MFA active on their account or not based on the
 // provided login id.
 // Deprecated: Clients should use Login method and check for MFA Error
@@ -1985,6 +2031,28 @@ func (c *Client4) GetTeamMembersForUser(userId string, etag string) ([]*TeamMemb
 	return TeamMembersFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTeamMembersWithUnreadForUser returns the team members for a user in the user's team order,
+// along with the unread counts for each team.
+func (c *Client4) GetTeamMembersWithUnreadForUser(userId string, etag string) ([]*TeamMemberWithUnread, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/teams/members", etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TeamMembersWithUnreadFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateTeamsOrderForUser sets the order of the teams in the user's team sidebar. Teams that
+// are left out are appended to the order. The resulting order is returned.
+func (c *Client4) UpdateTeamsOrderForUser(userId string, teamIds []string) ([]string, *Response) {
+	r, err := c.DoApiPut(c.GetUserRoute(userId)+"/teams/order", ArrayToJson(teamIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ArrayFromJson(r.Body), BuildResponse(r)
+}
+
 // GetTeamMembersByIds will return an array of team members based on the
 // team id and a list of user ids provided. Must be authenticated.
 func (c *Client4) GetTeamMembersByIds(teamId string, userIds []string) ([]*TeamMember, *Response) {
@@ -2365,6 +2433,17 @@ func (c *Client4) UpdateChannelPrivacy(channelId string, privacy string) (*Chann
 	return ChannelFromJson(r.Body), BuildResponse(r)
 }
 
+// UpdateChannelReadOnly makes a channel read-only for everyone except its channel admins, or reverts it.
+func (c *Client4) UpdateChannelReadOnly(channelId string, enabled bool) (*Channel, *Response) {
+	requestBody := map[string]bool{"enabled": enabled}
+	r, err := c.DoApiPut(c.GetChannelRoute(channelId)+"/readonly", MapBoolToJson(requestBody))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelFromJson(r.Body), BuildResponse(r)
+}
+
 // RestoreChannel restores a previously deleted channel. Any missing fields are not updated.
 func (c *Client4) RestoreChannel(channelId string) (*Channel, *Response) {
 	r, err := c.DoApiPost(c.GetChannelRoute(channelId)+"/restore", "")
@@ -2417,6 +2496,18 @@ func (c *Client4) GetChannelStats(channelId string, etag string) (*ChannelStats,
 	return ChannelStatsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelMemberHistory returns a page of the memberships of a channel that were joined or left
+// between since and until, in milliseconds.
+func (c *Client4) GetChannelMemberHistory(channelId string, since, until int64, page, perPage int) ([]*ChannelMemberHistoryResult, *Response) {
+	query := fmt.Sprintf("?since=%v&until=%v&page=%v&per_page=%v", since, until, page, perPage)
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/member_history"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMemberHistoryResultListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetChannelMembersTimezones gets a list of timezones for a channel.
 func (c *Client4) GetChannelMembersTimezones(channelId string) ([]string, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/timezones", "")
@@ -2427,6 +2518,17 @@ func (c *Client4) GetChannelMembersTimezones(channelId string) ([]string, *Respo
 	return ArrayFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelMembersDrift gets the members of a group-constrained channel that aren't members of any
+// of its groups.
+func (c *Client4) GetChannelMembersDrift(channelId string) (*ChannelMembersDrift, *Response) {
+	r, err := c.DoApiGet(c.GetChannelMembersRoute(channelId)+"/drift", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembersDriftFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPinnedPosts gets a list of pinned posts.
 func (c *Client4) GetPinnedPosts(channelId string, etag string) (*PostList, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/pinned", etag)
@@ -2459,6 +2561,18 @@ func (c *Client4) GetPublicChannelsForTeam(teamId string, page int, perPage int,
 	return ChannelSliceFromJson(r.Body), BuildResponse(r)
 }
 
+// GetPublicChannelsForTeamIncludingHidden returns a list of public channels based on the provided team id string,
+// including those hidden from the channel directory. Requires the manage_system permission.
+func (c *Client4) GetPublicChannelsForTeamIncludingHidden(teamId string, page int, perPage int, etag string) ([]*Channel, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&include_hidden=true", page, perPage)
+	r, err := c.DoApiGet(c.GetChannelsForTeamRoute(teamId)+query, etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelSliceFromJson(r.Body), BuildResponse(r)
+}
+
 // GetDeletedChannelsForTeam returns a list of public channels based on the provided team id string.
 func (c *Client4) GetDeletedChannelsForTeam(teamId string, page int, perPage int, etag string) ([]*Channel, *Response) {
 	query := fmt.Sprintf("/deleted?page=%v&per_page=%v", page, perPage)
@@ -2470,6 +2584,43 @@ func (c *Client4) GetDeletedChannelsForTeam(teamId string, page int, perPage int
 	return ChannelSliceFromJson(r.Body), BuildResponse(r)
 }
 
+// GetDeletedChannelsForTeamForUserSince returns the channels of a team deleted after the given time
+// that the user is a member of.
+func (c *Client4) GetDeletedChannelsForTeamForUserSince(teamId, userId string, since int64) ([]*Channel, *Response) {
+	query := fmt.Sprintf("/deleted?user_id=%v&since=%v", userId, since)
+	r, err := c.DoApiGet(c.GetChannelsForTeamRoute(teamId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelSliceFromJson(r.Body), BuildResponse(r)
+}
+
+// GetRecentlyDeletedChannelsForTeam returns a page of the archived channels of a team, including
+// private ones, most recently archived first. Requires the manage_system permission.
+func (c *Client4) GetRecentlyDeletedChannelsForTeam(teamId string, page int, perPage int) ([]*Channel, *Response) {
+	query := fmt.Sprintf("/recently_deleted?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetChannelsForTeamRoute(teamId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelSliceFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelsWithActivityForTeam returns a page of the public and private channels of a team along
+// with their number of members. The sort can be "display_name" or "activity", which lists the most
+// recently active channels first. Requires the manage_system permission.
+func (c *Client4) GetChannelsWithActivityForTeam(teamId string, page int, perPage int, includeDeleted bool, sort string) ([]*ChannelWithActivity, *Response) {
+	query := fmt.Sprintf("/activity?page=%v&per_page=%v&include_deleted=%v&sort=%v", page, perPage, includeDeleted, sort)
+	r, err := c.DoApiGet(c.GetChannelsForTeamRoute(teamId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelsWithActivityFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPublicChannelsByIdsForTeam returns a list of public channels based on provided team id string.
 func (c *Client4) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) ([]*Channel, *Response) {
 	r, err := c.DoApiPost(c.GetChannelsForTeamRoute(teamId)+"/ids", ArrayToJson(channelIds))
@@ -2667,6 +2818,16 @@ func (c *Client4) GetChannelUnread(channelId, userId string) (*ChannelUnread, *R
 	return ChannelUnreadFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelLastViewed returns the last post the user saw in a channel and when they last viewed it.
+func (c *Client4) GetChannelLastViewed(channelId, userId string) (*ChannelLastViewed, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+c.GetChannelRoute(channelId)+"/last_viewed", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelLastViewedFromJson(r.Body), BuildResponse(r)
+}
+
 // UpdateChannelRoles will update the roles on a channel for a user.
 func (c *Client4) UpdateChannelRoles(channelId, userId, roles string) (bool, *Response) {
 	requestBody := map[string]string{"roles": roles}
@@ -2688,6 +2849,18 @@ func (c *Client4) UpdateChannelMemberSchemeRoles(channelId string, userId string
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// UpdateChannelMemberManuallyManaged flags a channel admin as manually managed, so that the group
+// sync leaves them in a group-constrained channel.
+func (c *Client4) UpdateChannelMemberManuallyManaged(channelId string, userId string, manuallyManaged bool) (bool, *Response) {
+	requestBody := map[string]interface{}{"manually_managed": manuallyManaged}
+	r, err := c.DoApiPut(c.GetChannelMemberRoute(channelId, userId)+"/manually_managed", StringInterfaceToJson(requestBody))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // UpdateChannelNotifyProps will update the notification properties on a channel for a user.
 func (c *Client4) UpdateChannelNotifyProps(channelId, userId string, props map[string]string) (bool, *Response) {
 	r, err := c.DoApiPut(c.GetChannelMemberRoute(channelId, userId)+"/notify_props", MapToJson(props))
@@ -3195,6 +3368,16 @@ func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo,
 	return FileInfosFromJson(r.Body), BuildResponse(r)
 }
 
+// GetPostEditHistory gets the previous contents of a post, oldest first.
+func (c *Client4) GetPostEditHistory(postId string) ([]*PostEdit, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/edits", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostEditsFromJson(r.Body), BuildResponse(r)
+}
+
 // General/System Section
 
 // GetPing will return ok if the running goRoutines are below the threshold and unhealthy for above.
@@ -3472,6 +3655,50 @@ func (c *Client4) DeleteIncomingWebhook(hookID string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// ValidateIncomingWebhookPayload returns the posts that the payload would create when sent to
+// the incoming webhook, along with any problems with the payload, without posting anything.
+func (c *Client4) ValidateIncomingWebhookPayload(hookID string, payload *IncomingWebhookRequest) (*IncomingWebhookPreview, *Response) {
+	r, err := c.DoApiPost(c.GetIncomingWebhookRoute(hookID)+"/validate", payload.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IncomingWebhookPreviewFromJson(r.Body), BuildResponse(r)
+}
+
+// EnableIncomingWebhookDebugging starts capturing the requests received by the incoming webhook for
+// the next hour.
+func (c *Client4) EnableIncomingWebhookDebugging(hookID string) (*IncomingWebhookDebugSession, *Response) {
+	r, err := c.DoApiPost(c.GetIncomingWebhookRoute(hookID)+"/debug", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IncomingWebhookDebugSessionFromJson(r.Body), BuildResponse(r)
+}
+
+// DisableIncomingWebhookDebugging stops capturing the requests received by the incoming webhook and
+// discards the ones captured so far.
+func (c *Client4) DisableIncomingWebhookDebugging(hookID string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetIncomingWebhookRoute(hookID) + "/debug")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetIncomingWebhookRecentRequests returns the requests captured for the incoming webhook while
+// debugging is enabled for it.
+func (c *Client4) GetIncomingWebhookRecentRequests(hookID string) (*IncomingWebhookDebugSession, *Response) {
+	r, err := c.DoApiGet(c.GetIncomingWebhookRoute(hookID)+"/recent_requests", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IncomingWebhookDebugSessionFromJson(r.Body), BuildResponse(r)
+}
+
 // CreateOutgoingWebhook creates an outgoing webhook for a team or channel.
 func (c *Client4) CreateOutgoingWebhook(hook *OutgoingWebhook) (*OutgoingWebhook, *Response) {
 	r, err := c.DoApiPost(c.GetOutgoingWebhooksRoute(), hook.ToJson())
@@ -3756,6 +3983,18 @@ func (c *Client4) GetComplianceReports(page, perPage int) (Compliances, *Respons
 	return CompliancesFromJson(r.Body), BuildResponse(r)
 }
 
+// GetPostIdsWithFileExtensions returns a page of the ids of the posts with attachments having one of the
+// given extensions, uploaded since the given time.
+func (c *Client4) GetPostIdsWithFileExtensions(extensions []string, since int64, page, perPage int) ([]string, *Response) {
+	query := fmt.Sprintf("?extensions=%v&since=%v&page=%v&per_page=%v", url.QueryEscape(strings.Join(extensions, ",")), since, page, perPage)
+	r, err := c.DoApiGet("/compliance/posts/files"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ArrayFromJson(r.Body), BuildResponse(r)
+}
+
 // GetComplianceReport returns a compliance report.
 func (c *Client4) GetComplianceReport(reportId string) (*Compliance, *Response) {
 	r, err := c.DoApiGet(c.GetComplianceReportRoute(reportId), "")
@@ -4082,6 +4321,65 @@ func (c *Client4) UploadBrandImage(data []byte) (bool, *Response) {
 
 // Logs Section
 
+// GenerateSupportPacket generates a support packet and returns the bytes of the zip archive.
+func (c *Client4) GenerateSupportPacket() ([]byte, *Response) {
+	r, appErr := c.DoApiPost(c.GetSystemRoute()+"/support_packet", "")
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, NewAppError("GenerateSupportPacket", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode))
+	}
+	return data, BuildResponse(r)
+}
+
+// GetAdminNotifications returns a page of the events raised for system admins, most recent first.
+func (c *Client4) GetAdminNotifications(page, perPage int) ([]*AdminNotification, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/notifications"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return AdminNotificationsFromJson(r.Body), BuildResponse(r)
+}
+
+// ReloadCertificates reloads the TLS certificate of the server from its files.
+func (c *Client4) ReloadCertificates() (bool, *Response) {
+	r, err := c.DoApiPost(c.GetSystemRoute()+"/reload_certs", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetRateLimitStatus returns the current rate limit budget of the key, a user id or an IP address,
+// for each class of requests. The header value is the one sent in the header the requests are
+// varied by, if any.
+func (c *Client4) GetRateLimitStatus(key string, headerValue string) ([]*RateLimitStatus, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/rate_limits?key="+url.QueryEscape(key)+"&header="+url.QueryEscape(headerValue), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RateLimitStatusListFromJson(r.Body), BuildResponse(r)
+}
+
+// ResetRateLimit restores the full rate limit budget of the key, a user id or an IP address, given
+// like for GetRateLimitStatus.
+func (c *Client4) ResetRateLimit(key string, headerValue string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetSystemRoute() + "/rate_limits?key=" + url.QueryEscape(key) + "&header=" + url.QueryEscape(headerValue))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // GetLogs page of logs as a string array.
 func (c *Client4) GetLogs(page, perPage int) ([]string, *Response) {
 	query := fmt.Sprintf("?page=%v&logs_per_page=%v", page, perPage)
@@ -4434,6 +4732,17 @@ func (c *Client4) GetUsersStatusesByIds(userIds []string) ([]*Status, *Response)
 	return StatusListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTeamStatuses returns the statuses of the members of a team. Members without a status are
+// offline and left out.
+func (c *Client4) GetTeamStatuses(teamId string) ([]*Status, *Response) {
+	r, err := c.DoApiGet(c.GetTeamRoute(teamId)+"/statuses", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return StatusListFromJson(r.Body), BuildResponse(r)
+}
+
 // UpdateUserStatus sets a user's status based on the provided user id string.
 func (c *Client4) UpdateUserStatus(userId string, userStatus *Status) (*Status, *Response) {
 	r, err := c.DoApiPut(c.GetUserStatusRoute(userId), userStatus.ToJson())
@@ -4800,6 +5109,17 @@ func (c *Client4) GetChannelsForScheme(schemeId string, page int, perPage int) (
 	return *ChannelListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelPolicies gets the channels that have a scheme of their own, along with that scheme and the names of
+// the channel and its team.
+func (c *Client4) GetChannelPolicies(page int, perPage int) ([]*ChannelPolicyWithChannelInfo, *Response) {
+	r, err := c.DoApiGet(c.GetPermissionsRoute()+fmt.Sprintf("/channel_policies?page=%v&per_page=%v", page, perPage), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelPolicyWithChannelInfoListFromJson(r.Body), BuildResponse(r)
+}
+
 // Plugin Section
 
 // UploadPlugin takes an io.Reader stream pointing to the contents of a .tar.gz plugin.
@@ -4953,6 +5273,17 @@ func (c *Client4) DisablePlugin(id string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// GetPluginConfig will return the effective configuration of an installed plugin, with secrets redacted.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) GetPluginConfig(id string) (map[string]interface{}, *Response) {
+	r, err := c.DoApiGet(c.GetPluginRoute(id)+"/config", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return StringInterfaceFromJson(r.Body), BuildResponse(r)
+}
+
 // GetMarketplacePlugins will return a list of plugins that an admin can install.
 // WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
 func (c *Client4) GetMarketplacePlugins(filter *MarketplacePluginFilter) ([]*MarketplacePlugin, *Response) {
@@ -5126,6 +5457,52 @@ func (c *Client4) PatchGroup(groupID string, patch *GroupPatch) (*Group, *Respon
 	return GroupFromJson(r.Body), BuildResponse(r)
 }
 
+// CreateGroup creates a custom group, whose members are managed through the API.
+func (c *Client4) CreateGroup(group *Group) (*Group, *Response) {
+	r, appErr := c.DoApiPost(c.GetGroupsRoute(), group.ToJson())
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+	return GroupFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteGroup deletes a custom group.
+func (c *Client4) DeleteGroup(groupID string) (bool, *Response) {
+	r, appErr := c.DoApiDelete(c.GetGroupRoute(groupID))
+	if appErr != nil {
+		return false, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// UpsertGroupMembers adds the users to a custom group.
+func (c *Client4) UpsertGroupMembers(groupID string, userIds []string) ([]*GroupMember, *Response) {
+	payload := (&GroupModifyMembers{UserIds: userIds}).ToJson()
+	r, appErr := c.DoApiPost(c.GetGroupRoute(groupID)+"/members", payload)
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+	var members []*GroupMember
+	json.NewDecoder(r.Body).Decode(&members)
+	return members, BuildResponse(r)
+}
+
+// DeleteGroupMembers removes the users from a custom group.
+func (c *Client4) DeleteGroupMembers(groupID string, userIds []string) ([]*GroupMember, *Response) {
+	payload := (&GroupModifyMembers{UserIds: userIds}).ToJson()
+	r, appErr := c.DoApiRequest(http.MethodDelete, c.ApiUrl+c.GetGroupRoute(groupID)+"/members", payload, "")
+	if appErr != nil {
+		return nil, BuildErrorResponse(r, appErr)
+	}
+	defer closeBody(r)
+	var members []*GroupMember
+	json.NewDecoder(r.Body).Decode(&members)
+	return members, BuildResponse(r)
+}
+
 func (c *Client4) LinkGroupSyncable(groupID, syncableID string, syncableType GroupSyncableType, patch *GroupSyncablePatch) (*GroupSyncable, *Response) {
 	payload, _ := json.Marshal(patch)
 	url := fmt.Sprintf("%s/link", c.GetGroupSyncableRoute(groupID, syncableID, syncableType))
@@ -5208,6 +5585,57 @@ func (c *Client4) PatchConfig(config *Config) (*Config, *Response) {
 	return ConfigFromJson(r.Body), BuildResponse(r)
 }
 
+// GetConfigHistory returns a page of the configurations saved, most recent first.
+func (c *Client4) GetConfigHistory(page, perPage int) ([]*ConfigHistoryEntry, *Response) {
+	r, err := c.DoApiGet(c.GetConfigRoute()+fmt.Sprintf("/history?page=%v&per_page=%v", page, perPage), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigHistoryEntriesFromJson(r.Body), BuildResponse(r)
+}
+
+// RollbackConfig replaces the active configuration with the previously saved one.
+func (c *Client4) RollbackConfig(configId string) (*Config, *Response) {
+	r, err := c.DoApiPost(c.GetConfigRoute()+"/rollback/"+configId, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigFromJson(r.Body), BuildResponse(r)
+}
+
+// GetFeatureFlags returns every feature flag along with its configured value and override.
+func (c *Client4) GetFeatureFlags() ([]*FeatureFlag, *Response) {
+	r, err := c.DoApiGet(c.GetConfigRoute()+"/feature_flags", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FeatureFlagListFromJson(r.Body), BuildResponse(r)
+}
+
+// SetFeatureFlagOverride overrides the configured value of the feature flag.
+func (c *Client4) SetFeatureFlagOverride(name string, override *FeatureFlagOverride) (*FeatureFlag, *Response) {
+	r, err := c.DoApiPut(c.GetConfigRoute()+"/feature_flags/"+name+"/override", override.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FeatureFlagFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteFeatureFlagOverride removes the override of the feature flag, restoring its configured
+// value.
+func (c *Client4) DeleteFeatureFlagOverride(name string) (*FeatureFlag, *Response) {
+	r, err := c.DoApiDelete(c.GetConfigRoute() + "/feature_flags/" + name + "/override")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return FeatureFlagFromJson(r.Body), BuildResponse(r)
+}
+
 func (c *Client4) GetChannelModerations(channelID string, etag string) ([]*ChannelModeration, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelID)+"/moderations", etag)
 	if err != nil {
@@ -5357,3 +5785,226 @@ func (c *Client4) UpdateSidebarCategoryForTeamForUser(userID, teamID, categoryID
 
 	return cat, BuildResponse(r)
 }
+
+// Channel Bookmarks Section
+
+// GetChannelBookmarks returns the bookmarks of a channel in their sort order.
+func (c *Client4) GetChannelBookmarks(channelId string) ([]*ChannelBookmark, *Response) {
+	r, err := c.DoApiGet(c.GetChannelBookmarksRoute(channelId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarksFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateChannelBookmark adds a bookmark to the end of a channel's bookmarks.
+func (c *Client4) CreateChannelBookmark(bookmark *ChannelBookmark) (*ChannelBookmark, *Response) {
+	r, err := c.DoApiPost(c.GetChannelBookmarksRoute(bookmark.ChannelId), bookmark.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarkFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateChannelBookmark replaces the content of a channel bookmark.
+func (c *Client4) UpdateChannelBookmark(bookmark *ChannelBookmark) (*ChannelBookmark, *Response) {
+	r, err := c.DoApiPut(c.GetChannelBookmarkRoute(bookmark.ChannelId, bookmark.Id), bookmark.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarkFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchChannelBookmark partially updates a channel bookmark.
+func (c *Client4) PatchChannelBookmark(channelId, bookmarkId string, patch *ChannelBookmarkPatch) (*ChannelBookmark, *Response) {
+	r, err := c.DoApiPut(c.GetChannelBookmarkRoute(channelId, bookmarkId)+"/patch", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarkFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteChannelBookmark deletes a channel bookmark.
+func (c *Client4) DeleteChannelBookmark(channelId, bookmarkId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelBookmarkRoute(channelId, bookmarkId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// UpdateChannelBookmarksOrder sets the order of a channel's bookmarks. The list must contain
+// the ids of all of the channel's bookmarks.
+func (c *Client4) UpdateChannelBookmarksOrder(channelId string, bookmarkIds []string) ([]*ChannelBookmark, *Response) {
+	r, err := c.DoApiPut(c.GetChannelBookmarksRoute(channelId)+"/order", ArrayToJson(bookmarkIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelBookmarksFromJson(r.Body), BuildResponse(r)
+}
+
+// Saved Searches Section
+
+// GetSavedSearches returns the saved searches of a user ordered by name.
+func (c *Client4) GetSavedSearches(userId string) ([]*SavedSearch, *Response) {
+	r, err := c.DoApiGet(c.GetSavedSearchesRoute(userId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchesFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSavedSearch returns a saved search of a user.
+func (c *Client4) GetSavedSearch(userId, savedSearchId string) (*SavedSearch, *Response) {
+	r, err := c.DoApiGet(c.GetSavedSearchRoute(userId, savedSearchId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateSavedSearch saves a search for a user.
+func (c *Client4) CreateSavedSearch(savedSearch *SavedSearch) (*SavedSearch, *Response) {
+	r, err := c.DoApiPost(c.GetSavedSearchesRoute(savedSearch.UserId), savedSearch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchSavedSearch partially updates a saved search of a user.
+func (c *Client4) PatchSavedSearch(userId, savedSearchId string, patch *SavedSearchPatch) (*SavedSearch, *Response) {
+	r, err := c.DoApiPut(c.GetSavedSearchRoute(userId, savedSearchId)+"/patch", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedSearchFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteSavedSearch deletes a saved search of a user.
+func (c *Client4) DeleteSavedSearch(userId, savedSearchId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetSavedSearchRoute(userId, savedSearchId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Saved Posts Section
+
+// GetSavedPosts returns a page of the posts saved by a user, most recently saved first.
+func (c *Client4) GetSavedPosts(userId string, page, perPage int) ([]*SavedPost, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetSavedPostsRoute(userId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostsFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSavedPostsWithLabel returns a page of the posts saved by a user under the given label, most
+// recently saved first. An empty label returns the posts saved without one.
+func (c *Client4) GetSavedPostsWithLabel(userId, label string, page, perPage int) ([]*SavedPost, *Response) {
+	query := fmt.Sprintf("?label=%v&page=%v&per_page=%v", url.QueryEscape(label), page, perPage)
+	r, err := c.DoApiGet(c.GetSavedPostsRoute(userId)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostsFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSavedPostLabels returns the labels a user saved posts under.
+func (c *Client4) GetSavedPostLabels(userId string) ([]string, *Response) {
+	r, err := c.DoApiGet(c.GetSavedPostsRoute(userId)+"/labels", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ArrayFromJson(r.Body), BuildResponse(r)
+}
+
+// CreateSavedPost saves a post for a user.
+func (c *Client4) CreateSavedPost(savedPost *SavedPost) (*SavedPost, *Response) {
+	r, err := c.DoApiPost(c.GetSavedPostsRoute(savedPost.UserId), savedPost.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchSavedPost changes the label or the note of a post saved by a user.
+func (c *Client4) PatchSavedPost(userId, postId string, patch *SavedPostPatch) (*SavedPost, *Response) {
+	r, err := c.DoApiPut(c.GetSavedPostRoute(userId, postId)+"/patch", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostFromJson(r.Body), BuildResponse(r)
+}
+
+// DeleteSavedPost unsaves a post for a user.
+func (c *Client4) DeleteSavedPost(userId, postId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetSavedPostRoute(userId, postId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// Post Share Tokens Section
+
+// CreatePostShareToken creates a token granting read access to a post to the members of the team of
+// its channel who aren't members of the channel.
+func (c *Client4) CreatePostShareToken(postId string) (*PostShareToken, *Response) {
+	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/share", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostShareTokenFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostWithShareToken gets a single post using a post share token to read it.
+func (c *Client4) GetPostWithShareToken(postId, shareToken string) (*Post, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"?share_token="+url.QueryEscape(shareToken), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostThreadWithShareToken gets the thread of a post using a post share token to read it.
+func (c *Client4) GetPostThreadWithShareToken(postId, shareToken string) (*PostList, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/thread?share_token="+url.QueryEscape(shareToken), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostListFromJson(r.Body), BuildResponse(r)
+}
+
+// RevokePostShareTokensForChannel revokes every post share token of a channel.
+func (c *Client4) RevokePostShareTokensForChannel(channelId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetChannelRoute(channelId) + "/post_share_tokens")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
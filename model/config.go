@@ -4,6 +4,7 @@
 package model
 
 import (
+	"compress/gzip"
 	"crypto/tls"
 	"encoding/json"
 	"io"
@@ -18,9 +19,15 @@ import (
 	"time"
 
 	"github.com/mattermost/ldap"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
 )
 
 const (
+	// CURRENT_CONFIG_VERSION is the version of the configuration schema understood by this server.
+	// Configurations written with an older version are migrated when loaded.
+	CURRENT_CONFIG_VERSION = 2
+
 	CONN_SECURITY_NONE     = ""
 	CONN_SECURITY_PLAIN    = "PLAIN"
 	CONN_SECURITY_TLS      = "TLS"
@@ -88,24 +95,32 @@ const (
 
 	SITENAME_MAX_LENGTH = 30
 
-	SERVICE_SETTINGS_DEFAULT_SITE_URL           = "http://localhost:8065"
-	SERVICE_SETTINGS_DEFAULT_TLS_CERT_FILE      = ""
-	SERVICE_SETTINGS_DEFAULT_TLS_KEY_FILE       = ""
-	SERVICE_SETTINGS_DEFAULT_READ_TIMEOUT       = 300
-	SERVICE_SETTINGS_DEFAULT_WRITE_TIMEOUT      = 300
-	SERVICE_SETTINGS_DEFAULT_IDLE_TIMEOUT       = 60
-	SERVICE_SETTINGS_DEFAULT_MAX_LOGIN_ATTEMPTS = 10
-	SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM    = ""
-	SERVICE_SETTINGS_DEFAULT_LISTEN_AND_ADDRESS = ":8065"
-	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY     = "2_KtH_W5"
-	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_SECRET  = "3wLVZPiswc3DnaiaFoLkDvB4X0IV6CpMkj4tf2inJRsBY6-FnkT08zGmppWFgeof"
+	SERVICE_SETTINGS_DEFAULT_SITE_URL                         = "http://localhost:8065"
+	SERVICE_SETTINGS_DEFAULT_TLS_CERT_FILE                    = ""
+	SERVICE_SETTINGS_DEFAULT_TLS_KEY_FILE                     = ""
+	SERVICE_SETTINGS_DEFAULT_READ_TIMEOUT                     = 300
+	SERVICE_SETTINGS_DEFAULT_WRITE_TIMEOUT                    = 300
+	SERVICE_SETTINGS_DEFAULT_IDLE_TIMEOUT                     = 60
+	SERVICE_SETTINGS_DEFAULT_MAX_LOGIN_ATTEMPTS               = 10
+	SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM                  = ""
+	SERVICE_SETTINGS_DEFAULT_CORS_MAX_AGE                     = 86400
+	SERVICE_SETTINGS_DEFAULT_LISTEN_AND_ADDRESS               = ":8065"
+	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY                   = "2_KtH_W5"
+	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_SECRET                = "3wLVZPiswc3DnaiaFoLkDvB4X0IV6CpMkj4tf2inJRsBY6-FnkT08zGmppWFgeof"
+	SERVICE_SETTINGS_DEFAULT_POST_SHARE_TOKEN_EXPIRY_IN_HOURS = 24
+	SERVICE_SETTINGS_DEFAULT_MAX_OUTGOING_WEBHOOK_TIMEOUT     = 60
+
+	CORS_ORIGIN_ANY = "*"
 
 	TEAM_SETTINGS_DEFAULT_SITE_NAME                = "Mattermost"
-	TEAM_SETTINGS_DEFAULT_MAX_USERS_PER_TEAM       = 50
+	TEAM_SETTINGS_DEFAULT_MAX_USERS_PER_TEAM       = 0
 	TEAM_SETTINGS_DEFAULT_CUSTOM_BRAND_TEXT        = ""
 	TEAM_SETTINGS_DEFAULT_CUSTOM_DESCRIPTION_TEXT  = ""
 	TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT = 300
 
+	TEAM_SETTINGS_DEFAULT_INACTIVE_CHANNEL_ARCHIVE_DAYS         = 90
+	TEAM_SETTINGS_DEFAULT_INACTIVE_CHANNEL_ARCHIVE_WARNING_DAYS = 7
+
 	SQL_SETTINGS_DEFAULT_DATA_SOURCE = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
 
 	FILE_SETTINGS_DEFAULT_DIRECTORY = "./data/"
@@ -201,6 +216,10 @@ const (
 	GLOBALRELAY_CUSTOMER_TYPE_A9           = "A9"
 	GLOBALRELAY_CUSTOMER_TYPE_A10          = "A10"
 
+	COMPLIANCE_SYSLOG_PROTOCOL_TCP     = "tcp"
+	COMPLIANCE_SYSLOG_PROTOCOL_UDP     = "udp"
+	COMPLIANCE_SYSLOG_PROTOCOL_TCP_TLS = "tcp+tls" // SyslogProtocol "tcp" with SyslogEnableTLS set
+
 	CLIENT_SIDE_CERT_CHECK_PRIMARY_AUTH   = "primary"
 	CLIENT_SIDE_CERT_CHECK_SECONDARY_AUTH = "secondary"
 
@@ -245,6 +264,97 @@ var ServerTLSSupportedCiphers = map[string]uint16{
 	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
 }
 
+// CorsOriginSettings is the CORS policy for the requests from one origin. The origin is either "*",
+// an exact origin such as https://example.com, or an origin with a wildcard subdomain such as
+// https://*.example.com.
+type CorsOriginSettings struct {
+	Origin           *string  `restricted:"true"`
+	AllowCredentials *bool    `restricted:"true"`
+	ExposedHeaders   []string `restricted:"true"`
+	MaxAge           *int     `restricted:"true"`
+}
+
+func (s *CorsOriginSettings) SetDefaults() {
+	if s.Origin == nil {
+		s.Origin = NewString("")
+	}
+
+	if s.AllowCredentials == nil {
+		s.AllowCredentials = NewBool(false)
+	}
+
+	if s.ExposedHeaders == nil {
+		s.ExposedHeaders = []string{}
+	}
+
+	if s.MaxAge == nil {
+		s.MaxAge = NewInt(SERVICE_SETTINGS_DEFAULT_CORS_MAX_AGE)
+	}
+}
+
+func (s *CorsOriginSettings) isValid() *AppError {
+	if *s.Origin != CORS_ORIGIN_ANY {
+		if !isValidCorsOrigin(*s.Origin) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cors_origin.app_error", map[string]interface{}{"Origin": *s.Origin}, "", http.StatusBadRequest)
+		}
+	} else if *s.AllowCredentials {
+		// Browsers refuse credentialed requests answered with a wildcard origin.
+		return NewAppError("Config.IsValid", "model.config.is_valid.cors_credentials.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxAge < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cors_max_age.app_error", map[string]interface{}{"Origin": *s.Origin}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Matches returns true if the value of an Origin header is allowed by these settings. The scheme
+// and port must match exactly, and a wildcard subdomain matches any subdomain but not the domain
+// itself. The null origin sent by sandboxed documents and local files never matches.
+func (s *CorsOriginSettings) Matches(origin string) bool {
+	if origin == "" || origin == "null" {
+		return false
+	}
+
+	if *s.Origin == CORS_ORIGIN_ANY {
+		return true
+	}
+
+	scheme, host, port, ok := parseCorsOrigin(*s.Origin)
+	if !ok {
+		return false
+	}
+
+	originScheme, originHost, originPort, ok := parseCorsOrigin(origin)
+	if !ok || originScheme != scheme || originPort != port {
+		return false
+	}
+
+	if strings.HasPrefix(host, "*.") {
+		return len(originHost) > len(host)-1 && strings.HasSuffix(originHost, host[1:])
+	}
+
+	return originHost == host
+}
+
+// isValidCorsOrigin checks that an origin other than "*" is of the form scheme://host[:port], the
+// host being a domain, possibly with a wildcard subdomain, or an IP address.
+func isValidCorsOrigin(origin string) bool {
+	_, host, _, ok := parseCorsOrigin(origin)
+	return ok && (IsDomainName(strings.TrimPrefix(host, "*.")) || net.ParseIP(host) != nil)
+}
+
+// parseCorsOrigin splits an origin of the form scheme://host[:port] into its lowercase parts.
+func parseCorsOrigin(origin string) (scheme, host, port string, ok bool) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return "", "", "", false
+	}
+
+	return strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), u.Port(), true
+}
+
 type ServiceSettings struct {
 	SiteURL                                           *string  `restricted:"true"`
 	WebsocketURL                                      *string  `restricted:"true"`
@@ -264,17 +374,27 @@ type ServiceSettings struct {
 	ReadTimeout                                       *int     `restricted:"true"`
 	WriteTimeout                                      *int     `restricted:"true"`
 	IdleTimeout                                       *int     `restricted:"true"`
+	ReadHeaderTimeout                                 *int     `restricted:"true"`
+	MaxConnsPerIP                                     *int     `restricted:"true"`
+	EnableH2C                                         *bool    `restricted:"true"`
 	MaximumLoginAttempts                              *int     `restricted:"true"`
 	GoroutineHealthThreshold                          *int     `restricted:"true"`
 	GoogleDeveloperKey                                *string  `restricted:"true"`
 	EnableOAuthServiceProvider                        *bool
 	EnableIncomingWebhooks                            *bool
 	EnableOutgoingWebhooks                            *bool
+	MaxOutgoingWebhookTimeout                         *int
 	EnableCommands                                    *bool
 	DEPRECATED_DO_NOT_USE_EnableOnlyAdminIntegrations *bool `json:"EnableOnlyAdminIntegrations" mapstructure:"EnableOnlyAdminIntegrations"` // This field is deprecated and must not be used.
 	EnablePostUsernameOverride                        *bool
 	EnablePostIconOverride                            *bool
+	IntegrationContextAllowedKeys                     []string
 	EnableLinkPreviews                                *bool
+	EnablePostShareTokens                             *bool
+	EnableWebSocketPostCreation                       *bool
+	PostShareTokenExpiryInHours                       *int
+	PostShareTokenIncludeThread                       *bool
+	CheckPasswordBreachEnabled                        *bool
 	EnableTesting                                     *bool   `restricted:"true"`
 	EnableDeveloper                                   *bool   `restricted:"true"`
 	EnableOpenTracing                                 *bool   `restricted:"true"`
@@ -284,29 +404,42 @@ type ServiceSettings struct {
 	EnableMultifactorAuthentication                   *bool
 	EnforceMultifactorAuthentication                  *bool
 	EnableUserAccessTokens                            *bool
-	AllowCorsFrom                                     *string `restricted:"true"`
-	CorsExposedHeaders                                *string `restricted:"true"`
-	CorsAllowCredentials                              *bool   `restricted:"true"`
-	CorsDebug                                         *bool   `restricted:"true"`
-	AllowCookiesForSubdomains                         *bool   `restricted:"true"`
-	ExtendSessionLengthWithActivity                   *bool   `restricted:"true"`
-	SessionLengthWebInDays                            *int    `restricted:"true"`
-	SessionLengthMobileInDays                         *int    `restricted:"true"`
-	SessionLengthSSOInDays                            *int    `restricted:"true"`
-	SessionCacheInMinutes                             *int    `restricted:"true"`
-	SessionIdleTimeoutInMinutes                       *int    `restricted:"true"`
-	WebsocketSecurePort                               *int    `restricted:"true"`
-	WebsocketPort                                     *int    `restricted:"true"`
-	WebserverMode                                     *string `restricted:"true"`
+	// EnforceMultifactorAuthenticationForRoles requires multi-factor authentication only from the users
+	// with one of these roles, when it isn't enforced for everyone already. Only system_admin and team_admin
+	// are allowed, team_admin applying to the admins of any team.
+	EnforceMultifactorAuthenticationForRoles []string
+	// Deprecated: AllowCorsFrom, CorsExposedHeaders and CorsAllowCredentials are replaced by CorsOrigins,
+	// and are only read to migrate existing configurations. They are ignored once CorsOrigins is set,
+	// which the migration does even when there is no origin to migrate.
+	AllowCorsFrom                                     *string               `restricted:"true"`
+	CorsExposedHeaders                                *string               `restricted:"true"`
+	CorsAllowCredentials                              *bool                 `restricted:"true"`
+	CorsOrigins                                       []*CorsOriginSettings `restricted:"true"`
+	CorsDebug                                         *bool                 `restricted:"true"`
+	AllowCookiesForSubdomains                         *bool                 `restricted:"true"`
+	ExtendSessionLengthWithActivity                   *bool                 `restricted:"true"`
+	SessionLengthWebInDays                            *int                  `restricted:"true"`
+	SessionLengthMobileInDays                         *int                  `restricted:"true"`
+	SessionLengthSSOInDays                            *int                  `restricted:"true"`
+	SessionCacheInMinutes                             *int                  `restricted:"true"`
+	SessionIdleTimeoutInMinutes                       *int                  `restricted:"true"`
+	WebsocketSecurePort                               *int                  `restricted:"true"`
+	WebsocketPort                                     *int                  `restricted:"true"`
+	WebserverMode                                     *string               `restricted:"true"`
+	HTTPResponseCompressionLevel                      *int                  `restricted:"true"`
 	EnableCustomEmoji                                 *bool
 	EnableEmojiPicker                                 *bool
 	EnableGifPicker                                   *bool
+	DefaultEmojiSkinTone                              *string
+	MaxCustomEmojiPerUser                             *int
 	GfycatApiKey                                      *string
 	GfycatApiSecret                                   *string
 	DEPRECATED_DO_NOT_USE_RestrictCustomEmojiCreation *string `json:"RestrictCustomEmojiCreation" mapstructure:"RestrictCustomEmojiCreation"` // This field is deprecated and must not be used.
 	DEPRECATED_DO_NOT_USE_RestrictPostDelete          *string `json:"RestrictPostDelete" mapstructure:"RestrictPostDelete"`                   // This field is deprecated and must not be used.
 	DEPRECATED_DO_NOT_USE_AllowEditPost               *string `json:"AllowEditPost" mapstructure:"AllowEditPost"`                             // This field is deprecated and must not be used.
 	PostEditTimeLimit                                 *int
+	MaxReactionsBeforeCollapse                        *int
+	MaxRepliesPerThread                               *int
 	TimeBetweenUserTypingUpdatesMilliseconds          *int64 `restricted:"true"`
 	EnablePostSearch                                  *bool  `restricted:"true"`
 	MinimumHashtagLength                              *int   `restricted:"true"`
@@ -323,6 +456,7 @@ type ServiceSettings struct {
 	ExperimentalChannelOrganization                   *bool
 	ExperimentalChannelSidebarOrganization            *string
 	ExperimentalDataPrefetch                          *bool
+	EnableExperimentalAppBarMenu                      *bool
 	DEPRECATED_DO_NOT_USE_ImageProxyType              *string `json:"ImageProxyType" mapstructure:"ImageProxyType"`       // This field is deprecated and must not be used.
 	DEPRECATED_DO_NOT_USE_ImageProxyURL               *string `json:"ImageProxyURL" mapstructure:"ImageProxyURL"`         // This field is deprecated and must not be used.
 	DEPRECATED_DO_NOT_USE_ImageProxyOptions           *string `json:"ImageProxyOptions" mapstructure:"ImageProxyOptions"` // This field is deprecated and must not be used.
@@ -338,6 +472,7 @@ type ServiceSettings struct {
 	EnableLatex                                       *bool
 	EnableLocalMode                                   *bool
 	LocalModeSocketLocation                           *string
+	AdminAlertsChannelId                              *string
 }
 
 func (s *ServiceSettings) SetDefaults(isUpdate bool) {
@@ -374,6 +509,18 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableLinkPreviews = NewBool(true)
 	}
 
+	if s.EnablePostShareTokens == nil {
+		s.EnablePostShareTokens = NewBool(false)
+	}
+
+	if s.PostShareTokenExpiryInHours == nil {
+		s.PostShareTokenExpiryInHours = NewInt(SERVICE_SETTINGS_DEFAULT_POST_SHARE_TOKEN_EXPIRY_IN_HOURS)
+	}
+
+	if s.PostShareTokenIncludeThread == nil {
+		s.PostShareTokenIncludeThread = NewBool(true)
+	}
+
 	if s.EnableTesting == nil {
 		s.EnableTesting = NewBool(false)
 	}
@@ -406,6 +553,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnforceMultifactorAuthentication = NewBool(false)
 	}
 
+	if s.EnforceMultifactorAuthenticationForRoles == nil {
+		s.EnforceMultifactorAuthenticationForRoles = []string{}
+	}
+
 	if s.EnableUserAccessTokens == nil {
 		s.EnableUserAccessTokens = NewBool(false)
 	}
@@ -430,6 +581,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableOutgoingWebhooks = NewBool(true)
 	}
 
+	if s.MaxOutgoingWebhookTimeout == nil {
+		s.MaxOutgoingWebhookTimeout = NewInt(SERVICE_SETTINGS_DEFAULT_MAX_OUTGOING_WEBHOOK_TIMEOUT)
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = NewString("")
 	}
@@ -478,10 +633,28 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.IdleTimeout = NewInt(SERVICE_SETTINGS_DEFAULT_IDLE_TIMEOUT)
 	}
 
+	// The read timeout applies to the headers too when this is 0.
+	if s.ReadHeaderTimeout == nil {
+		s.ReadHeaderTimeout = NewInt(0)
+	}
+
+	// 0 allows any number of connections from an IP address.
+	if s.MaxConnsPerIP == nil {
+		s.MaxConnsPerIP = NewInt(0)
+	}
+
+	if s.EnableH2C == nil {
+		s.EnableH2C = NewBool(false)
+	}
+
 	if s.MaximumLoginAttempts == nil {
 		s.MaximumLoginAttempts = NewInt(SERVICE_SETTINGS_DEFAULT_MAX_LOGIN_ATTEMPTS)
 	}
 
+	if s.CheckPasswordBreachEnabled == nil {
+		s.CheckPasswordBreachEnabled = NewBool(false)
+	}
+
 	if s.Forward80To443 == nil {
 		s.Forward80To443 = NewBool(false)
 	}
@@ -589,6 +762,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.WebsocketSecurePort = NewInt(443)
 	}
 
+	if s.EnableWebSocketPostCreation == nil {
+		s.EnableWebSocketPostCreation = NewBool(false)
+	}
+
 	if s.AllowCorsFrom == nil {
 		s.AllowCorsFrom = NewString(SERVICE_SETTINGS_DEFAULT_ALLOW_CORS_FROM)
 	}
@@ -601,6 +778,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.CorsAllowCredentials = NewBool(false)
 	}
 
+	if s.CorsOrigins == nil {
+		s.CorsOrigins = s.MigratedCorsOrigins()
+	}
+
+	for _, origin := range s.CorsOrigins {
+		origin.SetDefaults()
+	}
+
 	if s.CorsDebug == nil {
 		s.CorsDebug = NewBool(false)
 	}
@@ -615,6 +800,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		*s.WebserverMode = "gzip"
 	}
 
+	if s.HTTPResponseCompressionLevel == nil {
+		s.HTTPResponseCompressionLevel = NewInt(gzip.DefaultCompression)
+	}
+
 	if s.EnableCustomEmoji == nil {
 		s.EnableCustomEmoji = NewBool(false)
 	}
@@ -627,6 +816,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableGifPicker = NewBool(false)
 	}
 
+	if s.DefaultEmojiSkinTone == nil {
+		s.DefaultEmojiSkinTone = NewString(EMOJI_SKIN_TONE_DEFAULT)
+	}
+
+	if s.MaxCustomEmojiPerUser == nil {
+		s.MaxCustomEmojiPerUser = NewInt(0)
+	}
+
 	if s.GfycatApiKey == nil || *s.GfycatApiKey == "" {
 		s.GfycatApiKey = NewString(SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY)
 	}
@@ -655,6 +852,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.PostEditTimeLimit = NewInt(-1)
 	}
 
+	if s.MaxReactionsBeforeCollapse == nil {
+		s.MaxReactionsBeforeCollapse = NewInt(0)
+	}
+
+	if s.MaxRepliesPerThread == nil {
+		s.MaxRepliesPerThread = NewInt(0)
+	}
+
 	if s.EnablePreviewFeatures == nil {
 		s.EnablePreviewFeatures = NewBool(true)
 	}
@@ -684,6 +889,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.ExperimentalDataPrefetch = NewBool(true)
 	}
 
+	if s.EnableExperimentalAppBarMenu == nil {
+		s.EnableExperimentalAppBarMenu = NewBool(false)
+	}
+
 	if s.DEPRECATED_DO_NOT_USE_ImageProxyType == nil {
 		s.DEPRECATED_DO_NOT_USE_ImageProxyType = NewString("")
 	}
@@ -747,6 +956,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 	if s.LocalModeSocketLocation == nil {
 		s.LocalModeSocketLocation = NewString(LOCAL_MODE_SOCKET_PATH)
 	}
+
+	if s.AdminAlertsChannelId == nil {
+		s.AdminAlertsChannelId = NewString("")
+	}
 }
 
 type ClusterSettings struct {
@@ -1132,6 +1345,8 @@ type ExperimentalAuditSettings struct {
 	FileMaxBackups   *int    `restricted:"true"`
 	FileCompress     *bool   `restricted:"true"`
 	FileMaxQueueSize *int    `restricted:"true"`
+
+	AdditionalRedactedQueryParams []string `restricted:"true"`
 }
 
 func (s *ExperimentalAuditSettings) SetDefaults() {
@@ -1190,6 +1405,10 @@ func (s *ExperimentalAuditSettings) SetDefaults() {
 	if s.FileMaxQueueSize == nil {
 		s.FileMaxQueueSize = NewInt(1000)
 	}
+
+	if s.AdditionalRedactedQueryParams == nil {
+		s.AdditionalRedactedQueryParams = []string{}
+	}
 }
 
 type NotificationLogSettings struct {
@@ -1238,6 +1457,9 @@ type PasswordSettings struct {
 	Number        *bool
 	Uppercase     *bool
 	Symbol        *bool
+	// MinimumChangeIntervalInHours keeps users from changing their password again too soon, to cycle
+	// back to an old one. 0 disables it.
+	MinimumChangeIntervalInHours *int
 }
 
 func (s *PasswordSettings) SetDefaults() {
@@ -1260,28 +1482,34 @@ func (s *PasswordSettings) SetDefaults() {
 	if s.Symbol == nil {
 		s.Symbol = NewBool(true)
 	}
+
+	if s.MinimumChangeIntervalInHours == nil {
+		s.MinimumChangeIntervalInHours = NewInt(0)
+	}
 }
 
 type FileSettings struct {
-	EnableFileAttachments   *bool
-	EnableMobileUpload      *bool
-	EnableMobileDownload    *bool
-	MaxFileSize             *int64
-	DriverName              *string `restricted:"true"`
-	Directory               *string `restricted:"true"`
-	EnablePublicLink        *bool
-	PublicLinkSalt          *string
-	InitialFont             *string
-	AmazonS3AccessKeyId     *string `restricted:"true"`
-	AmazonS3SecretAccessKey *string `restricted:"true"`
-	AmazonS3Bucket          *string `restricted:"true"`
-	AmazonS3PathPrefix      *string `restricted:"true"`
-	AmazonS3Region          *string `restricted:"true"`
-	AmazonS3Endpoint        *string `restricted:"true"`
-	AmazonS3SSL             *bool   `restricted:"true"`
-	AmazonS3SignV2          *bool   `restricted:"true"`
-	AmazonS3SSE             *bool   `restricted:"true"`
-	AmazonS3Trace           *bool   `restricted:"true"`
+	EnableFileAttachments           *bool
+	EnableMobileUpload              *bool
+	EnableMobileDownload            *bool
+	AdminsBypassDisabledAttachments *bool
+	MaxFileSize                     *int64
+	DriverName                      *string `restricted:"true"`
+	Directory                       *string `restricted:"true"`
+	DisableLocalStorage             *bool   `restricted:"true"`
+	EnablePublicLink                *bool
+	PublicLinkSalt                  *string
+	InitialFont                     *string
+	AmazonS3AccessKeyId             *string `restricted:"true"`
+	AmazonS3SecretAccessKey         *string `restricted:"true"`
+	AmazonS3Bucket                  *string `restricted:"true"`
+	AmazonS3PathPrefix              *string `restricted:"true"`
+	AmazonS3Region                  *string `restricted:"true"`
+	AmazonS3Endpoint                *string `restricted:"true"`
+	AmazonS3SSL                     *bool   `restricted:"true"`
+	AmazonS3SignV2                  *bool   `restricted:"true"`
+	AmazonS3SSE                     *bool   `restricted:"true"`
+	AmazonS3Trace                   *bool   `restricted:"true"`
 }
 
 func (s *FileSettings) SetDefaults(isUpdate bool) {
@@ -1297,6 +1525,10 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.EnableMobileDownload = NewBool(true)
 	}
 
+	if s.AdminsBypassDisabledAttachments == nil {
+		s.AdminsBypassDisabledAttachments = NewBool(false)
+	}
+
 	if s.MaxFileSize == nil {
 		s.MaxFileSize = NewInt64(52428800) // 50 MB
 	}
@@ -1309,6 +1541,10 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.Directory = NewString(FILE_SETTINGS_DEFAULT_DIRECTORY)
 	}
 
+	if s.DisableLocalStorage == nil {
+		s.DisableLocalStorage = NewBool(false)
+	}
+
 	if s.EnablePublicLink == nil {
 		s.EnablePublicLink = NewBool(false)
 	}
@@ -1372,36 +1608,40 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 }
 
 type EmailSettings struct {
-	EnableSignUpWithEmail             *bool
-	EnableSignInWithEmail             *bool
-	EnableSignInWithUsername          *bool
-	SendEmailNotifications            *bool
-	UseChannelInEmailNotifications    *bool
-	RequireEmailVerification          *bool
-	FeedbackName                      *string
-	FeedbackEmail                     *string
-	ReplyToAddress                    *string
-	FeedbackOrganization              *string
-	EnableSMTPAuth                    *bool   `restricted:"true"`
-	SMTPUsername                      *string `restricted:"true"`
-	SMTPPassword                      *string `restricted:"true"`
-	SMTPServer                        *string `restricted:"true"`
-	SMTPPort                          *string `restricted:"true"`
-	SMTPServerTimeout                 *int
-	ConnectionSecurity                *string `restricted:"true"`
-	SendPushNotifications             *bool
-	PushNotificationServer            *string
-	PushNotificationContents          *string
-	PushNotificationBuffer            *int
-	EnableEmailBatching               *bool
-	EmailBatchingBufferSize           *int
-	EmailBatchingInterval             *int
-	EnablePreviewModeBanner           *bool
-	SkipServerCertificateVerification *bool `restricted:"true"`
-	EmailNotificationContentsType     *string
-	LoginButtonColor                  *string
-	LoginButtonBorderColor            *string
-	LoginButtonTextColor              *string
+	EnableSignUpWithEmail          *bool
+	EnableSignInWithEmail          *bool
+	EnableSignInWithUsername       *bool
+	SendEmailNotifications         *bool
+	UseChannelInEmailNotifications *bool
+	// SendEmailNotificationsFromUserDisplayName sends notification emails under the display name
+	// of the user who posted, e.g. "Jane Doe via Mattermost", instead of FeedbackName.
+	SendEmailNotificationsFromUserDisplayName *bool
+	RequireEmailVerification                  *bool
+	FeedbackName                              *string
+	FeedbackEmail                             *string
+	ReplyToAddress                            *string
+	FeedbackOrganization                      *string
+	EnableSMTPAuth                            *bool   `restricted:"true"`
+	SMTPUsername                              *string `restricted:"true"`
+	SMTPPassword                              *string `restricted:"true"`
+	SMTPServer                                *string `restricted:"true"`
+	SMTPPort                                  *string `restricted:"true"`
+	SMTPServerTimeout                         *int
+	ConnectionSecurity                        *string `restricted:"true"`
+	SendPushNotifications                     *bool
+	PushNotificationServer                    *string
+	PushNotificationContents                  *string
+	PushNotificationBuffer                    *int
+	EnableEmailBatching                       *bool
+	EmailBatchingBufferSize                   *int
+	EmailBatchingInterval                     *int
+	EnablePreviewModeBanner                   *bool
+	SkipServerCertificateVerification         *bool `restricted:"true"`
+	EmailNotificationContentsType             *string
+	LoginButtonColor                          *string
+	LoginButtonBorderColor                    *string
+	LoginButtonTextColor                      *string
+	TemplateOverrideDirectory                 *string `restricted:"true"`
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -1425,6 +1665,10 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 		s.UseChannelInEmailNotifications = NewBool(false)
 	}
 
+	if s.SendEmailNotificationsFromUserDisplayName == nil {
+		s.SendEmailNotificationsFromUserDisplayName = NewBool(false)
+	}
+
 	if s.RequireEmailVerification == nil {
 		s.RequireEmailVerification = NewBool(false)
 	}
@@ -1544,6 +1788,10 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	if s.LoginButtonTextColor == nil {
 		s.LoginButtonTextColor = NewString("#2389D7")
 	}
+
+	if s.TemplateOverrideDirectory == nil {
+		s.TemplateOverrideDirectory = NewString("")
+	}
 }
 
 type RateLimitSettings struct {
@@ -1554,6 +1802,10 @@ type RateLimitSettings struct {
 	VaryByRemoteAddr *bool  `restricted:"true"`
 	VaryByUser       *bool  `restricted:"true"`
 	VaryByHeader     string `restricted:"true"`
+	AuthPerSec       *int   `restricted:"true"`
+	AuthMaxBurst     *int   `restricted:"true"`
+	FilesPerSec      *int   `restricted:"true"`
+	FilesMaxBurst    *int   `restricted:"true"`
 }
 
 func (s *RateLimitSettings) SetDefaults() {
@@ -1580,6 +1832,22 @@ func (s *RateLimitSettings) SetDefaults() {
 	if s.VaryByUser == nil {
 		s.VaryByUser = NewBool(false)
 	}
+
+	if s.AuthPerSec == nil {
+		s.AuthPerSec = NewInt(5)
+	}
+
+	if s.AuthMaxBurst == nil {
+		s.AuthMaxBurst = NewInt(100)
+	}
+
+	if s.FilesPerSec == nil {
+		s.FilesPerSec = NewInt(50)
+	}
+
+	if s.FilesMaxBurst == nil {
+		s.FilesMaxBurst = NewInt(500)
+	}
 }
 
 type PrivacySettings struct {
@@ -1730,6 +1998,7 @@ type TeamSettings struct {
 	EnableOpenServer                                          *bool
 	EnableUserDeactivation                                    *bool
 	RestrictCreationToDomains                                 *string
+	RestrictTeamInviteToEmail                                 *bool
 	EnableCustomBrand                                         *bool
 	CustomBrandText                                           *string
 	CustomDescriptionText                                     *string
@@ -1745,9 +2014,11 @@ type TeamSettings struct {
 	EnableXToLeaveChannelsFromLHS                             *bool
 	UserStatusAwayTimeout                                     *int64
 	MaxChannelsPerTeam                                        *int64
+	MaxChannelsCreatedPerUserPerDay                           *int64
 	MaxNotificationsPerChannel                                *int64
 	EnableConfirmNotificationsToChannel                       *bool
 	TeammateNameDisplay                                       *string
+	DefaultSidebarSorting                                     *string
 	ExperimentalViewArchivedChannels                          *bool
 	ExperimentalEnableAutomaticReplies                        *bool
 	ExperimentalHideTownSquareinLHS                           *bool
@@ -1755,6 +2026,11 @@ type TeamSettings struct {
 	LockTeammateNameDisplay                                   *bool
 	ExperimentalPrimaryTeam                                   *string
 	ExperimentalDefaultChannels                               []string
+	EnableInactiveChannelArchiving                            *bool
+	InactiveChannelArchiveDays                                *int
+	InactiveChannelArchiveWarningDays                         *int
+	DefaultChannelAddedNotification                           *bool
+	ExcludeGroupSyncFromChannelAddedNotification              *bool
 }
 
 func (s *TeamSettings) SetDefaults() {
@@ -1783,6 +2059,10 @@ func (s *TeamSettings) SetDefaults() {
 		s.RestrictCreationToDomains = NewString("")
 	}
 
+	if s.RestrictTeamInviteToEmail == nil {
+		s.RestrictTeamInviteToEmail = NewBool(false)
+	}
+
 	if s.EnableCustomBrand == nil {
 		s.EnableCustomBrand = NewBool(false)
 	}
@@ -1860,6 +2140,10 @@ func (s *TeamSettings) SetDefaults() {
 		s.MaxChannelsPerTeam = NewInt64(2000)
 	}
 
+	if s.MaxChannelsCreatedPerUserPerDay == nil {
+		s.MaxChannelsCreatedPerUserPerDay = NewInt64(0)
+	}
+
 	if s.MaxNotificationsPerChannel == nil {
 		s.MaxNotificationsPerChannel = NewInt64(1000)
 	}
@@ -1888,6 +2172,27 @@ func (s *TeamSettings) SetDefaults() {
 		s.ExperimentalDefaultChannels = []string{}
 	}
 
+	if s.EnableInactiveChannelArchiving == nil {
+		s.EnableInactiveChannelArchiving = NewBool(false)
+	}
+
+	if s.InactiveChannelArchiveDays == nil {
+		s.InactiveChannelArchiveDays = NewInt(TEAM_SETTINGS_DEFAULT_INACTIVE_CHANNEL_ARCHIVE_DAYS)
+	}
+
+	if s.InactiveChannelArchiveWarningDays == nil {
+		s.InactiveChannelArchiveWarningDays = NewInt(TEAM_SETTINGS_DEFAULT_INACTIVE_CHANNEL_ARCHIVE_WARNING_DAYS)
+	}
+
+	// Applies to the users who haven't chosen whether to be notified when added to a channel.
+	if s.DefaultChannelAddedNotification == nil {
+		s.DefaultChannelAddedNotification = NewBool(false)
+	}
+
+	if s.ExcludeGroupSyncFromChannelAddedNotification == nil {
+		s.ExcludeGroupSyncFromChannelAddedNotification = NewBool(true)
+	}
+
 	if s.DEPRECATED_DO_NOT_USE_EnableTeamCreation == nil {
 		s.DEPRECATED_DO_NOT_USE_EnableTeamCreation = NewBool(true)
 	}
@@ -1903,6 +2208,10 @@ func (s *TeamSettings) SetDefaults() {
 	if s.LockTeammateNameDisplay == nil {
 		s.LockTeammateNameDisplay = NewBool(false)
 	}
+
+	if s.DefaultSidebarSorting == nil {
+		s.DefaultSidebarSorting = NewString(string(SidebarCategorySortManual))
+	}
 }
 
 type ClientRequirements struct {
@@ -1948,7 +2257,8 @@ type LdapSettings struct {
 	PictureAttribute   *string
 
 	// Synchronization
-	SyncIntervalMinutes *int
+	SyncIntervalMinutes       *int
+	RepairChannelMembersDrift *bool
 
 	// Advanced
 	SkipCertificateVerification *bool
@@ -2069,6 +2379,10 @@ func (s *LdapSettings) SetDefaults() {
 		s.SyncIntervalMinutes = NewInt(60)
 	}
 
+	if s.RepairChannelMembersDrift == nil {
+		s.RepairChannelMembersDrift = NewBool(false)
+	}
+
 	if s.SkipCertificateVerification == nil {
 		s.SkipCertificateVerification = NewBool(false)
 	}
@@ -2106,6 +2420,14 @@ type ComplianceSettings struct {
 	Enable      *bool
 	Directory   *string
 	EnableDaily *bool
+	// SyslogAddress is the host:port of a syslog server every new audit record is streamed to. Streaming is
+	// disabled when it is empty.
+	SyslogAddress   *string
+	SyslogProtocol  *string
+	SyslogEnableTLS *bool
+	// SyslogTLSCACertFile is the path to a PEM file of the certificate authorities trusted to verify the
+	// syslog server when SyslogEnableTLS is set. The system roots are trusted when it is empty.
+	SyslogTLSCACertFile *string
 }
 
 func (s *ComplianceSettings) SetDefaults() {
@@ -2120,12 +2442,31 @@ func (s *ComplianceSettings) SetDefaults() {
 	if s.EnableDaily == nil {
 		s.EnableDaily = NewBool(false)
 	}
+
+	if s.SyslogAddress == nil {
+		s.SyslogAddress = NewString("")
+	}
+
+	if s.SyslogProtocol == nil {
+		s.SyslogProtocol = NewString(COMPLIANCE_SYSLOG_PROTOCOL_TCP)
+	}
+
+	if s.SyslogEnableTLS == nil {
+		s.SyslogEnableTLS = NewBool(false)
+	}
+
+	if s.SyslogTLSCACertFile == nil {
+		s.SyslogTLSCACertFile = NewString("")
+	}
 }
 
 type LocalizationSettings struct {
 	DefaultServerLocale *string
 	DefaultClientLocale *string
 	AvailableLocales    *string
+	// TeamDefaultClientLocales maps team ids to the locale new users of those teams get instead of
+	// DefaultClientLocale when they haven't chosen one.
+	TeamDefaultClientLocales map[string]string
 }
 
 func (s *LocalizationSettings) SetDefaults() {
@@ -2140,6 +2481,10 @@ func (s *LocalizationSettings) SetDefaults() {
 	if s.AvailableLocales == nil {
 		s.AvailableLocales = NewString("")
 	}
+
+	if s.TeamDefaultClientLocales == nil {
+		s.TeamDefaultClientLocales = make(map[string]string)
+	}
 }
 
 type SamlSettings struct {
@@ -2485,11 +2830,12 @@ func (bs *BleveSettings) SetDefaults() {
 }
 
 type DataRetentionSettings struct {
-	EnableMessageDeletion *bool
-	EnableFileDeletion    *bool
-	MessageRetentionDays  *int
-	FileRetentionDays     *int
-	DeletionJobStartTime  *string
+	EnableMessageDeletion           *bool
+	EnableFileDeletion              *bool
+	MessageRetentionDays            *int
+	FileRetentionDays               *int
+	DeletionJobStartTime            *string
+	EditedPostOriginalRetentionDays *int
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2512,6 +2858,10 @@ func (s *DataRetentionSettings) SetDefaults() {
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME)
 	}
+
+	if s.EditedPostOriginalRetentionDays == nil {
+		s.EditedPostOriginalRetentionDays = NewInt(0)
+	}
 }
 
 type JobSettings struct {
@@ -2762,6 +3112,7 @@ func (s *ImageProxySettings) SetDefaults(ss ServiceSettings) {
 type ConfigFunc func() *Config
 
 type Config struct {
+	ConfigVersion             *int
 	ServiceSettings           ServiceSettings
 	TeamSettings              TeamSettings
 	ClientRequirements        ClientRequirements
@@ -2798,6 +3149,7 @@ type Config struct {
 	DisplaySettings           DisplaySettings
 	GuestAccountsSettings     GuestAccountsSettings
 	ImageProxySettings        ImageProxySettings
+	FeatureFlags              FeatureFlags
 }
 
 func (o *Config) Clone() *Config {
@@ -2832,6 +3184,24 @@ func ConfigFromJson(data io.Reader) *Config {
 	return o
 }
 
+// ConfigWithWarnings is a configuration returned after saving it, along with the warnings raised
+// while checking it. The warnings don't prevent the configuration from being saved.
+type ConfigWithWarnings struct {
+	*Config
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (o *ConfigWithWarnings) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ConfigWithWarningsFromJson(data io.Reader) *ConfigWithWarnings {
+	var o *ConfigWithWarnings
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 // isUpdate detects a pre-existing config based on whether SiteURL has been changed
 func (o *Config) isUpdate() bool {
 	return o.ServiceSettings.SiteURL != nil
@@ -2840,6 +3210,10 @@ func (o *Config) isUpdate() bool {
 func (o *Config) SetDefaults() {
 	isUpdate := o.isUpdate()
 
+	if o.ConfigVersion == nil {
+		o.ConfigVersion = NewInt(CURRENT_CONFIG_VERSION)
+	}
+
 	o.LdapSettings.SetDefaults()
 	o.SamlSettings.SetDefaults()
 
@@ -2884,9 +3258,14 @@ func (o *Config) SetDefaults() {
 	o.DisplaySettings.SetDefaults()
 	o.GuestAccountsSettings.SetDefaults()
 	o.ImageProxySettings.SetDefaults(o.ServiceSettings)
+	o.FeatureFlags.SetDefaults()
 }
 
 func (o *Config) IsValid() *AppError {
+	if *o.ConfigVersion > CURRENT_CONFIG_VERSION {
+		return NewAppError("Config.IsValid", "model.config.is_valid.config_version.app_error", map[string]interface{}{"Version": *o.ConfigVersion, "CurrentVersion": CURRENT_CONFIG_VERSION}, "", http.StatusBadRequest)
+	}
+
 	if len(*o.ServiceSettings.SiteURL) == 0 && *o.EmailSettings.EnableEmailBatching {
 		return NewAppError("Config.IsValid", "model.config.is_valid.site_url_email_batching.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2927,6 +3306,10 @@ func (o *Config) IsValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]interface{}{"MinLength": PASSWORD_MINIMUM_LENGTH, "MaxLength": PASSWORD_MAXIMUM_LENGTH}, "", http.StatusBadRequest)
 	}
 
+	if *o.PasswordSettings.MinimumChangeIntervalInHours < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_minimum_change_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if err := o.RateLimitSettings.isValid(); err != nil {
 		return err
 	}
@@ -2962,11 +3345,15 @@ func (o *Config) IsValid() *AppError {
 	if err := o.ImageProxySettings.isValid(); err != nil {
 		return err
 	}
+
+	if err := o.ComplianceSettings.isValid(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (s *TeamSettings) isValid() *AppError {
-	if *s.MaxUsersPerTeam <= 0 {
+	if *s.MaxUsersPerTeam < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_users.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -2974,10 +3361,18 @@ func (s *TeamSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.MaxChannelsCreatedPerUserPerDay < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_channels_created_per_user_per_day.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.MaxNotificationsPerChannel <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_notify_per_channel.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.UserStatusAwayTimeout <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.user_status_away_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if !(*s.RestrictDirectMessage == DIRECT_MESSAGE_ANY || *s.RestrictDirectMessage == DIRECT_MESSAGE_TEAM) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.restrict_direct_message.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2986,10 +3381,22 @@ func (s *TeamSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.teammate_name_display.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !IsValidSidebarSorting(*s.DefaultSidebarSorting) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.default_sidebar_sorting.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*s.SiteName) > SITENAME_MAX_LENGTH {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sitename_length.app_error", map[string]interface{}{"MaxLength": SITENAME_MAX_LENGTH}, "", http.StatusBadRequest)
 	}
 
+	if *s.InactiveChannelArchiveDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.inactive_channel_archive_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.InactiveChannelArchiveWarningDays < 0 || *s.InactiveChannelArchiveWarningDays >= *s.InactiveChannelArchiveDays {
+		return NewAppError("Config.IsValid", "model.config.is_valid.inactive_channel_archive_warning_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3034,6 +3441,10 @@ func (s *FileSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.file_driver.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.DisableLocalStorage && *s.DriverName == IMAGE_DRIVER_LOCAL {
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_local_storage_disabled.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.PublicLinkSalt != "" && len(*s.PublicLinkSalt) < 32 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.file_salt.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3074,6 +3485,14 @@ func (s *RateLimitSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.AuthPerSec <= 0 || *s.FilesPerSec <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_sec.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.AuthMaxBurst <= 0 || *s.FilesMaxBurst <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3212,6 +3631,40 @@ func (s *SamlSettings) isValid() *AppError {
 	return nil
 }
 
+// MigratedCorsOrigins converts the deprecated CORS settings, which applied the same policy to every
+// allowed origin, any of which may be unset. Credentials are dropped for "*", which no longer allows
+// them. Origins are lowercased and stripped of a trailing slash. Those still invalid, such as bare
+// hosts, never matched the Origin header of a browser and are dropped with a warning.
+func (s *ServiceSettings) MigratedCorsOrigins() []*CorsOriginSettings {
+	origins := []*CorsOriginSettings{}
+	if s.AllowCorsFrom == nil {
+		return origins
+	}
+
+	allowCredentials := s.CorsAllowCredentials != nil && *s.CorsAllowCredentials
+	exposedHeaders := []string{}
+	if s.CorsExposedHeaders != nil {
+		exposedHeaders = strings.Fields(*s.CorsExposedHeaders)
+	}
+
+	for _, origin := range strings.Fields(*s.AllowCorsFrom) {
+		origin = strings.TrimSuffix(strings.ToLower(origin), "/")
+		if origin != CORS_ORIGIN_ANY && !isValidCorsOrigin(origin) {
+			mlog.Warn("Dropping an invalid origin from AllowCorsFrom while migrating it to CorsOrigins, origins must be of the form scheme://host[:port].", mlog.String("origin", origin))
+			continue
+		}
+
+		origins = append(origins, &CorsOriginSettings{
+			Origin:           NewString(origin),
+			AllowCredentials: NewBool(allowCredentials && origin != CORS_ORIGIN_ANY),
+			ExposedHeaders:   exposedHeaders,
+			MaxAge:           NewInt(SERVICE_SETTINGS_DEFAULT_CORS_MAX_AGE),
+		})
+	}
+
+	return origins
+}
+
 func (s *ServiceSettings) isValid() *AppError {
 	if !(*s.ConnectionSecurity == CONN_SECURITY_NONE || *s.ConnectionSecurity == CONN_SECURITY_TLS) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.webserver_security.app_error", nil, "", http.StatusBadRequest)
@@ -3251,6 +3704,14 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.write_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.ReadHeaderTimeout < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_header_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxConnsPerIP < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_conns_per_ip.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.TimeBetweenUserTypingUpdatesMilliseconds < 1000 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.time_between_user_typing.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3259,6 +3720,18 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.MaxReactionsBeforeCollapse < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_reactions_before_collapse.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxRepliesPerThread < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_replies_per_thread.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxOutgoingWebhookTimeout <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_outgoing_webhook_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*s.SiteURL) != 0 {
 		if _, err := url.ParseRequestURI(*s.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, "", http.StatusBadRequest)
@@ -3289,6 +3762,44 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.group_unread_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.AdminAlertsChannelId != "" && !IsValidId(*s.AdminAlertsChannelId) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.admin_alerts_channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidEmojiSkinTone(*s.DefaultEmojiSkinTone) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.default_emoji_skin_tone.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxCustomEmojiPerUser < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.max_custom_emoji_per_user.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.HTTPResponseCompressionLevel < gzip.DefaultCompression || *s.HTTPResponseCompressionLevel > gzip.BestCompression {
+		return NewAppError("Config.IsValid", "model.config.is_valid.http_response_compression_level.app_error", map[string]interface{}{"Min": gzip.DefaultCompression, "Max": gzip.BestCompression}, "", http.StatusBadRequest)
+	}
+
+	if *s.PostShareTokenExpiryInHours <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.post_share_token_expiry.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, role := range s.EnforceMultifactorAuthenticationForRoles {
+		if role != SYSTEM_ADMIN_ROLE_ID && role != TEAM_ADMIN_ROLE_ID {
+			return NewAppError("Config.IsValid", "model.config.is_valid.enforce_mfa_for_roles.app_error", map[string]interface{}{"Role": role}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, key := range s.IntegrationContextAllowedKeys {
+		if strings.TrimSpace(key) == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.integration_context_allowed_keys.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, origin := range s.CorsOrigins {
+		if err := origin.isValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -3363,6 +3874,10 @@ func (s *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
+	if *s.EditedPostOriginalRetentionDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.edited_post_original_retention_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3373,6 +3888,27 @@ func (s *LocalizationSettings) isValid() *AppError {
 		}
 	}
 
+	availableLocales := map[string]bool{}
+	if len(*s.AvailableLocales) > 0 {
+		for _, locale := range strings.Split(*s.AvailableLocales, ",") {
+			availableLocales[strings.TrimSpace(locale)] = true
+		}
+	}
+
+	for teamId, locale := range s.TeamDefaultClientLocales {
+		if !IsValidId(teamId) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.localization.team_default_client_locales.team_id.app_error", nil, "team_id="+teamId, http.StatusBadRequest)
+		}
+
+		if locale == "" || !IsValidLocale(locale) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.localization.team_default_client_locales.locale.app_error", map[string]interface{}{"Locale": locale}, "team_id="+teamId, http.StatusBadRequest)
+		}
+
+		if len(availableLocales) > 0 && !availableLocales[locale] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.localization.team_default_client_locales.available_locales.app_error", map[string]interface{}{"Locale": locale}, "team_id="+teamId, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -3432,6 +3968,30 @@ func (s *DisplaySettings) isValid() *AppError {
 	return nil
 }
 
+func (s *ComplianceSettings) isValid() *AppError {
+	if *s.SyslogProtocol != COMPLIANCE_SYSLOG_PROTOCOL_TCP && *s.SyslogProtocol != COMPLIANCE_SYSLOG_PROTOCOL_UDP {
+		return NewAppError("Config.IsValid", "model.config.is_valid.compliance_syslog_protocol.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SyslogEnableTLS && *s.SyslogProtocol != COMPLIANCE_SYSLOG_PROTOCOL_TCP {
+		return NewAppError("Config.IsValid", "model.config.is_valid.compliance_syslog_tls.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SyslogAddress != "" {
+		if _, _, err := net.SplitHostPort(*s.SyslogAddress); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.compliance_syslog_address.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	if *s.SyslogTLSCACertFile != "" {
+		if _, err := os.Stat(*s.SyslogTLSCACertFile); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.compliance_syslog_tls_ca_cert_file.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 func (s *ImageProxySettings) isValid() *AppError {
 	if *s.Enable {
 		switch *s.ImageProxyType {
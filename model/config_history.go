@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ConfigHistoryEntry describes a configuration saved to a store keeping previous configurations.
+// Changes lists the settings changed from the configuration it replaced, in dot notation.
+type ConfigHistoryEntry struct {
+	Id       string   `json:"id"`
+	CreateAt int64    `json:"create_at"`
+	UserId   string   `json:"user_id"`
+	Active   bool     `json:"active"`
+	Changes  []string `json:"changes"`
+}
+
+func ConfigHistoryEntriesToJson(o []*ConfigHistoryEntry) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ConfigHistoryEntriesFromJson(data io.Reader) []*ConfigHistoryEntry {
+	var o []*ConfigHistoryEntry
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -4,8 +4,10 @@
 package model
 
 import (
+	"compress/gzip"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -91,6 +93,25 @@ func TestConfigEnableDeveloper(t *testing.T) {
 	}
 }
 
+func TestConfigVersion(t *testing.T) {
+	t.Run("defaults to the current version", func(t *testing.T) {
+		c1 := Config{}
+		c1.SetDefaults()
+
+		require.Equal(t, CURRENT_CONFIG_VERSION, *c1.ConfigVersion)
+		require.Nil(t, c1.IsValid())
+	})
+
+	t.Run("newer version is invalid", func(t *testing.T) {
+		c1 := Config{ConfigVersion: NewInt(CURRENT_CONFIG_VERSION + 1)}
+		c1.SetDefaults()
+
+		appErr := c1.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.config_version.app_error", appErr.Id)
+	})
+}
+
 func TestConfigDefaultFileSettingsDirectory(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -105,6 +126,17 @@ func TestConfigDefaultEmailNotificationContentsType(t *testing.T) {
 	require.Equal(t, *c1.EmailSettings.EmailNotificationContentsType, EMAIL_NOTIFICATION_CONTENTS_FULL)
 }
 
+func TestConfigSendEmailNotificationsFromUserDisplayName(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.EmailSettings.SendEmailNotificationsFromUserDisplayName)
+	require.Nil(t, c1.IsValid())
+
+	*c1.EmailSettings.SendEmailNotificationsFromUserDisplayName = true
+	require.Nil(t, c1.IsValid())
+}
+
 func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -112,6 +144,22 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestFileSettingsIsValidDisableLocalStorage(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	require.False(t, *c1.FileSettings.DisableLocalStorage)
+	require.Nil(t, c1.FileSettings.isValid())
+
+	*c1.FileSettings.DisableLocalStorage = true
+	err := c1.FileSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.file_local_storage_disabled.app_error", err.Id)
+
+	*c1.FileSettings.DriverName = IMAGE_DRIVER_S3
+	require.Nil(t, c1.FileSettings.isValid())
+}
+
 func TestConfigDefaultSignatureAlgorithm(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -316,6 +364,147 @@ func TestTeamSettingsIsValidSiteNameEmpty(t *testing.T) {
 	require.Nil(t, c1.TeamSettings.isValid())
 }
 
+func TestTeamSettingsIsValidInactiveChannelArchive(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.InactiveChannelArchiveDays = 0
+	require.NotNil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.InactiveChannelArchiveDays = 30
+	*c1.TeamSettings.InactiveChannelArchiveWarningDays = 30
+	require.NotNil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.InactiveChannelArchiveWarningDays = -1
+	require.NotNil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.InactiveChannelArchiveWarningDays = 0
+	require.Nil(t, c1.TeamSettings.isValid())
+}
+
+func TestTeamSettingsIsValidUserStatusAwayTimeout(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, int64(TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT), *c1.TeamSettings.UserStatusAwayTimeout)
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.UserStatusAwayTimeout = 0
+	require.NotNil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.UserStatusAwayTimeout = -1
+	require.NotNil(t, c1.TeamSettings.isValid())
+
+	c1.SetDefaults()
+	require.NotNil(t, c1.TeamSettings.isValid(), "should leave migrating a timeout that isn't positive to the config upgrade")
+}
+
+func TestTeamSettingsIsValidMaxChannelsCreatedPerUserPerDay(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, int64(0), *c1.TeamSettings.MaxChannelsCreatedPerUserPerDay)
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.MaxChannelsCreatedPerUserPerDay = 10
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.MaxChannelsCreatedPerUserPerDay = -1
+	require.NotNil(t, c1.TeamSettings.isValid())
+}
+
+func TestTeamSettingsIsValidDefaultSidebarSorting(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, string(SidebarCategorySortManual), *c1.TeamSettings.DefaultSidebarSorting)
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.DefaultSidebarSorting = string(SidebarCategorySortAlphabetical)
+	require.Nil(t, c1.TeamSettings.isValid())
+
+	*c1.TeamSettings.DefaultSidebarSorting = "unknown"
+	require.NotNil(t, c1.TeamSettings.isValid())
+}
+
+func TestServiceSettingsIsValidMaxRepliesPerThread(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, 0, *c1.ServiceSettings.MaxRepliesPerThread)
+	require.Nil(t, c1.ServiceSettings.isValid())
+
+	*c1.ServiceSettings.MaxRepliesPerThread = 500
+	require.Nil(t, c1.ServiceSettings.isValid())
+
+	*c1.ServiceSettings.MaxRepliesPerThread = -1
+	require.NotNil(t, c1.ServiceSettings.isValid())
+}
+
+func TestServiceSettingsIsValidEnforceMultifactorAuthenticationForRoles(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.ServiceSettings.isValid())
+
+	c1.ServiceSettings.EnforceMultifactorAuthenticationForRoles = []string{SYSTEM_ADMIN_ROLE_ID, TEAM_ADMIN_ROLE_ID}
+	require.Nil(t, c1.ServiceSettings.isValid())
+
+	c1.ServiceSettings.EnforceMultifactorAuthenticationForRoles = []string{SYSTEM_ADMIN_ROLE_ID, SYSTEM_USER_ROLE_ID}
+	require.NotNil(t, c1.ServiceSettings.isValid())
+}
+
+func TestServiceSettingsIsValidMaxOutgoingWebhookTimeout(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, SERVICE_SETTINGS_DEFAULT_MAX_OUTGOING_WEBHOOK_TIMEOUT, *c1.ServiceSettings.MaxOutgoingWebhookTimeout)
+	require.Nil(t, c1.ServiceSettings.isValid())
+
+	*c1.ServiceSettings.MaxOutgoingWebhookTimeout = 0
+	require.NotNil(t, c1.ServiceSettings.isValid())
+
+	*c1.ServiceSettings.MaxOutgoingWebhookTimeout = -1
+	require.NotNil(t, c1.ServiceSettings.isValid())
+}
+
+func TestComplianceSettingsIsValid(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogAddress = "siem.example.com:6514"
+	require.Nil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogEnableTLS = true
+	require.Nil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogProtocol = COMPLIANCE_SYSLOG_PROTOCOL_UDP
+	require.NotNil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogEnableTLS = false
+	require.Nil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogProtocol = "http"
+	require.NotNil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogProtocol = COMPLIANCE_SYSLOG_PROTOCOL_TCP
+	*c1.ComplianceSettings.SyslogAddress = "siem.example.com"
+	require.NotNil(t, c1.ComplianceSettings.isValid())
+
+	*c1.ComplianceSettings.SyslogAddress = "siem.example.com:6514"
+	*c1.ComplianceSettings.SyslogTLSCACertFile = "/missing/" + NewId() + ".pem"
+	require.NotNil(t, c1.ComplianceSettings.isValid())
+}
+
+func TestDataRetentionSettingsIsValidEditedPostOriginalRetentionDays(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, 0, *c1.DataRetentionSettings.EditedPostOriginalRetentionDays)
+	require.Nil(t, c1.DataRetentionSettings.isValid())
+
+	*c1.DataRetentionSettings.EditedPostOriginalRetentionDays = 30
+	require.Nil(t, c1.DataRetentionSettings.isValid())
+
+	*c1.DataRetentionSettings.EditedPostOriginalRetentionDays = -1
+	require.NotNil(t, c1.DataRetentionSettings.isValid())
+}
+
 func TestMessageExportSettingsIsValidEnableExportNotSet(t *testing.T) {
 	fs := &FileSettings{}
 	mes := &MessageExportSettings{}
@@ -1301,3 +1490,253 @@ func TestConfigMarketplaceDefaults(t *testing.T) {
 		require.Equal(t, "https://marketplace.example.com", *c.PluginSettings.MarketplaceUrl)
 	})
 }
+
+func TestServiceSettingsCorsOriginsMigration(t *testing.T) {
+	t.Run("no allowed origins", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+
+		require.NotNil(t, c.ServiceSettings.CorsOrigins)
+		require.Empty(t, c.ServiceSettings.CorsOrigins)
+	})
+
+	t.Run("allowed origins", func(t *testing.T) {
+		c := Config{ServiceSettings: ServiceSettings{
+			AllowCorsFrom:        NewString("https://example.com * http://localhost:8080"),
+			CorsExposedHeaders:   NewString("X-Version-ID X-Request-ID"),
+			CorsAllowCredentials: NewBool(true),
+		}}
+		c.SetDefaults()
+
+		require.Len(t, c.ServiceSettings.CorsOrigins, 3)
+		for i, origin := range []string{"https://example.com", "*", "http://localhost:8080"} {
+			assert.Equal(t, origin, *c.ServiceSettings.CorsOrigins[i].Origin)
+			assert.Equal(t, []string{"X-Version-ID", "X-Request-ID"}, c.ServiceSettings.CorsOrigins[i].ExposedHeaders)
+			assert.Equal(t, SERVICE_SETTINGS_DEFAULT_CORS_MAX_AGE, *c.ServiceSettings.CorsOrigins[i].MaxAge)
+		}
+		assert.True(t, *c.ServiceSettings.CorsOrigins[0].AllowCredentials)
+		assert.False(t, *c.ServiceSettings.CorsOrigins[1].AllowCredentials)
+		assert.True(t, *c.ServiceSettings.CorsOrigins[2].AllowCredentials)
+		assert.Nil(t, c.ServiceSettings.isValid())
+	})
+
+	t.Run("invalid origins", func(t *testing.T) {
+		c := Config{ServiceSettings: ServiceSettings{
+			AllowCorsFrom: NewString("example.com HTTPS://Example.com/ https://example.com/path http://localhost:8080"),
+		}}
+		c.SetDefaults()
+
+		require.Len(t, c.ServiceSettings.CorsOrigins, 2)
+		assert.Equal(t, "https://example.com", *c.ServiceSettings.CorsOrigins[0].Origin)
+		assert.Equal(t, "http://localhost:8080", *c.ServiceSettings.CorsOrigins[1].Origin)
+		assert.Nil(t, c.ServiceSettings.isValid())
+	})
+
+	t.Run("already migrated", func(t *testing.T) {
+		c := Config{ServiceSettings: ServiceSettings{
+			AllowCorsFrom: NewString("https://example.com"),
+			CorsOrigins:   []*CorsOriginSettings{{Origin: NewString("https://other.example.com")}},
+		}}
+		c.SetDefaults()
+
+		require.Len(t, c.ServiceSettings.CorsOrigins, 1)
+		assert.Equal(t, "https://other.example.com", *c.ServiceSettings.CorsOrigins[0].Origin)
+		assert.False(t, *c.ServiceSettings.CorsOrigins[0].AllowCredentials)
+		assert.Equal(t, SERVICE_SETTINGS_DEFAULT_CORS_MAX_AGE, *c.ServiceSettings.CorsOrigins[0].MaxAge)
+	})
+}
+
+func TestCorsOriginSettingsIsValid(t *testing.T) {
+	for _, tc := range []struct {
+		Name             string
+		Origin           string
+		AllowCredentials bool
+		MaxAge           int
+		ExpectError      bool
+	}{
+		{"any origin", "*", false, 0, false},
+		{"any origin with credentials", "*", true, 0, true},
+		{"exact origin", "https://example.com", true, 600, false},
+		{"origin with a port", "http://localhost:8065", false, 0, false},
+		{"ip origin", "http://127.0.0.1:8065", false, 0, false},
+		{"wildcard subdomain", "https://*.example.com", true, 0, false},
+		{"trailing slash", "https://example.com/", false, 0, false},
+		{"no scheme", "example.com", false, 0, true},
+		{"path", "https://example.com/path", false, 0, true},
+		{"misplaced wildcard", "https://example.*.com", false, 0, true},
+		{"null", "null", false, 0, true},
+		{"empty", "", false, 0, true},
+		{"negative max age", "https://example.com", false, -1, true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &CorsOriginSettings{
+				Origin:           NewString(tc.Origin),
+				AllowCredentials: NewBool(tc.AllowCredentials),
+				MaxAge:           NewInt(tc.MaxAge),
+			}
+			s.SetDefaults()
+
+			if tc.ExpectError {
+				assert.NotNil(t, s.isValid())
+			} else {
+				assert.Nil(t, s.isValid())
+			}
+		})
+	}
+}
+
+func TestCorsOriginSettingsMatches(t *testing.T) {
+	for _, tc := range []struct {
+		Name     string
+		Allowed  string
+		Origin   string
+		Expected bool
+	}{
+		{"any origin", "*", "https://example.com", true},
+		{"any origin with null", "*", "null", false},
+		{"any origin without origin", "*", "", false},
+		{"exact origin", "https://example.com", "https://example.com", true},
+		{"exact origin with different case", "https://example.com", "https://EXAMPLE.com", true},
+		{"exact origin with trailing slash", "https://example.com/", "https://example.com", true},
+		{"mismatched scheme", "https://example.com", "http://example.com", false},
+		{"mismatched port", "https://example.com", "https://example.com:8443", false},
+		{"matching port", "http://localhost:8065", "http://localhost:8065", true},
+		{"other domain", "https://example.com", "https://example.org", false},
+		{"suffix of domain", "https://example.com", "https://evilexample.com", false},
+		{"null origin", "https://example.com", "null", false},
+		{"wildcard subdomain", "https://*.example.com", "https://chat.example.com", true},
+		{"wildcard nested subdomain", "https://*.example.com", "https://a.chat.example.com", true},
+		{"wildcard base domain", "https://*.example.com", "https://example.com", false},
+		{"wildcard suffix of domain", "https://*.example.com", "https://chat.evilexample.com", false},
+		{"wildcard mismatched scheme", "https://*.example.com", "http://chat.example.com", false},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &CorsOriginSettings{Origin: NewString(tc.Allowed)}
+			s.SetDefaults()
+
+			assert.Equal(t, tc.Expected, s.Matches(tc.Origin))
+		})
+	}
+}
+
+func TestConfigWithWarningsJson(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.SiteURL = "http://example.com"
+
+	t.Run("configuration fields are not nested", func(t *testing.T) {
+		data := (&ConfigWithWarnings{Config: cfg, Warnings: []string{"unreachable"}}).ToJson()
+
+		decoded := ConfigFromJson(strings.NewReader(data))
+		require.NotNil(t, decoded)
+		assert.Equal(t, "http://example.com", *decoded.ServiceSettings.SiteURL)
+
+		result := ConfigWithWarningsFromJson(strings.NewReader(data))
+		require.NotNil(t, result)
+		assert.Equal(t, cfg, result.Config)
+		assert.Equal(t, []string{"unreachable"}, result.Warnings)
+	})
+
+	t.Run("no warnings", func(t *testing.T) {
+		data := (&ConfigWithWarnings{Config: cfg}).ToJson()
+		assert.NotContains(t, data, `"warnings"`)
+	})
+}
+
+func TestLocalizationSettingsIsValid(t *testing.T) {
+	teamId := NewId()
+
+	for _, tc := range []struct {
+		Name                     string
+		AvailableLocales         string
+		TeamDefaultClientLocales map[string]string
+		Valid                    bool
+	}{
+		{"no team default locales", "", nil, true},
+		{"team default locale", "", map[string]string{teamId: "de"}, true},
+		{"team default locale among available locales", "en,de", map[string]string{teamId: "de"}, true},
+		{"team default locale not among available locales", "en,fr", map[string]string{teamId: "de"}, false},
+		{"team default locale only part of an available locale", "en,pt-BR", map[string]string{teamId: "pt"}, false},
+		{"team default locale among spaced available locales", "en, de", map[string]string{teamId: "de"}, true},
+		{"invalid team id", "", map[string]string{"junk": "de"}, false},
+		{"empty locale", "", map[string]string{teamId: ""}, false},
+		{"invalid locale", "", map[string]string{teamId: "not a locale"}, false},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &LocalizationSettings{
+				AvailableLocales:         NewString(tc.AvailableLocales),
+				TeamDefaultClientLocales: tc.TeamDefaultClientLocales,
+			}
+			s.SetDefaults()
+
+			if tc.Valid {
+				assert.Nil(t, s.isValid())
+			} else {
+				assert.NotNil(t, s.isValid())
+			}
+		})
+	}
+}
+
+func TestTeamSettingsMaxUsersPerTeam(t *testing.T) {
+	t.Run("defaults to unlimited", func(t *testing.T) {
+		c := &Config{}
+		c.SetDefaults()
+
+		require.Equal(t, 0, *c.TeamSettings.MaxUsersPerTeam)
+	})
+
+	for _, tc := range []struct {
+		Name            string
+		MaxUsersPerTeam int
+		Valid           bool
+	}{
+		{"unlimited", 0, true},
+		{"limited", 50, true},
+		{"negative", -1, false},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := &Config{}
+			c.SetDefaults()
+			*c.TeamSettings.MaxUsersPerTeam = tc.MaxUsersPerTeam
+
+			if tc.Valid {
+				assert.Nil(t, c.TeamSettings.isValid())
+			} else {
+				assert.NotNil(t, c.TeamSettings.isValid())
+			}
+		})
+	}
+}
+
+func TestServiceSettingsHTTPResponseCompressionLevel(t *testing.T) {
+	t.Run("defaults to the default compression", func(t *testing.T) {
+		c1 := Config{}
+		c1.SetDefaults()
+
+		require.Equal(t, gzip.DefaultCompression, *c1.ServiceSettings.HTTPResponseCompressionLevel)
+		require.Nil(t, c1.IsValid())
+	})
+
+	for _, level := range []int{gzip.DefaultCompression, gzip.NoCompression, gzip.BestSpeed, gzip.BestCompression} {
+		t.Run(fmt.Sprintf("level %d is valid", level), func(t *testing.T) {
+			c1 := Config{}
+			c1.SetDefaults()
+			c1.ServiceSettings.HTTPResponseCompressionLevel = NewInt(level)
+
+			require.Nil(t, c1.IsValid())
+		})
+	}
+
+	for _, level := range []int{gzip.HuffmanOnly, gzip.BestCompression + 1} {
+		t.Run(fmt.Sprintf("level %d is invalid", level), func(t *testing.T) {
+			c1 := Config{}
+			c1.SetDefaults()
+			c1.ServiceSettings.HTTPResponseCompressionLevel = NewInt(level)
+
+			appErr := c1.IsValid()
+			require.NotNil(t, appErr)
+			require.Equal(t, "model.config.is_valid.http_response_compression_level.app_error", appErr.Id)
+		})
+	}
+}
@@ -13,8 +13,24 @@ import (
 const (
 	EMOJI_NAME_MAX_LENGTH = 64
 	EMOJI_SORT_BY_NAME    = "name"
+
+	// The skin tones are named after the code point of their modifier.
+	EMOJI_SKIN_TONE_DEFAULT      = "default"
+	EMOJI_SKIN_TONE_LIGHT        = "1F3FB"
+	EMOJI_SKIN_TONE_MEDIUM_LIGHT = "1F3FC"
+	EMOJI_SKIN_TONE_MEDIUM       = "1F3FD"
+	EMOJI_SKIN_TONE_MEDIUM_DARK  = "1F3FE"
+	EMOJI_SKIN_TONE_DARK         = "1F3FF"
 )
 
+var emojiSkinTones = map[string]bool{
+	EMOJI_SKIN_TONE_LIGHT:        true,
+	EMOJI_SKIN_TONE_MEDIUM_LIGHT: true,
+	EMOJI_SKIN_TONE_MEDIUM:       true,
+	EMOJI_SKIN_TONE_MEDIUM_DARK:  true,
+	EMOJI_SKIN_TONE_DARK:         true,
+}
+
 var EMOJI_PATTERN = regexp.MustCompile(`:[a-zA-Z0-9_-]+:`)
 
 type Emoji struct {
@@ -36,6 +52,14 @@ func GetSystemEmojiId(emojiName string) (string, bool) {
 	return id, found
 }
 
+func IsValidEmojiSkinTone(skinTone string) bool {
+	if skinTone == EMOJI_SKIN_TONE_DEFAULT {
+		return true
+	}
+
+	return emojiSkinTones[skinTone]
+}
+
 func (emoji *Emoji) IsValid() *AppError {
 	if !IsValidId(emoji.Id) {
 		return NewAppError("Emoji.IsValid", "model.emoji.id.app_error", nil, "", http.StatusBadRequest)
@@ -59,3 +59,11 @@ func TestEmojiIsValid(t *testing.T) {
 	emoji.Name = "croissant"
 	require.NotNil(t, emoji.IsValid())
 }
+
+func TestIsValidEmojiSkinTone(t *testing.T) {
+	for _, skinTone := range []string{EMOJI_SKIN_TONE_DEFAULT, EMOJI_SKIN_TONE_LIGHT, EMOJI_SKIN_TONE_DARK} {
+		require.True(t, IsValidEmojiSkinTone(skinTone))
+	}
+	require.False(t, IsValidEmojiSkinTone(""))
+	require.False(t, IsValidEmojiSkinTone("1F600"))
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+const (
+	FEATURE_FLAG_OVERRIDE_MAX_ROLLOUT_PERCENTAGE = 100
+	FEATURE_FLAG_OVERRIDE_MAX_IDS                = 1000
+)
+
+// FeatureFlags gate features that are still being rolled out. Each flag is defined here along with
+// its default, and can be set through the config or the environment, e.g.
+// MM_FEATUREFLAGS_TESTFEATURE=true. A FeatureFlagOverride can further enable a flag for some users
+// only.
+type FeatureFlags struct {
+	// Exists only for unit and manual testing.
+	TestFeature *bool
+}
+
+func (f *FeatureFlags) SetDefaults() {
+	if f.TestFeature == nil {
+		f.TestFeature = NewBool(false)
+	}
+}
+
+// ToMap returns the configured value of every flag by name.
+func (f *FeatureFlags) ToMap() map[string]bool {
+	flags := map[string]bool{}
+
+	v := reflect.ValueOf(f).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if value, ok := v.Field(i).Interface().(*bool); ok {
+			flags[v.Type().Field(i).Name] = value != nil && *value
+		}
+	}
+
+	return flags
+}
+
+// FeatureFlagOverride replaces the configured value of a feature flag, enabling it only for the
+// given users, for the members of the given teams and for a stable share of the remaining users.
+type FeatureFlagOverride struct {
+	RolloutPercentage int      `json:"rollout_percentage"`
+	UserIds           []string `json:"user_ids"`
+	TeamIds           []string `json:"team_ids"`
+}
+
+func (o *FeatureFlagOverride) IsValid() *AppError {
+	if o.RolloutPercentage < 0 || o.RolloutPercentage > FEATURE_FLAG_OVERRIDE_MAX_ROLLOUT_PERCENTAGE {
+		return NewAppError("FeatureFlagOverride.IsValid", "model.feature_flag_override.is_valid.rollout_percentage.app_error", map[string]interface{}{"Max": FEATURE_FLAG_OVERRIDE_MAX_ROLLOUT_PERCENTAGE}, "", http.StatusBadRequest)
+	}
+
+	if len(o.UserIds) > FEATURE_FLAG_OVERRIDE_MAX_IDS {
+		return NewAppError("FeatureFlagOverride.IsValid", "model.feature_flag_override.is_valid.user_ids.app_error", map[string]interface{}{"Max": FEATURE_FLAG_OVERRIDE_MAX_IDS}, "", http.StatusBadRequest)
+	}
+	for _, userId := range o.UserIds {
+		if !IsValidId(userId) {
+			return NewAppError("FeatureFlagOverride.IsValid", "model.feature_flag_override.is_valid.user_ids.app_error", map[string]interface{}{"Max": FEATURE_FLAG_OVERRIDE_MAX_IDS}, "user_id="+userId, http.StatusBadRequest)
+		}
+	}
+
+	if len(o.TeamIds) > FEATURE_FLAG_OVERRIDE_MAX_IDS {
+		return NewAppError("FeatureFlagOverride.IsValid", "model.feature_flag_override.is_valid.team_ids.app_error", map[string]interface{}{"Max": FEATURE_FLAG_OVERRIDE_MAX_IDS}, "", http.StatusBadRequest)
+	}
+	for _, teamId := range o.TeamIds {
+		if !IsValidId(teamId) {
+			return NewAppError("FeatureFlagOverride.IsValid", "model.feature_flag_override.is_valid.team_ids.app_error", map[string]interface{}{"Max": FEATURE_FLAG_OVERRIDE_MAX_IDS}, "team_id="+teamId, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *FeatureFlagOverride) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func FeatureFlagOverrideFromJson(data io.Reader) *FeatureFlagOverride {
+	var o *FeatureFlagOverride
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// FeatureFlagOverrides are the overrides of feature flags by flag name.
+type FeatureFlagOverrides map[string]*FeatureFlagOverride
+
+func (o FeatureFlagOverrides) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func FeatureFlagOverridesFromJson(data io.Reader) FeatureFlagOverrides {
+	var o FeatureFlagOverrides
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// FeatureFlag describes a feature flag to system admins: its configured value and its override,
+// if any.
+type FeatureFlag struct {
+	Name       string               `json:"name"`
+	Configured bool                 `json:"configured"`
+	Override   *FeatureFlagOverride `json:"override,omitempty"`
+}
+
+// FeatureFlagsFromConfig returns the feature flags configured in the given settings along with
+// their overrides, sorted by name. Overrides of flags that no longer exist are left out.
+func FeatureFlagsFromConfig(f *FeatureFlags, overrides FeatureFlagOverrides) []*FeatureFlag {
+	flags := []*FeatureFlag{}
+	for name, configured := range f.ToMap() {
+		flags = append(flags, &FeatureFlag{
+			Name:       name,
+			Configured: configured,
+			Override:   overrides[name],
+		})
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	return flags
+}
+
+func (o *FeatureFlag) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func FeatureFlagFromJson(data io.Reader) *FeatureFlag {
+	var o *FeatureFlag
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func FeatureFlagListToJson(o []*FeatureFlag) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func FeatureFlagListFromJson(data io.Reader) []*FeatureFlag {
+	var o []*FeatureFlag
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagsToMap(t *testing.T) {
+	f := FeatureFlags{}
+	f.SetDefaults()
+	assert.Equal(t, map[string]bool{"TestFeature": false}, f.ToMap())
+
+	f.TestFeature = NewBool(true)
+	assert.Equal(t, map[string]bool{"TestFeature": true}, f.ToMap())
+}
+
+func TestFeatureFlagOverrideIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Override *FeatureFlagOverride
+		Valid    bool
+	}{
+		"empty": {
+			Override: &FeatureFlagOverride{},
+			Valid:    true,
+		},
+		"full rollout": {
+			Override: &FeatureFlagOverride{RolloutPercentage: 100},
+			Valid:    true,
+		},
+		"negative rollout": {
+			Override: &FeatureFlagOverride{RolloutPercentage: -1},
+		},
+		"rollout over 100": {
+			Override: &FeatureFlagOverride{RolloutPercentage: 101},
+		},
+		"valid ids": {
+			Override: &FeatureFlagOverride{UserIds: []string{NewId()}, TeamIds: []string{NewId()}},
+			Valid:    true,
+		},
+		"invalid user id": {
+			Override: &FeatureFlagOverride{UserIds: []string{"junk"}},
+		},
+		"invalid team id": {
+			Override: &FeatureFlagOverride{TeamIds: []string{"junk"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Override.IsValid())
+			} else {
+				assert.NotNil(t, tc.Override.IsValid())
+			}
+		})
+	}
+}
+
+func TestFeatureFlagsFromConfig(t *testing.T) {
+	f := FeatureFlags{}
+	f.SetDefaults()
+
+	override := &FeatureFlagOverride{RolloutPercentage: 10}
+	flags := FeatureFlagsFromConfig(&f, FeatureFlagOverrides{"TestFeature": override, "Removed": override})
+	require.Len(t, flags, 1)
+	assert.Equal(t, &FeatureFlag{Name: "TestFeature", Configured: false, Override: override}, flags[0])
+}
@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	GroupSourceLdap GroupSource = "ldap"
+	GroupSourceLdap   GroupSource = "ldap"
+	GroupSourceCustom GroupSource = "custom"
 
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
@@ -24,6 +25,7 @@ type GroupSource string
 
 var allGroupSources = []GroupSource{
 	GroupSourceLdap,
+	GroupSourceCustom,
 }
 
 var groupSourcesRequiringRemoteID = []GroupSource{
@@ -67,6 +69,10 @@ type GroupPatch struct {
 	AllowReference *bool   `json:"allow_reference"`
 }
 
+type GroupModifyMembers struct {
+	UserIds []string `json:"user_ids"`
+}
+
 type LdapGroupSearchOpts struct {
 	Q            string
 	IsLinked     *bool
@@ -146,6 +152,12 @@ func (group *Group) IsValidForCreate() *AppError {
 	return nil
 }
 
+// IsCustom returns whether the group is managed by its members through the API, rather than synced
+// from an external source such as LDAP.
+func (group *Group) IsCustom() bool {
+	return group.Source == GroupSourceCustom
+}
+
 func (group *Group) requiresRemoteId() bool {
 	for _, groupSource := range groupSourcesRequiringRemoteID {
 		if groupSource == group.Source {
@@ -219,3 +231,14 @@ func GroupStatsFromJson(data io.Reader) *GroupStats {
 	json.NewDecoder(data).Decode(&groupStats)
 	return groupStats
 }
+
+func (gm *GroupModifyMembers) ToJson() string {
+	b, _ := json.Marshal(gm)
+	return string(b)
+}
+
+func GroupModifyMembersFromJson(data io.Reader) *GroupModifyMembers {
+	var groupModifyMembers *GroupModifyMembers
+	json.NewDecoder(data).Decode(&groupModifyMembers)
+	return groupModifyMembers
+}
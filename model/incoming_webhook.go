@@ -41,6 +41,13 @@ type IncomingWebhookRequest struct {
 	IconEmoji   string             `json:"icon_emoji"`
 }
 
+// IncomingWebhookPreview holds the posts that an incoming webhook request would create, along with
+// the problems found in the request.
+type IncomingWebhookPreview struct {
+	Posts  []*Post     `json:"posts"`
+	Errors []*AppError `json:"errors"`
+}
+
 func (o *IncomingWebhook) ToJson() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -215,3 +222,14 @@ func (o *IncomingWebhookRequest) ToJson() string {
 		return string(b)
 	}
 }
+
+func (o *IncomingWebhookPreview) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func IncomingWebhookPreviewFromJson(data io.Reader) *IncomingWebhookPreview {
+	var o *IncomingWebhookPreview
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
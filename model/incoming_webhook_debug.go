@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	INCOMING_WEBHOOK_DEBUG_DURATION      = 60 * 60 * 1000 // 1 hour, in milliseconds
+	INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS  = 25
+	INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE = 16 * 1024
+)
+
+// incomingWebhookDebugRedactedHeaders lists the fragments of the names of the headers whose
+// values are never captured, since they usually carry credentials.
+var incomingWebhookDebugRedactedHeaders = []string{
+	"authorization",
+	"cookie",
+	"token",
+	"secret",
+	"key",
+	"password",
+	"signature",
+}
+
+// IncomingWebhookRequestCapture is a request received by an incoming webhook while debugging was
+// enabled for it, along with the outcome of handling it.
+type IncomingWebhookRequestCapture struct {
+	CreateAt      int64             `json:"create_at"`
+	RequestId     string            `json:"request_id"`
+	Method        string            `json:"method"`
+	ContentType   string            `json:"content_type"`
+	Headers       map[string]string `json:"headers"`
+	Body          string            `json:"body"`
+	BodyTruncated bool              `json:"body_truncated"`
+	// Payload is the request as parsed by the server, if parsing it succeeded.
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	StatusCode int             `json:"status_code"`
+	ErrorId    string          `json:"error_id,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// IncomingWebhookDebugSession holds the requests captured for an incoming webhook, oldest first,
+// until debugging expires at ExpiresAt.
+type IncomingWebhookDebugSession struct {
+	HookId    string                           `json:"hook_id"`
+	ExpiresAt int64                            `json:"expires_at"`
+	Requests  []*IncomingWebhookRequestCapture `json:"requests"`
+}
+
+func (o *IncomingWebhookDebugSession) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func IncomingWebhookDebugSessionFromJson(data io.Reader) *IncomingWebhookDebugSession {
+	var o *IncomingWebhookDebugSession
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// AddRequest appends a captured request, dropping the oldest ones to keep at most
+// INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS of them.
+func (o *IncomingWebhookDebugSession) AddRequest(capture *IncomingWebhookRequestCapture) {
+	o.Requests = append(o.Requests, capture)
+	if extra := len(o.Requests) - INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS; extra > 0 {
+		o.Requests = append([]*IncomingWebhookRequestCapture(nil), o.Requests[extra:]...)
+	}
+}
+
+// SetBody stores the body of the captured request, truncated to INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE.
+func (o *IncomingWebhookRequestCapture) SetBody(body []byte) {
+	if len(body) > INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE {
+		body = body[:INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE]
+		o.BodyTruncated = true
+	}
+	o.Body = string(body)
+}
+
+// RedactIncomingWebhookHeaders flattens the headers of a request for capturing, replacing the
+// values of the ones that may carry credentials.
+func RedactIncomingWebhookHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		lowerName := strings.ToLower(name)
+		redacted := false
+		for _, fragment := range incomingWebhookDebugRedactedHeaders {
+			if strings.Contains(lowerName, fragment) {
+				redacted = true
+				break
+			}
+		}
+
+		if redacted {
+			headers[name] = FAKE_SETTING
+		} else {
+			headers[name] = strings.Join(values, ", ")
+		}
+	}
+	return headers
+}
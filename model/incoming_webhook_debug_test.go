@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomingWebhookDebugSessionJson(t *testing.T) {
+	session := &IncomingWebhookDebugSession{
+		HookId:    NewId(),
+		ExpiresAt: GetMillis(),
+		Requests:  []*IncomingWebhookRequestCapture{{Body: "body", StatusCode: http.StatusOK}},
+	}
+
+	decoded := IncomingWebhookDebugSessionFromJson(strings.NewReader(session.ToJson()))
+	assert.Equal(t, session, decoded)
+}
+
+func TestIncomingWebhookDebugSessionAddRequest(t *testing.T) {
+	session := &IncomingWebhookDebugSession{}
+	for i := 0; i < INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS+5; i++ {
+		session.AddRequest(&IncomingWebhookRequestCapture{CreateAt: int64(i)})
+	}
+
+	require.Len(t, session.Requests, INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS)
+	assert.Equal(t, int64(5), session.Requests[0].CreateAt)
+	assert.Equal(t, int64(INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS+4), session.Requests[INCOMING_WEBHOOK_DEBUG_MAX_REQUESTS-1].CreateAt)
+}
+
+func TestIncomingWebhookRequestCaptureSetBody(t *testing.T) {
+	capture := &IncomingWebhookRequestCapture{}
+	capture.SetBody([]byte("short"))
+	assert.Equal(t, "short", capture.Body)
+	assert.False(t, capture.BodyTruncated)
+
+	capture = &IncomingWebhookRequestCapture{}
+	capture.SetBody([]byte(strings.Repeat("a", INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE+1)))
+	assert.Len(t, capture.Body, INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE)
+	assert.True(t, capture.BodyTruncated)
+}
+
+func TestRedactIncomingWebhookHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Add("Accept", "text/plain")
+	header.Add("Accept", "application/json")
+	header.Set("Authorization", "Bearer token")
+	header.Set("Cookie", "MMAUTHTOKEN=token")
+	header.Set("X-Api-Key", "key")
+	header.Set("X-Hub-Signature", "sha1=abc")
+
+	headers := RedactIncomingWebhookHeaders(header)
+	assert.Equal(t, map[string]string{
+		"Content-Type":    "application/json",
+		"Accept":          "text/plain, application/json",
+		"Authorization":   FAKE_SETTING,
+		"Cookie":          FAKE_SETTING,
+		"X-Api-Key":       FAKE_SETTING,
+		"X-Hub-Signature": FAKE_SETTING,
+	}, headers)
+}
@@ -438,11 +438,7 @@ func AddPostActionCookies(o *Post, secret []byte) *Post {
 			}
 
 			c.PostId = p.Id
-			if p.RootId == "" {
-				c.RootPostId = p.Id
-			} else {
-				c.RootPostId = p.RootId
-			}
+			c.RootPostId = p.GetRootId()
 
 			b, _ := json.Marshal(c)
 			action.Cookie, _ = encryptPostActionCookie(string(b), secret)
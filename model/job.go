@@ -20,6 +20,9 @@ const (
 	JOB_TYPE_MIGRATIONS                     = "migrations"
 	JOB_TYPE_PLUGINS                        = "plugins"
 	JOB_TYPE_EXPIRY_NOTIFY                  = "expiry_notify"
+	JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE       = "inactive_channel_archive"
+	JOB_TYPE_EDIT_RETENTION                 = "edit_retention"
+	JOB_TYPE_CHANNEL_MEMBERS_DRIFT          = "channel_members_drift"
 
 	JOB_STATUS_PENDING          = "pending"
 	JOB_STATUS_IN_PROGRESS      = "in_progress"
@@ -61,6 +64,9 @@ func (j *Job) IsValid() *AppError {
 	case JOB_TYPE_MIGRATIONS:
 	case JOB_TYPE_PLUGINS:
 	case JOB_TYPE_EXPIRY_NOTIFY:
+	case JOB_TYPE_INACTIVE_CHANNEL_ARCHIVE:
+	case JOB_TYPE_EDIT_RETENTION:
+	case JOB_TYPE_CHANNEL_MEMBERS_DRIFT:
 	default:
 		return NewAppError("Job.IsValid", "model.job.is_valid.type.app_error", nil, "id="+j.Id, http.StatusBadRequest)
 	}
@@ -85,6 +85,10 @@ type PluginSetting struct {
 	// For "radio" or "dropdown" settings, this is the list of pre-defined options that the user can choose
 	// from.
 	Options []*PluginOption `json:"options,omitempty" yaml:"options,omitempty"`
+
+	// If true, the value of the setting is redacted whenever the plugin's configuration is
+	// exposed through the API. Settings of the "generated" type are always redacted.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
 }
 
 type PluginSettingsSchema struct {
@@ -312,6 +316,43 @@ func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
 
+// EffectiveConfig merges the given admin-provided settings over the defaults declared in the
+// settings schema. Keys are lowercased, matching the configuration a plugin receives through
+// LoadPluginConfiguration.
+func (m *Manifest) EffectiveConfig(settings map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{})
+
+	if m.SettingsSchema != nil {
+		for _, setting := range m.SettingsSchema.Settings {
+			config[strings.ToLower(setting.Key)] = setting.Default
+		}
+	}
+
+	for key, value := range settings {
+		config[strings.ToLower(key)] = value
+	}
+
+	return config
+}
+
+// SanitizeConfig redacts the values of any secret settings declared in the settings schema.
+func (m *Manifest) SanitizeConfig(config map[string]interface{}) {
+	if m.SettingsSchema == nil {
+		return
+	}
+
+	for _, setting := range m.SettingsSchema.Settings {
+		if !setting.Secret && setting.Type != "generated" {
+			continue
+		}
+
+		key := strings.ToLower(setting.Key)
+		if value, ok := config[key]; ok && value != nil && value != "" {
+			config[key] = FAKE_SETTING
+		}
+	}
+}
+
 func (m *Manifest) MeetMinServerVersion(serverVersion string) (bool, error) {
 	minServerVersion, err := semver.Parse(m.MinServerVersion)
 	if err != nil {
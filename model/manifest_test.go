@@ -894,3 +894,55 @@ func TestManifestMeetMinServerVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestEffectiveConfig(t *testing.T) {
+	manifest := &Manifest{
+		SettingsSchema: &PluginSettingsSchema{
+			Settings: []*PluginSetting{
+				{Key: "EnableFeature", Type: "bool", Default: false},
+				{Key: "Greeting", Type: "text", Default: "hello"},
+			},
+		},
+	}
+
+	t.Run("defaults only", func(t *testing.T) {
+		config := manifest.EffectiveConfig(nil)
+		assert.Equal(t, map[string]interface{}{"enablefeature": false, "greeting": "hello"}, config)
+	})
+
+	t.Run("overrides replace defaults", func(t *testing.T) {
+		config := manifest.EffectiveConfig(map[string]interface{}{"EnableFeature": true, "extra": "value"})
+		assert.Equal(t, map[string]interface{}{"enablefeature": true, "greeting": "hello", "extra": "value"}, config)
+	})
+
+	t.Run("no settings schema", func(t *testing.T) {
+		config := (&Manifest{}).EffectiveConfig(map[string]interface{}{"Key": "value"})
+		assert.Equal(t, map[string]interface{}{"key": "value"}, config)
+	})
+}
+
+func TestManifestSanitizeConfig(t *testing.T) {
+	manifest := &Manifest{
+		SettingsSchema: &PluginSettingsSchema{
+			Settings: []*PluginSetting{
+				{Key: "Token", Type: "text", Secret: true},
+				{Key: "EncryptionKey", Type: "generated"},
+				{Key: "Greeting", Type: "text"},
+				{Key: "EmptySecret", Type: "text", Secret: true},
+			},
+		},
+	}
+
+	config := map[string]interface{}{
+		"token":         "abc",
+		"encryptionkey": "def",
+		"greeting":      "hello",
+		"emptysecret":   "",
+	}
+	manifest.SanitizeConfig(config)
+
+	assert.Equal(t, FAKE_SETTING, config["token"])
+	assert.Equal(t, FAKE_SETTING, config["encryptionkey"])
+	assert.Equal(t, "hello", config["greeting"])
+	assert.Equal(t, "", config["emptysecret"])
+}
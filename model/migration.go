@@ -17,6 +17,9 @@ const (
 	MIGRATION_KEY_ADD_MANAGE_GUESTS_PERMISSIONS               = "add_manage_guests_permissions"
 	MIGRATION_KEY_CHANNEL_MODERATIONS_PERMISSIONS             = "channel_moderations_permissions"
 	MIGRATION_KEY_ADD_USE_GROUP_MENTIONS_PERMISSION           = "add_use_group_mentions_permission"
+	MIGRATION_KEY_ADD_BOOKMARK_PERMISSION                     = "add_bookmark_permission"
+	MIGRATION_KEY_ADD_MANAGE_CHANNEL_READ_ONLY_PERMISSION     = "add_manage_channel_read_only_permission"
+	MIGRATION_KEY_ADD_MANAGE_CUSTOM_GROUPS_PERMISSION         = "add_manage_custom_groups_permission"
 
 	MIGRATION_KEY_SIDEBAR_CATEGORIES_PHASE_2 = "migration_sidebar_categories_phase_2"
 )
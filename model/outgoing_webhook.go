@@ -30,6 +30,7 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+	Timeout      int         `json:"timeout"` // In seconds, capped by ServiceSettings.MaxOutgoingWebhookTimeout. Zero uses the default timeout.
 }
 
 type OutgoingWebhookPayload struct {
@@ -194,6 +195,10 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.Timeout < 0 {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
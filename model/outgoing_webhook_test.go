@@ -91,6 +91,12 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	assert.Nilf(t, o.IsValid(), "IconURL length %d should be valid", len(o.IconURL))
+
+	o.Timeout = -1
+	assert.NotNil(t, o.IsValid(), "negative timeout should be invalid")
+
+	o.Timeout = 10
+	assert.Nil(t, o.IsValid(), "positive timeout should be valid")
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
@@ -92,6 +92,9 @@ var PERMISSION_PROMOTE_GUEST *Permission
 var PERMISSION_DEMOTE_TO_GUEST *Permission
 var PERMISSION_USE_CHANNEL_MENTIONS *Permission
 var PERMISSION_USE_GROUP_MENTIONS *Permission
+var PERMISSION_ADD_BOOKMARK *Permission
+var PERMISSION_MANAGE_CHANNEL_READ_ONLY *Permission
+var PERMISSION_MANAGE_CUSTOM_GROUPS *Permission
 
 // General permission that encompasses all system admin functions
 // in the future this could be broken up to allow access to some
@@ -575,6 +578,27 @@ func initializePermissions() {
 		PERMISSION_SCOPE_CHANNEL,
 	}
 
+	PERMISSION_ADD_BOOKMARK = &Permission{
+		"add_bookmark",
+		"authentication.permissions.add_bookmark.name",
+		"authentication.permissions.add_bookmark.description",
+		PERMISSION_SCOPE_CHANNEL,
+	}
+
+	PERMISSION_MANAGE_CHANNEL_READ_ONLY = &Permission{
+		"manage_channel_read_only",
+		"authentication.permissions.manage_channel_read_only.name",
+		"authentication.permissions.manage_channel_read_only.description",
+		PERMISSION_SCOPE_CHANNEL,
+	}
+
+	PERMISSION_MANAGE_CUSTOM_GROUPS = &Permission{
+		"manage_custom_groups",
+		"authentication.permissions.manage_custom_groups.name",
+		"authentication.permissions.manage_custom_groups.description",
+		PERMISSION_SCOPE_SYSTEM,
+	}
+
 	ALL_PERMISSIONS = []*Permission{
 		PERMISSION_INVITE_USER,
 		PERMISSION_ADD_USER_TO_TEAM,
@@ -652,6 +676,9 @@ func initializePermissions() {
 		PERMISSION_DEMOTE_TO_GUEST,
 		PERMISSION_USE_CHANNEL_MENTIONS,
 		PERMISSION_USE_GROUP_MENTIONS,
+		PERMISSION_ADD_BOOKMARK,
+		PERMISSION_MANAGE_CHANNEL_READ_ONLY,
+		PERMISSION_MANAGE_CUSTOM_GROUPS,
 	}
 
 	CHANNEL_MODERATED_PERMISSIONS = []string{
@@ -44,6 +44,8 @@ const (
 	POST_CHANNEL_RESTORED       = "system_channel_restored"
 	POST_EPHEMERAL              = "system_ephemeral"
 	POST_CHANGE_CHANNEL_PRIVACY = "system_change_chan_privacy"
+	POST_CHANGE_READ_ONLY       = "system_change_read_only"
+	POST_INACTIVE_WARNING       = "system_inactive_warning"
 	POST_ADD_BOT_TEAMS_CHANNELS = "add_bot_teams_channels"
 	POST_FILEIDS_MAX_RUNES      = 150
 	POST_FILENAMES_MAX_RUNES    = 4000
@@ -58,6 +60,7 @@ const (
 	PROPS_ADD_CHANNEL_MEMBER    = "add_channel_member"
 
 	POST_PROPS_ADDED_USER_ID       = "addedUserId"
+	POST_PROPS_ACTOR_ID            = "actor_id"
 	POST_PROPS_DELETE_BY           = "deleteBy"
 	POST_PROPS_OVERRIDE_ICON_URL   = "override_icon_url"
 	POST_PROPS_OVERRIDE_ICON_EMOJI = "override_icon_emoji"
@@ -311,6 +314,8 @@ func (o *Post) IsValid(maxPostSize int) *AppError {
 		POST_CHANNEL_DELETED,
 		POST_CHANNEL_RESTORED,
 		POST_CHANGE_CHANNEL_PRIVACY,
+		POST_CHANGE_READ_ONLY,
+		POST_INACTIVE_WARNING,
 		POST_ME,
 		POST_ADD_BOT_TEAMS_CHANNELS:
 	default:
@@ -426,6 +431,15 @@ func (o *Post) GetProp(key string) interface{} {
 	return o.Props[key]
 }
 
+// GetRootId returns the id of the root post of the thread the post is in, which is the post itself
+// when it isn't a reply.
+func (o *Post) GetRootId() string {
+	if o.RootId == "" {
+		return o.Id
+	}
+	return o.RootId
+}
+
 func (o *Post) IsSystemMessage() bool {
 	return len(o.Type) >= len(POST_SYSTEM_MESSAGE_PREFIX) && o.Type[:len(POST_SYSTEM_MESSAGE_PREFIX)] == POST_SYSTEM_MESSAGE_PREFIX
 }
@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// PostEdit is the content a post had before it was edited, along with the time it was replaced.
+type PostEdit struct {
+	PostId  string `json:"post_id"`
+	Content string `json:"content"`
+	EditAt  int64  `json:"edit_at"`
+}
+
+func PostEditsToJson(o []*PostEdit) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostEditsFromJson(data io.Reader) []*PostEdit {
+	var o []*PostEdit
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
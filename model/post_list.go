@@ -149,6 +149,38 @@ func (o *PostList) Etag() string {
 	return Etag(orderId, id, t)
 }
 
+// NextId returns the id of the post following the given one in the list, which is the newer one
+// since the list is ordered from newest to oldest. It returns "" for the newest post or a post that
+// isn't in the list.
+func (o *PostList) NextId(postId string) string {
+	for i, id := range o.Order {
+		if id == postId {
+			if i == 0 {
+				return ""
+			}
+			return o.Order[i-1]
+		}
+	}
+
+	return ""
+}
+
+// PrevId returns the id of the post preceding the given one in the list, which is the older one
+// since the list is ordered from newest to oldest. It returns "" for the oldest post or a post that
+// isn't in the list.
+func (o *PostList) PrevId(postId string) string {
+	for i, id := range o.Order {
+		if id == postId {
+			if i == len(o.Order)-1 {
+				return ""
+			}
+			return o.Order[i+1]
+		}
+	}
+
+	return ""
+}
+
 func (o *PostList) IsChannelId(channelId string) bool {
 	for _, v := range o.Posts {
 		if v.ChannelId != channelId {
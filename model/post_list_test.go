@@ -143,3 +143,27 @@ func TestPostListToSlice(t *testing.T) {
 
 	assert.Equal(t, want, pl.ToSlice())
 }
+
+func TestPostListNextIdAndPrevId(t *testing.T) {
+	pl := NewPostList()
+	p1 := &Post{Id: NewId(), CreateAt: 3}
+	p2 := &Post{Id: NewId(), CreateAt: 2}
+	p3 := &Post{Id: NewId(), CreateAt: 1}
+	for _, p := range []*Post{p1, p2, p3} {
+		pl.AddPost(p)
+		pl.AddOrder(p.Id)
+	}
+
+	assert.Equal(t, "", pl.NextId(p1.Id))
+	assert.Equal(t, p1.Id, pl.NextId(p2.Id))
+	assert.Equal(t, p2.Id, pl.NextId(p3.Id))
+
+	assert.Equal(t, p2.Id, pl.PrevId(p1.Id))
+	assert.Equal(t, p3.Id, pl.PrevId(p2.Id))
+	assert.Equal(t, "", pl.PrevId(p3.Id))
+
+	assert.Equal(t, "", pl.NextId(NewId()))
+	assert.Equal(t, "", pl.PrevId(NewId()))
+	assert.Equal(t, "", NewPostList().NextId(p1.Id))
+	assert.Equal(t, "", NewPostList().PrevId(p1.Id))
+}
@@ -26,6 +26,29 @@ type PostMetadata struct {
 
 	// Reactions holds reactions made to the post.
 	Reactions []*Reaction `json:"reactions,omitempty"`
+
+	// ReactionCounts holds the number of reactions made to the post for each emoji name. Clients compare the
+	// number of distinct emojis to ServiceSettings.MaxReactionsBeforeCollapse to decide whether to collapse them.
+	ReactionCounts map[string]int `json:"reaction_counts,omitempty"`
+
+	// SavedPost holds the label and note of the post for the user requesting it if they saved the post. It is
+	// only set on posts returned to that user and never on posts broadcast to other users.
+	SavedPost *SavedPost `json:"saved_post,omitempty"`
+
+	// ChannelContext holds the names of the channel and team of the post. It is only set on posts listed
+	// away from their channel, such as the saved posts of a user.
+	ChannelContext *PostChannelContext `json:"channel_context,omitempty"`
+}
+
+// PostChannelContext describes where a post was made, for display next to the post. The team fields are
+// empty for direct and group messages.
+type PostChannelContext struct {
+	ChannelName        string `json:"channel_name"`
+	ChannelDisplayName string `json:"channel_display_name"`
+	ChannelType        string `json:"channel_type"`
+	TeamId             string `json:"team_id"`
+	TeamName           string `json:"team_name"`
+	TeamDisplayName    string `json:"team_display_name"`
 }
 
 type PostImage struct {
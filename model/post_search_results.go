@@ -13,12 +13,14 @@ type PostSearchMatches map[string][]string
 type PostSearchResults struct {
 	*PostList
 	Matches PostSearchMatches `json:"matches"`
+	// Total is the number of posts matching the search across all the pages of results.
+	Total int64 `json:"total"`
 }
 
 func MakePostSearchResults(posts *PostList, matches PostSearchMatches) *PostSearchResults {
 	return &PostSearchResults{
-		posts,
-		matches,
+		PostList: posts,
+		Matches:  matches,
 	}
 }
 
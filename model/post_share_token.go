@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PostShareToken grants authenticated members of a team read access to a single post of one of the
+// team's channels, without requiring them to be members of that channel, until it expires or the
+// tokens of the channel are revoked. When IncludeThread is set, the thread of the post can be read
+// too.
+type PostShareToken struct {
+	Token         string `json:"token"`
+	PostId        string `json:"post_id"`
+	ChannelId     string `json:"channel_id"`
+	TeamId        string `json:"team_id"`
+	CreatorId     string `json:"creator_id"`
+	IncludeThread bool   `json:"include_thread"`
+	CreateAt      int64  `json:"create_at"`
+	ExpiresAt     int64  `json:"expires_at"`
+}
+
+// IsValid validates the post share token and returns an error if it isn't configured correctly.
+func (o *PostShareToken) IsValid() *AppError {
+	if !IsValidId(o.Token) {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.channel_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.team_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.creator_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.create_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if o.ExpiresAt <= o.CreateAt {
+		return NewAppError("PostShareToken.IsValid", "model.post_share_token.is_valid.expires_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PreSave should be run before saving a new post share token to the database.
+func (o *PostShareToken) PreSave() {
+	if o.Token == "" {
+		o.Token = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// IsExpired returns whether the post share token no longer grants access to its post.
+func (o *PostShareToken) IsExpired() bool {
+	return o.ExpiresAt <= GetMillis()
+}
+
+// GrantsAccessToThread returns whether the post share token grants access to the given thread,
+// which is the case when it includes thread context and the shared post is part of the thread.
+func (o *PostShareToken) GrantsAccessToThread(thread *PostList) bool {
+	if !o.IncludeThread || thread == nil {
+		return false
+	}
+
+	_, ok := thread.Posts[o.PostId]
+	return ok
+}
+
+func (o *PostShareToken) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostShareTokenFromJson(data io.Reader) *PostShareToken {
+	var o *PostShareToken
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostShareTokenIsValid(t *testing.T) {
+	newPostShareToken := func() *PostShareToken {
+		token := &PostShareToken{
+			PostId:    NewId(),
+			ChannelId: NewId(),
+			TeamId:    NewId(),
+			CreatorId: NewId(),
+		}
+		token.PreSave()
+		token.ExpiresAt = token.CreateAt + 1000
+		return token
+	}
+
+	testCases := []struct {
+		Description string
+		Modify      func(o *PostShareToken)
+		Valid       bool
+	}{
+		{"valid", func(o *PostShareToken) {}, true},
+		{"invalid token", func(o *PostShareToken) { o.Token = "junk" }, false},
+		{"invalid post id", func(o *PostShareToken) { o.PostId = "" }, false},
+		{"invalid channel id", func(o *PostShareToken) { o.ChannelId = "junk" }, false},
+		{"invalid team id", func(o *PostShareToken) { o.TeamId = "" }, false},
+		{"invalid creator id", func(o *PostShareToken) { o.CreatorId = strings.Repeat("a", 27) }, false},
+		{"missing create at", func(o *PostShareToken) { o.CreateAt = 0 }, false},
+		{"expires when created", func(o *PostShareToken) { o.ExpiresAt = o.CreateAt }, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			token := newPostShareToken()
+			testCase.Modify(token)
+			if testCase.Valid {
+				assert.Nil(t, token.IsValid())
+			} else {
+				assert.NotNil(t, token.IsValid())
+			}
+		})
+	}
+}
+
+func TestPostShareTokenPreSave(t *testing.T) {
+	token := &PostShareToken{}
+	token.PreSave()
+	assert.True(t, IsValidId(token.Token))
+	assert.NotZero(t, token.CreateAt)
+
+	token = &PostShareToken{Token: "token", CreateAt: 1}
+	token.PreSave()
+	assert.Equal(t, "token", token.Token)
+	assert.Equal(t, int64(1), token.CreateAt)
+}
+
+func TestPostShareTokenIsExpired(t *testing.T) {
+	assert.True(t, (&PostShareToken{ExpiresAt: GetMillis() - 1000}).IsExpired())
+	assert.False(t, (&PostShareToken{ExpiresAt: GetMillis() + 60000}).IsExpired())
+}
+
+func TestPostShareTokenGrantsAccessToThread(t *testing.T) {
+	root := &Post{Id: NewId()}
+	reply := &Post{Id: NewId(), RootId: root.Id}
+
+	thread := NewPostList()
+	thread.AddPost(root)
+	thread.AddPost(reply)
+
+	t.Run("shared reply", func(t *testing.T) {
+		assert.True(t, (&PostShareToken{PostId: reply.Id, IncludeThread: true}).GrantsAccessToThread(thread))
+	})
+
+	t.Run("shared root post", func(t *testing.T) {
+		assert.True(t, (&PostShareToken{PostId: root.Id, IncludeThread: true}).GrantsAccessToThread(thread))
+	})
+
+	t.Run("without thread context", func(t *testing.T) {
+		assert.False(t, (&PostShareToken{PostId: reply.Id}).GrantsAccessToThread(thread))
+	})
+
+	t.Run("post of another thread", func(t *testing.T) {
+		assert.False(t, (&PostShareToken{PostId: NewId(), IncludeThread: true}).GrantsAccessToThread(thread))
+	})
+
+	t.Run("no thread", func(t *testing.T) {
+		assert.False(t, (&PostShareToken{PostId: reply.Id, IncludeThread: true}).GrantsAccessToThread(nil))
+	})
+}
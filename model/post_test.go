@@ -113,6 +113,14 @@ func TestPostIsSystemMessage(t *testing.T) {
 	require.True(t, post2.IsSystemMessage())
 }
 
+func TestPostGetRootId(t *testing.T) {
+	root := &Post{Id: NewId()}
+	require.Equal(t, root.Id, root.GetRootId())
+
+	reply := &Post{Id: NewId(), RootId: root.Id, ParentId: root.Id}
+	require.Equal(t, root.Id, reply.GetRootId())
+}
+
 func TestPostChannelMentions(t *testing.T) {
 	post := Post{Message: "~a ~b ~b ~c/~d."}
 	assert.Equal(t, []string{"a", "b", "c", "d"}, post.ChannelMentions())
@@ -20,6 +20,9 @@ const (
 	PREFERENCE_CATEGORY_FLAGGED_POST        = "flagged_post"
 	PREFERENCE_CATEGORY_FAVORITE_CHANNEL    = "favorite_channel"
 	PREFERENCE_CATEGORY_SIDEBAR_SETTINGS    = "sidebar_settings"
+	PREFERENCE_CATEGORY_TEAMS_ORDER         = "teams_order"
+
+	PREFERENCE_NAME_SIDEBAR_SORTING = "sidebar_sorting"
 
 	PREFERENCE_CATEGORY_DISPLAY_SETTINGS = "display_settings"
 	PREFERENCE_NAME_CHANNEL_DISPLAY_MODE = "channel_display_mode"
@@ -38,8 +41,16 @@ const (
 	PREFERENCE_NAME_LAST_CHANNEL = "channel"
 	PREFERENCE_NAME_LAST_TEAM    = "team"
 
-	PREFERENCE_CATEGORY_NOTIFICATIONS = "notifications"
-	PREFERENCE_NAME_EMAIL_INTERVAL    = "email_interval"
+	PREFERENCE_CATEGORY_EMOJI       = "emoji"
+	PREFERENCE_NAME_EMOJI_SKIN_TONE = "emoji_skintone"
+
+	// The password category is managed by the server and can't be updated through the API.
+	PREFERENCE_CATEGORY_PASSWORD          = "password"
+	PREFERENCE_NAME_PASSWORD_SET_BY_ADMIN = "set_by_admin"
+
+	PREFERENCE_CATEGORY_NOTIFICATIONS               = "notifications"
+	PREFERENCE_NAME_EMAIL_INTERVAL                  = "email_interval"
+	PREFERENCE_NAME_MUTED_CHANNEL_MENTIONS_IN_BADGE = "muted_channel_mentions_in_badge"
 
 	PREFERENCE_EMAIL_INTERVAL_NO_BATCHING_SECONDS = "30"  // the "immediate" setting is actually 30s
 	PREFERENCE_EMAIL_INTERVAL_BATCHING_SECONDS    = "900" // fifteen minutes is 900 seconds
@@ -92,6 +103,14 @@ func (o *Preference) IsValid() *AppError {
 		}
 	}
 
+	if o.Category == PREFERENCE_CATEGORY_EMOJI && o.Name == PREFERENCE_NAME_EMOJI_SKIN_TONE && !IsValidEmojiSkinTone(o.Value) {
+		return NewAppError("Preference.IsValid", "model.preference.is_valid.emoji_skin_tone.app_error", nil, "value="+o.Value, http.StatusBadRequest)
+	}
+
+	if o.Category == PREFERENCE_CATEGORY_SIDEBAR_SETTINGS && o.Name == PREFERENCE_NAME_SIDEBAR_SORTING && !IsValidSidebarSorting(o.Value) {
+		return NewAppError("Preference.IsValid", "model.preference.is_valid.sidebar_sorting.app_error", nil, "value="+o.Value, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -46,6 +46,26 @@ func TestPreferenceIsValid(t *testing.T) {
 
 	preference.Value = `{"color": "#ff0000", "color2": "#faf"}`
 	require.Nil(t, preference.IsValid())
+
+	preference.Category = PREFERENCE_CATEGORY_EMOJI
+	preference.Name = PREFERENCE_NAME_EMOJI_SKIN_TONE
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = EMOJI_SKIN_TONE_MEDIUM_DARK
+	require.Nil(t, preference.IsValid())
+
+	preference.Value = EMOJI_SKIN_TONE_DEFAULT
+	require.Nil(t, preference.IsValid())
+
+	preference.Category = PREFERENCE_CATEGORY_SIDEBAR_SETTINGS
+	preference.Name = PREFERENCE_NAME_SIDEBAR_SORTING
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = string(SidebarCategorySortDefault)
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = string(SidebarCategorySortRecent)
+	require.Nil(t, preference.IsValid())
 }
 
 func TestPreferencePreUpdate(t *testing.T) {
@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// RateLimitStatus is the current budget of a rate limited key for one class of requests.
+type RateLimitStatus struct {
+	Class     string `json:"class"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	// ResetAfter is the number of milliseconds until the full budget is available again.
+	ResetAfter int64 `json:"reset_after"`
+}
+
+func RateLimitStatusListToJson(statuses []*RateLimitStatus) string {
+	b, _ := json.Marshal(statuses)
+	return string(b)
+}
+
+func RateLimitStatusListFromJson(data io.Reader) []*RateLimitStatus {
+	var statuses []*RateLimitStatus
+	json.NewDecoder(data).Decode(&statuses)
+	return statuses
+}
@@ -428,6 +428,7 @@ func MakeDefaultRoles() map[string]*Role {
 			PERMISSION_CREATE_POST.Id,
 			PERMISSION_USE_CHANNEL_MENTIONS.Id,
 			PERMISSION_USE_SLASH_COMMANDS.Id,
+			PERMISSION_ADD_BOOKMARK.Id,
 		},
 		SchemeManaged: true,
 		BuiltIn:       true,
@@ -440,6 +441,7 @@ func MakeDefaultRoles() map[string]*Role {
 		Permissions: []string{
 			PERMISSION_MANAGE_CHANNEL_ROLES.Id,
 			PERMISSION_USE_GROUP_MENTIONS.Id,
+			PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		},
 		SchemeManaged: true,
 		BuiltIn:       true,
@@ -510,6 +512,7 @@ func MakeDefaultRoles() map[string]*Role {
 			PERMISSION_MANAGE_OTHERS_SLASH_COMMANDS.Id,
 			PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id,
 			PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id,
+			PERMISSION_MANAGE_CHANNEL_READ_ONLY.Id,
 		},
 		SchemeManaged: true,
 		BuiltIn:       true,
@@ -632,6 +635,7 @@ func MakeDefaultRoles() map[string]*Role {
 							PERMISSION_LIST_PRIVATE_TEAMS.Id,
 							PERMISSION_JOIN_PRIVATE_TEAMS.Id,
 							PERMISSION_VIEW_MEMBERS.Id,
+							PERMISSION_MANAGE_CUSTOM_GROUPS.Id,
 						},
 						roles[TEAM_USER_ROLE_ID].Permissions...,
 					),
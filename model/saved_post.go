@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SAVED_POST_LABEL_MAX_RUNES = 64
+	SAVED_POST_NOTE_MAX_RUNES  = 1024
+)
+
+// SavedPost is a post flagged by a user, optionally filed under a label of the user's choosing and
+// annotated with a note. Saved posts without a label are listed together with all the others.
+type SavedPost struct {
+	UserId  string `json:"user_id"`
+	PostId  string `json:"post_id"`
+	Label   string `json:"label"`
+	Note    string `json:"note"`
+	SavedAt int64  `json:"saved_at"`
+}
+
+// SavedPostPatch is a description of what fields to update on an existing saved post.
+type SavedPostPatch struct {
+	Label *string `json:"label"`
+	Note  *string `json:"note"`
+}
+
+// IsValid validates the saved post and returns an error if it isn't configured correctly.
+func (o *SavedPost) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.post_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.SavedAt == 0 {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.saved_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Label) > SAVED_POST_LABEL_MAX_RUNES {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.label.app_error", map[string]interface{}{"Max": SAVED_POST_LABEL_MAX_RUNES}, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Note) > SAVED_POST_NOTE_MAX_RUNES {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.note.app_error", map[string]interface{}{"Max": SAVED_POST_NOTE_MAX_RUNES}, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PreSave should be run before saving a saved post to the database. Posts keep the time they were
+// first saved, such as when they are imported.
+func (o *SavedPost) PreSave() {
+	if o.SavedAt == 0 {
+		o.SavedAt = GetMillis()
+	}
+
+	o.Label = strings.TrimSpace(o.Label)
+}
+
+// Patch modifies an existing saved post with optional fields from the given patch.
+func (o *SavedPost) Patch(patch *SavedPostPatch) {
+	if patch.Label != nil {
+		o.Label = strings.TrimSpace(*patch.Label)
+	}
+
+	if patch.Note != nil {
+		o.Note = *patch.Note
+	}
+}
+
+func (o *SavedPost) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedPostFromJson(data io.Reader) *SavedPost {
+	var o *SavedPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func SavedPostsToJson(o []*SavedPost) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedPostsFromJson(data io.Reader) []*SavedPost {
+	var o []*SavedPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *SavedPostPatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedPostPatchFromJson(data io.Reader) *SavedPostPatch {
+	var o *SavedPostPatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
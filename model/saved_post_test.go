@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedPostIsValid(t *testing.T) {
+	newSavedPost := func() *SavedPost {
+		savedPost := &SavedPost{
+			UserId: NewId(),
+			PostId: NewId(),
+			Label:  "Follow up",
+			Note:   "Ask about the release date",
+		}
+		savedPost.PreSave()
+		return savedPost
+	}
+
+	testCases := []struct {
+		Description string
+		Modify      func(s *SavedPost)
+		Valid       bool
+	}{
+		{"valid", func(s *SavedPost) {}, true},
+		{"valid without label or note", func(s *SavedPost) { s.Label = ""; s.Note = "" }, true},
+		{"invalid user id", func(s *SavedPost) { s.UserId = "junk" }, false},
+		{"invalid post id", func(s *SavedPost) { s.PostId = "" }, false},
+		{"missing saved at", func(s *SavedPost) { s.SavedAt = 0 }, false},
+		{"long label", func(s *SavedPost) { s.Label = strings.Repeat("a", SAVED_POST_LABEL_MAX_RUNES+1) }, false},
+		{"long note", func(s *SavedPost) { s.Note = strings.Repeat("a", SAVED_POST_NOTE_MAX_RUNES+1) }, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			savedPost := newSavedPost()
+			testCase.Modify(savedPost)
+			if testCase.Valid {
+				assert.Nil(t, savedPost.IsValid())
+			} else {
+				assert.NotNil(t, savedPost.IsValid())
+			}
+		})
+	}
+}
+
+func TestSavedPostPreSave(t *testing.T) {
+	savedPost := &SavedPost{Label: "  Follow up "}
+	savedPost.PreSave()
+	assert.NotZero(t, savedPost.SavedAt)
+	assert.Equal(t, "Follow up", savedPost.Label)
+
+	savedPost = &SavedPost{SavedAt: 1}
+	savedPost.PreSave()
+	assert.Equal(t, int64(1), savedPost.SavedAt)
+}
+
+func TestSavedPostPatch(t *testing.T) {
+	savedPost := &SavedPost{Label: "Follow up", Note: "Ask about it"}
+
+	savedPost.Patch(&SavedPostPatch{Note: NewString("Done")})
+	assert.Equal(t, "Follow up", savedPost.Label)
+	assert.Equal(t, "Done", savedPost.Note)
+
+	savedPost.Patch(&SavedPostPatch{Label: NewString(" Archive ")})
+	assert.Equal(t, "Archive", savedPost.Label)
+	assert.Equal(t, "Done", savedPost.Note)
+}
+
+func TestSavedPostJson(t *testing.T) {
+	savedPost := &SavedPost{UserId: NewId(), PostId: NewId(), Label: "Follow up", SavedAt: 1}
+	assert.Equal(t, savedPost, SavedPostFromJson(strings.NewReader(savedPost.ToJson())))
+
+	savedPosts := []*SavedPost{savedPost}
+	assert.Equal(t, savedPosts, SavedPostsFromJson(strings.NewReader(SavedPostsToJson(savedPosts))))
+
+	patch := &SavedPostPatch{Note: NewString("Done")}
+	assert.Equal(t, patch, SavedPostPatchFromJson(strings.NewReader(patch.ToJson())))
+}
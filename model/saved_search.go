@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SAVED_SEARCH_NAME_MAX_RUNES  = 64
+	SAVED_SEARCH_TERMS_MAX_RUNES = 512
+
+	// SAVED_SEARCH_MAX_PER_USER is the number of saved searches a user may have.
+	SAVED_SEARCH_MAX_PER_USER = 25
+	// SAVED_SEARCH_MAX_NOTIFY_PER_USER is the number of a user's saved searches that may notify
+	// them of new matches, since each of them is run periodically on the user's behalf.
+	SAVED_SEARCH_MAX_NOTIFY_PER_USER = 5
+)
+
+// SavedSearch is a named search query kept by a user to be run again later. When NotifyOnNewMatch
+// is set, the query is run periodically and the user is told about posts matching it that were
+// created since LastEvaluatedAt.
+type SavedSearch struct {
+	Id               string `json:"id"`
+	CreateAt         int64  `json:"create_at"`
+	UpdateAt         int64  `json:"update_at"`
+	UserId           string `json:"user_id"`
+	TeamId           string `json:"team_id"`
+	Name             string `json:"name"`
+	Terms            string `json:"terms"`
+	IsOrSearch       bool   `json:"is_or_search"`
+	NotifyOnNewMatch bool   `json:"notify_on_new_match"`
+	LastEvaluatedAt  int64  `json:"last_evaluated_at"`
+}
+
+// SavedSearchPatch is a description of what fields to update on an existing saved search.
+type SavedSearchPatch struct {
+	Name             *string `json:"name"`
+	Terms            *string `json:"terms"`
+	IsOrSearch       *bool   `json:"is_or_search"`
+	NotifyOnNewMatch *bool   `json:"notify_on_new_match"`
+}
+
+// IsValid validates the saved search and returns an error if it isn't configured correctly. The
+// terms must be accepted by the same parser used when searching posts.
+func (o *SavedSearch) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if strings.TrimSpace(o.Name) == "" || utf8.RuneCountInString(o.Name) > SAVED_SEARCH_NAME_MAX_RUNES {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.name.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Terms) > SAVED_SEARCH_TERMS_MAX_RUNES || !isValidSavedSearchTerms(o.Terms) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.terms.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// isValidSavedSearchTerms returns whether the terms parse into at least one search that would be
+// run, since searches for everything are never run.
+func isValidSavedSearchTerms(terms string) bool {
+	for _, params := range ParseSearchParams(strings.TrimSpace(terms), 0) {
+		if params.Terms != "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// PreSave should be run before saving a new saved search to the database. Only posts created after
+// the search is saved are reported as new matches.
+func (o *SavedSearch) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+	o.LastEvaluatedAt = o.CreateAt
+}
+
+// PreUpdate should be run before saving an updated saved search to the database.
+func (o *SavedSearch) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// Patch modifies an existing saved search with optional fields from the given patch.
+func (o *SavedSearch) Patch(patch *SavedSearchPatch) {
+	if patch.Name != nil {
+		o.Name = *patch.Name
+	}
+
+	if patch.Terms != nil {
+		o.Terms = *patch.Terms
+	}
+
+	if patch.IsOrSearch != nil {
+		o.IsOrSearch = *patch.IsOrSearch
+	}
+
+	if patch.NotifyOnNewMatch != nil {
+		o.NotifyOnNewMatch = *patch.NotifyOnNewMatch
+	}
+}
+
+func (o *SavedSearch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedSearchFromJson(data io.Reader) *SavedSearch {
+	var o *SavedSearch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func SavedSearchesToJson(o []*SavedSearch) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedSearchesFromJson(data io.Reader) []*SavedSearch {
+	var o []*SavedSearch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *SavedSearchPatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedSearchPatchFromJson(data io.Reader) *SavedSearchPatch {
+	var o *SavedSearchPatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchIsValid(t *testing.T) {
+	newSavedSearch := func() *SavedSearch {
+		savedSearch := &SavedSearch{
+			UserId: NewId(),
+			TeamId: NewId(),
+			Name:   "Outages",
+			Terms:  "outage in:town-square",
+		}
+		savedSearch.PreSave()
+		return savedSearch
+	}
+
+	testCases := []struct {
+		Description string
+		Modify      func(s *SavedSearch)
+		Valid       bool
+	}{
+		{"valid", func(s *SavedSearch) {}, true},
+		{"valid with only filters", func(s *SavedSearch) { s.Terms = "from:someone after:2020-01-01" }, true},
+		{"valid hashtag", func(s *SavedSearch) { s.Terms = "#incident" }, true},
+		{"invalid id", func(s *SavedSearch) { s.Id = "junk" }, false},
+		{"missing create at", func(s *SavedSearch) { s.CreateAt = 0 }, false},
+		{"missing update at", func(s *SavedSearch) { s.UpdateAt = 0 }, false},
+		{"invalid user id", func(s *SavedSearch) { s.UserId = "" }, false},
+		{"invalid team id", func(s *SavedSearch) { s.TeamId = "junk" }, false},
+		{"empty name", func(s *SavedSearch) { s.Name = "  " }, false},
+		{"long name", func(s *SavedSearch) { s.Name = strings.Repeat("a", SAVED_SEARCH_NAME_MAX_RUNES+1) }, false},
+		{"empty terms", func(s *SavedSearch) { s.Terms = " " }, false},
+		{"search for everything", func(s *SavedSearch) { s.Terms = "*" }, false},
+		{"long terms", func(s *SavedSearch) { s.Terms = strings.Repeat("a", SAVED_SEARCH_TERMS_MAX_RUNES+1) }, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			savedSearch := newSavedSearch()
+			testCase.Modify(savedSearch)
+			if testCase.Valid {
+				assert.Nil(t, savedSearch.IsValid())
+			} else {
+				assert.NotNil(t, savedSearch.IsValid())
+			}
+		})
+	}
+}
+
+func TestSavedSearchPreSave(t *testing.T) {
+	savedSearch := &SavedSearch{LastEvaluatedAt: 1}
+	savedSearch.PreSave()
+
+	assert.True(t, IsValidId(savedSearch.Id))
+	assert.NotZero(t, savedSearch.CreateAt)
+	assert.Equal(t, savedSearch.CreateAt, savedSearch.UpdateAt)
+	assert.Equal(t, savedSearch.CreateAt, savedSearch.LastEvaluatedAt)
+}
+
+func TestSavedSearchPatch(t *testing.T) {
+	savedSearch := &SavedSearch{
+		Name:  "Outages",
+		Terms: "outage",
+	}
+
+	savedSearch.Patch(&SavedSearchPatch{
+		Terms:            NewString("outage incident"),
+		IsOrSearch:       NewBool(true),
+		NotifyOnNewMatch: NewBool(true),
+	})
+
+	assert.Equal(t, "Outages", savedSearch.Name)
+	assert.Equal(t, "outage incident", savedSearch.Terms)
+	assert.True(t, savedSearch.IsOrSearch)
+	assert.True(t, savedSearch.NotifyOnNewMatch)
+}
+
+func TestSavedSearchJson(t *testing.T) {
+	savedSearch := &SavedSearch{
+		Id:               NewId(),
+		UserId:           NewId(),
+		TeamId:           NewId(),
+		Name:             "Outages",
+		Terms:            "outage",
+		NotifyOnNewMatch: true,
+	}
+
+	result := SavedSearchFromJson(strings.NewReader(savedSearch.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, savedSearch, result)
+
+	results := SavedSearchesFromJson(strings.NewReader(SavedSearchesToJson([]*SavedSearch{savedSearch})))
+	require.Len(t, results, 1)
+	assert.Equal(t, savedSearch, results[0])
+}
@@ -5,6 +5,7 @@ package model
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -29,80 +30,128 @@ type SearchParams struct {
 	OrTerms                bool
 	IncludeDeletedChannels bool
 	TimeZoneOffset         int
+	// The IANA name of the time zone of the searching user, such as America/New_York. When set, it takes precedence
+	// over TimeZoneOffset, so that days spanning daylight saving time transitions are handled properly.
+	TimeZone string
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool
 }
 
-// Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
+// searchDateWithOffset matches the dates of the date search flags, which can carry an explicit UTC offset as in
+// 2024-03-01+02:00.
+var searchDateWithOffset = regexp.MustCompile(`^(\d{4}-\d{1,2}-\d{1,2})(?:([+-])(\d{2}):(\d{2}))?$`)
+
+// location returns the time zone the days of the date search flags are in: the time zone of the searching user if
+// it's known, or else the fixed offset sent by the client.
+func (p *SearchParams) location() *time.Location {
+	if p.TimeZone != "" {
+		if location, err := time.LoadLocation(p.TimeZone); err == nil {
+			return location
+		}
+	}
+
+	return time.FixedZone("Local Search Time Zone", p.TimeZoneOffset)
+}
+
+// parseDate parses the value of a date search flag, returning the date and the time zone its day is in. An explicit
+// offset in the value takes precedence over the time zone of the search.
+func (p *SearchParams) parseDate(value string) (time.Time, *time.Location, error) {
+	location := p.location()
+
+	match := searchDateWithOffset.FindStringSubmatch(value)
+	if match == nil {
+		_, err := time.Parse("2006-01-02", PadDateStringZeros(value))
+		return time.Time{}, location, err
+	}
+
+	date, err := time.Parse("2006-01-02", PadDateStringZeros(match[1]))
+	if err != nil {
+		return time.Time{}, location, err
+	}
+
+	if match[2] != "" {
+		hours, _ := strconv.Atoi(match[3])
+		minutes, _ := strconv.Atoi(match[4])
+		offset := hours*60*60 + minutes*60
+		if match[2] == "-" {
+			offset = -offset
+		}
+		location = time.FixedZone(match[2]+match[3]+":"+match[4], offset)
+	}
+
+	return date, location, nil
+}
+
+// startOfDayMillis returns the epoch timestamp of the start of the given day, offset by the given number of days, in
+// the given time zone. Days aren't assumed to last 24 hours, so daylight saving time transitions are accounted for.
+func startOfDayMillis(date time.Time, days int, location *time.Location) int64 {
+	return GetMillisForTime(time.Date(date.Year(), date.Month(), date.Day()+days, 0, 0, 0, 0, location))
+}
+
+// endOfDayMillis returns the epoch timestamp of the end of the given day, offset by the given number of days, in the
+// given time zone.
+func endOfDayMillis(date time.Time, days int, location *time.Location) int64 {
+	return GetMillisForTime(time.Date(date.Year(), date.Month(), date.Day()+days, 23, 59, 59, 999999999, location))
+}
+
+// Returns the epoch timestamp of the start of the day after the one specified by SearchParams.AfterDate
 func (p *SearchParams) GetAfterDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.AfterDate))
+	date, location, err := p.parseDate(p.AfterDate)
 	if err != nil {
 		date = time.Now()
 	}
 
-	// travel forward 1 day
-	oneDay := time.Hour * 24
-	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return startOfDayMillis(date, 1, location)
 }
 
-// Returns the epoch timestamp of the start of the day specified by SearchParams.ExcludedAfterDate
+// Returns the epoch timestamp of the start of the day after the one specified by SearchParams.ExcludedAfterDate
 func (p *SearchParams) GetExcludedAfterDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedAfterDate))
+	date, location, err := p.parseDate(p.ExcludedAfterDate)
 	if err != nil {
 		date = time.Now()
 	}
 
-	// travel forward 1 day
-	oneDay := time.Hour * 24
-	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return startOfDayMillis(date, 1, location)
 }
 
-// Returns the epoch timestamp of the end of the day specified by SearchParams.BeforeDate
+// Returns the epoch timestamp of the end of the day before the one specified by SearchParams.BeforeDate
 func (p *SearchParams) GetBeforeDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.BeforeDate))
+	date, location, err := p.parseDate(p.BeforeDate)
 	if err != nil {
 		return 0
 	}
 
-	// travel back 1 day
-	oneDay := time.Hour * -24
-	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return endOfDayMillis(date, -1, location)
 }
 
-// Returns the epoch timestamp of the end of the day specified by SearchParams.ExcludedBeforeDate
+// Returns the epoch timestamp of the end of the day before the one specified by SearchParams.ExcludedBeforeDate
 func (p *SearchParams) GetExcludedBeforeDateMillis() int64 {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedBeforeDate))
+	date, location, err := p.parseDate(p.ExcludedBeforeDate)
 	if err != nil {
 		return 0
 	}
 
-	// travel back 1 day
-	oneDay := time.Hour * -24
-	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return endOfDayMillis(date, -1, location)
 }
 
 // Returns the epoch timestamps of the start and end of the day specified by SearchParams.OnDate
 func (p *SearchParams) GetOnDateMillis() (int64, int64) {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.OnDate))
+	date, location, err := p.parseDate(p.OnDate)
 	if err != nil {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return startOfDayMillis(date, 0, location), endOfDayMillis(date, 0, location)
 }
 
 // Returns the epoch timestamps of the start and end of the day specified by SearchParams.ExcludedDate
 func (p *SearchParams) GetExcludedDateMillis() (int64, int64) {
-	date, err := time.Parse("2006-01-02", PadDateStringZeros(p.ExcludedDate))
+	date, location, err := p.parseDate(p.ExcludedDate)
 	if err != nil {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return startOfDayMillis(date, 0, location), endOfDayMillis(date, 0, location)
 }
 
 var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on"}
@@ -225,6 +274,43 @@ func parseSearchFlags(input []string) ([]searchWord, []flag) {
 	return words, flags
 }
 
+// normalizeSearchChannelTarget rewrites the direct and group message targets of the in: flag, such as
+// "@Alice,@bob", to the "@alice,bob" form. Channel names are left as they are.
+func normalizeSearchChannelTarget(target string) string {
+	usernames, ok := SearchChannelTargetUsernames(target)
+	if !ok {
+		return target
+	}
+
+	return "@" + strings.Join(usernames, ",")
+}
+
+// SearchChannelTargetUsernames returns the usernames listed by an in: flag value targeting a direct
+// message, as in "@username", or a group message, as in "@username1,username2". The searcher may be
+// left out of the list.
+func SearchChannelTargetUsernames(target string) ([]string, bool) {
+	if !strings.HasPrefix(target, "@") {
+		return nil, false
+	}
+
+	usernames := []string{}
+	seen := map[string]bool{}
+	for _, username := range strings.Split(target, ",") {
+		username = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	if len(usernames) == 0 {
+		return nil, false
+	}
+
+	return usernames, true
+}
+
 func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	words, flags := parseSearchFlags(splitWords(text))
 
@@ -267,10 +353,11 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 
 	for _, flag := range flags {
 		if flag.name == "in" || flag.name == "channel" {
+			channel := normalizeSearchChannelTarget(flag.value)
 			if flag.exclude {
-				excludedChannels = append(excludedChannels, flag.value)
+				excludedChannels = append(excludedChannels, channel)
 			} else {
-				inChannels = append(inChannels, flag.value)
+				inChannels = append(inChannels, channel)
 			}
 		} else if flag.name == "from" {
 			if flag.exclude {
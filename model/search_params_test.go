@@ -1501,6 +1501,36 @@ func TestParseSearchParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "input with in: targeting a direct message should result in a single InChannel",
+			Input: "testing in:@Username",
+			Output: []*SearchParams{
+				{
+					Terms:            "testing",
+					ExcludedTerms:    "",
+					IsHashtag:        false,
+					InChannels:       []string{"@username"},
+					ExcludedChannels: []string{},
+					FromUsers:        []string{},
+					ExcludedUsers:    []string{},
+				},
+			},
+		},
+		{
+			Name:  "input with -in: targeting a group message should result in a normalized ExcludedChannel",
+			Input: "testing -in:@user1,@User2,,user1",
+			Output: []*SearchParams{
+				{
+					Terms:            "testing",
+					ExcludedTerms:    "",
+					IsHashtag:        false,
+					InChannels:       []string{},
+					ExcludedChannels: []string{"@user1,user2"},
+					FromUsers:        []string{},
+					ExcludedUsers:    []string{},
+				},
+			},
+		},
 	} {
 		t.Run(testCase.Name, func(t *testing.T) {
 			require.Equal(t, testCase.Output, ParseSearchParams(testCase.Input, 0))
@@ -1508,6 +1538,27 @@ func TestParseSearchParams(t *testing.T) {
 	}
 }
 
+func TestSearchChannelTargetUsernames(t *testing.T) {
+	for _, testCase := range []struct {
+		Input     string
+		Usernames []string
+		Ok        bool
+	}{
+		{"channel-name", nil, false},
+		{"@", nil, false},
+		{"@,", nil, false},
+		{"@username", []string{"username"}, true},
+		{"@User1,@user2, user3", []string{"user1", "user2", "user3"}, true},
+		{"@user1,user1", []string{"user1"}, true},
+	} {
+		t.Run(testCase.Input, func(t *testing.T) {
+			usernames, ok := SearchChannelTargetUsernames(testCase.Input)
+			assert.Equal(t, testCase.Ok, ok)
+			assert.Equal(t, testCase.Usernames, usernames)
+		})
+	}
+}
+
 func TestGetOnDateMillis(t *testing.T) {
 	for _, testCase := range []struct {
 		Name        string
@@ -1618,3 +1669,72 @@ func TestGetAfterDateMillis(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchDatesInTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.Nil(t, err)
+
+	millis := func(year int, month time.Month, day, hour, min, sec, nsec int, location *time.Location) int64 {
+		return GetMillisForTime(time.Date(year, month, day, hour, min, sec, nsec, location))
+	}
+
+	t.Run("day with a daylight saving time start", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2024-03-10", TimeZone: "America/New_York"}
+		start, end := sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.March, 10, 5, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, millis(2024, time.March, 11, 3, 59, 59, 999999999, time.UTC), end)
+		assert.Equal(t, int64(23*60*60*1000-1), end-start)
+	})
+
+	t.Run("day with a daylight saving time end", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2024-11-03", TimeZone: "America/New_York"}
+		start, end := sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.November, 3, 4, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, int64(25*60*60*1000-1), end-start)
+	})
+
+	t.Run("before and after a daylight saving time start", func(t *testing.T) {
+		sp := &SearchParams{AfterDate: "2024-03-09", BeforeDate: "2024-03-11", TimeZone: "America/New_York"}
+		assert.Equal(t, millis(2024, time.March, 10, 0, 0, 0, 0, newYork), sp.GetAfterDateMillis())
+		assert.Equal(t, millis(2024, time.March, 10, 23, 59, 59, 999999999, newYork), sp.GetBeforeDateMillis())
+
+		sp = &SearchParams{ExcludedAfterDate: "2024-03-09", ExcludedBeforeDate: "2024-03-11", TimeZone: "America/New_York"}
+		assert.Equal(t, millis(2024, time.March, 10, 0, 0, 0, 0, newYork), sp.GetExcludedAfterDateMillis())
+		assert.Equal(t, millis(2024, time.March, 10, 23, 59, 59, 999999999, newYork), sp.GetExcludedBeforeDateMillis())
+	})
+
+	t.Run("time zone takes precedence over the offset", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2024-03-01", TimeZone: "America/New_York", TimeZoneOffset: 2 * 60 * 60}
+		start, _ := sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.March, 1, 5, 0, 0, 0, time.UTC), start)
+	})
+
+	t.Run("unknown time zones fall back to the offset", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2024-03-01", TimeZone: "Nowhere/Special", TimeZoneOffset: 2 * 60 * 60}
+		start, _ := sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.February, 29, 22, 0, 0, 0, time.UTC), start)
+	})
+
+	t.Run("explicit offsets take precedence over the time zone", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2024-03-01+02:00", TimeZone: "America/New_York"}
+		start, end := sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.February, 29, 22, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, millis(2024, time.March, 1, 21, 59, 59, 999999999, time.UTC), end)
+
+		sp = &SearchParams{OnDate: "2024-3-1-05:30"}
+		start, _ = sp.GetOnDateMillis()
+		assert.Equal(t, millis(2024, time.March, 1, 5, 30, 0, 0, time.UTC), start)
+
+		sp = &SearchParams{OnDate: "2024-03-01+2:00"}
+		start, end = sp.GetOnDateMillis()
+		assert.Zero(t, start)
+		assert.Zero(t, end)
+	})
+
+	t.Run("explicit offsets are parsed from search terms", func(t *testing.T) {
+		params := ParseSearchParams("on:2024-03-01+02:00 after:2024-02-01-05:00 hello", 0)
+		require.Len(t, params, 1)
+		assert.Equal(t, "2024-03-01+02:00", params[0].OnDate)
+		assert.Equal(t, "2024-02-01-05:00", params[0].AfterDate)
+	})
+}
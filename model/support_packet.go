@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	SUPPORT_PACKET_FILE_NAME        = "support_packet.json"
+	SUPPORT_PACKET_CONFIG_FILE_NAME = "sanitized_config.json"
+	SUPPORT_PACKET_LOG_FILE_NAME    = "mattermost.log"
+)
+
+// SupportPacket holds the diagnostic information collected for a support request. Everything
+// in it must be redacted before it leaves the server.
+type SupportPacket struct {
+	GeneratedAt  int64                 `json:"generated_at"`
+	Server       *SupportPacketServer  `json:"server"`
+	License      *SupportPacketLicense `json:"license,omitempty"`
+	Plugins      []*PluginStatus       `json:"plugins"`
+	Jobs         []*Job                `json:"jobs"`
+	Connectivity []*SupportPacketCheck `json:"connectivity"`
+	Metrics      *SupportPacketMetrics `json:"metrics"`
+	Warnings     []string              `json:"warnings,omitempty"`
+}
+
+type SupportPacketServer struct {
+	Version              string `json:"version"`
+	BuildNumber          string `json:"build_number"`
+	BuildDate            string `json:"build_date"`
+	BuildHash            string `json:"build_hash"`
+	BuildHashEnterprise  string `json:"build_hash_enterprise"`
+	BuildEnterpriseReady string `json:"build_enterprise_ready"`
+	OperatingSystem      string `json:"operating_system"`
+	Architecture         string `json:"architecture"`
+	GoVersion            string `json:"go_version"`
+	DatabaseType         string `json:"database_type"`
+	DatabaseVersion      string `json:"database_version"`
+	ClusterEnabled       bool   `json:"cluster_enabled"`
+}
+
+// SupportPacketLicense is the part of the license that is useful to support, without any
+// customer details.
+type SupportPacketLicense struct {
+	Id           string `json:"id"`
+	SkuShortName string `json:"sku_short_name"`
+	IssuedAt     int64  `json:"issued_at"`
+	StartsAt     int64  `json:"starts_at"`
+	ExpiresAt    int64  `json:"expires_at"`
+	Users        int    `json:"users"`
+}
+
+// SupportPacketCheck is the result of a connectivity check against one of the server's dependencies.
+type SupportPacketCheck struct {
+	Name  string `json:"name"`
+	Ok    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type SupportPacketMetrics struct {
+	ActiveUsers          int64  `json:"active_users"`
+	WebsocketConnections int    `json:"websocket_connections"`
+	Goroutines           int    `json:"goroutines"`
+	HeapAllocBytes       uint64 `json:"heap_alloc_bytes"`
+	SysBytes             uint64 `json:"sys_bytes"`
+}
+
+// SupportPacketFile is a single file of the support packet archive.
+type SupportPacketFile struct {
+	Name string
+	Data []byte
+}
+
+func (sp *SupportPacket) ToJson() string {
+	b, _ := json.MarshalIndent(sp, "", "  ")
+	return string(b)
+}
+
+func SupportPacketFromJson(data io.Reader) *SupportPacket {
+	var sp *SupportPacket
+	json.NewDecoder(data).Decode(&sp)
+	return sp
+}
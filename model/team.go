@@ -26,31 +26,35 @@ const (
 )
 
 type Team struct {
-	Id                 string  `json:"id"`
-	CreateAt           int64   `json:"create_at"`
-	UpdateAt           int64   `json:"update_at"`
-	DeleteAt           int64   `json:"delete_at"`
-	DisplayName        string  `json:"display_name"`
-	Name               string  `json:"name"`
-	Description        string  `json:"description"`
-	Email              string  `json:"email"`
-	Type               string  `json:"type"`
-	CompanyName        string  `json:"company_name"`
-	AllowedDomains     string  `json:"allowed_domains"`
-	InviteId           string  `json:"invite_id"`
-	AllowOpenInvite    bool    `json:"allow_open_invite"`
-	LastTeamIconUpdate int64   `json:"last_team_icon_update,omitempty"`
-	SchemeId           *string `json:"scheme_id"`
-	GroupConstrained   *bool   `json:"group_constrained"`
+	Id                         string  `json:"id"`
+	CreateAt                   int64   `json:"create_at"`
+	UpdateAt                   int64   `json:"update_at"`
+	DeleteAt                   int64   `json:"delete_at"`
+	DisplayName                string  `json:"display_name"`
+	Name                       string  `json:"name"`
+	Description                string  `json:"description"`
+	Email                      string  `json:"email"`
+	Type                       string  `json:"type"`
+	CompanyName                string  `json:"company_name"`
+	AllowedDomains             string  `json:"allowed_domains"`
+	AutoJoinDomains            string  `json:"auto_join_domains"`
+	InviteId                   string  `json:"invite_id"`
+	AllowOpenInvite            bool    `json:"allow_open_invite"`
+	LastTeamIconUpdate         int64   `json:"last_team_icon_update,omitempty"`
+	SchemeId                   *string `json:"scheme_id"`
+	GroupConstrained           *bool   `json:"group_constrained"`
+	InactiveChannelArchiveDays *int64  `json:"inactive_channel_archive_days"`
 }
 
 type TeamPatch struct {
-	DisplayName      *string `json:"display_name"`
-	Description      *string `json:"description"`
-	CompanyName      *string `json:"company_name"`
-	AllowedDomains   *string `json:"allowed_domains"`
-	AllowOpenInvite  *bool   `json:"allow_open_invite"`
-	GroupConstrained *bool   `json:"group_constrained"`
+	DisplayName                *string `json:"display_name"`
+	Description                *string `json:"description"`
+	CompanyName                *string `json:"company_name"`
+	AllowedDomains             *string `json:"allowed_domains"`
+	AutoJoinDomains            *string `json:"auto_join_domains"`
+	AllowOpenInvite            *bool   `json:"allow_open_invite"`
+	GroupConstrained           *bool   `json:"group_constrained"`
+	InactiveChannelArchiveDays *int64  `json:"inactive_channel_archive_days"`
 }
 
 type TeamForExport struct {
@@ -67,6 +71,14 @@ type TeamsWithCount struct {
 	TotalCount int64   `json:"total_count"`
 }
 
+// TeamWithUnreads is a team along with the unread counts of a user for that team, as shown by the
+// team switcher badges.
+type TeamWithUnreads struct {
+	Team
+	MsgCount     int64 `json:"msg_count"`
+	MentionCount int64 `json:"mention_count"`
+}
+
 func InvitesFromJson(data io.Reader) *Invites {
 	var o *Invites
 	json.NewDecoder(data).Decode(&o)
@@ -192,6 +204,20 @@ func (o *Team) IsValid() *AppError {
 		return NewAppError("Team.IsValid", "model.team.is_valid.domains.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
+	if len(o.AutoJoinDomains) > TEAM_ALLOWED_DOMAINS_MAX_LENGTH {
+		return NewAppError("Team.IsValid", "model.team.is_valid.auto_join_domains.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, domain := range o.GetAutoJoinDomains() {
+		if !IsDomainName(domain) {
+			return NewAppError("Team.IsValid", "model.team.is_valid.auto_join_domains.app_error", nil, "id="+o.Id+", domain="+domain, http.StatusBadRequest)
+		}
+	}
+
+	if o.InactiveChannelArchiveDays != nil && *o.InactiveChannelArchiveDays < 0 {
+		return NewAppError("Team.IsValid", "model.team.is_valid.inactive_channel_archive_days.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -296,6 +322,10 @@ func (o *Team) Patch(patch *TeamPatch) {
 		o.AllowedDomains = *patch.AllowedDomains
 	}
 
+	if patch.AutoJoinDomains != nil {
+		o.AutoJoinDomains = *patch.AutoJoinDomains
+	}
+
 	if patch.AllowOpenInvite != nil {
 		o.AllowOpenInvite = *patch.AllowOpenInvite
 	}
@@ -303,12 +333,26 @@ func (o *Team) Patch(patch *TeamPatch) {
 	if patch.GroupConstrained != nil {
 		o.GroupConstrained = patch.GroupConstrained
 	}
+
+	if patch.InactiveChannelArchiveDays != nil {
+		o.InactiveChannelArchiveDays = patch.InactiveChannelArchiveDays
+	}
 }
 
 func (o *Team) IsGroupConstrained() bool {
 	return o.GroupConstrained != nil && *o.GroupConstrained
 }
 
+// GetInactiveChannelArchiveDays returns the number of days without posts after which the team's
+// channels are archived, falling back to the given server-wide default. A value of zero means
+// inactive channels in the team are never archived.
+func (o *Team) GetInactiveChannelArchiveDays(defaultDays int64) int64 {
+	if o.InactiveChannelArchiveDays != nil {
+		return *o.InactiveChannelArchiveDays
+	}
+	return defaultDays
+}
+
 func (t *TeamPatch) ToJson() string {
 	b, err := json.Marshal(t)
 	if err != nil {
@@ -328,3 +372,9 @@ func TeamPatchFromJson(data io.Reader) *TeamPatch {
 
 	return &team
 }
+
+// GetAutoJoinDomains returns the email domains whose users join the team automatically when their
+// account is created. Domains can be separated by spaces or commas and may start with an @.
+func (o *Team) GetAutoJoinDomains() []string {
+	return strings.Fields(strings.ToLower(strings.NewReplacer("@", " ", ",", " ").Replace(o.AutoJoinDomains)))
+}
@@ -32,6 +32,14 @@ type TeamUnread struct {
 	MentionCount int64  `json:"mention_count"`
 }
 
+// TeamMemberWithUnread is a team membership along with the unread counts for that team, allowing
+// the team sidebar to be painted with a single request.
+type TeamMemberWithUnread struct {
+	TeamMember
+	MsgCount     int64 `json:"msg_count"`
+	MentionCount int64 `json:"mention_count"`
+}
+
 type TeamMemberForExport struct {
 	TeamMember
 	TeamName string
@@ -151,6 +159,20 @@ func TeamMembersFromJson(data io.Reader) []*TeamMember {
 	return o
 }
 
+func TeamMembersWithUnreadToJson(o []*TeamMemberWithUnread) string {
+	if b, err := json.Marshal(o); err != nil {
+		return "[]"
+	} else {
+		return string(b)
+	}
+}
+
+func TeamMembersWithUnreadFromJson(data io.Reader) []*TeamMemberWithUnread {
+	var o []*TeamMemberWithUnread
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func TeamsUnreadToJson(o []*TeamUnread) string {
 	if b, err := json.Marshal(o); err != nil {
 		return "[]"
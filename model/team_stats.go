@@ -9,9 +9,12 @@ import (
 )
 
 type TeamStats struct {
-	TeamId            string `json:"team_id"`
-	TotalMemberCount  int64  `json:"total_member_count"`
-	ActiveMemberCount int64  `json:"active_member_count"`
+	TeamId              string `json:"team_id"`
+	TotalMemberCount    int64  `json:"total_member_count"`
+	ActiveMemberCount   int64  `json:"active_member_count"`
+	OpenChannelCount    int64  `json:"open_channel_count,omitempty"`
+	PrivateChannelCount int64  `json:"private_channel_count,omitempty"`
+	PostCount           int64  `json:"post_count,omitempty"`
 }
 
 func (o *TeamStats) ToJson() string {
@@ -56,6 +56,14 @@ func TestTeamIsValid(t *testing.T) {
 	o.InviteId = NewId()
 	err = o.IsValid()
 	require.Nil(t, err, err)
+
+	o.AutoJoinDomains = "example.com, @example..com"
+	err = o.IsValid()
+	require.NotNil(t, err, "should be invalid")
+
+	o.AutoJoinDomains = "example.com, @corp.example.com"
+	err = o.IsValid()
+	require.Nil(t, err, err)
 }
 
 func TestTeamPreSave(t *testing.T) {
@@ -126,14 +134,18 @@ func TestTeamPatch(t *testing.T) {
 		Description:      new(string),
 		CompanyName:      new(string),
 		AllowedDomains:   new(string),
+		AutoJoinDomains:  new(string),
 		AllowOpenInvite:  new(bool),
 		GroupConstrained: new(bool),
+
+		InactiveChannelArchiveDays: NewInt64(30),
 	}
 
 	*p.DisplayName = NewId()
 	*p.Description = NewId()
 	*p.CompanyName = NewId()
 	*p.AllowedDomains = NewId()
+	*p.AutoJoinDomains = "example.com"
 	*p.AllowOpenInvite = true
 	*p.GroupConstrained = true
 
@@ -144,6 +156,30 @@ func TestTeamPatch(t *testing.T) {
 	require.Equal(t, *p.Description, o.Description, "Description did not update")
 	require.Equal(t, *p.CompanyName, o.CompanyName, "CompanyName did not update")
 	require.Equal(t, *p.AllowedDomains, o.AllowedDomains, "AllowedDomains did not update")
+	require.Equal(t, *p.AutoJoinDomains, o.AutoJoinDomains, "AutoJoinDomains did not update")
 	require.Equal(t, *p.AllowOpenInvite, o.AllowOpenInvite, "AllowOpenInvite did not update")
 	require.Equal(t, *p.GroupConstrained, *o.GroupConstrained)
+	require.Equal(t, int64(30), *o.InactiveChannelArchiveDays)
+}
+
+func TestTeamGetAutoJoinDomains(t *testing.T) {
+	o := Team{Id: NewId()}
+	require.Empty(t, o.GetAutoJoinDomains())
+
+	o.AutoJoinDomains = " , @ "
+	require.Empty(t, o.GetAutoJoinDomains())
+
+	o.AutoJoinDomains = "@Example.com, corp.example.com  mattermost.org"
+	require.Equal(t, []string{"example.com", "corp.example.com", "mattermost.org"}, o.GetAutoJoinDomains())
+}
+
+func TestTeamGetInactiveChannelArchiveDays(t *testing.T) {
+	o := Team{Id: NewId()}
+	require.Equal(t, int64(90), o.GetInactiveChannelArchiveDays(90))
+
+	o.InactiveChannelArchiveDays = NewInt64(0)
+	require.Equal(t, int64(0), o.GetInactiveChannelArchiveDays(90))
+
+	o.InactiveChannelArchiveDays = NewInt64(30)
+	require.Equal(t, int64(30), o.GetInactiveChannelArchiveDays(90))
 }
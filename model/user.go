@@ -43,10 +43,13 @@ const (
 	FIRST_NAME_NOTIFY_PROP             = "first_name"
 	AUTO_RESPONDER_ACTIVE_NOTIFY_PROP  = "auto_responder_active"
 	AUTO_RESPONDER_MESSAGE_NOTIFY_PROP = "auto_responder_message"
+	CHANNEL_ADDED_NOTIFY_PROP          = "channel_added"
 
 	DEFAULT_LOCALE          = "en"
 	USER_AUTH_SERVICE_EMAIL = "email"
 
+	USER_PROP_DEFAULTED_LOCALE = "defaulted_locale"
+
 	USER_EMAIL_MAX_LENGTH     = 128
 	USER_NICKNAME_MAX_RUNES   = 64
 	USER_POSITION_MAX_RUNES   = 128
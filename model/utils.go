@@ -52,6 +52,17 @@ func (sa StringArray) Equals(input StringArray) bool {
 	return true
 }
 
+// Contains returns whether the array contains the input.
+func (sa StringArray) Contains(input string) bool {
+	for index := range sa {
+		if sa[index] == input {
+			return true
+		}
+	}
+
+	return false
+}
+
 var translateFunc goi18n.TranslateFunc = nil
 
 func AppErrorInit(t goi18n.TranslateFunc) {
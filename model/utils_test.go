@@ -283,6 +283,14 @@ func TestStringArray_Equal(t *testing.T) {
 	}
 }
 
+func TestStringArray_Contains(t *testing.T) {
+	sa := StringArray{"123", "abc"}
+
+	assert.True(t, sa.Contains("abc"))
+	assert.False(t, sa.Contains("ab"))
+	assert.False(t, StringArray(nil).Contains(""))
+}
+
 func TestParseHashtags(t *testing.T) {
 	for input, output := range hashtags {
 		o, _ := ParseHashtags(input)
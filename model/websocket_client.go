@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -66,7 +67,23 @@ func NewWebSocketClient(url, authToken string) (*WebSocketClient, *AppError) {
 // NewWebSocketClientWithDialer constructs a new WebSocket client with convenience
 // methods for talking to the server using a custom dialer.
 func NewWebSocketClientWithDialer(dialer *websocket.Dialer, url, authToken string) (*WebSocketClient, *AppError) {
-	conn, _, err := dialer.Dial(url+API_URL_SUFFIX+"/websocket", nil)
+	return newWebSocketClient(dialer, url, authToken, nil)
+}
+
+// NewWebSocketClientWithCapabilities constructs a new WebSocket client asking the server for the
+// given optional features, from the ones in WEBSOCKET_CAPABILITIES. The features granted are
+// listed in the hello event.
+func NewWebSocketClientWithCapabilities(url, authToken string, capabilities []string) (*WebSocketClient, *AppError) {
+	return newWebSocketClient(websocket.DefaultDialer, url, authToken, capabilities)
+}
+
+func newWebSocketClient(dialer *websocket.Dialer, url, authToken string, capabilities []string) (*WebSocketClient, *AppError) {
+	connectUrl := url + API_URL_SUFFIX + "/websocket"
+	if len(capabilities) > 0 {
+		connectUrl += "?capabilities=" + strings.Join(capabilities, ",")
+	}
+
+	conn, _, err := dialer.Dial(connectUrl, nil)
 	if err != nil {
 		return nil, NewAppError("NewWebSocketClient", "model.websocket_client.connect_fail.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -74,7 +91,7 @@ func NewWebSocketClientWithDialer(dialer *websocket.Dialer, url, authToken strin
 	client := &WebSocketClient{
 		Url:                url,
 		ApiUrl:             url + API_URL_SUFFIX,
-		ConnectUrl:         url + API_URL_SUFFIX + "/websocket",
+		ConnectUrl:         connectUrl,
 		Conn:               conn,
 		AuthToken:          authToken,
 		Sequence:           1,
@@ -266,6 +283,16 @@ func (wsc *WebSocketClient) UserTyping(channelId, parentId string) {
 	wsc.SendMessage("user_typing", data)
 }
 
+// CreatePost will create the post as done by POST /api/v4/posts, responding with the created
+// post. The connection must have been granted the create_post capability.
+func (wsc *WebSocketClient) CreatePost(post *Post) {
+	data := map[string]interface{}{
+		"post": post,
+	}
+
+	wsc.SendMessage("create_post", data)
+}
+
 // GetStatuses will return a map of string statuses using user id as the key
 func (wsc *WebSocketClient) GetStatuses() {
 	wsc.SendMessage("get_statuses", nil)
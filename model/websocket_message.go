@@ -66,6 +66,11 @@ const (
 	WEBSOCKET_EVENT_SIDEBAR_CATEGORY_UPDATED                 = "sidebar_category_updated"
 	WEBSOCKET_EVENT_SIDEBAR_CATEGORY_DELETED                 = "sidebar_category_deleted"
 	WEBSOCKET_EVENT_SIDEBAR_CATEGORY_ORDER_UPDATED           = "sidebar_category_order_updated"
+	WEBSOCKET_EVENT_TEAMS_ORDER_UPDATED                      = "teams_order_updated"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_CREATED                 = "channel_bookmark_created"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_UPDATED                 = "channel_bookmark_updated"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_DELETED                 = "channel_bookmark_deleted"
+	WEBSOCKET_EVENT_CHANNEL_BOOKMARK_SORTED                  = "channel_bookmark_sorted"
 )
 
 type WebSocketMessage interface {
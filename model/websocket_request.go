@@ -10,6 +10,15 @@ import (
 	goi18n "github.com/mattermost/go-i18n/i18n"
 )
 
+const (
+	// WEBSOCKET_CAPABILITY_CREATE_POST lets the client create posts with the create_post action.
+	WEBSOCKET_CAPABILITY_CREATE_POST = "create_post"
+)
+
+// WEBSOCKET_CAPABILITIES lists the optional features a client can ask for with the capabilities
+// query parameter when connecting. The ones granted are listed in the hello event.
+var WEBSOCKET_CAPABILITIES = []string{WEBSOCKET_CAPABILITY_CREATE_POST}
+
 // WebSocketRequest represents a request made to the server through a websocket.
 type WebSocketRequest struct {
 	// Client-provided fields
@@ -245,6 +245,16 @@ type API interface {
 	// Minimum server version: 5.3
 	GetLDAPUserAttributes(userId string, attributes []string) (map[string]string, *model.AppError)
 
+	// GetLDAPUserAttributeValues will return LDAP attributes for a user, keeping every value of
+	// multi-valued attributes such as memberOf.
+	// The attributes parameter should be a list of attributes to pull.
+	// Returns a map with attribute names as keys and all of the user's values for each attribute as values.
+	// Requires an enterprise license, LDAP to be configured and for the user to use LDAP as an authentication method.
+	//
+	// @tag User
+	// Minimum server version: 5.28
+	GetLDAPUserAttributeValues(userId string, attributes []string) (map[string][]string, *model.AppError)
+
 	// CreateTeam creates a team.
 	//
 	// @tag Team
@@ -280,6 +280,13 @@ func (api *apiTimerLayer) GetLDAPUserAttributes(userId string, attributes []stri
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) GetLDAPUserAttributeValues(userId string, attributes []string) (map[string][]string, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetLDAPUserAttributeValues(userId, attributes)
+	api.recordTime(startTime, "GetLDAPUserAttributeValues", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) CreateTeam(team *model.Team) (*model.Team, *model.AppError) {
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := api.apiImpl.CreateTeam(team)
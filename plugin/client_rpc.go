@@ -7,6 +7,7 @@ package plugin
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"net/rpc"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/dyatlov/go-opengraph/opengraph"
 	"github.com/hashicorp/go-plugin"
@@ -568,6 +570,60 @@ func (s *hooksRPCServer) MessageWillBePosted(args *Z_MessageWillBePostedArgs, re
 	return nil
 }
 
+// MessageWillBePostedWithContext is in this file because the context can't be sent over RPC. Only its
+// deadline and plugin Context are, and the plugin receives a new context built from them. Plugins that
+// only implement MessageWillBePosted receive that hook instead.
+func init() {
+	hookNameToId["MessageWillBePostedWithContext"] = MessageWillBePostedWithContextId
+}
+
+type Z_MessageWillBePostedWithContextArgs struct {
+	A        *Context
+	B        *model.Post
+	Deadline time.Time
+}
+
+type Z_MessageWillBePostedWithContextReturns struct {
+	A *model.Post
+	B string
+}
+
+func (g *hooksRPCClient) MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string) {
+	c, _ := PluginContextFromContext(ctx)
+	if !g.implemented[MessageWillBePostedWithContextId] {
+		return g.MessageWillBePosted(c, post)
+	}
+
+	deadline, _ := ctx.Deadline()
+	_args := &Z_MessageWillBePostedWithContextArgs{c, post, deadline}
+	_returns := &Z_MessageWillBePostedWithContextReturns{A: _args.B}
+	if err := g.client.Call("Plugin.MessageWillBePostedWithContext", _args, _returns); err != nil {
+		g.log.Error("RPC call MessageWillBePostedWithContext to plugin failed.", mlog.Err(err))
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) MessageWillBePostedWithContext(args *Z_MessageWillBePostedWithContextArgs, returns *Z_MessageWillBePostedWithContextReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string)
+	}); ok {
+		ctx := context.Background()
+		if args.A != nil {
+			ctx = NewContextWithPluginContext(ctx, args.A)
+		}
+		if !args.Deadline.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, args.Deadline)
+			defer cancel()
+		}
+
+		returns.A, returns.B = hook.MessageWillBePostedWithContext(ctx, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessageWillBePostedWithContext called but not implemented."))
+	}
+	return nil
+}
+
 // MessageWillBeUpdated is in this file because of the difficulty of identifying which fields need special behaviour.
 // The special behaviour needed is decoding the returned post into the original one to avoid the unintentional removal
 // of fields by older plugins.
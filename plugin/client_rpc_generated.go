@@ -1471,6 +1471,36 @@ func (s *apiRPCServer) GetLDAPUserAttributes(args *Z_GetLDAPUserAttributesArgs,
 	return nil
 }
 
+type Z_GetLDAPUserAttributeValuesArgs struct {
+	A string
+	B []string
+}
+
+type Z_GetLDAPUserAttributeValuesReturns struct {
+	A map[string][]string
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetLDAPUserAttributeValues(userId string, attributes []string) (map[string][]string, *model.AppError) {
+	_args := &Z_GetLDAPUserAttributeValuesArgs{userId, attributes}
+	_returns := &Z_GetLDAPUserAttributeValuesReturns{}
+	if err := g.client.Call("Plugin.GetLDAPUserAttributeValues", _args, _returns); err != nil {
+		log.Printf("RPC call to GetLDAPUserAttributeValues API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetLDAPUserAttributeValues(args *Z_GetLDAPUserAttributeValuesArgs, returns *Z_GetLDAPUserAttributeValuesReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetLDAPUserAttributeValues(userId string, attributes []string) (map[string][]string, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetLDAPUserAttributeValues(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API GetLDAPUserAttributeValues called but not implemented."))
+	}
+	return nil
+}
+
 type Z_CreateTeamArgs struct {
 	A *model.Team
 }
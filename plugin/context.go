@@ -3,6 +3,8 @@
 
 package plugin
 
+import "context"
+
 // Context passes through metadata about the request or hook event.
 // For requests this is built in app/plugin_requests.go
 // For hooks, app.PluginContext() is called.
@@ -14,3 +16,16 @@ type Context struct {
 	UserAgent      string
 	SourcePluginId string
 }
+
+type pluginContextKey struct{}
+
+// NewContextWithPluginContext returns a copy of ctx carrying the plugin Context c.
+func NewContextWithPluginContext(ctx context.Context, c *Context) context.Context {
+	return context.WithValue(ctx, pluginContextKey{}, c)
+}
+
+// PluginContextFromContext returns the plugin Context carried by ctx, if any.
+func PluginContextFromContext(ctx context.Context) (*Context, bool) {
+	c, ok := ctx.Value(pluginContextKey{}).(*Context)
+	return c, ok
+}
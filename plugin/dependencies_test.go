@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+const serverModulePath = "github.com/mattermost/mattermost-server/v5"
+
+// publicPackageDependencies are the packages of the server that plugins may depend on through the
+// model and plugin packages. Anything else, such as the store or the app, must not be reachable
+// from them.
+var publicPackageDependencies = []string{
+	serverModulePath + "/einterfaces",
+	serverModulePath + "/mlog",
+	serverModulePath + "/model",
+	serverModulePath + "/plugin",
+	serverModulePath + "/services/timezones",
+	serverModulePath + "/utils/fileutils",
+	serverModulePath + "/utils/jsonutils",
+	serverModulePath + "/utils/markdown",
+	serverModulePath + "/utils/mergeutils",
+	serverModulePath + "/utils/retryutils",
+}
+
+var forbiddenPublicPackageDependencies = []string{
+	"github.com/go-sql-driver/mysql",
+	"github.com/lib/pq",
+	"github.com/stretchr/testify",
+}
+
+func TestPublicPackagesDependencies(t *testing.T) {
+	t.Run("a minimal plugin builds", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		utils.CompileGo(t, `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/v5/model"
+				"github.com/mattermost/mattermost-server/v5/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
+				p.API.LogDebug("message posted", "post_id", post.Id)
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`, filepath.Join(dir, "backend.exe"))
+	})
+
+	t.Run("only public packages are imported", func(t *testing.T) {
+		out, err := exec.Command("go", "list", "-deps", serverModulePath+"/model", serverModulePath+"/plugin").CombinedOutput()
+		require.NoError(t, err, string(out))
+
+		for _, dependency := range strings.Fields(string(out)) {
+			if strings.HasPrefix(dependency, serverModulePath+"/") {
+				assert.Contains(t, publicPackageDependencies, dependency)
+			}
+
+			for _, forbidden := range forbiddenPublicPackageDependencies {
+				assert.False(t, strings.HasPrefix(dependency, forbidden), "%s must not be imported", dependency)
+			}
+		}
+	})
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 	"github.com/pkg/errors"
 )
 
@@ -57,6 +57,8 @@ type Environment struct {
 	webappPluginDir        string
 	prepackagedPlugins     []*PrepackagedPlugin
 	prepackagedPluginsLock sync.RWMutex
+
+	healthCheckFailureListener func(id string, deactivated bool, err error)
 }
 
 func NewEnvironment(newAPIImpl apiImplCreatorFunc, pluginDir string, webappPluginDir string, logger *mlog.Logger, metrics einterfaces.MetricsInterface) (*Environment, error) {
@@ -400,7 +402,7 @@ func (env *Environment) UnpackWebappBundle(id string) (*model.Manifest, error) {
 		return nil, errors.Wrapf(err, "unable to remove old webapp bundle directory: %v", destinationPath)
 	}
 
-	if err = utils.CopyDir(filepath.Dir(bundlePath), destinationPath); err != nil {
+	if err = fileutils.CopyDir(filepath.Dir(bundlePath), destinationPath); err != nil {
 		return nil, errors.Wrapf(err, "unable to copy webapp bundle directory: %v", id)
 	}
 
@@ -487,6 +489,12 @@ func (env *Environment) performHealthCheck(id string) error {
 	return sup.PerformHealthCheck()
 }
 
+// SetHealthCheckFailureListener sets a function to be called whenever a plugin fails its health check,
+// after it has been restarted or deactivated. It must be set before the health check job is started.
+func (env *Environment) SetHealthCheckFailureListener(listener func(id string, deactivated bool, err error)) {
+	env.healthCheckFailureListener = listener
+}
+
 // SetPrepackagedPlugins saves prepackaged plugins in the environment.
 func (env *Environment) SetPrepackagedPlugins(plugins []*PrepackagedPlugin) {
 	env.prepackagedPluginsLock.Lock()
@@ -68,6 +68,10 @@ func (job *PluginHealthCheckJob) CheckPlugin(id string) {
 		// Reset timestamp state for this plugin
 		job.failureTimestamps.Delete(id)
 		job.env.setPluginState(id, model.PluginStateFailedToStayRunning)
+
+		if job.env.healthCheckFailureListener != nil {
+			job.env.healthCheckFailureListener(id, true, err)
+		}
 	} else {
 		mlog.Debug("Restarting plugin due to failed health check", mlog.String("id", id))
 		if err := job.env.RestartPlugin(id); err != nil {
@@ -76,6 +80,10 @@ func (job *PluginHealthCheckJob) CheckPlugin(id string) {
 
 		// Store this failure so we can continue to monitor the plugin
 		job.failureTimestamps.Store(id, removeStaleTimestamps(timestamps))
+
+		if job.env.healthCheckFailureListener != nil {
+			job.env.healthCheckFailureListener(id, false, err)
+		}
 	}
 }
 
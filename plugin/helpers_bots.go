@@ -10,7 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/retryutils"
 )
 
 type ensureBotOptions struct {
@@ -163,11 +163,11 @@ func (p *HelpersImpl) ShouldProcessMessage(post *model.Post, options ...ShouldPr
 		}
 	}
 
-	if len(messageProcessOptions.FilterChannelIDs) != 0 && !utils.StringInSlice(post.ChannelId, messageProcessOptions.FilterChannelIDs) {
+	if len(messageProcessOptions.FilterChannelIDs) != 0 && !model.StringArray(messageProcessOptions.FilterChannelIDs).Contains(post.ChannelId) {
 		return false, nil
 	}
 
-	if len(messageProcessOptions.FilterUserIDs) != 0 && !utils.StringInSlice(post.UserId, messageProcessOptions.FilterUserIDs) {
+	if len(messageProcessOptions.FilterUserIDs) != 0 && !model.StringArray(messageProcessOptions.FilterUserIDs).Contains(post.UserId) {
 		return false, nil
 	}
 
@@ -211,7 +211,7 @@ func (p *HelpersImpl) ensureBot(bot *model.Bot) (retBotID string, retErr error)
 			var err error
 			var botIDBytes []byte
 
-			err = utils.ProgressiveRetry(func() error {
+			err = retryutils.ProgressiveRetry(func() error {
 				botIDBytes, err = p.API.KVGet(BOT_USER_KEY)
 				if err != nil {
 					return err
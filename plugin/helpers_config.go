@@ -7,7 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v5/model"
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/mergeutils"
 )
 
 // CheckRequiredServerConfiguration implements Helpers.CheckRequiredServerConfiguration
@@ -18,7 +18,7 @@ func (p *HelpersImpl) CheckRequiredServerConfiguration(req *model.Config) (bool,
 
 	cfg := p.API.GetConfig()
 
-	mc, err := utils.Merge(cfg, req, nil)
+	mc, err := mergeutils.Merge(cfg, req, nil)
 	if err != nil {
 		return false, errors.Wrap(err, "could not merge configurations")
 	}
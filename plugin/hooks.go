@@ -4,6 +4,7 @@
 package plugin
 
 import (
+	"context"
 	"io"
 	"net/http"
 
@@ -15,25 +16,26 @@ import (
 // Feel free to add more, but do not change existing assignments. Follow the naming convention of
 // <HookName>Id as the autogenerated glue code depends on that.
 const (
-	OnActivateId            = 0
-	OnDeactivateId          = 1
-	ServeHTTPId             = 2
-	OnConfigurationChangeId = 3
-	ExecuteCommandId        = 4
-	MessageWillBePostedId   = 5
-	MessageWillBeUpdatedId  = 6
-	MessageHasBeenPostedId  = 7
-	MessageHasBeenUpdatedId = 8
-	UserHasJoinedChannelId  = 9
-	UserHasLeftChannelId    = 10
-	UserHasJoinedTeamId     = 11
-	UserHasLeftTeamId       = 12
-	ChannelHasBeenCreatedId = 13
-	FileWillBeUploadedId    = 14
-	UserWillLogInId         = 15
-	UserHasLoggedInId       = 16
-	UserHasBeenCreatedId    = 17
-	TotalHooksId            = iota
+	OnActivateId                     = 0
+	OnDeactivateId                   = 1
+	ServeHTTPId                      = 2
+	OnConfigurationChangeId          = 3
+	ExecuteCommandId                 = 4
+	MessageWillBePostedId            = 5
+	MessageWillBeUpdatedId           = 6
+	MessageHasBeenPostedId           = 7
+	MessageHasBeenUpdatedId          = 8
+	UserHasJoinedChannelId           = 9
+	UserHasLeftChannelId             = 10
+	UserHasJoinedTeamId              = 11
+	UserHasLeftTeamId                = 12
+	ChannelHasBeenCreatedId          = 13
+	FileWillBeUploadedId             = 14
+	UserWillLogInId                  = 15
+	UserHasLoggedInId                = 16
+	UserHasBeenCreatedId             = 17
+	MessageWillBePostedWithContextId = 18
+	TotalHooksId                     = iota
 )
 
 const (
@@ -121,6 +123,16 @@ type Hooks interface {
 	// Minimum server version: 5.2
 	MessageWillBePosted(c *Context, post *model.Post) (*model.Post, string)
 
+	// MessageWillBePostedWithContext is MessageWillBePosted with a context carrying the deadline of the
+	// request creating the post, for plugins making outbound calls that should not outlive it. The
+	// plugin Context of the hook is available through PluginContextFromContext.
+	//
+	// Plugins implementing both hooks only receive this one. MessageWillBePosted is invoked instead
+	// for plugins that don't implement it.
+	//
+	// Minimum server version: 5.28
+	MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string)
+
 	// MessageWillBeUpdated is invoked when a message is updated by a user before it is committed
 	// to the database. If you also want to act on new posts, see MessageWillBePosted.
 	// Return values should be the modified post or nil if rejected and an explanation for the user.
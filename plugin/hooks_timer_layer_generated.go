@@ -7,6 +7,7 @@
 package plugin
 
 import (
+	"context"
 	"io"
 	"net/http"
 	timePkg "time"
@@ -95,6 +96,13 @@ func (hooks *hooksTimerLayer) MessageWillBePosted(c *Context, post *model.Post)
 	return _returnsA, _returnsB
 }
 
+func (hooks *hooksTimerLayer) MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.MessageWillBePostedWithContext(ctx, post)
+	hooks.recordTime(startTime, "MessageWillBePostedWithContext", true)
+	return _returnsA, _returnsB
+}
+
 func (hooks *hooksTimerLayer) MessageWillBeUpdated(c *Context, newPost, oldPost *model.Post) (*model.Post, string) {
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.MessageWillBeUpdated(c, newPost, oldPost)
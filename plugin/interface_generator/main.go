@@ -584,6 +584,7 @@ func removeExcluded(info *PluginInterfaceInfo) *PluginInterfaceInfo {
 			"LogInfo",
 			"LogWarn",
 			"MessageWillBePosted",
+			"MessageWillBePostedWithContext",
 			"MessageWillBeUpdated",
 			"OnActivate",
 			"PluginHTTP",
@@ -5,9 +5,11 @@
 package plugintest
 
 import (
-	io "io"
+	context "context"
 	http "net/http"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mattermost/mattermost-server/v5/model"
@@ -129,6 +131,29 @@ func (_m *Hooks) MessageWillBePosted(c *plugin.Context, post *model.Post) (*mode
 	return r0, r1
 }
 
+// MessageWillBePostedWithContext provides a mock function with given fields: ctx, post
+func (_m *Hooks) MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string) {
+	ret := _m.Called(ctx, post)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Post) *model.Post); ok {
+		r0 = rf(ctx, post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Post) string); ok {
+		r1 = rf(ctx, post)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // MessageWillBeUpdated provides a mock function with given fields: c, newPost, oldPost
 func (_m *Hooks) MessageWillBeUpdated(c *plugin.Context, newPost *model.Post, oldPost *model.Post) (*model.Post, string) {
 	ret := _m.Called(c, newPost, oldPost)
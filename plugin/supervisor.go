@@ -92,6 +92,10 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, parentLogger *mlog
 			sup.implemented[hookId] = true
 		}
 	}
+	// Plugins only implementing MessageWillBePosted receive it through MessageWillBePostedWithContext.
+	if sup.implemented[MessageWillBePostedId] {
+		sup.implemented[MessageWillBePostedWithContextId] = true
+	}
 
 	return &sup, nil
 }
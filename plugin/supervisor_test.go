@@ -4,10 +4,12 @@
 package plugin
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -93,3 +95,122 @@ func testSupervisorStartTimeout(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, supervisor)
 }
+
+func TestSupervisorMessageWillBePostedWithContext(t *testing.T) {
+	log := mlog.NewLogger(&mlog.LoggerConfiguration{
+		EnableConsole: true,
+		ConsoleJson:   true,
+		ConsoleLevel:  "error",
+		EnableFile:    false,
+	})
+
+	newPluginSupervisor := func(t *testing.T, sourceCode string) (*supervisor, func()) {
+		dir, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+
+		utils.CompileGo(t, sourceCode, filepath.Join(dir, "backend.exe"))
+		ioutil.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "backend": {"executable": "backend.exe"}}`), 0600)
+
+		supervisor, err := newSupervisor(model.BundleInfoForPath(dir), nil, log, nil)
+		require.NoError(t, err)
+
+		return supervisor, func() {
+			supervisor.Shutdown()
+			os.RemoveAll(dir)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = NewContextWithPluginContext(ctx, &Context{RequestId: "requestid"})
+
+	t.Run("context hook", func(t *testing.T) {
+		supervisor, tearDown := newPluginSupervisor(t, `
+			package main
+
+			import (
+				"context"
+				"fmt"
+
+				"github.com/mattermost/mattermost-server/v5/model"
+				"github.com/mattermost/mattermost-server/v5/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+				return nil, "the context hook should be preferred"
+			}
+
+			func (p *MyPlugin) MessageWillBePostedWithContext(ctx context.Context, post *model.Post) (*model.Post, string) {
+				c, _ := plugin.PluginContextFromContext(ctx)
+				_, hasDeadline := ctx.Deadline()
+				post.Message = fmt.Sprintf("%s %s %v", post.Message, c.RequestId, hasDeadline)
+				return post, ""
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`)
+		defer tearDown()
+
+		require.True(t, supervisor.Implements(MessageWillBePostedWithContextId))
+		post, rejectionReason := supervisor.Hooks().MessageWillBePostedWithContext(ctx, &model.Post{Message: "message"})
+		require.Empty(t, rejectionReason)
+		assert.Equal(t, "message requestid true", post.Message)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		supervisor, tearDown := newPluginSupervisor(t, `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/v5/model"
+				"github.com/mattermost/mattermost-server/v5/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+				post.Message = post.Message + " " + c.RequestId
+				return post, ""
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`)
+		defer tearDown()
+
+		require.True(t, supervisor.Implements(MessageWillBePostedWithContextId))
+		post, rejectionReason := supervisor.Hooks().MessageWillBePostedWithContext(ctx, &model.Post{Message: "message"})
+		require.Empty(t, rejectionReason)
+		assert.Equal(t, "message requestid", post.Message)
+	})
+
+	t.Run("neither hook", func(t *testing.T) {
+		supervisor, tearDown := newPluginSupervisor(t, `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/v5/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`)
+		defer tearDown()
+
+		assert.False(t, supervisor.Implements(MessageWillBePostedWithContextId))
+	})
+}
@@ -46,6 +46,9 @@ func NewFileBackend(settings *model.FileSettings, enableComplianceFeatures bool)
 			trace:      settings.AmazonS3Trace != nil && *settings.AmazonS3Trace,
 		}, nil
 	case model.IMAGE_DRIVER_LOCAL:
+		if settings.DisableLocalStorage != nil && *settings.DisableLocalStorage {
+			return nil, model.NewAppError("NewFileBackend", "api.file.local_storage_disabled.app_error", nil, "", http.StatusInternalServerError)
+		}
 		return &LocalFileBackend{
 			directory: *settings.Directory,
 		}, nil
@@ -47,6 +47,24 @@ func TestLocalFileBackendTestSuite(t *testing.T) {
 	})
 }
 
+func TestNewFileBackendLocalStorageDisabled(t *testing.T) {
+	settings := &model.FileSettings{
+		DriverName:          model.NewString(model.IMAGE_DRIVER_LOCAL),
+		Directory:           model.NewString("./data/"),
+		DisableLocalStorage: model.NewBool(true),
+	}
+
+	backend, err := NewFileBackend(settings, false)
+	require.NotNil(t, err)
+	require.Equal(t, "api.file.local_storage_disabled.app_error", err.Id)
+	require.Nil(t, backend)
+
+	settings.DisableLocalStorage = model.NewBool(false)
+	backend, err = NewFileBackend(settings, false)
+	require.Nil(t, err)
+	require.NotNil(t, backend)
+}
+
 func TestS3FileBackendTestSuite(t *testing.T) {
 	runBackendTest(t, false)
 }
@@ -251,7 +251,17 @@ func TestConnection(config *model.Config) *model.AppError {
 }
 
 func SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, config *model.Config, enableComplianceFeatures bool) *model.AppError {
-	fromMail := mail.Address{Name: *config.EmailSettings.FeedbackName, Address: *config.EmailSettings.FeedbackEmail}
+	return sendMailFromNameUsingConfig(to, *config.EmailSettings.FeedbackName, subject, htmlBody, embeddedFiles, config, enableComplianceFeatures)
+}
+
+// SendMailFromNameUsingConfig sends the email from the feedback address, but under the given
+// display name instead of EmailSettings.FeedbackName.
+func SendMailFromNameUsingConfig(to, fromName, subject, htmlBody string, config *model.Config, enableComplianceFeatures bool) *model.AppError {
+	return sendMailFromNameUsingConfig(to, fromName, subject, htmlBody, nil, config, enableComplianceFeatures)
+}
+
+func sendMailFromNameUsingConfig(to, fromName, subject, htmlBody string, embeddedFiles map[string]io.Reader, config *model.Config, enableComplianceFeatures bool) *model.AppError {
+	fromMail := mail.Address{Name: fromName, Address: *config.EmailSettings.FeedbackEmail}
 	replyTo := mail.Address{Name: *config.EmailSettings.FeedbackName, Address: *config.EmailSettings.ReplyToAddress}
 
 	mail := mailData{
@@ -165,6 +165,43 @@ func TestSendMailUsingConfig(t *testing.T) {
 	}
 }
 
+func TestSendMailFromNameUsingConfig(t *testing.T) {
+	utils.T = utils.GetUserTranslations("en")
+
+	fs, err := config.NewFileStore("config.json", false)
+	require.Nil(t, err)
+
+	cfg := fs.Get()
+
+	var emailTo = "test@example.com"
+	var emailFromName = "Jane Doe via Mattermost"
+	var emailSubject = "Testing this email"
+	var emailBody = "This is a test from autobot"
+
+	//Delete all the messages before check the sample email
+	DeleteMailBox(emailTo)
+
+	err2 := SendMailFromNameUsingConfig(emailTo, emailFromName, emailSubject, emailBody, cfg, true)
+	require.Nil(t, err2, "Should connect to the SMTP Server")
+
+	//Check if the email was send from the given name
+	var resultsMailbox JSONMessageHeaderInbucket
+	err3 := RetryInbucket(5, func() error {
+		var err error
+		resultsMailbox, err = GetMailBox(emailTo)
+		return err
+	})
+	if err3 != nil {
+		t.Log(err3)
+		t.Log("No email was received, maybe due load on the server. Skipping this verification")
+	} else {
+		if len(resultsMailbox) > 0 {
+			require.Contains(t, resultsMailbox[0].From, emailFromName, "Wrong From: name")
+			require.Contains(t, resultsMailbox[0].From, *cfg.EmailSettings.FeedbackEmail, "Wrong From: address")
+		}
+	}
+}
+
 func TestSendMailWithEmbeddedFilesUsingConfig(t *testing.T) {
 	utils.T = utils.GetUserTranslations("en")
 
@@ -27,7 +27,7 @@ func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError
 	return nil
 }
 
-func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, int64, *model.AppError) {
 	channelQueries := []query.Query{}
 	for _, channel := range *channels {
 		channelIdQ := bleve.NewTermQuery(channel.Id)
@@ -200,7 +200,7 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 	search.SortBy([]string{"-CreateAt"})
 	results, err := b.PostIndex.Search(search)
 	if err != nil {
-		return nil, nil, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
+		return nil, nil, 0, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
 	postIds := []string{}
@@ -210,7 +210,7 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 		postIds = append(postIds, r.ID)
 	}
 
-	return postIds, matches, nil
+	return postIds, matches, int64(results.Total), nil
 }
 
 func (b *BleveEngine) deletePosts(searchRequest *bleve.SearchRequest, batchSize int) (int64, error) {
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestSearchPosts(t *testing.T) {
+	engine, err := createMemOnlyEngine()
+	require.Nil(t, err)
+	defer engine.Stop()
+
+	teamId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	channel := &model.Channel{Id: model.NewId(), TeamId: teamId}
+	otherChannel := &model.Channel{Id: model.NewId(), TeamId: teamId}
+	channels := &model.ChannelList{channel, otherChannel}
+
+	indexPost := func(userId, channelId, message string, createAt int64) *model.Post {
+		post := createPost(userId, channelId, message)
+		post.CreateAt = createAt
+		require.Nil(t, engine.IndexPost(post, teamId))
+		return post
+	}
+
+	p1 := indexPost(userId, channel.Id, "searching for apples", 1000)
+	p2 := indexPost(otherUserId, otherChannel.Id, "apples and oranges", 2000)
+	p3 := indexPost(userId, otherChannel.Id, "only oranges here", 3000)
+	outside := indexPost(userId, model.NewId(), "apples outside the searched channels", 4000)
+
+	systemPost := createPost(userId, channel.Id, "apples joined the channel")
+	systemPost.Type = model.POST_JOIN_CHANNEL
+	require.Nil(t, engine.IndexPost(systemPost, teamId))
+
+	t.Run("should match terms in the given channels ordered by creation", func(t *testing.T) {
+		ids, _, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples"}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p2.Id, p1.Id}, ids)
+		assert.NotContains(t, ids, outside.Id)
+		assert.NotContains(t, ids, systemPost.Id)
+	})
+
+	t.Run("should match any term with or search", func(t *testing.T) {
+		ids, _, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples oranges", OrTerms: true}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p3.Id, p2.Id, p1.Id}, ids)
+	})
+
+	t.Run("should match all terms by default", func(t *testing.T) {
+		ids, _, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples oranges"}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p2.Id}, ids)
+	})
+
+	t.Run("should filter by channel and user", func(t *testing.T) {
+		ids, _, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples", InChannels: []string{channel.Id}}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p1.Id}, ids)
+
+		ids, _, _, appErr = engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples", ExcludedUsers: []string{userId}}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p2.Id}, ids)
+	})
+
+	t.Run("should paginate", func(t *testing.T) {
+		ids, _, total, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "apples oranges", OrTerms: true}}, 1, 2)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{p1.Id}, ids)
+		assert.Equal(t, int64(3), total, "the total should count the results of every page")
+	})
+
+	t.Run("should return nothing without channels", func(t *testing.T) {
+		ids, _, _, appErr := engine.SearchPosts(&model.ChannelList{}, []*model.SearchParams{{Terms: "apples"}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Empty(t, ids)
+	})
+}
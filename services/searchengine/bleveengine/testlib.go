@@ -5,6 +5,9 @@ package bleveengine
 
 import (
 	"fmt"
+	"sync/atomic"
+
+	"github.com/blevesearch/bleve"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 )
@@ -21,3 +24,29 @@ func createPost(userId string, channelId string, message string) *model.Post {
 
 	return post
 }
+
+// createMemOnlyEngine returns a started engine backed by in-memory indexes, for tests that don't
+// need a database or an index directory.
+func createMemOnlyEngine() (*BleveEngine, error) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model.NewBool(true)
+	cfg.BleveSettings.EnableSearching = model.NewBool(true)
+
+	b := NewBleveEngine(cfg, nil)
+	b.indexSync = true
+
+	var err error
+	if b.PostIndex, err = bleve.NewMemOnly(getPostIndexMapping()); err != nil {
+		return nil, err
+	}
+	if b.UserIndex, err = bleve.NewMemOnly(getUserIndexMapping()); err != nil {
+		return nil, err
+	}
+	if b.ChannelIndex, err = bleve.NewMemOnly(getChannelIndexMapping()); err != nil {
+		return nil, err
+	}
+	atomic.StoreInt32(&b.ready, 1)
+
+	return b, nil
+}
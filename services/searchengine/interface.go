@@ -21,7 +21,7 @@ type SearchEngineInterface interface {
 	IsAutocompletionEnabled() bool
 	IsIndexingSync() bool
 	IndexPost(post *model.Post, teamId string) *model.AppError
-	SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError)
+	SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, int64, *model.AppError)
 	DeletePost(post *model.Post) *model.AppError
 	DeleteChannelPosts(channelID string) *model.AppError
 	DeleteUserPosts(userID string) *model.AppError
@@ -316,7 +316,7 @@ func (_m *SearchEngineInterface) SearchChannels(teamId string, term string) ([]s
 }
 
 // SearchPosts provides a mock function with given fields: channels, searchParams, page, perPage
-func (_m *SearchEngineInterface) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+func (_m *SearchEngineInterface) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, int64, *model.AppError) {
 	ret := _m.Called(channels, searchParams, page, perPage)
 
 	var r0 []string
@@ -337,16 +337,23 @@ func (_m *SearchEngineInterface) SearchPosts(channels *model.ChannelList, search
 		}
 	}
 
-	var r2 *model.AppError
-	if rf, ok := ret.Get(2).(func(*model.ChannelList, []*model.SearchParams, int, int) *model.AppError); ok {
+	var r2 int64
+	if rf, ok := ret.Get(2).(func(*model.ChannelList, []*model.SearchParams, int, int) int64); ok {
 		r2 = rf(channels, searchParams, page, perPage)
 	} else {
-		if ret.Get(2) != nil {
-			r2 = ret.Get(2).(*model.AppError)
+		r2 = ret.Get(2).(int64)
+	}
+
+	var r3 *model.AppError
+	if rf, ok := ret.Get(3).(func(*model.ChannelList, []*model.SearchParams, int, int) *model.AppError); ok {
+		r3 = rf(channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(3) != nil {
+			r3 = ret.Get(3).(*model.AppError)
 		}
 	}
 
-	return r0, r1, r2
+	return r0, r1, r2, r3
 }
 
 // SearchUsersInChannel provides a mock function with given fields: teamId, channelId, restrictedToChannels, term, options
@@ -35,6 +35,8 @@ func getMockStore() *mocks.Store {
 	mockReactionsStore.On("Delete", &fakeReaction).Return(&model.Reaction{}, nil)
 	mockReactionsStore.On("GetForPost", "123", false).Return([]*model.Reaction{&fakeReaction}, nil)
 	mockReactionsStore.On("GetForPost", "123", true).Return([]*model.Reaction{&fakeReaction}, nil)
+	mockReactionsStore.On("GetForPosts", []string{"123", "456"}).Return(map[string][]*model.Reaction{"123": {&fakeReaction}, "456": {}}, nil)
+	mockReactionsStore.On("GetForPosts", []string{"456"}).Return(map[string][]*model.Reaction{"456": {}}, nil)
 	mockStore.On("Reaction").Return(&mockReactionsStore)
 
 	fakeRole := model.Role{Id: "123", Name: "role-name"}
@@ -51,6 +51,38 @@ func (s LocalCacheReactionStore) GetForPost(postId string, allowFromCache bool)
 	return reaction, nil
 }
 
+// GetForPosts returns the cached reactions of the given posts, fetching the ones missing from the
+// cache in a single query.
+func (s LocalCacheReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	reactionsByPostId := make(map[string][]*model.Reaction, len(postIds))
+
+	var missingPostIds []string
+	for _, postId := range postIds {
+		var reactions []*model.Reaction
+		if err := s.rootStore.doStandardReadCache(s.rootStore.reactionCache, postId, &reactions); err == nil {
+			reactionsByPostId[postId] = reactions
+		} else {
+			missingPostIds = append(missingPostIds, postId)
+		}
+	}
+
+	if len(missingPostIds) == 0 {
+		return reactionsByPostId, nil
+	}
+
+	fetched, err := s.ReactionStore.GetForPosts(missingPostIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for postId, reactions := range fetched {
+		s.rootStore.doStandardAddToCache(s.rootStore.reactionCache, postId, reactions)
+		reactionsByPostId[postId] = reactions
+	}
+
+	return reactionsByPostId, nil
+}
+
 func (s LocalCacheReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	// This could be improved. Right now we just clear the whole
 	// cache because we don't have a way find what post Ids have this emoji name.
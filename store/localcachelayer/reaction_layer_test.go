@@ -69,4 +69,43 @@ func TestReactionStoreCache(t *testing.T) {
 		cachedStore.Reaction().GetForPost("123", true)
 		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPost", 2)
 	})
+
+	t.Run("bulk call caches every post, including the ones without reactions", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		reactions, err := cachedStore.Reaction().GetForPosts([]string{"123", "456"})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Reaction{&fakeReaction}, reactions["123"])
+		assert.Empty(t, reactions["456"])
+		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPosts", 1)
+
+		reactions, err = cachedStore.Reaction().GetForPosts([]string{"123", "456"})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Reaction{&fakeReaction}, reactions["123"])
+		assert.Contains(t, reactions, "456")
+		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPosts", 1)
+
+		reaction, err := cachedStore.Reaction().GetForPost("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Reaction{&fakeReaction}, reaction)
+		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPost", 0)
+	})
+
+	t.Run("bulk call only fetches the posts missing from the cache", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Reaction().GetForPost("123", true)
+		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPost", 1)
+
+		reactions, err := cachedStore.Reaction().GetForPosts([]string{"123", "456"})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Reaction{&fakeReaction}, reactions["123"])
+		assert.Contains(t, reactions, "456")
+		mockStore.Reaction().(*mocks.ReactionStore).AssertCalled(t, "GetForPosts", []string{"456"})
+		mockStore.Reaction().(*mocks.ReactionStore).AssertNumberOfCalls(t, "GetForPosts", 1)
+	})
 }
@@ -106,7 +106,7 @@ func (s LocalCacheUserStore) GetProfileByIds(userIds []string, options *store.Us
 	for _, userId := range userIds {
 		var cacheItem *model.User
 		if err := s.rootStore.doStandardReadCache(s.rootStore.userProfileByIdsCache, userId, &cacheItem); err == nil {
-			if options.Since == 0 || cacheItem.UpdateAt > options.Since {
+			if (options.Since == 0 || cacheItem.UpdateAt > options.Since) && (!options.ExcludeDeleted || cacheItem.DeleteAt == 0) {
 				users = append(users, cacheItem.DeepCopy())
 			}
 		} else {
@@ -130,6 +130,10 @@ func (s LocalCacheUserStore) GetProfileByIds(userIds []string, options *store.Us
 		}
 	}
 
+	if options.OrderByIds {
+		return store.SortUsersByIds(users, userIds), nil
+	}
+
 	return users, nil
 }
 
@@ -53,6 +53,14 @@ func (c *SearchChannelStore) Save(channel *model.Channel, maxChannels int64) (*m
 	return newChannel, err
 }
 
+func (c *SearchChannelStore) SaveWithCreatorLimit(channel *model.Channel, maxChannels int64, maxChannelsPerCreator int64, createdSince int64) (*model.Channel, error) {
+	newChannel, err := c.ChannelStore.SaveWithCreatorLimit(channel, maxChannels, maxChannelsPerCreator, createdSince)
+	if err == nil {
+		c.indexChannel(newChannel)
+	}
+	return newChannel, err
+}
+
 func (c *SearchChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
 	updatedChannel, err := c.ChannelStore.Update(channel)
 	if err == nil {
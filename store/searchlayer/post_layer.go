@@ -13,6 +13,9 @@ import (
 	"github.com/mattermost/mattermost-server/v5/store"
 )
 
+// SEARCH_POSTS_IN_TEAM_MAX_RESULTS matches the number of results returned by the database search.
+const SEARCH_POSTS_IN_TEAM_MAX_RESULTS = 100
+
 type SearchPostStore struct {
 	store.PostStore
 	rootStore *SearchStore
@@ -146,12 +149,62 @@ func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.Se
 		}
 	}
 
-	postIds, matches, err := engine.SearchPosts(userChannels, paramsList, page, perPage)
+	postIds, matches, total, err := engine.SearchPosts(userChannels, paramsList, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	postList, err := s.getPostListByIds(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	results := model.MakePostSearchResults(postList, matches)
+	results.Total = total
+
+	return results, nil
+}
+
+func (s SearchPostStore) searchPostsInTeamByEngine(engine searchengine.SearchEngineInterface, teamId, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	var channels *model.ChannelList
+	if params.SearchWithoutUserId {
+		teamChannels, err := s.rootStore.Channel().GetTeamChannels(teamId)
+		if err != nil {
+			if err.StatusCode == http.StatusNotFound {
+				return model.NewPostList(), nil
+			}
+			return nil, err
+		}
+
+		channels = &model.ChannelList{}
+		for _, channel := range *teamChannels {
+			if channel.DeleteAt == 0 || params.IncludeDeletedChannels {
+				*channels = append(*channels, channel)
+			}
+		}
+	} else {
+		userChannels, nErr := s.rootStore.Channel().GetChannels(teamId, userId, params.IncludeDeletedChannels)
+		if nErr != nil {
+			var nfErr *store.ErrNotFound
+			switch {
+			case errors.As(nErr, &nfErr):
+				return model.NewPostList(), nil
+			default:
+				return nil, model.NewAppError("searchPostsInTeamByEngine", "app.channel.get_channels.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+			}
+		}
+		channels = userChannels
+	}
+
+	postIds, _, _, err := engine.SearchPosts(channels, []*model.SearchParams{params}, 0, SEARCH_POSTS_IN_TEAM_MAX_RESULTS)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the posts
+	return s.getPostListByIds(postIds)
+}
+
+func (s SearchPostStore) getPostListByIds(postIds []string) (*model.PostList, *model.AppError) {
 	postList := model.NewPostList()
 	if len(postIds) > 0 {
 		posts, err := s.PostStore.GetPostsByIds(postIds)
@@ -166,7 +219,29 @@ func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.Se
 		}
 	}
 
-	return model.MakePostSearchResults(postList, matches), nil
+	return postList, nil
+}
+
+func (s SearchPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsSearchEnabled() {
+			postList, err := s.searchPostsInTeamByEngine(engine, teamId, userId, params)
+			if err != nil {
+				mlog.Error("Encountered error on Search.", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
+				continue
+			}
+			mlog.Debug("Using the first available search engine", mlog.String("search_engine", engine.GetName()))
+			return postList, nil
+		}
+	}
+
+	if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+		mlog.Debug("Returning empty results for post Search as the database search is disabled")
+		return model.NewPostList(), nil
+	}
+
+	mlog.Debug("Using database search because no other search engine is available")
+	return s.PostStore.Search(teamId, userId, params)
 }
 
 func (s SearchPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError) {
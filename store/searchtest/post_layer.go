@@ -23,6 +23,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchPostsWithPagination,
 		Tags: []string{ENGINE_ELASTICSEARCH, ENGINE_BLEVE},
 	},
+	{
+		Name: "Should count the posts matching several searches once",
+		Fn:   testSearchPostsTotal,
+		Tags: []string{ENGINE_POSTGRES, ENGINE_MYSQL},
+	},
 	{
 		Name: "Should return pinned and unpinned posts",
 		Fn:   testSearchReturnPinnedAndUnpinned,
@@ -263,6 +268,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testShouldNotReturnLinksEmbeddedInMarkdown,
 		Tags: []string{ENGINE_POSTGRES, ENGINE_ELASTICSEARCH},
 	},
+	{
+		Name: "Should be able to search posts in a team with or without a user",
+		Fn:   testSearchPostsInTeam,
+		Tags: []string{ENGINE_ALL},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -294,10 +304,40 @@ func testSearchPostsIncludingDMs(t *testing.T, th *SearchTestHelper) {
 	require.Nil(t, err)
 
 	require.Len(t, results.Posts, 2)
+	require.Equal(t, int64(2), results.Total)
 	th.checkPostInSearchResults(t, p1.Id, results.Posts)
 	th.checkPostInSearchResults(t, p2.Id, results.Posts)
 }
 
+func testSearchPostsTotal(t *testing.T, th *SearchTestHelper) {
+	_, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "apple banana", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	_, err = th.createPost(th.User.Id, th.ChannelBasic.Id, "apple", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	_, err = th.createPost(th.User.Id, th.ChannelBasic.Id, "searching hashtag #hashtag-test", "#hashtag-test", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	_, err = th.createPost(th.User.Id, th.ChannelBasic.Id, "searching hashtag #hashtagtest", "#hashtagtest", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	paramsList := []*model.SearchParams{{Terms: "apple"}, {Terms: "banana"}}
+	results, apperr := th.Store.Post().SearchPostsInTeamForUser(paramsList, th.User.Id, th.Team.Id, false, false, 0, 20)
+	require.Nil(t, apperr)
+	require.Len(t, results.Posts, 2)
+	require.Equal(t, int64(2), results.Total)
+
+	results, apperr = th.Store.Post().SearchPostsInTeamForUser(paramsList, th.User.Id, th.Team.Id, false, false, 1, 20)
+	require.Nil(t, apperr)
+	require.Empty(t, results.Posts)
+	require.Equal(t, int64(2), results.Total, "later pages should still report the total")
+
+	params := &model.SearchParams{Terms: "#hashtag-test", IsHashtag: true}
+	results, apperr = th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, false, false, 0, 20)
+	require.Nil(t, apperr)
+	require.Len(t, results.Posts, 1)
+	require.Equal(t, int64(1), results.Total)
+}
+
 func testSearchPostsWithPagination(t *testing.T, th *SearchTestHelper) {
 	direct, err := th.createDirectChannel(th.Team.Id, "direct", "direct", []*model.User{th.User, th.User2})
 	require.Nil(t, err)
@@ -316,6 +356,7 @@ func testSearchPostsWithPagination(t *testing.T, th *SearchTestHelper) {
 	require.Nil(t, err)
 
 	require.Len(t, results.Posts, 1)
+	require.Equal(t, int64(2), results.Total, "the total should count the results of every page")
 	th.checkPostInSearchResults(t, p2.Id, results.Posts)
 
 	results, err = th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, false, false, 1, 1)
@@ -1772,3 +1813,40 @@ func testShouldNotReturnLinksEmbeddedInMarkdown(t *testing.T, th *SearchTestHelp
 
 	require.Len(t, results.Posts, 0)
 }
+
+func testSearchPostsInTeam(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "team search basic", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User2.Id, th.ChannelPrivate.Id, "team search private", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	p3, err := th.createPost(th.UserAnotherTeam.Id, th.ChannelAnotherTeam.Id, "team search another", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+	defer th.deleteUserPosts(th.User2.Id)
+	defer th.deleteUserPosts(th.UserAnotherTeam.Id)
+
+	t.Run("without a user it should search all the channels of the team", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "search", SearchWithoutUserId: true}
+		results, apperr := th.Store.Post().Search(th.Team.Id, "", params)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 2)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("with a user it should only search the channels of the user", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "search"}
+		results, apperr := th.Store.Post().Search(th.Team.Id, th.User2.Id, params)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+
+		results, apperr = th.Store.Post().Search(th.AnotherTeam.Id, th.UserAnotherTeam.Id, params)
+		require.Nil(t, apperr)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type SqlAdminNotificationStore struct {
+	SqlStore
+}
+
+func newSqlAdminNotificationStore(sqlStore SqlStore) store.AdminNotificationStore {
+	s := &SqlAdminNotificationStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.AdminNotification{}, "AdminNotifications").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("Type").SetMaxSize(64)
+		table.ColMap("Message").SetMaxSize(model.ADMIN_NOTIFICATION_MESSAGE_MAX_RUNES)
+		table.ColMap("PostId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlAdminNotificationStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_adminnotifications_update_at", "AdminNotifications", "UpdateAt")
+	s.CreateCompositeIndexIfNotExists("idx_adminnotifications_type_update_at", "AdminNotifications", []string{"Type", "UpdateAt"})
+}
+
+func (s SqlAdminNotificationStore) Save(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	if notification.Id != "" {
+		return nil, store.NewErrInvalidInput("AdminNotification", "Id", notification.Id)
+	}
+
+	notification.PreSave()
+	if err := notification.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(notification); err != nil {
+		return nil, errors.Wrapf(err, "failed to save AdminNotification with id=%s", notification.Id)
+	}
+
+	return notification, nil
+}
+
+func (s SqlAdminNotificationStore) Update(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	notification.PreUpdate()
+	if err := notification.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(notification)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update AdminNotification with id=%s", notification.Id)
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("AdminNotification", notification.Id)
+	}
+
+	return notification, nil
+}
+
+// GetLatest returns the most recent notification of the given type and message that was raised
+// since the given time.
+func (s SqlAdminNotificationStore) GetLatest(notificationType, message string, since int64) (*model.AdminNotification, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("AdminNotifications").
+		Where(sq.Eq{"Type": notificationType, "Message": message}).
+		Where(sq.GtOrEq{"UpdateAt": since}).
+		OrderBy("UpdateAt DESC").
+		Limit(1)
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "admin_notification_tosql")
+	}
+
+	var notification model.AdminNotification
+	if err := s.GetMaster().SelectOne(&notification, queryString, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("AdminNotification", notificationType)
+		}
+		return nil, errors.Wrapf(err, "failed to get AdminNotification with type=%s", notificationType)
+	}
+
+	return &notification, nil
+}
+
+// GetAll returns a page of the notifications, the most recently raised first.
+func (s SqlAdminNotificationStore) GetAll(offset, limit int) ([]*model.AdminNotification, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("AdminNotifications").
+		OrderBy("UpdateAt DESC", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "admin_notifications_tosql")
+	}
+
+	notifications := []*model.AdminNotification{}
+	if _, err := s.GetReplica().Select(&notifications, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get AdminNotifications")
+	}
+
+	return notifications, nil
+}
@@ -4,19 +4,37 @@
 package sqlstore
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
+	syslog "github.com/wiggin77/srslog"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
 )
 
+const (
+	auditSyslogTag        = "mattermost-audit"
+	auditSyslogBufferSize = 1000
+	auditSyslogMinBackoff = time.Second
+	auditSyslogMaxBackoff = time.Minute
+)
+
 type SqlAuditStore struct {
 	SqlStore
+
+	syslogMutex  sync.Mutex
+	syslogStream *auditSyslogStream
 }
 
 func newSqlAuditStore(sqlStore SqlStore) store.AuditStore {
-	s := &SqlAuditStore{sqlStore}
+	s := &SqlAuditStore{SqlStore: sqlStore}
 
 	for _, db := range sqlStore.GetAllConns() {
 		table := db.AddTableWithName(model.Audit{}, "Audits").SetKeys(false, "Id")
@@ -31,21 +49,193 @@ func newSqlAuditStore(sqlStore SqlStore) store.AuditStore {
 	return s
 }
 
-func (s SqlAuditStore) createIndexesIfNotExists() {
+func (s *SqlAuditStore) createIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_audits_user_id", "Audits", "UserId")
 }
 
-func (s SqlAuditStore) Save(audit *model.Audit) error {
+func (s *SqlAuditStore) Save(audit *model.Audit) error {
 	audit.Id = model.NewId()
 	audit.CreateAt = model.GetMillis()
 
 	if err := s.GetMaster().Insert(audit); err != nil {
 		return errors.Wrapf(err, "failed to save Audit with userId=%s and action=%s", audit.UserId, audit.Action)
 	}
+
+	s.syslogMutex.Lock()
+	stream := s.syslogStream
+	s.syslogMutex.Unlock()
+
+	if stream != nil {
+		stream.send(audit.ToJson())
+	}
+
+	return nil
+}
+
+// StreamToSyslog publishes every audit record saved from now on to the syslog server at addr, in the RFC 5424
+// format. The protocol is one of "tcp", "tcp+tls" or "udp". Over TLS, the server is verified with the PEM
+// encoded certificate authorities of caCert, or the system roots when it is empty. Any previous stream is
+// stopped, and an empty addr only stops streaming. The server is reached in the background, so it doesn't
+// need to be up yet.
+func (s *SqlAuditStore) StreamToSyslog(addr string, protocol string, caCert []byte) error {
+	var stream *auditSyslogStream
+	if addr != "" {
+		switch protocol {
+		case model.COMPLIANCE_SYSLOG_PROTOCOL_TCP, model.COMPLIANCE_SYSLOG_PROTOCOL_TCP_TLS, model.COMPLIANCE_SYSLOG_PROTOCOL_UDP:
+		default:
+			return store.NewErrInvalidInput("Audit", "protocol", protocol)
+		}
+
+		tlsConfig := &tls.Config{}
+		if len(caCert) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+				return store.NewErrInvalidInput("Audit", "caCert", "no PEM certificate")
+			}
+		}
+
+		stream = newAuditSyslogStream(addr, protocol, tlsConfig)
+	}
+
+	s.syslogMutex.Lock()
+	defer s.syslogMutex.Unlock()
+
+	if s.syslogStream != nil {
+		s.syslogStream.stop()
+	}
+	s.syslogStream = stream
+
 	return nil
 }
 
-func (s SqlAuditStore) Get(userId string, offset int, limit int) (model.Audits, error) {
+// auditSyslogStream forwards audit records to a syslog server from its own goroutine, so that saving a record
+// never waits on the network. Failed connections are retried with a growing backoff, and records are dropped
+// while the buffer is full.
+type auditSyslogStream struct {
+	addr      string
+	protocol  string
+	tlsConfig *tls.Config
+	records   chan string
+	done      chan struct{}
+	dropped   int64
+}
+
+func newAuditSyslogStream(addr, protocol string, tlsConfig *tls.Config) *auditSyslogStream {
+	stream := &auditSyslogStream{
+		addr:      addr,
+		protocol:  protocol,
+		tlsConfig: tlsConfig,
+		records:   make(chan string, auditSyslogBufferSize),
+		done:      make(chan struct{}),
+	}
+
+	go stream.run()
+
+	return stream
+}
+
+func (s *auditSyslogStream) send(record string) {
+	select {
+	case s.records <- record:
+	default:
+		atomic.AddInt64(&s.dropped, 1)
+	}
+}
+
+// stop discards the records not sent yet and closes the connection.
+func (s *auditSyslogStream) stop() {
+	close(s.done)
+}
+
+func (s *auditSyslogStream) dial() (*syslog.Writer, error) {
+	var writer *syslog.Writer
+	var err error
+	if s.protocol == model.COMPLIANCE_SYSLOG_PROTOCOL_TCP_TLS {
+		writer, err = syslog.DialWithTLSConfig(s.protocol, s.addr, syslog.LOG_INFO|syslog.LOG_AUTH, auditSyslogTag, s.tlsConfig)
+	} else {
+		writer, err = syslog.Dial(s.protocol, s.addr, syslog.LOG_INFO|syslog.LOG_AUTH, auditSyslogTag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	writer.SetFormatter(syslog.RFC5424Formatter)
+	if s.protocol != model.COMPLIANCE_SYSLOG_PROTOCOL_UDP {
+		// Stream transports need each message framed with its length so the server can split them.
+		writer.SetFramer(syslog.RFC5425MessageLengthFramer)
+	}
+
+	return writer, nil
+}
+
+// connect dials the server until it succeeds, waiting longer after each failure, or returns nil once the
+// stream is stopped.
+func (s *auditSyslogStream) connect() *syslog.Writer {
+	backoff := auditSyslogMinBackoff
+	for {
+		writer, err := s.dial()
+		if err == nil {
+			return writer
+		}
+
+		mlog.Warn("Failed to connect to the audit syslog server, retrying", mlog.String("address", s.addr), mlog.String("protocol", s.protocol), mlog.Duration("backoff", backoff), mlog.Err(err))
+
+		select {
+		case <-s.done:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > auditSyslogMaxBackoff {
+			backoff = auditSyslogMaxBackoff
+		}
+	}
+}
+
+func (s *auditSyslogStream) run() {
+	var writer *syslog.Writer
+	defer func() {
+		if writer != nil {
+			if err := writer.Close(); err != nil {
+				mlog.Warn("Failed to close the audit syslog stream", mlog.Err(err))
+			}
+		}
+	}()
+
+	for {
+		var record string
+		select {
+		case <-s.done:
+			return
+		case record = <-s.records:
+		}
+
+		// A record that still fails on a fresh connection is dropped rather than blocking the stream.
+		for attempt := 0; attempt < 2; attempt++ {
+			if writer == nil {
+				if writer = s.connect(); writer == nil {
+					return
+				}
+			}
+
+			err := writer.Info(record)
+			if err == nil {
+				break
+			}
+
+			mlog.Warn("Failed to stream audit record to syslog", mlog.String("address", s.addr), mlog.Err(err))
+			writer.Close()
+			writer = nil
+		}
+
+		if dropped := atomic.SwapInt64(&s.dropped, 0); dropped > 0 {
+			mlog.Warn("Dropped audit records while the syslog stream was behind", mlog.String("address", s.addr), mlog.Int64("dropped", dropped))
+		}
+	}
+}
+
+func (s *SqlAuditStore) Get(userId string, offset int, limit int) (model.Audits, error) {
 	if limit > 1000 {
 		return nil, store.NewErrOutOfBounds(limit)
 	}
@@ -73,7 +263,7 @@ func (s SqlAuditStore) Get(userId string, offset int, limit int) (model.Audits,
 	return audits, nil
 }
 
-func (s SqlAuditStore) PermanentDeleteByUser(userId string) error {
+func (s *SqlAuditStore) PermanentDeleteByUser(userId string) error {
 	if _, err := s.GetMaster().Exec("DELETE FROM Audits WHERE UserId = :userId",
 		map[string]interface{}{"userId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to delete Audit with userId=%s", userId)
@@ -125,6 +125,10 @@ func (us SqlBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error)
 		additionalJoin = "JOIN Users o ON (o.Id = b.OwnerId)"
 		conditions = append(conditions, "o.DeleteAt != 0")
 	}
+	if !options.IncludeSystemBot {
+		conditions = append(conditions, "u.Username != :system_bot_username")
+		params["system_bot_username"] = model.SYSTEM_BOT_USERNAME
+	}
 
 	if len(conditions) > 0 {
 		conditionsSql = "WHERE " + strings.Join(conditions, " AND ")
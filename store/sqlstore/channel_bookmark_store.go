@@ -0,0 +1,220 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type SqlChannelBookmarkStore struct {
+	SqlStore
+}
+
+func newSqlChannelBookmarkStore(sqlStore SqlStore) store.ChannelBookmarkStore {
+	s := &SqlChannelBookmarkStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelBookmark{}, "ChannelBookmarks").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("OwnerId").SetMaxSize(26)
+		table.ColMap("FileId").SetMaxSize(26)
+		table.ColMap("DisplayName").SetMaxSize(64)
+		table.ColMap("LinkUrl").SetMaxSize(1024)
+		table.ColMap("Emoji").SetMaxSize(64)
+		table.ColMap("Type").SetMaxSize(16)
+	}
+
+	return s
+}
+
+func (s SqlChannelBookmarkStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelbookmarks_channel_id", "ChannelBookmarks", "ChannelId")
+	s.CreateIndexIfNotExists("idx_channelbookmarks_file_id", "ChannelBookmarks", "FileId")
+	s.CreateIndexIfNotExists("idx_channelbookmarks_delete_at", "ChannelBookmarks", "DeleteAt")
+}
+
+func (s SqlChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	if bookmark.Id != "" {
+		return nil, store.NewErrInvalidInput("ChannelBookmark", "Id", bookmark.Id)
+	}
+
+	bookmark.PreSave()
+	if err := bookmark.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// New bookmarks are appended after the existing ones.
+	maxSortOrder, err := s.GetMaster().SelectNullInt("SELECT MAX(SortOrder) FROM ChannelBookmarks WHERE ChannelId = :ChannelId AND DeleteAt = 0", map[string]interface{}{"ChannelId": bookmark.ChannelId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get max sort order for channel_id=%s", bookmark.ChannelId)
+	}
+	if maxSortOrder.Valid {
+		bookmark.SortOrder = maxSortOrder.Int64 + 1
+	} else {
+		bookmark.SortOrder = 0
+	}
+
+	if err := s.GetMaster().Insert(bookmark); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelBookmark with id=%s", bookmark.Id)
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	bookmark.PreUpdate()
+	if err := bookmark.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(bookmark)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update ChannelBookmark with id=%s", bookmark.Id)
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id)
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	query := s.getQueryBuilder().
+		Select("*").
+		From("ChannelBookmarks").
+		Where(sq.Eq{"Id": id})
+
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"DeleteAt": 0})
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	var bookmark model.ChannelBookmark
+	if err := s.GetReplica().SelectOne(&bookmark, queryString, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelBookmark", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelBookmark with id=%s", id)
+	}
+
+	return &bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("ChannelBookmarks").
+		Where(sq.Eq{"ChannelId": channelId, "DeleteAt": 0}).
+		OrderBy("SortOrder ASC", "CreateAt ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmarks_tosql")
+	}
+
+	bookmarks := []*model.ChannelBookmark{}
+	if _, err := s.GetReplica().Select(&bookmarks, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with channel_id=%s", channelId)
+	}
+
+	return bookmarks, nil
+}
+
+// GetBookmarksForFile returns the active bookmarks pointing at the given file.
+func (s SqlChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("ChannelBookmarks").
+		Where(sq.Eq{"FileId": fileId, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmarks_tosql")
+	}
+
+	bookmarks := []*model.ChannelBookmark{}
+	if _, err := s.GetReplica().Select(&bookmarks, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with file_id=%s", fileId)
+	}
+
+	return bookmarks, nil
+}
+
+// UpdateSortOrder sets the sort order of the channel's bookmarks to match the given list of ids,
+// which must contain every bookmark of the channel exactly once.
+func (s SqlChannelBookmarkStore) UpdateSortOrder(channelId string, bookmarkIds []string) error {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	var existingIds []string
+	if _, err := transaction.Select(&existingIds, "SELECT Id FROM ChannelBookmarks WHERE ChannelId = :ChannelId AND DeleteAt = 0", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return errors.Wrapf(err, "failed to find ChannelBookmarks with channel_id=%s", channelId)
+	}
+
+	if len(existingIds) != len(bookmarkIds) {
+		return store.NewErrInvalidInput("ChannelBookmark", "BookmarkIds", bookmarkIds)
+	}
+
+	existing := make(map[string]bool, len(existingIds))
+	for _, id := range existingIds {
+		existing[id] = true
+	}
+	for _, id := range bookmarkIds {
+		if !existing[id] {
+			return store.NewErrInvalidInput("ChannelBookmark", "BookmarkIds", bookmarkIds)
+		}
+		delete(existing, id)
+	}
+
+	updateAt := model.GetMillis()
+	for i, id := range bookmarkIds {
+		if _, err := transaction.Exec("UPDATE ChannelBookmarks SET SortOrder = :SortOrder, UpdateAt = :UpdateAt WHERE Id = :Id", map[string]interface{}{"SortOrder": i, "UpdateAt": updateAt, "Id": id}); err != nil {
+			return errors.Wrapf(err, "failed to update sort order of ChannelBookmark with id=%s", id)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s SqlChannelBookmarkStore) Delete(id string) error {
+	curTime := model.GetMillis()
+	result, err := s.GetMaster().Exec("UPDATE ChannelBookmarks SET DeleteAt = :DeleteAt, UpdateAt = :UpdateAt WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"DeleteAt": curTime, "UpdateAt": curTime, "Id": id})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelBookmark with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected for ChannelBookmark with id=%s", id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("ChannelBookmark", id)
+	}
+
+	return nil
+}
+
+func (s SqlChannelBookmarkStore) PermanentDeleteByChannel(channelId string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelBookmarks WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelBookmarks with channel_id=%s", channelId)
+	}
+
+	return nil
+}
@@ -159,6 +159,35 @@ func (s SqlChannelMemberHistoryStore) getFromChannelMembersTable(startTime int64
 	return histories, nil
 }
 
+// GetForChannel returns the memberships of the channel that were joined or left between since and
+// until, both inclusive, ordered by the time they were joined.
+func (s SqlChannelMemberHistoryStore) GetForChannel(channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelMemberHistoryResult, error) {
+	query := `
+			SELECT
+				cmh.*,
+				u.Email,
+				u.Username,
+			    Bots.UserId IS NOT NULL AS IsBot
+			FROM ChannelMemberHistory cmh
+			INNER JOIN Users u ON cmh.UserId = u.Id
+			LEFT JOIN Bots ON Bots.UserId = u.Id
+			WHERE cmh.ChannelId = :ChannelId
+			AND (
+				(cmh.JoinTime >= :Since AND cmh.JoinTime <= :Until)
+				OR (cmh.LeaveTime IS NOT NULL AND cmh.LeaveTime >= :Since AND cmh.LeaveTime <= :Until)
+			)
+			ORDER BY cmh.JoinTime ASC, cmh.UserId ASC
+			LIMIT :Limit OFFSET :Offset`
+
+	params := map[string]interface{}{"ChannelId": channelId, "Since": since, "Until": until, "Limit": limit, "Offset": offset}
+	var histories []*model.ChannelMemberHistoryResult
+	if _, err := s.GetReplica().Select(&histories, query, params); err != nil {
+		return nil, errors.Wrapf(err, "GetForChannel channelId=%s since=%d until=%d", channelId, since, until)
+	}
+
+	return histories, nil
+}
+
 func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	var query string
 	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
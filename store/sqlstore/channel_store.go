@@ -39,32 +39,34 @@ type SqlChannelStore struct {
 }
 
 type channelMember struct {
-	ChannelId    string
-	UserId       string
-	Roles        string
-	LastViewedAt int64
-	MsgCount     int64
-	MentionCount int64
-	NotifyProps  model.StringMap
-	LastUpdateAt int64
-	SchemeUser   sql.NullBool
-	SchemeAdmin  sql.NullBool
-	SchemeGuest  sql.NullBool
+	ChannelId       string
+	UserId          string
+	Roles           string
+	LastViewedAt    int64
+	MsgCount        int64
+	MentionCount    int64
+	NotifyProps     model.StringMap
+	LastUpdateAt    int64
+	SchemeUser      sql.NullBool
+	SchemeAdmin     sql.NullBool
+	SchemeGuest     sql.NullBool
+	ManuallyManaged sql.NullBool
 }
 
 func NewChannelMemberFromModel(cm *model.ChannelMember) *channelMember {
 	return &channelMember{
-		ChannelId:    cm.ChannelId,
-		UserId:       cm.UserId,
-		Roles:        cm.ExplicitRoles,
-		LastViewedAt: cm.LastViewedAt,
-		MsgCount:     cm.MsgCount,
-		MentionCount: cm.MentionCount,
-		NotifyProps:  cm.NotifyProps,
-		LastUpdateAt: cm.LastUpdateAt,
-		SchemeGuest:  sql.NullBool{Valid: true, Bool: cm.SchemeGuest},
-		SchemeUser:   sql.NullBool{Valid: true, Bool: cm.SchemeUser},
-		SchemeAdmin:  sql.NullBool{Valid: true, Bool: cm.SchemeAdmin},
+		ChannelId:       cm.ChannelId,
+		UserId:          cm.UserId,
+		Roles:           cm.ExplicitRoles,
+		LastViewedAt:    cm.LastViewedAt,
+		MsgCount:        cm.MsgCount,
+		MentionCount:    cm.MentionCount,
+		NotifyProps:     cm.NotifyProps,
+		LastUpdateAt:    cm.LastUpdateAt,
+		SchemeGuest:     sql.NullBool{Valid: true, Bool: cm.SchemeGuest},
+		SchemeUser:      sql.NullBool{Valid: true, Bool: cm.SchemeUser},
+		SchemeAdmin:     sql.NullBool{Valid: true, Bool: cm.SchemeAdmin},
+		ManuallyManaged: sql.NullBool{Valid: true, Bool: cm.ManuallyManaged},
 	}
 }
 
@@ -80,6 +82,7 @@ type channelMemberWithSchemeRoles struct {
 	SchemeGuest                   sql.NullBool
 	SchemeUser                    sql.NullBool
 	SchemeAdmin                   sql.NullBool
+	ManuallyManaged               sql.NullBool
 	TeamSchemeDefaultGuestRole    sql.NullString
 	TeamSchemeDefaultUserRole     sql.NullString
 	TeamSchemeDefaultAdminRole    sql.NullString
@@ -89,7 +92,7 @@ type channelMemberWithSchemeRoles struct {
 }
 
 func channelMemberSliceColumns() []string {
-	return []string{"ChannelId", "UserId", "Roles", "LastViewedAt", "MsgCount", "MentionCount", "NotifyProps", "LastUpdateAt", "SchemeUser", "SchemeAdmin", "SchemeGuest"}
+	return []string{"ChannelId", "UserId", "Roles", "LastViewedAt", "MsgCount", "MentionCount", "NotifyProps", "LastUpdateAt", "SchemeUser", "SchemeAdmin", "SchemeGuest", "ManuallyManaged"}
 }
 
 func channelMemberToSlice(member *model.ChannelMember) []interface{} {
@@ -105,6 +108,7 @@ func channelMemberToSlice(member *model.ChannelMember) []interface{} {
 	resultSlice = append(resultSlice, member.SchemeUser)
 	resultSlice = append(resultSlice, member.SchemeAdmin)
 	resultSlice = append(resultSlice, member.SchemeGuest)
+	resultSlice = append(resultSlice, member.ManuallyManaged)
 	return resultSlice
 }
 
@@ -223,20 +227,23 @@ func (db channelMemberWithSchemeRoles) ToModel() *model.ChannelMember {
 		defaultChannelGuestRole, defaultChannelUserRole, defaultChannelAdminRole,
 		strings.Fields(db.Roles),
 	)
-	return &model.ChannelMember{
-		ChannelId:     db.ChannelId,
-		UserId:        db.UserId,
-		Roles:         strings.Join(rolesResult.roles, " "),
-		LastViewedAt:  db.LastViewedAt,
-		MsgCount:      db.MsgCount,
-		MentionCount:  db.MentionCount,
-		NotifyProps:   db.NotifyProps,
-		LastUpdateAt:  db.LastUpdateAt,
-		SchemeAdmin:   rolesResult.schemeAdmin,
-		SchemeUser:    rolesResult.schemeUser,
-		SchemeGuest:   rolesResult.schemeGuest,
-		ExplicitRoles: strings.Join(rolesResult.explicitRoles, " "),
+	member := &model.ChannelMember{
+		ChannelId:       db.ChannelId,
+		UserId:          db.UserId,
+		LastViewedAt:    db.LastViewedAt,
+		MsgCount:        db.MsgCount,
+		MentionCount:    db.MentionCount,
+		NotifyProps:     db.NotifyProps,
+		LastUpdateAt:    db.LastUpdateAt,
+		SchemeAdmin:     rolesResult.schemeAdmin,
+		SchemeUser:      rolesResult.schemeUser,
+		SchemeGuest:     rolesResult.schemeGuest,
+		ExplicitRoles:   strings.Join(rolesResult.explicitRoles, " "),
+		ManuallyManaged: db.ManuallyManaged.Valid && db.ManuallyManaged.Bool,
 	}
+	member.SetRoles(rolesResult.roles)
+
+	return member
 }
 
 func (db channelMemberWithSchemeRolesList) ToModel() *model.ChannelMembers {
@@ -726,6 +733,13 @@ func (s SqlChannelStore) upsertPublicChannelT(transaction *gorp.Transaction, cha
 
 // Save writes the (non-direct) channel channel to the database.
 func (s SqlChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	return s.SaveWithCreatorLimit(channel, maxChannelsPerTeam, -1, 0)
+}
+
+// SaveWithCreatorLimit saves the channel unless its creator already created maxChannelsPerCreator
+// public or private channels since createdSince. A negative maxChannelsPerCreator disables the
+// check.
+func (s SqlChannelStore) SaveWithCreatorLimit(channel *model.Channel, maxChannelsPerTeam int64, maxChannelsPerCreator int64, createdSince int64) (*model.Channel, error) {
 	if channel.DeleteAt != 0 {
 		return nil, store.NewErrInvalidInput("Channel", "DeleteAt", channel.DeleteAt)
 	}
@@ -742,6 +756,10 @@ func (s SqlChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64)
 		}
 		defer finalizeTransaction(transaction)
 
+		if err = s.checkCreatorLimitT(transaction, channel, maxChannelsPerCreator, createdSince); err != nil {
+			return err
+		}
+
 		newChannel, err = s.saveChannelT(transaction, channel, maxChannelsPerTeam)
 		if err != nil {
 			return err
@@ -829,6 +847,30 @@ func (s SqlChannelStore) SaveDirectChannel(directchannel *model.Channel, member1
 
 }
 
+// checkCreatorLimitT returns an error when the creator of the channel already created the maximum
+// number of public or private channels since the given time.
+func (s SqlChannelStore) checkCreatorLimitT(transaction *gorp.Transaction, channel *model.Channel, maxChannelsPerCreator int64, createdSince int64) error {
+	if maxChannelsPerCreator < 0 || channel.CreatorId == "" || channel.Type == model.CHANNEL_DIRECT || channel.Type == model.CHANNEL_GROUP {
+		return nil
+	}
+
+	// Lock the user row so that concurrent saves by the same creator are counted one after the
+	// other, instead of all passing the check before any of them is inserted.
+	if _, err := transaction.SelectNullStr("SELECT Id FROM Users WHERE Id = :CreatorId FOR UPDATE", map[string]interface{}{"CreatorId": channel.CreatorId}); err != nil {
+		return errors.Wrapf(err, "save_channel_lock_creator: creatorId=%s", channel.CreatorId)
+	}
+
+	count, err := transaction.SelectInt("SELECT COUNT(0) FROM Channels WHERE CreatorId = :CreatorId AND CreateAt >= :CreatedSince AND (Type = 'O' OR Type = 'P')", map[string]interface{}{"CreatorId": channel.CreatorId, "CreatedSince": createdSince})
+	if err != nil {
+		return errors.Wrapf(err, "save_channel_count_created: creatorId=%s", channel.CreatorId)
+	}
+	if count >= maxChannelsPerCreator {
+		return store.NewErrLimitExceeded("channels_per_creator", int(count), "creatorId="+channel.CreatorId)
+	}
+
+	return nil
+}
+
 func (s SqlChannelStore) saveChannelT(transaction *gorp.Transaction, channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
 	if len(channel.Id) > 0 {
 		return nil, store.NewErrInvalidInput("Channel", "Id", channel.Id)
@@ -840,6 +882,12 @@ func (s SqlChannelStore) saveChannelT(transaction *gorp.Transaction, channel *mo
 	}
 
 	if channel.Type != model.CHANNEL_DIRECT && channel.Type != model.CHANNEL_GROUP && maxChannelsPerTeam >= 0 {
+		// Lock the team row so that concurrent saves to the same team are counted one after the
+		// other, instead of all passing the check before any of them is inserted.
+		if _, err := transaction.SelectNullStr("SELECT Id FROM Teams WHERE Id = :TeamId FOR UPDATE", map[string]interface{}{"TeamId": channel.TeamId}); err != nil {
+			return nil, errors.Wrapf(err, "save_channel_lock_team: teamId=%s", channel.TeamId)
+		}
+
 		if count, err := transaction.SelectInt("SELECT COUNT(0) FROM Channels WHERE TeamId = :TeamId AND DeleteAt = 0 AND (Type = 'O' OR Type = 'P')", map[string]interface{}{"TeamId": channel.TeamId}); err != nil {
 			return nil, errors.Wrapf(err, "save_channel_count: teamId=%s", channel.TeamId)
 		} else if count >= maxChannelsPerTeam {
@@ -936,6 +984,45 @@ func (s SqlChannelStore) GetChannelUnread(channelId, userId string) (*model.Chan
 	return &unreadChannel, nil
 }
 
+func (s SqlChannelStore) GetLastViewedPostForUser(userId, channelId string) (string, int64, error) {
+	lastViewedAt, err := s.GetReplica().SelectNullInt(`
+		SELECT
+			LastViewedAt
+		FROM
+			ChannelMembers
+		WHERE
+			ChannelId = :ChannelId
+			AND UserId = :UserId`,
+		map[string]interface{}{"ChannelId": channelId, "UserId": userId})
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "failed to get LastViewedAt with channelId=%s and userId=%s", channelId, userId)
+	}
+	if !lastViewedAt.Valid {
+		return "", 0, store.NewErrNotFound("ChannelMember", fmt.Sprintf("channelId=%s, userId=%s", channelId, userId))
+	}
+	if lastViewedAt.Int64 == 0 {
+		return "", 0, nil
+	}
+
+	postId, err := s.GetReplica().SelectNullStr(`
+		SELECT
+			Id
+		FROM
+			Posts
+		WHERE
+			ChannelId = :ChannelId
+			AND CreateAt <= :LastViewedAt
+			AND DeleteAt = 0
+		ORDER BY CreateAt DESC
+		LIMIT 1`,
+		map[string]interface{}{"ChannelId": channelId, "LastViewedAt": lastViewedAt.Int64})
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "failed to get the last viewed post with channelId=%s", channelId)
+	}
+
+	return postId.String, lastViewedAt.Int64, nil
+}
+
 func (s SqlChannelStore) InvalidateChannel(id string) {
 }
 
@@ -1188,6 +1275,38 @@ func (s SqlChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int6
 	return count, nil
 }
 
+// GetTeamChannelsWithActivity returns a page of the public and private channels of a team along
+// with their number of active members, ordered by name or with the most recently active first.
+func (s SqlChannelStore) GetTeamChannelsWithActivity(teamId string, offset, limit int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, error) {
+	query := s.getQueryBuilder().
+		Select("c.*, (SELECT COUNT(*) FROM ChannelMembers, Users WHERE ChannelMembers.ChannelId = c.Id AND ChannelMembers.UserId = Users.Id AND Users.DeleteAt = 0) AS MemberCount").
+		From("Channels AS c").
+		Where(sq.Eq{"c.TeamId": teamId}).
+		Where(sq.Eq{"c.Type": []string{model.CHANNEL_OPEN, model.CHANNEL_PRIVATE}})
+
+	if !opts.IncludeDeleted {
+		query = query.Where(sq.Eq{"c.DeleteAt": int(0)})
+	}
+
+	if opts.SortByActivity {
+		query = query.OrderBy("c.LastPostAt DESC", "c.Id")
+	} else {
+		query = query.OrderBy("c.DisplayName", "c.Id")
+	}
+
+	queryString, args, err := query.Limit(uint64(limit)).Offset(uint64(offset)).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create query")
+	}
+
+	channels := []*model.ChannelWithActivity{}
+	if _, err := s.GetReplica().Select(&channels, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get channels with activity with teamId=%s", teamId)
+	}
+
+	return channels, nil
+}
+
 func (s SqlChannelStore) getAllChannelsQuery(opts store.ChannelSearchOpts, forCount bool) sq.SelectBuilder {
 	var selectStr string
 	if forCount {
@@ -1285,7 +1404,12 @@ func (s SqlChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, li
 	return channels, nil
 }
 
-func (s SqlChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+func (s SqlChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError) {
+	hiddenFilter := ""
+	if !includeHidden {
+		hiddenFilter = "AND (Channels.ExperimentalHideChannelFromPublicSearch IS NULL OR Channels.ExperimentalHideChannelFromPublicSearch = FALSE)"
+	}
+
 	channels := &model.ChannelList{}
 	_, err := s.GetReplica().Select(channels, `
 		SELECT
@@ -1297,6 +1421,7 @@ func (s SqlChannelStore) GetPublicChannelsForTeam(teamId string, offset int, lim
 		WHERE
 			pc.TeamId = :TeamId
 		AND pc.DeleteAt = 0
+		`+hiddenFilter+`
 		ORDER BY pc.DisplayName
 		LIMIT :Limit
 		OFFSET :Offset
@@ -1540,6 +1665,42 @@ func (s SqlChannelStore) GetDeleted(teamId string, offset int, limit int, userId
 	return channels, nil
 }
 
+func (s SqlChannelStore) GetDeletedByTeamForUserSince(teamId string, userId string, since int64) (model.ChannelList, error) {
+	channels := model.ChannelList{}
+
+	query := `
+		SELECT Channels.* FROM Channels
+		INNER JOIN ChannelMembers ON ChannelMembers.ChannelId = Channels.Id
+		WHERE Channels.TeamId = :TeamId
+		AND ChannelMembers.UserId = :UserId
+		AND Channels.DeleteAt > :Since
+		ORDER BY Channels.DeleteAt, Channels.Id
+	`
+
+	if _, err := s.GetReplica().Select(&channels, query, map[string]interface{}{"TeamId": teamId, "UserId": userId, "Since": since}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get deleted channels with TeamId=%s, UserId=%s and since=%d", teamId, userId, since)
+	}
+
+	return channels, nil
+}
+
+func (s SqlChannelStore) GetRecentlyDeleted(teamId string, offset int, limit int) ([]*model.Channel, error) {
+	var channels []*model.Channel
+
+	query := `
+		SELECT * FROM Channels
+		WHERE TeamId = :TeamId
+		AND DeleteAt != 0
+		ORDER BY DeleteAt DESC, Id LIMIT :Limit OFFSET :Offset
+	`
+
+	if _, err := s.GetReplica().Select(&channels, query, map[string]interface{}{"TeamId": teamId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get recently deleted channels with TeamId=%s", teamId)
+	}
+
+	return channels, nil
+}
+
 var CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY = `
 	SELECT
 		ChannelMembers.*,
@@ -1739,7 +1900,7 @@ func (s SqlChannelStore) saveMultipleMembersT(transaction *gorp.Transaction, mem
 		newMember.SchemeGuest = rolesResult.schemeGuest
 		newMember.SchemeUser = rolesResult.schemeUser
 		newMember.SchemeAdmin = rolesResult.schemeAdmin
-		newMember.Roles = strings.Join(rolesResult.roles, " ")
+		newMember.SetRoles(rolesResult.roles)
 		newMember.ExplicitRoles = strings.Join(rolesResult.explicitRoles, " ")
 		newMembers = append(newMembers, &newMember)
 	}
@@ -2162,6 +2323,30 @@ func (s SqlChannelStore) GetPinnedPostCount(channelId string, allowFromCache boo
 	return count, nil
 }
 
+// GetRootPostCountSince returns the number of root posts created in the channel after the given time.
+func (s SqlChannelStore) GetRootPostCountSince(channelId string, since int64) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("Posts").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelId},
+			sq.Eq{"RootId": ""},
+			sq.Eq{"DeleteAt": 0},
+			sq.Gt{"CreateAt": since},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "root_post_count_tosql")
+	}
+
+	count, err := s.GetReplica().SelectInt(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to count root posts for channelId=%s", channelId)
+	}
+
+	return count, nil
+}
+
 func (s SqlChannelStore) InvalidateGuestCount(channelId string) {
 }
 
@@ -3337,6 +3522,111 @@ func (s SqlChannelStore) GetChannelMembersForExport(userId string, teamId string
 	return members, nil
 }
 
+// GetRecentDirectChannels returns up to limit direct and group message channels the user belongs to, or
+// all of them when limit isn't positive, most recently posted in first, with the ids of the other channel
+// members set as ParticipantIds.
+func (s SqlChannelStore) GetRecentDirectChannels(userID string, limit int) ([]*model.Channel, error) {
+	builder := s.getQueryBuilder().
+		Select("Channels.*").
+		From("Channels").
+		Join("ChannelMembers ON ChannelMembers.ChannelId = Channels.Id").
+		Where(sq.And{
+			sq.Eq{"ChannelMembers.UserId": userID},
+			sq.Eq{"Channels.Type": []string{model.CHANNEL_DIRECT, model.CHANNEL_GROUP}},
+			sq.Eq{"Channels.DeleteAt": 0},
+		}).
+		OrderBy("Channels.LastPostAt DESC", "Channels.Id ASC")
+	if limit > 0 {
+		builder = builder.Limit(uint64(limit))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "recent_direct_channels_tosql")
+	}
+
+	channels := []*model.Channel{}
+	if _, err = s.GetReplica().Select(&channels, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find recent direct channels for userId=%s", userID)
+	}
+
+	if len(channels) == 0 {
+		return channels, nil
+	}
+
+	channelsById := make(map[string]*model.Channel, len(channels))
+	channelIds := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		channel.ParticipantIds = []string{}
+		channelsById[channel.Id] = channel
+		channelIds = append(channelIds, channel.Id)
+	}
+
+	query, args, err = s.getQueryBuilder().
+		Select("ChannelId", "UserId").
+		From("ChannelMembers").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelIds},
+			sq.NotEq{"UserId": userID},
+		}).
+		OrderBy("ChannelId", "UserId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "recent_direct_channel_members_tosql")
+	}
+
+	var members []struct {
+		ChannelId string
+		UserId    string
+	}
+	if _, err = s.GetReplica().Select(&members, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find members of recent direct channels for userId=%s", userID)
+	}
+
+	for _, member := range members {
+		channel := channelsById[member.ChannelId]
+		channel.ParticipantIds = append(channel.ParticipantIds, member.UserId)
+	}
+
+	return channels, nil
+}
+
+func (s SqlChannelStore) GetInactiveChannelsForTeam(teamID string, inactiveSince int64, warned bool, afterID string, limit int) ([]*model.Channel, error) {
+	lastPostType := "COALESCE((SELECT Posts.Type FROM Posts WHERE Posts.ChannelId = Channels.Id AND Posts.DeleteAt = 0 ORDER BY Posts.CreateAt DESC LIMIT 1), '')"
+	lastPostIsWarning := lastPostType + " = ?"
+	if !warned {
+		lastPostIsWarning = lastPostType + " != ?"
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("Channels.*").
+		From("Channels").
+		Where(sq.And{
+			sq.Eq{"Channels.TeamId": teamID},
+			sq.Gt{"Channels.Id": afterID},
+			sq.Eq{"Channels.Type": []string{model.CHANNEL_OPEN, model.CHANNEL_PRIVATE}},
+			sq.Eq{"Channels.DeleteAt": 0},
+			sq.Lt{"Channels.LastPostAt": inactiveSince},
+			sq.Lt{"Channels.CreateAt": inactiveSince},
+			sq.Or{sq.Eq{"Channels.GroupConstrained": nil}, sq.Eq{"Channels.GroupConstrained": false}},
+			sq.Or{sq.Eq{"Channels.ExcludeFromAutoArchive": nil}, sq.Eq{"Channels.ExcludeFromAutoArchive": false}},
+			sq.Expr(lastPostIsWarning, model.POST_INACTIVE_WARNING),
+		}).
+		OrderBy("Channels.Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "inactive_channels_tosql")
+	}
+
+	channels := []*model.Channel{}
+	if _, err = s.GetReplica().Select(&channels, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find inactive channels for teamId=%s", teamID)
+	}
+
+	return channels, nil
+}
+
 func (s SqlChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, *model.AppError) {
 	var directChannelsForExport []*model.DirectChannelForExport
 	query := s.getQueryBuilder().
@@ -3455,6 +3745,7 @@ func (s SqlChannelStore) UpdateMembersRole(channelID string, userIDs []string) *
 		WHERE
 			ChannelId = :ChannelId
 			AND (SchemeGuest = false OR SchemeGuest IS NULL)
+			AND NOT (COALESCE(SchemeAdmin, false) AND COALESCE(ManuallyManaged, false))
 			`, strings.Join(userIDs, "', '"))
 
 	if _, err := s.GetMaster().Exec(sql, map[string]interface{}{"ChannelId": channelID}); err != nil {
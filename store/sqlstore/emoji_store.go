@@ -42,18 +42,55 @@ func (es SqlEmojiStore) createIndexesIfNotExists() {
 	es.CreateIndexIfNotExists("idx_emoji_create_at", "Emoji", "CreateAt")
 	es.CreateIndexIfNotExists("idx_emoji_delete_at", "Emoji", "DeleteAt")
 	es.CreateIndexIfNotExists("idx_emoji_name", "Emoji", "Name")
+	es.CreateIndexIfNotExists("idx_emoji_creator_id", "Emoji", "CreatorId")
 }
 
 func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	return es.SaveWithCreatorLimit(emoji, -1)
+}
+
+func (es SqlEmojiStore) SaveWithCreatorLimit(emoji *model.Emoji, maxEmojiPerCreator int64) (*model.Emoji, error) {
 	emoji.PreSave()
 	if err := emoji.IsValid(); err != nil {
 		return nil, err
 	}
 
-	if err := es.GetMaster().Insert(emoji); err != nil {
+	if maxEmojiPerCreator < 0 {
+		if err := es.GetMaster().Insert(emoji); err != nil {
+			return nil, errors.Wrap(err, "error saving emoji")
+		}
+
+		return emoji, nil
+	}
+
+	transaction, err := es.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	// Lock the user row so that concurrent saves by the same creator are counted one after the
+	// other, instead of all passing the check before any of them is inserted.
+	if _, err = transaction.SelectNullStr("SELECT Id FROM Users WHERE Id = :CreatorId FOR UPDATE", map[string]interface{}{"CreatorId": emoji.CreatorId}); err != nil {
+		return nil, errors.Wrapf(err, "save_emoji_lock_creator: creatorId=%s", emoji.CreatorId)
+	}
+
+	count, err := transaction.SelectInt("SELECT COUNT(*) FROM Emoji WHERE CreatorId = :CreatorId AND DeleteAt = 0", map[string]interface{}{"CreatorId": emoji.CreatorId})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not count emojis with CreatorId=%s", emoji.CreatorId)
+	}
+	if count >= maxEmojiPerCreator {
+		return nil, store.NewErrLimitExceeded("emoji_per_creator", int(count), "creatorId="+emoji.CreatorId)
+	}
+
+	if err = transaction.Insert(emoji); err != nil {
 		return nil, errors.Wrap(err, "error saving emoji")
 	}
 
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
 	return emoji, nil
 }
 
@@ -145,6 +182,15 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	return emojis, nil
 }
 
+// CountByCreator returns the number of active (not deleted) emojis created by the user.
+func (es SqlEmojiStore) CountByCreator(creatorId string) (int64, error) {
+	count, err := es.GetReplica().SelectInt("SELECT COUNT(*) FROM Emoji WHERE CreatorId = :CreatorId AND DeleteAt = 0", map[string]interface{}{"CreatorId": creatorId})
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not count emojis with CreatorId=%s", creatorId)
+	}
+	return count, nil
+}
+
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(what, key string, addToCache bool) (*model.Emoji, error) {
 	var emoji *model.Emoji
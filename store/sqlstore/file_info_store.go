@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -50,6 +51,7 @@ func (fs SqlFileInfoStore) createIndexesIfNotExists() {
 	fs.CreateIndexIfNotExists("idx_fileinfo_create_at", "FileInfo", "CreateAt")
 	fs.CreateIndexIfNotExists("idx_fileinfo_delete_at", "FileInfo", "DeleteAt")
 	fs.CreateIndexIfNotExists("idx_fileinfo_postid_at", "FileInfo", "PostId")
+	fs.CreateIndexIfNotExists("idx_fileinfo_extension_at", "FileInfo", "Extension")
 }
 
 func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
@@ -152,6 +154,35 @@ func (fs SqlFileInfoStore) GetWithOptions(page, perPage int, opt *model.GetFileI
 	return infos, nil
 }
 
+// GetPostsWithFileExtensions returns the ids of the posts with attachments having one of the given
+// extensions that were uploaded since the given time. Only the FileInfo table is scanned, with posts
+// joined by id to leave out the deleted ones.
+func (fs SqlFileInfoStore) GetPostsWithFileExtensions(extensions []string, since int64, offset, limit int) ([]string, error) {
+	query := fs.getQueryBuilder().
+		Select("DISTINCT FileInfo.PostId").
+		From("FileInfo").
+		Join("Posts ON Posts.Id = FileInfo.PostId").
+		Where(sq.Eq{"FileInfo.Extension": extensions}).
+		Where(sq.GtOrEq{"FileInfo.CreateAt": since}).
+		Where(sq.Eq{"FileInfo.DeleteAt": 0}).
+		Where(sq.Eq{"Posts.DeleteAt": 0}).
+		OrderBy("FileInfo.PostId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "posts_with_file_extensions_tosql")
+	}
+
+	var postIds []string
+	if _, err := fs.GetReplica().Select(&postIds, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find posts with file extensions")
+	}
+
+	return postIds, nil
+}
+
 func (fs SqlFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
 	info := &model.FileInfo{}
 
@@ -283,15 +314,36 @@ func (fs SqlFileInfoStore) PermanentDelete(fileId string) *model.AppError {
 	return nil
 }
 
+// PermanentDeleteBatch deletes up to limit files created before endTime, along with the channel
+// bookmarks pointing at them.
 func (fs SqlFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
-	var query string
-	if fs.DriverName() == "postgres" {
-		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit))"
-	} else {
-		query = "DELETE from FileInfo WHERE CreateAt < :EndTime LIMIT :Limit"
+	var fileIds []string
+	if _, err := fs.GetMaster().Select(&fileIds, "SELECT Id FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit", map[string]interface{}{"EndTime": endTime, "Limit": limit}); err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+	if len(fileIds) == 0 {
+		return 0, nil
 	}
 
-	sqlResult, err := fs.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit})
+	transaction, err := fs.GetMaster().Begin()
+	if err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	bookmarksQuery, args, err := fs.getQueryBuilder().Delete("ChannelBookmarks").Where(sq.Eq{"FileId": fileIds}).ToSql()
+	if err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = transaction.Exec(bookmarksQuery, args...); err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+
+	filesQuery, args, err := fs.getQueryBuilder().Delete("FileInfo").Where(sq.Eq{"Id": fileIds}).ToSql()
+	if err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+	sqlResult, err := transaction.Exec(filesQuery, args...)
 	if err != nil {
 		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
 	}
@@ -301,6 +353,10 @@ func (fs SqlFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int
 		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
 	}
 
+	if err = transaction.Commit(); err != nil {
+		return 0, model.NewAppError("SqlFileInfoStore.PermanentDeleteBatch", "store.sql_file_info.permanent_delete_batch.app_error", nil, ""+err.Error(), http.StatusInternalServerError)
+	}
+
 	return rowsAffected, nil
 }
 
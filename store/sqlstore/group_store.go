@@ -100,6 +100,10 @@ func (s *SqlGroupStore) Create(group *model.Group) (*model.Group, *model.AppErro
 		return nil, err
 	}
 
+	if err := s.checkGroupNameAvailable(group, "SqlGroupStore.GroupCreate"); err != nil {
+		return nil, err
+	}
+
 	group.Id = model.NewId()
 	group.CreateAt = model.GetMillis()
 	group.UpdateAt = group.CreateAt
@@ -114,6 +118,24 @@ func (s *SqlGroupStore) Create(group *model.Group) (*model.Group, *model.AppErro
 	return group, nil
 }
 
+// checkGroupNameAvailable fails when the name of the group is the username of a user, since both
+// are mentioned the same way. Names shared with other groups are caught by the unique constraint.
+func (s *SqlGroupStore) checkGroupNameAvailable(group *model.Group, where string) *model.AppError {
+	if group.Name == nil {
+		return nil
+	}
+
+	count, err := s.GetMaster().SelectInt("SELECT COUNT(*) FROM Users WHERE Username = :Username", map[string]interface{}{"Username": *group.Name})
+	if err != nil {
+		return model.NewAppError(where, "store.select_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if count > 0 {
+		return model.NewAppError(where, "store.sql_group.name_conflicts_with_username", nil, "name="+*group.Name, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (s *SqlGroupStore) Get(groupId string) (*model.Group, *model.AppError) {
 	var group *model.Group
 	if err := s.GetReplica().SelectOne(&group, "SELECT * from UserGroups WHERE Id = :Id", map[string]interface{}{"Id": groupId}); err != nil {
@@ -225,6 +247,13 @@ func (s *SqlGroupStore) Update(group *model.Group) (*model.Group, *model.AppErro
 		return nil, err
 	}
 
+	// Only a new name is checked, so that groups named before a user took the name can still be updated
+	if group.Name != nil && (retrievedGroup.Name == nil || *group.Name != *retrievedGroup.Name) {
+		if err := s.checkGroupNameAvailable(group, "SqlGroupStore.GroupUpdate"); err != nil {
+			return nil, err
+		}
+	}
+
 	rowsChanged, err := s.GetMaster().Update(group)
 	if err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "groups_name_key"}) {
@@ -935,6 +964,7 @@ func (s *SqlGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.Chan
 		"ChannelMembers.SchemeUser",
 		"ChannelMembers.SchemeAdmin",
 		"(ChannelMembers.SchemeGuest IS NOT NULL AND ChannelMembers.SchemeGuest) AS SchemeGuest",
+		"(ChannelMembers.ManuallyManaged IS NOT NULL AND ChannelMembers.ManuallyManaged) AS ManuallyManaged",
 	).
 		From("ChannelMembers").
 		Join("Channels ON Channels.Id = ChannelMembers.ChannelId").
@@ -261,6 +261,16 @@ func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byt
 }
 
 func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, *model.AppError) {
+	return ps.get(pluginId, key, false)
+}
+
+// GetFromMaster is like Get, but reads the key from the master, for callers that update it with
+// CompareAndSet right after and can't wait for it to reach the replicas.
+func (ps SqlPluginStore) GetFromMaster(pluginId, key string) (*model.PluginKeyValue, *model.AppError) {
+	return ps.get(pluginId, key, true)
+}
+
+func (ps SqlPluginStore) get(pluginId, key string, master bool) (*model.PluginKeyValue, *model.AppError) {
 	currentTime := model.GetMillis()
 
 	failure := func(err error, statusCode int) *model.AppError {
@@ -282,7 +292,12 @@ func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, *mode
 	if err != nil {
 		return nil, failure(err, http.StatusInternalServerError)
 	}
-	row := ps.GetReplica().Db.QueryRow(queryString, args...)
+	db := ps.GetReplica()
+	if master {
+		db = ps.GetMaster()
+	}
+
+	row := db.Db.QueryRow(queryString, args...)
 	var kv model.PluginKeyValue
 	if err := row.Scan(&kv.PluginId, &kv.Key, &kv.Value, &kv.ExpireAt); err != nil {
 		if err == sql.ErrNoRows {
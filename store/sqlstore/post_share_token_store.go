@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+
+	"github.com/pkg/errors"
+)
+
+type SqlPostShareTokenStore struct {
+	SqlStore
+}
+
+func newSqlPostShareTokenStore(sqlStore SqlStore) store.PostShareTokenStore {
+	s := &SqlPostShareTokenStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostShareToken{}, "PostShareTokens").SetKeys(false, "Token")
+		table.ColMap("Token").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("CreatorId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPostShareTokenStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_postsharetokens_channel_id", "PostShareTokens", "ChannelId")
+	s.CreateIndexIfNotExists("idx_postsharetokens_expires_at", "PostShareTokens", "ExpiresAt")
+}
+
+func (s SqlPostShareTokenStore) Save(token *model.PostShareToken) (*model.PostShareToken, error) {
+	token.PreSave()
+	if err := token.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(token); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PostShareToken with post_id=%s", token.PostId)
+	}
+
+	return token, nil
+}
+
+// Get returns the given post share token, whether it expired or not. It reads from the master, so that
+// a token shared right after being created, or revoked, isn't missed or honoured because of replica lag.
+func (s SqlPostShareTokenStore) Get(token string) (*model.PostShareToken, error) {
+	var postShareToken model.PostShareToken
+	if err := s.GetMaster().SelectOne(&postShareToken, "SELECT * FROM PostShareTokens WHERE Token = :Token", map[string]interface{}{"Token": token}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostShareToken", token)
+		}
+		return nil, errors.Wrap(err, "failed to get PostShareToken")
+	}
+
+	return &postShareToken, nil
+}
+
+// DeleteForChannel revokes every post share token of the given channel, and returns the number of
+// tokens revoked.
+func (s SqlPostShareTokenStore) DeleteForChannel(channelId string) (int64, error) {
+	result, err := s.GetMaster().Exec("DELETE FROM PostShareTokens WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete PostShareTokens with channel_id=%s", channelId)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get rows affected for PostShareTokens with channel_id=%s", channelId)
+	}
+
+	return rowsAffected, nil
+}
+
+// DeleteExpired deletes the post share tokens that expired before the given time.
+func (s SqlPostShareTokenStore) DeleteExpired(expiryTime int64) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostShareTokens WHERE ExpiresAt < :ExpiryTime", map[string]interface{}{"ExpiryTime": expiryTime}); err != nil {
+		return errors.Wrap(err, "failed to delete expired PostShareTokens")
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/pkg/errors"
 )
 
 type SqlPostStore struct {
@@ -78,6 +79,10 @@ func newSqlPostStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface) st
 		table.ColMap("Props").SetMaxSize(8000)
 		table.ColMap("Filenames").SetMaxSize(model.POST_FILENAMES_MAX_RUNES)
 		table.ColMap("FileIds").SetMaxSize(150)
+
+		tableEdits := db.AddTableWithName(model.PostEdit{}, "PostEdits").SetKeys(false, "PostId", "EditAt")
+		tableEdits.ColMap("PostId").SetMaxSize(26)
+		tableEdits.ColMap("Content").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
 	}
 
 	return s
@@ -412,15 +417,7 @@ func (s *SqlPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, *
 	pl.AddPost(&post)
 	pl.AddOrder(id)
 	if !skipFetchThreads {
-		rootId := post.RootId
-
-		if rootId == "" {
-			rootId = post.Id
-		}
-
-		if len(rootId) == 0 {
-			return nil, model.NewAppError("SqlPostStore.GetPost", "store.sql_post.get.app_error", nil, "root_id="+rootId, http.StatusInternalServerError)
-		}
+		rootId := post.GetRootId()
 
 		var posts []*model.Post
 		_, err = s.GetReplica().Select(&posts, "SELECT *, (SELECT count(Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) as ReplyCount FROM Posts p WHERE (Id = :Id OR RootId = :RootId) AND DeleteAt = 0", map[string]interface{}{"Id": rootId, "RootId": rootId})
@@ -1134,21 +1131,87 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 }
 
 func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, *model.AppError) {
-	queryParams := map[string]interface{}{
-		"TeamId": teamId,
-		"UserId": userId,
-	}
-
 	list := model.NewPostList()
-	if params.Terms == "" && params.ExcludedTerms == "" &&
-		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
-		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+	if !hasSearchCriteria(params) {
 		return list, nil
 	}
 
 	var posts []*model.Post
 
+	searchQuery, queryParams := s.buildSearchQuery(teamId, userId, params, channelsByName, userByUsername, false)
+
+	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
+	if err != nil {
+		mlog.Warn("Query error searching posts.", mlog.Err(err))
+		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
+	} else {
+		for _, p := range posts {
+			list.AddPost(p)
+			list.AddOrder(p.Id)
+		}
+	}
+	list.MakeNonNil()
+	return list, nil
+}
+
+// searchParamPattern matches the named parameters of a search query.
+var searchParamPattern = regexp.MustCompile(`:\w+`)
+
+// searchCount returns the number of distinct posts matching any of the searches, without the limit
+// on the number of results returned by search.
+func (s *SqlPostStore) searchCount(teamId string, userId string, paramsList []*model.SearchParams, channelsByName bool, userByUsername bool) int64 {
+	queries := []string{}
+	queryParams := map[string]interface{}{}
+	for i, params := range paramsList {
+		if !hasSearchCriteria(params) {
+			continue
+		}
+
+		query, searchParams := s.buildSearchQuery(teamId, userId, params, channelsByName, userByUsername, true)
+
+		// Each search names its parameters alike, so they are prefixed to be combined in one query.
+		prefix := fmt.Sprintf("Search%d", i)
+		query = searchParamPattern.ReplaceAllStringFunc(query, func(name string) string {
+			if _, ok := searchParams[name[1:]]; ok {
+				return ":" + prefix + name[1:]
+			}
+			return name
+		})
+		for name, value := range searchParams {
+			queryParams[prefix+name] = value
+		}
+
+		queries = append(queries, query)
+	}
+
+	if len(queries) == 0 {
+		return 0
+	}
+
+	count, err := s.GetSearchReplica().SelectInt("SELECT COUNT(*) FROM ("+strings.Join(queries, " UNION ")+") AS Matches", queryParams)
+	if err != nil {
+		mlog.Warn("Query error counting searched posts.", mlog.Err(err))
+		return 0
+	}
+
+	return count
+}
+
+func hasSearchCriteria(params *model.SearchParams) bool {
+	return params.Terms != "" || params.ExcludedTerms != "" ||
+		len(params.InChannels) != 0 || len(params.ExcludedChannels) != 0 ||
+		len(params.FromUsers) != 0 || len(params.ExcludedUsers) != 0 ||
+		len(params.OnDate) != 0 || len(params.AfterDate) != 0 || len(params.BeforeDate) != 0
+}
+
+// buildSearchQuery returns the query selecting the posts matching the search, or only their ids to
+// count them, along with its parameters.
+func (s *SqlPostStore) buildSearchQuery(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool, idsOnly bool) (string, map[string]interface{}) {
+	queryParams := map[string]interface{}{
+		"TeamId": teamId,
+		"UserId": userId,
+	}
+
 	deletedQueryPart := "AND DeleteAt = 0"
 	if params.IncludeDeletedChannels {
 		deletedQueryPart = ""
@@ -1159,9 +1222,17 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		userIdPart = ""
 	}
 
+	selectPart := "* ,(SELECT COUNT(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN q2.RootId = '' THEN q2.Id ELSE q2.RootId END) AND Posts.DeleteAt = 0) as ReplyCount"
+	orderPart := `ORDER BY CreateAt DESC
+			LIMIT 100`
+	if idsOnly {
+		selectPart = "Id"
+		orderPart = ""
+	}
+
 	searchQuery := `
 			SELECT
-				* ,(SELECT COUNT(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN q2.RootId = '' THEN q2.Id ELSE q2.RootId END) AND Posts.DeleteAt = 0) as ReplyCount
+				` + selectPart + `
 			FROM
 				Posts q2
 			WHERE
@@ -1183,8 +1254,8 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 							EXCLUDED_CHANNEL_FILTER)
 				CREATEDATE_CLAUSE
 				SEARCH_CLAUSE
-				ORDER BY CreateAt DESC
-			LIMIT 100`
+				HASHTAG_CLAUSE
+				` + orderPart
 
 	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams, channelsByName)
 	searchQuery = strings.Replace(searchQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
@@ -1198,17 +1269,27 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	createDateFilterClause, queryParams := s.buildCreateDateFilterClause(params, queryParams)
 	searchQuery = strings.Replace(searchQuery, "CREATEDATE_CLAUSE", createDateFilterClause, 1)
 
-	termMap := map[string]bool{}
 	terms := params.Terms
 	excludedTerms := params.ExcludedTerms
 
 	searchType := "Message"
+	hashtagClause := ""
 	if params.IsHashtag {
 		searchType = "Hashtags"
-		for _, term := range strings.Split(terms, " ") {
-			termMap[strings.ToUpper(term)] = true
+
+		// The full text search also matches hashtags that only contain a term, so one of the
+		// hashtags must be a term exactly.
+		hashtagConditions := []string{}
+		for i, term := range strings.Fields(params.Terms) {
+			paramName := fmt.Sprintf("Hashtag%d", i)
+			queryParams[paramName] = "% " + sanitizeSearchTerm(strings.ToLower(term), "\\") + " %"
+			hashtagConditions = append(hashtagConditions, "LOWER(CONCAT(' ', Hashtags, ' ')) LIKE :"+paramName)
+		}
+		if len(hashtagConditions) > 0 {
+			hashtagClause = "AND (" + strings.Join(hashtagConditions, " OR ") + ")"
 		}
 	}
+	searchQuery = strings.Replace(searchQuery, "HASHTAG_CLAUSE", hashtagClause, 1)
 
 	// these chars have special meaning and can be treated as spaces
 	for _, c := range specialSearchChar {
@@ -1259,30 +1340,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		}
 	}
 
-	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
-	if err != nil {
-		mlog.Warn("Query error searching posts.", mlog.Err(err))
-		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
-	} else {
-		for _, p := range posts {
-			if searchType == "Hashtags" {
-				exactMatch := false
-				for _, tag := range strings.Split(p.Hashtags, " ") {
-					if termMap[strings.ToUpper(tag)] {
-						exactMatch = true
-						break
-					}
-				}
-				if !exactMatch {
-					continue
-				}
-			}
-			list.AddPost(p)
-			list.AddOrder(p.Id)
-		}
-	}
-	list.MakeNonNil()
-	return list, nil
+	return searchQuery, queryParams
 }
 
 func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
@@ -1513,6 +1571,28 @@ func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64,
 	return rowsAffected, nil
 }
 
+// PermanentDeleteEditOriginalsBatch deletes up to limit of the originals kept when posts are
+// edited, for the edits made before endTime. The posts themselves are left untouched.
+func (s *SqlPostStore) PermanentDeleteEditOriginalsBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var query string
+	if s.DriverName() == "postgres" {
+		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE OriginalId != '' AND DeleteAt != 0 AND DeleteAt < :EndTime LIMIT :Limit))"
+	} else {
+		query = "DELETE from Posts WHERE OriginalId != '' AND DeleteAt != 0 AND DeleteAt < :EndTime LIMIT :Limit"
+	}
+
+	sqlResult, err := s.GetMaster().Exec(query, map[string]interface{}{"EndTime": endTime, "Limit": limit})
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.PermanentDeleteEditOriginalsBatch", "store.sql_post.permanent_delete_edit_originals_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.PermanentDeleteEditOriginalsBatch", "store.sql_post.permanent_delete_edit_originals_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return rowsAffected, nil
+}
+
 func (s *SqlPostStore) GetOldest() (*model.Post, *model.AppError) {
 	var post model.Post
 	err := s.GetReplica().SelectOne(&post, "SELECT * FROM Posts ORDER BY CreateAt LIMIT 1")
@@ -1659,11 +1739,11 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 				Posts.RootId = :RootId
 				AND Posts.DeleteAt = 0
 			ORDER BY
-				Posts.Id`,
+				Posts.CreateAt, Posts.Id`,
 		map[string]interface{}{"RootId": rootId})
 
 	if err != nil {
-		return nil, model.NewAppError("SqlPostStore.GetAllAfterForExport", "store.sql_post.get_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return nil, model.NewAppError("SqlPostStore.GetRepliesForExport", "store.sql_post.get_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
 	return posts, nil
@@ -1740,15 +1820,7 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 }
 
 func (s *SqlPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError) {
-	// Since we don't support paging for DB search, we just return nothing for later pages
-	if page > 0 {
-		return model.MakePostSearchResults(model.NewPostList(), nil), nil
-	}
-
-	var wg sync.WaitGroup
-
-	pchan := make(chan store.StoreResult, len(paramsList))
-
+	searches := []*model.SearchParams{}
 	for _, params := range paramsList {
 		// Don't allow users to search for everything.
 		if params.Terms == "*" {
@@ -1757,7 +1829,24 @@ func (s *SqlPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams
 
 		params.IncludeDeletedChannels = includeDeletedChannels
 		params.OrTerms = isOrSearch
+		searches = append(searches, params)
+	}
+
+	total := s.searchCount(teamId, userId, searches, false, false)
+
+	// Since we don't support paging for DB search, we just return nothing for later pages
+	if page > 0 {
+		results := model.MakePostSearchResults(model.NewPostList(), nil)
+		results.Total = total
 
+		return results, nil
+	}
+
+	var wg sync.WaitGroup
+
+	pchan := make(chan store.StoreResult, len(searches))
+
+	for _, params := range searches {
 		wg.Add(1)
 
 		go func(params *model.SearchParams) {
@@ -1782,7 +1871,10 @@ func (s *SqlPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams
 
 	posts.SortByCreateAt()
 
-	return model.MakePostSearchResults(posts, nil), nil
+	results := model.MakePostSearchResults(posts, nil)
+	results.Total = total
+
+	return results, nil
 }
 
 func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, *model.AppError) {
@@ -1807,3 +1899,38 @@ func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, *model.AppError) {
 	}
 	return oldest, nil
 }
+
+// SaveEditHistory keeps the content the given post had before being edited.
+func (s *SqlPostStore) SaveEditHistory(original *model.Post) error {
+	postEdit := &model.PostEdit{
+		PostId:  original.Id,
+		Content: original.Message,
+		EditAt:  model.GetMillis(),
+	}
+
+	if err := s.GetMaster().Insert(postEdit); err != nil {
+		return errors.Wrapf(err, "failed to save PostEdit with post_id=%s", original.Id)
+	}
+
+	return nil
+}
+
+// GetEditHistory returns the previous contents of the given post, oldest first.
+func (s *SqlPostStore) GetEditHistory(postId string) ([]*model.PostEdit, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("PostEdits").
+		Where(sq.Eq{"PostId": postId}).
+		OrderBy("EditAt ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_edits_tosql")
+	}
+
+	postEdits := []*model.PostEdit{}
+	if _, err := s.GetReplica().Select(&postEdits, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PostEdits with post_id=%s", postId)
+	}
+
+	return postEdits, nil
+}
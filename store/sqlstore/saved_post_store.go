@@ -0,0 +1,283 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type SqlSavedPostStore struct {
+	SqlStore
+}
+
+func newSqlSavedPostStore(sqlStore SqlStore) store.SavedPostStore {
+	s := &SqlSavedPostStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.SavedPost{}, "SavedPosts").SetKeys(false, "UserId", "PostId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("Label").SetMaxSize(64)
+		table.ColMap("Note").SetMaxSize(1024)
+	}
+
+	return s
+}
+
+func (s SqlSavedPostStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_savedposts_post_id", "SavedPosts", "PostId")
+	s.CreateCompositeIndexIfNotExists("idx_savedposts_user_id_label", "SavedPosts", []string{"UserId", "Label"})
+}
+
+func (s SqlSavedPostStore) Save(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	savedPost.PreSave()
+	if err := savedPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(savedPost); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "savedposts_pkey"}) {
+			return nil, store.NewErrConflict("SavedPost", err, "user_id="+savedPost.UserId+", post_id="+savedPost.PostId)
+		}
+		return nil, errors.Wrapf(err, "failed to save SavedPost with user_id=%s and post_id=%s", savedPost.UserId, savedPost.PostId)
+	}
+
+	return savedPost, nil
+}
+
+func (s SqlSavedPostStore) Update(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	if err := savedPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(savedPost)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update SavedPost with user_id=%s and post_id=%s", savedPost.UserId, savedPost.PostId)
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("SavedPost", savedPost.PostId)
+	}
+
+	return savedPost, nil
+}
+
+func (s SqlSavedPostStore) Get(userId, postId string) (*model.SavedPost, error) {
+	var savedPost model.SavedPost
+	if err := s.GetReplica().SelectOne(&savedPost, "SELECT * FROM SavedPosts WHERE UserId = :UserId AND PostId = :PostId", map[string]interface{}{"UserId": userId, "PostId": postId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SavedPost", postId)
+		}
+		return nil, errors.Wrapf(err, "failed to get SavedPost with user_id=%s and post_id=%s", userId, postId)
+	}
+
+	return &savedPost, nil
+}
+
+// GetForUser returns a page of the posts saved by the given user, most recently saved first. When
+// a label is given, only the posts saved under that label are returned, and an empty label returns
+// the posts saved without one.
+func (s SqlSavedPostStore) GetForUser(userId string, label *string, offset, limit int) ([]*model.SavedPost, error) {
+	builder := s.getQueryBuilder().
+		Select("*").
+		From("SavedPosts").
+		Where(sq.Eq{"UserId": userId})
+
+	if label != nil {
+		builder = builder.Where(sq.Eq{"Label": *label})
+	}
+
+	query, args, err := builder.
+		OrderBy("SavedAt DESC", "PostId ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_posts_tosql")
+	}
+
+	savedPosts := []*model.SavedPost{}
+	if _, err := s.GetReplica().Select(&savedPosts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedPosts with user_id=%s", userId)
+	}
+
+	return savedPosts, nil
+}
+
+// GetLabelsForUser returns the distinct labels the given user saved posts under, in alphabetical
+// order.
+func (s SqlSavedPostStore) GetLabelsForUser(userId string) ([]string, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("DISTINCT Label").
+		From("SavedPosts").
+		Where(sq.Eq{"UserId": userId}).
+		Where(sq.NotEq{"Label": ""}).
+		OrderBy("Label ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_posts_tosql")
+	}
+
+	labels := []string{}
+	if _, err := s.GetReplica().Select(&labels, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedPost labels with user_id=%s", userId)
+	}
+
+	return labels, nil
+}
+
+// GetForPosts returns the posts saved by the given user among the given posts, keyed by post id.
+// Posts the user didn't save have no entry.
+func (s SqlSavedPostStore) GetForPosts(userId string, postIds []string) (map[string]*model.SavedPost, error) {
+	savedPostsByPostId := make(map[string]*model.SavedPost)
+	if len(postIds) == 0 {
+		return savedPostsByPostId, nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("SavedPosts").
+		Where(sq.Eq{"UserId": userId, "PostId": postIds}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_posts_tosql")
+	}
+
+	var savedPosts []*model.SavedPost
+	if _, err := s.GetReplica().Select(&savedPosts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedPosts with user_id=%s", userId)
+	}
+
+	for _, savedPost := range savedPosts {
+		savedPostsByPostId[savedPost.PostId] = savedPost
+	}
+
+	return savedPostsByPostId, nil
+}
+
+// GetForPost returns every save of the given post, in the order the post was saved.
+func (s SqlSavedPostStore) GetForPost(postId string) ([]*model.SavedPost, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("SavedPosts").
+		Where(sq.Eq{"PostId": postId}).
+		OrderBy("SavedAt ASC", "UserId ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_posts_tosql")
+	}
+
+	savedPosts := []*model.SavedPost{}
+	if _, err := s.GetReplica().Select(&savedPosts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedPosts with post_id=%s", postId)
+	}
+
+	return savedPosts, nil
+}
+
+func (s SqlSavedPostStore) Delete(userId, postId string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE UserId = :UserId AND PostId = :PostId", map[string]interface{}{"UserId": userId, "PostId": postId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete SavedPost with user_id=%s and post_id=%s", userId, postId)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected for SavedPost with user_id=%s and post_id=%s", userId, postId)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("SavedPost", postId)
+	}
+
+	return nil
+}
+
+func (s SqlSavedPostStore) DeleteForPost(postId string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE PostId = :PostId", map[string]interface{}{"PostId": postId}); err != nil {
+		return errors.Wrapf(err, "failed to delete SavedPosts with post_id=%s", postId)
+	}
+
+	return nil
+}
+
+func (s SqlSavedPostStore) PermanentDeleteByUser(userId string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return errors.Wrapf(err, "failed to delete SavedPosts with user_id=%s", userId)
+	}
+
+	return nil
+}
+
+// MigrateFlaggedPosts saves the posts flagged through the next flagged post preferences after the
+// given user and post ids that aren't saved yet, without a label or a note. At most limit
+// preferences are read, in the order of their user and post ids. Since the preferences don't record
+// when posts were flagged, posts are considered saved when they were created, which keeps them in
+// the order the flagged posts were listed in. It returns the number of posts saved and the user and
+// post ids of the last preference read, to continue from, which are empty once all were read.
+func (s SqlSavedPostStore) MigrateFlaggedPosts(afterUserId, afterPostId string, limit int) (int64, string, string, error) {
+	var preferences []*model.Preference
+	if _, err := s.GetMaster().Select(&preferences, `
+		SELECT
+			UserId, Name
+		FROM
+			Preferences
+		WHERE
+			Category = :Category
+			AND (UserId > :AfterUserId OR (UserId = :AfterUserId AND Name > :AfterPostId))
+		ORDER BY
+			UserId, Name
+		LIMIT :Limit`, map[string]interface{}{
+		"Category":    model.PREFERENCE_CATEGORY_FLAGGED_POST,
+		"AfterUserId": afterUserId,
+		"AfterPostId": afterPostId,
+		"Limit":       limit,
+	}); err != nil {
+		return 0, "", "", errors.Wrap(err, "failed to get flagged post preferences")
+	}
+
+	if len(preferences) == 0 {
+		return 0, "", "", nil
+	}
+	last := preferences[len(preferences)-1]
+
+	result, err := s.GetMaster().Exec(`
+		INSERT INTO SavedPosts
+			(UserId, PostId, Label, Note, SavedAt)
+		SELECT
+			Preferences.UserId, Posts.Id, '', '', Posts.CreateAt
+		FROM
+			Preferences
+			INNER JOIN Posts ON Posts.Id = Preferences.Name
+		WHERE
+			Preferences.Category = :Category
+			AND (Preferences.UserId > :AfterUserId OR (Preferences.UserId = :AfterUserId AND Preferences.Name > :AfterPostId))
+			AND (Preferences.UserId < :LastUserId OR (Preferences.UserId = :LastUserId AND Preferences.Name <= :LastPostId))
+			AND Preferences.Value = 'true'
+			AND Posts.DeleteAt = 0
+			AND NOT EXISTS (
+				SELECT 1 FROM SavedPosts
+				WHERE SavedPosts.UserId = Preferences.UserId AND SavedPosts.PostId = Posts.Id
+			)`, map[string]interface{}{
+		"Category":    model.PREFERENCE_CATEGORY_FLAGGED_POST,
+		"AfterUserId": afterUserId,
+		"AfterPostId": afterPostId,
+		"LastUserId":  last.UserId,
+		"LastPostId":  last.Name,
+	})
+	if err != nil {
+		return 0, "", "", errors.Wrap(err, "failed to migrate flagged posts to SavedPosts")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, "", "", errors.Wrap(err, "failed to get rows affected for migrated flagged posts")
+	}
+
+	return rowsAffected, last.UserId, last.Name, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+type SqlSavedSearchStore struct {
+	SqlStore
+}
+
+func newSqlSavedSearchStore(sqlStore SqlStore) store.SavedSearchStore {
+	s := &SqlSavedSearchStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.SavedSearch{}, "SavedSearches").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("Name").SetMaxSize(64)
+		table.ColMap("Terms").SetMaxSize(512)
+	}
+
+	return s
+}
+
+func (s SqlSavedSearchStore) createIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_savedsearches_user_id", "SavedSearches", "UserId")
+	s.CreateCompositeIndexIfNotExists("idx_savedsearches_notify_evaluated_at", "SavedSearches", []string{"NotifyOnNewMatch", "LastEvaluatedAt"})
+}
+
+func (s SqlSavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	if savedSearch.Id != "" {
+		return nil, store.NewErrInvalidInput("SavedSearch", "Id", savedSearch.Id)
+	}
+
+	savedSearch.PreSave()
+	if err := savedSearch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(savedSearch); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SavedSearch with id=%s", savedSearch.Id)
+	}
+
+	return savedSearch, nil
+}
+
+func (s SqlSavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	savedSearch.PreUpdate()
+	if err := savedSearch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.GetMaster().Update(savedSearch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update SavedSearch with id=%s", savedSearch.Id)
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("SavedSearch", savedSearch.Id)
+	}
+
+	return savedSearch, nil
+}
+
+func (s SqlSavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	var savedSearch model.SavedSearch
+	if err := s.GetReplica().SelectOne(&savedSearch, "SELECT * FROM SavedSearches WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SavedSearch", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get SavedSearch with id=%s", id)
+	}
+
+	return &savedSearch, nil
+}
+
+func (s SqlSavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("SavedSearches").
+		Where(sq.Eq{"UserId": userId}).
+		OrderBy("Name ASC", "Id ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_searches_tosql")
+	}
+
+	savedSearches := []*model.SavedSearch{}
+	if _, err := s.GetReplica().Select(&savedSearches, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find SavedSearches with user_id=%s", userId)
+	}
+
+	return savedSearches, nil
+}
+
+// GetToEvaluate returns the saved searches notifying of new matches that were last evaluated
+// before the given time, least recently evaluated first.
+func (s SqlSavedSearchStore) GetToEvaluate(evaluatedBefore int64, limit int) ([]*model.SavedSearch, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("SavedSearches").
+		Where(sq.Eq{"NotifyOnNewMatch": true}).
+		Where(sq.Lt{"LastEvaluatedAt": evaluatedBefore}).
+		OrderBy("LastEvaluatedAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "saved_searches_tosql")
+	}
+
+	savedSearches := []*model.SavedSearch{}
+	if _, err := s.GetMaster().Select(&savedSearches, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find SavedSearches to evaluate")
+	}
+
+	return savedSearches, nil
+}
+
+func (s SqlSavedSearchStore) UpdateLastEvaluatedAt(id string, lastEvaluatedAt int64) error {
+	result, err := s.GetMaster().Exec("UPDATE SavedSearches SET LastEvaluatedAt = :LastEvaluatedAt WHERE Id = :Id", map[string]interface{}{"LastEvaluatedAt": lastEvaluatedAt, "Id": id})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update last evaluation of SavedSearch with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected for SavedSearch with id=%s", id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("SavedSearch", id)
+	}
+
+	return nil
+}
+
+func (s SqlSavedSearchStore) Delete(id string) error {
+	result, err := s.GetMaster().Exec("DELETE FROM SavedSearches WHERE Id = :Id", map[string]interface{}{"Id": id})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete SavedSearch with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected for SavedSearch with id=%s", id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("SavedSearch", id)
+	}
+
+	return nil
+}
+
+func (s SqlSavedSearchStore) PermanentDeleteByUser(userId string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedSearches WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return errors.Wrapf(err, "failed to delete SavedSearches with user_id=%s", userId)
+	}
+
+	return nil
+}
@@ -343,6 +343,42 @@ func (s *SqlSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*mod
 	return schemes, nil
 }
 
+// GetChannelPolicies returns the channels that have a scheme of their own, along with that scheme,
+// sorted by the display names of their team and then their own.
+func (s *SqlSchemeStore) GetChannelPolicies(offset, limit int) ([]*model.ChannelPolicyWithChannelInfo, error) {
+	var policies []*model.ChannelPolicyWithChannelInfo
+
+	query := `
+		SELECT
+			Channels.Id AS ChannelId,
+			Channels.Name AS ChannelName,
+			Channels.DisplayName AS ChannelDisplayName,
+			Channels.Type AS ChannelType,
+			Teams.Id AS TeamId,
+			Teams.Name AS TeamName,
+			Teams.DisplayName AS TeamDisplayName,
+			Schemes.Id AS SchemeId,
+			Schemes.Name AS SchemeName,
+			Schemes.DisplayName AS SchemeDisplayName,
+			Schemes.DefaultChannelAdminRole,
+			Schemes.DefaultChannelUserRole,
+			Schemes.DefaultChannelGuestRole
+		FROM Channels
+			JOIN Schemes ON Schemes.Id = Channels.SchemeId
+			JOIN Teams ON Teams.Id = Channels.TeamId
+		WHERE
+			Channels.DeleteAt = 0 AND
+			Schemes.DeleteAt = 0
+		ORDER BY Teams.DisplayName, Channels.DisplayName, Channels.Id
+		LIMIT :Limit OFFSET :Offset`
+
+	if _, err := s.GetReplica().Select(&policies, query, map[string]interface{}{"Limit": limit, "Offset": offset}); err != nil {
+		return nil, errors.Wrap(err, "failed to get channel policies")
+	}
+
+	return policies, nil
+}
+
 func (s *SqlSchemeStore) PermanentDeleteAll() error {
 	if _, err := s.GetMaster().Exec("DELETE from Schemes"); err != nil {
 		return errors.Wrap(err, "failed to delete Schemes")
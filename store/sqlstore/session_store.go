@@ -276,6 +276,28 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, error) {
 	return count, nil
 }
 
+// CountActiveWebSessionsCreatedBefore counts the unexpired sessions created before the given time,
+// leaving out the sessions of mobile devices and OAuth logins, which have their own session length.
+func (me SqlSessionStore) CountActiveWebSessionsCreatedBefore(createdBefore int64) (int64, error) {
+	query, args, err := me.getQueryBuilder().
+		Select("COUNT(*)").
+		From("Sessions").
+		Where(sq.Gt{"ExpiresAt": model.GetMillis()}).
+		Where(sq.Lt{"CreateAt": createdBefore}).
+		Where(sq.Eq{"DeviceId": ""}).
+		Where(sq.Eq{"IsOAuth": false}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "sessions_tosql")
+	}
+
+	count, err := me.GetReplica().SelectInt(query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count Sessions")
+	}
+	return count, nil
+}
+
 func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	mlog.Debug("Cleaning up session store.")
 
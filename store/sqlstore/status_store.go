@@ -74,40 +74,54 @@ func (s SqlStatusStore) Get(userId string) (*model.Status, *model.AppError) {
 }
 
 func (s SqlStatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppError) {
+	query := s.getQueryBuilder().
+		Select("UserId, Status, Manual, LastActivityAt").
+		From("Status").
+		Where(sq.Eq{"UserId": userIds})
 
-	failure := func(err error) *model.AppError {
-		return model.NewAppError(
-			"SqlStatusStore.GetByIds",
-			"store.sql_status.get.app_error",
-			nil,
-			err.Error(),
-			http.StatusInternalServerError,
-		)
+	statuses, err := s.selectStatuses(query)
+	if err != nil {
+		return nil, model.NewAppError("SqlStatusStore.GetByIds", "store.sql_status.get.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	return statuses, nil
+}
+
+func (s SqlStatusStore) GetByTeam(teamId string) ([]*model.Status, *model.AppError) {
 	query := s.getQueryBuilder().
-		Select("UserId, Status, Manual, LastActivityAt").
+		Select("Status.UserId, Status.Status, Status.Manual, Status.LastActivityAt").
 		From("Status").
-		Where(sq.Eq{"UserId": userIds})
+		Join("TeamMembers ON TeamMembers.UserId = Status.UserId").
+		Where(sq.Eq{"TeamMembers.TeamId": teamId, "TeamMembers.DeleteAt": 0})
+
+	statuses, err := s.selectStatuses(query)
+	if err != nil {
+		return nil, model.NewAppError("SqlStatusStore.GetByTeam", "store.sql_status.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return statuses, nil
+}
+
+func (s SqlStatusStore) selectStatuses(query sq.SelectBuilder) ([]*model.Status, error) {
 	queryString, args, err := query.ToSql()
 	if err != nil {
-		return nil, failure(err)
+		return nil, err
 	}
 	rows, err := s.GetReplica().Db.Query(queryString, args...)
 	if err != nil {
-		return nil, failure(err)
+		return nil, err
 	}
 	var statuses []*model.Status
 	defer rows.Close()
 	for rows.Next() {
 		var status model.Status
 		if err = rows.Scan(&status.UserId, &status.Status, &status.Manual, &status.LastActivityAt); err != nil {
-			return nil, failure(err)
+			return nil, err
 		}
 		statuses = append(statuses, &status)
 	}
 	if err = rows.Err(); err != nil {
-		return nil, failure(err)
+		return nil, err
 	}
 
 	return statuses, nil
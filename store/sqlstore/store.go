@@ -101,5 +101,10 @@ type SqlStore interface {
 	TermsOfService() store.TermsOfServiceStore
 	UserTermsOfService() store.UserTermsOfServiceStore
 	LinkMetadata() store.LinkMetadataStore
+	ChannelBookmark() store.ChannelBookmarkStore
+	SavedSearch() store.SavedSearchStore
+	SavedPost() store.SavedPostStore
+	PostShareToken() store.PostShareTokenStore
+	AdminNotification() store.AdminNotificationStore
 	getQueryBuilder() sq.StatementBuilderType
 }
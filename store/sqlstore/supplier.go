@@ -100,6 +100,11 @@ type SqlSupplierStores struct {
 	group                store.GroupStore
 	UserTermsOfService   store.UserTermsOfServiceStore
 	linkMetadata         store.LinkMetadataStore
+	channelBookmark      store.ChannelBookmarkStore
+	savedSearch          store.SavedSearchStore
+	savedPost            store.SavedPostStore
+	postShareToken       store.PostShareTokenStore
+	adminNotification    store.AdminNotificationStore
 }
 
 type SqlSupplier struct {
@@ -164,6 +169,11 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.TermsOfService = newSqlTermsOfServiceStore(supplier, metrics)
 	supplier.stores.UserTermsOfService = newSqlUserTermsOfServiceStore(supplier)
 	supplier.stores.linkMetadata = newSqlLinkMetadataStore(supplier)
+	supplier.stores.channelBookmark = newSqlChannelBookmarkStore(supplier)
+	supplier.stores.savedSearch = newSqlSavedSearchStore(supplier)
+	supplier.stores.savedPost = newSqlSavedPostStore(supplier)
+	supplier.stores.postShareToken = newSqlPostShareTokenStore(supplier)
+	supplier.stores.adminNotification = newSqlAdminNotificationStore(supplier)
 	supplier.stores.reaction = newSqlReactionStore(supplier)
 	supplier.stores.role = newSqlRoleStore(supplier)
 	supplier.stores.scheme = newSqlSchemeStore(supplier)
@@ -208,6 +218,11 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.TermsOfService.(SqlTermsOfServiceStore).createIndexesIfNotExists()
 	supplier.stores.UserTermsOfService.(SqlUserTermsOfServiceStore).createIndexesIfNotExists()
 	supplier.stores.linkMetadata.(*SqlLinkMetadataStore).createIndexesIfNotExists()
+	supplier.stores.channelBookmark.(*SqlChannelBookmarkStore).createIndexesIfNotExists()
+	supplier.stores.savedSearch.(*SqlSavedSearchStore).createIndexesIfNotExists()
+	supplier.stores.savedPost.(*SqlSavedPostStore).createIndexesIfNotExists()
+	supplier.stores.postShareToken.(*SqlPostShareTokenStore).createIndexesIfNotExists()
+	supplier.stores.adminNotification.(*SqlAdminNotificationStore).createIndexesIfNotExists()
 	supplier.stores.group.(*SqlGroupStore).createIndexesIfNotExists()
 	supplier.stores.scheme.(*SqlSchemeStore).createIndexesIfNotExists()
 	supplier.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
@@ -1172,6 +1187,26 @@ func (ss *SqlSupplier) LinkMetadata() store.LinkMetadataStore {
 	return ss.stores.linkMetadata
 }
 
+func (ss *SqlSupplier) ChannelBookmark() store.ChannelBookmarkStore {
+	return ss.stores.channelBookmark
+}
+
+func (ss *SqlSupplier) SavedSearch() store.SavedSearchStore {
+	return ss.stores.savedSearch
+}
+
+func (ss *SqlSupplier) SavedPost() store.SavedPostStore {
+	return ss.stores.savedPost
+}
+
+func (ss *SqlSupplier) PostShareToken() store.PostShareTokenStore {
+	return ss.stores.postShareToken
+}
+
+func (ss *SqlSupplier) AdminNotification() store.AdminNotificationStore {
+	return ss.stores.adminNotification
+}
+
 func (ss *SqlSupplier) DropAllTables() {
 	ss.master.TruncateTables()
 }
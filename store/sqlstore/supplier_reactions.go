@@ -114,6 +114,29 @@ func (s *SqlReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction,
 	return reactions, nil
 }
 
+// GetForPosts returns the reactions of the given posts in a single query, grouped by post id. Every
+// post has an entry, empty for the ones without reactions.
+func (s *SqlReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	reactionsByPostId := make(map[string][]*model.Reaction, len(postIds))
+	if len(postIds) == 0 {
+		return reactionsByPostId, nil
+	}
+
+	reactions, err := s.BulkGetForPosts(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, postId := range postIds {
+		reactionsByPostId[postId] = []*model.Reaction{}
+	}
+	for _, reaction := range reactions {
+		reactionsByPostId[reaction.PostId] = append(reactionsByPostId[reaction.PostId], reaction)
+	}
+
+	return reactionsByPostId, nil
+}
+
 func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	var reactions []*model.Reaction
 
@@ -11,6 +11,8 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/mattermost/mattermost-server/v5/utils"
@@ -212,6 +214,7 @@ func newSqlTeamStore(sqlStore SqlStore) store.TeamStore {
 		table.ColMap("Email").SetMaxSize(128)
 		table.ColMap("CompanyName").SetMaxSize(64)
 		table.ColMap("AllowedDomains").SetMaxSize(1000)
+		table.ColMap("AutoJoinDomains").SetMaxSize(1000)
 		table.ColMap("InviteId").SetMaxSize(32)
 
 		tablem := db.AddTableWithName(teamMember{}, "TeamMembers").SetKeys(false, "TeamId", "UserId")
@@ -494,6 +497,29 @@ func (s SqlTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, *model.A
 	return teams, nil
 }
 
+// GetAllWithAutoJoinDomains returns the teams that aren't deleted and have auto-join domains set.
+func (s SqlTeamStore) GetAllWithAutoJoinDomains() ([]*model.Team, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Teams").
+		Where(sq.And{
+			sq.NotEq{"AutoJoinDomains": ""},
+			sq.Eq{"DeleteAt": 0},
+		}).
+		OrderBy("Id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "auto_join_teams_tosql")
+	}
+
+	teams := []*model.Team{}
+	if _, err := s.GetReplica().Select(&teams, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find teams with auto-join domains")
+	}
+
+	return teams, nil
+}
+
 // GetTeamsByUserId returns from the database all teams that userId belongs to.
 func (s SqlTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *model.AppError) {
 	var teams []*model.Team
@@ -985,6 +1011,41 @@ func (s SqlTeamStore) GetActiveMemberCount(teamId string, restrictions *model.Vi
 	return count, nil
 }
 
+func (s SqlTeamStore) GetTeamStats(teamID string) (*model.TeamStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(DISTINCT TeamMembers.UserId)
+				FROM TeamMembers
+				INNER JOIN Users ON TeamMembers.UserId = Users.Id
+				WHERE TeamMembers.TeamId = :TeamId AND TeamMembers.DeleteAt = 0) AS TotalMemberCount,
+			(SELECT COUNT(DISTINCT TeamMembers.UserId)
+				FROM TeamMembers
+				INNER JOIN Users ON TeamMembers.UserId = Users.Id
+				WHERE TeamMembers.TeamId = :TeamId AND TeamMembers.DeleteAt = 0 AND Users.DeleteAt = 0) AS ActiveMemberCount,
+			(SELECT COUNT(Id)
+				FROM Channels
+				WHERE TeamId = :TeamId AND Type = :OpenType AND DeleteAt = 0) AS OpenChannelCount,
+			(SELECT COUNT(Id)
+				FROM Channels
+				WHERE TeamId = :TeamId AND Type = :PrivateType AND DeleteAt = 0) AS PrivateChannelCount,
+			(SELECT COUNT(Posts.Id)
+				FROM Posts
+				INNER JOIN Channels ON Posts.ChannelId = Channels.Id
+				WHERE Channels.TeamId = :TeamId AND Channels.DeleteAt = 0 AND Posts.DeleteAt = 0) AS PostCount`
+
+	var stats model.TeamStats
+	if err := s.GetReplica().SelectOne(&stats, query, map[string]interface{}{
+		"TeamId":      teamID,
+		"OpenType":    model.CHANNEL_OPEN,
+		"PrivateType": model.CHANNEL_PRIVATE,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the stats of team with id=%s", teamID)
+	}
+	stats.TeamId = teamID
+
+	return &stats, nil
+}
+
 func (s SqlTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
 	if len(userIds) == 0 {
 		return nil, model.NewAppError("SqlTeamStore.GetMembersByIds", "store.sql_team.get_members_by_ids.app_error", nil, "Invalid list of user ids", http.StatusInternalServerError)
@@ -831,4 +831,13 @@ func upgradeDatabaseToVersion527(sqlStore SqlStore) {
 
 	// 	saveSchemaVersion(sqlStore, VERSION_5_27_0)
 	// }
+
+	sqlStore.CreateColumnIfNotExistsNoDefault("Channels", "SuppressJoinLeaveMessages", "tinyint(1)", "boolean")
+	sqlStore.CreateColumnIfNotExistsNoDefault("Channels", "ExperimentalHideChannelFromPublicSearch", "tinyint(1)", "boolean")
+	sqlStore.CreateColumnIfNotExistsNoDefault("Channels", "ExcludeFromAutoArchive", "tinyint(1)", "boolean")
+	sqlStore.CreateColumnIfNotExistsNoDefault("Channels", "DisableFileAttachments", "tinyint(1)", "boolean")
+	sqlStore.CreateColumnIfNotExistsNoDefault("Teams", "InactiveChannelArchiveDays", "bigint", "bigint")
+	sqlStore.CreateColumnIfNotExists("Teams", "AutoJoinDomains", "varchar(1000)", "varchar(1000)", "")
+	sqlStore.CreateColumnIfNotExists("OutgoingWebhooks", "Timeout", "int", "integer", "0")
+	sqlStore.CreateColumnIfNotExistsNoDefault("ChannelMembers", "ManuallyManaged", "tinyint(1)", "boolean")
 }
@@ -108,6 +108,10 @@ func (us SqlUserStore) Save(user *model.User) (*model.User, *model.AppError) {
 		return nil, err
 	}
 
+	if err := us.checkUsernameAvailable(user.Username, "SqlUserStore.Save"); err != nil {
+		return nil, err
+	}
+
 	if err := us.GetMaster().Insert(user); err != nil {
 		if IsUniqueConstraintError(err, []string{"Email", "users_email_key", "idx_users_email_unique"}) {
 			return nil, model.NewAppError("SqlUserStore.Save", "store.sql_user.save.email_exists.app_error", nil, "user_id="+user.Id+", "+err.Error(), http.StatusBadRequest)
@@ -121,6 +125,21 @@ func (us SqlUserStore) Save(user *model.User) (*model.User, *model.AppError) {
 	return user, nil
 }
 
+// checkUsernameAvailable fails when the username is the name of an active custom group, since both are
+// mentioned the same way. LDAP groups are left out, so that the sync can't keep users from signing up.
+// Usernames shared with other users are caught by the unique constraint.
+func (us SqlUserStore) checkUsernameAvailable(username string, where string) *model.AppError {
+	count, err := us.GetMaster().SelectInt("SELECT COUNT(*) FROM UserGroups WHERE Name = :Name AND Source = :Source AND DeleteAt = 0", map[string]interface{}{"Name": username, "Source": model.GroupSourceCustom})
+	if err != nil {
+		return model.NewAppError(where, "store.select_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if count > 0 {
+		return model.NewAppError(where, "store.sql_user.username_conflicts_with_group.app_error", nil, "username="+username, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (us SqlUserStore) DeactivateGuests() ([]string, *model.AppError) {
 	curTime := model.GetMillis()
 	updateQuery := us.getQueryBuilder().Update("Users").
@@ -201,6 +220,10 @@ func (us SqlUserStore) Update(user *model.User, trustedUpdateData bool) (*model.
 	}
 
 	if user.Username != oldUser.Username {
+		if appErr := us.checkUsernameAvailable(user.Username, "SqlUserStore.Update"); appErr != nil {
+			return nil, appErr
+		}
+
 		user.UpdateMentionKeysFromUsername(oldUser.Username)
 	}
 
@@ -958,6 +981,10 @@ func (us SqlUserStore) GetProfileByIds(userIds []string, options *store.UserGetB
 		}))
 	}
 
+	if options.ExcludeDeleted {
+		query = query.Where("u.DeleteAt = 0")
+	}
+
 	query = applyViewRestrictionsFilter(query, options.ViewRestrictions, true)
 
 	queryString, args, err := query.ToSql()
@@ -969,6 +996,10 @@ func (us SqlUserStore) GetProfileByIds(userIds []string, options *store.UserGetB
 		return nil, model.NewAppError("SqlUserStore.GetProfileByIds", "store.sql_user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if options.OrderByIds {
+		return store.SortUsersByIds(users, userIds), nil
+	}
+
 	return users, nil
 }
 
@@ -1264,7 +1295,10 @@ func (us SqlUserStore) AnalyticsActiveCount(timePeriod int64, options model.User
 	return v, nil
 }
 
-func (us SqlUserStore) GetUnreadCount(userId string) (int64, *model.AppError) {
+// GetUnreadCount returns the number of unread direct messages plus mentions
+// in other channels for the user. When excludeMutedDirect is set, direct and
+// group channels the user has muted are left out of the count.
+func (us SqlUserStore) GetUnreadCount(userId string, excludeMutedDirect bool) (int64, *model.AppError) {
 	query := `
 		SELECT SUM(CASE WHEN c.Type = 'D' THEN (c.TotalMsgCount - cm.MsgCount) ELSE cm.MentionCount END)
 		FROM Channels c
@@ -1273,6 +1307,12 @@ func (us SqlUserStore) GetUnreadCount(userId string) (int64, *model.AppError) {
 			AND cm.UserId = :UserId
 			AND c.DeleteAt = 0
 	`
+	if excludeMutedDirect {
+		query += `
+		WHERE c.Type NOT IN ('D', 'G')
+			OR cm.NotifyProps NOT LIKE '%"` + model.MARK_UNREAD_NOTIFY_PROP + `":"` + model.CHANNEL_MARK_UNREAD_MENTION + `"%'
+		`
+	}
 	count, err := us.GetReplica().SelectInt(query, map[string]interface{}{"UserId": userId})
 	if err != nil {
 		return count, model.NewAppError("SqlUserStore.GetMentionCount", "store.sql_user.get_unread_count.app_error", nil, err.Error(), http.StatusInternalServerError)
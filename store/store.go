@@ -56,6 +56,11 @@ type Store interface {
 	Group() GroupStore
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
+	ChannelBookmark() ChannelBookmarkStore
+	SavedSearch() SavedSearchStore
+	SavedPost() SavedPostStore
+	PostShareToken() PostShareTokenStore
+	AdminNotification() AdminNotificationStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -84,6 +89,7 @@ type TeamStore interface {
 	SearchPrivate(term string) ([]*model.Team, *model.AppError)
 	GetAll() ([]*model.Team, *model.AppError)
 	GetAllPage(offset int, limit int) ([]*model.Team, *model.AppError)
+	GetAllWithAutoJoinDomains() ([]*model.Team, error)
 	GetAllPrivateTeamListing() ([]*model.Team, *model.AppError)
 	GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError)
 	GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError)
@@ -104,6 +110,10 @@ type TeamStore interface {
 	GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError)
 	GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError)
 	GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError)
+
+	// GetTeamStats returns the member, channel and post counts of the team in a single query. Deactivated
+	// users aren't counted as active members, and archived channels and their posts aren't counted.
+	GetTeamStats(teamID string) (*model.TeamStats, error)
 	GetTeamsForUser(userId string) ([]*model.TeamMember, *model.AppError)
 	GetTeamsForUserWithPagination(userId string, page, perPage int) ([]*model.TeamMember, *model.AppError)
 	GetChannelUnreadsForAllTeams(excludeTeamId, userId string) ([]*model.ChannelUnread, *model.AppError)
@@ -135,6 +145,7 @@ type TeamStore interface {
 
 type ChannelStore interface {
 	Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error)
+	SaveWithCreatorLimit(channel *model.Channel, maxChannelsPerTeam int64, maxChannelsPerCreator int64, createdSince int64) (*model.Channel, error)
 	CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error)
 	SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error)
 	Update(channel *model.Channel) (*model.Channel, error)
@@ -154,17 +165,24 @@ type ChannelStore interface {
 	GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error)
 	GetDeletedByName(team_id string, name string) (*model.Channel, error)
 	GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error)
+	// GetRecentlyDeleted returns the deleted channels of a team, including private ones, most recently deleted first.
+	GetRecentlyDeleted(teamId string, offset int, limit int) ([]*model.Channel, error)
+	// GetDeletedByTeamForUserSince returns the channels of a team deleted after the given time that the user is a member of.
+	GetDeletedByTeamForUserSince(teamId string, userId string, since int64) (model.ChannelList, error)
 	GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, error)
 	GetAllChannels(page, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, error)
 	GetAllChannelsCount(opts ChannelSearchOpts) (int64, error)
+	GetTeamChannelsWithActivity(teamId string, offset, limit int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, error)
 	GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error)
 	GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError)
-	GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError)
+	GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError)
 	GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError)
 	GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError)
 	GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError)
 	GetAll(teamId string) ([]*model.Channel, *model.AppError)
 	GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, *model.AppError)
+	GetRecentDirectChannels(userID string, limit int) ([]*model.Channel, error)
+	GetInactiveChannelsForTeam(teamID string, inactiveSince int64, warned bool, afterID string, limit int) ([]*model.Channel, error)
 	GetForPost(postId string) (*model.Channel, *model.AppError)
 	SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, *model.AppError)
 	SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError)
@@ -185,6 +203,7 @@ type ChannelStore interface {
 	GetMemberCountsByGroup(channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, *model.AppError)
 	InvalidatePinnedPostCount(channelId string)
 	GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError)
+	GetRootPostCountSince(channelId string, since int64) (int64, error)
 	InvalidateGuestCount(channelId string)
 	GetGuestCount(channelId string, allowFromCache bool) (int64, *model.AppError)
 	GetPinnedPosts(channelId string) (*model.PostList, *model.AppError)
@@ -210,6 +229,10 @@ type ChannelStore interface {
 	GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, *model.AppError)
 	AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, *model.AppError)
 	GetChannelUnread(channelId, userId string) (*model.ChannelUnread, *model.AppError)
+	// GetLastViewedPostForUser returns the id of the last post that the user saw in the channel,
+	// and when they last viewed the channel. Both are empty when the user never viewed the channel,
+	// and the post id is empty when there was no post yet at the time.
+	GetLastViewedPostForUser(userId, channelId string) (string, int64, error)
 	ClearCaches()
 	GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, *model.AppError)
 	MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError)
@@ -247,6 +270,7 @@ type ChannelMemberHistoryStore interface {
 	LogJoinEvent(userId string, channelId string, joinTime int64) error
 	LogLeaveEvent(userId string, channelId string, leaveTime int64) error
 	GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error)
+	GetForChannel(channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelMemberHistoryResult, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 }
 
@@ -283,6 +307,7 @@ type PostStore interface {
 	GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError)
 	GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
+	PermanentDeleteEditOriginalsBatch(endTime int64, limit int64) (int64, *model.AppError)
 	GetOldest() (*model.Post, *model.AppError)
 	GetMaxPostSize() int
 	GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError)
@@ -290,6 +315,8 @@ type PostStore interface {
 	GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError)
 	SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError)
 	GetOldestEntityCreationTime() (int64, *model.AppError)
+	SaveEditHistory(original *model.Post) error
+	GetEditHistory(postId string) ([]*model.PostEdit, error)
 }
 
 type UserStore interface {
@@ -331,7 +358,7 @@ type UserStore interface {
 	GetSystemAdminProfiles() (map[string]*model.User, *model.AppError)
 	PermanentDelete(userId string) *model.AppError
 	AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError)
-	GetUnreadCount(userId string) (int64, *model.AppError)
+	GetUnreadCount(userId string, excludeMutedDirect bool) (int64, *model.AppError)
 	GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError)
 	GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError)
 	GetRecentlyActiveUsersForTeam(teamId string, offset, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
@@ -385,6 +412,7 @@ type SessionStore interface {
 	UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error)
 	UpdateProps(session *model.Session) error
 	AnalyticsSessionCount() (int64, error)
+	CountActiveWebSessionsCreatedBefore(createdBefore int64) (int64, error)
 	Cleanup(expiryTime int64, batchSize int64)
 }
 
@@ -392,6 +420,7 @@ type AuditStore interface {
 	Save(audit *model.Audit) error
 	Get(user_id string, offset int, limit int) (model.Audits, error)
 	PermanentDeleteByUser(userId string) error
+	StreamToSyslog(addr string, protocol string, caCert []byte) error
 }
 
 type ClusterDiscoveryStore interface {
@@ -522,18 +551,25 @@ type TokenStore interface {
 
 type EmojiStore interface {
 	Save(emoji *model.Emoji) (*model.Emoji, error)
+	// SaveWithCreatorLimit saves the emoji unless its creator already has maxEmojiPerCreator custom emoji that
+	// haven't been deleted, returning an ErrLimitExceeded then. A negative maximum doesn't limit the creator.
+	SaveWithCreatorLimit(emoji *model.Emoji, maxEmojiPerCreator int64) (*model.Emoji, error)
 	Get(id string, allowFromCache bool) (*model.Emoji, error)
 	GetByName(name string, allowFromCache bool) (*model.Emoji, error)
 	GetMultipleByName(names []string) ([]*model.Emoji, error)
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, time int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
+	// CountByCreator returns the number of custom emoji created by a user that haven't been deleted.
+	CountByCreator(creatorId string) (int64, error)
 }
 
 type StatusStore interface {
 	SaveOrUpdate(status *model.Status) *model.AppError
 	Get(userId string) (*model.Status, *model.AppError)
 	GetByIds(userIds []string) ([]*model.Status, *model.AppError)
+	// GetByTeam returns the statuses of the active members of the team.
+	GetByTeam(teamId string) ([]*model.Status, *model.AppError)
 	ResetAll() *model.AppError
 	GetTotalActiveUsersCount() (int64, *model.AppError)
 	UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError
@@ -552,6 +588,7 @@ type FileInfoStore interface {
 	PermanentDelete(fileId string) *model.AppError
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
 	PermanentDeleteByUser(userId string) (int64, *model.AppError)
+	GetPostsWithFileExtensions(extensions []string, since int64, offset, limit int) ([]string, error)
 	ClearCaches()
 }
 
@@ -562,6 +599,7 @@ type ReactionStore interface {
 	DeleteAllWithEmojiName(emojiName string) error
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	BulkGetForPosts(postIds []string) ([]*model.Reaction, error)
+	GetForPosts(postIds []string) (map[string][]*model.Reaction, error)
 }
 
 type JobStore interface {
@@ -598,6 +636,7 @@ type PluginStore interface {
 	CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError)
 	SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
 	Get(pluginId, key string) (*model.PluginKeyValue, *model.AppError)
+	GetFromMaster(pluginId, key string) (*model.PluginKeyValue, *model.AppError)
 	Delete(pluginId, key string) *model.AppError
 	DeleteAllForPlugin(PluginId string) *model.AppError
 	DeleteAllExpired() *model.AppError
@@ -634,6 +673,7 @@ type SchemeStore interface {
 	PermanentDeleteAll() error
 	CountByScope(scope string) (int64, error)
 	CountWithoutPermission(scope, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error)
+	GetChannelPolicies(offset, limit int) ([]*model.ChannelPolicyWithChannelInfo, error)
 }
 
 type TermsOfServiceStore interface {
@@ -740,6 +780,56 @@ type LinkMetadataStore interface {
 	Get(url string, timestamp int64) (*model.LinkMetadata, error)
 }
 
+type ChannelBookmarkStore interface {
+	Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Get(id string, includeDeleted bool) (*model.ChannelBookmark, error)
+	GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error)
+	GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error)
+	UpdateSortOrder(channelId string, bookmarkIds []string) error
+	Delete(id string) error
+	PermanentDeleteByChannel(channelId string) error
+}
+
+type SavedSearchStore interface {
+	Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error)
+	Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error)
+	Get(id string) (*model.SavedSearch, error)
+	GetForUser(userId string) ([]*model.SavedSearch, error)
+	GetToEvaluate(evaluatedBefore int64, limit int) ([]*model.SavedSearch, error)
+	UpdateLastEvaluatedAt(id string, lastEvaluatedAt int64) error
+	Delete(id string) error
+	PermanentDeleteByUser(userId string) error
+}
+
+type SavedPostStore interface {
+	Save(savedPost *model.SavedPost) (*model.SavedPost, error)
+	Update(savedPost *model.SavedPost) (*model.SavedPost, error)
+	Get(userId, postId string) (*model.SavedPost, error)
+	GetForUser(userId string, label *string, offset, limit int) ([]*model.SavedPost, error)
+	GetLabelsForUser(userId string) ([]string, error)
+	GetForPosts(userId string, postIds []string) (map[string]*model.SavedPost, error)
+	GetForPost(postId string) ([]*model.SavedPost, error)
+	Delete(userId, postId string) error
+	DeleteForPost(postId string) error
+	PermanentDeleteByUser(userId string) error
+	MigrateFlaggedPosts(afterUserId, afterPostId string, limit int) (int64, string, string, error)
+}
+
+type PostShareTokenStore interface {
+	Save(token *model.PostShareToken) (*model.PostShareToken, error)
+	Get(token string) (*model.PostShareToken, error)
+	DeleteForChannel(channelId string) (int64, error)
+	DeleteExpired(expiryTime int64) error
+}
+
+type AdminNotificationStore interface {
+	Save(notification *model.AdminNotification) (*model.AdminNotification, error)
+	Update(notification *model.AdminNotification) (*model.AdminNotification, error)
+	GetLatest(notificationType, message string, since int64) (*model.AdminNotification, error)
+	GetAll(offset, limit int) ([]*model.AdminNotification, error)
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
@@ -770,6 +860,31 @@ type UserGetByIdsOpts struct {
 
 	// Since filters the users based on their UpdateAt timestamp.
 	Since int64
+
+	// ExcludeDeleted filters out deactivated users.
+	ExcludeDeleted bool
+
+	// OrderByIds returns the users in the order of the given ids, listing each user once.
+	OrderByIds bool
+}
+
+// SortUsersByIds returns the given users in the order of the given ids, leaving out users whose id
+// isn't listed and listing each user once.
+func SortUsersByIds(users []*model.User, userIds []string) []*model.User {
+	usersById := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		usersById[user.Id] = user
+	}
+
+	sorted := make([]*model.User, 0, len(users))
+	for _, userId := range userIds {
+		if user, ok := usersById[userId]; ok {
+			sorted = append(sorted, user)
+			delete(usersById, userId)
+		}
+	}
+
+	return sorted
 }
 
 type OrphanedRecord struct {
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminNotificationStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndUpdate", func(t *testing.T) { testAdminNotificationStoreSaveAndUpdate(t, ss) })
+	t.Run("GetLatest", func(t *testing.T) { testAdminNotificationStoreGetLatest(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testAdminNotificationStoreGetAll(t, ss) })
+}
+
+func testAdminNotificationStoreSaveAndUpdate(t *testing.T, ss store.Store) {
+	notification, err := ss.AdminNotification().Save(&model.AdminNotification{
+		Type:    model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED,
+		Message: "job failed " + model.NewId(),
+	})
+	require.Nil(t, err)
+	assert.NotEmpty(t, notification.Id)
+	assert.Equal(t, int64(1), notification.Count)
+
+	t.Run("should not save a notification with an id", func(t *testing.T) {
+		_, err := ss.AdminNotification().Save(&model.AdminNotification{
+			Id:      model.NewId(),
+			Type:    model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED,
+			Message: "job failed",
+		})
+		require.NotNil(t, err)
+	})
+
+	t.Run("should not save an invalid notification", func(t *testing.T) {
+		_, err := ss.AdminNotification().Save(&model.AdminNotification{
+			Type:    "unknown",
+			Message: "job failed",
+		})
+		require.NotNil(t, err)
+	})
+
+	t.Run("should update the count and post", func(t *testing.T) {
+		notification.Count++
+		notification.PostId = model.NewId()
+		updated, err := ss.AdminNotification().Update(notification)
+		require.Nil(t, err)
+		assert.Equal(t, int64(2), updated.Count)
+
+		latest, err := ss.AdminNotification().GetLatest(notification.Type, notification.Message, 0)
+		require.Nil(t, err)
+		assert.Equal(t, int64(2), latest.Count)
+		assert.Equal(t, notification.PostId, latest.PostId)
+	})
+
+	t.Run("should not update a missing notification", func(t *testing.T) {
+		missing := *notification
+		missing.Id = model.NewId()
+		_, err := ss.AdminNotification().Update(&missing)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testAdminNotificationStoreGetLatest(t *testing.T, ss store.Store) {
+	message := "plugin failed " + model.NewId()
+
+	first, err := ss.AdminNotification().Save(&model.AdminNotification{Type: model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, Message: message})
+	require.Nil(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	second, err := ss.AdminNotification().Save(&model.AdminNotification{Type: model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, Message: message})
+	require.Nil(t, err)
+
+	t.Run("should return the most recent matching notification", func(t *testing.T) {
+		latest, err := ss.AdminNotification().GetLatest(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message, first.UpdateAt)
+		require.Nil(t, err)
+		assert.Equal(t, second.Id, latest.Id)
+	})
+
+	t.Run("should not return older notifications", func(t *testing.T) {
+		_, err := ss.AdminNotification().GetLatest(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message, second.UpdateAt+1)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should match the type and message", func(t *testing.T) {
+		_, err := ss.AdminNotification().GetLatest(model.ADMIN_NOTIFICATION_TYPE_JOB_FAILED, message, 0)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+
+		_, err = ss.AdminNotification().GetLatest(model.ADMIN_NOTIFICATION_TYPE_PLUGIN_HEALTH_CHECK_FAILED, message+"2", 0)
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testAdminNotificationStoreGetAll(t *testing.T, ss store.Store) {
+	var saved []*model.AdminNotification
+	for i := 0; i < 3; i++ {
+		notification, err := ss.AdminNotification().Save(&model.AdminNotification{
+			Type:    model.ADMIN_NOTIFICATION_TYPE_CONFIG_SAVE_FAILED,
+			Message: "config save failed " + model.NewId(),
+		})
+		require.Nil(t, err)
+		saved = append(saved, notification)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	notifications, err := ss.AdminNotification().GetAll(0, 2)
+	require.Nil(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, saved[2].Id, notifications[0].Id)
+	assert.Equal(t, saved[1].Id, notifications[1].Id)
+
+	notifications, err = ss.AdminNotification().GetAll(2, 1)
+	require.Nil(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, saved[0].Id, notifications[0].Id)
+}
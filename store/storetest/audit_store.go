@@ -4,6 +4,9 @@
 package storetest
 
 import (
+	"errors"
+	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 
 func TestAuditStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testAuditStore(t, ss) })
+	t.Run("StreamToSyslog", func(t *testing.T) { testAuditStoreStreamToSyslog(t, ss) })
 }
 
 func testAuditStore(t *testing.T, ss store.Store) {
@@ -48,3 +52,85 @@ func testAuditStore(t *testing.T, ss store.Store) {
 
 	require.Nil(t, ss.Audit().PermanentDeleteByUser(audit.UserId))
 }
+
+func testAuditStoreStreamToSyslog(t *testing.T, ss store.Store) {
+	t.Run("invalid protocol", func(t *testing.T) {
+		err := ss.Audit().StreamToSyslog("localhost:514", "http", nil)
+		require.NotNil(t, err)
+		var invErr *store.ErrInvalidInput
+		assert.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("invalid certificate authorities", func(t *testing.T) {
+		err := ss.Audit().StreamToSyslog("localhost:6514", model.COMPLIANCE_SYSLOG_PROTOCOL_TCP_TLS, []byte("not a certificate"))
+		require.NotNil(t, err)
+		var invErr *store.ErrInvalidInput
+		assert.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.Nil(t, err)
+		defer conn.Close()
+
+		require.Nil(t, ss.Audit().StreamToSyslog(conn.LocalAddr().String(), "udp", nil))
+		defer ss.Audit().StreamToSyslog("", "", nil)
+
+		audit := &model.Audit{UserId: model.NewId(), IpAddress: "ipaddress", Action: "Action"}
+		require.Nil(t, ss.Audit().Save(audit))
+
+		buf := make([]byte, 4096)
+		require.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.Nil(t, err)
+
+		message := string(buf[:n])
+		assert.True(t, strings.HasPrefix(message, "<"))
+		assert.Contains(t, message, "mattermost-audit")
+		assert.Contains(t, message, audit.Id)
+		assert.Contains(t, message, audit.UserId)
+	})
+
+	t.Run("server not up yet", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		addr := listener.Addr().String()
+		require.Nil(t, listener.Close())
+
+		require.Nil(t, ss.Audit().StreamToSyslog(addr, "tcp", nil))
+		defer ss.Audit().StreamToSyslog("", "", nil)
+
+		audit := &model.Audit{UserId: model.NewId(), IpAddress: "ipaddress", Action: "Action"}
+		require.Nil(t, ss.Audit().Save(audit))
+
+		listener, err = net.Listen("tcp", addr)
+		require.Nil(t, err)
+		defer listener.Close()
+
+		conn, err := listener.Accept()
+		require.Nil(t, err)
+		defer conn.Close()
+
+		buf := make([]byte, 4096)
+		require.Nil(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+		n, err := conn.Read(buf)
+		require.Nil(t, err)
+		assert.Contains(t, string(buf[:n]), audit.Id)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.Nil(t, err)
+		defer conn.Close()
+
+		require.Nil(t, ss.Audit().StreamToSyslog(conn.LocalAddr().String(), "udp", nil))
+		require.Nil(t, ss.Audit().StreamToSyslog("", "", nil))
+
+		require.Nil(t, ss.Audit().Save(&model.Audit{UserId: model.NewId(), IpAddress: "ipaddress", Action: "Action"}))
+
+		buf := make([]byte, 4096)
+		require.Nil(t, conn.SetReadDeadline(time.Now().Add(500*time.Millisecond)))
+		_, _, err = conn.ReadFrom(buf)
+		require.NotNil(t, err)
+	})
+}
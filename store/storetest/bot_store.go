@@ -308,6 +308,29 @@ func testBotStoreGetAll(t *testing.T, ss store.Store, s SqlSupplier) {
 			b4,
 		}, bots)
 	})
+
+	systemBot, _ := makeBotWithUser(t, ss, &model.Bot{
+		Username:    model.SYSTEM_BOT_USERNAME,
+		Description: "The system bot",
+		OwnerId:     OwnerId2,
+	})
+	defer func() { require.Nil(t, ss.Bot().PermanentDelete(systemBot.UserId)) }()
+	defer func() { require.Nil(t, ss.User().PermanentDelete(systemBot.UserId)) }()
+
+	t.Run("get system bot", func(t *testing.T) {
+		bots, err := ss.Bot().GetAll(&model.BotGetOptions{Page: 0, PerPage: 10, OwnerId: OwnerId2})
+		require.Nil(t, err)
+		require.Equal(t, []*model.Bot{
+			b4,
+		}, bots)
+
+		bots, err = ss.Bot().GetAll(&model.BotGetOptions{Page: 0, PerPage: 10, OwnerId: OwnerId2, IncludeSystemBot: true})
+		require.Nil(t, err)
+		require.Equal(t, []*model.Bot{
+			b4,
+			systemBot,
+		}, bots)
+	})
 }
 
 func testBotStoreSave(t *testing.T, ss store.Store) {
@@ -0,0 +1,251 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBookmarkStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testChannelBookmarkStoreSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testChannelBookmarkStoreUpdate(t, ss) })
+	t.Run("UpdateSortOrder", func(t *testing.T) { testChannelBookmarkStoreUpdateSortOrder(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testChannelBookmarkStoreDelete(t, ss) })
+	t.Run("PermanentDeleteByChannel", func(t *testing.T) { testChannelBookmarkStorePermanentDeleteByChannel(t, ss) })
+	t.Run("GetBookmarksForFile", func(t *testing.T) { testChannelBookmarkStoreGetBookmarksForFile(t, ss) })
+	t.Run("DeletedWithFile", func(t *testing.T) { testChannelBookmarkStoreDeletedWithFile(t, ss) })
+}
+
+func newLinkBookmark(channelId, displayName string) *model.ChannelBookmark {
+	return &model.ChannelBookmark{
+		ChannelId:   channelId,
+		OwnerId:     model.NewId(),
+		DisplayName: displayName,
+		LinkUrl:     "https://example.com/" + displayName,
+		Type:        model.CHANNEL_BOOKMARK_TYPE_LINK,
+	}
+}
+
+func testChannelBookmarkStoreSaveAndGet(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	first, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "first"))
+	require.Nil(t, err)
+	assert.NotEmpty(t, first.Id)
+	assert.Equal(t, int64(0), first.SortOrder)
+
+	second, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "second"))
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), second.SortOrder)
+
+	t.Run("should not save a bookmark with an id", func(t *testing.T) {
+		bookmark := newLinkBookmark(channelId, "third")
+		bookmark.Id = model.NewId()
+		_, err := ss.ChannelBookmark().Save(bookmark)
+		require.NotNil(t, err)
+	})
+
+	t.Run("should not save an invalid bookmark", func(t *testing.T) {
+		bookmark := newLinkBookmark(channelId, "third")
+		bookmark.LinkUrl = ""
+		_, err := ss.ChannelBookmark().Save(bookmark)
+		require.NotNil(t, err)
+	})
+
+	t.Run("should get a bookmark", func(t *testing.T) {
+		bookmark, err := ss.ChannelBookmark().Get(first.Id, false)
+		require.Nil(t, err)
+		assert.Equal(t, first, bookmark)
+	})
+
+	t.Run("should not get a missing bookmark", func(t *testing.T) {
+		_, err := ss.ChannelBookmark().Get(model.NewId(), false)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should get the bookmarks for a channel in order", func(t *testing.T) {
+		bookmarks, err := ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+		require.Nil(t, err)
+		require.Len(t, bookmarks, 2)
+		assert.Equal(t, first.Id, bookmarks[0].Id)
+		assert.Equal(t, second.Id, bookmarks[1].Id)
+	})
+}
+
+func testChannelBookmarkStoreUpdate(t *testing.T, ss store.Store) {
+	bookmark, err := ss.ChannelBookmark().Save(newLinkBookmark(model.NewId(), "original"))
+	require.Nil(t, err)
+
+	bookmark.DisplayName = "updated"
+	bookmark.Emoji = "smile"
+	_, err = ss.ChannelBookmark().Update(bookmark)
+	require.Nil(t, err)
+
+	updated, err := ss.ChannelBookmark().Get(bookmark.Id, false)
+	require.Nil(t, err)
+	assert.Equal(t, "updated", updated.DisplayName)
+	assert.Equal(t, "smile", updated.Emoji)
+
+	t.Run("should not update a missing bookmark", func(t *testing.T) {
+		missing := newLinkBookmark(model.NewId(), "missing")
+		missing.PreSave()
+		_, err := ss.ChannelBookmark().Update(missing)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testChannelBookmarkStoreUpdateSortOrder(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	first, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "first"))
+	require.Nil(t, err)
+	second, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "second"))
+	require.Nil(t, err)
+	third, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "third"))
+	require.Nil(t, err)
+
+	err = ss.ChannelBookmark().UpdateSortOrder(channelId, []string{third.Id, first.Id, second.Id})
+	require.Nil(t, err)
+
+	bookmarks, err := ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+	require.Nil(t, err)
+	require.Len(t, bookmarks, 3)
+	assert.Equal(t, third.Id, bookmarks[0].Id)
+	assert.Equal(t, first.Id, bookmarks[1].Id)
+	assert.Equal(t, second.Id, bookmarks[2].Id)
+
+	t.Run("should reject an incomplete order", func(t *testing.T) {
+		err := ss.ChannelBookmark().UpdateSortOrder(channelId, []string{third.Id, first.Id})
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("should reject unknown bookmarks", func(t *testing.T) {
+		err := ss.ChannelBookmark().UpdateSortOrder(channelId, []string{third.Id, first.Id, model.NewId()})
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("should reject duplicated bookmarks", func(t *testing.T) {
+		err := ss.ChannelBookmark().UpdateSortOrder(channelId, []string{third.Id, first.Id, first.Id})
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+	})
+}
+
+func testChannelBookmarkStoreDelete(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	bookmark, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "deleted"))
+	require.Nil(t, err)
+
+	err = ss.ChannelBookmark().Delete(bookmark.Id)
+	require.Nil(t, err)
+
+	_, err = ss.ChannelBookmark().Get(bookmark.Id, false)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	deleted, err := ss.ChannelBookmark().Get(bookmark.Id, true)
+	require.Nil(t, err)
+	assert.NotZero(t, deleted.DeleteAt)
+
+	bookmarks, err := ss.ChannelBookmark().GetBookmarksForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, bookmarks)
+
+	err = ss.ChannelBookmark().Delete(bookmark.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testChannelBookmarkStorePermanentDeleteByChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	otherChannelId := model.NewId()
+
+	bookmark, err := ss.ChannelBookmark().Save(newLinkBookmark(channelId, "deleted"))
+	require.Nil(t, err)
+	other, err := ss.ChannelBookmark().Save(newLinkBookmark(otherChannelId, "kept"))
+	require.Nil(t, err)
+
+	err = ss.ChannelBookmark().PermanentDeleteByChannel(channelId)
+	require.Nil(t, err)
+
+	_, err = ss.ChannelBookmark().Get(bookmark.Id, true)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.ChannelBookmark().Get(other.Id, false)
+	require.Nil(t, err)
+}
+
+func testChannelBookmarkStoreGetBookmarksForFile(t *testing.T, ss store.Store) {
+	fileId := model.NewId()
+
+	bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   model.NewId(),
+		OwnerId:     model.NewId(),
+		FileId:      fileId,
+		DisplayName: "file",
+		Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+	})
+	require.Nil(t, err)
+	defer ss.ChannelBookmark().PermanentDeleteByChannel(bookmark.ChannelId)
+
+	deleted, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   model.NewId(),
+		OwnerId:     model.NewId(),
+		FileId:      fileId,
+		DisplayName: "file",
+		Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+	})
+	require.Nil(t, err)
+	defer ss.ChannelBookmark().PermanentDeleteByChannel(deleted.ChannelId)
+	require.Nil(t, ss.ChannelBookmark().Delete(deleted.Id))
+
+	bookmarks, err := ss.ChannelBookmark().GetBookmarksForFile(fileId)
+	require.Nil(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, bookmark.Id, bookmarks[0].Id)
+
+	bookmarks, err = ss.ChannelBookmark().GetBookmarksForFile(model.NewId())
+	require.Nil(t, err)
+	assert.Empty(t, bookmarks)
+}
+
+func testChannelBookmarkStoreDeletedWithFile(t *testing.T, ss store.Store) {
+	bookmarkedFile, appErr := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file.txt",
+		CreateAt:  1000,
+	})
+	require.Nil(t, appErr)
+	defer ss.FileInfo().PermanentDelete(bookmarkedFile.Id)
+
+	bookmark, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   model.NewId(),
+		OwnerId:     bookmarkedFile.CreatorId,
+		FileId:      bookmarkedFile.Id,
+		DisplayName: "file",
+		Type:        model.CHANNEL_BOOKMARK_TYPE_FILE,
+	})
+	require.Nil(t, err)
+	defer ss.ChannelBookmark().PermanentDeleteByChannel(bookmark.ChannelId)
+
+	_, appErr = ss.FileInfo().PermanentDeleteBatch(2000, 1000)
+	require.Nil(t, appErr)
+
+	_, appErr = ss.FileInfo().Get(bookmarkedFile.Id)
+	require.NotNil(t, appErr, "bookmarked file should have been deleted")
+
+	_, err = ss.ChannelBookmark().Get(bookmark.Id, true)
+	require.NotNil(t, err, "bookmark of a deleted file should have been deleted")
+}
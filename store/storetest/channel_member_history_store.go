@@ -20,6 +20,7 @@ func TestChannelMemberHistoryStore(t *testing.T, ss store.Store) {
 	t.Run("TestGetUsersInChannelAtChannelMemberHistory", func(t *testing.T) { testGetUsersInChannelAtChannelMemberHistory(t, ss) })
 	t.Run("TestGetUsersInChannelAtChannelMembers", func(t *testing.T) { testGetUsersInChannelAtChannelMembers(t, ss) })
 	t.Run("TestPermanentDeleteBatch", func(t *testing.T) { testPermanentDeleteBatch(t, ss) })
+	t.Run("TestGetForChannel", func(t *testing.T) { testGetForChannel(t, ss) })
 }
 
 func testLogJoinEvent(t *testing.T, ss store.Store) {
@@ -342,3 +343,59 @@ func testPermanentDeleteBatch(t *testing.T, ss store.Store) {
 	assert.Len(t, channelMembers, 1)
 	assert.Equal(t, user2.Id, channelMembers[0].UserId)
 }
+
+func testGetForChannel(t *testing.T, ss store.Store) {
+	ch := &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Display " + model.NewId(),
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}
+	channel, err := ss.Channel().Save(ch, -1)
+	require.Nil(t, err)
+
+	users := make([]*model.User, 3)
+	for i := range users {
+		users[i], err = ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Nickname: model.NewId(),
+			Username: model.NewId(),
+		})
+		require.Nil(t, err)
+	}
+
+	// the first user joins and leaves early, the second joins early and leaves late, and the third
+	// joins late and stays
+	now := model.GetMillis()
+	require.Nil(t, ss.ChannelMemberHistory().LogJoinEvent(users[0].Id, channel.Id, now-5000))
+	require.Nil(t, ss.ChannelMemberHistory().LogLeaveEvent(users[0].Id, channel.Id, now-4000))
+	require.Nil(t, ss.ChannelMemberHistory().LogJoinEvent(users[1].Id, channel.Id, now-4500))
+	require.Nil(t, ss.ChannelMemberHistory().LogLeaveEvent(users[1].Id, channel.Id, now-1000))
+	require.Nil(t, ss.ChannelMemberHistory().LogJoinEvent(users[2].Id, channel.Id, now-500))
+
+	t.Run("all events", func(t *testing.T) {
+		histories, err := ss.ChannelMemberHistory().GetForChannel(channel.Id, now-10000, now, 0, 100)
+		require.Nil(t, err)
+		require.Len(t, histories, 3)
+		assert.Equal(t, users[0].Id, histories[0].UserId)
+		assert.Equal(t, users[0].Username, histories[0].Username)
+		assert.Equal(t, now-4000, *histories[0].LeaveTime)
+		assert.Equal(t, users[1].Id, histories[1].UserId)
+		assert.Equal(t, users[2].Id, histories[2].UserId)
+		assert.Nil(t, histories[2].LeaveTime)
+	})
+
+	t.Run("events in a range", func(t *testing.T) {
+		histories, err := ss.ChannelMemberHistory().GetForChannel(channel.Id, now-3000, now-800, 0, 100)
+		require.Nil(t, err)
+		require.Len(t, histories, 1)
+		assert.Equal(t, users[1].Id, histories[0].UserId)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		histories, err := ss.ChannelMemberHistory().GetForChannel(channel.Id, now-10000, now, 1, 1)
+		require.Nil(t, err)
+		require.Len(t, histories, 1)
+		assert.Equal(t, users[1].Id, histories[0].UserId)
+	})
+}
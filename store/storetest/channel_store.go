@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -43,6 +45,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("CreateDirectChannel", func(t *testing.T) { testChannelStoreCreateDirectChannel(t, ss) })
 	t.Run("Update", func(t *testing.T) { testChannelStoreUpdate(t, ss) })
 	t.Run("GetChannelUnread", func(t *testing.T) { testGetChannelUnread(t, ss) })
+	t.Run("GetLastViewedPostForUser", func(t *testing.T) { testChannelStoreGetLastViewedPostForUser(t, ss) })
 	t.Run("Get", func(t *testing.T) { testChannelStoreGet(t, ss, s) })
 	t.Run("GetChannelsByIds", func(t *testing.T) { testChannelStoreGetChannelsByIds(t, ss) })
 	t.Run("GetForPost", func(t *testing.T) { testChannelStoreGetForPost(t, ss) })
@@ -52,6 +55,8 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetByNames", func(t *testing.T) { testChannelStoreGetByNames(t, ss) })
 	t.Run("GetDeletedByName", func(t *testing.T) { testChannelStoreGetDeletedByName(t, ss) })
 	t.Run("GetDeleted", func(t *testing.T) { testChannelStoreGetDeleted(t, ss) })
+	t.Run("GetRecentlyDeleted", func(t *testing.T) { testChannelStoreGetRecentlyDeleted(t, ss) })
+	t.Run("GetDeletedByTeamForUserSince", func(t *testing.T) { testChannelStoreGetDeletedByTeamForUserSince(t, ss) })
 	t.Run("ChannelMemberStore", func(t *testing.T) { testChannelMemberStore(t, ss) })
 	t.Run("SaveMember", func(t *testing.T) { testChannelSaveMember(t, ss) })
 	t.Run("SaveMultipleMembers", func(t *testing.T) { testChannelSaveMultipleMembers(t, ss) })
@@ -62,10 +67,13 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("ChannelDeleteMemberStore", func(t *testing.T) { testChannelDeleteMemberStore(t, ss) })
 	t.Run("GetChannels", func(t *testing.T) { testChannelStoreGetChannels(t, ss) })
 	t.Run("GetAllChannels", func(t *testing.T) { testChannelStoreGetAllChannels(t, ss, s) })
+	t.Run("GetTeamChannelsWithActivity", func(t *testing.T) { testChannelStoreGetTeamChannelsWithActivity(t, ss) })
 	t.Run("GetMoreChannels", func(t *testing.T) { testChannelStoreGetMoreChannels(t, ss) })
 	t.Run("GetPrivateChannelsForTeam", func(t *testing.T) { testChannelStoreGetPrivateChannelsForTeam(t, ss) })
 	t.Run("GetPublicChannelsForTeam", func(t *testing.T) { testChannelStoreGetPublicChannelsForTeam(t, ss) })
 	t.Run("GetPublicChannelsByIdsForTeam", func(t *testing.T) { testChannelStoreGetPublicChannelsByIdsForTeam(t, ss) })
+	t.Run("GetRecentDirectChannels", func(t *testing.T) { testChannelStoreGetRecentDirectChannels(t, ss) })
+	t.Run("GetInactiveChannelsForTeam", func(t *testing.T) { testChannelStoreGetInactiveChannelsForTeam(t, ss) })
 	t.Run("GetChannelCounts", func(t *testing.T) { testChannelStoreGetChannelCounts(t, ss) })
 	t.Run("GetMembersForUser", func(t *testing.T) { testChannelStoreGetMembersForUser(t, ss) })
 	t.Run("GetMembersForUserWithPagination", func(t *testing.T) { testChannelStoreGetMembersForUserWithPagination(t, ss) })
@@ -87,7 +95,9 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("AnalyticsDeletedTypeCount", func(t *testing.T) { testChannelStoreAnalyticsDeletedTypeCount(t, ss) })
 	t.Run("GetPinnedPosts", func(t *testing.T) { testChannelStoreGetPinnedPosts(t, ss) })
 	t.Run("GetPinnedPostCount", func(t *testing.T) { testChannelStoreGetPinnedPostCount(t, ss) })
+	t.Run("GetRootPostCountSince", func(t *testing.T) { testChannelStoreGetRootPostCountSince(t, ss) })
 	t.Run("MaxChannelsPerTeam", func(t *testing.T) { testChannelStoreMaxChannelsPerTeam(t, ss) })
+	t.Run("SaveWithCreatorLimit", func(t *testing.T) { testChannelStoreSaveWithCreatorLimit(t, ss) })
 	t.Run("GetChannelsByScheme", func(t *testing.T) { testChannelStoreGetChannelsByScheme(t, ss) })
 	t.Run("MigrateChannelMembers", func(t *testing.T) { testChannelStoreMigrateChannelMembers(t, ss) })
 	t.Run("ResetAllChannelSchemes", func(t *testing.T) { testResetAllChannelSchemes(t, ss) })
@@ -313,6 +323,65 @@ func testChannelStoreUpdate(t *testing.T, ss store.Store) {
 	require.NotNil(t, err, "update should have failed because of existing name")
 }
 
+func testChannelStoreGetLastViewedPostForUser(t *testing.T, ss store.Store) {
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+
+	member, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      model.NewId(),
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.Nil(t, err)
+
+	t.Run("never viewed", func(t *testing.T) {
+		postId, lastViewedAt, nErr := ss.Channel().GetLastViewedPostForUser(member.UserId, channel.Id)
+		require.Nil(t, nErr)
+		assert.Equal(t, "", postId)
+		assert.Equal(t, int64(0), lastViewedAt)
+	})
+
+	t.Run("viewed before any post", func(t *testing.T) {
+		member.LastViewedAt = 500
+		member, err = ss.Channel().UpdateMember(member)
+		require.Nil(t, err)
+
+		postId, lastViewedAt, nErr := ss.Channel().GetLastViewedPostForUser(member.UserId, channel.Id)
+		require.Nil(t, nErr)
+		assert.Equal(t, "", postId)
+		assert.Equal(t, int64(500), lastViewedAt)
+	})
+
+	t.Run("viewed between posts", func(t *testing.T) {
+		seenPost, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: "seen", CreateAt: 1000})
+		require.Nil(t, err)
+		_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: "deleted", CreateAt: 1100, DeleteAt: 1200})
+		require.Nil(t, err)
+		_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: "unseen", CreateAt: 2000})
+		require.Nil(t, err)
+
+		member.LastViewedAt = 1500
+		member, err = ss.Channel().UpdateMember(member)
+		require.Nil(t, err)
+
+		postId, lastViewedAt, nErr := ss.Channel().GetLastViewedPostForUser(member.UserId, channel.Id)
+		require.Nil(t, nErr)
+		assert.Equal(t, seenPost.Id, postId)
+		assert.Equal(t, int64(1500), lastViewedAt)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		_, _, nErr := ss.Channel().GetLastViewedPostForUser(model.NewId(), channel.Id)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(nErr, &nfErr))
+	})
+}
+
 func testGetChannelUnread(t *testing.T, ss store.Store) {
 	teamId1 := model.NewId()
 	teamId2 := model.NewId()
@@ -516,6 +585,14 @@ func testChannelStoreGetChannelsByIds(t *testing.T, ss store.Store) {
 		require.Equal(t, o2.ToJson(), r1[1].ToJson())
 		require.Equal(t, o3.ToJson(), r1[2].ToJson())
 	})
+
+	t.Run("Get 2 existing channels without the deleted one", func(t *testing.T) {
+		r1, err := ss.Channel().GetChannelsByIds([]string{o1.Id, o2.Id, o3.Id}, false)
+		require.Nil(t, err, err)
+		require.Len(t, r1, 2, "invalid returned channels, exepected 2 and got "+strconv.Itoa(len(r1)))
+		require.Equal(t, o1.ToJson(), r1[0].ToJson())
+		require.Equal(t, o2.ToJson(), r1[1].ToJson())
+	})
 }
 
 func testChannelStoreGetForPost(t *testing.T, ss store.Store) {
@@ -810,6 +887,95 @@ func testChannelStoreGetDeleted(t *testing.T, ss store.Store) {
 
 }
 
+func testChannelStoreGetRecentlyDeleted(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	o1, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel1", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	o2, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel2", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_PRIVATE}, -1)
+	require.Nil(t, nErr)
+	_, nErr = ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel3", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	o4, nErr := ss.Channel().Save(&model.Channel{TeamId: model.NewId(), DisplayName: "Channel4", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+
+	now := model.GetMillis()
+	require.Nil(t, ss.Channel().Delete(o1.Id, now-1000))
+	require.Nil(t, ss.Channel().Delete(o2.Id, now))
+	require.Nil(t, ss.Channel().Delete(o4.Id, now))
+
+	channels, err := ss.Channel().GetRecentlyDeleted(teamId, 0, 100)
+	require.Nil(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, o2.Id, channels[0].Id, "should list the private channel deleted last first")
+	assert.Equal(t, o1.Id, channels[1].Id)
+
+	channels, err = ss.Channel().GetRecentlyDeleted(teamId, 1, 1)
+	require.Nil(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, o1.Id, channels[0].Id)
+
+	channels, err = ss.Channel().GetRecentlyDeleted(model.NewId(), 0, 100)
+	require.Nil(t, err)
+	assert.Empty(t, channels)
+}
+
+func testChannelStoreGetDeletedByTeamForUserSince(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	o1, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel1", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	o2, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel2", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_PRIVATE}, -1)
+	require.Nil(t, nErr)
+	o3, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel3", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	o4, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel4", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	o5, nErr := ss.Channel().Save(&model.Channel{TeamId: model.NewId(), DisplayName: "Channel5", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+
+	for _, channel := range []*model.Channel{o1, o2, o3, o5} {
+		_, err := ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.Nil(t, err)
+	}
+
+	now := model.GetMillis()
+	require.Nil(t, ss.Channel().Delete(o1.Id, now-2000))
+	require.Nil(t, ss.Channel().Delete(o2.Id, now))
+	require.Nil(t, ss.Channel().Delete(o4.Id, now))
+	require.Nil(t, ss.Channel().Delete(o5.Id, now))
+
+	t.Run("should only get deleted channels the user is a member of", func(t *testing.T) {
+		channels, err := ss.Channel().GetDeletedByTeamForUserSince(teamId, userId, 0)
+		require.Nil(t, err)
+		require.Len(t, channels, 2)
+		assert.Equal(t, o1.Id, channels[0].Id)
+		assert.Equal(t, o2.Id, channels[1].Id)
+	})
+
+	t.Run("should only get channels deleted after since", func(t *testing.T) {
+		channels, err := ss.Channel().GetDeletedByTeamForUserSince(teamId, userId, now-1000)
+		require.Nil(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, o2.Id, channels[0].Id)
+
+		channels, err = ss.Channel().GetDeletedByTeamForUserSince(teamId, userId, now)
+		require.Nil(t, err)
+		assert.Empty(t, channels)
+	})
+
+	t.Run("should get nothing for a user without memberships", func(t *testing.T) {
+		channels, err := ss.Channel().GetDeletedByTeamForUserSince(teamId, model.NewId(), 0)
+		require.Nil(t, err)
+		assert.Empty(t, channels)
+	})
+}
+
 func testChannelMemberStore(t *testing.T, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -3314,6 +3480,74 @@ func testChannelStoreGetAllChannels(t *testing.T, ss store.Store, s SqlSupplier)
 	s.GetMaster().Exec("TRUNCATE Channels")
 }
 
+func testChannelStoreGetTeamChannelsWithActivity(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	saveChannel := func(displayName, channelType string, lastPostAt int64) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: displayName,
+			Name:        "zz" + model.NewId() + "b",
+			Type:        channelType,
+			LastPostAt:  lastPostAt,
+		}, -1)
+		require.Nil(t, err)
+		return channel
+	}
+
+	quiet := saveChannel("Quiet", model.CHANNEL_OPEN, 1000)
+	busy := saveChannel("Busy", model.CHANNEL_PRIVATE, 3000)
+	archived := saveChannel("Archived", model.CHANNEL_OPEN, 2000)
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	// Channels of other teams aren't listed.
+	_, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Other",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	active, appErr := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, appErr)
+	deactivated, appErr := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId(), DeleteAt: model.GetMillis()})
+	require.Nil(t, appErr)
+	for _, userId := range []string{active.Id, deactivated.Id} {
+		_, appErr = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: busy.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.Nil(t, appErr)
+	}
+
+	channelIds := func(channels []*model.ChannelWithActivity) []string {
+		ids := []string{}
+		for _, channel := range channels {
+			ids = append(ids, channel.Id)
+		}
+		return ids
+	}
+
+	t.Run("ordered by name", func(t *testing.T) {
+		channels, err := ss.Channel().GetTeamChannelsWithActivity(teamId, 0, 10, model.ChannelActivityOpts{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{busy.Id, quiet.Id}, channelIds(channels))
+		assert.EqualValues(t, 1, channels[0].MemberCount, "deactivated users aren't counted")
+		assert.EqualValues(t, 3000, channels[0].LastPostAt)
+		assert.EqualValues(t, 0, channels[1].MemberCount)
+	})
+
+	t.Run("ordered by activity including archived channels", func(t *testing.T) {
+		channels, err := ss.Channel().GetTeamChannelsWithActivity(teamId, 0, 10, model.ChannelActivityOpts{IncludeDeleted: true, SortByActivity: true})
+		require.Nil(t, err)
+		assert.Equal(t, []string{busy.Id, archived.Id, quiet.Id}, channelIds(channels))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		channels, err := ss.Channel().GetTeamChannelsWithActivity(teamId, 1, 1, model.ChannelActivityOpts{IncludeDeleted: true, SortByActivity: true})
+		require.Nil(t, err)
+		assert.Equal(t, []string{archived.Id}, channelIds(channels))
+	})
+}
+
 func testChannelStoreGetMoreChannels(t *testing.T, ss store.Store) {
 	teamId := model.NewId()
 	otherTeamId := model.NewId()
@@ -3588,7 +3822,7 @@ func testChannelStoreGetPublicChannelsForTeam(t *testing.T, ss store.Store) {
 	require.Nil(t, nErr)
 
 	t.Run("only o1 initially listed in public channels", func(t *testing.T) {
-		list, channelErr := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100)
+		list, channelErr := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100, false)
 		require.Nil(t, channelErr)
 		require.Equal(t, &model.ChannelList{&o1}, list)
 	})
@@ -3616,19 +3850,19 @@ func testChannelStoreGetPublicChannelsForTeam(t *testing.T, ss store.Store) {
 	require.Nil(t, err, "channel should have been deleted")
 
 	t.Run("both o1 and o4 listed in public channels", func(t *testing.T) {
-		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100)
+		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100, false)
 		require.Nil(t, err)
 		require.Equal(t, &model.ChannelList{&o1, &o4}, list)
 	})
 
 	t.Run("only o1 listed in public channels with offset 0, limit 1", func(t *testing.T) {
-		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 1)
+		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 1, false)
 		require.Nil(t, err)
 		require.Equal(t, &model.ChannelList{&o1}, list)
 	})
 
 	t.Run("only o4 listed in public channels with offset 1, limit 1", func(t *testing.T) {
-		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 1, 1)
+		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 1, 1, false)
 		require.Nil(t, err)
 		require.Equal(t, &model.ChannelList{&o4}, list)
 	})
@@ -3644,6 +3878,109 @@ func testChannelStoreGetPublicChannelsForTeam(t *testing.T, ss store.Store) {
 		require.Nil(t, err)
 		require.EqualValues(t, 1, count)
 	})
+
+	// o6 is another public channel on the team, hidden from public search
+	o6 := model.Channel{
+		TeamId:                                  teamId,
+		DisplayName:                             "OpenChannel4Team1",
+		Name:                                    "zz" + model.NewId() + "b",
+		Type:                                    model.CHANNEL_OPEN,
+		ExperimentalHideChannelFromPublicSearch: model.NewBool(true),
+	}
+	_, nErr = ss.Channel().Save(&o6, -1)
+	require.Nil(t, nErr)
+
+	t.Run("o6 not listed in public channels", func(t *testing.T) {
+		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100, false)
+		require.Nil(t, err)
+		require.Equal(t, &model.ChannelList{&o1, &o4}, list)
+	})
+
+	t.Run("o6 listed in public channels when including hidden channels", func(t *testing.T) {
+		list, err := ss.Channel().GetPublicChannelsForTeam(teamId, 0, 100, true)
+		require.Nil(t, err)
+		require.Equal(t, &model.ChannelList{&o1, &o4, &o6}, list)
+	})
+}
+
+func testChannelStoreGetRecentDirectChannels(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	u3, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+
+	// dm1 has no posts
+	dm1, nErr := ss.Channel().CreateDirectChannel(u1, u2)
+	require.Nil(t, nErr)
+
+	// dm2 was posted in before gm
+	dm2, nErr := ss.Channel().CreateDirectChannel(u1, u3)
+	require.Nil(t, nErr)
+	_, err = ss.Post().Save(&model.Post{ChannelId: dm2.Id, UserId: u1.Id, Message: "hello", CreateAt: 1000})
+	require.Nil(t, err)
+
+	gm, nErr := ss.Channel().Save(&model.Channel{
+		Name:        model.NewId(),
+		DisplayName: "group",
+		Type:        model.CHANNEL_GROUP,
+	}, -1)
+	require.Nil(t, nErr)
+	for _, user := range []*model.User{u1, u2, u3} {
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: gm.Id, UserId: user.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.Nil(t, err)
+	}
+	_, err = ss.Post().Save(&model.Post{ChannelId: gm.Id, UserId: u2.Id, Message: "hello", CreateAt: 2000})
+	require.Nil(t, err)
+
+	// Public channels are never returned
+	o1, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		Name:        model.NewId(),
+		DisplayName: "public",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: o1.Id, UserId: u1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	t.Run("should return channels ordered by last post", func(t *testing.T) {
+		channels, err := ss.Channel().GetRecentDirectChannels(u1.Id, 10)
+		require.Nil(t, err)
+		require.Len(t, channels, 3)
+		assert.Equal(t, gm.Id, channels[0].Id)
+		assert.Equal(t, dm2.Id, channels[1].Id)
+		assert.Equal(t, dm1.Id, channels[2].Id)
+	})
+
+	t.Run("should attach the other participants", func(t *testing.T) {
+		channels, err := ss.Channel().GetRecentDirectChannels(u1.Id, 10)
+		require.Nil(t, err)
+		require.Len(t, channels, 3)
+		assert.ElementsMatch(t, []string{u2.Id, u3.Id}, channels[0].ParticipantIds)
+		assert.Equal(t, []string{u3.Id}, channels[1].ParticipantIds)
+		assert.Equal(t, []string{u2.Id}, channels[2].ParticipantIds)
+	})
+
+	t.Run("should respect the limit", func(t *testing.T) {
+		channels, err := ss.Channel().GetRecentDirectChannels(u1.Id, 1)
+		require.Nil(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, gm.Id, channels[0].Id)
+	})
+
+	t.Run("should return all channels without a limit", func(t *testing.T) {
+		channels, err := ss.Channel().GetRecentDirectChannels(u1.Id, 0)
+		require.Nil(t, err)
+		require.Len(t, channels, 3)
+	})
+
+	t.Run("should return nothing for a user without direct channels", func(t *testing.T) {
+		channels, err := ss.Channel().GetRecentDirectChannels(model.NewId(), 10)
+		require.Nil(t, err)
+		assert.Empty(t, channels)
+	})
 }
 
 func testChannelStoreGetPublicChannelsByIdsForTeam(t *testing.T, ss store.Store) {
@@ -5831,6 +6168,40 @@ func testChannelStoreGetPinnedPostCount(t *testing.T, ss store.Store) {
 	require.EqualValues(t, 0, count, "should return 0")
 }
 
+func testChannelStoreGetRootPostCountSince(t *testing.T, ss store.Store) {
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Name",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+
+	root, err := ss.Post().Save(&model.Post{UserId: model.NewId(), ChannelId: channel.Id, Message: "root", CreateAt: 3000})
+	require.Nil(t, err)
+	_, err = ss.Post().Save(&model.Post{UserId: model.NewId(), ChannelId: channel.Id, Message: "reply", RootId: root.Id, ParentId: root.Id, CreateAt: 3001})
+	require.Nil(t, err)
+	_, err = ss.Post().Save(&model.Post{UserId: model.NewId(), ChannelId: channel.Id, Message: "old", CreateAt: 1000})
+	require.Nil(t, err)
+	deleted, err := ss.Post().Save(&model.Post{UserId: model.NewId(), ChannelId: channel.Id, Message: "deleted", CreateAt: 3002})
+	require.Nil(t, err)
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), ""))
+	_, err = ss.Post().Save(&model.Post{UserId: model.NewId(), ChannelId: model.NewId(), Message: "other channel", CreateAt: 3000})
+	require.Nil(t, err)
+
+	count, countErr := ss.Channel().GetRootPostCountSince(channel.Id, 2000)
+	require.Nil(t, countErr)
+	require.EqualValues(t, 1, count)
+
+	count, countErr = ss.Channel().GetRootPostCountSince(channel.Id, 0)
+	require.Nil(t, countErr)
+	require.EqualValues(t, 2, count)
+
+	count, countErr = ss.Channel().GetRootPostCountSince(channel.Id, 3000)
+	require.Nil(t, countErr)
+	require.EqualValues(t, 0, count)
+}
+
 func testChannelStoreMaxChannelsPerTeam(t *testing.T, ss store.Store) {
 	channel := &model.Channel{
 		TeamId:      model.NewId(),
@@ -5846,6 +6217,103 @@ func testChannelStoreMaxChannelsPerTeam(t *testing.T, ss store.Store) {
 	channel.Id = ""
 	_, nErr = ss.Channel().Save(channel, 1)
 	assert.Nil(t, nErr)
+
+	t.Run("concurrent saves don't exceed the limit", func(t *testing.T) {
+		team, err := ss.Team().Save(&model.Team{
+			DisplayName: "Team",
+			Name:        "zz" + model.NewId(),
+			Email:       MakeEmail(),
+			Type:        model.TEAM_OPEN,
+		})
+		require.Nil(t, err)
+
+		var wg sync.WaitGroup
+		var saved int32
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, nErr := ss.Channel().Save(&model.Channel{
+					TeamId:      team.Id,
+					DisplayName: "Channel",
+					Name:        model.NewId(),
+					Type:        model.CHANNEL_OPEN,
+				}, 3)
+				if nErr == nil {
+					atomic.AddInt32(&saved, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(3), saved)
+	})
+}
+
+func testChannelStoreSaveWithCreatorLimit(t *testing.T, ss store.Store) {
+	user, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u" + model.NewId(),
+	})
+	require.Nil(t, err)
+
+	newChannel := func() *model.Channel {
+		return &model.Channel{
+			TeamId:      model.NewId(),
+			DisplayName: "Channel",
+			Name:        model.NewId(),
+			Type:        model.CHANNEL_OPEN,
+			CreatorId:   user.Id,
+		}
+	}
+
+	since := model.GetMillis()
+
+	_, nErr := ss.Channel().SaveWithCreatorLimit(newChannel(), -1, 2, since)
+	require.Nil(t, nErr)
+	_, nErr = ss.Channel().SaveWithCreatorLimit(newChannel(), -1, 2, since)
+	require.Nil(t, nErr)
+
+	_, nErr = ss.Channel().SaveWithCreatorLimit(newChannel(), -1, 2, since)
+	require.NotNil(t, nErr)
+	var ltErr *store.ErrLimitExceeded
+	require.True(t, errors.As(nErr, &ltErr))
+	assert.Equal(t, "channels_per_creator", ltErr.What)
+
+	t.Run("channels created earlier aren't counted", func(t *testing.T) {
+		_, nErr := ss.Channel().SaveWithCreatorLimit(newChannel(), -1, 2, model.GetMillis()+1)
+		require.Nil(t, nErr)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		_, nErr := ss.Channel().SaveWithCreatorLimit(newChannel(), -1, -1, since)
+		require.Nil(t, nErr)
+	})
+
+	t.Run("concurrent saves don't exceed the limit", func(t *testing.T) {
+		other, err := ss.User().Save(&model.User{
+			Email:    MakeEmail(),
+			Username: "u" + model.NewId(),
+		})
+		require.Nil(t, err)
+
+		var wg sync.WaitGroup
+		var saved int32
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				channel := newChannel()
+				channel.CreatorId = other.Id
+				if _, nErr := ss.Channel().SaveWithCreatorLimit(channel, -1, 3, since); nErr == nil {
+					atomic.AddInt32(&saved, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(3), saved)
+	})
 }
 
 func testChannelStoreGetChannelsByScheme(t *testing.T, ss store.Store) {
@@ -8095,3 +8563,84 @@ func testDeleteSidebarCategory(t *testing.T, ss store.Store, s SqlSupplier) {
 		assert.NotNil(t, err)
 	})
 }
+
+func testChannelStoreGetInactiveChannelsForTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	now := model.GetMillis()
+
+	saveChannel := func(channel *model.Channel) *model.Channel {
+		channel.TeamId = teamId
+		channel.Name = "zz" + model.NewId() + "b"
+		channel.DisplayName = "Channel"
+		saved, err := ss.Channel().Save(channel, -1)
+		require.Nil(t, err)
+		return saved
+	}
+
+	o1 := saveChannel(&model.Channel{Type: model.CHANNEL_OPEN, LastPostAt: 1000})
+	p1 := saveChannel(&model.Channel{Type: model.CHANNEL_PRIVATE, LastPostAt: 2000})
+	saveChannel(&model.Channel{Type: model.CHANNEL_OPEN, LastPostAt: now + 60000})
+	saveChannel(&model.Channel{Type: model.CHANNEL_OPEN, LastPostAt: 1000, GroupConstrained: model.NewBool(true)})
+	saveChannel(&model.Channel{Type: model.CHANNEL_OPEN, LastPostAt: 1000, ExcludeFromAutoArchive: model.NewBool(true)})
+	deleted := saveChannel(&model.Channel{Type: model.CHANNEL_OPEN, LastPostAt: 1000})
+	require.Nil(t, ss.Channel().Delete(deleted.Id, now))
+
+	_, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		Name:        "zz" + model.NewId() + "b",
+		DisplayName: "Other team",
+		Type:        model.CHANNEL_OPEN,
+		LastPostAt:  1000,
+	}, -1)
+	require.Nil(t, err)
+
+	expectedIds := []string{o1.Id, p1.Id}
+	sort.Strings(expectedIds)
+
+	t.Run("should return inactive channels ordered by id", func(t *testing.T) {
+		channels, err := ss.Channel().GetInactiveChannelsForTeam(teamId, now+30000, false, "", 100)
+		require.Nil(t, err)
+		require.Len(t, channels, 2)
+		assert.Equal(t, expectedIds[0], channels[0].Id)
+		assert.Equal(t, expectedIds[1], channels[1].Id)
+	})
+
+	t.Run("should page after the given channel id", func(t *testing.T) {
+		channels, err := ss.Channel().GetInactiveChannelsForTeam(teamId, now+30000, false, "", 1)
+		require.Nil(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, expectedIds[0], channels[0].Id)
+
+		channels, err = ss.Channel().GetInactiveChannelsForTeam(teamId, now+30000, false, channels[0].Id, 1)
+		require.Nil(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, expectedIds[1], channels[0].Id)
+	})
+
+	t.Run("should not return channels created after the cutoff", func(t *testing.T) {
+		channels, err := ss.Channel().GetInactiveChannelsForTeam(teamId, 1500, false, "", 100)
+		require.Nil(t, err)
+		assert.Empty(t, channels)
+	})
+
+	t.Run("should filter on whether the last post is the inactivity warning", func(t *testing.T) {
+		_, err := ss.Post().Save(&model.Post{
+			ChannelId: o1.Id,
+			UserId:    model.NewId(),
+			Message:   "warning",
+			Type:      model.POST_INACTIVE_WARNING,
+			CreateAt:  3000,
+		})
+		require.Nil(t, err)
+
+		channels, nErr := ss.Channel().GetInactiveChannelsForTeam(teamId, now+30000, true, "", 100)
+		require.Nil(t, nErr)
+		require.Len(t, channels, 1)
+		assert.Equal(t, o1.Id, channels[0].Id)
+
+		channels, nErr = ss.Channel().GetInactiveChannelsForTeam(teamId, now+30000, false, "", 100)
+		require.Nil(t, nErr)
+		require.Len(t, channels, 1)
+		assert.Equal(t, p1.Id, channels[0].Id)
+	})
+}
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -21,6 +22,8 @@ func TestEmojiStore(t *testing.T, ss store.Store) {
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, ss) })
+	t.Run("EmojiCountByCreator", func(t *testing.T) { testEmojiCountByCreator(t, ss) })
+	t.Run("EmojiSaveWithCreatorLimit", func(t *testing.T) { testEmojiSaveWithCreatorLimit(t, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, ss store.Store) {
@@ -302,3 +305,67 @@ func testEmojiSearch(t *testing.T, ss store.Store) {
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
 }
+
+func testEmojiCountByCreator(t *testing.T, ss store.Store) {
+	creatorId := model.NewId()
+
+	count, err := ss.Emoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	emojis := []*model.Emoji{
+		{CreatorId: creatorId, Name: model.NewId()},
+		{CreatorId: creatorId, Name: model.NewId()},
+		{CreatorId: model.NewId(), Name: model.NewId()},
+	}
+	for _, emoji := range emojis {
+		_, err = ss.Emoji().Save(emoji)
+		require.Nil(t, err)
+	}
+	defer func() {
+		for _, emoji := range emojis {
+			ss.Emoji().Delete(emoji, time.Now().Unix())
+		}
+	}()
+
+	count, err = ss.Emoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = ss.Emoji().Delete(emojis[0], time.Now().Unix())
+	require.Nil(t, err)
+
+	count, err = ss.Emoji().CountByCreator(creatorId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count, "should not count deleted emoji")
+}
+
+func testEmojiSaveWithCreatorLimit(t *testing.T, ss store.Store) {
+	creatorId := model.NewId()
+
+	emoji1, err := ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: creatorId, Name: model.NewId()}, 2)
+	require.Nil(t, err)
+	defer ss.Emoji().Delete(emoji1, time.Now().Unix())
+
+	emoji2, err := ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: creatorId, Name: model.NewId()}, 2)
+	require.Nil(t, err)
+	defer ss.Emoji().Delete(emoji2, time.Now().Unix())
+
+	_, err = ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: creatorId, Name: model.NewId()}, 2)
+	require.NotNil(t, err)
+	var ltErr *store.ErrLimitExceeded
+	assert.True(t, errors.As(err, &ltErr))
+
+	other, err := ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId()}, 2)
+	require.Nil(t, err, "should only count the emoji of the creator")
+	defer ss.Emoji().Delete(other, time.Now().Unix())
+
+	require.Nil(t, ss.Emoji().Delete(emoji1, time.Now().Unix()))
+	emoji3, err := ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: creatorId, Name: model.NewId()}, 2)
+	require.Nil(t, err, "should not count deleted emoji")
+	defer ss.Emoji().Delete(emoji3, time.Now().Unix())
+
+	unlimited, err := ss.Emoji().SaveWithCreatorLimit(&model.Emoji{CreatorId: creatorId, Name: model.NewId()}, -1)
+	require.Nil(t, err)
+	defer ss.Emoji().Delete(unlimited, time.Now().Unix())
+}
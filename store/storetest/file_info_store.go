@@ -27,6 +27,7 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoPermanentDelete", func(t *testing.T) { testFileInfoPermanentDelete(t, ss) })
 	t.Run("FileInfoPermanentDeleteBatch", func(t *testing.T) { testFileInfoPermanentDeleteBatch(t, ss) })
 	t.Run("FileInfoPermanentDeleteByUser", func(t *testing.T) { testFileInfoPermanentDeleteByUser(t, ss) })
+	t.Run("GetPostsWithFileExtensions", func(t *testing.T) { testFileInfoGetPostsWithFileExtensions(t, ss) })
 }
 
 func testFileInfoSaveGet(t *testing.T, ss store.Store) {
@@ -602,3 +603,87 @@ func testFileInfoPermanentDeleteByUser(t *testing.T, ss store.Store) {
 	_, err = ss.FileInfo().PermanentDeleteByUser(userId)
 	require.Nil(t, err)
 }
+
+func testFileInfoGetPostsWithFileExtensions(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	exe := "exe" + model.NewId()
+	bat := "bat" + model.NewId()
+
+	createPost := func() *model.Post {
+		post, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: userId, Message: "message"})
+		require.Nil(t, err)
+		return post
+	}
+
+	var fileIds []string
+	defer func() {
+		for _, fileId := range fileIds {
+			ss.FileInfo().PermanentDelete(fileId)
+		}
+	}()
+
+	createFile := func(postId, extension string, createAt, deleteAt int64) {
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			PostId:    postId,
+			CreatorId: userId,
+			Path:      "file." + extension,
+			Extension: extension,
+			CreateAt:  createAt,
+			DeleteAt:  deleteAt,
+		})
+		require.Nil(t, err)
+		fileIds = append(fileIds, info.Id)
+	}
+
+	now := model.GetMillis()
+
+	exePost := createPost()
+	createFile(exePost.Id, exe, now, 0)
+	createFile(exePost.Id, exe, now, 0)
+
+	batPost := createPost()
+	createFile(batPost.Id, "txt", now, 0)
+	createFile(batPost.Id, bat, now, 0)
+
+	oldPost := createPost()
+	createFile(oldPost.Id, exe, now-10000, 0)
+
+	deletedFilePost := createPost()
+	createFile(deletedFilePost.Id, exe, now, now)
+
+	deletedPost := createPost()
+	createFile(deletedPost.Id, exe, now, 0)
+	require.Nil(t, ss.Post().Delete(deletedPost.Id, model.GetMillis(), userId))
+
+	createFile("", exe, now, 0)
+
+	t.Run("should return posts with the given extension", func(t *testing.T) {
+		postIds, err := ss.FileInfo().GetPostsWithFileExtensions([]string{exe}, now, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{exePost.Id}, postIds)
+	})
+
+	t.Run("should return posts with any of the given extensions", func(t *testing.T) {
+		postIds, err := ss.FileInfo().GetPostsWithFileExtensions([]string{exe, bat}, now, 0, 100)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{exePost.Id, batPost.Id}, postIds)
+	})
+
+	t.Run("should return older posts", func(t *testing.T) {
+		postIds, err := ss.FileInfo().GetPostsWithFileExtensions([]string{exe}, 0, 0, 100)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{exePost.Id, oldPost.Id}, postIds)
+	})
+
+	t.Run("should page the results", func(t *testing.T) {
+		postIds, err := ss.FileInfo().GetPostsWithFileExtensions([]string{exe, bat}, 0, 0, 2)
+		require.NoError(t, err)
+		require.Len(t, postIds, 2)
+
+		nextPostIds, err := ss.FileInfo().GetPostsWithFileExtensions([]string{exe, bat}, 0, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, nextPostIds, 1)
+
+		assert.ElementsMatch(t, []string{exePost.Id, batPost.Id, oldPost.Id}, append(postIds, nextPostIds...))
+	})
+}
@@ -29,6 +29,7 @@ func TestGroupStore(t *testing.T, ss store.Store) {
 	t.Run("GetByUser", func(t *testing.T) { testGroupStoreGetByUser(t, ss) })
 	t.Run("Update", func(t *testing.T) { testGroupStoreUpdate(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testGroupStoreDelete(t, ss) })
+	t.Run("NameConflictsWithUsername", func(t *testing.T) { testGroupStoreNameConflictsWithUsername(t, ss) })
 
 	t.Run("GetMemberUsers", func(t *testing.T) { testGroupGetMemberUsers(t, ss) })
 	t.Run("GetMemberUsersPage", func(t *testing.T) { testGroupGetMemberUsersPage(t, ss) })
@@ -507,6 +508,83 @@ func testGroupStoreUpdate(t *testing.T, ss store.Store) {
 	require.Zero(t, d4.DeleteAt)
 }
 
+func testGroupStoreNameConflictsWithUsername(t *testing.T, ss store.Store) {
+	user, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u" + model.NewId(),
+	})
+	require.Nil(t, err)
+
+	// Cannot create a group named after a user
+	_, err = ss.Group().Create(&model.Group{
+		Name:        model.NewString(user.Username),
+		DisplayName: model.NewId(),
+		Source:      model.GroupSourceCustom,
+		RemoteId:    model.NewId(),
+	})
+	require.NotNil(t, err)
+	require.Equal(t, "store.sql_group.name_conflicts_with_username", err.Id)
+
+	group, err := ss.Group().Create(&model.Group{
+		Name:        model.NewString("g" + model.NewId()),
+		DisplayName: model.NewId(),
+		Source:      model.GroupSourceCustom,
+		RemoteId:    model.NewId(),
+	})
+	require.Nil(t, err)
+
+	// Cannot rename a group after a user
+	group.Name = model.NewString(user.Username)
+	_, err = ss.Group().Update(group)
+	require.NotNil(t, err)
+	require.Equal(t, "store.sql_group.name_conflicts_with_username", err.Id)
+
+	// Cannot create a user named after a group
+	groupName := "g" + model.NewId()
+	group.Name = model.NewString(groupName)
+	_, err = ss.Group().Update(group)
+	require.Nil(t, err)
+
+	_, err = ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: groupName,
+	})
+	require.NotNil(t, err)
+	require.Equal(t, "store.sql_user.username_conflicts_with_group.app_error", err.Id)
+
+	// Cannot rename a user after a group
+	user.Username = groupName
+	_, err = ss.User().Update(user, false)
+	require.NotNil(t, err)
+	require.Equal(t, "store.sql_user.username_conflicts_with_group.app_error", err.Id)
+
+	// Can take the name of a deleted group
+	_, err = ss.Group().Delete(group.Id)
+	require.Nil(t, err)
+
+	_, err = ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: groupName,
+	})
+	require.Nil(t, err)
+
+	// Can take the name of an LDAP group
+	ldapGroupName := "g" + model.NewId()
+	_, err = ss.Group().Create(&model.Group{
+		Name:        model.NewString(ldapGroupName),
+		DisplayName: model.NewId(),
+		Source:      model.GroupSourceLdap,
+		RemoteId:    model.NewId(),
+	})
+	require.Nil(t, err)
+
+	_, err = ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: ldapGroupName,
+	})
+	require.Nil(t, err)
+}
+
 func testGroupStoreDelete(t *testing.T, ss store.Store) {
 	// Save a group
 	g1 := &model.Group{
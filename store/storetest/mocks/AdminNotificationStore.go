@@ -0,0 +1,107 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost-server/v5/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminNotificationStore is an autogenerated mock type for the AdminNotificationStore type
+type AdminNotificationStore struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: offset, limit
+func (_m *AdminNotificationStore) GetAll(offset int, limit int) ([]*model.AdminNotification, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.AdminNotification
+	if rf, ok := ret.Get(0).(func(int, int) []*model.AdminNotification); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AdminNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatest provides a mock function with given fields: notificationType, message, since
+func (_m *AdminNotificationStore) GetLatest(notificationType string, message string, since int64) (*model.AdminNotification, error) {
+	ret := _m.Called(notificationType, message, since)
+
+	var r0 *model.AdminNotification
+	if rf, ok := ret.Get(0).(func(string, string, int64) *model.AdminNotification); ok {
+		r0 = rf(notificationType, message, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AdminNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(notificationType, message, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: notification
+func (_m *AdminNotificationStore) Save(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	ret := _m.Called(notification)
+
+	var r0 *model.AdminNotification
+	if rf, ok := ret.Get(0).(func(*model.AdminNotification) *model.AdminNotification); ok {
+		r0 = rf(notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AdminNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.AdminNotification) error); ok {
+		r1 = rf(notification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: notification
+func (_m *AdminNotificationStore) Update(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	ret := _m.Called(notification)
+
+	var r0 *model.AdminNotification
+	if rf, ok := ret.Get(0).(func(*model.AdminNotification) *model.AdminNotification); ok {
+		r0 = rf(notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AdminNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.AdminNotification) error); ok {
+		r1 = rf(notification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
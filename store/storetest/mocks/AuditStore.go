@@ -64,3 +64,17 @@ func (_m *AuditStore) Save(audit *model.Audit) error {
 
 	return r0
 }
+
+// StreamToSyslog provides a mock function with given fields: addr, protocol, caCert
+func (_m *AuditStore) StreamToSyslog(addr string, protocol string, caCert []byte) error {
+	ret := _m.Called(addr, protocol, caCert)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []byte) error); ok {
+		r0 = rf(addr, protocol, caCert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost-server/v5/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChannelBookmarkStore is an autogenerated mock type for the ChannelBookmarkStore type
+type ChannelBookmarkStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *ChannelBookmarkStore) Delete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id, includeDeleted
+func (_m *ChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	ret := _m.Called(id, includeDeleted)
+
+	var r0 *model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string, bool) *model.ChannelBookmark); ok {
+		r0 = rf(id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarksForChannel provides a mock function with given fields: channelId
+func (_m *ChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
+	ret := _m.Called(channelId)
+
+	var r0 []*model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelBookmark); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarksForFile provides a mock function with given fields: fileId
+func (_m *ChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
+	ret := _m.Called(fileId)
+
+	var r0 []*model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelBookmark); ok {
+		r0 = rf(fileId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fileId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteByChannel provides a mock function with given fields: channelId
+func (_m *ChannelBookmarkStore) PermanentDeleteByChannel(channelId string) error {
+	ret := _m.Called(channelId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(channelId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: bookmark
+func (_m *ChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	ret := _m.Called(bookmark)
+
+	var r0 *model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(*model.ChannelBookmark) *model.ChannelBookmark); ok {
+		r0 = rf(bookmark)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.ChannelBookmark) error); ok {
+		r1 = rf(bookmark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: bookmark
+func (_m *ChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	ret := _m.Called(bookmark)
+
+	var r0 *model.ChannelBookmark
+	if rf, ok := ret.Get(0).(func(*model.ChannelBookmark) *model.ChannelBookmark); ok {
+		r0 = rf(bookmark)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelBookmark)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.ChannelBookmark) error); ok {
+		r1 = rf(bookmark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSortOrder provides a mock function with given fields: channelId, bookmarkIds
+func (_m *ChannelBookmarkStore) UpdateSortOrder(channelId string, bookmarkIds []string) error {
+	ret := _m.Called(channelId, bookmarkIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(channelId, bookmarkIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
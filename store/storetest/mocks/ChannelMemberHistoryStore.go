@@ -14,6 +14,29 @@ type ChannelMemberHistoryStore struct {
 	mock.Mock
 }
 
+// GetForChannel provides a mock function with given fields: channelId, since, until, offset, limit
+func (_m *ChannelMemberHistoryStore) GetForChannel(channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelMemberHistoryResult, error) {
+	ret := _m.Called(channelId, since, until, offset, limit)
+
+	var r0 []*model.ChannelMemberHistoryResult
+	if rf, ok := ret.Get(0).(func(string, int64, int64, int, int) []*model.ChannelMemberHistoryResult); ok {
+		r0 = rf(channelId, since, until, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistoryResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64, int, int) error); ok {
+		r1 = rf(channelId, since, until, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsersInChannelDuring provides a mock function with given fields: startTime, endTime, channelId
 func (_m *ChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
 	ret := _m.Called(startTime, endTime, channelId)
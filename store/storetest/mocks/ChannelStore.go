@@ -782,6 +782,29 @@ func (_m *ChannelStore) GetDeletedByName(team_id string, name string) (*model.Ch
 	return r0, r1
 }
 
+// GetDeletedByTeamForUserSince provides a mock function with given fields: teamId, userId, since
+func (_m *ChannelStore) GetDeletedByTeamForUserSince(teamId string, userId string, since int64) (model.ChannelList, error) {
+	ret := _m.Called(teamId, userId, since)
+
+	var r0 model.ChannelList
+	if rf, ok := ret.Get(0).(func(string, string, int64) model.ChannelList); ok {
+		r0 = rf(teamId, userId, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(teamId, userId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetForPost provides a mock function with given fields: postId
 func (_m *ChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
 	ret := _m.Called(postId)
@@ -853,6 +876,57 @@ func (_m *ChannelStore) GetGuestCount(channelId string, allowFromCache bool) (in
 	return r0, r1
 }
 
+// GetInactiveChannelsForTeam provides a mock function with given fields: teamID, inactiveSince, warned, afterID, limit
+func (_m *ChannelStore) GetInactiveChannelsForTeam(teamID string, inactiveSince int64, warned bool, afterID string, limit int) ([]*model.Channel, error) {
+	ret := _m.Called(teamID, inactiveSince, warned, afterID, limit)
+
+	var r0 []*model.Channel
+	if rf, ok := ret.Get(0).(func(string, int64, bool, string, int) []*model.Channel); ok {
+		r0 = rf(teamID, inactiveSince, warned, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, bool, string, int) error); ok {
+		r1 = rf(teamID, inactiveSince, warned, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastViewedPostForUser provides a mock function with given fields: userId, channelId
+func (_m *ChannelStore) GetLastViewedPostForUser(userId string, channelId string) (string, int64, error) {
+	ret := _m.Called(userId, channelId)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(userId, channelId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, string) int64); ok {
+		r1 = rf(userId, channelId)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(userId, channelId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetMember provides a mock function with given fields: channelId, userId
 func (_m *ChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
 	ret := _m.Called(channelId, userId)
@@ -1186,13 +1260,13 @@ func (_m *ChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds
 	return r0, r1
 }
 
-// GetPublicChannelsForTeam provides a mock function with given fields: teamId, offset, limit
-func (_m *ChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
-	ret := _m.Called(teamId, offset, limit)
+// GetPublicChannelsForTeam provides a mock function with given fields: teamId, offset, limit, includeHidden
+func (_m *ChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError) {
+	ret := _m.Called(teamId, offset, limit, includeHidden)
 
 	var r0 *model.ChannelList
-	if rf, ok := ret.Get(0).(func(string, int, int) *model.ChannelList); ok {
-		r0 = rf(teamId, offset, limit)
+	if rf, ok := ret.Get(0).(func(string, int, int, bool) *model.ChannelList); ok {
+		r0 = rf(teamId, offset, limit, includeHidden)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.ChannelList)
@@ -1200,8 +1274,8 @@ func (_m *ChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limi
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string, int, int) *model.AppError); ok {
-		r1 = rf(teamId, offset, limit)
+	if rf, ok := ret.Get(1).(func(string, int, int, bool) *model.AppError); ok {
+		r1 = rf(teamId, offset, limit, includeHidden)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
@@ -1211,6 +1285,73 @@ func (_m *ChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limi
 	return r0, r1
 }
 
+// GetRecentDirectChannels provides a mock function with given fields: userID, limit
+func (_m *ChannelStore) GetRecentDirectChannels(userID string, limit int) ([]*model.Channel, error) {
+	ret := _m.Called(userID, limit)
+
+	var r0 []*model.Channel
+	if rf, ok := ret.Get(0).(func(string, int) []*model.Channel); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecentlyDeleted provides a mock function with given fields: teamId, offset, limit
+func (_m *ChannelStore) GetRecentlyDeleted(teamId string, offset int, limit int) ([]*model.Channel, error) {
+	ret := _m.Called(teamId, offset, limit)
+
+	var r0 []*model.Channel
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.Channel); ok {
+		r0 = rf(teamId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(teamId, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRootPostCountSince provides a mock function with given fields: channelId, since
+func (_m *ChannelStore) GetRootPostCountSince(channelId string, since int64) (int64, error) {
+	ret := _m.Called(channelId, since)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(channelId, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(channelId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSidebarCategories provides a mock function with given fields: userId, teamId
 func (_m *ChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, *model.AppError) {
 	ret := _m.Called(userId, teamId)
@@ -1311,6 +1452,29 @@ func (_m *ChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *mod
 	return r0, r1
 }
 
+// GetTeamChannelsWithActivity provides a mock function with given fields: teamId, offset, limit, opts
+func (_m *ChannelStore) GetTeamChannelsWithActivity(teamId string, offset int, limit int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, error) {
+	ret := _m.Called(teamId, offset, limit, opts)
+
+	var r0 []*model.ChannelWithActivity
+	if rf, ok := ret.Get(0).(func(string, int, int, model.ChannelActivityOpts) []*model.ChannelWithActivity); ok {
+		r0 = rf(teamId, offset, limit, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelWithActivity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int, model.ChannelActivityOpts) error); ok {
+		r1 = rf(teamId, offset, limit, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GroupSyncedChannelCount provides a mock function with given fields:
 func (_m *ChannelStore) GroupSyncedChannelCount() (int64, *model.AppError) {
 	ret := _m.Called()
@@ -1718,6 +1882,29 @@ func (_m *ChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*
 	return r0, r1
 }
 
+// SaveWithCreatorLimit provides a mock function with given fields: channel, maxChannelsPerTeam, maxChannelsPerCreator, createdSince
+func (_m *ChannelStore) SaveWithCreatorLimit(channel *model.Channel, maxChannelsPerTeam int64, maxChannelsPerCreator int64, createdSince int64) (*model.Channel, error) {
+	ret := _m.Called(channel, maxChannelsPerTeam, maxChannelsPerCreator, createdSince)
+
+	var r0 *model.Channel
+	if rf, ok := ret.Get(0).(func(*model.Channel, int64, int64, int64) *model.Channel); ok {
+		r0 = rf(channel, maxChannelsPerTeam, maxChannelsPerCreator, createdSince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Channel, int64, int64, int64) error); ok {
+		r1 = rf(channel, maxChannelsPerTeam, maxChannelsPerCreator, createdSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchAllChannels provides a mock function with given fields: term, opts
 func (_m *ChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, *model.AppError) {
 	ret := _m.Called(term, opts)
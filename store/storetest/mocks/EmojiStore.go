@@ -14,6 +14,27 @@ type EmojiStore struct {
 	mock.Mock
 }
 
+// CountByCreator provides a mock function with given fields: creatorId
+func (_m *EmojiStore) CountByCreator(creatorId string) (int64, error) {
+	ret := _m.Called(creatorId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(creatorId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(creatorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: emoji, time
 func (_m *EmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	ret := _m.Called(emoji, time)
@@ -143,6 +164,29 @@ func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	return r0, r1
 }
 
+// SaveWithCreatorLimit provides a mock function with given fields: emoji, maxEmojiPerCreator
+func (_m *EmojiStore) SaveWithCreatorLimit(emoji *model.Emoji, maxEmojiPerCreator int64) (*model.Emoji, error) {
+	ret := _m.Called(emoji, maxEmojiPerCreator)
+
+	var r0 *model.Emoji
+	if rf, ok := ret.Get(0).(func(*model.Emoji, int64) *model.Emoji); ok {
+		r0 = rf(emoji, maxEmojiPerCreator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Emoji)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Emoji, int64) error); ok {
+		r1 = rf(emoji, maxEmojiPerCreator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: name, prefixOnly, limit
 func (_m *EmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	ret := _m.Called(name, prefixOnly, limit)
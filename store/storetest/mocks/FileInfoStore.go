@@ -158,6 +158,29 @@ func (_m *FileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.Ap
 	return r0, r1
 }
 
+// GetPostsWithFileExtensions provides a mock function with given fields: extensions, since, offset, limit
+func (_m *FileInfoStore) GetPostsWithFileExtensions(extensions []string, since int64, offset int, limit int) ([]string, error) {
+	ret := _m.Called(extensions, since, offset, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string, int64, int, int) []string); ok {
+		r0 = rf(extensions, since, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, int64, int, int) error); ok {
+		r1 = rf(extensions, since, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWithOptions provides a mock function with given fields: page, perPage, opt
 func (_m *FileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError) {
 	ret := _m.Called(page, perPage, opt)
@@ -133,6 +133,31 @@ func (_m *PluginStore) Get(pluginId string, key string) (*model.PluginKeyValue,
 	return r0, r1
 }
 
+// GetFromMaster provides a mock function with given fields: pluginId, key
+func (_m *PluginStore) GetFromMaster(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
+	ret := _m.Called(pluginId, key)
+
+	var r0 *model.PluginKeyValue
+	if rf, ok := ret.Get(0).(func(string, string) *model.PluginKeyValue); ok {
+		r0 = rf(pluginId, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PluginKeyValue)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string) *model.AppError); ok {
+		r1 = rf(pluginId, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: pluginId, page, perPage
 func (_m *PluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
 	ret := _m.Called(pluginId, page, perPage)
@@ -0,0 +1,96 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost-server/v5/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostShareTokenStore is an autogenerated mock type for the PostShareTokenStore type
+type PostShareTokenStore struct {
+	mock.Mock
+}
+
+// DeleteExpired provides a mock function with given fields: expiryTime
+func (_m *PostShareTokenStore) DeleteExpired(expiryTime int64) error {
+	ret := _m.Called(expiryTime)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(expiryTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteForChannel provides a mock function with given fields: channelId
+func (_m *PostShareTokenStore) DeleteForChannel(channelId string) (int64, error) {
+	ret := _m.Called(channelId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(channelId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: token
+func (_m *PostShareTokenStore) Get(token string) (*model.PostShareToken, error) {
+	ret := _m.Called(token)
+
+	var r0 *model.PostShareToken
+	if rf, ok := ret.Get(0).(func(string) *model.PostShareToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostShareToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: token
+func (_m *PostShareTokenStore) Save(token *model.PostShareToken) (*model.PostShareToken, error) {
+	ret := _m.Called(token)
+
+	var r0 *model.PostShareToken
+	if rf, ok := ret.Get(0).(func(*model.PostShareToken) *model.PostShareToken); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostShareToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.PostShareToken) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
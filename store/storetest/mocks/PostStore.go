@@ -158,6 +158,29 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterId strin
 	return r0, r1
 }
 
+// GetEditHistory provides a mock function with given fields: postId
+func (_m *PostStore) GetEditHistory(postId string) ([]*model.PostEdit, error) {
+	ret := _m.Called(postId)
+
+	var r0 []*model.PostEdit
+	if rf, ok := ret.Get(0).(func(string) []*model.PostEdit); ok {
+		r0 = rf(postId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostEdit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(postId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEtag provides a mock function with given fields: channelId, allowFromCache
 func (_m *PostStore) GetEtag(channelId string, allowFromCache bool) string {
 	ret := _m.Called(channelId, allowFromCache)
@@ -747,6 +770,29 @@ func (_m *PostStore) PermanentDeleteByUser(userId string) *model.AppError {
 	return r0
 }
 
+// PermanentDeleteEditOriginalsBatch provides a mock function with given fields: endTime, limit
+func (_m *PostStore) PermanentDeleteEditOriginalsBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int64) *model.AppError); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: post
 func (_m *PostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	ret := _m.Called(post)
@@ -772,6 +818,20 @@ func (_m *PostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	return r0, r1
 }
 
+// SaveEditHistory provides a mock function with given fields: original
+func (_m *PostStore) SaveEditHistory(original *model.Post) error {
+	ret := _m.Called(original)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Post) error); ok {
+		r0 = rf(original)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveMultiple provides a mock function with given fields: posts
 func (_m *PostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, *model.AppError) {
 	ret := _m.Called(posts)
@@ -97,6 +97,29 @@ func (_m *ReactionStore) GetForPost(postId string, allowFromCache bool) ([]*mode
 	return r0, r1
 }
 
+// GetForPosts provides a mock function with given fields: postIds
+func (_m *ReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	ret := _m.Called(postIds)
+
+	var r0 map[string][]*model.Reaction
+	if rf, ok := ret.Get(0).(func([]string) map[string][]*model.Reaction); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*model.Reaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteBatch provides a mock function with given fields: endTime, limit
 func (_m *ReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	ret := _m.Called(endTime, limit)
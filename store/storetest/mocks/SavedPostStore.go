@@ -0,0 +1,253 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost-server/v5/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SavedPostStore is an autogenerated mock type for the SavedPostStore type
+type SavedPostStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userId, postId
+func (_m *SavedPostStore) Delete(userId string, postId string) error {
+	ret := _m.Called(userId, postId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userId, postId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteForPost provides a mock function with given fields: postId
+func (_m *SavedPostStore) DeleteForPost(postId string) error {
+	ret := _m.Called(postId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(postId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userId, postId
+func (_m *SavedPostStore) Get(userId string, postId string) (*model.SavedPost, error) {
+	ret := _m.Called(userId, postId)
+
+	var r0 *model.SavedPost
+	if rf, ok := ret.Get(0).(func(string, string) *model.SavedPost); ok {
+		r0 = rf(userId, postId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, postId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForPost provides a mock function with given fields: postId
+func (_m *SavedPostStore) GetForPost(postId string) ([]*model.SavedPost, error) {
+	ret := _m.Called(postId)
+
+	var r0 []*model.SavedPost
+	if rf, ok := ret.Get(0).(func(string) []*model.SavedPost); ok {
+		r0 = rf(postId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(postId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForPosts provides a mock function with given fields: userId, postIds
+func (_m *SavedPostStore) GetForPosts(userId string, postIds []string) (map[string]*model.SavedPost, error) {
+	ret := _m.Called(userId, postIds)
+
+	var r0 map[string]*model.SavedPost
+	if rf, ok := ret.Get(0).(func(string, []string) map[string]*model.SavedPost); ok {
+		r0 = rf(userId, postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(userId, postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userId, label, offset, limit
+func (_m *SavedPostStore) GetForUser(userId string, label *string, offset int, limit int) ([]*model.SavedPost, error) {
+	ret := _m.Called(userId, label, offset, limit)
+
+	var r0 []*model.SavedPost
+	if rf, ok := ret.Get(0).(func(string, *string, int, int) []*model.SavedPost); ok {
+		r0 = rf(userId, label, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *string, int, int) error); ok {
+		r1 = rf(userId, label, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLabelsForUser provides a mock function with given fields: userId
+func (_m *SavedPostStore) GetLabelsForUser(userId string) ([]string, error) {
+	ret := _m.Called(userId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateFlaggedPosts provides a mock function with given fields: afterUserId, afterPostId, limit
+func (_m *SavedPostStore) MigrateFlaggedPosts(afterUserId string, afterPostId string, limit int) (int64, string, string, error) {
+	ret := _m.Called(afterUserId, afterPostId, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, int) int64); ok {
+		r0 = rf(afterUserId, afterPostId, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, int) string); ok {
+		r1 = rf(afterUserId, afterPostId, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(string, string, int) string); ok {
+		r2 = rf(afterUserId, afterPostId, limit)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(string, string, int) error); ok {
+		r3 = rf(afterUserId, afterPostId, limit)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// PermanentDeleteByUser provides a mock function with given fields: userId
+func (_m *SavedPostStore) PermanentDeleteByUser(userId string) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: savedPost
+func (_m *SavedPostStore) Save(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	ret := _m.Called(savedPost)
+
+	var r0 *model.SavedPost
+	if rf, ok := ret.Get(0).(func(*model.SavedPost) *model.SavedPost); ok {
+		r0 = rf(savedPost)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedPost) error); ok {
+		r1 = rf(savedPost)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: savedPost
+func (_m *SavedPostStore) Update(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	ret := _m.Called(savedPost)
+
+	var r0 *model.SavedPost
+	if rf, ok := ret.Get(0).(func(*model.SavedPost) *model.SavedPost); ok {
+		r0 = rf(savedPost)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedPost) error); ok {
+		r1 = rf(savedPost)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost-server/v5/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SavedSearchStore is an autogenerated mock type for the SavedSearchStore type
+type SavedSearchStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *SavedSearchStore) Delete(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: id
+func (_m *SavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(string) *model.SavedSearch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userId
+func (_m *SavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	ret := _m.Called(userId)
+
+	var r0 []*model.SavedSearch
+	if rf, ok := ret.Get(0).(func(string) []*model.SavedSearch); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetToEvaluate provides a mock function with given fields: evaluatedBefore, limit
+func (_m *SavedSearchStore) GetToEvaluate(evaluatedBefore int64, limit int) ([]*model.SavedSearch, error) {
+	ret := _m.Called(evaluatedBefore, limit)
+
+	var r0 []*model.SavedSearch
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.SavedSearch); ok {
+		r0 = rf(evaluatedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(evaluatedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteByUser provides a mock function with given fields: userId
+func (_m *SavedSearchStore) PermanentDeleteByUser(userId string) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: savedSearch
+func (_m *SavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(savedSearch)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(savedSearch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(savedSearch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: savedSearch
+func (_m *SavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(savedSearch)
+
+	var r0 *model.SavedSearch
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(savedSearch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(savedSearch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastEvaluatedAt provides a mock function with given fields: id, lastEvaluatedAt
+func (_m *SavedSearchStore) UpdateLastEvaluatedAt(id string, lastEvaluatedAt int64) error {
+	ret := _m.Called(id, lastEvaluatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, lastEvaluatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -125,6 +125,29 @@ func (_m *SchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model
 	return r0, r1
 }
 
+// GetChannelPolicies provides a mock function with given fields: offset, limit
+func (_m *SchemeStore) GetChannelPolicies(offset int, limit int) ([]*model.ChannelPolicyWithChannelInfo, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.ChannelPolicyWithChannelInfo
+	if rf, ok := ret.Get(0).(func(int, int) []*model.ChannelPolicyWithChannelInfo); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelPolicyWithChannelInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByName provides a mock function with given fields: schemeName
 func (_m *SchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
 	ret := _m.Called(schemeName)
@@ -40,6 +40,27 @@ func (_m *SessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	_m.Called(expiryTime, batchSize)
 }
 
+// CountActiveWebSessionsCreatedBefore provides a mock function with given fields: createdBefore
+func (_m *SessionStore) CountActiveWebSessionsCreatedBefore(createdBefore int64) (int64, error) {
+	ret := _m.Called(createdBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(createdBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(createdBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: sessionIdOrToken
 func (_m *SessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
 	ret := _m.Called(sessionIdOrToken)
@@ -18,6 +18,22 @@ type SqlStore struct {
 	mock.Mock
 }
 
+// AdminNotification provides a mock function with given fields:
+func (_m *SqlStore) AdminNotification() store.AdminNotificationStore {
+	ret := _m.Called()
+
+	var r0 store.AdminNotificationStore
+	if rf, ok := ret.Get(0).(func() store.AdminNotificationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AdminNotificationStore)
+		}
+	}
+
+	return r0
+}
+
 // AlterColumnDefaultIfExists provides a mock function with given fields: tableName, columnName, mySqlColDefault, postgresColDefault
 func (_m *SqlStore) AlterColumnDefaultIfExists(tableName string, columnName string, mySqlColDefault *string, postgresColDefault *string) bool {
 	ret := _m.Called(tableName, columnName, mySqlColDefault, postgresColDefault)
@@ -108,6 +124,22 @@ func (_m *SqlStore) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelBookmark provides a mock function with given fields:
+func (_m *SqlStore) ChannelBookmark() store.ChannelBookmarkStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelBookmarkStore
+	if rf, ok := ret.Get(0).(func() store.ChannelBookmarkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelBookmarkStore)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *SqlStore) Close() {
 	_m.Called()
@@ -582,6 +614,22 @@ func (_m *SqlStore) Post() store.PostStore {
 	return r0
 }
 
+// PostShareToken provides a mock function with given fields:
+func (_m *SqlStore) PostShareToken() store.PostShareTokenStore {
+	ret := _m.Called()
+
+	var r0 store.PostShareTokenStore
+	if rf, ok := ret.Get(0).(func() store.PostShareTokenStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostShareTokenStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *SqlStore) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -686,6 +734,38 @@ func (_m *SqlStore) Role() store.RoleStore {
 	return r0
 }
 
+// SavedPost provides a mock function with given fields:
+func (_m *SqlStore) SavedPost() store.SavedPostStore {
+	ret := _m.Called()
+
+	var r0 store.SavedPostStore
+	if rf, ok := ret.Get(0).(func() store.SavedPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedPostStore)
+		}
+	}
+
+	return r0
+}
+
+// SavedSearch provides a mock function with given fields:
+func (_m *SqlStore) SavedSearch() store.SavedSearchStore {
+	ret := _m.Called()
+
+	var r0 store.SavedSearchStore
+	if rf, ok := ret.Get(0).(func() store.SavedSearchStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedSearchStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *SqlStore) Scheme() store.SchemeStore {
 	ret := _m.Called()
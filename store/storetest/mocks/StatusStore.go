@@ -64,6 +64,31 @@ func (_m *StatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppEr
 	return r0, r1
 }
 
+// GetByTeam provides a mock function with given fields: teamId
+func (_m *StatusStore) GetByTeam(teamId string) ([]*model.Status, *model.AppError) {
+	ret := _m.Called(teamId)
+
+	var r0 []*model.Status
+	if rf, ok := ret.Get(0).(func(string) []*model.Status); ok {
+		r0 = rf(teamId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Status)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(teamId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetTotalActiveUsersCount provides a mock function with given fields:
 func (_m *StatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
 	ret := _m.Called()
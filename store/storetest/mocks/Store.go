@@ -18,6 +18,22 @@ type Store struct {
 	mock.Mock
 }
 
+// AdminNotification provides a mock function with given fields:
+func (_m *Store) AdminNotification() store.AdminNotificationStore {
+	ret := _m.Called()
+
+	var r0 store.AdminNotificationStore
+	if rf, ok := ret.Get(0).(func() store.AdminNotificationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AdminNotificationStore)
+		}
+	}
+
+	return r0
+}
+
 // Audit provides a mock function with given fields:
 func (_m *Store) Audit() store.AuditStore {
 	ret := _m.Called()
@@ -66,6 +82,22 @@ func (_m *Store) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelBookmark provides a mock function with given fields:
+func (_m *Store) ChannelBookmark() store.ChannelBookmarkStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelBookmarkStore
+	if rf, ok := ret.Get(0).(func() store.ChannelBookmarkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelBookmarkStore)
+		}
+	}
+
+	return r0
+}
+
 // ChannelMemberHistory provides a mock function with given fields:
 func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	ret := _m.Called()
@@ -377,6 +409,22 @@ func (_m *Store) Post() store.PostStore {
 	return r0
 }
 
+// PostShareToken provides a mock function with given fields:
+func (_m *Store) PostShareToken() store.PostShareTokenStore {
+	ret := _m.Called()
+
+	var r0 store.PostShareTokenStore
+	if rf, ok := ret.Get(0).(func() store.PostShareTokenStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostShareTokenStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -430,6 +478,38 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// SavedPost provides a mock function with given fields:
+func (_m *Store) SavedPost() store.SavedPostStore {
+	ret := _m.Called()
+
+	var r0 store.SavedPostStore
+	if rf, ok := ret.Get(0).(func() store.SavedPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedPostStore)
+		}
+	}
+
+	return r0
+}
+
+// SavedSearch provides a mock function with given fields:
+func (_m *Store) SavedSearch() store.SavedSearchStore {
+	ret := _m.Called()
+
+	var r0 store.SavedSearchStore
+	if rf, ok := ret.Get(0).(func() store.SavedSearchStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedSearchStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
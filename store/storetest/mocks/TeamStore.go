@@ -375,6 +375,29 @@ func (_m *TeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team
 	return r0, r1
 }
 
+// GetAllWithAutoJoinDomains provides a mock function with given fields:
+func (_m *TeamStore) GetAllWithAutoJoinDomains() ([]*model.Team, error) {
+	ret := _m.Called()
+
+	var r0 []*model.Team
+	if rf, ok := ret.Get(0).(func() []*model.Team); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Team)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByInviteId provides a mock function with given fields: inviteId
 func (_m *TeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
 	ret := _m.Called(inviteId)
@@ -600,6 +623,29 @@ func (_m *TeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMember
 	return r0, r1
 }
 
+// GetTeamStats provides a mock function with given fields: teamID
+func (_m *TeamStore) GetTeamStats(teamID string) (*model.TeamStats, error) {
+	ret := _m.Called(teamID)
+
+	var r0 *model.TeamStats
+	if rf, ok := ret.Get(0).(func(string) *model.TeamStats); ok {
+		r0 = rf(teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamsByScheme provides a mock function with given fields: schemeId, offset, limit
 func (_m *TeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
 	ret := _m.Called(schemeId, offset, limit)
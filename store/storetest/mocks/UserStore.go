@@ -932,20 +932,20 @@ func (_m *UserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *model.App
 	return r0, r1
 }
 
-// GetUnreadCount provides a mock function with given fields: userId
-func (_m *UserStore) GetUnreadCount(userId string) (int64, *model.AppError) {
-	ret := _m.Called(userId)
+// GetUnreadCount provides a mock function with given fields: userId, excludeMutedDirect
+func (_m *UserStore) GetUnreadCount(userId string, excludeMutedDirect bool) (int64, *model.AppError) {
+	ret := _m.Called(userId, excludeMutedDirect)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(userId)
+	if rf, ok := ret.Get(0).(func(string, bool) int64); ok {
+		r0 = rf(userId, excludeMutedDirect)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
-		r1 = rf(userId)
+	if rf, ok := ret.Get(1).(func(string, bool) *model.AppError); ok {
+		r1 = rf(userId, excludeMutedDirect)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
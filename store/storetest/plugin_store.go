@@ -21,6 +21,7 @@ func TestPluginStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("CompareAndDelete", func(t *testing.T) { testPluginCompareAndDelete(t, ss, s) })
 	t.Run("SetWithOptions", func(t *testing.T) { testPluginSetWithOptions(t, ss, s) })
 	t.Run("Get", func(t *testing.T) { testPluginGet(t, ss) })
+	t.Run("GetFromMaster", func(t *testing.T) { testPluginGetFromMaster(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testPluginDelete(t, ss) })
 	t.Run("DeleteAllForPlugin", func(t *testing.T) { testPluginDeleteAllForPlugin(t, ss) })
 	t.Run("DeleteAllExpired", func(t *testing.T) { testPluginDeleteAllExpired(t, ss) })
@@ -864,6 +865,28 @@ func testPluginGet(t *testing.T, ss store.Store) {
 	})
 }
 
+func testPluginGetFromMaster(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+	key := model.NewId()
+
+	kv, err := ss.Plugin().GetFromMaster(pluginId, key)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Nil(t, kv)
+
+	_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{
+		PluginId: pluginId,
+		Key:      key,
+		Value:    []byte("value"),
+		ExpireAt: 0,
+	})
+	require.Nil(t, err)
+
+	kv, err = ss.Plugin().GetFromMaster(pluginId, key)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("value"), kv.Value)
+}
+
 func testPluginDelete(t *testing.T, ss store.Store) {
 	t.Run("no matching key value", func(t *testing.T) {
 		pluginId, tearDown := setupKVs(t, ss)
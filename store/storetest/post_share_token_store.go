@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostShareTokenStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testPostShareTokenStoreSaveAndGet(t, ss) })
+	t.Run("DeleteForChannel", func(t *testing.T) { testPostShareTokenStoreDeleteForChannel(t, ss) })
+	t.Run("DeleteExpired", func(t *testing.T) { testPostShareTokenStoreDeleteExpired(t, ss) })
+}
+
+func newTestPostShareToken(channelId string, expiresAt int64) *model.PostShareToken {
+	return &model.PostShareToken{
+		PostId:    model.NewId(),
+		ChannelId: channelId,
+		TeamId:    model.NewId(),
+		CreatorId: model.NewId(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func testPostShareTokenStoreSaveAndGet(t *testing.T, ss store.Store) {
+	token := newTestPostShareToken(model.NewId(), model.GetMillis()+60000)
+	token.IncludeThread = true
+
+	token, err := ss.PostShareToken().Save(token)
+	require.Nil(t, err)
+	assert.True(t, model.IsValidId(token.Token))
+	assert.NotZero(t, token.CreateAt)
+
+	t.Run("should get a post share token", func(t *testing.T) {
+		fetched, err := ss.PostShareToken().Get(token.Token)
+		require.Nil(t, err)
+		assert.Equal(t, token, fetched)
+	})
+
+	t.Run("should not get a missing post share token", func(t *testing.T) {
+		_, err := ss.PostShareToken().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should not save an invalid post share token", func(t *testing.T) {
+		_, err := ss.PostShareToken().Save(newTestPostShareToken("junk", model.GetMillis()+60000))
+		require.NotNil(t, err)
+	})
+}
+
+func testPostShareTokenStoreDeleteForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	expiresAt := model.GetMillis() + 60000
+
+	token1, err := ss.PostShareToken().Save(newTestPostShareToken(channelId, expiresAt))
+	require.Nil(t, err)
+	token2, err := ss.PostShareToken().Save(newTestPostShareToken(channelId, expiresAt))
+	require.Nil(t, err)
+	otherToken, err := ss.PostShareToken().Save(newTestPostShareToken(model.NewId(), expiresAt))
+	require.Nil(t, err)
+
+	count, err := ss.PostShareToken().DeleteForChannel(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	for _, token := range []*model.PostShareToken{token1, token2} {
+		_, err = ss.PostShareToken().Get(token.Token)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	}
+
+	_, err = ss.PostShareToken().Get(otherToken.Token)
+	require.Nil(t, err)
+
+	count, err = ss.PostShareToken().DeleteForChannel(channelId)
+	require.Nil(t, err)
+	assert.Zero(t, count)
+}
+
+func testPostShareTokenStoreDeleteExpired(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired := newTestPostShareToken(model.NewId(), now-1000)
+	expired.CreateAt = now - 60000
+	expired, err := ss.PostShareToken().Save(expired)
+	require.Nil(t, err)
+
+	active, err := ss.PostShareToken().Save(newTestPostShareToken(model.NewId(), now+60000))
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PostShareToken().DeleteExpired(now))
+
+	_, err = ss.PostShareToken().Get(expired.Token)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.PostShareToken().Get(active.Token)
+	require.Nil(t, err)
+}
@@ -25,6 +25,7 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("Get", func(t *testing.T) { testPostStoreGet(t, ss) })
 	t.Run("GetSingle", func(t *testing.T) { testPostStoreGetSingle(t, ss) })
 	t.Run("Update", func(t *testing.T) { testPostStoreUpdate(t, ss) })
+	t.Run("EditHistory", func(t *testing.T) { testPostStoreEditHistory(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testPostStoreDelete(t, ss) })
 	t.Run("Delete1Level", func(t *testing.T) { testPostStoreDelete1Level(t, ss) })
 	t.Run("Delete2Level", func(t *testing.T) { testPostStoreDelete2Level(t, ss) })
@@ -47,6 +48,7 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testPostStorePermanentDeleteBatch(t, ss) })
+	t.Run("PermanentDeleteEditOriginalsBatch", func(t *testing.T) { testPostStorePermanentDeleteEditOriginalsBatch(t, ss) })
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, ss) })
@@ -543,6 +545,38 @@ func testPostStoreUpdate(t *testing.T, ss store.Store) {
 	require.Len(t, ro4a.FileIds, 1, "Failed to set FileIds")
 }
 
+func testPostStoreEditHistory(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "first version",
+	})
+	require.Nil(t, err)
+
+	postEdits, nErr := ss.Post().GetEditHistory(post.Id)
+	require.NoError(t, nErr)
+	assert.Empty(t, postEdits)
+
+	require.NoError(t, ss.Post().SaveEditHistory(post))
+	time.Sleep(2 * time.Millisecond)
+
+	edited := post.Clone()
+	edited.Message = "second version"
+	require.NoError(t, ss.Post().SaveEditHistory(edited))
+
+	postEdits, nErr = ss.Post().GetEditHistory(post.Id)
+	require.NoError(t, nErr)
+	require.Len(t, postEdits, 2)
+	assert.Equal(t, post.Id, postEdits[0].PostId)
+	assert.Equal(t, "first version", postEdits[0].Content)
+	assert.Equal(t, "second version", postEdits[1].Content)
+	assert.Less(t, postEdits[0].EditAt, postEdits[1].EditAt)
+
+	postEdits, nErr = ss.Post().GetEditHistory(model.NewId())
+	require.NoError(t, nErr)
+	assert.Empty(t, postEdits)
+}
+
 func testPostStoreDelete(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -2457,6 +2491,49 @@ func testPostStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
 	require.Nil(t, err, "Should have not found post 3 after purge")
 }
 
+func testPostStorePermanentDeleteEditOriginalsBatch(t *testing.T, ss store.Store) {
+	o1, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "zz" + model.NewId() + "AAAAAAAAAAA",
+	})
+	require.Nil(t, err)
+
+	original := o1.Clone()
+	edited := o1.Clone()
+	edited.Message = "zz" + model.NewId() + "BBBBBBBBBBB"
+	_, err = ss.Post().Update(edited, original)
+	require.Nil(t, err)
+
+	o2, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "zz" + model.NewId() + "AAAAAAAAAAA",
+	})
+	require.Nil(t, err)
+	err = ss.Post().Delete(o2.Id, model.GetMillis(), "")
+	require.Nil(t, err)
+
+	_, err = ss.Post().PermanentDeleteEditOriginalsBatch(original.DeleteAt, 1000)
+	require.Nil(t, err)
+
+	posts, err := ss.Post().GetPostsByIds([]string{original.Id})
+	require.Nil(t, err)
+	require.Len(t, posts, 1, "should keep originals of edits made at or after the end time")
+
+	deleted, err := ss.Post().PermanentDeleteEditOriginalsBatch(original.DeleteAt+1, 1000)
+	require.Nil(t, err)
+	require.True(t, deleted >= 1)
+
+	posts, err = ss.Post().GetPostsByIds([]string{original.Id})
+	require.Nil(t, err)
+	require.Empty(t, posts, "should have deleted the original of the edit")
+
+	posts, err = ss.Post().GetPostsByIds([]string{o1.Id, o2.Id})
+	require.Nil(t, err)
+	require.Len(t, posts, 2, "should have kept the edited post and the deleted post")
+}
+
 func testPostStoreGetOldest(t *testing.T, ss store.Store) {
 	o0 := &model.Post{}
 	o0.ChannelId = model.NewId()
@@ -2608,6 +2685,34 @@ func testPostStoreGetRepliesForExport(t *testing.T, ss store.Store) {
 	assert.Equal(t, reply1.Message, p2.Message)
 	assert.Equal(t, reply1.Username, u1.Username)
 
+	// Checking whether replies are exported in the order they were made
+	p3 := &model.Post{}
+	p3.ChannelId = c1.Id
+	p3.UserId = u1.Id
+	p3.Message = "zz" + model.NewId() + "AAAAAAAAAAA"
+	p3.CreateAt = 1003
+	p3.ParentId = p1.Id
+	p3.RootId = p1.Id
+	p3, err = ss.Post().Save(p3)
+	require.Nil(t, err)
+
+	p4 := &model.Post{}
+	p4.ChannelId = c1.Id
+	p4.UserId = u1.Id
+	p4.Message = "zz" + model.NewId() + "AAAAAAAAAAA"
+	p4.CreateAt = 1002
+	p4.ParentId = p1.Id
+	p4.RootId = p1.Id
+	p4, err = ss.Post().Save(p4)
+	require.Nil(t, err)
+
+	r1, err = ss.Post().GetRepliesForExport(p1.Id)
+	assert.Nil(t, err)
+
+	require.Len(t, r1, 3)
+	assert.Equal(t, p2.Id, r1[0].Id)
+	assert.Equal(t, p4.Id, r1[1].Id)
+	assert.Equal(t, p3.Id, r1[2].Id)
 }
 
 func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, ss store.Store, s SqlSupplier) {
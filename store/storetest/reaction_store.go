@@ -21,6 +21,7 @@ func TestReactionStore(t *testing.T, ss store.Store) {
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testReactionStorePermanentDeleteBatch(t, ss) })
 	t.Run("ReactionBulkGetForPosts", func(t *testing.T) { testReactionBulkGetForPosts(t, ss) })
+	t.Run("ReactionGetForPosts", func(t *testing.T) { testReactionGetForPosts(t, ss) })
 	t.Run("ReactionDeadlock", func(t *testing.T) { testReactionDeadlock(t, ss) })
 }
 
@@ -426,6 +427,66 @@ func testReactionBulkGetForPosts(t *testing.T, ss store.Store) {
 
 }
 
+func testReactionGetForPosts(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	post2Id := model.NewId()
+	post3Id := model.NewId()
+	otherPostId := model.NewId()
+
+	userId := model.NewId()
+
+	reactions := []*model.Reaction{
+		{
+			UserId:    userId,
+			PostId:    postId,
+			EmojiName: "smile",
+		},
+		{
+			UserId:    model.NewId(),
+			PostId:    post2Id,
+			EmojiName: "smile",
+		},
+		{
+			UserId:    userId,
+			PostId:    postId,
+			EmojiName: "angry",
+		},
+		{
+			UserId:    userId,
+			PostId:    otherPostId,
+			EmojiName: "angry",
+		},
+	}
+
+	for _, reaction := range reactions {
+		_, err := ss.Reaction().Save(reaction)
+		require.Nil(t, err)
+	}
+
+	t.Run("should group the reactions by post", func(t *testing.T) {
+		returned, err := ss.Reaction().GetForPosts([]string{postId, post2Id, post3Id})
+		require.Nil(t, err)
+		require.Len(t, returned, 3)
+
+		require.Len(t, returned[postId], 2)
+		assert.ElementsMatch(t, []string{"smile", "angry"}, []string{returned[postId][0].EmojiName, returned[postId][1].EmojiName})
+
+		require.Len(t, returned[post2Id], 1)
+		assert.Equal(t, "smile", returned[post2Id][0].EmojiName)
+
+		require.Contains(t, returned, post3Id)
+		assert.Empty(t, returned[post3Id])
+
+		assert.NotContains(t, returned, otherPostId)
+	})
+
+	t.Run("should return nothing for no posts", func(t *testing.T) {
+		returned, err := ss.Reaction().GetForPosts([]string{})
+		require.Nil(t, err)
+		assert.Empty(t, returned)
+	})
+}
+
 // testReactionDeadlock is a best-case attempt to recreate the deadlock scenario.
 // It at least deadlocks 2 times out of 5.
 func testReactionDeadlock(t *testing.T, ss store.Store) {
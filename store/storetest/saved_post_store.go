@@ -0,0 +1,296 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedPostStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testSavedPostStoreSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testSavedPostStoreUpdate(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testSavedPostStoreGetForUser(t, ss) })
+	t.Run("GetForPosts", func(t *testing.T) { testSavedPostStoreGetForPosts(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testSavedPostStoreDelete(t, ss) })
+	t.Run("DeleteForPost", func(t *testing.T) { testSavedPostStoreDeleteForPost(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testSavedPostStorePermanentDeleteByUser(t, ss) })
+	t.Run("MigrateFlaggedPosts", func(t *testing.T) { testSavedPostStoreMigrateFlaggedPosts(t, ss) })
+}
+
+func testSavedPostStoreSaveAndGet(t *testing.T, ss store.Store) {
+	savedPost, err := ss.SavedPost().Save(&model.SavedPost{
+		UserId: model.NewId(),
+		PostId: model.NewId(),
+		Label:  " Follow up ",
+		Note:   "Ask about the release date",
+	})
+	require.Nil(t, err)
+	assert.NotZero(t, savedPost.SavedAt)
+	assert.Equal(t, "Follow up", savedPost.Label)
+
+	t.Run("should get a saved post", func(t *testing.T) {
+		fetched, err := ss.SavedPost().Get(savedPost.UserId, savedPost.PostId)
+		require.Nil(t, err)
+		assert.Equal(t, savedPost, fetched)
+	})
+
+	t.Run("should not get a post saved by another user", func(t *testing.T) {
+		_, err := ss.SavedPost().Get(model.NewId(), savedPost.PostId)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should not save a post twice", func(t *testing.T) {
+		_, err := ss.SavedPost().Save(&model.SavedPost{UserId: savedPost.UserId, PostId: savedPost.PostId})
+		var cErr *store.ErrConflict
+		require.True(t, errors.As(err, &cErr))
+	})
+
+	t.Run("should not save an invalid saved post", func(t *testing.T) {
+		_, err := ss.SavedPost().Save(&model.SavedPost{UserId: savedPost.UserId, PostId: "junk"})
+		require.NotNil(t, err)
+	})
+}
+
+func testSavedPostStoreUpdate(t *testing.T, ss store.Store) {
+	savedPost, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId()})
+	require.Nil(t, err)
+
+	t.Run("should update a saved post", func(t *testing.T) {
+		savedPost.Label = "Follow up"
+		savedPost.Note = "Ask about the release date"
+		_, err := ss.SavedPost().Update(savedPost)
+		require.Nil(t, err)
+
+		fetched, err := ss.SavedPost().Get(savedPost.UserId, savedPost.PostId)
+		require.Nil(t, err)
+		assert.Equal(t, savedPost, fetched)
+	})
+
+	t.Run("should not update a missing saved post", func(t *testing.T) {
+		missing := *savedPost
+		missing.PostId = model.NewId()
+		_, err := ss.SavedPost().Update(&missing)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testSavedPostStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	save := func(label string, savedAt int64) *model.SavedPost {
+		savedPost, err := ss.SavedPost().Save(&model.SavedPost{
+			UserId:  userId,
+			PostId:  model.NewId(),
+			Label:   label,
+			SavedAt: savedAt,
+		})
+		require.Nil(t, err)
+		return savedPost
+	}
+
+	oldest := save("Follow up", 1000)
+	unlabeled := save("", 2000)
+	newest := save("Archive", 3000)
+	older := save("Follow up", 1500)
+
+	_, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId(), Label: "Other"})
+	require.Nil(t, err)
+
+	t.Run("should get the saved posts of a user, most recently saved first", func(t *testing.T) {
+		savedPosts, err := ss.SavedPost().GetForUser(userId, nil, 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.SavedPost{newest, unlabeled, older, oldest}, savedPosts)
+	})
+
+	t.Run("should page the saved posts of a user", func(t *testing.T) {
+		savedPosts, err := ss.SavedPost().GetForUser(userId, nil, 1, 2)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.SavedPost{unlabeled, older}, savedPosts)
+	})
+
+	t.Run("should get the saved posts with a label", func(t *testing.T) {
+		savedPosts, err := ss.SavedPost().GetForUser(userId, model.NewString("Follow up"), 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.SavedPost{older, oldest}, savedPosts)
+	})
+
+	t.Run("should get the saved posts without a label", func(t *testing.T) {
+		savedPosts, err := ss.SavedPost().GetForUser(userId, model.NewString(""), 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.SavedPost{unlabeled}, savedPosts)
+	})
+
+	t.Run("should get the labels of a user", func(t *testing.T) {
+		labels, err := ss.SavedPost().GetLabelsForUser(userId)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"Archive", "Follow up"}, labels)
+	})
+
+	t.Run("should get no labels for a user without saved posts", func(t *testing.T) {
+		labels, err := ss.SavedPost().GetLabelsForUser(model.NewId())
+		require.Nil(t, err)
+		assert.Empty(t, labels)
+	})
+}
+
+func testSavedPostStoreGetForPosts(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	first, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: model.NewId(), Label: "Follow up"})
+	require.Nil(t, err)
+	second, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: model.NewId()})
+	require.Nil(t, err)
+	otherUser, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId()})
+	require.Nil(t, err)
+
+	savedPosts, err := ss.SavedPost().GetForPosts(userId, []string{first.PostId, second.PostId, otherUser.PostId, model.NewId()})
+	require.Nil(t, err)
+	assert.Equal(t, map[string]*model.SavedPost{
+		first.PostId:  first,
+		second.PostId: second,
+	}, savedPosts)
+
+	savedPosts, err = ss.SavedPost().GetForPosts(userId, []string{})
+	require.Nil(t, err)
+	assert.Empty(t, savedPosts)
+
+	t.Run("should get every save of a post", func(t *testing.T) {
+		later, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: first.PostId, SavedAt: first.SavedAt + 1})
+		require.Nil(t, err)
+
+		saves, err := ss.SavedPost().GetForPost(first.PostId)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.SavedPost{first, later}, saves)
+	})
+}
+
+func testSavedPostStoreDelete(t *testing.T, ss store.Store) {
+	savedPost, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedPost().Delete(savedPost.UserId, savedPost.PostId))
+
+	_, err = ss.SavedPost().Get(savedPost.UserId, savedPost.PostId)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.SavedPost().Delete(savedPost.UserId, savedPost.PostId)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavedPostStoreDeleteForPost(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+
+	first, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: postId})
+	require.Nil(t, err)
+	second, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: postId})
+	require.Nil(t, err)
+	other, err := ss.SavedPost().Save(&model.SavedPost{UserId: first.UserId, PostId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedPost().DeleteForPost(postId))
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.SavedPost().Get(first.UserId, postId)
+	require.True(t, errors.As(err, &nfErr))
+	_, err = ss.SavedPost().Get(second.UserId, postId)
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.SavedPost().Get(other.UserId, other.PostId)
+	require.Nil(t, err)
+}
+
+func testSavedPostStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: model.NewId()})
+	require.Nil(t, err)
+	other, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId()})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedPost().PermanentDeleteByUser(userId))
+
+	savedPosts, err := ss.SavedPost().GetForUser(userId, nil, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, savedPosts)
+
+	_, err = ss.SavedPost().Get(other.UserId, other.PostId)
+	require.Nil(t, err)
+}
+
+func testSavedPostStoreMigrateFlaggedPosts(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	newPost := func() *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Message:   "message",
+		})
+		require.Nil(t, err)
+		return post
+	}
+
+	flagged := newPost()
+	alreadySaved := newPost()
+	unflagged := newPost()
+	deleted := newPost()
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userId))
+
+	_, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: alreadySaved.Id, Label: "Follow up"})
+	require.Nil(t, err)
+
+	preferences := model.Preferences{
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: flagged.Id, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: alreadySaved.Id, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: unflagged.Id, Value: "false"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: deleted.Id, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: model.NewId(), Value: "true"},
+	}
+	require.Nil(t, ss.Preference().Save(&preferences))
+
+	migrate := func(limit int) int64 {
+		var total int64
+		userId, postId := "", ""
+		for {
+			migrated, lastUserId, lastPostId, err := ss.SavedPost().MigrateFlaggedPosts(userId, postId, limit)
+			require.Nil(t, err)
+			if lastUserId == "" {
+				return total
+			}
+			total += migrated
+			userId, postId = lastUserId, lastPostId
+		}
+	}
+
+	assert.Equal(t, int64(1), migrate(2))
+
+	savedPosts, err := ss.SavedPost().GetForUser(userId, nil, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, savedPosts, 2)
+
+	savedPostsByPostId := map[string]*model.SavedPost{}
+	for _, savedPost := range savedPosts {
+		savedPostsByPostId[savedPost.PostId] = savedPost
+	}
+
+	require.Contains(t, savedPostsByPostId, flagged.Id)
+	assert.Equal(t, "", savedPostsByPostId[flagged.Id].Label)
+	assert.Equal(t, flagged.CreateAt, savedPostsByPostId[flagged.Id].SavedAt)
+
+	require.Contains(t, savedPostsByPostId, alreadySaved.Id)
+	assert.Equal(t, "Follow up", savedPostsByPostId[alreadySaved.Id].Label)
+
+	t.Run("should not save the flagged posts again", func(t *testing.T) {
+		assert.Zero(t, migrate(100))
+	})
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testSavedSearchStoreSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testSavedSearchStoreUpdate(t, ss) })
+	t.Run("GetToEvaluate", func(t *testing.T) { testSavedSearchStoreGetToEvaluate(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testSavedSearchStoreDelete(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testSavedSearchStorePermanentDeleteByUser(t, ss) })
+}
+
+func newSavedSearch(userId, name string) *model.SavedSearch {
+	return &model.SavedSearch{
+		UserId: userId,
+		TeamId: model.NewId(),
+		Name:   name,
+		Terms:  name + " from:someone",
+	}
+}
+
+func testSavedSearchStoreSaveAndGet(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	second, err := ss.SavedSearch().Save(newSavedSearch(userId, "second"))
+	require.Nil(t, err)
+	assert.NotEmpty(t, second.Id)
+	assert.Equal(t, second.CreateAt, second.LastEvaluatedAt)
+
+	first, err := ss.SavedSearch().Save(newSavedSearch(userId, "first"))
+	require.Nil(t, err)
+
+	_, err = ss.SavedSearch().Save(newSavedSearch(model.NewId(), "other"))
+	require.Nil(t, err)
+
+	t.Run("should not save a saved search with an id", func(t *testing.T) {
+		savedSearch := newSavedSearch(userId, "third")
+		savedSearch.Id = model.NewId()
+		_, err := ss.SavedSearch().Save(savedSearch)
+		require.NotNil(t, err)
+	})
+
+	t.Run("should not save an invalid saved search", func(t *testing.T) {
+		savedSearch := newSavedSearch(userId, "third")
+		savedSearch.Terms = "*"
+		_, err := ss.SavedSearch().Save(savedSearch)
+		require.NotNil(t, err)
+	})
+
+	t.Run("should get a saved search", func(t *testing.T) {
+		savedSearch, err := ss.SavedSearch().Get(first.Id)
+		require.Nil(t, err)
+		assert.Equal(t, first, savedSearch)
+	})
+
+	t.Run("should not get a missing saved search", func(t *testing.T) {
+		_, err := ss.SavedSearch().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should get the saved searches for a user by name", func(t *testing.T) {
+		savedSearches, err := ss.SavedSearch().GetForUser(userId)
+		require.Nil(t, err)
+		require.Len(t, savedSearches, 2)
+		assert.Equal(t, first.Id, savedSearches[0].Id)
+		assert.Equal(t, second.Id, savedSearches[1].Id)
+	})
+
+	t.Run("should get no saved searches for a user without any", func(t *testing.T) {
+		savedSearches, err := ss.SavedSearch().GetForUser(model.NewId())
+		require.Nil(t, err)
+		assert.Empty(t, savedSearches)
+	})
+}
+
+func testSavedSearchStoreUpdate(t *testing.T, ss store.Store) {
+	savedSearch, err := ss.SavedSearch().Save(newSavedSearch(model.NewId(), "outages"))
+	require.Nil(t, err)
+
+	t.Run("should update a saved search", func(t *testing.T) {
+		savedSearch.Terms = "outage incident"
+		savedSearch.NotifyOnNewMatch = true
+		updated, err := ss.SavedSearch().Update(savedSearch)
+		require.Nil(t, err)
+
+		fetched, err := ss.SavedSearch().Get(savedSearch.Id)
+		require.Nil(t, err)
+		assert.Equal(t, updated, fetched)
+		assert.Equal(t, "outage incident", fetched.Terms)
+		assert.True(t, fetched.NotifyOnNewMatch)
+	})
+
+	t.Run("should not update an invalid saved search", func(t *testing.T) {
+		invalid := *savedSearch
+		invalid.Name = ""
+		_, err := ss.SavedSearch().Update(&invalid)
+		require.NotNil(t, err)
+	})
+
+	t.Run("should not update a missing saved search", func(t *testing.T) {
+		missing := *savedSearch
+		missing.Id = model.NewId()
+		_, err := ss.SavedSearch().Update(&missing)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testSavedSearchStoreGetToEvaluate(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	save := func(name string, notify bool, lastEvaluatedAt int64) *model.SavedSearch {
+		savedSearch := newSavedSearch(userId, name)
+		savedSearch.NotifyOnNewMatch = notify
+		savedSearch, err := ss.SavedSearch().Save(savedSearch)
+		require.Nil(t, err)
+		require.Nil(t, ss.SavedSearch().UpdateLastEvaluatedAt(savedSearch.Id, lastEvaluatedAt))
+		savedSearch.LastEvaluatedAt = lastEvaluatedAt
+		return savedSearch
+	}
+
+	// Saved searches left behind by other tests were last evaluated when saved, long after these.
+	recent := save("recent", true, 3000)
+	oldest := save("oldest", true, 1000)
+	older := save("older", true, 2000)
+	save("silent", false, 1000)
+	save("current", true, 5000)
+
+	t.Run("should get the saved searches to evaluate, least recently evaluated first", func(t *testing.T) {
+		savedSearches, err := ss.SavedSearch().GetToEvaluate(4000, 100)
+		require.Nil(t, err)
+		require.Len(t, savedSearches, 3)
+		assert.Equal(t, oldest, savedSearches[0])
+		assert.Equal(t, older, savedSearches[1])
+		assert.Equal(t, recent, savedSearches[2])
+	})
+
+	t.Run("should limit the saved searches to evaluate", func(t *testing.T) {
+		savedSearches, err := ss.SavedSearch().GetToEvaluate(4000, 2)
+		require.Nil(t, err)
+		require.Len(t, savedSearches, 2)
+		assert.Equal(t, oldest.Id, savedSearches[0].Id)
+		assert.Equal(t, older.Id, savedSearches[1].Id)
+	})
+
+	t.Run("should not update the last evaluation of a missing saved search", func(t *testing.T) {
+		err := ss.SavedSearch().UpdateLastEvaluatedAt(model.NewId(), 1000)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testSavedSearchStoreDelete(t *testing.T, ss store.Store) {
+	savedSearch, err := ss.SavedSearch().Save(newSavedSearch(model.NewId(), "outages"))
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedSearch().Delete(savedSearch.Id))
+
+	_, err = ss.SavedSearch().Get(savedSearch.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.SavedSearch().Delete(savedSearch.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavedSearchStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	_, err := ss.SavedSearch().Save(newSavedSearch(userId, "first"))
+	require.Nil(t, err)
+	_, err = ss.SavedSearch().Save(newSavedSearch(userId, "second"))
+	require.Nil(t, err)
+	other, err := ss.SavedSearch().Save(newSavedSearch(model.NewId(), "other"))
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedSearch().PermanentDeleteByUser(userId))
+
+	savedSearches, err := ss.SavedSearch().GetForUser(userId)
+	require.Nil(t, err)
+	assert.Empty(t, savedSearches)
+
+	_, err = ss.SavedSearch().Get(other.Id)
+	require.Nil(t, err)
+}
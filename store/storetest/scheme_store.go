@@ -24,6 +24,7 @@ func TestSchemeStore(t *testing.T, ss store.Store) {
 	t.Run("GetByName", func(t *testing.T) { testSchemeStoreGetByName(t, ss) })
 	t.Run("CountByScope", func(t *testing.T) { testSchemeStoreCountByScope(t, ss) })
 	t.Run("CountWithoutPermission", func(t *testing.T) { testCountWithoutPermission(t, ss) })
+	t.Run("GetChannelPolicies", func(t *testing.T) { testSchemeStoreGetChannelPolicies(t, ss) })
 }
 
 func createDefaultRoles(t *testing.T, ss store.Store) {
@@ -630,3 +631,68 @@ func testCountWithoutPermission(t *testing.T, ss store.Store) {
 		require.Equal(t, int64(test.expectChannelSchemeChannelGuestCount), count)
 	}
 }
+
+func testSchemeStoreGetChannelPolicies(t *testing.T, ss store.Store) {
+	team, appErr := ss.Team().Save(&model.Team{
+		DisplayName: "Name",
+		Name:        "zz" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TEAM_OPEN,
+	})
+	require.Nil(t, appErr)
+
+	scheme, err := ss.Scheme().Save(&model.Scheme{
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Scope:       model.SCHEME_SCOPE_CHANNEL,
+	})
+	require.Nil(t, err)
+
+	newChannel := func(displayName string, schemeId *string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      team.Id,
+			DisplayName: displayName,
+			Name:        "zz" + model.NewId(),
+			Type:        model.CHANNEL_OPEN,
+			SchemeId:    schemeId,
+		}, -1)
+		require.Nil(t, err)
+		return channel
+	}
+
+	second := newChannel("B", &scheme.Id)
+	first := newChannel("A", &scheme.Id)
+	newChannel("C", nil)
+	deleted := newChannel("D", &scheme.Id)
+	require.Nil(t, ss.Channel().Delete(deleted.Id, model.GetMillis()))
+
+	policies, err := ss.Scheme().GetChannelPolicies(0, 10000)
+	require.Nil(t, err)
+
+	var teamPolicies []*model.ChannelPolicyWithChannelInfo
+	for _, policy := range policies {
+		if policy.TeamId == team.Id {
+			teamPolicies = append(teamPolicies, policy)
+		}
+	}
+
+	require.Len(t, teamPolicies, 2)
+	assert.Equal(t, first.Id, teamPolicies[0].ChannelId)
+	assert.Equal(t, second.Id, teamPolicies[1].ChannelId)
+
+	policy := teamPolicies[0]
+	assert.Equal(t, first.Name, policy.ChannelName)
+	assert.Equal(t, "A", policy.ChannelDisplayName)
+	assert.Equal(t, model.CHANNEL_OPEN, policy.ChannelType)
+	assert.Equal(t, team.Name, policy.TeamName)
+	assert.Equal(t, team.DisplayName, policy.TeamDisplayName)
+	assert.Equal(t, scheme.Id, policy.SchemeId)
+	assert.Equal(t, scheme.Name, policy.SchemeName)
+	assert.Equal(t, scheme.DefaultChannelAdminRole, policy.DefaultChannelAdminRole)
+	assert.Equal(t, scheme.DefaultChannelUserRole, policy.DefaultChannelUserRole)
+	assert.Equal(t, scheme.DefaultChannelGuestRole, policy.DefaultChannelGuestRole)
+
+	policies, err = ss.Scheme().GetChannelPolicies(0, 1)
+	require.Nil(t, err)
+	assert.Len(t, policies, 1)
+}
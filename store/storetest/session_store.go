@@ -35,6 +35,7 @@ func TestSessionStore(t *testing.T, ss store.Store) {
 	t.Run("SessionCount", func(t *testing.T) { testSessionCount(t, ss) })
 	t.Run("GetSessionsExpired", func(t *testing.T) { testGetSessionsExpired(t, ss) })
 	t.Run("UpdateExpiredNotify", func(t *testing.T) { testUpdateExpiredNotify(t, ss) })
+	t.Run("CountActiveWebSessionsCreatedBefore", func(t *testing.T) { testCountActiveWebSessionsCreatedBefore(t, ss) })
 }
 
 func testSessionStoreSave(t *testing.T, ss store.Store) {
@@ -393,3 +394,30 @@ func testUpdateExpiredNotify(t *testing.T, ss store.Store) {
 	require.Nil(t, err)
 	require.False(t, session.ExpiredNotify)
 }
+
+func testCountActiveWebSessionsCreatedBefore(t *testing.T, ss store.Store) {
+	createdBefore := model.GetMillis() + 60000
+
+	before, err := ss.Session().CountActiveWebSessionsCreatedBefore(createdBefore)
+	require.Nil(t, err)
+
+	web, err := ss.Session().Save(&model.Session{UserId: model.NewId(), ExpiresAt: model.GetMillis() + 100000})
+	require.Nil(t, err)
+	defer ss.Session().Remove(web.Id)
+
+	mobile, err := ss.Session().Save(&model.Session{UserId: model.NewId(), DeviceId: model.NewId(), ExpiresAt: model.GetMillis() + 100000})
+	require.Nil(t, err)
+	defer ss.Session().Remove(mobile.Id)
+
+	expired, err := ss.Session().Save(&model.Session{UserId: model.NewId(), ExpiresAt: model.GetMillis() - 1000})
+	require.Nil(t, err)
+	defer ss.Session().Remove(expired.Id)
+
+	count, err := ss.Session().CountActiveWebSessionsCreatedBefore(createdBefore)
+	require.Nil(t, err)
+	require.Equal(t, before+1, count)
+
+	count, err = ss.Session().CountActiveWebSessionsCreatedBefore(web.CreateAt - 60000)
+	require.Nil(t, err)
+	require.Equal(t, before, count)
+}
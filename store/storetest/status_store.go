@@ -15,6 +15,7 @@ import (
 func TestStatusStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testStatusStore(t, ss) })
 	t.Run("ActiveUserCount", func(t *testing.T) { testActiveUserCount(t, ss) })
+	t.Run("GetByTeam", func(t *testing.T) { testStatusGetByTeam(t, ss) })
 }
 
 func testStatusStore(t *testing.T, ss store.Store) {
@@ -56,6 +57,31 @@ func testActiveUserCount(t *testing.T, ss store.Store) {
 	require.True(t, count > 0, "expected count > 0, got %d", count)
 }
 
+func testStatusGetByTeam(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	member := &model.Status{UserId: model.NewId(), Status: model.STATUS_ONLINE}
+	require.Nil(t, ss.Status().SaveOrUpdate(member))
+	_, err := ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: member.UserId}, -1)
+	require.Nil(t, err)
+
+	formerMember := &model.Status{UserId: model.NewId(), Status: model.STATUS_ONLINE}
+	require.Nil(t, ss.Status().SaveOrUpdate(formerMember))
+	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: formerMember.UserId, DeleteAt: model.GetMillis()}, -1)
+	require.Nil(t, err)
+
+	nonMember := &model.Status{UserId: model.NewId(), Status: model.STATUS_ONLINE}
+	require.Nil(t, ss.Status().SaveOrUpdate(nonMember))
+	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: model.NewId(), UserId: nonMember.UserId}, -1)
+	require.Nil(t, err)
+
+	statuses, err := ss.Status().GetByTeam(teamId)
+	require.Nil(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, member.UserId, statuses[0].UserId)
+	require.Equal(t, model.STATUS_ONLINE, statuses[0].Status)
+}
+
 type ByUserId []*model.Status
 
 func (s ByUserId) Len() int           { return len(s) }
@@ -45,6 +45,11 @@ type Store struct {
 	GroupStore                mocks.GroupStore
 	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
 	LinkMetadataStore         mocks.LinkMetadataStore
+	ChannelBookmarkStore      mocks.ChannelBookmarkStore
+	SavedSearchStore          mocks.SavedSearchStore
+	SavedPostStore            mocks.SavedPostStore
+	PostShareTokenStore       mocks.PostShareTokenStore
+	AdminNotificationStore    mocks.AdminNotificationStore
 	context                   context.Context
 }
 
@@ -83,17 +88,28 @@ func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 }
 func (s *Store) Group() store.GroupStore               { return &s.GroupStore }
 func (s *Store) LinkMetadata() store.LinkMetadataStore { return &s.LinkMetadataStore }
-func (s *Store) MarkSystemRanUnitTests()               { /* do nothing */ }
-func (s *Store) Close()                                { /* do nothing */ }
-func (s *Store) LockToMaster()                         { /* do nothing */ }
-func (s *Store) UnlockFromMaster()                     { /* do nothing */ }
-func (s *Store) DropAllTables()                        { /* do nothing */ }
-func (s *Store) GetDbVersion() (string, error)         { return "", nil }
-func (s *Store) RecycleDBConnections(time.Duration)    {}
-func (s *Store) TotalMasterDbConnections() int         { return 1 }
-func (s *Store) TotalReadDbConnections() int           { return 1 }
-func (s *Store) TotalSearchDbConnections() int         { return 1 }
-func (s *Store) GetCurrentSchemaVersion() string       { return "" }
+func (s *Store) ChannelBookmark() store.ChannelBookmarkStore {
+	return &s.ChannelBookmarkStore
+}
+func (s *Store) SavedSearch() store.SavedSearchStore { return &s.SavedSearchStore }
+func (s *Store) SavedPost() store.SavedPostStore     { return &s.SavedPostStore }
+func (s *Store) PostShareToken() store.PostShareTokenStore {
+	return &s.PostShareTokenStore
+}
+func (s *Store) AdminNotification() store.AdminNotificationStore {
+	return &s.AdminNotificationStore
+}
+func (s *Store) MarkSystemRanUnitTests()            { /* do nothing */ }
+func (s *Store) Close()                             { /* do nothing */ }
+func (s *Store) LockToMaster()                      { /* do nothing */ }
+func (s *Store) UnlockFromMaster()                  { /* do nothing */ }
+func (s *Store) DropAllTables()                     { /* do nothing */ }
+func (s *Store) GetDbVersion() (string, error)      { return "", nil }
+func (s *Store) RecycleDBConnections(time.Duration) {}
+func (s *Store) TotalMasterDbConnections() int      { return 1 }
+func (s *Store) TotalReadDbConnections() int        { return 1 }
+func (s *Store) TotalSearchDbConnections() int      { return 1 }
+func (s *Store) GetCurrentSchemaVersion() string    { return "" }
 func (s *Store) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	return make(chan store.IntegrityCheckResult)
 }
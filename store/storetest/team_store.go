@@ -36,6 +36,7 @@ func TestTeamStore(t *testing.T, ss store.Store) {
 	t.Run("SearchPrivate", func(t *testing.T) { testTeamStoreSearchPrivate(t, ss) })
 	t.Run("GetByInviteId", func(t *testing.T) { testTeamStoreGetByInviteId(t, ss) })
 	t.Run("ByUserId", func(t *testing.T) { testTeamStoreByUserId(t, ss) })
+	t.Run("GetAllWithAutoJoinDomains", func(t *testing.T) { testTeamStoreGetAllWithAutoJoinDomains(t, ss) })
 	t.Run("GetAllTeamListing", func(t *testing.T) { testGetAllTeamListing(t, ss) })
 	t.Run("GetAllTeamPageListing", func(t *testing.T) { testGetAllTeamPageListing(t, ss) })
 	t.Run("GetAllPrivateTeamListing", func(t *testing.T) { testGetAllPrivateTeamListing(t, ss) })
@@ -57,6 +58,7 @@ func TestTeamStore(t *testing.T, ss store.Store) {
 	t.Run("GetTeamMember", func(t *testing.T) { testGetTeamMember(t, ss) })
 	t.Run("GetTeamMembersByIds", func(t *testing.T) { testGetTeamMembersByIds(t, ss) })
 	t.Run("MemberCount", func(t *testing.T) { testTeamStoreMemberCount(t, ss) })
+	t.Run("GetTeamStats", func(t *testing.T) { testTeamStoreGetTeamStats(t, ss) })
 	t.Run("GetChannelUnreadsForAllTeams", func(t *testing.T) { testGetChannelUnreadsForAllTeams(t, ss) })
 	t.Run("GetChannelUnreadsForTeam", func(t *testing.T) { testGetChannelUnreadsForTeam(t, ss) })
 	t.Run("UpdateLastTeamIconUpdate", func(t *testing.T) { testUpdateLastTeamIconUpdate(t, ss) })
@@ -558,6 +560,46 @@ func testTeamStoreGetByInviteId(t *testing.T, ss store.Store) {
 	require.NotNil(t, err, "Missing id should have failed")
 }
 
+func testTeamStoreGetAllWithAutoJoinDomains(t *testing.T, ss store.Store) {
+	o1 := &model.Team{}
+	o1.DisplayName = "DisplayName"
+	o1.Name = "z-z-z" + model.NewId() + "b"
+	o1.Email = MakeEmail()
+	o1.Type = model.TEAM_OPEN
+	o1.AutoJoinDomains = "example.com"
+	o1, err := ss.Team().Save(o1)
+	require.Nil(t, err)
+
+	o2 := &model.Team{}
+	o2.DisplayName = "DisplayName"
+	o2.Name = "z-z-z" + model.NewId() + "b"
+	o2.Email = MakeEmail()
+	o2.Type = model.TEAM_OPEN
+	o2, err = ss.Team().Save(o2)
+	require.Nil(t, err)
+
+	o3 := &model.Team{}
+	o3.DisplayName = "DisplayName"
+	o3.Name = "z-z-z" + model.NewId() + "b"
+	o3.Email = MakeEmail()
+	o3.Type = model.TEAM_OPEN
+	o3.AutoJoinDomains = "example.com"
+	o3.DeleteAt = model.GetMillis()
+	o3, err = ss.Team().Save(o3)
+	require.Nil(t, err)
+
+	teams, nErr := ss.Team().GetAllWithAutoJoinDomains()
+	require.Nil(t, nErr)
+
+	found := map[string]bool{}
+	for _, team := range teams {
+		found[team.Id] = true
+	}
+	assert.True(t, found[o1.Id], "should return the team with auto-join domains")
+	assert.False(t, found[o2.Id], "should not return a team without auto-join domains")
+	assert.False(t, found[o3.Id], "should not return a deleted team")
+}
+
 func testTeamStoreByUserId(t *testing.T, ss store.Store) {
 	o1 := &model.Team{}
 	o1.DisplayName = "DisplayName"
@@ -2915,6 +2957,56 @@ func testTeamStoreMemberCount(t *testing.T, ss store.Store) {
 	require.Equal(t, 1, int(result), "wrong count")
 }
 
+func testTeamStoreGetTeamStats(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	active := &model.User{Email: MakeEmail()}
+	_, err := ss.User().Save(active)
+	require.Nil(t, err)
+
+	deactivated := &model.User{Email: MakeEmail(), DeleteAt: 1}
+	_, err = ss.User().Save(deactivated)
+	require.Nil(t, err)
+
+	for _, user := range []*model.User{active, deactivated} {
+		_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: user.Id}, -1)
+		require.Nil(t, err)
+	}
+
+	open, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, Name: model.NewId(), DisplayName: "Open", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+	_, nErr = ss.Channel().Save(&model.Channel{TeamId: teamId, Name: model.NewId(), DisplayName: "Private", Type: model.CHANNEL_PRIVATE}, -1)
+	require.Nil(t, nErr)
+	archived, nErr := ss.Channel().Save(&model.Channel{TeamId: teamId, Name: model.NewId(), DisplayName: "Archived", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+
+	for _, channelId := range []string{open.Id, open.Id, archived.Id} {
+		_, err = ss.Post().Save(&model.Post{ChannelId: channelId, UserId: active.Id, Message: "message"})
+		require.Nil(t, err)
+	}
+	deletedPost, err := ss.Post().Save(&model.Post{ChannelId: open.Id, UserId: active.Id, Message: "deleted"})
+	require.Nil(t, err)
+	require.Nil(t, ss.Post().Delete(deletedPost.Id, model.GetMillis(), active.Id))
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	stats, nErr := ss.Team().GetTeamStats(teamId)
+	require.Nil(t, nErr)
+	assert.Equal(t, &model.TeamStats{
+		TeamId:              teamId,
+		TotalMemberCount:    2,
+		ActiveMemberCount:   1,
+		OpenChannelCount:    1,
+		PrivateChannelCount: 1,
+		PostCount:           2,
+	}, stats)
+
+	stats, nErr = ss.Team().GetTeamStats(model.NewId())
+	require.Nil(t, nErr)
+	assert.Zero(t, stats.TotalMemberCount)
+	assert.Zero(t, stats.OpenChannelCount)
+	assert.Zero(t, stats.PostCount)
+}
+
 func testGetChannelUnreadsForAllTeams(t *testing.T, ss store.Store) {
 	teamId1 := model.NewId()
 	teamId2 := model.NewId()
@@ -1335,6 +1335,14 @@ func testUserStoreGetProfilesByIds(t *testing.T, ss store.Store) {
 	require.Nil(t, err)
 	defer func() { require.Nil(t, ss.User().PermanentDelete(u4.Id)) }()
 
+	u5, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u5" + model.NewId(),
+		DeleteAt: model.GetMillis(),
+	})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u5.Id)) }()
+
 	t.Run("get u1 by id, no caching", func(t *testing.T) {
 		users, err := ss.User().GetProfileByIds([]string{u1.Id}, nil, false)
 		require.Nil(t, err)
@@ -1374,6 +1382,44 @@ func testUserStoreGetProfilesByIds(t *testing.T, ss store.Store) {
 		// u3 comes from the cache, and u4 does not
 		assert.Equal(t, []*model.User{u3, u4}, users)
 	})
+
+	t.Run("get u4, u2, unknown id, u1, u2 by id in the given order, no caching", func(t *testing.T) {
+		users, err := ss.User().GetProfileByIds([]string{u4.Id, u2.Id, model.NewId(), u1.Id, u2.Id}, &store.UserGetByIdsOpts{
+			OrderByIds: true,
+		}, false)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.User{u4, u2, u1}, users)
+	})
+
+	t.Run("get u4, u2, unknown id, u1, u2 by id in the given order, caching", func(t *testing.T) {
+		users, err := ss.User().GetProfileByIds([]string{u4.Id, u2.Id, model.NewId(), u1.Id, u2.Id}, &store.UserGetByIdsOpts{
+			OrderByIds: true,
+		}, true)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.User{u4, u2, u1}, users)
+	})
+
+	t.Run("get deactivated users by default", func(t *testing.T) {
+		users, err := ss.User().GetProfileByIds([]string{u5.Id, u1.Id}, nil, true)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []*model.User{u5, u1}, users)
+	})
+
+	t.Run("exclude deactivated users, no caching", func(t *testing.T) {
+		users, err := ss.User().GetProfileByIds([]string{u5.Id, u1.Id}, &store.UserGetByIdsOpts{
+			ExcludeDeleted: true,
+		}, false)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.User{u1}, users)
+	})
+
+	t.Run("exclude deactivated users, caching", func(t *testing.T) {
+		users, err := ss.User().GetProfileByIds([]string{u5.Id, u1.Id}, &store.UserGetByIdsOpts{
+			ExcludeDeleted: true,
+		}, true)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.User{u1}, users)
+	})
 }
 
 func testUserStoreGetProfileByGroupChannelIdsForUser(t *testing.T, ss store.Store) {
@@ -2068,10 +2114,14 @@ func testUserUnreadCount(t *testing.T, ss store.Store) {
 	err = ss.Channel().IncrementMentionCount(c2.Id, u2.Id)
 	require.Nil(t, err)
 
-	badge, unreadCountErr := ss.User().GetUnreadCount(u2.Id)
+	badge, unreadCountErr := ss.User().GetUnreadCount(u2.Id, false)
 	require.Nil(t, unreadCountErr)
 	require.Equal(t, int64(3), badge, "should have 3 unread messages")
 
+	badge, unreadCountErr = ss.User().GetUnreadCount(u2.Id, true)
+	require.Nil(t, unreadCountErr)
+	require.Equal(t, int64(3), badge, "should have 3 unread messages while the direct channel is not muted")
+
 	badge, unreadCountErr = ss.User().GetUnreadCountForChannel(u2.Id, c1.Id)
 	require.Nil(t, unreadCountErr)
 	require.Equal(t, int64(1), badge, "should have 1 unread messages for that channel")
@@ -2079,6 +2129,18 @@ func testUserUnreadCount(t *testing.T, ss store.Store) {
 	badge, unreadCountErr = ss.User().GetUnreadCountForChannel(u2.Id, c2.Id)
 	require.Nil(t, unreadCountErr)
 	require.Equal(t, int64(2), badge, "should have 2 unread messages for that channel")
+
+	m2.NotifyProps[model.MARK_UNREAD_NOTIFY_PROP] = model.CHANNEL_MARK_UNREAD_MENTION
+	_, err = ss.Channel().UpdateMember(&m2)
+	require.Nil(t, err)
+
+	badge, unreadCountErr = ss.User().GetUnreadCount(u2.Id, true)
+	require.Nil(t, unreadCountErr)
+	require.Equal(t, int64(1), badge, "should leave out the muted direct channel")
+
+	badge, unreadCountErr = ss.User().GetUnreadCount(u2.Id, false)
+	require.Nil(t, unreadCountErr)
+	require.Equal(t, int64(3), badge, "should still count the muted direct channel when asked to")
 }
 
 func testUserStoreUpdateMfaSecret(t *testing.T, ss store.Store) {
@@ -17,9 +17,11 @@ import (
 type TimerLayer struct {
 	Store
 	Metrics                   einterfaces.MetricsInterface
+	AdminNotificationStore    AdminNotificationStore
 	AuditStore                AuditStore
 	BotStore                  BotStore
 	ChannelStore              ChannelStore
+	ChannelBookmarkStore      ChannelBookmarkStore
 	ChannelMemberHistoryStore ChannelMemberHistoryStore
 	ClusterDiscoveryStore     ClusterDiscoveryStore
 	CommandStore              CommandStore
@@ -34,9 +36,12 @@ type TimerLayer struct {
 	OAuthStore                OAuthStore
 	PluginStore               PluginStore
 	PostStore                 PostStore
+	PostShareTokenStore       PostShareTokenStore
 	PreferenceStore           PreferenceStore
 	ReactionStore             ReactionStore
 	RoleStore                 RoleStore
+	SavedPostStore            SavedPostStore
+	SavedSearchStore          SavedSearchStore
 	SchemeStore               SchemeStore
 	SessionStore              SessionStore
 	StatusStore               StatusStore
@@ -50,6 +55,10 @@ type TimerLayer struct {
 	WebhookStore              WebhookStore
 }
 
+func (s *TimerLayer) AdminNotification() AdminNotificationStore {
+	return s.AdminNotificationStore
+}
+
 func (s *TimerLayer) Audit() AuditStore {
 	return s.AuditStore
 }
@@ -62,6 +71,10 @@ func (s *TimerLayer) Channel() ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *TimerLayer) ChannelBookmark() ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
 func (s *TimerLayer) ChannelMemberHistory() ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
@@ -118,6 +131,10 @@ func (s *TimerLayer) Post() PostStore {
 	return s.PostStore
 }
 
+func (s *TimerLayer) PostShareToken() PostShareTokenStore {
+	return s.PostShareTokenStore
+}
+
 func (s *TimerLayer) Preference() PreferenceStore {
 	return s.PreferenceStore
 }
@@ -130,6 +147,14 @@ func (s *TimerLayer) Role() RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) SavedPost() SavedPostStore {
+	return s.SavedPostStore
+}
+
+func (s *TimerLayer) SavedSearch() SavedSearchStore {
+	return s.SavedSearchStore
+}
+
 func (s *TimerLayer) Scheme() SchemeStore {
 	return s.SchemeStore
 }
@@ -174,6 +199,11 @@ func (s *TimerLayer) Webhook() WebhookStore {
 	return s.WebhookStore
 }
 
+type TimerLayerAdminNotificationStore struct {
+	AdminNotificationStore
+	Root *TimerLayer
+}
+
 type TimerLayerAuditStore struct {
 	AuditStore
 	Root *TimerLayer
@@ -189,6 +219,11 @@ type TimerLayerChannelStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChannelBookmarkStore struct {
+	ChannelBookmarkStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelMemberHistoryStore struct {
 	ChannelMemberHistoryStore
 	Root *TimerLayer
@@ -259,6 +294,11 @@ type TimerLayerPostStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPostShareTokenStore struct {
+	PostShareTokenStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	PreferenceStore
 	Root *TimerLayer
@@ -274,6 +314,16 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSavedPostStore struct {
+	SavedPostStore
+	Root *TimerLayer
+}
+
+type TimerLayerSavedSearchStore struct {
+	SavedSearchStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	SchemeStore
 	Root *TimerLayer
@@ -329,6 +379,70 @@ type TimerLayerWebhookStore struct {
 	Root *TimerLayer
 }
 
+func (s *TimerLayerAdminNotificationStore) GetAll(offset int, limit int) ([]*model.AdminNotification, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.AdminNotificationStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AdminNotificationStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerAdminNotificationStore) GetLatest(notificationType string, message string, since int64) (*model.AdminNotification, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.AdminNotificationStore.GetLatest(notificationType, message, since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AdminNotificationStore.GetLatest", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerAdminNotificationStore) Save(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.AdminNotificationStore.Save(notification)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AdminNotificationStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerAdminNotificationStore) Update(notification *model.AdminNotification) (*model.AdminNotification, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.AdminNotificationStore.Update(notification)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AdminNotificationStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
 	start := timemodule.Now()
 
@@ -377,6 +491,22 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 	return resultVar0
 }
 
+func (s *TimerLayerAuditStore) StreamToSyslog(addr string, protocol string, caCert []byte) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.AuditStore.StreamToSyslog(addr, protocol, caCert)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.StreamToSyslog", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
 	start := timemodule.Now()
 
@@ -1016,6 +1146,22 @@ func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetDeletedByTeamForUserSince(teamId string, userId string, since int64) (model.ChannelList, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetDeletedByTeamForUserSince(teamId, userId, since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeletedByTeamForUserSince", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1064,6 +1210,38 @@ func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetInactiveChannelsForTeam(teamID string, inactiveSince int64, warned bool, afterID string, limit int) ([]*model.Channel, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetInactiveChannelsForTeam(teamID, inactiveSince, warned, afterID, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetInactiveChannelsForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetLastViewedPostForUser(userId string, channelId string) (string, int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1, resultVar2 := s.ChannelStore.GetLastViewedPostForUser(userId, channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar2 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetLastViewedPostForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1, resultVar2
+}
+
 func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1288,10 +1466,10 @@ func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, ch
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int, includeHidden bool) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit, includeHidden)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1304,6 +1482,54 @@ func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetRecentDirectChannels(userID string, limit int) ([]*model.Channel, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetRecentDirectChannels(userID, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetRecentDirectChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetRecentlyDeleted(teamId string, offset int, limit int) ([]*model.Channel, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetRecentlyDeleted(teamId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetRecentlyDeleted", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetRootPostCountSince(channelId string, since int64) (int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetRootPostCountSince(channelId, since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetRootPostCountSince", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1368,6 +1594,22 @@ func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelL
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetTeamChannelsWithActivity(teamId string, offset int, limit int, opts model.ChannelActivityOpts) ([]*model.ChannelWithActivity, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetTeamChannelsWithActivity(teamId, offset, limit, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannelsWithActivity", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerChannelStore) GroupSyncedChannelCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1793,6 +2035,22 @@ func (s *TimerLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMem
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) SaveWithCreatorLimit(channel *model.Channel, maxChannelsPerTeam int64, maxChannelsPerCreator int64, createdSince int64) (*model.Channel, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SaveWithCreatorLimit(channel, maxChannelsPerTeam, maxChannelsPerCreator, createdSince)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveWithCreatorLimit", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, *model.AppError) {
 	start := timemodule.Now()
 
@@ -2081,58 +2339,58 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
+func (s *TimerLayerChannelBookmarkStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	resultVar0 := s.ChannelBookmarkStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Delete", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+func (s *TimerLayerChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	resultVar0, resultVar1 := s.ChannelBookmarkStore.Get(id, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+func (s *TimerLayerChannelBookmarkStore) GetBookmarksForChannel(channelId string) ([]*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	resultVar0, resultVar1 := s.ChannelBookmarkStore.GetBookmarksForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetBookmarksForChannel", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerChannelBookmarkStore) GetBookmarksForFile(fileId string) ([]*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := s.ChannelBookmarkStore.GetBookmarksForFile(fileId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2140,15 +2398,15 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetBookmarksForFile", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
+func (s *TimerLayerChannelBookmarkStore) PermanentDeleteByChannel(channelId string) error {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+	resultVar0 := s.ChannelBookmarkStore.PermanentDeleteByChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2156,15 +2414,15 @@ func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.PermanentDeleteByChannel", success, elapsed)
 	}
 	return resultVar0
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+	resultVar0, resultVar1 := s.ChannelBookmarkStore.Save(bookmark)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2172,15 +2430,15 @@ func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Save", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+	resultVar0, resultVar1 := s.ChannelBookmarkStore.Update(bookmark)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2188,68 +2446,212 @@ func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Update", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+func (s *TimerLayerChannelBookmarkStore) UpdateSortOrder(channelId string, bookmarkIds []string) error {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	resultVar0 := s.ChannelBookmarkStore.UpdateSortOrder(channelId, bookmarkIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.UpdateSortOrder", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+func (s *TimerLayerChannelMemberHistoryStore) GetForChannel(channelId string, since int64, until int64, offset int, limit int) ([]*model.ChannelMemberHistoryResult, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetForChannel(channelId, since, until, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetForChannel", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+	resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
 			success = "true"
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
@@ -2544,6 +2946,22 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerEmojiStore) CountByCreator(creatorId string) (int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.CountByCreator(creatorId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.CountByCreator", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	start := timemodule.Now()
 
@@ -2640,6 +3058,22 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerEmojiStore) SaveWithCreatorLimit(emoji *model.Emoji, maxEmojiPerCreator int64) (*model.Emoji, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.EmojiStore.SaveWithCreatorLimit(emoji, maxEmojiPerCreator)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.SaveWithCreatorLimit", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	start := timemodule.Now()
 
@@ -2767,6 +3201,22 @@ func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo,
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerFileInfoStore) GetPostsWithFileExtensions(extensions []string, since int64, offset int, limit int) ([]string, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.FileInfoStore.GetPostsWithFileExtensions(extensions, since, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetPostsWithFileExtensions", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4222,6 +4672,22 @@ func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginK
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPluginStore) GetFromMaster(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PluginStore.GetFromMaster(pluginId, key)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.GetFromMaster", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4381,6 +4847,22 @@ func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPostStore) GetEditHistory(postId string) ([]*model.PostEdit, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostStore.GetEditHistory(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEditHistory", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
 	start := timemodule.Now()
 
@@ -4796,6 +5278,22 @@ func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppErr
 	return resultVar0
 }
 
+func (s *TimerLayerPostStore) PermanentDeleteEditOriginalsBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostStore.PermanentDeleteEditOriginalsBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteEditOriginalsBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4812,6 +5310,22 @@ func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppErr
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPostStore) SaveEditHistory(original *model.Post) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.PostStore.SaveEditHistory(original)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SaveEditHistory", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4876,52 +5390,116 @@ func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+func (s *TimerLayerPostShareTokenStore) DeleteExpired(expiryTime int64) error {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
+	resultVar0 := s.PostShareTokenStore.DeleteExpired(expiryTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShareTokenStore.DeleteExpired", success, elapsed)
 	}
-	return resultVar0, resultVar1
+	return resultVar0
 }
 
-func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+func (s *TimerLayerPostShareTokenStore) DeleteForChannel(channelId string) (int64, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.Delete(userId, category, name)
+	resultVar0, resultVar1 := s.PostShareTokenStore.DeleteForChannel(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShareTokenStore.DeleteForChannel", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+func (s *TimerLayerPostShareTokenStore) Get(token string) (*model.PostShareToken, error) {
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
+	resultVar0, resultVar1 := s.PostShareTokenStore.Get(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShareTokenStore.Get", success, elapsed)
 	}
-	return resultVar0
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostShareTokenStore) Save(token *model.PostShareToken) (*model.PostShareToken, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostShareTokenStore.Save(token)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShareTokenStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.PreferenceStore.Delete(userId, category, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+	}
+	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
@@ -5084,6 +5662,22 @@ func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ReactionStore.GetForPosts(postIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPosts", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
@@ -5276,6 +5870,310 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppErr
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSavedPostStore) Delete(userId string, postId string) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.Delete(userId, postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) DeleteForPost(postId string) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.DeleteForPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.DeleteForPost", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) Get(userId string, postId string) (*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.Get(userId, postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) GetForPost(postId string) ([]*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.GetForPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.GetForPost", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) GetForPosts(userId string, postIds []string) (map[string]*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.GetForPosts(userId, postIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.GetForPosts", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) GetForUser(userId string, label *string, offset int, limit int) ([]*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.GetForUser(userId, label, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) GetLabelsForUser(userId string) ([]string, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.GetLabelsForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.GetLabelsForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) MigrateFlaggedPosts(afterUserId string, afterPostId string, limit int) (int64, string, string, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1, resultVar2, resultVar3 := s.SavedPostStore.MigrateFlaggedPosts(afterUserId, afterPostId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar3 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.MigrateFlaggedPosts", success, elapsed)
+	}
+	return resultVar0, resultVar1, resultVar2, resultVar3
+}
+
+func (s *TimerLayerSavedPostStore) PermanentDeleteByUser(userId string) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) Save(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.Save(savedPost)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) Update(savedPost *model.SavedPost) (*model.SavedPost, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.Update(savedPost)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) Delete(id string) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedSearchStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedSearchStore) Get(id string) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedSearchStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) GetForUser(userId string) ([]*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedSearchStore.GetForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) GetToEvaluate(evaluatedBefore int64, limit int) ([]*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedSearchStore.GetToEvaluate(evaluatedBefore, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.GetToEvaluate", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) PermanentDeleteByUser(userId string) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedSearchStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedSearchStore.Save(savedSearch)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedSearchStore.Update(savedSearch)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedSearchStore) UpdateLastEvaluatedAt(id string, lastEvaluatedAt int64) error {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedSearchStore.UpdateLastEvaluatedAt(id, lastEvaluatedAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedSearchStore.UpdateLastEvaluatedAt", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 	start := timemodule.Now()
 
@@ -5372,6 +6270,22 @@ func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, err
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSchemeStore) GetChannelPolicies(offset int, limit int) ([]*model.ChannelPolicyWithChannelInfo, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SchemeStore.GetChannelPolicies(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetChannelPolicies", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSchemeStore) PermanentDeleteAll() error {
 	start := timemodule.Now()
 
@@ -5435,6 +6349,22 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	}
 }
 
+func (s *TimerLayerSessionStore) CountActiveWebSessionsCreatedBefore(createdBefore int64) (int64, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SessionStore.CountActiveWebSessionsCreatedBefore(createdBefore)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.CountActiveWebSessionsCreatedBefore", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
 	start := timemodule.Now()
 
@@ -5691,6 +6621,22 @@ func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *mo
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerStatusStore) GetByTeam(teamId string) ([]*model.Status, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.StatusStore.GetByTeam(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
@@ -6122,6 +7068,22 @@ func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*m
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerTeamStore) GetAllWithAutoJoinDomains() ([]*model.Team, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.TeamStore.GetAllWithAutoJoinDomains()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllWithAutoJoinDomains", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
@@ -6266,6 +7228,22 @@ func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.T
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerTeamStore) GetTeamStats(teamID string) (*model.TeamStats, error) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.TeamStore.GetTeamStats(teamID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamStats", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
 	start := timemodule.Now()
 
@@ -7479,10 +8457,10 @@ func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, *model.AppError) {
+func (s *TimerLayerUserStore) GetUnreadCount(userId string, excludeMutedDirect bool) (int64, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUnreadCount(userId)
+	resultVar0, resultVar1 := s.UserStore.GetUnreadCount(userId, excludeMutedDirect)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8576,9 +9554,11 @@ func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *Time
 		Metrics: metrics,
 	}
 
+	newStore.AdminNotificationStore = &TimerLayerAdminNotificationStore{AdminNotificationStore: childStore.AdminNotification(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
@@ -8593,9 +9573,12 @@ func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *Time
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostShareTokenStore = &TimerLayerPostShareTokenStore{PostShareTokenStore: childStore.PostShareToken(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SavedPostStore = &TimerLayerSavedPostStore{SavedPostStore: childStore.SavedPost(), Root: &newStore}
+	newStore.SavedSearchStore = &TimerLayerSavedSearchStore{SavedSearchStore: childStore.SavedSearch(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
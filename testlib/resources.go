@@ -13,7 +13,6 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
 
-	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 )
 
@@ -142,12 +141,12 @@ func SetupTestResources() (string, error) {
 
 		if testResource.action == actionCopy {
 			if testResource.resType == resourceTypeFile {
-				err = utils.CopyFile(testResource.src, resourceDestInTemp)
+				err = fileutils.CopyFile(testResource.src, resourceDestInTemp)
 				if err != nil {
 					return "", errors.Wrapf(err, "failed to copy file %s to %s", testResource.src, resourceDestInTemp)
 				}
 			} else if testResource.resType == resourceTypeFolder {
-				err = utils.CopyDir(testResource.src, resourceDestInTemp)
+				err = fileutils.CopyDir(testResource.src, resourceDestInTemp)
 				if err != nil {
 					return "", errors.Wrapf(err, "failed to copy folder %s to %s", testResource.src, resourceDestInTemp)
 				}
@@ -17,6 +17,9 @@ import (
 	"github.com/mattermost/mattermost-server/v5/model"
 )
 
+// CheckOrigin reports whether the origin of the request is one of the space separated allowed origins.
+//
+// Deprecated: configure ServiceSettings.CorsOrigins and use App.OriginChecker instead.
 func CheckOrigin(r *http.Request, allowedOrigins string) bool {
 	origin := r.Header.Get("Origin")
 	if origin == "" {
@@ -34,6 +37,9 @@ func CheckOrigin(r *http.Request, allowedOrigins string) bool {
 	return false
 }
 
+// OriginChecker returns a function checking the origin of requests against the space separated allowed origins.
+//
+// Deprecated: configure ServiceSettings.CorsOrigins and use App.OriginChecker instead.
 func OriginChecker(allowedOrigins string) func(*http.Request) bool {
 	return func(r *http.Request) bool {
 		return CheckOrigin(r, allowedOrigins)
@@ -4,23 +4,12 @@
 package utils
 
 import (
-	"time"
+	"github.com/mattermost/mattermost-server/v5/utils/retryutils"
 )
 
-var backoffTimeouts = []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 400 * time.Millisecond}
-
 // ProgressiveRetry executes a BackoffOperation and waits an increasing time before retrying the operation.
+//
+// Deprecated: Use retryutils.ProgressiveRetry instead.
 func ProgressiveRetry(operation func() error) error {
-	var err error
-
-	for attempts := 0; attempts < len(backoffTimeouts); attempts++ {
-		err = operation()
-		if err == nil {
-			return nil
-		}
-
-		time.Sleep(backoffTimeouts[attempts])
-	}
-
-	return err
+	return retryutils.ProgressiveRetry(operation)
 }
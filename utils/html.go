@@ -6,12 +6,16 @@ package utils
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"html/template"
 	"io"
+	"io/ioutil"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"text/template/parse"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/mattermost/go-i18n/i18n"
@@ -20,9 +24,14 @@ import (
 )
 
 type HTMLTemplateWatcher struct {
-	templates atomic.Value
-	stop      chan struct{}
-	stopped   chan struct{}
+	templates    atomic.Value
+	templatesDir string
+	watcher      *fsnotify.Watcher
+	stop         chan struct{}
+	stopped      chan struct{}
+
+	mutex             sync.Mutex
+	overrideDirectory string
 }
 
 func NewHTMLTemplateWatcher(directory string) (*HTMLTemplateWatcher, error) {
@@ -30,8 +39,9 @@ func NewHTMLTemplateWatcher(directory string) (*HTMLTemplateWatcher, error) {
 	mlog.Debug("Parsing server templates", mlog.String("templates_directory", templatesDir))
 
 	ret := &HTMLTemplateWatcher{
-		stop:    make(chan struct{}),
-		stopped: make(chan struct{}),
+		templatesDir: templatesDir,
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
 	}
 
 	watcher, err := fsnotify.NewWatcher()
@@ -40,13 +50,14 @@ func NewHTMLTemplateWatcher(directory string) (*HTMLTemplateWatcher, error) {
 	}
 
 	if err = watcher.Add(templatesDir); err != nil {
+		watcher.Close()
 		return nil, err
 	}
+	ret.watcher = watcher
 
-	if htmlTemplates, err := template.ParseGlob(filepath.Join(templatesDir, "*.html")); err != nil {
+	if err := ret.parse(); err != nil {
+		watcher.Close()
 		return nil, err
-	} else {
-		ret.templates.Store(htmlTemplates)
 	}
 
 	go func() {
@@ -60,10 +71,8 @@ func NewHTMLTemplateWatcher(directory string) (*HTMLTemplateWatcher, error) {
 			case event := <-watcher.Events:
 				if event.Op&fsnotify.Write == fsnotify.Write {
 					mlog.Info("Re-parsing templates because of modified file", mlog.String("file_name", event.Name))
-					if htmlTemplates, err := template.ParseGlob(filepath.Join(templatesDir, "*.html")); err != nil {
+					if err := ret.parse(); err != nil {
 						mlog.Error("Failed to parse templates.", mlog.Err(err))
-					} else {
-						ret.templates.Store(htmlTemplates)
 					}
 				}
 			case err := <-watcher.Errors:
@@ -75,6 +84,130 @@ func NewHTMLTemplateWatcher(directory string) (*HTMLTemplateWatcher, error) {
 	return ret, nil
 }
 
+// SetOverrideDirectory sets a directory of templates to be used in preference to the ones of the
+// templates directory, and watches it for changes. Each file there overrides the templates it
+// defines, as long as it parses and only defines templates that already exist. Files that don't
+// are logged and skipped, leaving the default templates in place. An empty directory removes the
+// overrides.
+func (w *HTMLTemplateWatcher) SetOverrideDirectory(directory string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if directory == w.overrideDirectory {
+		return
+	}
+
+	if w.overrideDirectory != "" {
+		w.watcher.Remove(w.overrideDirectory)
+	}
+	w.overrideDirectory = directory
+
+	if directory != "" {
+		mlog.Debug("Parsing server template overrides", mlog.String("override_directory", directory))
+		if err := w.watcher.Add(directory); err != nil {
+			mlog.Error("Failed to watch the template override directory", mlog.String("override_directory", directory), mlog.Err(err))
+		}
+	}
+
+	if err := w.parseLocked(); err != nil {
+		mlog.Error("Failed to parse templates.", mlog.Err(err))
+	}
+}
+
+func (w *HTMLTemplateWatcher) parse() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.parseLocked()
+}
+
+func (w *HTMLTemplateWatcher) parseLocked() error {
+	htmlTemplates, err := template.ParseGlob(filepath.Join(w.templatesDir, "*.html"))
+	if err != nil {
+		return err
+	}
+
+	if w.overrideDirectory != "" {
+		htmlTemplates = applyTemplateOverrides(htmlTemplates, w.overrideDirectory)
+	}
+
+	w.templates.Store(htmlTemplates)
+
+	return nil
+}
+
+func applyTemplateOverrides(htmlTemplates *template.Template, directory string) *template.Template {
+	files, err := filepath.Glob(filepath.Join(directory, "*.html"))
+	if err != nil {
+		mlog.Error("Failed to list template overrides", mlog.String("override_directory", directory), mlog.Err(err))
+		return htmlTemplates
+	}
+
+	for _, file := range files {
+		overridden, err := overrideTemplates(htmlTemplates, file)
+		if err != nil {
+			mlog.Error("Failed to apply template override, using the default templates instead", mlog.String("file_name", file), mlog.Err(err))
+			continue
+		}
+		htmlTemplates = overridden
+	}
+
+	return htmlTemplates
+}
+
+func overrideTemplates(htmlTemplates *template.Template, file string) (*template.Template, error) {
+	overrides, err := template.ParseFiles(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, override := range overrides.Templates() {
+		if override.Name() == overrides.Name() {
+			continue
+		}
+		if htmlTemplates.Lookup(override.Name()) == nil {
+			return nil, fmt.Errorf("%q is not a known template", override.Name())
+		}
+		names = append(names, override.Name())
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no templates are defined")
+	}
+
+	overridden, err := htmlTemplates.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if overridden, err = overridden.ParseFiles(file); err != nil {
+		return nil, err
+	}
+
+	// Templates are only rendered against empty data here, so an override is only rejected when it
+	// fails where the default template succeeds, e.g. because it uses fields that don't exist.
+	for _, name := range names {
+		if tryExecuteTemplate(htmlTemplates, name) != nil {
+			continue
+		}
+		if err := tryExecuteTemplate(overridden, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return overridden, nil
+}
+
+// tryExecuteTemplate executes a copy of the templates, since templates can't be changed any
+// further once they have been executed.
+func tryExecuteTemplate(htmlTemplates *template.Template, name string) error {
+	clone, err := htmlTemplates.Clone()
+	if err != nil {
+		return err
+	}
+
+	return clone.ExecuteTemplate(ioutil.Discard, name, NewHTMLTemplate(clone, name))
+}
+
 func (w *HTMLTemplateWatcher) Templates() *template.Template {
 	return w.templates.Load().(*template.Template)
 }
@@ -119,6 +252,74 @@ func (t *HTMLTemplate) RenderToWriter(w io.Writer) error {
 	return nil
 }
 
+// TemplateFields returns the names of the Props and Html fields used by the given template,
+// including the ones used by the templates it includes.
+func TemplateFields(templates *template.Template, templateName string) (props []string, html []string) {
+	seenTemplates := map[string]bool{}
+	seenFields := map[string]bool{}
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+			for _, child := range node.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(node.Pipe)
+		case *parse.IfNode:
+			walk(node.Pipe)
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.RangeNode:
+			walk(node.Pipe)
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.WithNode:
+			walk(node.Pipe)
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.PipeNode:
+			if node == nil {
+				return
+			}
+			for _, cmd := range node.Cmds {
+				for _, arg := range cmd.Args {
+					walk(arg)
+				}
+			}
+		case *parse.TemplateNode:
+			walk(node.Pipe)
+			if included := templates.Lookup(node.Name); included != nil && !seenTemplates[node.Name] {
+				seenTemplates[node.Name] = true
+				walk(included.Tree.Root)
+			}
+		case *parse.FieldNode:
+			if len(node.Ident) < 2 || seenFields[node.Ident[0]+"."+node.Ident[1]] {
+				return
+			}
+			seenFields[node.Ident[0]+"."+node.Ident[1]] = true
+
+			switch node.Ident[0] {
+			case "Props":
+				props = append(props, node.Ident[1])
+			case "Html":
+				html = append(html, node.Ident[1])
+			}
+		}
+	}
+
+	if t := templates.Lookup(templateName); t != nil && t.Tree != nil {
+		seenTemplates[templateName] = true
+		walk(t.Tree.Root)
+	}
+
+	return props, html
+}
+
 func TranslateAsHtml(t i18n.TranslateFunc, translationID string, args map[string]interface{}) template.HTML {
 	message := t(translationID, escapeForHtml(args))
 	message = strings.Replace(message, "[[", "<strong>", -1)
@@ -75,6 +75,80 @@ func TestHTMLTemplateWatcher_BadDirectory(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestHTMLTemplateWatcher_OverrideDirectory(t *testing.T) {
+	TranslationsPreInit()
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	templatesDir := filepath.Join(dir, "templates")
+	overrideDir := filepath.Join(dir, "overrides")
+	require.NoError(t, os.Mkdir(templatesDir, 0700))
+	require.NoError(t, os.Mkdir(overrideDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(templatesDir, "foo.html"), []byte(`{{ define "foo" }}foo{{ template "footer" . }}{{ end }}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(templatesDir, "bar.html"), []byte(`{{ define "bar" }}bar{{ .Props.Bar }}{{ end }}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(templatesDir, "footer.html"), []byte(`{{ define "footer" }} footer{{ end }}`), 0600))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overrideDir, "footer.html"), []byte(`{{ define "footer" }} legal footer{{ end }}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overrideDir, "bar.html"), []byte(`{{ define "bar" }}custom bar{{ .NotAField }}{{ end }}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overrideDir, "unknown.html"), []byte(`{{ define "unknown" }}unknown{{ end }}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overrideDir, "broken.html"), []byte(`{{ define "foo" }}broken{{ end`), 0600))
+
+	watcher, err := NewHTMLTemplateWatcher(templatesDir)
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	watcher.SetOverrideDirectory(overrideDir)
+
+	t.Run("valid overrides are used", func(t *testing.T) {
+		assert.Equal(t, "foo legal footer", NewHTMLTemplate(watcher.Templates(), "foo").Render())
+	})
+
+	t.Run("invalid overrides fall back to the default template", func(t *testing.T) {
+		assert.Equal(t, "bar", NewHTMLTemplate(watcher.Templates(), "bar").Render())
+	})
+
+	t.Run("overrides can't add templates", func(t *testing.T) {
+		assert.Nil(t, watcher.Templates().Lookup("unknown"))
+	})
+
+	t.Run("modified overrides are reloaded", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(overrideDir, "bar.html"), []byte(`{{ define "bar" }}custom bar{{ .Props.Bar }}{{ end }}`), 0600))
+
+		var rendered string
+		for i := 0; i < 30; i++ {
+			rendered = NewHTMLTemplate(watcher.Templates(), "bar").Render()
+			if rendered == "custom bar" {
+				break
+			}
+			time.Sleep(time.Millisecond * 50)
+		}
+		assert.Equal(t, "custom bar", rendered)
+	})
+
+	t.Run("removing the override directory restores the default templates", func(t *testing.T) {
+		watcher.SetOverrideDirectory("")
+
+		assert.Equal(t, "foo footer", NewHTMLTemplate(watcher.Templates(), "foo").Render())
+		assert.Equal(t, "bar", NewHTMLTemplate(watcher.Templates(), "bar").Render())
+	})
+}
+
+func TestTemplateFields(t *testing.T) {
+	tpl, err := template.New("test").Parse(`{{ define "footer" }}{{ .Props.Footer }}{{ .Props.Title }}{{ end }}` +
+		`{{ define "foo" }}{{ .Props.Title }}{{ if .Props.Link }}{{ .Html.Info }}{{ end }}{{ template "footer" . }}{{ end }}`)
+	require.NoError(t, err)
+
+	props, html := TemplateFields(tpl, "foo")
+	assert.Equal(t, []string{"Title", "Link", "Footer"}, props)
+	assert.Equal(t, []string{"Info"}, html)
+
+	props, html = TemplateFields(tpl, "unknown")
+	assert.Empty(t, props)
+	assert.Empty(t, html)
+}
+
 func TestHTMLTemplate(t *testing.T) {
 	tpl := template.New("test")
 	_, err := tpl.Parse(`{{ define "foo" }}foo{{ .Props.Bar }}{{ end }}`)
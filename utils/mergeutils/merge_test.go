@@ -1,7 +1,7 @@
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
-package utils_test
+package mergeutils_test
 
 import (
 	"fmt"
@@ -11,7 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/mattermost/mattermost-server/v5/utils"
+	"github.com/mattermost/mattermost-server/v5/utils/mergeutils"
 )
 
 // Test merging maps alone. This isolates the complexity of merging maps from merging maps recursively in
@@ -1168,7 +1168,7 @@ func TestMergeWithStructFieldFilter(t *testing.T) {
 		t2 := evenSimpler{newBool(false), &evenSimpler2{newString("patch")}}
 		expected := evenSimpler{newBool(true), &evenSimpler2{newString("base")}}
 
-		merged, err := mergeEvenSimplerWithConfig(t1, t2, &utils.MergeConfig{
+		merged, err := mergeEvenSimplerWithConfig(t1, t2, &mergeutils.MergeConfig{
 			StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
 				return false
 			},
@@ -1183,7 +1183,7 @@ func TestMergeWithStructFieldFilter(t *testing.T) {
 		t2 := evenSimpler{newBool(false), &evenSimpler2{newString("patch")}}
 		expected := evenSimpler{newBool(false), &evenSimpler2{newString("base")}}
 
-		merged, err := mergeEvenSimplerWithConfig(t1, t2, &utils.MergeConfig{
+		merged, err := mergeEvenSimplerWithConfig(t1, t2, &mergeutils.MergeConfig{
 			StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
 				return structField.Name == "B"
 			},
@@ -1545,7 +1545,7 @@ func setupStructs(t *testing.T) {
 }
 
 func mergeSimple(base, patch simple) (*simple, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1554,7 +1554,7 @@ func mergeSimple(base, patch simple) (*simple, error) {
 }
 
 func mergeEvenSimpler(base, patch evenSimpler) (*evenSimpler, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1562,8 +1562,8 @@ func mergeEvenSimpler(base, patch evenSimpler) (*evenSimpler, error) {
 	return &retTS, nil
 }
 
-func mergeEvenSimplerWithConfig(base, patch evenSimpler, mergeConfig *utils.MergeConfig) (*evenSimpler, error) {
-	ret, err := utils.Merge(base, patch, mergeConfig)
+func mergeEvenSimplerWithConfig(base, patch evenSimpler, mergeConfig *mergeutils.MergeConfig) (*evenSimpler, error) {
+	ret, err := mergeutils.Merge(base, patch, mergeConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -1572,7 +1572,7 @@ func mergeEvenSimplerWithConfig(base, patch evenSimpler, mergeConfig *utils.Merg
 }
 
 func mergeSliceStruct(base, patch sliceStruct) (*sliceStruct, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1581,7 +1581,7 @@ func mergeSliceStruct(base, patch sliceStruct) (*sliceStruct, error) {
 }
 
 func mergeMapPtr(base, patch mapPtr) (*mapPtr, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1590,7 +1590,7 @@ func mergeMapPtr(base, patch mapPtr) (*mapPtr, error) {
 }
 
 func mergeMapPtrState(base, patch mapPtrState) (*mapPtrState, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1599,7 +1599,7 @@ func mergeMapPtrState(base, patch mapPtrState) (*mapPtrState, error) {
 }
 
 func mergeMapPtrState2(base, patch mapPtrState2) (*mapPtrState2, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1608,7 +1608,7 @@ func mergeMapPtrState2(base, patch mapPtrState2) (*mapPtrState2, error) {
 }
 
 func mergeTestStructs(base, patch testStruct) (*testStruct, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1617,7 +1617,7 @@ func mergeTestStructs(base, patch testStruct) (*testStruct, error) {
 }
 
 func mergeStringIntMap(base, patch map[string]int) (map[string]int, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1626,7 +1626,7 @@ func mergeStringIntMap(base, patch map[string]int) (map[string]int, error) {
 }
 
 func mergeStringPtrIntMap(base, patch map[string]*int) (map[string]*int, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1635,7 +1635,7 @@ func mergeStringPtrIntMap(base, patch map[string]*int) (map[string]*int, error)
 }
 
 func mergeStringSliceIntMap(base, patch map[string][]int) (map[string][]int, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1644,7 +1644,7 @@ func mergeStringSliceIntMap(base, patch map[string][]int) (map[string][]int, err
 }
 
 func mergeMapOfMap(base, patch map[string]map[string]*int) (map[string]map[string]*int, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1653,7 +1653,7 @@ func mergeMapOfMap(base, patch map[string]map[string]*int) (map[string]map[strin
 }
 
 func mergeInterfaceMap(base, patch map[string]interface{}) (map[string]interface{}, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1662,7 +1662,7 @@ func mergeInterfaceMap(base, patch map[string]interface{}) (map[string]interface
 }
 
 func mergeStringSlices(base, patch []string) ([]string, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1671,7 +1671,7 @@ func mergeStringSlices(base, patch []string) ([]string, error) {
 }
 
 func mergeTestStructsPtrs(base, patch *testStruct) (*testStruct, error) {
-	ret, err := utils.Merge(base, patch, nil)
+	ret, err := mergeutils.Merge(base, patch, nil)
 	if err != nil {
 		return nil, err
 	}
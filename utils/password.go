@@ -4,8 +4,14 @@
 package utils
 
 import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 )
@@ -56,3 +62,50 @@ func IsPasswordValidWithSettings(password string, settings *model.PasswordSettin
 
 	return nil
 }
+
+var (
+	// passwordBreachRangeURL is the HaveIBeenPwned endpoint returning the suffixes of the breached
+	// password hashes that start with a prefix.
+	passwordBreachRangeURL = "https://api.pwnedpasswords.com/range/"
+
+	passwordBreachHTTPClient = &http.Client{Timeout: 5 * time.Second}
+)
+
+// CheckPasswordBreach reports whether the password appears in the HaveIBeenPwned breach data. Only the
+// first 5 characters of the SHA1 hash of the password are sent, and the rest is matched locally.
+func CheckPasswordBreach(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequest(http.MethodGet, passwordBreachRangeURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the number of breached hashes sharing the prefix from an observer.
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := passwordBreachHTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d from the password breach API", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], suffix) {
+			continue
+		}
+
+		// The padding entries have a count of 0.
+		count, err := strconv.Atoi(parts[1])
+		return err == nil && count > 0, nil
+	}
+
+	return false, scanner.Err()
+}
@@ -4,10 +4,16 @@
 package utils
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 )
@@ -128,3 +134,59 @@ func TestIsPasswordValidWithSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPasswordBreach(t *testing.T) {
+	sum := sha1.Sum([]byte("padding"))
+	paddingSuffix := strings.ToUpper(hex.EncodeToString(sum[:]))[5:]
+
+	var requestedPath, padding string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		padding = r.Header.Get("Add-Padding")
+		w.WriteHeader(status)
+		// The hash of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8, and the one of
+		// "padding" is only listed as a padding entry.
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n")
+		fmt.Fprintf(w, "%s:0\r\n", paddingSuffix)
+	}))
+	defer server.Close()
+
+	defaultURL := passwordBreachRangeURL
+	passwordBreachRangeURL = server.URL + "/range/"
+	defer func() {
+		passwordBreachRangeURL = defaultURL
+	}()
+
+	t.Run("breached password", func(t *testing.T) {
+		breached, err := CheckPasswordBreach("password")
+		require.NoError(t, err)
+		assert.True(t, breached)
+		assert.Equal(t, "/range/5BAA6", requestedPath)
+		assert.Equal(t, "true", padding)
+	})
+
+	t.Run("password not breached", func(t *testing.T) {
+		breached, err := CheckPasswordBreach("correct horse battery staple " + model.NewId())
+		require.NoError(t, err)
+		assert.False(t, breached)
+	})
+
+	t.Run("padding entry", func(t *testing.T) {
+		breached, err := CheckPasswordBreach("padding")
+		require.NoError(t, err)
+		assert.False(t, breached)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		defer func() {
+			status = http.StatusOK
+		}()
+
+		breached, err := CheckPasswordBreach("password")
+		require.Error(t, err)
+		assert.False(t, breached)
+	})
+}
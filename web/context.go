@@ -22,6 +22,8 @@ type Context struct {
 	Params        *Params
 	Err           *model.AppError
 	siteURLHeader string
+	// rawQuery is the query of the request, recorded in audit records once redacted.
+	rawQuery string
 }
 
 // LogAuditRec logs an audit record using default RestLevel.
@@ -57,6 +59,9 @@ func (c *Context) MakeAuditRecord(event string, initialStatus string) *audit.Rec
 		IPAddress: c.App.IpAddress(),
 		Meta:      audit.Meta{audit.KeyClusterID: c.App.GetClusterId()},
 	}
+	if c.rawQuery != "" {
+		rec.Meta[audit.KeyQuery] = c.rawQuery
+	}
 	rec.AddMetaTypeConverter(model.AuditModelTypeConv)
 
 	return rec
@@ -142,8 +147,11 @@ func (c *Context) SessionRequired() {
 }
 
 func (c *Context) MfaRequired() {
-	// Must be licensed for MFA and have it configured for enforcement
-	if license := c.App.Srv().License(); license == nil || !*license.Features.MFA || !*c.App.Config().ServiceSettings.EnableMultifactorAuthentication || !*c.App.Config().ServiceSettings.EnforceMultifactorAuthentication {
+	// Must be licensed for MFA and have it configured for enforcement, for everyone or for some roles
+	if license := c.App.Srv().License(); license == nil || !*license.Features.MFA || !*c.App.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return
+	}
+	if !*c.App.Config().ServiceSettings.EnforceMultifactorAuthentication && len(c.App.Config().ServiceSettings.EnforceMultifactorAuthenticationForRoles) == 0 {
 		return
 	}
 
@@ -156,9 +164,6 @@ func (c *Context) MfaRequired() {
 		c.Err = model.NewAppError("", "api.context.session_expired.app_error", nil, "MfaRequired", http.StatusUnauthorized)
 		return
 	} else {
-		if user.IsGuest() && !*c.App.Config().GuestAccountsSettings.EnforceMultifactorAuthentication {
-			return
-		}
 		// Only required for email and ldap accounts
 		if user.AuthService != "" &&
 			user.AuthService != model.USER_AUTH_SERVICE_EMAIL &&
@@ -177,7 +182,11 @@ func (c *Context) MfaRequired() {
 			return
 		}
 
-		if !user.MfaActive {
+		if user.MfaActive {
+			return
+		}
+
+		if c.App.IsMfaEnforcedForSession(c.App.Session(), user) {
 			c.Err = model.NewAppError("", "api.context.mfa_required.app_error", nil, "MfaRequired", http.StatusForbidden)
 			return
 		}
@@ -304,6 +313,50 @@ func (c *Context) RequireCategoryId() *Context {
 	return c
 }
 
+func (c *Context) RequireBookmarkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.BookmarkId) {
+		c.SetInvalidUrlParam("bookmark_id")
+	}
+	return c
+}
+
+func (c *Context) RequireConfigId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ConfigId) {
+		c.SetInvalidUrlParam("config_id")
+	}
+	return c
+}
+
+func (c *Context) RequireFeatureFlagName() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.FeatureFlagName) == 0 {
+		c.SetInvalidUrlParam("feature_flag_name")
+	}
+	return c
+}
+
+func (c *Context) RequireSavedSearchId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.SavedSearchId) {
+		c.SetInvalidUrlParam("saved_search_id")
+	}
+	return c
+}
+
 func (c *Context) RequireInviteId() *Context {
 	if c.Err != nil {
 		return c
@@ -12,9 +12,11 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NYTimes/gziphandler"
+	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/app"
 	app_opentracing "github.com/mattermost/mattermost-server/v5/app/opentracing"
 	"github.com/mattermost/mattermost-server/v5/mlog"
@@ -113,6 +115,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.App.SetUserAgent(r.UserAgent())
 	c.App.SetAcceptLanguage(r.Header.Get("Accept-Language"))
 	c.App.SetPath(r.URL.Path)
+	c.rawQuery = r.URL.RawQuery
 	c.Params = ParamsFromRequest(r)
 	c.Log = c.App.Log()
 
@@ -198,11 +201,14 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Rate limit by UserID
-		if c.App.Srv().RateLimiter != nil && c.App.Srv().RateLimiter.UserIdRateLimit(c.App.Session().UserId, w) {
+		if c.App.Srv().RateLimiter != nil && c.App.Srv().RateLimiter.UserIdRateLimit(c.App.Session().UserId, w, r) {
 			return
 		}
 
-		h.checkCSRFToken(c, r, token, tokenLocation, session)
+		if _, passed := h.checkCSRFToken(c, r, token, tokenLocation, session); passed && r.Header.Get(model.HEADER_CSRF_TOKEN) != session.GetCSRF() {
+			// The request only passed thanks to the deprecated X-Requested-With header.
+			w.Header().Set("Warning", `299 - "X-Requested-With is deprecated, send the X-CSRF-Token header instead"`)
+		}
 	}
 
 	c.Log = c.App.Log().With(
@@ -287,8 +293,83 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != model.API_URL_SUFFIX+"/websocket" {
 			elapsed := float64(time.Since(now)) / float64(time.Second)
 			c.App.Metrics().ObserveApiEndpointDuration(h.HandlerName, r.Method, statusCode, elapsed)
+
+			route := routeLabel(r)
+			c.App.Metrics().ObserveApiRouteDuration(route, r.Method, elapsed)
+			c.App.Metrics().ObserveApiRouteResponseSize(route, r.Method, float64(w.(*responseWriterWrapper).BytesWritten()))
+			c.App.Metrics().IncrementApiRouteStatusClass(route, r.Method, statusClass(w.(*responseWriterWrapper).StatusCode()))
+		}
+	}
+}
+
+// routeLabels caches the label of each route path template, since there are only as many as there are routes.
+var routeLabels sync.Map
+
+// routeLabel returns the path template of the route that matched the request without the patterns of its
+// variables, such as /api/v4/channels/{channel_id}/posts, for labelling metrics. Requests that matched no
+// route share the same label.
+func routeLabel(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "other"
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "other"
+	}
+
+	if label, ok := routeLabels.Load(template); ok {
+		return label.(string)
+	}
+
+	label := stripRouteVariablePatterns(template)
+	routeLabels.Store(template, label)
+
+	return label
+}
+
+// stripRouteVariablePatterns turns the variables of a route path template such as {channel_id:[A-Za-z0-9]+}
+// into {channel_id}. Patterns may contain braces of their own, such as [a-z]{26}.
+func stripRouteVariablePatterns(template string) string {
+	var b strings.Builder
+	depth := 0
+	inPattern := false
+
+	for _, c := range template {
+		switch {
+		case c == '{':
+			depth++
+			if depth == 1 {
+				b.WriteRune(c)
+				continue
+			}
+		case c == '}':
+			depth--
+			if depth == 0 {
+				inPattern = false
+				b.WriteRune(c)
+				continue
+			}
+		case c == ':' && depth == 1:
+			inPattern = true
+		}
+
+		if !inPattern {
+			b.WriteRune(c)
 		}
 	}
+
+	return b.String()
+}
+
+// statusClass returns the class of an HTTP status code, such as "2xx".
+func statusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return "other"
+	}
+
+	return strconv.Itoa(statusCode/100) + "xx"
 }
 
 // checkCSRFToken performs a CSRF check on the provided request with the given CSRF token. Returns whether or not
@@ -324,7 +405,7 @@ func (h *Handler) checkCSRFToken(c *Context, r *http.Request, token string, toke
 			if *c.App.Config().ServiceSettings.ExperimentalStrictCSRFEnforcement {
 				c.Log.Warn(csrfErrorMessage, fields...)
 			} else {
-				c.Log.Debug(csrfErrorMessage, fields...)
+				c.Log.Warn("Deprecated X-Requested-With header used to pass the CSRF check", fields...)
 				csrfCheckPassed = true
 			}
 		}
@@ -338,6 +419,16 @@ func (h *Handler) checkCSRFToken(c *Context, r *http.Request, token string, toke
 	return csrfCheckNeeded, csrfCheckPassed
 }
 
+// GzipHandler compresses the responses of the handler at the compression level set in the config.
+func GzipHandler(cfg *model.Config, handler http.Handler) http.Handler {
+	gzipWrapper, err := gziphandler.NewGzipLevelHandler(*cfg.ServiceSettings.HTTPResponseCompressionLevel)
+	if err != nil {
+		mlog.Warn("Invalid HTTP response compression level, using the default", mlog.Err(err))
+		return gziphandler.GzipHandler(handler)
+	}
+	return gzipWrapper(handler)
+}
+
 // ApiHandler provides a handler for API endpoints which do not require the user to be logged in order for access to be
 // granted.
 func (w *Web) ApiHandler(h func(*Context, http.ResponseWriter, *http.Request)) http.Handler {
@@ -352,7 +443,7 @@ func (w *Web) ApiHandler(h func(*Context, http.ResponseWriter, *http.Request)) h
 		IsLocal:             false,
 	}
 	if *w.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return GzipHandler(w.ConfigService.Config(), handler)
 	}
 	return handler
 }
@@ -372,7 +463,7 @@ func (w *Web) ApiHandlerTrustRequester(h func(*Context, http.ResponseWriter, *ht
 		IsLocal:             false,
 	}
 	if *w.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return GzipHandler(w.ConfigService.Config(), handler)
 	}
 	return handler
 }
@@ -391,7 +482,7 @@ func (w *Web) ApiSessionRequired(h func(*Context, http.ResponseWriter, *http.Req
 		IsLocal:             false,
 	}
 	if *w.ConfigService.Config().ServiceSettings.WebserverMode == "gzip" {
-		return gziphandler.GzipHandler(handler)
+		return GzipHandler(w.ConfigService.Config(), handler)
 	}
 	return handler
 }
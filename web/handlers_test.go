@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/app"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin/plugintest/mock"
@@ -657,3 +658,32 @@ func TestCheckCSRFToken(t *testing.T) {
 		assert.Nil(t, c.Err)
 	})
 }
+
+func TestRouteLabel(t *testing.T) {
+	router := mux.NewRouter()
+	channels := router.PathPrefix("/api/v4/channels/{channel_id:[A-Za-z0-9]+}").Subrouter()
+
+	var label string
+	channels.HandleFunc("/posts", func(w http.ResponseWriter, r *http.Request) {
+		label = routeLabel(r)
+	})
+	channels.HandleFunc("/members/{user_id:[a-z0-9]{26}}", func(w http.ResponseWriter, r *http.Request) {
+		label = routeLabel(r)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v4/channels/"+model.NewId()+"/posts", nil))
+	assert.Equal(t, "/api/v4/channels/{channel_id}/posts", label)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v4/channels/"+model.NewId()+"/members/"+model.NewId(), nil))
+	assert.Equal(t, "/api/v4/channels/{channel_id}/members/{user_id}", label)
+
+	assert.Equal(t, "other", routeLabel(httptest.NewRequest("GET", "/api/v4/channels", nil)))
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass(http.StatusOK))
+	assert.Equal(t, "3xx", statusClass(http.StatusNotModified))
+	assert.Equal(t, "4xx", statusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", statusClass(http.StatusServiceUnavailable))
+	assert.Equal(t, "other", statusClass(0))
+}
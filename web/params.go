@@ -78,6 +78,10 @@ type Params struct {
 	FilterAllowReference      bool
 	FilterParentTeamPermitted bool
 	CategoryId                string
+	BookmarkId                string
+	SavedSearchId             string
+	ConfigId                  string
+	FeatureFlagName           string
 }
 
 func ParamsFromRequest(r *http.Request) *Params {
@@ -98,6 +102,22 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.CategoryId = val
 	}
 
+	if val, ok := props["bookmark_id"]; ok {
+		params.BookmarkId = val
+	}
+
+	if val, ok := props["saved_search_id"]; ok {
+		params.SavedSearchId = val
+	}
+
+	if val, ok := props["config_id"]; ok {
+		params.ConfigId = val
+	}
+
+	if val, ok := props["feature_flag_name"]; ok {
+		params.FeatureFlagName = val
+	}
+
 	if val, ok := props["invite_id"]; ok {
 		params.InviteId = val
 	}
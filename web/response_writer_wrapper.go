@@ -14,6 +14,7 @@ type responseWriterWrapper struct {
 	http.ResponseWriter
 	statusCode        int
 	statusCodeWritten bool
+	bytesWritten      int
 	hijacker          http.Hijacker
 	flusher           http.Flusher
 }
@@ -33,6 +34,11 @@ func (rw *responseWriterWrapper) StatusCode() int {
 	return rw.statusCode
 }
 
+// BytesWritten returns the size of the response body written so far.
+func (rw *responseWriterWrapper) BytesWritten() int {
+	return rw.bytesWritten
+}
+
 func (rw *responseWriterWrapper) WriteHeader(statusCode int) {
 	rw.statusCode = statusCode
 	rw.statusCodeWritten = true
@@ -43,7 +49,9 @@ func (rw *responseWriterWrapper) Write(data []byte) (int, error) {
 	if !rw.statusCodeWritten {
 		rw.statusCode = http.StatusOK
 	}
-	return rw.ResponseWriter.Write(data)
+	n, err := rw.ResponseWriter.Write(data)
+	rw.bytesWritten += n
+	return n, err
 }
 
 // Using as embedded makes the ResponseWrite be stored as interface and that way
@@ -54,6 +54,17 @@ func TestStatusCodeShouldBe200IfNotHeaderWritten(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode())
 }
 
+func TestBytesWrittenIsAccessible(t *testing.T) {
+	resp := newWrappedWriter(httptest.NewRecorder())
+	req := httptest.NewRequest("GET", "/api/v4/test", nil)
+	handler := TestHandler{func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+		w.Write([]byte(" world"))
+	}}
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, 11, resp.BytesWritten())
+}
+
 func TestForUnsupportedHijack(t *testing.T) {
 	resp := newWrappedWriter(httptest.NewRecorder())
 	req := httptest.NewRequest("GET", "/api/v4/test", nil)
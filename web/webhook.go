@@ -4,7 +4,10 @@
 package web
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
 	"strings"
@@ -25,11 +28,24 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
+	capture := newIncomingWebhookRequestCapture(c, id, r)
+
 	r.ParseForm()
 
 	var err *model.AppError
 	var mediaType string
+	var parsed bool
 	incomingWebhookPayload := &model.IncomingWebhookRequest{}
+
+	if capture != nil {
+		defer func() {
+			if parsed {
+				capture.Payload = json.RawMessage(incomingWebhookPayload.ToJson())
+			}
+			finishIncomingWebhookRequestCapture(c, id, capture)
+		}()
+	}
+
 	contentType := r.Header.Get("Content-Type")
 	// Content-Type header is optional so could be empty
 	if contentType != "" {
@@ -85,6 +101,8 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	parsed = true
+
 	err = c.App.HandleIncomingWebhook(id, incomingWebhookPayload)
 	if err != nil {
 		c.Err = err
@@ -95,6 +113,54 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
+// newIncomingWebhookRequestCapture starts capturing the request when debugging is enabled for the
+// incoming webhook. The beginning of the body is buffered so that it can still be read in full when
+// handling the request.
+func newIncomingWebhookRequestCapture(c *Context, hookId string, r *http.Request) *model.IncomingWebhookRequestCapture {
+	if !c.App.IsIncomingWebhookDebuggingEnabled(hookId) {
+		return nil
+	}
+
+	capture := &model.IncomingWebhookRequestCapture{
+		CreateAt:    model.GetMillis(),
+		RequestId:   c.App.RequestId(),
+		Method:      r.Method,
+		ContentType: r.Header.Get("Content-Type"),
+		Headers:     model.RedactIncomingWebhookHeaders(r.Header),
+	}
+
+	body, readErr := ioutil.ReadAll(io.LimitReader(r.Body, model.INCOMING_WEBHOOK_DEBUG_MAX_BODY_SIZE+1))
+	if readErr != nil {
+		mlog.Debug("Failed to read the incoming webhook request for debugging", mlog.String("webhook_id", hookId), mlog.Err(readErr))
+	}
+	capture.SetBody(body)
+
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+	return capture
+}
+
+// finishIncomingWebhookRequestCapture records the outcome of handling the request. Details of
+// server errors are left out, since they are of no use to integrators.
+func finishIncomingWebhookRequestCapture(c *Context, hookId string, capture *model.IncomingWebhookRequestCapture) {
+	capture.StatusCode = http.StatusOK
+	if c.Err != nil {
+		c.Err.Translate(c.App.T)
+
+		capture.StatusCode = c.Err.StatusCode
+		capture.ErrorId = c.Err.Id
+		capture.Error = c.Err.Message
+		if c.Err.StatusCode < http.StatusInternalServerError && c.Err.DetailedError != "" {
+			capture.Error += " " + c.Err.DetailedError
+		}
+	}
+
+	c.App.CaptureIncomingWebhookRequest(hookId, capture)
+}
+
 func commandWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package wsapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/audit"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func (api *API) InitPost() {
+	api.Router.Handle("create_post", api.ApiWebSocketConnHandler(api.createPost))
+}
+
+// createPost creates a post like POST /api/v4/posts does, for clients that asked for the
+// create_post capability when connecting.
+func (api *API) createPost(conn *app.WebConn, req *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+	if !conn.HasCapability(model.WEBSOCKET_CAPABILITY_CREATE_POST) {
+		return nil, model.NewAppError("websocket: "+req.Action, "api.websocket_handler.create_post.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if rateLimiter := api.App.Srv().RateLimiter; rateLimiter != nil && rateLimiter.WebSocketRateLimit(conn.RateLimitKey(), req.Session.UserId) {
+		return nil, model.NewAppError("websocket: "+req.Action, "api.websocket_handler.create_post.rate_limited.app_error", nil, "", http.StatusTooManyRequests)
+	}
+
+	api.App.ExtendSessionExpiryIfNeeded(&req.Session)
+
+	data, ok := req.Data["post"].(map[string]interface{})
+	if !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "post")
+	}
+	b, _ := json.Marshal(data)
+	post := model.PostFromJson(bytes.NewReader(b))
+	if post == nil {
+		return nil, NewInvalidWebSocketParamError(req.Action, "post")
+	}
+
+	var setOnline bool
+	if setOnline, ok = req.Data["set_online"].(bool); !ok {
+		setOnline = true // By default, always set online.
+	}
+
+	auditRec := api.App.MakeAuditRecord("createPost", audit.Fail)
+	auditRec.UserID = req.Session.UserId
+	auditRec.SessionID = req.Session.Id
+	auditRec.Client = "websocket"
+
+	rp, err := api.App.CreatePostForSession(req.Session, post, setOnline, auditRec)
+	if err != nil {
+		api.App.LogAuditRecWithLevel(auditRec, app.RestContentLevel, err)
+		return nil, err
+	}
+	api.App.LogAuditRecWithLevel(auditRec, app.RestContentLevel, nil)
+
+	return map[string]interface{}{"post": rp}, nil
+}
@@ -13,12 +13,20 @@ import (
 )
 
 func (api *API) ApiWebSocketHandler(wh func(*model.WebSocketRequest) (map[string]interface{}, *model.AppError)) webSocketHandler {
+	return webSocketHandler{api.App, func(conn *app.WebConn, r *model.WebSocketRequest) (map[string]interface{}, *model.AppError) {
+		return wh(r)
+	}}
+}
+
+// ApiWebSocketConnHandler is like ApiWebSocketHandler, for handlers that need the connection the
+// request was made through.
+func (api *API) ApiWebSocketConnHandler(wh func(*app.WebConn, *model.WebSocketRequest) (map[string]interface{}, *model.AppError)) webSocketHandler {
 	return webSocketHandler{api.App, wh}
 }
 
 type webSocketHandler struct {
 	app         *app.App
-	handlerFunc func(*model.WebSocketRequest) (map[string]interface{}, *model.AppError)
+	handlerFunc func(*app.WebConn, *model.WebSocketRequest) (map[string]interface{}, *model.AppError)
 }
 
 func (wh webSocketHandler) ServeWebSocket(conn *app.WebConn, r *model.WebSocketRequest) {
@@ -51,7 +59,7 @@ func (wh webSocketHandler) ServeWebSocket(conn *app.WebConn, r *model.WebSocketR
 	var data map[string]interface{}
 	var err *model.AppError
 
-	if data, err = wh.handlerFunc(r); err != nil {
+	if data, err = wh.handlerFunc(conn, r); err != nil {
 		mlog.Error(
 			"websocket request handling error",
 			mlog.String("action", r.Action),